@@ -0,0 +1,64 @@
+// Command gather collects the operator's custom resources, managed objects, operator CRDs, pod
+// logs and events into the must-gather layout, with Secret data redacted, for debugging support
+// cases offline. The operator image ships it as /usr/bin/gather:
+//
+//	oc adm must-gather --image=<operator image> -- /usr/bin/gather --operator-namespace openshift-operators
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/controller"
+)
+
+func main() {
+	opts := controller.GatherOptions{}
+	flag.StringVar(&opts.Dest, "dest", "/must-gather", "Directory to write the must-gather layout to.")
+	flag.StringVar(&opts.OperatorNamespace, "operator-namespace", os.Getenv(controller.OperatorNamespaceEnv), "Namespace the operator runs in, whose pods, logs and events are gathered too.")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		fmt.Fprintln(os.Stderr, "gather:", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts controller.GatherOptions) error {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(smv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	opts.PodLogs = func(ctx context.Context, namespace, pod, container string, previous bool) (io.ReadCloser, error) {
+		return clientset.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{Container: container, Previous: previous}).Stream(ctx)
+	}
+
+	return controller.Gather(ctrl.SetupSignalHandler(), c, opts)
+}
@@ -1,22 +1,42 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
+	"time"
 
+	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
+	"github.com/openshift/ocp-secrets-management/operator/pkg/accessreview"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/apiauth"
 	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/audit"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/cloudcredentials"
 	"github.com/openshift/ocp-secrets-management/operator/pkg/controller"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/crdschema"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/diagnostics"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/logging"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/resourcemetrics"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/telemetry"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/tracing"
 )
 
 var (
@@ -36,44 +56,318 @@ func main() {
 	var probeAddr string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	var secureMetrics bool
+	flag.BoolVar(&secureMetrics, "metrics-secure", true,
+		"Serve metrics over HTTPS to callers allowed to get /metrics, such as those bound to the "+diagnostics.MetricsReaderClusterRole+" ClusterRole.")
+	var metricsCertDir string
+	flag.StringVar(&metricsCertDir, "metrics-cert-dir", "",
+		"Directory holding tls.crt and tls.key for the metrics endpoint. A self-signed certificate is generated when empty.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	var leaseDuration, renewDeadline, retryPeriod time.Duration
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 137*time.Second,
+		"How long standby replicas wait before taking over a lease the leader stopped renewing.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 107*time.Second,
+		"How long the leader keeps retrying to renew its lease before giving up leadership. Must be less than the lease duration.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 26*time.Second,
+		"How often replicas try to acquire or renew the lease.")
 	var developmentMode bool
-	flag.BoolVar(&developmentMode, "development", false, "Enable development mode logging.")
+	flag.BoolVar(&developmentMode, "development", false, "Enable development mode logging: console format at debug level, unless set otherwise.")
+	var reconcileInterval time.Duration
+	flag.DurationVar(&reconcileInterval, "reconcile-interval", controller.DefaultReconcileInterval,
+		"How often to re-reconcile to refresh operator detection. Jitter of up to 10% is added.")
+	var deletionRetryBudget time.Duration
+	flag.DurationVar(&deletionRetryBudget, "deletion-retry-budget", controller.DefaultDeletionRetryBudget,
+		"How long the cleanup of a deleted SecretsManagementConfig is retried before its finalizer is removed with the remaining resources left in place.")
+	var auditAddr, auditCertDir string
+	flag.StringVar(&auditAddr, "audit-bind-address", fmt.Sprintf(":%d", controller.AuditPort), "The address the audit endpoint binds to.")
+	flag.StringVar(&auditCertDir, "audit-cert-dir", "/var/run/secrets/audit-tls", "Directory holding tls.crt and tls.key for the audit endpoint.")
+	var accessReviewTTL time.Duration
+	flag.DurationVar(&accessReviewTTL, "access-review-cache-ttl", accessreview.DefaultTTL,
+		"How long the plugin API caches a user's token and the access review endpoint its decisions.")
+	var apiRateLimit float64
+	var apiRateBurst int
+	var apiMaxBodyBytes int64
+	flag.Float64Var(&apiRateLimit, "api-rate-limit", audit.DefaultRateLimit,
+		"Requests per second each console user may send to the operator's plugin API; 0 disables the limit.")
+	flag.IntVar(&apiRateBurst, "api-rate-burst", audit.DefaultRateBurst,
+		"Requests each console user may send at once to the operator's plugin API.")
+	flag.Int64Var(&apiMaxBodyBytes, "api-max-body-bytes", audit.DefaultMaxBodyBytes,
+		"Largest request body the operator's plugin API accepts; 0 disables the limit.")
+	var diagnosticsAddr, diagnosticsCertDir string
+	flag.StringVar(&diagnosticsAddr, "diagnostics-bind-address", "",
+		"The address the pprof and expvar endpoint binds to. Disabled when empty. Callers need a token allowed to get /debug/* non-resource URLs.")
+	flag.StringVar(&diagnosticsCertDir, "diagnostics-cert-dir", "",
+		"Directory holding tls.crt and tls.key for the diagnostics endpoint. Defaults to --audit-cert-dir.")
+	var updateEvents bool
+	flag.BoolVar(&updateEvents, "update-events", false, "Emit an Event on each managed object the operator updates, listing the fields that changed.")
+	var scan bool
+	flag.BoolVar(&scan, controller.ScanFlag, false, "Run the compliance scan once, write the SecretsComplianceReport and exit. Used by the scheduled scan CronJob.")
+	var backup bool
+	flag.BoolVar(&backup, controller.BackupFlag, false, "Export the secrets management configuration once to spec.backup.destination and exit. Used by the backup CronJob.")
+	var serveCLIDownloads bool
+	flag.BoolVar(&serveCLIDownloads, controller.ServeCLIDownloadsFlag, false, "Serve the smcctl binaries in the image instead of running the controllers. Used by the smcctl downloads Deployment.")
 
-	opts := zap.Options{
-		Development: developmentMode,
-	}
-	opts.BindFlags(flag.CommandLine)
+	logOpts := logging.DefaultOptions()
+	logOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	if developmentMode {
+		logOpts.Development = true
+		if !logSettingGiven("log-level", logging.LevelEnv) {
+			logOpts.Level = "debug"
+		}
+		if !logSettingGiven("log-format", logging.FormatEnv) {
+			logOpts.Format = logging.FormatConsole
+		}
+	}
+	logger, logLevel, err := logging.New(os.Stderr, logOpts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	ctrl.SetLogger(logger)
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
-		Metrics: metricsserver.Options{
-			BindAddress: metricsAddr,
-		},
+	// WATCH_NAMESPACE switches to restricted mode; it must name the plugin namespace
+	watchNamespace := os.Getenv(controller.WatchNamespaceEnv)
+	restricted := watchNamespace != ""
+	if restricted && watchNamespace != controller.PluginNamespace {
+		setupLog.Error(nil, "WATCH_NAMESPACE must be the plugin namespace", "watchNamespace", watchNamespace, "pluginNamespace", controller.PluginNamespace)
+		os.Exit(1)
+	}
+
+	if serveCLIDownloads {
+		os.Exit(runCLIDownloads())
+	}
+	if scan {
+		os.Exit(runScan(restricted))
+	}
+	if backup {
+		os.Exit(runBackup(restricted))
+	}
+
+	// Export spans of the reconcile loops and their API requests when an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	restConfig := ctrl.GetConfigOrDie()
+	if tracing.Enabled() {
+		setupLog.Info("Exporting traces to the configured OTLP endpoint")
+		restConfig.Wrap(tracing.WrapTransport)
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsOptions(metricsAddr, secureMetrics, metricsCertDir),
 		HealthProbeBindAddress: probeAddr,
+		Cache:                  controller.CacheOptions(restricted),
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "secrets-management.openshift.io",
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		RetryPeriod:            &retryPeriod,
+		// Hand the lease over on SIGTERM instead of letting it expire, for fast failover during
+		// drains; safe because the process exits as soon as the manager stops
+		LeaderElectionReleaseOnCancel: true,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	// Follow the logging ConfigMap in the operator namespace, so the level can change without a restart
+	if operatorNamespace := os.Getenv(controller.OperatorNamespaceEnv); operatorNamespace != "" {
+		clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+		if err != nil {
+			setupLog.Error(err, "unable to create clientset for the log level watcher")
+			os.Exit(1)
+		}
+		levelWatcher := logging.NewLevelWatcher(clientset, operatorNamespace, logLevel, ctrl.Log.WithName("logging"))
+		if err := mgr.Add(levelWatcher); err != nil {
+			setupLog.Error(err, "unable to set up log level watcher")
+			os.Exit(1)
+		}
+	}
+
+	auditForwarder := audit.NewForwarder(ctrl.Log.WithName("audit").WithName("forwarder"))
+	if err := mgr.Add(auditForwarder); err != nil {
+		setupLog.Error(err, "unable to set up audit forwarder")
+		os.Exit(1)
+	}
+
+	// Only the leader reconciles, so only its metrics carry the usage report
+	usageReporter := telemetry.NewReporter()
+	metrics.Registry.MustRegister(usageReporter)
+
+	// Likewise only the leader's metrics carry the per-resource metrics
+	resourceExporter := resourcemetrics.NewExporter()
+	metrics.Registry.MustRegister(resourceExporter)
+
+	// Minimal installs can leave out the console; the plugin would have nothing to load it
+	consoleEnabled, err := controller.ConsoleCapabilityEnabled(context.Background(), mgr.GetAPIReader())
+	if err != nil {
+		setupLog.Error(err, "unable to read the cluster capabilities")
+		os.Exit(1)
+	}
+	if !consoleEnabled {
+		setupLog.Info("Console capability is disabled, the console plugin will not be deployed")
+	}
+
 	if err = (&controller.SecretsManagementConfigReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("SecretsManagementConfig"),
-		Scheme: mgr.GetScheme(),
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controllers").WithName("SecretsManagementConfig"),
+		Scheme:                mgr.GetScheme(),
+		ReconcileInterval:     reconcileInterval,
+		Restricted:            restricted,
+		OperatorNamespace:     os.Getenv(controller.OperatorNamespaceEnv),
+		OperatorConditionName: os.Getenv(controller.OperatorConditionNameEnv),
+		APIReader:             mgr.GetAPIReader(),
+		AuditForwarder:        auditForwarder,
+		Telemetry:             usageReporter,
+		ResourceMetrics:       resourceExporter,
+		CredentialChecker:     &cloudcredentials.Checker{},
+		Recorder:              mgr.GetEventRecorderFor("secretsmanagementconfig-controller"),
+		UpdateEvents:          updateEvents,
+		ConsoleDisabled:       !consoleEnabled,
+		DeletionRetryBudget:   deletionRetryBudget,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SecretsManagementConfig")
 		os.Exit(1)
 	}
 
+	if err = (&controller.SecretsAccessRequestReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("controllers").WithName("SecretsAccessRequest"),
+		Scheme:    mgr.GetScheme(),
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SecretsAccessRequest")
+		os.Exit(1)
+	}
+
+	if err = (&controller.SecretsManagementTenantReconciler{
+		Client:     mgr.GetClient(),
+		Log:        ctrl.Log.WithName("controllers").WithName("SecretsManagementTenant"),
+		Scheme:     mgr.GetScheme(),
+		APIReader:  mgr.GetAPIReader(),
+		Restricted: restricted,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SecretsManagementTenant")
+		os.Exit(1)
+	}
+
+	if err = (&controller.SecretRotationPolicyReconciler{
+		Client:     mgr.GetClient(),
+		Log:        ctrl.Log.WithName("controllers").WithName("SecretRotationPolicy"),
+		APIReader:  mgr.GetAPIReader(),
+		Restricted: restricted,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SecretRotationPolicy")
+		os.Exit(1)
+	}
+
+	if err = (&controller.BindingExpiryReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("BindingExpiry"),
+		Recorder: mgr.GetEventRecorderFor("binding-expiry-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BindingExpiry")
+		os.Exit(1)
+	}
+
+	if err = (&controller.NotificationReconciler{
+		Client:     mgr.GetClient(),
+		Log:        ctrl.Log.WithName("controllers").WithName("Notifications"),
+		APIReader:  mgr.GetAPIReader(),
+		Restricted: restricted,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Notifications")
+		os.Exit(1)
+	}
+
+	if err = (&controller.ComplianceReconciler{
+		Client:     mgr.GetClient(),
+		Log:        ctrl.Log.WithName("controllers").WithName("Compliance"),
+		Scheme:     mgr.GetScheme(),
+		APIReader:  mgr.GetAPIReader(),
+		Restricted: restricted,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Compliance")
+		os.Exit(1)
+	}
+
+	if err = (&controller.AuditSinkConfigReconciler{
+		Client:            mgr.GetClient(),
+		Log:               ctrl.Log.WithName("controllers").WithName("AuditSinkConfig"),
+		Forwarder:         auditForwarder,
+		APIReader:         mgr.GetAPIReader(),
+		ReconcileInterval: reconcileInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AuditSinkConfig")
+		os.Exit(1)
+	}
+
+	if err = (&controller.WarningEventReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("controllers").WithName("WarningEvents"),
+		Forwarder: auditForwarder,
+		Since:     time.Now(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "WarningEvents")
+		os.Exit(1)
+	}
+
+	// The plugin API endpoints share one cache of reviewed tokens
+	tokens := &apiauth.TokenCache{Client: mgr.GetClient(), TTL: accessReviewTTL}
+	if err := mgr.Add(&audit.Server{
+		Client:       mgr.GetClient(),
+		Store:        &audit.ConfigMapStore{Client: mgr.GetClient(), Namespace: controller.PluginNamespace},
+		Log:          ctrl.Log.WithName("audit"),
+		Tokens:       tokens,
+		Forwarder:    auditForwarder,
+		ConfigName:   controller.SingletonConfigName,
+		Addr:         auditAddr,
+		CertDir:      auditCertDir,
+		RateLimit:    rate.Limit(apiRateLimit),
+		RateBurst:    apiRateBurst,
+		MaxBodyBytes: apiMaxBodyBytes,
+		Endpoints: map[string]http.Handler{
+			accessreview.ReviewsPath: &accessreview.Reviewer{
+				Client: mgr.GetClient(),
+				Log:    ctrl.Log.WithName("accessreview"),
+				Tokens: tokens,
+				TTL:    accessReviewTTL,
+			},
+			crdschema.SchemasPath: &crdschema.Handler{
+				Tokens:    tokens,
+				Log:       ctrl.Log.WithName("crdschema"),
+				APIReader: mgr.GetAPIReader(),
+			},
+		},
+	}); err != nil {
+		setupLog.Error(err, "unable to set up audit endpoint")
+		os.Exit(1)
+	}
+
+	if diagnosticsAddr != "" {
+		if diagnosticsCertDir == "" {
+			diagnosticsCertDir = auditCertDir
+		}
+		if err := mgr.Add(&diagnostics.Server{
+			Client:  mgr.GetClient(),
+			Log:     ctrl.Log.WithName("diagnostics"),
+			Addr:    diagnosticsAddr,
+			CertDir: diagnosticsCertDir,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up diagnostics endpoint")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -82,10 +376,150 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := addReadyzChecks(mgr, auditCertDir); err != nil {
+		setupLog.Error(err, "unable to set up ready checks")
+		os.Exit(1)
+	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	// Flush the spans still buffered before exiting
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// runScan evaluates the compliance controls once against the API server and writes the report,
+// returning the process exit code
+func runScan(restricted bool) int {
+	log := ctrl.Log.WithName("scan")
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		log.Error(err, "unable to create client")
+		return 1
+	}
+	if err := (&controller.ComplianceReconciler{
+		Client:     c,
+		Log:        log,
+		Scheme:     scheme,
+		Restricted: restricted,
+	}).Scan(ctrl.SetupSignalHandler()); err != nil {
+		log.Error(err, "compliance scan failed")
+		return 1
+	}
+	log.Info("compliance scan complete")
+	return 0
+}
+
+// runBackup exports the configuration once to the destination of the primary config, returning
+// the process exit code
+func runBackup(restricted bool) int {
+	log := ctrl.Log.WithName("backup")
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		log.Error(err, "unable to create client")
+		return 1
+	}
+	name, err := controller.Backup(ctrl.SetupSignalHandler(), c, controller.BackupOptions{Restricted: restricted})
+	if err != nil {
+		log.Error(err, "backup failed")
+		return 1
+	}
+	if name == "" {
+		log.Info("no backup schedule is set, nothing to do")
+		return 0
+	}
+	log.Info("backup complete", "bundle", name)
+	return 0
+}
+
+// runCLIDownloads serves the smcctl binaries over plain HTTP until SIGTERM, returning the process
+// exit code. The Route in front of it terminates TLS.
+func runCLIDownloads() int {
+	log := ctrl.Log.WithName("cli-downloads")
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir(controller.CLIDownloadsDir)))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", controller.CLIDownloadsPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	log.Info("serving smcctl binaries", "dir", controller.CLIDownloadsDir, "addr", server.Addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Error(err, "smcctl downloads server failed")
+		return 1
+	}
+	return 0
+}
+
+// addReadyzChecks adds the component checks to /readyz; /readyz/<name> returns why one fails.
+// They are left out of /healthz, since restarting the operator fixes none of them.
+func addReadyzChecks(mgr ctrl.Manager, auditCertDir string) error {
+	cfg := rest.CopyConfig(mgr.GetConfig())
+	cfg.Timeout = 2 * time.Second
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	checks := map[string]healthz.Checker{
+		"apiserver":  diagnostics.APIServerCheck(discoveryClient),
+		"cache-sync": diagnostics.CacheSyncCheck(mgr.GetCache()),
+	}
+	// Only checked where the audit certificate is mounted, not when running from a workstation
+	if _, err := os.Stat(filepath.Join(auditCertDir, "tls.crt")); err == nil {
+		checks["audit-cert"] = diagnostics.CertificateCheck(auditCertDir, time.Now)
+	}
+	// Only checked on clusters that had the console API when the operator started
+	consoleGroupVersion := "console.openshift.io/v1"
+	if _, err := discoveryClient.ServerResourcesForGroupVersion(consoleGroupVersion); err == nil {
+		checks["console-api"] = diagnostics.APIGroupCheck(discoveryClient, consoleGroupVersion)
+	}
+	for name, check := range checks {
+		if err := mgr.AddReadyzCheck(name, check); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// metricsOptions serves metrics on addr, over HTTPS and only to authorized callers when secure is set
+func metricsOptions(addr string, secure bool, certDir string) metricsserver.Options {
+	opts := metricsserver.Options{BindAddress: addr}
+	if secure {
+		opts.SecureServing = true
+		opts.CertDir = certDir
+		opts.FilterProvider = diagnostics.WithAuthenticationAndAuthorization
+	}
+	return opts
+}
+
+// logSettingGiven reports whether a logging setting was given by its flag or environment variable
+func logSettingGiven(flagName, envName string) bool {
+	if os.Getenv(envName) != "" {
+		return true
+	}
+	given := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == flagName {
+			given = true
+		}
+	})
+	return given
+}
@@ -0,0 +1,60 @@
+// Command render prints the resources the operator would create for a SecretsManagementConfig as
+// a YAML bundle, for GitOps tools such as Argo CD to apply instead of the operator.
+//
+//	render --config config.yaml > manifests.yaml
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/controller"
+)
+
+func main() {
+	var configPath string
+	var opts controller.RenderOptions
+	flag.StringVar(&configPath, "config", "-", "File holding the SecretsManagementConfig to render, or - for standard input.")
+	flag.BoolVar(&opts.Restricted, "restricted", false, "Render what the operator creates in restricted mode (WATCH_NAMESPACE set).")
+	flag.StringVar(&opts.OperatorNamespace, "operator-namespace", "", "Namespace the operator runs in, exempted by the protection policy.")
+	flag.Parse()
+
+	if err := run(configPath, opts, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "render:", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string, opts controller.RenderOptions, out io.Writer) error {
+	var data []byte
+	var err error
+	if configPath == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(configPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	config := &smv1alpha1.SecretsManagementConfig{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return fmt.Errorf("parsing %s: %w", configPath, err)
+	}
+	if config.Kind != "SecretsManagementConfig" {
+		return fmt.Errorf("%s holds a %q, not a SecretsManagementConfig", configPath, config.Kind)
+	}
+
+	manifests, err := controller.Render(context.Background(), config, opts)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(manifests)
+	return err
+}
@@ -0,0 +1,160 @@
+// Command smcctl reports on a SecretsManagementConfig from the terminal:
+//
+//	smcctl status [--name cluster]   phase, conditions and detected operators
+//	smcctl doctor [--name cluster]   checks for what stops the console from loading the plugin
+//	smcctl restore --file F | --backup NAME [--dry-run]
+//	                                 recreates the configuration from a backup bundle
+//
+// doctor exits non-zero when a check fails, restore when an object cannot be restored.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/controller"
+)
+
+const usage = `Usage: smcctl <command> [--name NAME]
+
+Commands:
+  status  Print the phase, conditions and detected operators of a SecretsManagementConfig
+  doctor  Check the plugin Deployment, Service, serving certificate, console registration and roles
+  restore Restore a backup bundle from --file, or from the bundle Secret --backup in the plugin namespace
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	name := flags.String("name", controller.SingletonConfigName, "Name of the SecretsManagementConfig.")
+	file := flags.String("file", "", "restore: path of the backup bundle, - for stdin.")
+	backup := flags.String("backup", "", "restore: name of the bundle Secret in the plugin namespace.")
+	dryRun := flags.Bool("dry-run", false, "restore: report what would change without writing.")
+	_ = flags.Parse(os.Args[2:])
+
+	ok, err := run(command, *name, restoreSource{file: *file, backup: *backup, dryRun: *dryRun})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "smcctl:", err)
+		os.Exit(1)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// restoreSource is where restore reads the bundle from
+type restoreSource struct {
+	file   string
+	backup string
+	dryRun bool
+}
+
+// run executes command and reports whether it passed
+func run(command, name string, source restoreSource) (bool, error) {
+	if command != "status" && command != "doctor" && command != "restore" {
+		fmt.Fprint(os.Stderr, usage)
+		return false, fmt.Errorf("unknown command %q", command)
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(smv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return false, err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return false, err
+	}
+	ctx := ctrl.SetupSignalHandler()
+
+	if command == "status" {
+		config := &smv1alpha1.SecretsManagementConfig{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name}, config); err != nil {
+			return false, err
+		}
+		return true, controller.PrintStatus(os.Stdout, config, time.Now())
+	}
+
+	if command == "restore" {
+		return restore(ctx, c, source)
+	}
+
+	checks, err := controller.Doctor(ctx, c, name, time.Now())
+	if err != nil {
+		return false, err
+	}
+	if err := controller.PrintDoctor(os.Stdout, checks); err != nil {
+		return false, err
+	}
+	for _, check := range checks {
+		if check.Result == controller.DoctorFail {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// restore applies the bundle of source and reports whether every object was restored
+func restore(ctx context.Context, c client.Client, source restoreSource) (bool, error) {
+	var bundle *controller.BackupBundle
+	switch {
+	case source.file != "" && source.backup != "":
+		return false, fmt.Errorf("--file and --backup are mutually exclusive")
+	case source.file == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return false, err
+		}
+		if bundle, err = controller.ReadBackupBundle(data); err != nil {
+			return false, err
+		}
+	case source.file != "":
+		data, err := os.ReadFile(source.file)
+		if err != nil {
+			return false, err
+		}
+		if bundle, err = controller.ReadBackupBundle(data); err != nil {
+			return false, err
+		}
+	case source.backup != "":
+		var err error
+		if bundle, err = controller.ReadBackupSecret(ctx, c, source.backup); err != nil {
+			return false, err
+		}
+	default:
+		return false, fmt.Errorf("restore needs --file or --backup")
+	}
+
+	fmt.Fprintf(os.Stdout, "Restoring %d objects exported at %s\n", len(bundle.Items), bundle.CreatedAt.UTC().Format(time.RFC3339))
+	results, err := controller.Restore(ctx, c, bundle, controller.RestoreOptions{DryRun: source.dryRun})
+	if printErr := controller.PrintRestore(os.Stdout, results); printErr != nil {
+		return false, printErr
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "smcctl:", err)
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,29 @@
+#!/usr/bin/env bash
+# Regenerates the SecretsManagementConfig clientset, apply configurations, listers and informers in
+# pkg/generated from the +genclient markers in pkg/apis.
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT=$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)
+CODEGEN_VERSION=${CODEGEN_VERSION:-v0.29.0}
+CODEGEN_PKG=${CODEGEN_PKG:-$(go env GOMODCACHE)/k8s.io/code-generator@${CODEGEN_VERSION}}
+MODULE=github.com/openshift/ocp-secrets-management/operator
+
+if [[ ! -d "${CODEGEN_PKG}" ]]; then
+  go mod download "k8s.io/code-generator@${CODEGEN_VERSION}"
+fi
+source "${CODEGEN_PKG}/kube_codegen.sh"
+
+OUTPUT_BASE=$(mktemp -d)
+trap 'rm -rf "${OUTPUT_BASE}"' EXIT
+mkdir -p "${OUTPUT_BASE}/$(dirname "${MODULE}")"
+ln -s "${SCRIPT_ROOT}" "${OUTPUT_BASE}/${MODULE}"
+
+kube::codegen::gen_client \
+  --with-watch \
+  --with-applyconfig \
+  --input-pkg-root "${MODULE}/pkg/apis" \
+  --output-pkg-root "${MODULE}/pkg/generated" \
+  --output-base "${OUTPUT_BASE}" \
+  --boilerplate "${SCRIPT_ROOT}/hack/boilerplate.go.txt"
@@ -0,0 +1,20 @@
+package accessreview
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// cacheLookupsTotal counts cache lookups by kind and result (hit or miss). Tokens are cached by
+// apiauth.TokenCache, so the kind is always decision.
+var cacheLookupsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "secrets_management_access_review_cache_lookups_total",
+		Help: "Number of access review cache lookups for the console plugin, by kind and result",
+	},
+	[]string{"kind", "result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(cacheLookupsTotal)
+}
@@ -0,0 +1,203 @@
+// Package accessreview serves the console plugin's access checks. The plugin asks whether the
+// current user may perform an action on each row it renders; answering those from a short-lived
+// per-user cache spares the API server the identical SelfSubjectAccessReviews the console would
+// otherwise send for every row of a large list.
+package accessreview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/apiauth"
+)
+
+const (
+	// ReviewsPath is the endpoint the plugin reaches through the console proxy. POST a batch of
+	// resource attributes to learn whether the calling user may perform each of them.
+	ReviewsPath = "/api/v1/accessreviews"
+
+	// DefaultTTL is how long access decisions are cached
+	DefaultTTL = 10 * time.Second
+
+	// maxReviews bounds the number of reviews in one request
+	maxReviews = 200
+
+	// maxRequestBytes bounds the size of a request
+	maxRequestBytes = 64 * 1024
+
+	// maxEntries bounds the number of cached decisions. Expired entries are dropped once it is
+	// reached, and the cache is emptied if that is not enough.
+	maxEntries = 10000
+)
+
+// Request is the body posted to ReviewsPath
+type Request struct {
+	Reviews []authorizationv1.ResourceAttributes `json:"reviews"`
+}
+
+// Response answers a Request, with one result per review in the same order
+type Response struct {
+	Results []Result `json:"results"`
+}
+
+// Result is the decision for one review
+type Result struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// Reviewer answers access checks for the user owning the request's bearer token, which the
+// console proxy forwards. It authenticates the token through Tokens and checks each action with a
+// SubjectAccessReview, caching the decisions for TTL.
+type Reviewer struct {
+	// Client creates SubjectAccessReviews
+	Client client.Client
+	Log    logr.Logger
+
+	// Tokens authenticates the callers, sharing its cache with the operator's other endpoints
+	Tokens *apiauth.TokenCache
+
+	// TTL is how long decisions are cached; DefaultTTL when zero
+	TTL time.Duration
+
+	// now returns the current time; time.Now when nil
+	now func() time.Time
+
+	mu        sync.Mutex
+	decisions map[decisionKey]cachedDecision
+}
+
+// decisionKey identifies a decision by user and attributes. Groups are part of the identity a
+// SubjectAccessReview is evaluated for, so they are part of the key.
+type decisionKey struct {
+	user       string
+	groups     string
+	attributes authorizationv1.ResourceAttributes
+}
+
+type cachedDecision struct {
+	result  Result
+	expires time.Time
+}
+
+// ServeHTTP answers a batch of access reviews
+func (r *Reviewer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRequestBytes)).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(body.Reviews) > maxReviews {
+		http.Error(w, fmt.Sprintf("at most %d reviews may be requested at once", maxReviews), http.StatusBadRequest)
+		return
+	}
+	for i, attributes := range body.Reviews {
+		if attributes.Verb == "" || attributes.Resource == "" {
+			http.Error(w, fmt.Sprintf("reviews[%d]: verb and resource are required", i), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx := req.Context()
+	user, err := r.Tokens.Authenticate(ctx, req)
+	if err != nil {
+		r.Log.Error(err, "Failed to authenticate access review request")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if user == nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	response := Response{Results: make([]Result, 0, len(body.Reviews))}
+	for _, attributes := range body.Reviews {
+		result, err := r.review(ctx, user, attributes)
+		if err != nil {
+			r.Log.Error(err, "Failed to review access", "user", user.Username)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// review returns whether user may perform the action described by attributes
+func (r *Reviewer) review(ctx context.Context, user *authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (Result, error) {
+	groups := append([]string(nil), user.Groups...)
+	sort.Strings(groups)
+	key := decisionKey{user: user.Username, groups: strings.Join(groups, "\n"), attributes: attributes}
+
+	r.mu.Lock()
+	cached, found := r.decisions[key]
+	r.mu.Unlock()
+	if found && r.clock().Before(cached.expires) {
+		cacheLookupsTotal.WithLabelValues("decision", "hit").Inc()
+		return cached.result, nil
+	}
+	cacheLookupsTotal.WithLabelValues("decision", "miss").Inc()
+
+	status, err := apiauth.Authorize(ctx, r.Client, user, attributes.DeepCopy(), nil)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{Allowed: status.Allowed, Reason: status.Reason}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.decisions == nil || len(r.decisions) >= maxEntries {
+		r.decisions = r.pruneDecisions()
+	}
+	r.decisions[key] = cachedDecision{result: result, expires: r.clock().Add(r.ttl())}
+	return result, nil
+}
+
+// pruneDecisions returns the unexpired cached decisions, or an empty cache if they are still too
+// many. r.mu must be held.
+func (r *Reviewer) pruneDecisions() map[decisionKey]cachedDecision {
+	now := r.clock()
+	decisions := make(map[decisionKey]cachedDecision, len(r.decisions))
+	for key, cached := range r.decisions {
+		if now.Before(cached.expires) {
+			decisions[key] = cached
+		}
+	}
+	if len(decisions) >= maxEntries {
+		return map[decisionKey]cachedDecision{}
+	}
+	return decisions
+}
+
+func (r *Reviewer) ttl() time.Duration {
+	if r.TTL > 0 {
+		return r.TTL
+	}
+	return DefaultTTL
+}
+
+func (r *Reviewer) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,138 @@
+package accessreview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/apiauth"
+)
+
+// reviewCounts counts the reviews sent to the API server
+type reviewCounts struct {
+	tokens, subjects int
+}
+
+// newTestReviewer returns a reviewer whose TokenReviews accept "<user>-token" and whose
+// SubjectAccessReviews only allow the user "admin" to delete
+func newTestReviewer(t *testing.T, now *time.Time) (*Reviewer, *reviewCounts) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	counts := &reviewCounts{}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				switch review := obj.(type) {
+				case *authenticationv1.TokenReview:
+					counts.tokens++
+					if user, ok := strings.CutSuffix(review.Spec.Token, "-token"); ok {
+						review.Status.Authenticated = true
+						review.Status.User.Username = user
+					}
+					return nil
+				case *authorizationv1.SubjectAccessReview:
+					counts.subjects++
+					attributes := review.Spec.ResourceAttributes
+					review.Status.Allowed = attributes.Verb != "delete" || review.Spec.User == "admin"
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	return &Reviewer{
+		Client: c,
+		Log:    logr.Discard(),
+		Tokens: &apiauth.TokenCache{Client: c, TTL: time.Hour},
+		TTL:    time.Minute,
+		now:    func() time.Time { return *now },
+	}, counts
+}
+
+func doReviews(r *Reviewer, method, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, ReviewsPath, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestReviewer_CachesDecisions(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r, counts := newTestReviewer(t, &now)
+	body := `{"reviews":[` +
+		`{"group":"external-secrets.io","resource":"externalsecrets","verb":"delete","namespace":"team-a"},` +
+		`{"group":"external-secrets.io","resource":"externalsecrets","verb":"list","namespace":"team-a"},` +
+		`{"group":"external-secrets.io","resource":"externalsecrets","verb":"delete","namespace":"team-a"}]}`
+
+	rec := doReviews(r, http.MethodPost, "alice-token", body)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, []Result{{Allowed: false}, {Allowed: true}, {Allowed: false}}, response.Results)
+	assert.Equal(t, reviewCounts{tokens: 1, subjects: 2}, *counts, "identical reviews are sent once")
+
+	// Repeated reviews are answered from the cache until the TTL passes
+	rec = doReviews(r, http.MethodPost, "alice-token", body)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, reviewCounts{tokens: 1, subjects: 2}, *counts)
+
+	// Another user is reviewed separately
+	rec = doReviews(r, http.MethodPost, "admin-token", body)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.True(t, response.Results[0].Allowed)
+	assert.Equal(t, reviewCounts{tokens: 2, subjects: 4}, *counts)
+
+	// Decisions expire after the TTL; the token stays cached for the token cache's own TTL
+	now = now.Add(2 * time.Minute)
+	rec = doReviews(r, http.MethodPost, "alice-token", body)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, reviewCounts{tokens: 2, subjects: 6}, *counts)
+}
+
+func TestReviewer_UsesAuthenticatedUser(t *testing.T) {
+	now := time.Now()
+	r, counts := newTestReviewer(t, &now)
+
+	req := httptest.NewRequest(http.MethodPost, ReviewsPath, strings.NewReader(`{"reviews":[{"resource":"secrets","verb":"get"}]}`))
+	req = req.WithContext(apiauth.WithUser(req.Context(), &authenticationv1.UserInfo{Username: "admin"}))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, reviewCounts{subjects: 1}, *counts, "the user authenticated by the server is not reviewed again")
+}
+
+func TestReviewer_RejectsInvalidRequests(t *testing.T) {
+	now := time.Now()
+	r, counts := newTestReviewer(t, &now)
+	valid := `{"reviews":[{"resource":"secrets","verb":"get"}]}`
+
+	assert.Equal(t, http.StatusUnauthorized, doReviews(r, http.MethodPost, "", valid).Code)
+	assert.Equal(t, http.StatusUnauthorized, doReviews(r, http.MethodPost, "bogus", valid).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, doReviews(r, http.MethodGet, "alice-token", "").Code)
+	assert.Equal(t, http.StatusBadRequest, doReviews(r, http.MethodPost, "alice-token", `{"reviews":[{"verb":"get"}]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, doReviews(r, http.MethodPost, "alice-token", "not json").Code)
+	assert.Zero(t, counts.subjects)
+}
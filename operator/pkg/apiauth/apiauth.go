@@ -0,0 +1,90 @@
+// Package apiauth authenticates and authorizes the callers of the operator's HTTPS endpoints.
+// Callers send a bearer token, which the console proxy forwards for the logged in user; a
+// TokenReview tells whose it is and SubjectAccessReviews what that user may do.
+package apiauth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// userKey is the context key of the user authenticated for a request
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying user, so handlers further down the chain get it from
+// Authenticate without another TokenReview
+func WithUser(ctx context.Context, user *authenticationv1.UserInfo) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom returns the user stored in ctx by WithUser, or nil
+func UserFrom(ctx context.Context) *authenticationv1.UserInfo {
+	user, _ := ctx.Value(userKey{}).(*authenticationv1.UserInfo)
+	return user
+}
+
+// BearerToken returns the request's bearer token, or "" when it sends none
+func BearerToken(req *http.Request) string {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return token
+}
+
+// Authenticate returns the user owning the request's bearer token, or nil when it is missing or
+// invalid. A user an outer handler already authenticated is returned as is.
+func Authenticate(ctx context.Context, c client.Client, req *http.Request) (*authenticationv1.UserInfo, error) {
+	if user := UserFrom(ctx); user != nil {
+		return user, nil
+	}
+	token := BearerToken(req)
+	if token == "" {
+		return nil, nil
+	}
+	return ReviewToken(ctx, c, token)
+}
+
+// ReviewToken returns the user owning token, or nil when a TokenReview does not accept it
+func ReviewToken(ctx context.Context, c client.Client, token string) (*authenticationv1.UserInfo, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	if err := c.Create(ctx, review); err != nil {
+		return nil, err
+	}
+	if !review.Status.Authenticated {
+		return nil, nil
+	}
+	return &review.Status.User, nil
+}
+
+// Authorize returns the outcome of a SubjectAccessReview of the action described by either
+// resource or nonResource, for user
+func Authorize(ctx context.Context, c client.Client, user *authenticationv1.UserInfo,
+	resource *authorizationv1.ResourceAttributes, nonResource *authorizationv1.NonResourceAttributes) (authorizationv1.SubjectAccessReviewStatus, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:                  user.Username,
+			UID:                   user.UID,
+			Groups:                user.Groups,
+			Extra:                 extra,
+			ResourceAttributes:    resource,
+			NonResourceAttributes: nonResource,
+		},
+	}
+	if err := c.Create(ctx, review); err != nil {
+		return authorizationv1.SubjectAccessReviewStatus{}, err
+	}
+	return review.Status, nil
+}
@@ -0,0 +1,107 @@
+package apiauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newTestClient returns a client whose TokenReviews accept "<user>-token" and whose
+// SubjectAccessReviews only allow the group "admins". Each review is appended to reviews.
+func newTestClient(reviews *[]client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				*reviews = append(*reviews, obj)
+				switch review := obj.(type) {
+				case *authenticationv1.TokenReview:
+					if user, ok := strings.CutSuffix(review.Spec.Token, "-token"); ok {
+						review.Status.Authenticated = true
+						review.Status.User.Username = user
+					}
+					return nil
+				case *authorizationv1.SubjectAccessReview:
+					for _, group := range review.Spec.Groups {
+						review.Status.Allowed = review.Status.Allowed || group == "admins"
+					}
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+}
+
+func TestAuthenticate(t *testing.T) {
+	var reviews []client.Object
+	c := newTestClient(&reviews)
+	request := func(authorization string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return req
+	}
+
+	for _, authorization := range []string{"", "Basic YWxpY2U6c2VjcmV0", "Bearer ", "Bearer garbage"} {
+		user, err := Authenticate(context.Background(), c, request(authorization))
+		require.NoError(t, err)
+		assert.Nil(t, user, authorization)
+	}
+	assert.Len(t, reviews, 1, "only the bearer token is reviewed")
+
+	user, err := Authenticate(context.Background(), c, request("Bearer alice-token"))
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "alice", user.Username)
+
+	// A user authenticated further up the chain is not reviewed again
+	reviews = nil
+	ctx := WithUser(context.Background(), &authenticationv1.UserInfo{Username: "bob"})
+	user, err = Authenticate(ctx, c, request("Bearer alice-token"))
+	require.NoError(t, err)
+	assert.Equal(t, "bob", user.Username)
+	assert.Empty(t, reviews)
+}
+
+func TestAuthorize(t *testing.T) {
+	var reviews []client.Object
+	c := newTestClient(&reviews)
+	user := &authenticationv1.UserInfo{
+		Username: "alice",
+		UID:      "1234",
+		Groups:   []string{"admins"},
+		Extra:    map[string]authenticationv1.ExtraValue{"scopes.authorization.openshift.io": {"user:full"}},
+	}
+
+	status, err := Authorize(context.Background(), c, user, nil, &authorizationv1.NonResourceAttributes{Path: "/metrics", Verb: "get"})
+	require.NoError(t, err)
+	assert.True(t, status.Allowed)
+	require.Len(t, reviews, 1)
+	spec := reviews[0].(*authorizationv1.SubjectAccessReview).Spec
+	assert.Equal(t, "alice", spec.User)
+	assert.Equal(t, "1234", spec.UID)
+	assert.Equal(t, authorizationv1.ExtraValue{"user:full"}, spec.Extra["scopes.authorization.openshift.io"])
+	assert.Equal(t, "/metrics", spec.NonResourceAttributes.Path)
+	assert.Nil(t, spec.ResourceAttributes)
+
+	status, err = Authorize(context.Background(), c, &authenticationv1.UserInfo{Username: "bob"},
+		&authorizationv1.ResourceAttributes{Verb: "get", Resource: "secrets"}, nil)
+	require.NoError(t, err)
+	assert.False(t, status.Allowed)
+}
@@ -0,0 +1,19 @@
+package apiauth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// tokenCacheLookupsTotal counts token cache lookups by result (hit or miss)
+var tokenCacheLookupsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "secrets_management_api_token_cache_lookups_total",
+		Help: "Number of token cache lookups authenticating callers of the plugin API, by result",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(tokenCacheLookupsTotal)
+}
@@ -0,0 +1,111 @@
+package apiauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultTokenTTL is how long the user of an accepted token is cached
+	DefaultTokenTTL = 10 * time.Second
+
+	// maxTokens bounds the number of cached tokens. Expired ones are dropped once it is reached,
+	// and the cache is emptied if that is not enough.
+	maxTokens = 10000
+)
+
+// TokenCache authenticates requests like Authenticate, caching the user of each accepted token,
+// by hash, for TTL. The operator's endpoints share one, so a console user's token is reviewed once
+// per TTL rather than on every request. Rejected tokens are not cached.
+type TokenCache struct {
+	// Client creates TokenReviews
+	Client client.Client
+
+	// TTL is how long users are cached; DefaultTokenTTL when zero
+	TTL time.Duration
+
+	// now returns the current time; time.Now when nil
+	now func() time.Time
+
+	mu    sync.Mutex
+	users map[string]cachedUser
+}
+
+type cachedUser struct {
+	user    authenticationv1.UserInfo
+	expires time.Time
+}
+
+// Authenticate returns the user owning the request's bearer token, or nil when it is missing or
+// invalid. A user an outer handler already authenticated is returned as is.
+func (c *TokenCache) Authenticate(ctx context.Context, req *http.Request) (*authenticationv1.UserInfo, error) {
+	if user := UserFrom(ctx); user != nil {
+		return user, nil
+	}
+	token := BearerToken(req)
+	if token == "" {
+		return nil, nil
+	}
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+
+	c.mu.Lock()
+	cached, found := c.users[key]
+	c.mu.Unlock()
+	if found && c.clock().Before(cached.expires) {
+		tokenCacheLookupsTotal.WithLabelValues("hit").Inc()
+		user := cached.user
+		return &user, nil
+	}
+	tokenCacheLookupsTotal.WithLabelValues("miss").Inc()
+
+	user, err := ReviewToken(ctx, c.Client, token)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.users == nil || len(c.users) >= maxTokens {
+		c.users = c.prune()
+	}
+	c.users[key] = cachedUser{user: *user, expires: c.clock().Add(c.ttl())}
+	return user, nil
+}
+
+// prune returns the unexpired cached users, or an empty cache if they are still too many. c.mu
+// must be held.
+func (c *TokenCache) prune() map[string]cachedUser {
+	now := c.clock()
+	users := make(map[string]cachedUser, len(c.users))
+	for key, cached := range c.users {
+		if now.Before(cached.expires) {
+			users[key] = cached
+		}
+	}
+	if len(users) >= maxTokens {
+		return map[string]cachedUser{}
+	}
+	return users
+}
+
+func (c *TokenCache) ttl() time.Duration {
+	if c.TTL > 0 {
+		return c.TTL
+	}
+	return DefaultTokenTTL
+}
+
+func (c *TokenCache) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,44 @@
+package apiauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestTokenCache(t *testing.T) {
+	var reviews []client.Object
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := &TokenCache{Client: newTestClient(&reviews), TTL: time.Minute, now: func() time.Time { return now }}
+	authenticate := func(ctx context.Context, token string) *authenticationv1.UserInfo {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		user, err := cache.Authenticate(ctx, req)
+		require.NoError(t, err)
+		return user
+	}
+
+	require.Equal(t, "alice", authenticate(context.Background(), "alice-token").Username)
+	require.Equal(t, "alice", authenticate(context.Background(), "alice-token").Username)
+	assert.Len(t, reviews, 1, "accepted tokens are cached")
+
+	assert.Nil(t, authenticate(context.Background(), "garbage"))
+	assert.Nil(t, authenticate(context.Background(), "garbage"))
+	assert.Len(t, reviews, 3, "rejected tokens are not")
+
+	// A user authenticated further up the chain is not looked up
+	ctx := WithUser(context.Background(), &authenticationv1.UserInfo{Username: "bob"})
+	assert.Equal(t, "bob", authenticate(ctx, "alice-token").Username)
+	assert.Len(t, reviews, 3)
+
+	now = now.Add(2 * time.Minute)
+	require.Equal(t, "alice", authenticate(context.Background(), "alice-token").Username)
+	assert.Len(t, reviews, 4, "the token is reviewed again after the TTL")
+}
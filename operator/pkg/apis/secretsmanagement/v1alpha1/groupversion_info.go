@@ -17,8 +17,20 @@ var (
 
 	// AddToScheme adds the types in this group-version to the given scheme
 	AddToScheme = SchemeBuilder.AddToScheme
+
+	// SchemeGroupVersion is GroupVersion under the name the generated clients in pkg/generated use
+	SchemeGroupVersion = GroupVersion
 )
 
+// Resource takes an unqualified resource and returns a group qualified GroupResource
+func Resource(resource string) schema.GroupResource {
+	return GroupVersion.WithResource(resource).GroupResource()
+}
+
 func init() {
 	SchemeBuilder.Register(&SecretsManagementConfig{}, &SecretsManagementConfigList{})
+	SchemeBuilder.Register(&SecretsAccessRequest{}, &SecretsAccessRequestList{})
+	SchemeBuilder.Register(&SecretsComplianceReport{}, &SecretsComplianceReportList{})
+	SchemeBuilder.Register(&SecretRotationPolicy{}, &SecretRotationPolicyList{})
+	SchemeBuilder.Register(&SecretsManagementTenant{}, &SecretsManagementTenantList{})
 }
@@ -0,0 +1,111 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RotationAction is what the operator does with a Secret older than the policy's maxAge
+// +kubebuilder:validation:Enum=Annotate;RefreshExternalSecret;RenewCertificate
+type RotationAction string
+
+const (
+	// RotationActionAnnotate marks overdue Secrets with the
+	// secrets-management.openshift.io/rotation-due annotation for their owners to act on
+	RotationActionAnnotate RotationAction = "Annotate"
+
+	// RotationActionRefreshExternalSecret forces the ExternalSecret owning an overdue Secret to
+	// refresh it from the external store
+	RotationActionRefreshExternalSecret RotationAction = "RefreshExternalSecret"
+
+	// RotationActionRenewCertificate asks cert-manager to renew the Certificate issuing an overdue Secret
+	RotationActionRenewCertificate RotationAction = "RenewCertificate"
+)
+
+// SecretRotationPolicySpec selects Secrets and the age past which they are rotated
+type SecretRotationPolicySpec struct {
+	// NamespaceSelector selects the namespaces the policy covers. Every namespace outside the
+	// openshift-* and kube-* platform namespaces is covered when empty.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Selector selects the Secrets the policy covers by label; every Secret when empty
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// MaxAge is how long a Secret may go without rotation
+	// +kubebuilder:validation:Format=duration
+	MaxAge metav1.Duration `json:"maxAge"`
+
+	// Action is what the operator does with Secrets older than MaxAge
+	Action RotationAction `json:"action"`
+}
+
+// NamespaceRotationStatus reports rotation compliance in one namespace
+type NamespaceRotationStatus struct {
+	// Namespace the counts are for
+	Namespace string `json:"namespace"`
+
+	// Secrets is the number of Secrets the policy covers in the namespace
+	Secrets int32 `json:"secrets"`
+
+	// Compliant is the number of those Secrets rotated within MaxAge
+	Compliant int32 `json:"compliant"`
+
+	// Overdue names the Secrets older than MaxAge, at most 10
+	// +kubebuilder:validation:MaxItems=10
+	Overdue []string `json:"overdue,omitempty"`
+}
+
+// SecretRotationPolicyStatus defines the observed state of SecretRotationPolicy
+type SecretRotationPolicyStatus struct {
+	// ObservedGeneration is the generation the status was computed for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastEvaluated is when the covered Secrets were last checked
+	LastEvaluated *metav1.Time `json:"lastEvaluated,omitempty"`
+
+	// Secrets is the number of Secrets the policy covers
+	Secrets int32 `json:"secrets"`
+
+	// Compliant is the number of covered Secrets rotated within MaxAge
+	Compliant int32 `json:"compliant"`
+
+	// RotationsTriggered is the number of rotations started by the last evaluation
+	RotationsTriggered int32 `json:"rotationsTriggered"`
+
+	// Namespaces reports compliance in each covered namespace with Secrets, at most 100
+	// +kubebuilder:validation:MaxItems=100
+	Namespaces []NamespaceRotationStatus `json:"namespaces,omitempty"`
+
+	// Message explains why the policy cannot be applied
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=srp
+// +kubebuilder:printcolumn:name="Max Age",type=string,JSONPath=`.spec.maxAge`
+// +kubebuilder:printcolumn:name="Action",type=string,JSONPath=`.spec.action`
+// +kubebuilder:printcolumn:name="Secrets",type=integer,JSONPath=`.status.secrets`
+// +kubebuilder:printcolumn:name="Compliant",type=integer,JSONPath=`.status.compliant`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// SecretRotationPolicy keeps the Secrets it selects younger than a maximum age. Overdue Secrets
+// are annotated, refreshed through their ExternalSecret or renewed through their cert-manager
+// Certificate, and compliance is reported per namespace.
+type SecretRotationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretRotationPolicySpec   `json:"spec,omitempty"`
+	Status SecretRotationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretRotationPolicyList contains a list of SecretRotationPolicy
+type SecretRotationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretRotationPolicy `json:"items"`
+}
@@ -0,0 +1,145 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessLevel is the level of access granted by a SecretsAccessRequest; it selects the
+// view, delete or admin ClusterRole created by the operator
+// +kubebuilder:validation:Enum=view;delete;admin
+type AccessLevel string
+
+const (
+	// AccessLevelView grants read access to secrets-management resources
+	AccessLevelView AccessLevel = "view"
+
+	// AccessLevelDelete grants delete access to secrets-management resources
+	AccessLevelDelete AccessLevel = "delete"
+
+	// AccessLevelAdmin grants full access to secrets-management resources
+	AccessLevelAdmin AccessLevel = "admin"
+)
+
+// SecretsAccessRequestSpec defines the access being requested. Only the approval can change once
+// the request is created, so an approved request cannot be widened afterwards.
+// +kubebuilder:validation:XValidation:rule="self.user == oldSelf.user && self.accessLevel == oldSelf.accessLevel && self.duration == oldSelf.duration && has(self.namespace) == has(oldSelf.namespace) && (!has(self.namespace) || self.namespace == oldSelf.namespace)",message="only approved and approvedBy can be changed once a request is created"
+type SecretsAccessRequestSpec struct {
+	// User is the name of the user the access is granted to. Users can only request access for
+	// themselves, unless they may approve requests.
+	// +kubebuilder:validation:MinLength=1
+	User string `json:"user"`
+
+	// AccessLevel is the requested level of access
+	AccessLevel AccessLevel `json:"accessLevel"`
+
+	// Namespace limits the access to one namespace. Access is cluster-wide when empty, which
+	// spec.rbac.allowClusterWideAccessRequests of the SecretsManagementConfig must allow.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Duration is how long the access lasts once approved, at most 24h
+	// +kubebuilder:validation:Format=duration
+	Duration metav1.Duration `json:"duration"`
+
+	// Justification explains why the access is needed
+	// +kubebuilder:validation:MinLength=1
+	Justification string `json:"justification"`
+
+	// Approved is set by an approver, together with approvedBy, to grant the access. Clearing it
+	// revokes active access. Only users with the approve verb on secretsaccessrequests can change
+	// it, and not on requests for themselves.
+	// +optional
+	Approved bool `json:"approved,omitempty"`
+
+	// ApprovedBy is the username of the approver, set together with approved. The admission policy
+	// checks it names the user making the change.
+	// +optional
+	ApprovedBy string `json:"approvedBy,omitempty"`
+}
+
+// AccessRequestPhase represents the phase of a SecretsAccessRequest
+// +kubebuilder:validation:Enum=Pending;Active;Expired;Revoked;Rejected
+type AccessRequestPhase string
+
+const (
+	// AccessRequestPending means the request waits for approval
+	AccessRequestPending AccessRequestPhase = "Pending"
+
+	// AccessRequestActive means the access is granted until status.expiresAt
+	AccessRequestActive AccessRequestPhase = "Active"
+
+	// AccessRequestExpired means the access was granted and has expired
+	AccessRequestExpired AccessRequestPhase = "Expired"
+
+	// AccessRequestRevoked means the approval was withdrawn before the access expired
+	AccessRequestRevoked AccessRequestPhase = "Revoked"
+
+	// AccessRequestRejected means the request is invalid and will not be granted
+	AccessRequestRejected AccessRequestPhase = "Rejected"
+)
+
+// AccessRequestAuditEntry records one step of a request's lifecycle
+type AccessRequestAuditEntry struct {
+	// Time the step happened
+	Time metav1.Time `json:"time"`
+
+	// Phase entered by the step
+	Phase AccessRequestPhase `json:"phase"`
+
+	// Message describes the step
+	Message string `json:"message,omitempty"`
+}
+
+// SecretsAccessRequestStatus defines the observed state of SecretsAccessRequest
+type SecretsAccessRequestStatus struct {
+	// Phase is the current phase of the request
+	Phase AccessRequestPhase `json:"phase,omitempty"`
+
+	// ApprovedAt is when the controller first observed the approval
+	ApprovedAt *metav1.Time `json:"approvedAt,omitempty"`
+
+	// ApprovedBy is the approver who granted the access
+	ApprovedBy string `json:"approvedBy,omitempty"`
+
+	// ExpiresAt is when the granted access is revoked
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// BindingName is the name of the RoleBinding or ClusterRoleBinding granting the access
+	BindingName string `json:"bindingName,omitempty"`
+
+	// AuditTrail lists the lifecycle steps of the request, oldest first
+	// +kubebuilder:validation:MaxItems=20
+	AuditTrail []AccessRequestAuditEntry `json:"auditTrail,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=sar
+// +kubebuilder:printcolumn:name="User",type=string,JSONPath=`.spec.user`
+// +kubebuilder:printcolumn:name="Access",type=string,JSONPath=`.spec.accessLevel`
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.namespace`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.status.expiresAt`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// SecretsAccessRequest requests temporary access to secrets-management resources. Once an
+// approver sets spec.approved, the operator binds the user to the matching ClusterRole and
+// removes the binding when the access expires. The approval is enforced by the
+// secrets-management-access-request-approval ValidatingAdmissionPolicy, and nothing is granted
+// while it is not in place.
+type SecretsAccessRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretsAccessRequestSpec   `json:"spec,omitempty"`
+	Status SecretsAccessRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretsAccessRequestList contains a list of SecretsAccessRequest
+type SecretsAccessRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretsAccessRequest `json:"items"`
+}
@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComplianceCheckResult is the outcome of one control. The values match the Compliance
+// Operator's ComplianceCheckResult statuses so results can be consumed alongside its scans.
+// +kubebuilder:validation:Enum=PASS;FAIL;MANUAL;ERROR;NOT-APPLICABLE
+type ComplianceCheckResult string
+
+const (
+	// ComplianceResultPass means the cluster satisfies the control
+	ComplianceResultPass ComplianceCheckResult = "PASS"
+
+	// ComplianceResultFail means the cluster violates the control; findings list the offenders
+	ComplianceResultFail ComplianceCheckResult = "FAIL"
+
+	// ComplianceResultManual means the operator cannot evaluate the control and it must be checked by hand
+	ComplianceResultManual ComplianceCheckResult = "MANUAL"
+
+	// ComplianceResultError means evaluating the control failed
+	ComplianceResultError ComplianceCheckResult = "ERROR"
+
+	// ComplianceResultNotApplicable means the control does not apply to this cluster
+	ComplianceResultNotApplicable ComplianceCheckResult = "NOT-APPLICABLE"
+)
+
+// ComplianceSeverity is how much a failed control weighs in the report score
+// +kubebuilder:validation:Enum=high;medium;low
+type ComplianceSeverity string
+
+const (
+	// ComplianceSeverityHigh controls weigh 3 in the score
+	ComplianceSeverityHigh ComplianceSeverity = "high"
+
+	// ComplianceSeverityMedium controls weigh 2 in the score
+	ComplianceSeverityMedium ComplianceSeverity = "medium"
+
+	// ComplianceSeverityLow controls weigh 1 in the score
+	ComplianceSeverityLow ComplianceSeverity = "low"
+)
+
+// ComplianceControlStatus is the result of evaluating one control
+type ComplianceControlStatus struct {
+	// ID identifies the control, such as secrets-encrypted-at-rest
+	ID string `json:"id"`
+
+	// Title describes what the control requires
+	Title string `json:"title"`
+
+	// Severity of a failure
+	Severity ComplianceSeverity `json:"severity"`
+
+	// References are the CIS benchmark and NIST SP 800-53 controls this control maps to
+	References []string `json:"references,omitempty"`
+
+	// Result of the evaluation
+	Result ComplianceCheckResult `json:"result"`
+
+	// Message summarizes the result
+	Message string `json:"message,omitempty"`
+
+	// Findings name the objects violating the control, at most 20
+	// +kubebuilder:validation:MaxItems=20
+	Findings []string `json:"findings,omitempty"`
+}
+
+// SecretsComplianceReportStatus is the latest evaluation of the secret-related controls
+type SecretsComplianceReportStatus struct {
+	// GeneratedAt is when the controls were last evaluated
+	GeneratedAt *metav1.Time `json:"generatedAt,omitempty"`
+
+	// Score is the severity-weighted percentage of evaluated controls that pass. Controls that are
+	// MANUAL, ERROR or NOT-APPLICABLE are left out.
+	Score int32 `json:"score"`
+
+	// Passed is the number of controls that pass
+	Passed int32 `json:"passed"`
+
+	// Failed is the number of controls that fail
+	Failed int32 `json:"failed"`
+
+	// Controls are the results of each control
+	Controls []ComplianceControlStatus `json:"controls,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=scr
+// +kubebuilder:printcolumn:name="Score",type=integer,JSONPath=`.status.score`
+// +kubebuilder:printcolumn:name="Passed",type=integer,JSONPath=`.status.passed`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failed`
+// +kubebuilder:printcolumn:name="Generated",type=date,JSONPath=`.status.generatedAt`
+
+// SecretsComplianceReport is written by the operator when spec.compliance.enabled is set on the
+// SecretsManagementConfig. It scores the cluster against secret-related CIS and NIST controls:
+// encryption at rest, long-lived service account tokens, expired TLS secrets and broad read
+// access to secrets.
+type SecretsComplianceReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status SecretsComplianceReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretsComplianceReportList contains a list of SecretsComplianceReport
+type SecretsComplianceReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretsComplianceReport `json:"items"`
+}
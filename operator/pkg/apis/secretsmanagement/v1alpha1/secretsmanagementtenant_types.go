@@ -0,0 +1,135 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantOperators selects the operators whose resources a tenant's roles grant access to. When
+// none is selected, the roles cover all three.
+type TenantOperators struct {
+	// CertManager grants access to cert-manager Certificates and Issuers
+	// +optional
+	CertManager bool `json:"certManager,omitempty"`
+
+	// ExternalSecrets grants access to ExternalSecrets, SecretStores and PushSecrets
+	// +optional
+	ExternalSecrets bool `json:"externalSecrets,omitempty"`
+
+	// SecretsStoreCSI grants access to SecretProviderClasses
+	// +optional
+	SecretsStoreCSI bool `json:"secretsStoreCSI,omitempty"`
+}
+
+// TenantRoleBinding binds users and groups to one access level in every namespace of the tenant
+type TenantRoleBinding struct {
+	// AccessLevel is the level of access granted
+	AccessLevel AccessLevel `json:"accessLevel"`
+
+	// Users bound to the access level
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Groups bound to the access level
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// TenantQuota limits the secrets-management resources a team can create in each of its
+// namespaces. The limits are enforced at admission by a ResourceQuota per namespace.
+type TenantQuota struct {
+	// MaxExternalSecrets is the most ExternalSecrets allowed per namespace; unlimited when unset
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxExternalSecrets *int32 `json:"maxExternalSecrets,omitempty"`
+
+	// MaxCertificates is the most cert-manager Certificates allowed per namespace; unlimited when unset
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxCertificates *int32 `json:"maxCertificates,omitempty"`
+}
+
+// TenantQuotaUsage reports how much of the quota one namespace uses
+type TenantQuotaUsage struct {
+	// Namespace the usage is counted in
+	Namespace string `json:"namespace"`
+
+	// ExternalSecrets is the number of ExternalSecrets in the namespace
+	ExternalSecrets int32 `json:"externalSecrets"`
+
+	// Certificates is the number of Certificates in the namespace
+	Certificates int32 `json:"certificates"`
+}
+
+// SecretsManagementTenantSpec defines the namespaces, operators and role bindings of a team
+type SecretsManagementTenantSpec struct {
+	// Namespaces are additional namespaces of the team. The tenant's own namespace is always
+	// included; another namespace is only accepted when a cluster admin labelled it
+	// secrets-management.openshift.io/tenant=<namespace of this tenant>.
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Operators selects the operators the team uses
+	// +optional
+	Operators TenantOperators `json:"operators,omitempty"`
+
+	// RoleBindings lists the users and groups granted access in the tenant's namespaces
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	RoleBindings []TenantRoleBinding `json:"roleBindings,omitempty"`
+
+	// Quota limits the resources created in each accepted namespace
+	// +optional
+	Quota TenantQuota `json:"quota,omitempty"`
+}
+
+// SecretsManagementTenantStatus defines the observed state of SecretsManagementTenant
+type SecretsManagementTenantStatus struct {
+	// ObservedGeneration is the generation of the spec the status reflects
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Namespaces are the accepted namespaces, shown in the console and granted the role bindings
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// RejectedNamespaces are the requested namespaces that do not exist or are not labelled for this tenant
+	RejectedNamespaces []string `json:"rejectedNamespaces,omitempty"`
+
+	// RoleBindings is the number of RoleBindings created across the accepted namespaces
+	RoleBindings int32 `json:"roleBindings,omitempty"`
+
+	// QuotaUsage lists the quota usage of each accepted namespace while spec.quota sets a limit,
+	// as last counted by the API server
+	QuotaUsage []TenantQuotaUsage `json:"quotaUsage,omitempty"`
+
+	// Message describes the outcome of the last reconcile
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=smt
+// +kubebuilder:printcolumn:name="Namespaces",type=string,JSONPath=`.status.namespaces`
+// +kubebuilder:printcolumn:name="Bindings",type=integer,JSONPath=`.status.roleBindings`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// SecretsManagementTenant lets a team opt its namespaces into the console view and bind its users
+// to secrets-management roles there, without editing the cluster-scoped SecretsManagementConfig.
+// The operator creates namespaced Roles, RoleBindings and ResourceQuotas in the accepted
+// namespaces and removes them when the tenant is deleted.
+type SecretsManagementTenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretsManagementTenantSpec   `json:"spec,omitempty"`
+	Status SecretsManagementTenantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretsManagementTenantList contains a list of SecretsManagementTenant
+type SecretsManagementTenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretsManagementTenant `json:"items"`
+}
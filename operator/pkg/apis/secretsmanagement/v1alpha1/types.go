@@ -4,7 +4,9 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // FeatureConfig defines settings for a specific UI feature
@@ -18,16 +20,43 @@ type FeatureConfig struct {
 	CheckRBAC bool `json:"checkRBAC,omitempty"`
 }
 
+// DeleteFeatureConfig defines settings for the delete operation
+type DeleteFeatureConfig struct {
+	FeatureConfig `json:",inline"`
+
+	// BlockProtected rejects deletion of Secrets, ExternalSecrets and Certificates labeled
+	// secrets-management.openshift.io/protected=true unless they are annotated
+	// secrets-management.openshift.io/deletion-approved=true
+	BlockProtected bool `json:"blockProtected,omitempty"`
+}
+
 // FeaturesConfig defines all UI feature toggles
 type FeaturesConfig struct {
 	// Delete operation settings
-	Delete FeatureConfig `json:"delete,omitempty"`
+	Delete DeleteFeatureConfig `json:"delete,omitempty"`
 
 	// Create operation settings (future feature)
 	Create FeatureConfig `json:"create,omitempty"`
 
 	// Edit operation settings (future feature)
 	Edit FeatureConfig `json:"edit,omitempty"`
+
+	// ReadOnly turns the plugin into a pure dashboard
+	ReadOnly ReadOnlyConfig `json:"readOnly,omitempty"`
+}
+
+// ReadOnlyConfig defines the read-only mode of the plugin
+type ReadOnlyConfig struct {
+	// Enabled hides the plugin's create, edit and delete actions and limits the generated admin
+	// ClusterRole to reading
+	Enabled bool `json:"enabled,omitempty"`
+
+	// AdmissionPolicy additionally creates a ValidatingAdmissionPolicy rejecting creates, updates and
+	// deletes of secrets-management resources by users, as the console sends them. Admission
+	// requests do not carry the client's user agent, so writes by users through other clients are
+	// rejected too; service accounts and system components such as GitOps controllers are exempt.
+	// Requires Enabled.
+	AdmissionPolicy bool `json:"admissionPolicy,omitempty"`
 }
 
 // RBACConfig defines RBAC settings managed by the operator
@@ -38,15 +67,60 @@ type RBACConfig struct {
 
 	// RolePrefix is the prefix for generated RBAC resource names
 	// +kubebuilder:default="secrets-management"
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[a-z0-9]([-a-z0-9]*[a-z0-9])?$')",message="rolePrefix must be a lowercase RFC 1123 label: alphanumerics and '-', starting and ending with an alphanumeric"
 	RolePrefix string `json:"rolePrefix,omitempty"`
+
+	// RulesOverrideConfigMap names a ConfigMap in the plugin namespace whose view, delete and admin
+	// keys patch the rules of the matching generated ClusterRole. Each key holds YAML with "remove",
+	// PolicyRules whose resources are dropped from the role's rules of the same API groups, and
+	// "add", PolicyRules appended to the role. The built-in rules are kept while the ConfigMap is
+	// missing or invalid, as reported by the RulesOverridden condition.
+	// +optional
+	RulesOverrideConfigMap string `json:"rulesOverrideConfigMap,omitempty"`
+
+	// IncludeCoreSecrets adds core Secrets to the generated roles, since the console plugin also
+	// shows them: get, list and watch to the view role and the view Roles of visible namespaces,
+	// delete to the delete role, and full access to the admin role
+	// +optional
+	IncludeCoreSecrets bool `json:"includeCoreSecrets,omitempty"`
+
+	// AllowClusterWideAccessRequests lets SecretsAccessRequests without a namespace be granted,
+	// through a ClusterRoleBinding. They are rejected otherwise.
+	// +optional
+	AllowClusterWideAccessRequests bool `json:"allowClusterWideAccessRequests,omitempty"`
+}
+
+// VisibilityConfig limits the namespaces the console plugin shows resources for, so tenants of a
+// shared cluster only see their own
+type VisibilityConfig struct {
+	// NamespaceSelector selects the visible namespaces; every namespace is visible when unset
+	NamespaceSelector *NamespaceSelectorConfig `json:"namespaceSelector,omitempty"`
+}
+
+// NamespaceSelectorConfig selects namespaces by label and by name. Namespaces carrying every label
+// in MatchLabels are selected, as is every namespace when both MatchLabels and Include are empty.
+// Namespaces in Include are added and those in Exclude removed. Label changes on namespaces are
+// picked up on the next periodic reconcile.
+type NamespaceSelectorConfig struct {
+	// MatchLabels selects namespaces carrying all of these labels
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// Include lists namespaces that are visible whatever their labels
+	Include []string `json:"include,omitempty"`
+
+	// Exclude lists namespaces that are never visible
+	Exclude []string `json:"exclude,omitempty"`
 }
 
 // ResourceRequirements defines CPU and memory requirements
 type ResourceRequirements struct {
 	// CPU resource requirement
+	// +kubebuilder:validation:Pattern=`^(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$`
 	CPU string `json:"cpu,omitempty"`
 
 	// Memory resource requirement
+	// +kubebuilder:validation:Pattern=`^(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$`
 	Memory string `json:"memory,omitempty"`
 }
 
@@ -59,23 +133,275 @@ type ResourceConfig struct {
 	Limits ResourceRequirements `json:"limits,omitempty"`
 }
 
+// ServiceAccountConfig defines settings for the plugin ServiceAccount
+type ServiceAccountConfig struct {
+	// AutomountServiceAccountToken mounts the legacy API token into plugin pods.
+	// The plugin does not call the API server, so this is disabled by default.
+	// +kubebuilder:default=false
+	AutomountServiceAccountToken bool `json:"automountServiceAccountToken,omitempty"`
+
+	// Annotations are added to the ServiceAccount, e.g. eks.amazonaws.com/role-arn or
+	// azure.workload.identity/client-id for cloud workload identity
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// TokenAudiences lists audiences for which a bound, auto-rotated ServiceAccount token
+	// is projected into the plugin container under /var/run/secrets/tokens
+	TokenAudiences []string `json:"tokenAudiences,omitempty"`
+}
+
+// PluginServiceConfig defines settings of the plugin Service
+type PluginServiceConfig struct {
+	// IPFamilies are the IP families of the Service's cluster IPs, in order, such as [IPv6] or
+	// [IPv4, IPv6]. When empty the cluster assigns its default; the plugin serves on the families
+	// the Service gets.
+	// +kubebuilder:validation:MaxItems=2
+	// +listType=atomic
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+
+	// IPFamilyPolicy is SingleStack, PreferDualStack or RequireDualStack; the cluster default when empty
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	IPFamilyPolicy corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// Annotations are added to the Service, e.g. for service meshes or load balancer controllers.
+	// The serving certificate annotation is set by the operator and cannot be overridden.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// InternalTrafficPolicy is Cluster, or Local to keep in-cluster traffic on the calling node;
+	// Cluster when empty
+	// +kubebuilder:validation:Enum=Cluster;Local
+	InternalTrafficPolicy corev1.ServiceInternalTrafficPolicy `json:"internalTrafficPolicy,omitempty"`
+
+	// SessionAffinity is None, or ClientIP to send a client's requests to the same pod; None when empty
+	// +kubebuilder:validation:Enum=None;ClientIP
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+}
+
+// RouteConfig defines an optional OpenShift Route to the plugin Service
+type RouteConfig struct {
+	// Enabled creates a reencrypt Route to the plugin Service for direct access
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Host is the external hostname of the Route; the router generates one when empty
+	Host string `json:"host,omitempty"`
+
+	// InsecureEdgeTerminationPolicy defines how plain HTTP requests are handled
+	// +kubebuilder:validation:Enum=None;Redirect
+	// +kubebuilder:default="Redirect"
+	InsecureEdgeTerminationPolicy string `json:"insecureEdgeTerminationPolicy,omitempty"`
+
+	// ExternalCertificateSecretName references a kubernetes.io/tls Secret in the plugin
+	// namespace serving the Route instead of the default router certificate
+	ExternalCertificateSecretName string `json:"externalCertificateSecretName,omitempty"`
+}
+
+// NamespaceQuotaConfig defines the ResourceQuota and LimitRange applied to the plugin namespace
+type NamespaceQuotaConfig struct {
+	// Enabled creates a ResourceQuota and LimitRange in the plugin namespace
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Pods is the maximum number of pods allowed in the plugin namespace. Defaults to the pods of
+	// the operator, the plugin, a canary plugin, the smcctl downloads and the CronJobs, with the
+	// Deployments surging during a rolling update.
+	// +kubebuilder:validation:Minimum=1
+	Pods int32 `json:"pods,omitempty"`
+
+	// Requests caps the total resources requested by all pods in the namespace. Each defaults to
+	// what the pods counted by Pods request.
+	Requests ResourceRequirements `json:"requests,omitempty"`
+
+	// Limits caps the total resource limits of all pods in the namespace. Each defaults to the
+	// limits of the pods counted by Pods.
+	Limits ResourceRequirements `json:"limits,omitempty"`
+
+	// ContainerDefaults are the default requests and limits applied by the LimitRange
+	// to containers that do not set their own
+	ContainerDefaults ResourceConfig `json:"containerDefaults,omitempty"`
+}
+
+// DeploymentStrategyConfig defines how plugin pods are replaced on updates
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Recreate' || (!has(self.maxSurge) && !has(self.maxUnavailable))",message="maxSurge and maxUnavailable are only valid for RollingUpdate"
+type DeploymentStrategyConfig struct {
+	// Type of deployment strategy
+	// +kubebuilder:validation:Enum=RollingUpdate;Recreate
+	// +kubebuilder:default="RollingUpdate"
+	Type string `json:"type,omitempty"`
+
+	// MaxSurge is the maximum number of pods scheduled above the desired replicas during a rolling update
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the maximum number of pods that can be unavailable during a rolling update
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
 // PluginConfig defines the console plugin deployment settings
+// +kubebuilder:validation:XValidation:rule="!has(self.metricsPort) || self.metricsPort != (has(self.port) ? self.port : 9443)",message="metricsPort must differ from port"
 type PluginConfig struct {
 	// Image is the container image for the console plugin
 	Image string `json:"image,omitempty"`
 
+	// Images maps node architectures (e.g. amd64, arm64, ppc64le, s390x) to per-architecture
+	// plugin images; the entry matching the cluster node architecture takes precedence over Image
+	Images map[string]string `json:"images,omitempty"`
+
+	// RequireDigest rejects plugin images that are not pinned by digest (image@sha256:...)
+	RequireDigest bool `json:"requireDigest,omitempty"`
+
 	// ImagePullPolicy defines when to pull the image
 	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
 	// +kubebuilder:default="IfNotPresent"
 	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
 
+	// Port is the HTTPS port the plugin serves on
+	// +kubebuilder:default=9443
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
+	// MetricsPort optionally exposes a plain HTTP metrics listener on the Service and Deployment,
+	// separate from the TLS listener used by the console
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=65535
+	MetricsPort int32 `json:"metricsPort,omitempty"`
+
 	// Replicas is the number of plugin deployment replicas
 	// +kubebuilder:default=2
 	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
 	Replicas int32 `json:"replicas,omitempty"`
 
 	// Resources defines the resource requirements for the plugin container
 	Resources ResourceConfig `json:"resources,omitempty"`
+
+	// Strategy defines the plugin Deployment update strategy
+	Strategy DeploymentStrategyConfig `json:"strategy,omitempty"`
+
+	// NamespaceQuota optionally bounds resource usage in the plugin namespace
+	NamespaceQuota NamespaceQuotaConfig `json:"namespaceQuota,omitempty"`
+
+	// ServiceAccount defines settings for the plugin ServiceAccount
+	ServiceAccount ServiceAccountConfig `json:"serviceAccount,omitempty"`
+
+	// Service configures the plugin Service
+	Service PluginServiceConfig `json:"service,omitempty"`
+
+	// Route optionally exposes the plugin Service through an OpenShift Route
+	Route RouteConfig `json:"route,omitempty"`
+
+	// InjectTrustedCABundle creates a ConfigMap labeled for cluster CA bundle injection
+	// and mounts the merged bundle into the plugin pods
+	InjectTrustedCABundle bool `json:"injectTrustedCABundle,omitempty"`
+
+	// ExtraVolumes are additional volumes added to the plugin pods (e.g. CA bundles, branding assets)
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// ExtraVolumeMounts are additional volume mounts added to the plugin container
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// Logging configures the logs of the plugin's web server
+	Logging PluginLoggingConfig `json:"logging,omitempty"`
+
+	// ServiceMesh adapts the plugin pods and Service to an Istio or OpenShift Service Mesh
+	// data plane in the plugin namespace
+	ServiceMesh PluginServiceMeshConfig `json:"serviceMesh,omitempty"`
+
+	// ServingCert configures what happens while the service CA has not issued the plugin's
+	// serving certificate
+	ServingCert ServingCertConfig `json:"servingCert,omitempty"`
+
+	// ServerConfigOverride is nginx configuration appended to the plugin's server block, such as
+	// extra location blocks. The operator owns the nginx ConfigMap, so edits made there are lost.
+	// +kubebuilder:validation:MaxLength=8192
+	// +optional
+	ServerConfigOverride string `json:"serverConfigOverride,omitempty"`
+
+	// SecurityHeaders are HTTP response headers added to everything the plugin serves
+	SecurityHeaders PluginSecurityHeadersConfig `json:"securityHeaders,omitempty"`
+}
+
+// PluginSecurityHeadersConfig sets security related response headers on the plugin's responses.
+// Headers left empty are not sent.
+type PluginSecurityHeadersConfig struct {
+	// ContentSecurityPolicy is the value of the Content-Security-Policy header
+	// +kubebuilder:validation:MaxLength=4096
+	ContentSecurityPolicy string `json:"contentSecurityPolicy,omitempty"`
+
+	// FrameOptions is the value of the X-Frame-Options header
+	// +kubebuilder:validation:Enum=DENY;SAMEORIGIN
+	FrameOptions string `json:"frameOptions,omitempty"`
+
+	// StrictTransportSecurity is the value of the Strict-Transport-Security header, such as
+	// "max-age=31536000; includeSubDomains"
+	// +kubebuilder:validation:MaxLength=256
+	StrictTransportSecurity string `json:"strictTransportSecurity,omitempty"`
+}
+
+// ServingCertConfig configures the plugin serving certificate
+// +kubebuilder:validation:XValidation:rule="!has(self.fallbackAfter) || (has(self.selfSignedFallback) && self.selfSignedFallback)",message="fallbackAfter requires selfSignedFallback"
+type ServingCertConfig struct {
+	// SelfSignedFallback writes a self-signed certificate to the serving certificate Secret when
+	// the service CA has not issued one within FallbackAfter, so the plugin pods can start. Delete
+	// the Secret to have the service CA issue it again.
+	SelfSignedFallback bool `json:"selfSignedFallback,omitempty"`
+
+	// FallbackAfter is how long to wait for the service CA after the plugin Service is created.
+	// Defaults to 5m.
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	FallbackAfter *metav1.Duration `json:"fallbackAfter,omitempty"`
+}
+
+// SidecarInjection selects whether the mesh injects its proxy into the plugin pods
+// +kubebuilder:validation:Enum=Enabled;Disabled
+type SidecarInjection string
+
+const (
+	// SidecarInjectionEnabled injects the proxy regardless of the namespace setting
+	SidecarInjectionEnabled SidecarInjection = "Enabled"
+
+	// SidecarInjectionDisabled keeps the proxy out of the plugin pods
+	SidecarInjectionDisabled SidecarInjection = "Disabled"
+)
+
+// PluginServiceMeshConfig configures the plugin for a service mesh
+type PluginServiceMeshConfig struct {
+	// SidecarInjection sets sidecar.istio.io/inject on the plugin pods; the namespace's
+	// injection setting applies when unset
+	SidecarInjection SidecarInjection `json:"sidecarInjection,omitempty"`
+
+	// AppProtocols sets appProtocol on the plugin Service ports, https for the plugin port and
+	// http for the metrics port, so the mesh does not have to detect the protocol
+	AppProtocols bool `json:"appProtocols,omitempty"`
+
+	// ExcludeInboundPorts are pod ports the sidecar does not intercept. When the sidecar is
+	// enabled and none are set, the plugin port is excluded so the console's TLS connections
+	// reach nginx directly.
+	// +listType=set
+	ExcludeInboundPorts []int32 `json:"excludeInboundPorts,omitempty"`
+}
+
+// PluginLogFormat is the format of the plugin access log
+// +kubebuilder:validation:Enum=Text;JSON
+type PluginLogFormat string
+
+const (
+	// PluginLogFormatText writes access log entries in the nginx combined format
+	PluginLogFormatText PluginLogFormat = "Text"
+
+	// PluginLogFormatJSON writes one JSON object per access log entry
+	PluginLogFormatJSON PluginLogFormat = "JSON"
+)
+
+// PluginLoggingConfig configures the nginx logs of the plugin pods
+type PluginLoggingConfig struct {
+	// Level is the lowest severity written to the error log; info when unset
+	// +kubebuilder:validation:Enum=debug;info;notice;warn;error;crit
+	Level string `json:"level,omitempty"`
+
+	// DisableAccessLog stops logging every request the plugin serves
+	DisableAccessLog bool `json:"disableAccessLog,omitempty"`
+
+	// Format of the access log; Text when unset
+	Format PluginLogFormat `json:"format,omitempty"`
 }
 
 // OperatorConfig defines settings for a specific operator
@@ -97,164 +423,2099 @@ type OperatorsConfig struct {
 	SecretsStoreCSI OperatorConfig `json:"secretsStoreCSI,omitempty"`
 }
 
-// SecretsManagementConfigSpec defines the desired state of SecretsManagementConfig
-type SecretsManagementConfigSpec struct {
-	// Features defines UI feature toggles
-	Features FeaturesConfig `json:"features,omitempty"`
+// ProtectionConfig defines admission-time protection of operator-managed resources
+type ProtectionConfig struct {
+	// Enabled creates a ValidatingAdmissionPolicy that rejects updates and deletes of
+	// operator-managed resources by anyone other than the operator and platform controllers.
+	// Objects annotated secrets-management.openshift.io/allow-modification=true are exempt; only
+	// users who may update SecretsManagementConfigs can add the annotation.
+	Enabled bool `json:"enabled,omitempty"`
+}
 
-	// RBAC defines RBAC resources managed by the operator
-	RBAC RBACConfig `json:"rbac,omitempty"`
+// PolicyEngine is the admission engine the policy bundle is rendered for
+// +kubebuilder:validation:Enum=Gatekeeper;Kyverno;ValidatingAdmissionPolicy
+type PolicyEngine string
 
-	// Plugin defines the console plugin deployment settings
-	Plugin PluginConfig `json:"plugin,omitempty"`
+const (
+	// PolicyEngineGatekeeper renders OPA Gatekeeper ConstraintTemplates and constraints
+	PolicyEngineGatekeeper PolicyEngine = "Gatekeeper"
 
-	// Operators defines per-operator configuration
-	Operators OperatorsConfig `json:"operators,omitempty"`
-}
+	// PolicyEngineKyverno renders Kyverno ClusterPolicies
+	PolicyEngineKyverno PolicyEngine = "Kyverno"
 
-// ClusterRoleStatus represents a ClusterRole created by the operator
-type ClusterRoleStatus struct {
-	// Name of the ClusterRole
-	Name string `json:"name,omitempty"`
+	// PolicyEngineValidatingAdmissionPolicy renders ValidatingAdmissionPolicies and their bindings
+	PolicyEngineValidatingAdmissionPolicy PolicyEngine = "ValidatingAdmissionPolicy"
+)
 
-	// Operations this role grants (e.g., "view", "delete", "admin")
-	Operations []string `json:"operations,omitempty"`
+// PolicyAction is what the policy engine does with requests that violate a policy
+// +kubebuilder:validation:Enum=Deny;Warn
+type PolicyAction string
 
-	// Created is the timestamp when the role was created
-	Created metav1.Time `json:"created,omitempty"`
+const (
+	// PolicyActionDeny rejects violating requests
+	PolicyActionDeny PolicyAction = "Deny"
+
+	// PolicyActionWarn admits violating requests and reports them: as a warning to the client
+	// with Gatekeeper and ValidatingAdmissionPolicy, and in policy reports with Kyverno
+	PolicyActionWarn PolicyAction = "Warn"
+)
+
+// PoliciesConfig defines the organization rules for secrets the operator enforces through an
+// admission policy engine. The rendered policies follow the rule toggles and are removed when a
+// rule is turned off or the engine changes.
+type PoliciesConfig struct {
+	// Engine renders the policies for Gatekeeper, Kyverno or ValidatingAdmissionPolicy. No
+	// policies are created when empty.
+	// +optional
+	Engine PolicyEngine `json:"engine,omitempty"`
+
+	// Action is what happens to requests that violate a policy
+	// +kubebuilder:default=Deny
+	// +optional
+	Action PolicyAction `json:"action,omitempty"`
+
+	// ProductionNamespaceSelector selects the namespaces the rules apply to. Defaults to
+	// namespaces labeled environment=production.
+	// +optional
+	ProductionNamespaceSelector *metav1.LabelSelector `json:"productionNamespaceSelector,omitempty"`
+
+	// RequireExternalSecrets rejects Opaque Secrets in production namespaces unless they are
+	// owned by an ExternalSecret, so secret values are sourced from an external store
+	// +optional
+	RequireExternalSecrets bool `json:"requireExternalSecrets,omitempty"`
+
+	// ForbidServiceAccountTokenSecrets rejects long-lived service account token Secrets in
+	// production namespaces
+	// +optional
+	ForbidServiceAccountTokenSecrets bool `json:"forbidServiceAccountTokenSecrets,omitempty"`
 }
 
-// RBACStatus represents the status of RBAC resources
-type RBACStatus struct {
-	// ClusterRoles created by the operator
-	ClusterRoles []ClusterRoleStatus `json:"clusterRoles,omitempty"`
+// AuditConfig defines the audit trail of operations performed through the console plugin
+type AuditConfig struct {
+	// Enabled exposes the operator's audit endpoint to the plugin through the console proxy
+	// and records the operations it reports
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MaxRecords is the number of records kept; the oldest are dropped first
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=2000
+	// +kubebuilder:default=500
+	// +optional
+	MaxRecords int32 `json:"maxRecords,omitempty"`
+
+	// Sinks receive every audit record, and Warning events when ForwardWarningEvents is set
+	// +kubebuilder:validation:MaxItems=5
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Sinks []AuditSink `json:"sinks,omitempty"`
+
+	// ForwardWarningEvents forwards Warning events about cert-manager, External Secrets and
+	// Secrets Store CSI resources, such as failed secret syncs and expired certificates, to the sinks
+	// +optional
+	ForwardWarningEvents bool `json:"forwardWarningEvents,omitempty"`
 }
 
-// PluginStatus represents the status of the console plugin deployment
-type PluginStatus struct {
-	// DeploymentName is the name of the plugin Deployment
-	DeploymentName string `json:"deploymentName,omitempty"`
+// AuditSinkType is the kind of destination audit records are forwarded to
+// +kubebuilder:validation:Enum=Webhook;Syslog
+type AuditSinkType string
 
-	// ServiceName is the name of the plugin Service
-	ServiceName string `json:"serviceName,omitempty"`
+const (
+	// AuditSinkWebhook POSTs each record as JSON to an HTTP endpoint
+	AuditSinkWebhook AuditSinkType = "Webhook"
 
-	// ConsolePluginName is the name of the ConsolePlugin CR
-	ConsolePluginName string `json:"consolePluginName,omitempty"`
+	// AuditSinkSyslog sends each record as an RFC 5424 syslog message
+	AuditSinkSyslog AuditSinkType = "Syslog"
+)
 
-	// AvailableReplicas is the number of available replicas
-	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+// AuditSink is an external destination for audit records, such as a SIEM
+type AuditSink struct {
+	// Name identifies the sink in status
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 
-	// Ready indicates whether the plugin is ready
-	Ready bool `json:"ready,omitempty"`
+	// Type selects which of webhook or syslog is used
+	Type AuditSinkType `json:"type"`
+
+	// Webhook configures a Webhook sink
+	// +optional
+	Webhook *WebhookSinkConfig `json:"webhook,omitempty"`
+
+	// Syslog configures a Syslog sink
+	// +optional
+	Syslog *SyslogSinkConfig `json:"syslog,omitempty"`
 }
 
-// DetectedOperator represents the detection status of an operator
-type DetectedOperator struct {
-	// Installed indicates whether the operator's CRDs are installed
-	Installed bool `json:"installed,omitempty"`
+// WebhookSinkConfig defines an HTTP endpoint receiving audit records
+type WebhookSinkConfig struct {
+	// URL records are POSTed to
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
 
-	// Version is the detected operator version
-	Version string `json:"version,omitempty"`
+	// TokenSecret names a Secret in the plugin namespace whose "token" key is sent as a bearer token
+	// +optional
+	TokenSecret string `json:"tokenSecret,omitempty"`
 }
 
-// DetectedOperatorsStatus represents the status of detected operators
-type DetectedOperatorsStatus struct {
-	// CertManager detection status
-	CertManager DetectedOperator `json:"certManager,omitempty"`
+// SyslogSinkConfig defines a syslog receiver
+type SyslogSinkConfig struct {
+	// Address of the receiver as host:port
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// Protocol used to reach the receiver
+	// +kubebuilder:validation:Enum=tcp;udp
+	// +kubebuilder:default=tcp
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+}
 
-	// ExternalSecrets detection status
-	ExternalSecrets DetectedOperator `json:"externalSecrets,omitempty"`
+// NotificationEvent is a kind of situation receivers are notified about
+// +kubebuilder:validation:Enum=CertificateExpiring;ExternalSecretFailing;PluginDegraded
+type NotificationEvent string
 
-	// SecretsStoreCSI detection status
-	SecretsStoreCSI DetectedOperator `json:"secretsStoreCSI,omitempty"`
+const (
+	// NotificationCertificateExpiring is sent when a Certificate crosses an expiry threshold
+	NotificationCertificateExpiring NotificationEvent = "CertificateExpiring"
+
+	// NotificationExternalSecretFailing is sent when an ExternalSecret stays not Ready
+	NotificationExternalSecretFailing NotificationEvent = "ExternalSecretFailing"
+
+	// NotificationPluginDegraded is sent when the config enters the Degraded phase
+	NotificationPluginDegraded NotificationEvent = "PluginDegraded"
+)
+
+// NotificationReceiverType is the kind of endpoint notifications are sent to
+// +kubebuilder:validation:Enum=Slack;Teams;Webhook
+type NotificationReceiverType string
+
+const (
+	// NotificationReceiverSlack posts to a Slack incoming webhook
+	NotificationReceiverSlack NotificationReceiverType = "Slack"
+
+	// NotificationReceiverTeams posts to a Microsoft Teams incoming webhook
+	NotificationReceiverTeams NotificationReceiverType = "Teams"
+
+	// NotificationReceiverWebhook posts the notification as JSON to any HTTP endpoint
+	NotificationReceiverWebhook NotificationReceiverType = "Webhook"
+)
+
+// NotificationReceiver is an endpoint notifications are sent to
+type NotificationReceiver struct {
+	// Name identifies the receiver in status
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Type selects the message format
+	Type NotificationReceiverType `json:"type"`
+
+	// URLSecret names a Secret in the plugin namespace whose "url" key holds the webhook URL
+	// +kubebuilder:validation:MinLength=1
+	URLSecret string `json:"urlSecret"`
+
+	// Events limits the notifications sent to this receiver; all events are sent when empty
+	// +optional
+	Events []NotificationEvent `json:"events,omitempty"`
+
+	// Namespaces limits Certificate and ExternalSecret notifications to these namespaces; all
+	// namespaces when empty
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// MaxPerHour is the most notifications sent to this receiver in any hour; the rest are suppressed
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=20
+	// +optional
+	MaxPerHour int32 `json:"maxPerHour,omitempty"`
 }
 
-// ConfigPhase represents the phase of the SecretsManagementConfig
-// +kubebuilder:validation:Enum=Pending;Deploying;Ready;Degraded;Error
-type ConfigPhase string
+// NotificationsConfig defines notifications about expiring certificates, failing secret syncs
+// and plugin health
+type NotificationsConfig struct {
+	// Receivers are the endpoints notifications are sent to
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Receivers []NotificationReceiver `json:"receivers,omitempty"`
+
+	// CertificateExpiryThresholds notify when a Certificate's remaining validity drops below each
+	// threshold. Defaults to 30, 7 and 1 days.
+	// +optional
+	CertificateExpiryThresholds []metav1.Duration `json:"certificateExpiryThresholds,omitempty"`
+
+	// ExternalSecretFailureDuration is how long an ExternalSecret must stay not Ready before
+	// receivers are notified. Defaults to 15m.
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	ExternalSecretFailureDuration *metav1.Duration `json:"externalSecretFailureDuration,omitempty"`
+}
+
+// SecretStoreProvider is the secret backend a ClusterSecretStore reads from
+// +kubebuilder:validation:Enum=AWS;Vault;AzureKeyVault;GCPSecretManager
+type SecretStoreProvider string
 
 const (
-	// PhasePending indicates the config is pending processing
-	PhasePending ConfigPhase = "Pending"
+	// SecretStoreProviderAWS reads from AWS Secrets Manager
+	SecretStoreProviderAWS SecretStoreProvider = "AWS"
 
-	// PhaseDeploying indicates resources are being deployed
-	PhaseDeploying ConfigPhase = "Deploying"
+	// SecretStoreProviderVault reads from a HashiCorp Vault KV v2 engine
+	SecretStoreProviderVault SecretStoreProvider = "Vault"
 
-	// PhaseReady indicates everything is ready
-	PhaseReady ConfigPhase = "Ready"
+	// SecretStoreProviderAzureKeyVault reads from Azure Key Vault
+	SecretStoreProviderAzureKeyVault SecretStoreProvider = "AzureKeyVault"
 
-	// PhaseDegraded indicates some components are not fully functional
-	PhaseDegraded ConfigPhase = "Degraded"
+	// SecretStoreProviderGCPSecretManager reads from Google Cloud Secret Manager
+	SecretStoreProviderGCPSecretManager SecretStoreProvider = "GCPSecretManager"
+)
 
-	// PhaseError indicates an error occurred
-	PhaseError ConfigPhase = "Error"
+// SecretStoreConfig declares an External Secrets Operator ClusterSecretStore for the operator
+// to create. The auth Secret lives in the plugin namespace and holds the provider's credentials:
+// access-key-id and secret-access-key for AWS, token for Vault, client-id and client-secret for
+// Azure Key Vault, and secret-access-credentials for GCP Secret Manager.
+type SecretStoreConfig struct {
+	// Name of the ClusterSecretStore
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Provider is the secret backend
+	Provider SecretStoreProvider `json:"provider"`
+
+	// AuthSecret names the Secret in the plugin namespace holding the provider's credentials
+	// +kubebuilder:validation:MinLength=1
+	AuthSecret string `json:"authSecret"`
+
+	// Region of AWS Secrets Manager. Required for AWS.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Address is the Vault server URL or the Azure Key Vault URL. Required for Vault and AzureKeyVault.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Path is the Vault KV v2 mount path. Defaults to "secret".
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// TenantID is the Azure tenant of the service principal. Required for AzureKeyVault.
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+
+	// ProjectID is the GCP project holding the secrets. Required for GCPSecretManager.
+	// +optional
+	ProjectID string `json:"projectID,omitempty"`
+}
+
+// CloudAuthProvider is the cloud workload identity service accounts are federated with
+// +kubebuilder:validation:Enum=AWS;GCP;Azure
+type CloudAuthProvider string
+
+const (
+	// CloudAuthProviderAWS annotates service accounts for IAM Roles for Service Accounts (IRSA)
+	CloudAuthProviderAWS CloudAuthProvider = "AWS"
+
+	// CloudAuthProviderGCP annotates service accounts for GKE Workload Identity
+	CloudAuthProviderGCP CloudAuthProvider = "GCP"
+
+	// CloudAuthProviderAzure annotates service accounts for Azure Workload Identity
+	CloudAuthProviderAzure CloudAuthProvider = "Azure"
 )
 
-// ConditionType represents a condition type for SecretsManagementConfig
-type ConditionType string
+// CloudAuthConfig has the operator annotate the service accounts of the External Secrets Operator
+// and the Secrets Store CSI providers for cloud workload identity, so they authenticate to the
+// cloud with their projected token instead of a static credential
+// +kubebuilder:validation:XValidation:rule="!has(self.provider) || (self.provider == 'AWS' ? has(self.aws) : self.provider == 'GCP' ? has(self.gcp) : has(self.azure))",message="the aws, gcp or azure block of the selected provider must be set"
+type CloudAuthConfig struct {
+	// Provider selects which of aws, gcp or azure is used. No service account is annotated while
+	// it is empty, and the annotations the operator set are removed.
+	// +optional
+	Provider CloudAuthProvider `json:"provider,omitempty"`
+
+	// ServiceAccounts are annotated with the workload identity of Provider, such as the
+	// external-secrets service account of the External Secrets Operator or the service account of
+	// a CSI provider DaemonSet. Annotations are removed from service accounts dropped from the list.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	ServiceAccounts []ServiceAccountReference `json:"serviceAccounts,omitempty"`
+
+	// AWS configures IRSA
+	// +optional
+	AWS *AWSCloudAuthConfig `json:"aws,omitempty"`
+
+	// GCP configures GKE Workload Identity
+	// +optional
+	GCP *GCPCloudAuthConfig `json:"gcp,omitempty"`
+
+	// Azure configures Azure Workload Identity
+	// +optional
+	Azure *AzureCloudAuthConfig `json:"azure,omitempty"`
+}
+
+// ServiceAccountReference names a service account
+type ServiceAccountReference struct {
+	// Namespace of the service account
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace"`
+
+	// Name of the service account
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+}
+
+// AWSCloudAuthConfig configures IAM Roles for Service Accounts
+type AWSCloudAuthConfig struct {
+	// RoleARN is the IAM role the service accounts assume, set as eks.amazonaws.com/role-arn. Its
+	// trust policy must allow the cluster's OIDC provider and the service accounts.
+	// +kubebuilder:validation:Pattern=`^arn:aws(-cn|-us-gov)?:iam::[0-9]{12}:role/.+$`
+	RoleARN string `json:"roleARN"`
+
+	// Region of the STS endpoint the federation is validated against. Defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Audience of the projected service account token, set as eks.amazonaws.com/audience when it
+	// differs from the default sts.amazonaws.com
+	// +optional
+	Audience string `json:"audience,omitempty"`
+}
+
+// GCPCloudAuthConfig configures GKE Workload Identity
+type GCPCloudAuthConfig struct {
+	// ServiceAccount is the email of the Google service account impersonated, set as
+	// iam.gke.io/gcp-service-account
+	// +kubebuilder:validation:Pattern=`^[^@]+@[^@]+\.iam\.gserviceaccount\.com$`
+	ServiceAccount string `json:"serviceAccount"`
+
+	// WorkloadIdentityProvider is the full resource name of the workload identity pool provider
+	// trusting the cluster's OIDC issuer, such as
+	// //iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/openshift/providers/cluster.
+	// The federation is only validated when it is set.
+	// +kubebuilder:validation:Pattern=`^//iam\.googleapis\.com/projects/`
+	// +optional
+	WorkloadIdentityProvider string `json:"workloadIdentityProvider,omitempty"`
+
+	// Audience of the service account token exchanged with the provider. Defaults to the
+	// provider's default audience, https: followed by WorkloadIdentityProvider.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+}
+
+// AzureCloudAuthConfig configures Azure Workload Identity
+type AzureCloudAuthConfig struct {
+	// ClientID of the managed identity or app registration with a federated credential for the
+	// service accounts, set as azure.workload.identity/client-id
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientID"`
+
+	// TenantID of the identity, set as azure.workload.identity/tenant-id
+	// +kubebuilder:validation:MinLength=1
+	TenantID string `json:"tenantID"`
+}
+
+// IssuerType selects how a ClusterIssuer signs certificates
+// +kubebuilder:validation:Enum=ACME;CA;Vault
+type IssuerType string
 
 const (
-	// ConditionPluginDeployed indicates the plugin deployment status
-	ConditionPluginDeployed ConditionType = "PluginDeployed"
+	// IssuerTypeACME obtains certificates from an ACME server such as Let's Encrypt
+	IssuerTypeACME IssuerType = "ACME"
 
-	// ConditionRBACConfigured indicates the RBAC configuration status
-	ConditionRBACConfigured ConditionType = "RBACConfigured"
+	// IssuerTypeCA signs certificates with a CA key pair stored in a Secret
+	IssuerTypeCA IssuerType = "CA"
 
-	// ConditionConsolePluginRegistered indicates the ConsolePlugin CR status
-	ConditionConsolePluginRegistered ConditionType = "ConsolePluginRegistered"
+	// IssuerTypeVault signs certificates with a HashiCorp Vault PKI engine
+	IssuerTypeVault IssuerType = "Vault"
 )
 
-// Condition represents an observation of the config's state
-type Condition struct {
-	// Type of condition
-	Type ConditionType `json:"type"`
+// ACMEIssuerConfig configures an ACME ClusterIssuer solving HTTP-01 challenges through an ingress
+type ACMEIssuerConfig struct {
+	// Server is the ACME directory URL
+	// +kubebuilder:validation:Pattern=`^https://`
+	// +kubebuilder:default="https://acme-v02.api.letsencrypt.org/directory"
+	// +optional
+	Server string `json:"server,omitempty"`
+
+	// Email is the account contact for expiry notices from the ACME server
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// PrivateKeySecret names the Secret cert-manager stores the ACME account key in
+	// +kubebuilder:validation:MinLength=1
+	PrivateKeySecret string `json:"privateKeySecret"`
+
+	// IngressClass serves HTTP-01 challenges. Defaults to "openshift-default".
+	// +optional
+	IngressClass string `json:"ingressClass,omitempty"`
+}
 
-	// Status of the condition (True, False, Unknown)
-	// +kubebuilder:validation:Enum=True;False;Unknown
-	Status string `json:"status"`
+// CAIssuerConfig configures a CA ClusterIssuer
+type CAIssuerConfig struct {
+	// SecretName names the Secret holding the CA's tls.crt and tls.key
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+}
 
-	// Reason is a machine-readable reason for the condition
-	Reason string `json:"reason,omitempty"`
+// VaultIssuerConfig configures a Vault ClusterIssuer authenticating with a token
+type VaultIssuerConfig struct {
+	// Server is the Vault server URL
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Server string `json:"server"`
 
-	// Message is a human-readable message for the condition
-	Message string `json:"message,omitempty"`
+	// Path is the Vault PKI sign path, such as pki/sign/example-dot-com
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
 
-	// LastTransitionTime is the last time the condition transitioned
-	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// TokenSecret names the Secret whose "token" key holds the Vault token
+	// +kubebuilder:validation:MinLength=1
+	TokenSecret string `json:"tokenSecret"`
 }
 
-// SecretsManagementConfigStatus defines the observed state of SecretsManagementConfig
-type SecretsManagementConfigStatus struct {
-	// Phase is the overall status of the deployment
-	Phase ConfigPhase `json:"phase,omitempty"`
+// ComplianceConfig enables the SecretsComplianceReport the operator writes about secret-related
+// CIS and NIST controls
+type ComplianceConfig struct {
+	// Enabled evaluates the controls and writes the SecretsComplianceReport named cluster. The
+	// report is deleted when disabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
 
-	// ObservedGeneration is the last observed generation of the spec
-	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Interval is how often the controls are evaluated. Defaults to 1h.
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
 
-	// RBAC contains status of RBAC resources
-	RBAC RBACStatus `json:"rbac,omitempty"`
+	// PolicyReport also writes the results to the wgpolicyk8s.io ClusterPolicyReport named
+	// secrets-management, for fleet governance dashboards such as ACM's. Nothing is written
+	// when the PolicyReport CRDs are not installed.
+	// +optional
+	PolicyReport bool `json:"policyReport,omitempty"`
+}
 
-	// Plugin contains status of the console plugin deployment
-	Plugin PluginStatus `json:"plugin,omitempty"`
+// ScanConfig runs the compliance scan on a schedule in a pod of its own
+type ScanConfig struct {
+	// Schedule is a cron schedule, such as "0 */6 * * *", on which a CronJob in the plugin
+	// namespace runs the compliance scan under a read-only ServiceAccount instead of the operator
+	// evaluating the controls itself. spec.compliance.interval is ignored while it is set.
+	// Requires spec.compliance.enabled.
+	// +kubebuilder:validation:MaxLength=100
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+}
 
-	// DetectedOperators contains detection status of operators
-	DetectedOperators DetectedOperatorsStatus `json:"detectedOperators,omitempty"`
+// TelemetryConfig controls the anonymized usage report
+type TelemetryConfig struct {
+	// Enabled exports anonymized usage counters on the operator's metrics endpoint: which secret
+	// management operators are installed, how many of their resources exist and which plugin
+	// features are enabled. Cluster monitoring forwards them through the telemetry channel.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// ResourceMetricsConfig controls the per-resource metrics exporter
+type ResourceMetricsConfig struct {
+	// Enabled exports one series per Certificate, ExternalSecret and SecretProviderClassPodStatus
+	// on the operator's metrics endpoint, labeled by namespace and name, for dashboards. The
+	// series are refreshed on every reconcile; not available in restricted mode.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// MonitoringConfig configures where the secrets management alerts are delivered
+type MonitoringConfig struct {
+	// AlertReceivers render an AlertmanagerConfig in the plugin namespace routing alerts whose
+	// name starts with SecretsManagement to these destinations, so that, for example, certificate
+	// expiry and plugin availability alerts reach different teams. An alert goes to every
+	// receiver selecting it.
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	AlertReceivers []AlertReceiver `json:"alertReceivers,omitempty"`
+}
+
+// AlertReceiverType is the kind of destination alerts are sent to
+// +kubebuilder:validation:Enum=Email;PagerDuty;Webhook
+type AlertReceiverType string
+
+const (
+	// AlertReceiverEmail sends alerts by email through the SMTP server configured in Alertmanager
+	AlertReceiverEmail AlertReceiverType = "Email"
+
+	// AlertReceiverPagerDuty sends alerts to PagerDuty through the Events API v2
+	AlertReceiverPagerDuty AlertReceiverType = "PagerDuty"
+
+	// AlertReceiverWebhook POSTs alerts to an HTTP endpoint in the Alertmanager webhook format
+	AlertReceiverWebhook AlertReceiverType = "Webhook"
+)
+
+// AlertReceiver is a destination for secrets management alerts
+type AlertReceiver struct {
+	// Name identifies the receiver in the AlertmanagerConfig
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Type selects which of email, pagerDuty or webhook is used
+	Type AlertReceiverType `json:"type"`
+
+	// Alerts are the names of the alerts routed to the receiver, such as
+	// SecretsManagementCertificateExpiring. Every SecretsManagement alert when empty.
+	// +optional
+	Alerts []string `json:"alerts,omitempty"`
+
+	// Email configures an Email receiver
+	// +optional
+	Email *EmailAlertReceiver `json:"email,omitempty"`
+
+	// PagerDuty configures a PagerDuty receiver
+	// +optional
+	PagerDuty *PagerDutyAlertReceiver `json:"pagerDuty,omitempty"`
+
+	// Webhook configures a Webhook receiver
+	// +optional
+	Webhook *WebhookAlertReceiver `json:"webhook,omitempty"`
+}
+
+// EmailAlertReceiver sends alerts to an email address
+type EmailAlertReceiver struct {
+	// To is the address alerts are sent to
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+}
+
+// PagerDutyAlertReceiver sends alerts to a PagerDuty service
+type PagerDutyAlertReceiver struct {
+	// RoutingKeySecret names a Secret in the plugin namespace whose "routingKey" key holds the
+	// integration key of the PagerDuty service
+	// +kubebuilder:validation:MinLength=1
+	RoutingKeySecret string `json:"routingKeySecret"`
+}
+
+// WebhookAlertReceiver POSTs alerts to an HTTP endpoint
+type WebhookAlertReceiver struct {
+	// URL alerts are POSTed to
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+}
+
+// BackupConfig exports the secrets management configuration on a schedule for disaster recovery
+type BackupConfig struct {
+	// Schedule is a cron schedule, such as "0 2 * * *", on which a CronJob in the plugin
+	// namespace exports the SecretsManagementConfigs, the External Secrets Operator stores, the
+	// cert-manager issuers and the SecretProviderClasses to a bundle. Secret data is never
+	// exported. No backups are taken while it is empty. Only honored on the cluster config.
+	// +kubebuilder:validation:MaxLength=100
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Destination is where the bundles are written, Secrets in the plugin namespace by default
+	// +optional
+	Destination BackupDestination `json:"destination,omitempty"`
+
+	// Velero prepares the plugin namespace for OADP backups and restores
+	// +optional
+	Velero VeleroConfig `json:"velero,omitempty"`
+}
+
+// VeleroConfig keeps Velero backups and restores of the plugin namespace from racing the operator
+type VeleroConfig struct {
+	// Enabled labels every object the operator manages with
+	// secrets-management.openshift.io/backup-tier, "regenerated" for the objects rebuilt from the
+	// config and "bundle" for backup bundle Secrets, and pauses reconciling while a Velero Backup or
+	// Restore covering the plugin namespace is in progress
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Namespace Velero runs in, where its Backup and Restore objects are created. Defaults to
+	// openshift-adp, the namespace of the OpenShift API for Data Protection.
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// ExcludeRegenerated also labels the regenerated tier velero.io/exclude-from-backup=true, so
+	// backups only hold what the operator cannot recreate
+	// +optional
+	ExcludeRegenerated bool `json:"excludeRegenerated,omitempty"`
+}
+
+// BackupDestinationType is the kind of storage backup bundles are written to
+// +kubebuilder:validation:Enum=Secret;ObjectStorage
+type BackupDestinationType string
+
+const (
+	// BackupDestinationSecret writes each bundle to a Secret in the plugin namespace
+	BackupDestinationSecret BackupDestinationType = "Secret"
+
+	// BackupDestinationObjectStorage uploads each bundle to an S3 compatible bucket
+	BackupDestinationObjectStorage BackupDestinationType = "ObjectStorage"
+)
+
+// BackupDestination is where backup bundles are written
+type BackupDestination struct {
+	// Type selects Secrets in the plugin namespace or objectStorage. Defaults to Secret.
+	// +kubebuilder:default=Secret
+	// +optional
+	Type BackupDestinationType `json:"type,omitempty"`
+
+	// Retain is how many bundle Secrets are kept; older ones are deleted after each backup.
+	// Buckets keep every bundle, so use a lifecycle rule to expire them. Defaults to 7.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Retain int32 `json:"retain,omitempty"`
+
+	// ObjectStorage configures an ObjectStorage destination
+	// +optional
+	ObjectStorage *ObjectStorageBackupDestination `json:"objectStorage,omitempty"`
+}
+
+// ObjectStorageBackupDestination uploads backup bundles to an S3 compatible bucket
+type ObjectStorageBackupDestination struct {
+	// Endpoint is the URL of the S3 API, such as https://s3.us-east-1.amazonaws.com. Objects are
+	// addressed path-style, as <endpoint>/<bucket>/<prefix><bundle>.
+	// +kubebuilder:validation:Pattern=`^https://`
+	Endpoint string `json:"endpoint"`
+
+	// Bucket the bundles are uploaded to
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	Bucket string `json:"bucket"`
+
+	// Region the requests are signed for. Defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Prefix is prepended to the object name of each bundle, such as "clusters/prod/"
+	// +kubebuilder:validation:MaxLength=512
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// CredentialsSecret names a Secret in the plugin namespace holding the "access-key-id" and
+	// "secret-access-key" keys the uploads are signed with
+	// +kubebuilder:validation:MinLength=1
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// HubConfig makes the operator on an ACM hub cluster distribute the config to managed clusters
+// and aggregate their state
+type HubConfig struct {
+	// Enabled creates a ManifestWork in the namespace of each selected ManagedCluster that applies
+	// this config, without spec.hub, to the cluster. The operator installed there reports its
+	// detected operators and secrets health back through the ManifestWork status feedback, which
+	// is aggregated into status.fleet. Only honored on the cluster config.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ClusterSelector selects the ManagedClusters by label; every managed cluster when empty
+	// +optional
+	ClusterSelector map[string]string `json:"clusterSelector,omitempty"`
+}
+
+// IssuerConfig declares a cert-manager ClusterIssuer for the operator to create. Secrets it
+// names are read by cert-manager from its cluster resource namespace, cert-manager by default.
+type IssuerConfig struct {
+	// Name of the ClusterIssuer
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Type selects which of acme, ca or vault is used
+	Type IssuerType `json:"type"`
+
+	// ACME configures an ACME issuer
+	// +optional
+	ACME *ACMEIssuerConfig `json:"acme,omitempty"`
+
+	// CA configures a CA issuer
+	// +optional
+	CA *CAIssuerConfig `json:"ca,omitempty"`
+
+	// Vault configures a Vault issuer
+	// +optional
+	Vault *VaultIssuerConfig `json:"vault,omitempty"`
+}
+
+// SecretsManagementConfigSpec defines the desired state of SecretsManagementConfig
+type SecretsManagementConfigSpec struct {
+	// Features defines UI feature toggles
+	Features FeaturesConfig `json:"features,omitempty"`
+
+	// RBAC defines RBAC resources managed by the operator
+	RBAC RBACConfig `json:"rbac,omitempty"`
+
+	// Visibility limits the namespaces the console plugin shows secrets-management resources for
+	Visibility VisibilityConfig `json:"visibility,omitempty"`
+
+	// Plugin defines the console plugin deployment settings
+	Plugin PluginConfig `json:"plugin,omitempty"`
+
+	// Operators defines per-operator configuration
+	Operators OperatorsConfig `json:"operators,omitempty"`
+
+	// Protection guards managed ClusterRoles and plugin resources against modification
+	Protection ProtectionConfig `json:"protection,omitempty"`
+
+	// Policies enforces organization rules for secrets through Gatekeeper, Kyverno or
+	// ValidatingAdmissionPolicy
+	// +optional
+	Policies PoliciesConfig `json:"policies,omitempty"`
+
+	// Audit records create, edit and delete operations performed through the console plugin
+	Audit AuditConfig `json:"audit,omitempty"`
+
+	// Notifications sends alerts about expiring certificates, failing secret syncs and plugin health
+	Notifications NotificationsConfig `json:"notifications,omitempty"`
+
+	// Stores are External Secrets Operator ClusterSecretStores the operator creates and keeps in
+	// sync. Stores removed from the list are deleted.
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Stores []SecretStoreConfig `json:"stores,omitempty"`
+
+	// CloudAuth annotates the External Secrets Operator and CSI provider service accounts for
+	// cloud workload identity and validates the federation. Only honored on the cluster config.
+	// +optional
+	CloudAuth CloudAuthConfig `json:"cloudAuth,omitempty"`
+
+	// Issuers are cert-manager ClusterIssuers the operator creates and keeps in sync. Issuers
+	// removed from the list are deleted.
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Issuers []IssuerConfig `json:"issuers,omitempty"`
+
+	// Compliance writes a scored report of secret-related controls for the console and the
+	// Compliance Operator
+	// +optional
+	Compliance ComplianceConfig `json:"compliance,omitempty"`
+
+	// Scan moves the compliance scan out of the operator process into a scheduled CronJob
+	// +optional
+	Scan ScanConfig `json:"scan,omitempty"`
+
+	// Telemetry opts in to reporting anonymized usage, so the integrations in use can be prioritized
+	// +optional
+	Telemetry TelemetryConfig `json:"telemetry,omitempty"`
+
+	// Hub distributes this config to ACM managed clusters and aggregates their state, for a
+	// fleet-wide view in the hub's console
+	// +optional
+	Hub HubConfig `json:"hub,omitempty"`
+
+	// ResourceMetrics exports the state of individual secret management resources as metrics,
+	// such as the time left before each Certificate expires
+	// +optional
+	ResourceMetrics ResourceMetricsConfig `json:"resourceMetrics,omitempty"`
+
+	// Monitoring routes the secrets management alerts to the teams owning them
+	// +optional
+	Monitoring MonitoringConfig `json:"monitoring,omitempty"`
+
+	// Backup exports the configuration on a schedule, for restoring it with smcctl restore
+	// +optional
+	Backup BackupConfig `json:"backup,omitempty"`
+
+	// CommonLabels are added to every object the operator manages and to the plugin pods, for
+	// example for cost attribution or backup selectors. Labels the operator sets itself take
+	// precedence, and labels removed from the list are removed from the objects.
+	// +optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// CommonAnnotations are added to every object the operator manages, for example Argo CD
+	// sync waves or sync options. Annotations the operator sets itself take precedence, and
+	// annotations removed from the list are removed from the objects.
+	// +optional
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+
+	// ReconcileInterval is how often the operator re-reconciles to refresh operator detection.
+	// Overrides the operator's --reconcile-interval flag when set.
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+}
+
+// ClusterRoleStatus represents a ClusterRole created by the operator
+type ClusterRoleStatus struct {
+	// Name of the ClusterRole
+	Name string `json:"name,omitempty"`
+
+	// Operations this role grants (e.g., "view", "delete", "admin")
+	Operations []string `json:"operations,omitempty"`
+
+	// Created is the timestamp when the role was created
+	Created metav1.Time `json:"created,omitempty"`
+}
+
+// RBACStatus represents the status of RBAC resources
+type RBACStatus struct {
+	// ClusterRoles created by the operator
+	ClusterRoles []ClusterRoleStatus `json:"clusterRoles,omitempty"`
+
+	// Bindings summarizes who is bound to each ClusterRole created by the operator
+	Bindings []RoleBindingsStatus `json:"bindings,omitempty"`
+}
+
+// RoleBindingsStatus summarizes the ClusterRoleBindings and RoleBindings referencing a ClusterRole
+// created by the operator. In restricted mode only RoleBindings in the plugin namespace are counted.
+type RoleBindingsStatus struct {
+	// ClusterRole is the name of the ClusterRole
+	ClusterRole string `json:"clusterRole"`
+
+	// ClusterRoleBindings is the number of ClusterRoleBindings referencing the role
+	ClusterRoleBindings int32 `json:"clusterRoleBindings"`
+
+	// RoleBindings is the number of RoleBindings referencing the role
+	RoleBindings int32 `json:"roleBindings"`
+
+	// TotalSubjects is the number of distinct subjects bound to the role
+	TotalSubjects int32 `json:"totalSubjects"`
+
+	// Subjects bound to the role, sorted by kind, namespace and name. At most 50 are listed.
+	// +optional
+	Subjects []BindingSubjectStatus `json:"subjects,omitempty"`
+}
+
+// BindingSubjectStatus is a subject bound to a ClusterRole created by the operator
+type BindingSubjectStatus struct {
+	// Kind of the subject: User, Group or ServiceAccount
+	Kind string `json:"kind"`
+
+	// Name of the subject
+	Name string `json:"name"`
+
+	// Namespace of a ServiceAccount subject
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Bindings is the number of bindings granting the role to the subject
+	Bindings int32 `json:"bindings"`
+
+	// ClusterWide is set when one of those bindings is a ClusterRoleBinding
+	// +optional
+	ClusterWide bool `json:"clusterWide,omitempty"`
+}
+
+// ResolvedConfigurationStatus reports the values the operator applies, with the defaults it
+// filled in for fields the spec leaves unset
+type ResolvedConfigurationStatus struct {
+	// Image is the plugin image set on the Deployment, after selecting the image of the node
+	// architecture
+	Image string `json:"image,omitempty"`
+
+	// ImagePullPolicy is the pull policy of the plugin container
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+
+	// Replicas is the number of plugin replicas
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Port is the HTTPS port the plugin serves on
+	Port int32 `json:"port,omitempty"`
+
+	// Resources are the requests and limits of the plugin container
+	Resources ResourceConfig `json:"resources,omitempty"`
+
+	// Strategy is the update strategy type of the plugin Deployment
+	Strategy string `json:"strategy,omitempty"`
+
+	// RolePrefix is the prefix of the generated RBAC resource names
+	RolePrefix string `json:"rolePrefix,omitempty"`
+}
+
+// PluginStatus represents the status of the console plugin deployment
+type PluginStatus struct {
+	// DeploymentName is the name of the plugin Deployment
+	DeploymentName string `json:"deploymentName,omitempty"`
+
+	// ServiceName is the name of the plugin Service
+	ServiceName string `json:"serviceName,omitempty"`
+
+	// ConsolePluginName is the name of the ConsolePlugin CR
+	ConsolePluginName string `json:"consolePluginName,omitempty"`
+
+	// AvailableReplicas is the number of available replicas
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+	// Ready indicates whether the plugin is ready
+	Ready bool `json:"ready,omitempty"`
+
+	// ResolvedImage is the image the nodes will actually pull, after applying
+	// ImageDigestMirrorSet / ImageContentSourcePolicy mirror configuration
+	ResolvedImage string `json:"resolvedImage,omitempty"`
+
+	// RouteHost is the host admitted for the plugin Route, when enabled
+	RouteHost string `json:"routeHost,omitempty"`
+}
+
+// FeaturesStatus reports the UI feature toggles served by the plugin pods. A change to
+// spec.features restarts the pods; it has taken effect once UpToDate is true.
+type FeaturesStatus struct {
+	// Delete reports the delete operation settings the plugin pods serve
+	Delete ServedFeature `json:"delete"`
+
+	// Create reports the create operation settings the plugin pods serve
+	Create ServedFeature `json:"create"`
+
+	// Edit reports the edit operation settings the plugin pods serve
+	Edit ServedFeature `json:"edit"`
+
+	// ReadOnly is whether the plugin pods serve the read-only mode
+	ReadOnly bool `json:"readOnly"`
+
+	// Hash identifies the served feature set; the plugin pod template carries it in the
+	// features-hash annotation
+	Hash string `json:"hash,omitempty"`
+
+	// UpToDate is false while a change to spec.features is rolling out to the plugin pods
+	UpToDate bool `json:"upToDate,omitempty"`
+
+	// AppliedTime is when the plugin pods finished rolling out the served feature set
+	AppliedTime *metav1.Time `json:"appliedTime,omitempty"`
+}
+
+// ServedFeature reports the settings of a UI feature the plugin pods run with. Unlike
+// FeatureConfig, false values are kept rather than defaulted.
+type ServedFeature struct {
+	// Enabled is whether the feature is switched on
+	Enabled bool `json:"enabled"`
+
+	// CheckRBAC is whether the UI checks user RBAC for the feature
+	CheckRBAC bool `json:"checkRBAC"`
+}
+
+// DetectedOperator represents the detection status of an operator
+type DetectedOperator struct {
+	// Installed indicates whether the operator's CRDs are installed
+	Installed bool `json:"installed,omitempty"`
+
+	// Version is the detected operator version
+	Version string `json:"version,omitempty"`
+
+	// ReleaseVersion is the operator release, read from the app.kubernetes.io/version label of its
+	// CRD when the operator sets it
+	ReleaseVersion string `json:"releaseVersion,omitempty"`
+
+	// SupportLevel tells whether the console supports the installed operator. Unsupported when its
+	// CRD does not serve the API version the console uses or its release is older than the oldest
+	// supported one; Unknown when the release cannot be read.
+	// +optional
+	SupportLevel SupportLevel `json:"supportLevel,omitempty"`
+}
+
+// SupportLevel is whether the console supports an installed operator
+// +kubebuilder:validation:Enum=Supported;Unsupported;Unknown
+type SupportLevel string
+
+const (
+	// SupportLevelSupported means the operator meets every requirement of the console
+	SupportLevelSupported SupportLevel = "Supported"
+
+	// SupportLevelUnsupported means the operator is older than the console supports
+	SupportLevelUnsupported SupportLevel = "Unsupported"
+
+	// SupportLevelUnknown means the operator serves the API the console uses, but its release is unknown
+	SupportLevelUnknown SupportLevel = "Unknown"
+)
+
+// CSIDaemonSetStatus reports the health of a Secrets Store CSI driver or provider DaemonSet
+type CSIDaemonSetStatus struct {
+	// Provider is vault, aws, azure or gcp; empty for the driver
+	Provider string `json:"provider,omitempty"`
+
+	// Namespace of the DaemonSet
+	Namespace string `json:"namespace"`
+
+	// Name of the DaemonSet
+	Name string `json:"name"`
+
+	// DesiredNumberScheduled is the number of nodes that should run the DaemonSet's pod
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+
+	// NumberReady is the number of nodes running a ready pod
+	NumberReady int32 `json:"numberReady"`
+
+	// Ready is true when a ready pod runs on every scheduled node
+	Ready bool `json:"ready"`
+}
+
+// SecretsStoreCSIStatus reports the Secrets Store CSI driver and the provider DaemonSets found on
+// the cluster, so only configured providers are offered
+type SecretsStoreCSIStatus struct {
+	DetectedOperator `json:",inline"`
+
+	// Driver is the CSI driver DaemonSet, when found
+	// +optional
+	Driver *CSIDaemonSetStatus `json:"driver,omitempty"`
+
+	// Providers are the provider DaemonSets found, one per provider
+	// +optional
+	Providers []CSIDaemonSetStatus `json:"providers,omitempty"`
+}
+
+// DetectedOperatorsStatus represents the status of detected operators
+type DetectedOperatorsStatus struct {
+	// CertManager detection status
+	CertManager DetectedOperator `json:"certManager,omitempty"`
+
+	// ExternalSecrets detection status
+	ExternalSecrets DetectedOperator `json:"externalSecrets,omitempty"`
+
+	// SecretsStoreCSI detection status
+	SecretsStoreCSI SecretsStoreCSIStatus `json:"secretsStoreCSI,omitempty"`
+}
+
+// SecretProviderClassReference counts the pods mounting a SecretProviderClass through the CSI driver
+type SecretProviderClassReference struct {
+	// Namespace of the SecretProviderClass
+	Namespace string `json:"namespace"`
+
+	// Name of the SecretProviderClass
+	Name string `json:"name"`
+
+	// Pods is the number of pods with a CSI volume referencing the SecretProviderClass
+	Pods int32 `json:"pods"`
+}
+
+// SecretProviderClassUsageStatus aggregates how SecretProviderClasses are used by pods
+type SecretProviderClassUsageStatus struct {
+	// Total is the number of SecretProviderClasses in the cluster
+	Total int32 `json:"total,omitempty"`
+
+	// InUse is the number of SecretProviderClasses referenced by at least one pod.
+	// Deleting one of them breaks its pods the next time they restart.
+	InUse int32 `json:"inUse,omitempty"`
+
+	// InUseBy lists the referenced SecretProviderClasses with the most pods first
+	// +kubebuilder:validation:MaxItems=50
+	InUseBy []SecretProviderClassReference `json:"inUseBy,omitempty"`
+
+	// Missing lists SecretProviderClasses that pods reference but that no longer exist
+	// +kubebuilder:validation:MaxItems=50
+	Missing []SecretProviderClassReference `json:"missing,omitempty"`
+
+	// MountFailures aggregates pods that cannot start because the CSI driver has not mounted
+	// their SecretProviderClasses
+	MountFailures SecretProviderClassMountFailures `json:"mountFailures,omitempty"`
+}
+
+// SecretProviderClassMountFailures aggregates pending pods whose SecretProviderClass volumes the
+// Secrets Store CSI driver has not mounted, with the errors the kubelet reported for them
+type SecretProviderClassMountFailures struct {
+	// Pods is the number of pods pending for more than two minutes without a mounted
+	// SecretProviderClassPodStatus for each of their SecretProviderClasses
+	Pods int32 `json:"pods,omitempty"`
+
+	// Namespaces lists the namespaces of those pods in alphabetical order
+	// +kubebuilder:validation:MaxItems=50
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// TopErrors lists the most common FailedMount event messages for those pods, most pods first
+	// +kubebuilder:validation:MaxItems=5
+	TopErrors []MountErrorCount `json:"topErrors,omitempty"`
+}
+
+// MountErrorCount counts the pods reporting a mount error
+type MountErrorCount struct {
+	// Message is the error from the FailedMount event, without the volume and pod names
+	Message string `json:"message"`
+
+	// Pods is the number of pods with a FailedMount event carrying the message
+	Pods int32 `json:"pods"`
+}
+
+// ManagedResource describes an object created and owned by the operator
+type ManagedResource struct {
+	// Kind of the managed object
+	Kind string `json:"kind"`
+
+	// Name of the managed object
+	Name string `json:"name"`
+
+	// Namespace of the managed object, empty for cluster-scoped objects
+	Namespace string `json:"namespace,omitempty"`
+
+	// Health is the observed health of the managed object
+	Health ResourceHealth `json:"health"`
+
+	// LastAppliedHash is a hash of the object's desired content as last observed on the cluster
+	LastAppliedHash string `json:"lastAppliedHash,omitempty"`
+}
+
+// ResourceHealth represents the health of a managed resource
+// +kubebuilder:validation:Enum=Healthy;Progressing;Missing
+type ResourceHealth string
+
+const (
+	// ResourceHealthy indicates the object exists and is serving
+	ResourceHealthy ResourceHealth = "Healthy"
+
+	// ResourceProgressing indicates the object exists but is not fully available yet
+	ResourceProgressing ResourceHealth = "Progressing"
+
+	// ResourceMissing indicates the object was expected but not found
+	ResourceMissing ResourceHealth = "Missing"
+)
+
+// PhaseTransition records a change of the overall phase
+type PhaseTransition struct {
+	// Phase entered
+	Phase ConfigPhase `json:"phase"`
+
+	// Time the phase was entered
+	Time metav1.Time `json:"time"`
+
+	// Message explains the transition, e.g. the error that caused it
+	Message string `json:"message,omitempty"`
+}
+
+// ConfigPhase represents the phase of the SecretsManagementConfig
+// +kubebuilder:validation:Enum=Pending;Deploying;Ready;Degraded;Error;Ignored
+type ConfigPhase string
+
+const (
+	// PhasePending indicates the config is pending processing
+	PhasePending ConfigPhase = "Pending"
+
+	// PhaseDeploying indicates resources are being deployed
+	PhaseDeploying ConfigPhase = "Deploying"
+
+	// PhaseReady indicates everything is ready
+	PhaseReady ConfigPhase = "Ready"
+
+	// PhaseDegraded indicates some components are not fully functional
+	PhaseDegraded ConfigPhase = "Degraded"
+
+	// PhaseError indicates an error occurred
+	PhaseError ConfigPhase = "Error"
+
+	// PhaseIgnored indicates the config is not the singleton and is not reconciled
+	PhaseIgnored ConfigPhase = "Ignored"
+)
+
+// ConditionType represents a condition type for SecretsManagementConfig
+type ConditionType string
+
+const (
+	// ConditionPluginDeployed indicates the plugin deployment status
+	ConditionPluginDeployed ConditionType = "PluginDeployed"
+
+	// ConditionRBACConfigured indicates the RBAC configuration status
+	ConditionRBACConfigured ConditionType = "RBACConfigured"
+
+	// ConditionConsolePluginRegistered indicates the ConsolePlugin CR status
+	ConditionConsolePluginRegistered ConditionType = "ConsolePluginRegistered"
+
+	// ConditionNamespaceReady indicates the plugin namespace exists and is active
+	ConditionNamespaceReady ConditionType = "NamespaceReady"
+
+	// ConditionServiceReady indicates the plugin Service is configured
+	ConditionServiceReady ConditionType = "ServiceReady"
+
+	// ConditionDeploymentAvailable indicates the plugin Deployment has available replicas
+	ConditionDeploymentAvailable ConditionType = "DeploymentAvailable"
+
+	// ConditionProgressing is True while the plugin Deployment rolls out its current generation
+	ConditionProgressing ConditionType = "Progressing"
+
+	// ConditionCertSecretPresent indicates the serving certificate Secret for the plugin exists
+	ConditionCertSecretPresent ConditionType = "CertSecretPresent"
+
+	// ConditionAccessReconciled indicates the generated roles, bindings and namespace visibility
+	// are up to date
+	ConditionAccessReconciled ConditionType = "AccessReconciled"
+
+	// ConditionPluginReconciled indicates the plugin Deployment, Service, Route and ConsolePlugin
+	// are up to date
+	ConditionPluginReconciled ConditionType = "PluginReconciled"
+
+	// ConditionPoliciesReconciled indicates the admission policies, alert routing and backup
+	// schedule are up to date
+	ConditionPoliciesReconciled ConditionType = "PoliciesReconciled"
+
+	// ConditionOperatorsDetected indicates the installed operators were detected
+	ConditionOperatorsDetected ConditionType = "OperatorsDetected"
+
+	// ConditionIntegrationsReconciled indicates the secret stores, issuers, audit sinks, managed
+	// clusters and the other integrations are up to date
+	ConditionIntegrationsReconciled ConditionType = "IntegrationsReconciled"
+
+	// ConditionWaitingForServingCert is True while the plugin pods cannot start because the serving
+	// certificate Secret has not been issued
+	ConditionWaitingForServingCert ConditionType = "WaitingForServingCert"
+
+	// ConditionProtectionConfigured indicates the admission policy protecting managed resources is in place
+	ConditionProtectionConfigured ConditionType = "ProtectionConfigured"
+
+	// ConditionDeletionProtectionConfigured indicates the admission policy guarding protected secrets is in place
+	ConditionDeletionProtectionConfigured ConditionType = "DeletionProtectionConfigured"
+
+	// ConditionReadOnlyEnforced indicates the admission policy rejecting writes in read-only mode is in place
+	ConditionReadOnlyEnforced ConditionType = "ReadOnlyEnforced"
+
+	// ConditionAccessRequestApprovalEnforced indicates the admission policy guarding the approval of SecretsAccessRequests is in place
+	ConditionAccessRequestApprovalEnforced ConditionType = "AccessRequestApprovalEnforced"
+
+	// ConditionSecretProviderClassesAvailable indicates every SecretProviderClass mounted by pods exists
+	// and the CSI driver mounted it
+	ConditionSecretProviderClassesAvailable ConditionType = "SecretProviderClassesAvailable"
+
+	// ConditionDuplicateConfig indicates the config is not the singleton and is ignored
+	ConditionDuplicateConfig ConditionType = "DuplicateConfig"
+
+	// ConditionAuditSinksHealthy indicates whether audit records reach the configured sinks
+	ConditionAuditSinksHealthy ConditionType = "AuditSinksHealthy"
+
+	// ConditionSecretStoresReady indicates whether the ClusterSecretStores in spec.stores are ready
+	ConditionSecretStoresReady ConditionType = "SecretStoresReady"
+
+	// ConditionIssuersReady indicates whether the ClusterIssuers in spec.issuers are ready
+	ConditionIssuersReady ConditionType = "IssuersReady"
+
+	// ConditionPushSecretsSynced indicates whether every PushSecret pushes to its external store
+	ConditionPushSecretsSynced ConditionType = "PushSecretsSynced"
+
+	// ConditionClusterExternalSecretsProvisioned indicates whether every namespace targeted by a
+	// ClusterExternalSecret has its ExternalSecret provisioned and syncing
+	ConditionClusterExternalSecretsProvisioned ConditionType = "ClusterExternalSecretsProvisioned"
+
+	// ConditionVaultReachable indicates whether every Vault server used by a ClusterSecretStore or
+	// SecretProviderClass answers its health probe unsealed and accepts the stores' tokens
+	ConditionVaultReachable ConditionType = "VaultReachable"
+
+	// ConditionStoreCredentialsValid indicates whether the cloud providers accept the credentials of
+	// the AWS, AzureKeyVault and GCPSecretManager stores in spec.stores and none expires soon
+	ConditionStoreCredentialsValid ConditionType = "StoreCredentialsValid"
+
+	// ConditionCloudAuthFederated indicates whether the service accounts in spec.cloudAuth are
+	// annotated and the cloud provider exchanges their tokens for credentials
+	ConditionCloudAuthFederated ConditionType = "CloudAuthFederated"
+
+	// ConditionSecretsEncryptedAtRest indicates whether etcd encrypts Secrets at rest
+	ConditionSecretsEncryptedAtRest ConditionType = "SecretsEncryptedAtRest"
+
+	// ConditionPoliciesConfigured indicates the policy bundle from spec.policies is in place
+	ConditionPoliciesConfigured ConditionType = "PoliciesConfigured"
+
+	// ConditionFleetReporting indicates every managed cluster selected by spec.hub reports status
+	ConditionFleetReporting ConditionType = "FleetReporting"
+
+	// ConditionRulesOverridden indicates the generated ClusterRoles carry the rules patches from spec.rbac.rulesOverrideConfigMap
+	ConditionRulesOverridden ConditionType = "RulesOverridden"
+
+	// ConditionRBACDrifted indicates a generated ClusterRole was changed on the cluster and the operator reverted it
+	ConditionRBACDrifted ConditionType = "RBACDrifted"
+
+	// ConditionAlertRoutingConfigured indicates the AlertmanagerConfig of spec.monitoring.alertReceivers is in place
+	ConditionAlertRoutingConfigured ConditionType = "AlertRoutingConfigured"
+
+	// ConditionBackupScheduled indicates the CronJob of spec.backup is in place
+	ConditionBackupScheduled ConditionType = "BackupScheduled"
+
+	// ConditionReconcilePaused indicates the operator holds off writes while Velero works on the plugin namespace
+	ConditionReconcilePaused ConditionType = "ReconcilePaused"
+
+	// ConditionRestoreVerified indicates the stores and issuers reconnected after the last restore
+	ConditionRestoreVerified ConditionType = "RestoreVerified"
+
+	// ConditionDegraded indicates an installed operator is older than the console supports
+	ConditionDegraded ConditionType = "Degraded"
+)
+
+// Condition reasons. These are stable and may be relied on by external tooling.
+const (
+	// ReasonNamespaceActive means the plugin namespace exists and is active
+	ReasonNamespaceActive = "NamespaceActive"
+
+	// ReasonNamespaceTerminating means the plugin namespace is being deleted
+	ReasonNamespaceTerminating = "NamespaceTerminating"
+
+	// ReasonNamespaceUnmanaged means the operator runs in restricted mode and does not manage the namespace
+	ReasonNamespaceUnmanaged = "NamespaceUnmanaged"
+
+	// ReasonServiceConfigured means the plugin Service matches the desired spec
+	ReasonServiceConfigured = "ServiceConfigured"
+
+	// ReasonMinimumReplicasAvailable means the plugin Deployment has available replicas
+	ReasonMinimumReplicasAvailable = "MinimumReplicasAvailable"
+
+	// ReasonMinimumReplicasUnavailable means the plugin Deployment has no available replicas yet
+	ReasonMinimumReplicasUnavailable = "MinimumReplicasUnavailable"
+
+	// ReasonReplicaSetUpdating means the plugin Deployment is replacing pods with its new ReplicaSet
+	ReasonReplicaSetUpdating = "ReplicaSetUpdating"
+
+	// ReasonNewReplicaSetAvailable means every plugin replica runs the current generation and is available
+	ReasonNewReplicaSetAvailable = "NewReplicaSetAvailable"
+
+	// ReasonProgressDeadlineExceeded means the plugin rollout made no progress within the Deployment's
+	// progressDeadlineSeconds
+	ReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+	// ReasonConsolePluginRegistered means the ConsolePlugin CR matches the desired spec
+	ReasonConsolePluginRegistered = "ConsolePluginRegistered"
+
+	// ReasonConsolePluginAPIUnavailable means the ConsolePlugin API is not served by the cluster
+	ReasonConsolePluginAPIUnavailable = "ConsolePluginAPIUnavailable"
+
+	// ReasonCertSecretFound means the serving certificate Secret exists
+	ReasonCertSecretFound = "CertSecretFound"
+
+	// ReasonCertSecretNotFound means the serving certificate Secret has not been issued yet
+	ReasonCertSecretNotFound = "CertSecretNotFound"
+
+	// ReasonSelfSignedCertIssued means the operator wrote a self-signed serving certificate after
+	// the service CA did not issue one in time
+	ReasonSelfSignedCertIssued = "SelfSignedCertIssued"
+
+	// ReasonProtectionPolicyApplied means the ValidatingAdmissionPolicy and its binding match the desired spec
+	ReasonProtectionPolicyApplied = "ProtectionPolicyApplied"
+
+	// ReasonProtectionDisabled means spec.protection.enabled is false and no policy is installed
+	ReasonProtectionDisabled = "ProtectionDisabled"
+
+	// ReasonAdmissionPolicyAPIUnavailable means ValidatingAdmissionPolicy is not served by the cluster
+	ReasonAdmissionPolicyAPIUnavailable = "AdmissionPolicyAPIUnavailable"
+
+	// ReasonSecretProviderClassesResolved means every SecretProviderClass referenced by a pod exists
+	ReasonSecretProviderClassesResolved = "SecretProviderClassesResolved"
+
+	// ReasonSecretProviderClassMissing means pods reference a SecretProviderClass that was deleted
+	ReasonSecretProviderClassMissing = "SecretProviderClassMissing"
+
+	// ReasonSecretProviderClassMountFailed means pods are pending because the CSI driver failed to mount their SecretProviderClasses
+	ReasonSecretProviderClassMountFailed = "SecretProviderClassMountFailed"
+
+	// ReasonPushSecretsSynced means every PushSecret reports Ready
+	ReasonPushSecretsSynced = "PushSecretsSynced"
+
+	// ReasonPushSecretSyncFailed means at least one PushSecret reports Ready False
+	ReasonPushSecretSyncFailed = "PushSecretSyncFailed"
+
+	// ReasonClusterExternalSecretsProvisioned means every namespace targeted by a
+	// ClusterExternalSecret has a syncing ExternalSecret
+	ReasonClusterExternalSecretsProvisioned = "ClusterExternalSecretsProvisioned"
+
+	// ReasonNamespacesLagging means a ClusterExternalSecret failed to provision a namespace or
+	// its ExternalSecret there reports Ready False
+	ReasonNamespacesLagging = "NamespacesLagging"
+
+	// ReasonVaultReachable means every probed Vault server is reachable and unsealed
+	ReasonVaultReachable = "VaultReachable"
+
+	// ReasonVaultUnreachable means a Vault server did not answer its health endpoint
+	ReasonVaultUnreachable = "VaultUnreachable"
+
+	// ReasonVaultSealed means a Vault server reports it is sealed or not initialized
+	ReasonVaultSealed = "VaultSealed"
+
+	// ReasonVaultTokenRejected means a Vault server rejected the token of a ClusterSecretStore
+	ReasonVaultTokenRejected = "VaultTokenRejected"
+
+	// ReasonCredentialsValid means every checked store's credentials were accepted
+	ReasonCredentialsValid = "CredentialsValid"
+
+	// ReasonCredentialsInvalid means a cloud provider rejected the credentials of a store
+	ReasonCredentialsInvalid = "CredentialsInvalid"
+
+	// ReasonCredentialsExpiring means the credentials of a store expire within 14 days
+	ReasonCredentialsExpiring = "CredentialsExpiring"
+
+	// ReasonCloudAuthFederated means every service account in spec.cloudAuth is annotated and its
+	// token was accepted by the cloud provider
+	ReasonCloudAuthFederated = "CloudAuthFederated"
+
+	// ReasonServiceAccountNotFound means a service account in spec.cloudAuth does not exist
+	ReasonServiceAccountNotFound = "ServiceAccountNotFound"
+
+	// ReasonFederationRejected means the cloud provider rejected the token of a service account
+	ReasonFederationRejected = "FederationRejected"
+
+	// ReasonFederationUnverified means the service accounts are annotated but the federation could
+	// not be validated, because the provider was unreachable or is not fully configured
+	ReasonFederationUnverified = "FederationUnverified"
+
+	// ReasonCloudAuthUnavailable means spec.cloudAuth is set while the operator runs in restricted mode
+	ReasonCloudAuthUnavailable = "CloudAuthUnavailable"
+
+	// ReasonSecretsStoreCSINotInstalled means the Secrets Store CSI driver CRDs are not installed
+	ReasonSecretsStoreCSINotInstalled = "SecretsStoreCSINotInstalled"
+
+	// ReasonNotSingleton means the config is not named "cluster" and is ignored
+	ReasonNotSingleton = "NotSingleton"
+
+	// ReasonReconcileError means the last reconcile loop failed; status.lastError holds the error
+	ReasonReconcileError = "ReconcileError"
+
+	// ReasonAuditSinksDelivering indicates the last delivery to every sink succeeded
+	ReasonAuditSinksDelivering = "AuditSinksDelivering"
+
+	// ReasonAuditSinkFailing indicates at least one sink is failing deliveries
+	ReasonAuditSinkFailing = "AuditSinkFailing"
+
+	// ReasonAuditSinksNotConfigured indicates spec.audit has no sinks
+	ReasonAuditSinksNotConfigured = "AuditSinksNotConfigured"
+
+	// ReasonSecretStoresReady indicates every ClusterSecretStore in spec.stores is ready
+	ReasonSecretStoresReady = "SecretStoresReady"
+
+	// ReasonSecretStoreNotReady indicates a ClusterSecretStore is invalid or cannot reach its provider
+	ReasonSecretStoreNotReady = "SecretStoreNotReady"
+
+	// ReasonSecretStoresNotConfigured indicates spec.stores is empty
+	ReasonSecretStoresNotConfigured = "SecretStoresNotConfigured"
+
+	// ReasonExternalSecretsNotInstalled indicates the External Secrets Operator CRDs are not installed
+	ReasonExternalSecretsNotInstalled = "ExternalSecretsNotInstalled"
+
+	// ReasonIssuersReady indicates every ClusterIssuer in spec.issuers is ready
+	ReasonIssuersReady = "IssuersReady"
+
+	// ReasonIssuerNotReady indicates a ClusterIssuer is invalid or not ready
+	ReasonIssuerNotReady = "IssuerNotReady"
+
+	// ReasonIssuersNotConfigured indicates spec.issuers is empty
+	ReasonIssuersNotConfigured = "IssuersNotConfigured"
+
+	// ReasonCertManagerNotInstalled indicates the cert-manager CRDs are not installed
+	ReasonCertManagerNotInstalled = "CertManagerNotInstalled"
+
+	// ReasonEtcdEncryptionEnabled indicates etcd encrypts Secrets with the configured type
+	ReasonEtcdEncryptionEnabled = "EtcdEncryptionEnabled"
+
+	// ReasonEtcdEncryptionDisabled indicates the APIServer config sets no encryption, or identity
+	ReasonEtcdEncryptionDisabled = "EtcdEncryptionDisabled"
+
+	// ReasonEtcdEncryptionInProgress indicates encryption is configured but existing Secrets are
+	// still being rewritten
+	ReasonEtcdEncryptionInProgress = "EtcdEncryptionInProgress"
+
+	// ReasonEtcdEncryptionUnknown indicates the cluster does not serve the OpenShift APIServer config
+	ReasonEtcdEncryptionUnknown = "EtcdEncryptionUnknown"
+
+	// ReasonPoliciesApplied indicates every enabled rule is rendered for the policy engine
+	ReasonPoliciesApplied = "PoliciesApplied"
+
+	// ReasonPoliciesDisabled indicates no policy engine or no rule is configured
+	ReasonPoliciesDisabled = "PoliciesDisabled"
+
+	// ReasonPoliciesPending indicates the policy engine has not yet created the kinds the policies need
+	ReasonPoliciesPending = "PoliciesPending"
+
+	// ReasonPolicyEngineNotInstalled indicates the configured policy engine is not installed
+	ReasonPolicyEngineNotInstalled = "PolicyEngineNotInstalled"
+
+	// ReasonHubDisabled indicates spec.hub is not enabled
+	ReasonHubDisabled = "HubDisabled"
+
+	// ReasonHubAPIUnavailable indicates the cluster is not an ACM hub: the ManagedCluster or
+	// ManifestWork API is not served
+	ReasonHubAPIUnavailable = "HubAPIUnavailable"
+
+	// ReasonClustersReporting indicates every selected managed cluster reported status
+	ReasonClustersReporting = "ClustersReporting"
+
+	// ReasonClustersNotReporting indicates some selected managed clusters have not reported status
+	ReasonClustersNotReporting = "ClustersNotReporting"
+
+	// ReasonNoClustersSelected indicates spec.hub.clusterSelector matches no managed cluster
+	ReasonNoClustersSelected = "NoClustersSelected"
+
+	// ReasonRulesOverrideApplied indicates the rules patches are applied to the generated ClusterRoles
+	ReasonRulesOverrideApplied = "RulesOverrideApplied"
+
+	// ReasonRulesOverrideNotConfigured indicates spec.rbac.rulesOverrideConfigMap is not set
+	ReasonRulesOverrideNotConfigured = "RulesOverrideNotConfigured"
+
+	// ReasonRulesOverrideConfigMapNotFound indicates the rules override ConfigMap does not exist
+	ReasonRulesOverrideConfigMapNotFound = "RulesOverrideConfigMapNotFound"
+
+	// ReasonRulesOverrideInvalid indicates the rules override ConfigMap holds an invalid patch
+	ReasonRulesOverrideInvalid = "RulesOverrideInvalid"
+
+	// ReasonRBACDriftDetected indicates the live rules of a generated ClusterRole differed from the desired ones
+	ReasonRBACDriftDetected = "RBACDriftDetected"
+
+	// ReasonNoRBACDrift indicates the generated ClusterRoles were found as the operator last wrote them
+	ReasonNoRBACDrift = "NoRBACDrift"
+
+	// ReasonAlertRoutingApplied indicates the AlertmanagerConfig routes alerts to the configured receivers
+	ReasonAlertRoutingApplied = "AlertRoutingApplied"
+
+	// ReasonAlertRoutingNotConfigured indicates spec.monitoring.alertReceivers is empty
+	ReasonAlertRoutingNotConfigured = "AlertRoutingNotConfigured"
+
+	// ReasonAlertmanagerConfigUnavailable indicates the cluster does not serve the AlertmanagerConfig kind
+	ReasonAlertmanagerConfigUnavailable = "AlertmanagerConfigUnavailable"
+
+	// ReasonAlertReceiverInvalid indicates a receiver in spec.monitoring.alertReceivers is incomplete
+	ReasonAlertReceiverInvalid = "AlertReceiverInvalid"
+
+	// ReasonBackupScheduled indicates the backup CronJob runs on spec.backup.schedule
+	ReasonBackupScheduled = "BackupScheduled"
+
+	// ReasonBackupNotConfigured indicates spec.backup.schedule is empty
+	ReasonBackupNotConfigured = "BackupNotConfigured"
+
+	// ReasonBackupDestinationInvalid indicates spec.backup.destination is incomplete
+	ReasonBackupDestinationInvalid = "BackupDestinationInvalid"
+
+	// ReasonVeleroOperationInProgress indicates a Velero Backup or Restore covering the plugin namespace is running
+	ReasonVeleroOperationInProgress = "VeleroOperationInProgress"
+
+	// ReasonNoVeleroOperation indicates no Velero Backup or Restore covering the plugin namespace is running
+	ReasonNoVeleroOperation = "NoVeleroOperation"
+
+	// ReasonRestoreVerificationPending indicates the stores and issuers are re-validating after a restore
+	ReasonRestoreVerificationPending = "RestoreVerificationPending"
+
+	// ReasonRestoreVerified indicates every store and issuer is Ready after a restore
+	ReasonRestoreVerified = "RestoreVerified"
+
+	// ReasonRestoreVerificationFailed indicates stores or issuers are not Ready after a restore
+	ReasonRestoreVerificationFailed = "RestoreVerificationFailed"
+
+	// ReasonUnsupportedOperatorVersion indicates a detected operator is below its minimum supported version
+	ReasonUnsupportedOperatorVersion = "UnsupportedOperatorVersion"
+
+	// ReasonSupportedOperatorVersions indicates every detected operator is supported
+	ReasonSupportedOperatorVersions = "SupportedOperatorVersions"
+
+	// ReasonReconcileStepsSucceeded indicates every step reported by the condition succeeded
+	ReasonReconcileStepsSucceeded = "StepsSucceeded"
+
+	// ReasonReconcileStepFailed indicates a step reported by the condition failed; the message lists them
+	ReasonReconcileStepFailed = "StepFailed"
+
+	// ReasonConsoleDisabled indicates the cluster's Console capability is disabled, so the plugin is not deployed
+	ReasonConsoleDisabled = "ConsoleDisabled"
+)
+
+// Condition represents an observation of the config's state
+type Condition struct {
+	// Type of condition
+	Type ConditionType `json:"type"`
+
+	// Status of the condition (True, False, Unknown)
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status string `json:"status"`
+
+	// Reason is a machine-readable reason for the condition
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable message for the condition
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is the last time the condition transitioned
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// AuditSinkStatus reports delivery to one audit sink
+type AuditSinkStatus struct {
+	// Name of the sink
+	Name string `json:"name"`
+
+	// LastDeliveryTime is when a record was last delivered
+	LastDeliveryTime *metav1.Time `json:"lastDeliveryTime,omitempty"`
+
+	// Dropped is the number of records that could not be delivered after retries
+	Dropped int64 `json:"dropped,omitempty"`
+
+	// LastError is the last delivery error, cleared once a record is delivered
+	LastError string `json:"lastError,omitempty"`
+}
+
+// NotificationReceiverStatus reports notifications sent to one receiver
+type NotificationReceiverStatus struct {
+	// Name of the receiver
+	Name string `json:"name"`
+
+	// LastSentTime is when a notification was last sent
+	LastSentTime *metav1.Time `json:"lastSentTime,omitempty"`
+
+	// Sent is the number of notifications sent since the operator started
+	Sent int64 `json:"sent,omitempty"`
+
+	// Suppressed is the number of notifications dropped by rate limiting since the operator started
+	Suppressed int64 `json:"suppressed,omitempty"`
+
+	// LastError is the last send error, cleared once a notification is sent
+	LastError string `json:"lastError,omitempty"`
+}
+
+// SecretStoreStatus reports the health of one ClusterSecretStore from spec.stores
+type SecretStoreStatus struct {
+	// Name of the ClusterSecretStore
+	Name string `json:"name"`
+
+	// Provider is the secret backend
+	Provider SecretStoreProvider `json:"provider,omitempty"`
+
+	// Ready is true once the External Secrets Operator has validated the store against its provider
+	Ready bool `json:"ready"`
+
+	// Message explains why the store is not ready
+	Message string `json:"message,omitempty"`
+
+	// Credentials reports the last check of the store's credentials against its cloud provider;
+	// unset for Vault stores and until the auth Secret holds every key
+	// +optional
+	Credentials *StoreCredentialsStatus `json:"credentials,omitempty"`
+}
+
+// StoreCredentialsStatus reports the last check of a store's cloud credentials
+type StoreCredentialsStatus struct {
+	// Valid is true when the provider accepted the credentials, false when it rejected them, and
+	// unset when the provider could not be reached
+	// +optional
+	Valid *bool `json:"valid,omitempty"`
+
+	// Identity is the principal the credentials authenticate as: the IAM ARN, the Azure client ID
+	// or the GCP service account email
+	// +optional
+	Identity string `json:"identity,omitempty"`
+
+	// ExpiresAt is when the credentials expire, from the secrets-management.openshift.io/expires-at
+	// annotation of the auth Secret
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Expiring is true when ExpiresAt is less than 14 days away or past
+	// +optional
+	Expiring bool `json:"expiring,omitempty"`
+
+	// LastChecked is when the credentials were last checked
+	LastChecked metav1.Time `json:"lastChecked"`
+
+	// AuthSecretVersion is the resourceVersion of the auth Secret last checked; a changed Secret
+	// is checked again on the next reconcile
+	// +optional
+	AuthSecretVersion string `json:"authSecretVersion,omitempty"`
+
+	// Message explains why the credentials were rejected or could not be checked
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// IssuerStatus reports the health of one ClusterIssuer from spec.issuers
+type IssuerStatus struct {
+	// Name of the ClusterIssuer
+	Name string `json:"name"`
+
+	// Type of the issuer
+	Type IssuerType `json:"type,omitempty"`
+
+	// Ready is true once cert-manager reports the issuer Ready
+	Ready bool `json:"ready"`
+
+	// Message explains why the issuer is not ready
+	Message string `json:"message,omitempty"`
+}
+
+// PushSecretsStatus counts the PushSecrets in the cluster and lists the failing ones
+type PushSecretsStatus struct {
+	// Total is the number of PushSecrets in the cluster
+	Total int32 `json:"total,omitempty"`
+
+	// Failing is the number of PushSecrets whose Ready condition is False
+	Failing int32 `json:"failing,omitempty"`
+
+	// Failures lists the failing PushSecrets, longest failing first
+	// +kubebuilder:validation:MaxItems=50
+	Failures []PushSecretFailure `json:"failures,omitempty"`
+}
+
+// ClusterExternalSecretsStatus counts the namespaces ClusterExternalSecrets fan out to and lists
+// the ClusterExternalSecrets with lagging namespaces
+type ClusterExternalSecretsStatus struct {
+	// Total is the number of ClusterExternalSecrets in the cluster
+	Total int32 `json:"total,omitempty"`
+
+	// Namespaces is the number of namespaces targeted across the ClusterExternalSecrets
+	Namespaces int32 `json:"namespaces,omitempty"`
+
+	// Provisioned is the number of targeted namespaces whose ExternalSecret is provisioned and
+	// not failing
+	Provisioned int32 `json:"provisioned,omitempty"`
+
+	// Lagging is the number of targeted namespaces that failed to provision or whose
+	// ExternalSecret reports Ready False
+	Lagging int32 `json:"lagging,omitempty"`
+
+	// Degraded lists the ClusterExternalSecrets with lagging namespaces, most lagging first
+	// +kubebuilder:validation:MaxItems=50
+	Degraded []ClusterExternalSecretSummary `json:"degraded,omitempty"`
+}
+
+// VaultServerStatus reports the health probe of one Vault server
+type VaultServerStatus struct {
+	// Server is the address of the Vault server
+	Server string `json:"server"`
+
+	// UsedBy lists the ClusterSecretStores and SecretProviderClasses pointing at the server
+	// +kubebuilder:validation:MaxItems=10
+	UsedBy []string `json:"usedBy,omitempty"`
+
+	// Reachable is true when the server answered its health endpoint
+	Reachable bool `json:"reachable"`
+
+	// Sealed is true when the server reports it is sealed or not initialized
+	Sealed bool `json:"sealed,omitempty"`
+
+	// TokenValid reports whether the server accepted the tokens of the ClusterSecretStores using
+	// token auth; unset when no token was checked
+	TokenValid *bool `json:"tokenValid,omitempty"`
+
+	// Message describes why the probe failed
+	Message string `json:"message,omitempty"`
+}
+
+// CloudAuthStatus reports the service accounts annotated for spec.cloudAuth
+type CloudAuthStatus struct {
+	// Provider is the workload identity the service accounts are annotated for
+	Provider CloudAuthProvider `json:"provider"`
+
+	// ServiceAccounts reports each service account in spec.cloudAuth.serviceAccounts
+	// +kubebuilder:validation:MaxItems=20
+	ServiceAccounts []CloudAuthServiceAccountStatus `json:"serviceAccounts,omitempty"`
+
+	// ObservedGeneration is the generation of the config the federation was last validated for; a
+	// changed spec is validated again on the next reconcile
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastChecked is when the federation was last validated
+	// +optional
+	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
+}
+
+// CloudAuthServiceAccountStatus reports the workload identity of one service account
+type CloudAuthServiceAccountStatus struct {
+	// Namespace of the service account
+	Namespace string `json:"namespace"`
+
+	// Name of the service account
+	Name string `json:"name"`
+
+	// Annotated is true once the service account carries the workload identity annotations
+	Annotated bool `json:"annotated"`
+
+	// Federated is true when the provider exchanged a token of the service account for cloud
+	// credentials, false when it rejected the token, and unset when it was not validated
+	// +optional
+	Federated *bool `json:"federated,omitempty"`
+
+	// Identity is the principal the exchanged credentials authenticate as: the assumed role ARN,
+	// the Google service account email or the Azure client ID
+	// +optional
+	Identity string `json:"identity,omitempty"`
+
+	// Message explains why the service account is not annotated or its federation failed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PushSecretFailure describes a PushSecret that fails to push to its external store
+type PushSecretFailure struct {
+	// Namespace of the PushSecret
+	Namespace string `json:"namespace"`
+
+	// Name of the PushSecret
+	Name string `json:"name"`
+
+	// Reason of the PushSecret's Ready condition
+	Reason string `json:"reason,omitempty"`
+
+	// Message of the PushSecret's Ready condition
+	Message string `json:"message,omitempty"`
+
+	// Since is when the Ready condition turned False
+	// +optional
+	Since *metav1.Time `json:"since,omitempty"`
+}
+
+// ClusterExternalSecretSummary counts the namespaces of one ClusterExternalSecret
+type ClusterExternalSecretSummary struct {
+	// Name of the ClusterExternalSecret
+	Name string `json:"name"`
+
+	// Provisioned is the number of namespaces whose ExternalSecret is provisioned and not failing
+	Provisioned int32 `json:"provisioned"`
+
+	// Lagging is the number of namespaces that failed to provision or are failing to sync
+	Lagging int32 `json:"lagging"`
+
+	// LaggingNamespaces lists the lagging namespaces in alphabetical order
+	// +kubebuilder:validation:MaxItems=10
+	LaggingNamespaces []LaggingNamespace `json:"laggingNamespaces,omitempty"`
+}
+
+// LaggingNamespace is a namespace a ClusterExternalSecret has not provisioned a syncing
+// ExternalSecret in
+type LaggingNamespace struct {
+	// Namespace is the lagging namespace
+	Namespace string `json:"namespace"`
+
+	// Reason is the provisioning failure reported by the ClusterExternalSecret, or the message of
+	// the ExternalSecret's Ready condition
+	Reason string `json:"reason,omitempty"`
+}
+
+// IssuanceFailureSummary counts the failing CertificateRequests and stuck ACME Orders and
+// Challenges of one Issuer or ClusterIssuer
+type IssuanceFailureSummary struct {
+	// Kind of the issuer, Issuer or ClusterIssuer
+	Kind string `json:"kind"`
+
+	// Namespace of the Issuer, empty for ClusterIssuers
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the issuer
+	Name string `json:"name"`
+
+	// Failed is the number of Certificates whose latest CertificateRequest failed
+	Failed int32 `json:"failed,omitempty"`
+
+	// Denied is the number of Certificates whose latest CertificateRequest was denied by an approver
+	Denied int32 `json:"denied,omitempty"`
+
+	// StuckOrders is the number of ACME Orders that errored or are still pending after 30 minutes
+	StuckOrders int32 `json:"stuckOrders,omitempty"`
+
+	// StuckChallenges is the number of ACME Challenges that are still not valid after 30 minutes
+	StuckChallenges int32 `json:"stuckChallenges,omitempty"`
+
+	// Message is the most recent failure reported for the issuer, such as an ACME rate limit
+	Message string `json:"message,omitempty"`
+}
+
+// SecurityPostureStatus reports cluster settings that protect the secrets the plugin manages
+type SecurityPostureStatus struct {
+	// EtcdEncryption is the etcd encryption type set in the cluster APIServer config: aescbc,
+	// aesgcm or KMS, or identity when secrets are stored unencrypted
+	EtcdEncryption string `json:"etcdEncryption,omitempty"`
+
+	// EtcdEncryptionProgress is the reason of the kube-apiserver's Encrypted condition, such as
+	// EncryptionInProgress or EncryptionCompleted, while or after resources are rewritten
+	EtcdEncryptionProgress string `json:"etcdEncryptionProgress,omitempty"`
+}
+
+// SecretsHealthStatus summarizes the health of the stores and issuers in spec.stores and
+// spec.issuers, as counts a hub can collect through ManifestWork status feedback
+type SecretsHealthStatus struct {
+	// Stores is the number of ClusterSecretStores in spec.stores
+	Stores int32 `json:"stores"`
+
+	// StoresReady is the number of those stores that are ready
+	StoresReady int32 `json:"storesReady"`
+
+	// Issuers is the number of ClusterIssuers in spec.issuers
+	Issuers int32 `json:"issuers"`
+
+	// IssuersReady is the number of those issuers that are ready
+	IssuersReady int32 `json:"issuersReady"`
+}
+
+// FleetClusterStatus is the state one managed cluster reported to the hub
+type FleetClusterStatus struct {
+	// Name of the ManagedCluster
+	Name string `json:"name"`
+
+	// Reported is true once the cluster's operator has reported status
+	Reported bool `json:"reported"`
+
+	// Phase of the config on the cluster
+	Phase ConfigPhase `json:"phase,omitempty"`
+
+	// CertManager is true when cert-manager is installed on the cluster
+	CertManager bool `json:"certManager,omitempty"`
+
+	// ExternalSecrets is true when the External Secrets Operator is installed on the cluster
+	ExternalSecrets bool `json:"externalSecrets,omitempty"`
+
+	// SecretsStoreCSI is true when the Secrets Store CSI driver is installed on the cluster
+	SecretsStoreCSI bool `json:"secretsStoreCSI,omitempty"`
+
+	// EtcdEncryption is the etcd encryption type of the cluster
+	EtcdEncryption string `json:"etcdEncryption,omitempty"`
+
+	// Health summarizes the cluster's stores and issuers
+	Health SecretsHealthStatus `json:"health"`
+}
+
+// RestoreVerificationStatus reports the verification pass run after a restore
+type RestoreVerificationStatus struct {
+	// Restore identifies the restore verified: the name of the Velero Restore, or the time
+	// smcctl restore ran
+	Restore string `json:"restore"`
+
+	// StartTime is when the stores and issuers were asked to re-validate
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is when the result was recorded; unset while verifying
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Verified is how many stores and issuers reported Ready
+	Verified int32 `json:"verified"`
+
+	// Failures are the stores and issuers that did not, at most 20
+	// +optional
+	Failures []RestoreVerificationFailure `json:"failures,omitempty"`
+}
+
+// RestoreVerificationFailure is a store or issuer that did not reconnect after a restore
+type RestoreVerificationFailure struct {
+	// Kind is ClusterSecretStore or ClusterIssuer
+	Kind string `json:"kind"`
+
+	// Name of the store or issuer
+	Name string `json:"name"`
+
+	// Message is the reason its Ready condition gives, or why it has none
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FleetStatus aggregates the managed clusters selected by spec.hub
+type FleetStatus struct {
+	// Clusters is the number of managed clusters the config is distributed to
+	Clusters int32 `json:"clusters"`
+
+	// Reporting is the number of clusters that reported status
+	Reporting int32 `json:"reporting"`
+
+	// Ready is the number of clusters reporting the Ready phase
+	Ready int32 `json:"ready"`
+
+	// Members reports each cluster, sorted by name
+	// +optional
+	Members []FleetClusterStatus `json:"members,omitempty"`
+}
+
+// SecretsManagementConfigStatus defines the observed state of SecretsManagementConfig
+type SecretsManagementConfigStatus struct {
+	// Phase is the overall status of the deployment
+	Phase ConfigPhase `json:"phase,omitempty"`
+
+	// Reason is a CamelCase reason for a phase other than Ready, taken from the condition that
+	// determined it, such as MinimumReplicasUnavailable or ProgressDeadlineExceeded
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// ObservedGeneration is the last observed generation of the spec
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// RBAC contains status of RBAC resources
+	RBAC RBACStatus `json:"rbac,omitempty"`
+
+	// Plugin contains status of the console plugin deployment
+	Plugin PluginStatus `json:"plugin,omitempty"`
+
+	// ResolvedConfiguration shows the values the operator applies, including the defaults of
+	// fields the spec leaves unset
+	ResolvedConfiguration ResolvedConfigurationStatus `json:"resolvedConfiguration,omitempty"`
+
+	// Features reports the UI feature toggles the running plugin pods serve
+	Features FeaturesStatus `json:"features,omitempty"`
+
+	// DetectedOperators contains detection status of operators
+	DetectedOperators DetectedOperatorsStatus `json:"detectedOperators,omitempty"`
+
+	// SecretProviderClasses reports which SecretProviderClasses are mounted by pods
+	SecretProviderClasses SecretProviderClassUsageStatus `json:"secretProviderClasses,omitempty"`
+
+	// SecurityPosture reports whether secrets are encrypted at rest in etcd
+	SecurityPosture SecurityPostureStatus `json:"securityPosture,omitempty"`
+
+	// AuditSinks reports delivery to the sinks in spec.audit.sinks
+	AuditSinks []AuditSinkStatus `json:"auditSinks,omitempty"`
+
+	// NotificationReceivers reports notifications sent to spec.notifications.receivers
+	NotificationReceivers []NotificationReceiverStatus `json:"notificationReceivers,omitempty"`
+
+	// Stores reports the health of the ClusterSecretStores in spec.stores
+	Stores []SecretStoreStatus `json:"stores,omitempty"`
+
+	// Issuers reports the health of the ClusterIssuers in spec.issuers
+	Issuers []IssuerStatus `json:"issuers,omitempty"`
+
+	// PushSecrets summarizes whether External Secrets Operator PushSecrets push to their stores
+	PushSecrets PushSecretsStatus `json:"pushSecrets,omitempty"`
+
+	// ClusterExternalSecrets summarizes the namespaces ClusterExternalSecrets provision
+	ClusterExternalSecrets ClusterExternalSecretsStatus `json:"clusterExternalSecrets,omitempty"`
+
+	// IssuanceFailures summarizes, per issuer, the certificates cert-manager is failing to issue
+	// +kubebuilder:validation:MaxItems=50
+	IssuanceFailures []IssuanceFailureSummary `json:"issuanceFailures,omitempty"`
+
+	// Vault reports the health probes of the Vault servers used by ClusterSecretStores and
+	// SecretProviderClasses
+	// +kubebuilder:validation:MaxItems=20
+	Vault []VaultServerStatus `json:"vault,omitempty"`
+
+	// CloudAuth reports the service accounts annotated for spec.cloudAuth and whether their
+	// federation with the cloud provider works
+	// +optional
+	CloudAuth *CloudAuthStatus `json:"cloudAuth,omitempty"`
+
+	// Health counts the ready stores and issuers, for hubs to collect
+	Health SecretsHealthStatus `json:"health,omitempty"`
+
+	// Fleet aggregates the managed clusters selected by spec.hub
+	// +optional
+	Fleet *FleetStatus `json:"fleet,omitempty"`
+
+	// RestoreVerification reports the check that the stores and issuers reconnect after the
+	// configuration was last restored
+	// +optional
+	RestoreVerification *RestoreVerificationStatus `json:"restoreVerification,omitempty"`
+
+	// ManagedResources lists every object the operator owns and its health
+	ManagedResources []ManagedResource `json:"managedResources,omitempty"`
+
+	// LastReconcileTime is when the last reconcile loop started
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// LastReconcileDuration is how long the last reconcile loop took
+	LastReconcileDuration *metav1.Duration `json:"lastReconcileDuration,omitempty"`
+
+	// LastError is the error from the last reconcile loop, cleared once a loop succeeds
+	LastError string `json:"lastError,omitempty"`
+
+	// History lists the most recent phase transitions, oldest first
+	// +kubebuilder:validation:MaxItems=10
+	History []PhaseTransition `json:"history,omitempty"`
 
 	// Conditions represent the latest available observations
 	Conditions []Condition `json:"conditions,omitempty"`
 }
 
+// +genclient
+// +genclient:nonNamespaced
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,shortName=smc
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.reason`
+// +kubebuilder:printcolumn:name="Available",type=integer,JSONPath=`.status.plugin.availableReplicas`
 // +kubebuilder:printcolumn:name="Plugin Ready",type=boolean,JSONPath=`.status.plugin.ready`
 // +kubebuilder:printcolumn:name="cert-manager",type=boolean,JSONPath=`.status.detectedOperators.certManager.installed`
+// +kubebuilder:printcolumn:name="cert-manager Version",type=string,JSONPath=`.status.detectedOperators.certManager.releaseVersion`
 // +kubebuilder:printcolumn:name="ESO",type=boolean,JSONPath=`.status.detectedOperators.externalSecrets.installed`
+// +kubebuilder:printcolumn:name="ESO Version",type=string,JSONPath=`.status.detectedOperators.externalSecrets.releaseVersion`
 // +kubebuilder:printcolumn:name="SSCSI",type=boolean,JSONPath=`.status.detectedOperators.secretsStoreCSI.installed`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
-// SecretsManagementConfig is the Schema for the secretsmanagementconfigs API
+// SecretsManagementConfig is the Schema for the secretsmanagementconfigs API.
+// The operator reconciles the config named "cluster"; a config named "canary" deploys a parallel
+// plugin instance with its own ConsolePlugin and roles. Configs with any other name are ignored.
 type SecretsManagementConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -21,344 +21,2573 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRoleStatus) DeepCopyInto(out *ClusterRoleStatus) {
+func (in *ACMEIssuerConfig) DeepCopyInto(out *ACMEIssuerConfig) {
 	*out = *in
-	if in.Operations != nil {
-		in, out := &in.Operations, &out.Operations
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerConfig.
+func (in *ACMEIssuerConfig) DeepCopy() *ACMEIssuerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSCloudAuthConfig) DeepCopyInto(out *AWSCloudAuthConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSCloudAuthConfig.
+func (in *AWSCloudAuthConfig) DeepCopy() *AWSCloudAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSCloudAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessRequestAuditEntry) DeepCopyInto(out *AccessRequestAuditEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRequestAuditEntry.
+func (in *AccessRequestAuditEntry) DeepCopy() *AccessRequestAuditEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessRequestAuditEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertReceiver) DeepCopyInto(out *AlertReceiver) {
+	*out = *in
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	in.Created.DeepCopyInto(&out.Created)
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(EmailAlertReceiver)
+		**out = **in
+	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(PagerDutyAlertReceiver)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookAlertReceiver)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleStatus.
-func (in *ClusterRoleStatus) DeepCopy() *ClusterRoleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertReceiver.
+func (in *AlertReceiver) DeepCopy() *AlertReceiver {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRoleStatus)
+	out := new(AlertReceiver)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Condition) DeepCopyInto(out *Condition) {
+func (in *AuditConfig) DeepCopyInto(out *AuditConfig) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]AuditSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
-func (in *Condition) DeepCopy() *Condition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditConfig.
+func (in *AuditConfig) DeepCopy() *AuditConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(Condition)
+	out := new(AuditConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DetectedOperator) DeepCopyInto(out *DetectedOperator) {
+func (in *AuditSink) DeepCopyInto(out *AuditSink) {
 	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookSinkConfig)
+		**out = **in
+	}
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(SyslogSinkConfig)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DetectedOperator.
-func (in *DetectedOperator) DeepCopy() *DetectedOperator {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditSink.
+func (in *AuditSink) DeepCopy() *AuditSink {
 	if in == nil {
 		return nil
 	}
-	out := new(DetectedOperator)
+	out := new(AuditSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DetectedOperatorsStatus) DeepCopyInto(out *DetectedOperatorsStatus) {
+func (in *AuditSinkStatus) DeepCopyInto(out *AuditSinkStatus) {
 	*out = *in
-	out.CertManager = in.CertManager
-	out.ExternalSecrets = in.ExternalSecrets
-	out.SecretsStoreCSI = in.SecretsStoreCSI
+	if in.LastDeliveryTime != nil {
+		in, out := &in.LastDeliveryTime, &out.LastDeliveryTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DetectedOperatorsStatus.
-func (in *DetectedOperatorsStatus) DeepCopy() *DetectedOperatorsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditSinkStatus.
+func (in *AuditSinkStatus) DeepCopy() *AuditSinkStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DetectedOperatorsStatus)
+	out := new(AuditSinkStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FeatureConfig) DeepCopyInto(out *FeatureConfig) {
+func (in *AzureCloudAuthConfig) DeepCopyInto(out *AzureCloudAuthConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureConfig.
-func (in *FeatureConfig) DeepCopy() *FeatureConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureCloudAuthConfig.
+func (in *AzureCloudAuthConfig) DeepCopy() *AzureCloudAuthConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(FeatureConfig)
+	out := new(AzureCloudAuthConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FeaturesConfig) DeepCopyInto(out *FeaturesConfig) {
+func (in *BackupConfig) DeepCopyInto(out *BackupConfig) {
 	*out = *in
-	out.Delete = in.Delete
-	out.Create = in.Create
-	out.Edit = in.Edit
+	in.Destination.DeepCopyInto(&out.Destination)
+	out.Velero = in.Velero
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeaturesConfig.
-func (in *FeaturesConfig) DeepCopy() *FeaturesConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfig.
+func (in *BackupConfig) DeepCopy() *BackupConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(FeaturesConfig)
+	out := new(BackupConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperatorConfig) DeepCopyInto(out *OperatorConfig) {
+func (in *BackupDestination) DeepCopyInto(out *BackupDestination) {
 	*out = *in
+	if in.ObjectStorage != nil {
+		in, out := &in.ObjectStorage, &out.ObjectStorage
+		*out = new(ObjectStorageBackupDestination)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfig.
-func (in *OperatorConfig) DeepCopy() *OperatorConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDestination.
+func (in *BackupDestination) DeepCopy() *BackupDestination {
 	if in == nil {
 		return nil
 	}
-	out := new(OperatorConfig)
+	out := new(BackupDestination)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperatorsConfig) DeepCopyInto(out *OperatorsConfig) {
+func (in *BindingSubjectStatus) DeepCopyInto(out *BindingSubjectStatus) {
 	*out = *in
-	out.CertManager = in.CertManager
-	out.ExternalSecrets = in.ExternalSecrets
-	out.SecretsStoreCSI = in.SecretsStoreCSI
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorsConfig.
-func (in *OperatorsConfig) DeepCopy() *OperatorsConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindingSubjectStatus.
+func (in *BindingSubjectStatus) DeepCopy() *BindingSubjectStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OperatorsConfig)
+	out := new(BindingSubjectStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PluginConfig) DeepCopyInto(out *PluginConfig) {
+func (in *CAIssuerConfig) DeepCopyInto(out *CAIssuerConfig) {
 	*out = *in
-	out.Resources = in.Resources
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginConfig.
-func (in *PluginConfig) DeepCopy() *PluginConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAIssuerConfig.
+func (in *CAIssuerConfig) DeepCopy() *CAIssuerConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PluginConfig)
+	out := new(CAIssuerConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PluginStatus) DeepCopyInto(out *PluginStatus) {
+func (in *CSIDaemonSetStatus) DeepCopyInto(out *CSIDaemonSetStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginStatus.
-func (in *PluginStatus) DeepCopy() *PluginStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIDaemonSetStatus.
+func (in *CSIDaemonSetStatus) DeepCopy() *CSIDaemonSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PluginStatus)
+	out := new(CSIDaemonSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RBACConfig) DeepCopyInto(out *RBACConfig) {
+func (in *CloudAuthConfig) DeepCopyInto(out *CloudAuthConfig) {
 	*out = *in
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]ServiceAccountReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSCloudAuthConfig)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPCloudAuthConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureCloudAuthConfig)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACConfig.
-func (in *RBACConfig) DeepCopy() *RBACConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudAuthConfig.
+func (in *CloudAuthConfig) DeepCopy() *CloudAuthConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(RBACConfig)
+	out := new(CloudAuthConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RBACStatus) DeepCopyInto(out *RBACStatus) {
+func (in *CloudAuthServiceAccountStatus) DeepCopyInto(out *CloudAuthServiceAccountStatus) {
 	*out = *in
-	if in.ClusterRoles != nil {
-		in, out := &in.ClusterRoles, &out.ClusterRoles
-		*out = make([]ClusterRoleStatus, len(*in))
+	if in.Federated != nil {
+		in, out := &in.Federated, &out.Federated
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudAuthServiceAccountStatus.
+func (in *CloudAuthServiceAccountStatus) DeepCopy() *CloudAuthServiceAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudAuthServiceAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudAuthStatus) DeepCopyInto(out *CloudAuthStatus) {
+	*out = *in
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]CloudAuthServiceAccountStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastChecked != nil {
+		in, out := &in.LastChecked, &out.LastChecked
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACStatus.
-func (in *RBACStatus) DeepCopy() *RBACStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudAuthStatus.
+func (in *CloudAuthStatus) DeepCopy() *CloudAuthStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RBACStatus)
+	out := new(CloudAuthStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceConfig) DeepCopyInto(out *ResourceConfig) {
+func (in *ClusterExternalSecretSummary) DeepCopyInto(out *ClusterExternalSecretSummary) {
 	*out = *in
-	out.Requests = in.Requests
-	out.Limits = in.Limits
+	if in.LaggingNamespaces != nil {
+		in, out := &in.LaggingNamespaces, &out.LaggingNamespaces
+		*out = make([]LaggingNamespace, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceConfig.
-func (in *ResourceConfig) DeepCopy() *ResourceConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExternalSecretSummary.
+func (in *ClusterExternalSecretSummary) DeepCopy() *ClusterExternalSecretSummary {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceConfig)
+	out := new(ClusterExternalSecretSummary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
+func (in *ClusterExternalSecretsStatus) DeepCopyInto(out *ClusterExternalSecretsStatus) {
 	*out = *in
+	if in.Degraded != nil {
+		in, out := &in.Degraded, &out.Degraded
+		*out = make([]ClusterExternalSecretSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRequirements.
-func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExternalSecretsStatus.
+func (in *ClusterExternalSecretsStatus) DeepCopy() *ClusterExternalSecretsStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceRequirements)
+	out := new(ClusterExternalSecretsStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretsManagementConfig) DeepCopyInto(out *SecretsManagementConfig) {
+func (in *ClusterRoleStatus) DeepCopyInto(out *ClusterRoleStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Created.DeepCopyInto(&out.Created)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementConfig.
-func (in *SecretsManagementConfig) DeepCopy() *SecretsManagementConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleStatus.
+func (in *ClusterRoleStatus) DeepCopy() *ClusterRoleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretsManagementConfig)
+	out := new(ClusterRoleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecretsManagementConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceConfig) DeepCopyInto(out *ComplianceConfig) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceConfig.
+func (in *ComplianceConfig) DeepCopy() *ComplianceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceConfig)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretsManagementConfigList) DeepCopyInto(out *SecretsManagementConfigList) {
+func (in *ComplianceControlStatus) DeepCopyInto(out *ComplianceControlStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]SecretsManagementConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.References != nil {
+		in, out := &in.References, &out.References
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Findings != nil {
+		in, out := &in.Findings, &out.Findings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementConfigList.
-func (in *SecretsManagementConfigList) DeepCopy() *SecretsManagementConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceControlStatus.
+func (in *ComplianceControlStatus) DeepCopy() *ComplianceControlStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretsManagementConfigList)
+	out := new(ComplianceControlStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecretsManagementConfigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretsManagementConfigSpec) DeepCopyInto(out *SecretsManagementConfigSpec) {
+func (in *DeleteFeatureConfig) DeepCopyInto(out *DeleteFeatureConfig) {
 	*out = *in
-	out.Features = in.Features
-	out.RBAC = in.RBAC
-	out.Plugin = in.Plugin
-	out.Operators = in.Operators
+	out.FeatureConfig = in.FeatureConfig
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementConfigSpec.
-func (in *SecretsManagementConfigSpec) DeepCopy() *SecretsManagementConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteFeatureConfig.
+func (in *DeleteFeatureConfig) DeepCopy() *DeleteFeatureConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretsManagementConfigSpec)
+	out := new(DeleteFeatureConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretsManagementConfigStatus) DeepCopyInto(out *SecretsManagementConfigStatus) {
+func (in *DeploymentStrategyConfig) DeepCopyInto(out *DeploymentStrategyConfig) {
 	*out = *in
-	in.RBAC.DeepCopyInto(&out.RBAC)
-	out.Plugin = in.Plugin
-	out.DetectedOperators = in.DetectedOperators
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementConfigStatus.
-func (in *SecretsManagementConfigStatus) DeepCopy() *SecretsManagementConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStrategyConfig.
+func (in *DeploymentStrategyConfig) DeepCopy() *DeploymentStrategyConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretsManagementConfigStatus)
+	out := new(DeploymentStrategyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DetectedOperator) DeepCopyInto(out *DetectedOperator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DetectedOperator.
+func (in *DetectedOperator) DeepCopy() *DetectedOperator {
+	if in == nil {
+		return nil
+	}
+	out := new(DetectedOperator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DetectedOperatorsStatus) DeepCopyInto(out *DetectedOperatorsStatus) {
+	*out = *in
+	out.CertManager = in.CertManager
+	out.ExternalSecrets = in.ExternalSecrets
+	in.SecretsStoreCSI.DeepCopyInto(&out.SecretsStoreCSI)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DetectedOperatorsStatus.
+func (in *DetectedOperatorsStatus) DeepCopy() *DetectedOperatorsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DetectedOperatorsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailAlertReceiver) DeepCopyInto(out *EmailAlertReceiver) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailAlertReceiver.
+func (in *EmailAlertReceiver) DeepCopy() *EmailAlertReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailAlertReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureConfig) DeepCopyInto(out *FeatureConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureConfig.
+func (in *FeatureConfig) DeepCopy() *FeatureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeaturesConfig) DeepCopyInto(out *FeaturesConfig) {
+	*out = *in
+	out.Delete = in.Delete
+	out.Create = in.Create
+	out.Edit = in.Edit
+	out.ReadOnly = in.ReadOnly
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeaturesConfig.
+func (in *FeaturesConfig) DeepCopy() *FeaturesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FeaturesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeaturesStatus) DeepCopyInto(out *FeaturesStatus) {
+	*out = *in
+	out.Delete = in.Delete
+	out.Create = in.Create
+	out.Edit = in.Edit
+	if in.AppliedTime != nil {
+		in, out := &in.AppliedTime, &out.AppliedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeaturesStatus.
+func (in *FeaturesStatus) DeepCopy() *FeaturesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FeaturesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetClusterStatus) DeepCopyInto(out *FleetClusterStatus) {
+	*out = *in
+	out.Health = in.Health
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetClusterStatus.
+func (in *FleetClusterStatus) DeepCopy() *FleetClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetStatus) DeepCopyInto(out *FleetStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]FleetClusterStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetStatus.
+func (in *FleetStatus) DeepCopy() *FleetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPCloudAuthConfig) DeepCopyInto(out *GCPCloudAuthConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPCloudAuthConfig.
+func (in *GCPCloudAuthConfig) DeepCopy() *GCPCloudAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPCloudAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HubConfig) DeepCopyInto(out *HubConfig) {
+	*out = *in
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubConfig.
+func (in *HubConfig) DeepCopy() *HubConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HubConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuanceFailureSummary) DeepCopyInto(out *IssuanceFailureSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuanceFailureSummary.
+func (in *IssuanceFailureSummary) DeepCopy() *IssuanceFailureSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuanceFailureSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerConfig) DeepCopyInto(out *IssuerConfig) {
+	*out = *in
+	if in.ACME != nil {
+		in, out := &in.ACME, &out.ACME
+		*out = new(ACMEIssuerConfig)
+		**out = **in
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CAIssuerConfig)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultIssuerConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerConfig.
+func (in *IssuerConfig) DeepCopy() *IssuerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerStatus) DeepCopyInto(out *IssuerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerStatus.
+func (in *IssuerStatus) DeepCopy() *IssuerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LaggingNamespace) DeepCopyInto(out *LaggingNamespace) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LaggingNamespace.
+func (in *LaggingNamespace) DeepCopy() *LaggingNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(LaggingNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedResource) DeepCopyInto(out *ManagedResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedResource.
+func (in *ManagedResource) DeepCopy() *ManagedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringConfig) DeepCopyInto(out *MonitoringConfig) {
+	*out = *in
+	if in.AlertReceivers != nil {
+		in, out := &in.AlertReceivers, &out.AlertReceivers
+		*out = make([]AlertReceiver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfig.
+func (in *MonitoringConfig) DeepCopy() *MonitoringConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MountErrorCount) DeepCopyInto(out *MountErrorCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MountErrorCount.
+func (in *MountErrorCount) DeepCopy() *MountErrorCount {
+	if in == nil {
+		return nil
+	}
+	out := new(MountErrorCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceQuotaConfig) DeepCopyInto(out *NamespaceQuotaConfig) {
+	*out = *in
+	out.Requests = in.Requests
+	out.Limits = in.Limits
+	out.ContainerDefaults = in.ContainerDefaults
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceQuotaConfig.
+func (in *NamespaceQuotaConfig) DeepCopy() *NamespaceQuotaConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceQuotaConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceRotationStatus) DeepCopyInto(out *NamespaceRotationStatus) {
+	*out = *in
+	if in.Overdue != nil {
+		in, out := &in.Overdue, &out.Overdue
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRotationStatus.
+func (in *NamespaceRotationStatus) DeepCopy() *NamespaceRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSelectorConfig) DeepCopyInto(out *NamespaceSelectorConfig) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSelectorConfig.
+func (in *NamespaceSelectorConfig) DeepCopy() *NamespaceSelectorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSelectorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationReceiver) DeepCopyInto(out *NotificationReceiver) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NotificationEvent, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationReceiver.
+func (in *NotificationReceiver) DeepCopy() *NotificationReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationReceiverStatus) DeepCopyInto(out *NotificationReceiverStatus) {
+	*out = *in
+	if in.LastSentTime != nil {
+		in, out := &in.LastSentTime, &out.LastSentTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationReceiverStatus.
+func (in *NotificationReceiverStatus) DeepCopy() *NotificationReceiverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationReceiverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationsConfig) DeepCopyInto(out *NotificationsConfig) {
+	*out = *in
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = make([]NotificationReceiver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertificateExpiryThresholds != nil {
+		in, out := &in.CertificateExpiryThresholds, &out.CertificateExpiryThresholds
+		*out = make([]metav1.Duration, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalSecretFailureDuration != nil {
+		in, out := &in.ExternalSecretFailureDuration, &out.ExternalSecretFailureDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsConfig.
+func (in *NotificationsConfig) DeepCopy() *NotificationsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageBackupDestination) DeepCopyInto(out *ObjectStorageBackupDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageBackupDestination.
+func (in *ObjectStorageBackupDestination) DeepCopy() *ObjectStorageBackupDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageBackupDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfig) DeepCopyInto(out *OperatorConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfig.
+func (in *OperatorConfig) DeepCopy() *OperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorsConfig) DeepCopyInto(out *OperatorsConfig) {
+	*out = *in
+	out.CertManager = in.CertManager
+	out.ExternalSecrets = in.ExternalSecrets
+	out.SecretsStoreCSI = in.SecretsStoreCSI
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorsConfig.
+func (in *OperatorsConfig) DeepCopy() *OperatorsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDutyAlertReceiver) DeepCopyInto(out *PagerDutyAlertReceiver) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutyAlertReceiver.
+func (in *PagerDutyAlertReceiver) DeepCopy() *PagerDutyAlertReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(PagerDutyAlertReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseTransition) DeepCopyInto(out *PhaseTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseTransition.
+func (in *PhaseTransition) DeepCopy() *PhaseTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginConfig) DeepCopyInto(out *PluginConfig) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.Resources = in.Resources
+	in.Strategy.DeepCopyInto(&out.Strategy)
+	out.NamespaceQuota = in.NamespaceQuota
+	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
+	in.Service.DeepCopyInto(&out.Service)
+	out.Route = in.Route
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumeMounts != nil {
+		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Logging = in.Logging
+	in.ServiceMesh.DeepCopyInto(&out.ServiceMesh)
+	in.ServingCert.DeepCopyInto(&out.ServingCert)
+	out.SecurityHeaders = in.SecurityHeaders
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginConfig.
+func (in *PluginConfig) DeepCopy() *PluginConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginLoggingConfig) DeepCopyInto(out *PluginLoggingConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginLoggingConfig.
+func (in *PluginLoggingConfig) DeepCopy() *PluginLoggingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginLoggingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginSecurityHeadersConfig) DeepCopyInto(out *PluginSecurityHeadersConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSecurityHeadersConfig.
+func (in *PluginSecurityHeadersConfig) DeepCopy() *PluginSecurityHeadersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginSecurityHeadersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginServiceConfig) DeepCopyInto(out *PluginServiceConfig) {
+	*out = *in
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginServiceConfig.
+func (in *PluginServiceConfig) DeepCopy() *PluginServiceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginServiceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginServiceMeshConfig) DeepCopyInto(out *PluginServiceMeshConfig) {
+	*out = *in
+	if in.ExcludeInboundPorts != nil {
+		in, out := &in.ExcludeInboundPorts, &out.ExcludeInboundPorts
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginServiceMeshConfig.
+func (in *PluginServiceMeshConfig) DeepCopy() *PluginServiceMeshConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginServiceMeshConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginStatus) DeepCopyInto(out *PluginStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginStatus.
+func (in *PluginStatus) DeepCopy() *PluginStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoliciesConfig) DeepCopyInto(out *PoliciesConfig) {
+	*out = *in
+	if in.ProductionNamespaceSelector != nil {
+		in, out := &in.ProductionNamespaceSelector, &out.ProductionNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoliciesConfig.
+func (in *PoliciesConfig) DeepCopy() *PoliciesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PoliciesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectionConfig) DeepCopyInto(out *ProtectionConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectionConfig.
+func (in *ProtectionConfig) DeepCopy() *ProtectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PushSecretFailure) DeepCopyInto(out *PushSecretFailure) {
+	*out = *in
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushSecretFailure.
+func (in *PushSecretFailure) DeepCopy() *PushSecretFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(PushSecretFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PushSecretsStatus) DeepCopyInto(out *PushSecretsStatus) {
+	*out = *in
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]PushSecretFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushSecretsStatus.
+func (in *PushSecretsStatus) DeepCopy() *PushSecretsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PushSecretsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACConfig) DeepCopyInto(out *RBACConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACConfig.
+func (in *RBACConfig) DeepCopy() *RBACConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACStatus) DeepCopyInto(out *RBACStatus) {
+	*out = *in
+	if in.ClusterRoles != nil {
+		in, out := &in.ClusterRoles, &out.ClusterRoles
+		*out = make([]ClusterRoleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]RoleBindingsStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACStatus.
+func (in *RBACStatus) DeepCopy() *RBACStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadOnlyConfig) DeepCopyInto(out *ReadOnlyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadOnlyConfig.
+func (in *ReadOnlyConfig) DeepCopy() *ReadOnlyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadOnlyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedConfigurationStatus) DeepCopyInto(out *ResolvedConfigurationStatus) {
+	*out = *in
+	out.Resources = in.Resources
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedConfigurationStatus.
+func (in *ResolvedConfigurationStatus) DeepCopy() *ResolvedConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceConfig) DeepCopyInto(out *ResourceConfig) {
+	*out = *in
+	out.Requests = in.Requests
+	out.Limits = in.Limits
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceConfig.
+func (in *ResourceConfig) DeepCopy() *ResourceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMetricsConfig) DeepCopyInto(out *ResourceMetricsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMetricsConfig.
+func (in *ResourceMetricsConfig) DeepCopy() *ResourceMetricsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMetricsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRequirements.
+func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerificationFailure) DeepCopyInto(out *RestoreVerificationFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationFailure.
+func (in *RestoreVerificationFailure) DeepCopy() *RestoreVerificationFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerificationFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerificationStatus) DeepCopyInto(out *RestoreVerificationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]RestoreVerificationFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationStatus.
+func (in *RestoreVerificationStatus) DeepCopy() *RestoreVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleBindingsStatus) DeepCopyInto(out *RoleBindingsStatus) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]BindingSubjectStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBindingsStatus.
+func (in *RoleBindingsStatus) DeepCopy() *RoleBindingsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleBindingsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteConfig) DeepCopyInto(out *RouteConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteConfig.
+func (in *RouteConfig) DeepCopy() *RouteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanConfig) DeepCopyInto(out *ScanConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanConfig.
+func (in *ScanConfig) DeepCopy() *ScanConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderClassMountFailures) DeepCopyInto(out *SecretProviderClassMountFailures) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopErrors != nil {
+		in, out := &in.TopErrors, &out.TopErrors
+		*out = make([]MountErrorCount, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderClassMountFailures.
+func (in *SecretProviderClassMountFailures) DeepCopy() *SecretProviderClassMountFailures {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderClassMountFailures)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderClassReference) DeepCopyInto(out *SecretProviderClassReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderClassReference.
+func (in *SecretProviderClassReference) DeepCopy() *SecretProviderClassReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderClassReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderClassUsageStatus) DeepCopyInto(out *SecretProviderClassUsageStatus) {
+	*out = *in
+	if in.InUseBy != nil {
+		in, out := &in.InUseBy, &out.InUseBy
+		*out = make([]SecretProviderClassReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Missing != nil {
+		in, out := &in.Missing, &out.Missing
+		*out = make([]SecretProviderClassReference, len(*in))
+		copy(*out, *in)
+	}
+	in.MountFailures.DeepCopyInto(&out.MountFailures)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderClassUsageStatus.
+func (in *SecretProviderClassUsageStatus) DeepCopy() *SecretProviderClassUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderClassUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRotationPolicy) DeepCopyInto(out *SecretRotationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRotationPolicy.
+func (in *SecretRotationPolicy) DeepCopy() *SecretRotationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRotationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretRotationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRotationPolicyList) DeepCopyInto(out *SecretRotationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecretRotationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRotationPolicyList.
+func (in *SecretRotationPolicyList) DeepCopy() *SecretRotationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRotationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretRotationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRotationPolicySpec) DeepCopyInto(out *SecretRotationPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.MaxAge = in.MaxAge
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRotationPolicySpec.
+func (in *SecretRotationPolicySpec) DeepCopy() *SecretRotationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRotationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRotationPolicyStatus) DeepCopyInto(out *SecretRotationPolicyStatus) {
+	*out = *in
+	if in.LastEvaluated != nil {
+		in, out := &in.LastEvaluated, &out.LastEvaluated
+		*out = (*in).DeepCopy()
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]NamespaceRotationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRotationPolicyStatus.
+func (in *SecretRotationPolicyStatus) DeepCopy() *SecretRotationPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRotationPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretStoreConfig) DeepCopyInto(out *SecretStoreConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreConfig.
+func (in *SecretStoreConfig) DeepCopy() *SecretStoreConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretStoreConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretStoreStatus) DeepCopyInto(out *SecretStoreStatus) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(StoreCredentialsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreStatus.
+func (in *SecretStoreStatus) DeepCopy() *SecretStoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretStoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsAccessRequest) DeepCopyInto(out *SecretsAccessRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsAccessRequest.
+func (in *SecretsAccessRequest) DeepCopy() *SecretsAccessRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsAccessRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretsAccessRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsAccessRequestList) DeepCopyInto(out *SecretsAccessRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecretsAccessRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsAccessRequestList.
+func (in *SecretsAccessRequestList) DeepCopy() *SecretsAccessRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsAccessRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretsAccessRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsAccessRequestSpec) DeepCopyInto(out *SecretsAccessRequestSpec) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsAccessRequestSpec.
+func (in *SecretsAccessRequestSpec) DeepCopy() *SecretsAccessRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsAccessRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsAccessRequestStatus) DeepCopyInto(out *SecretsAccessRequestStatus) {
+	*out = *in
+	if in.ApprovedAt != nil {
+		in, out := &in.ApprovedAt, &out.ApprovedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.AuditTrail != nil {
+		in, out := &in.AuditTrail, &out.AuditTrail
+		*out = make([]AccessRequestAuditEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsAccessRequestStatus.
+func (in *SecretsAccessRequestStatus) DeepCopy() *SecretsAccessRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsAccessRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsComplianceReport) DeepCopyInto(out *SecretsComplianceReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsComplianceReport.
+func (in *SecretsComplianceReport) DeepCopy() *SecretsComplianceReport {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsComplianceReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretsComplianceReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsComplianceReportList) DeepCopyInto(out *SecretsComplianceReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecretsComplianceReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsComplianceReportList.
+func (in *SecretsComplianceReportList) DeepCopy() *SecretsComplianceReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsComplianceReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretsComplianceReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsComplianceReportStatus) DeepCopyInto(out *SecretsComplianceReportStatus) {
+	*out = *in
+	if in.GeneratedAt != nil {
+		in, out := &in.GeneratedAt, &out.GeneratedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Controls != nil {
+		in, out := &in.Controls, &out.Controls
+		*out = make([]ComplianceControlStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsComplianceReportStatus.
+func (in *SecretsComplianceReportStatus) DeepCopy() *SecretsComplianceReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsComplianceReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsHealthStatus) DeepCopyInto(out *SecretsHealthStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsHealthStatus.
+func (in *SecretsHealthStatus) DeepCopy() *SecretsHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsHealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsManagementConfig) DeepCopyInto(out *SecretsManagementConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementConfig.
+func (in *SecretsManagementConfig) DeepCopy() *SecretsManagementConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsManagementConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretsManagementConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsManagementConfigList) DeepCopyInto(out *SecretsManagementConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecretsManagementConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementConfigList.
+func (in *SecretsManagementConfigList) DeepCopy() *SecretsManagementConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsManagementConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretsManagementConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsManagementConfigSpec) DeepCopyInto(out *SecretsManagementConfigSpec) {
+	*out = *in
+	out.Features = in.Features
+	out.RBAC = in.RBAC
+	in.Visibility.DeepCopyInto(&out.Visibility)
+	in.Plugin.DeepCopyInto(&out.Plugin)
+	out.Operators = in.Operators
+	out.Protection = in.Protection
+	in.Policies.DeepCopyInto(&out.Policies)
+	in.Audit.DeepCopyInto(&out.Audit)
+	in.Notifications.DeepCopyInto(&out.Notifications)
+	if in.Stores != nil {
+		in, out := &in.Stores, &out.Stores
+		*out = make([]SecretStoreConfig, len(*in))
+		copy(*out, *in)
+	}
+	in.CloudAuth.DeepCopyInto(&out.CloudAuth)
+	if in.Issuers != nil {
+		in, out := &in.Issuers, &out.Issuers
+		*out = make([]IssuerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Compliance.DeepCopyInto(&out.Compliance)
+	out.Scan = in.Scan
+	out.Telemetry = in.Telemetry
+	in.Hub.DeepCopyInto(&out.Hub)
+	out.ResourceMetrics = in.ResourceMetrics
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	in.Backup.DeepCopyInto(&out.Backup)
+	if in.CommonLabels != nil {
+		in, out := &in.CommonLabels, &out.CommonLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CommonAnnotations != nil {
+		in, out := &in.CommonAnnotations, &out.CommonAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementConfigSpec.
+func (in *SecretsManagementConfigSpec) DeepCopy() *SecretsManagementConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsManagementConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsManagementConfigStatus) DeepCopyInto(out *SecretsManagementConfigStatus) {
+	*out = *in
+	in.RBAC.DeepCopyInto(&out.RBAC)
+	out.Plugin = in.Plugin
+	out.ResolvedConfiguration = in.ResolvedConfiguration
+	in.Features.DeepCopyInto(&out.Features)
+	in.DetectedOperators.DeepCopyInto(&out.DetectedOperators)
+	in.SecretProviderClasses.DeepCopyInto(&out.SecretProviderClasses)
+	out.SecurityPosture = in.SecurityPosture
+	if in.AuditSinks != nil {
+		in, out := &in.AuditSinks, &out.AuditSinks
+		*out = make([]AuditSinkStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NotificationReceivers != nil {
+		in, out := &in.NotificationReceivers, &out.NotificationReceivers
+		*out = make([]NotificationReceiverStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Stores != nil {
+		in, out := &in.Stores, &out.Stores
+		*out = make([]SecretStoreStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Issuers != nil {
+		in, out := &in.Issuers, &out.Issuers
+		*out = make([]IssuerStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.PushSecrets.DeepCopyInto(&out.PushSecrets)
+	in.ClusterExternalSecrets.DeepCopyInto(&out.ClusterExternalSecrets)
+	if in.IssuanceFailures != nil {
+		in, out := &in.IssuanceFailures, &out.IssuanceFailures
+		*out = make([]IssuanceFailureSummary, len(*in))
+		copy(*out, *in)
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = make([]VaultServerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CloudAuth != nil {
+		in, out := &in.CloudAuth, &out.CloudAuth
+		*out = new(CloudAuthStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Health = in.Health
+	if in.Fleet != nil {
+		in, out := &in.Fleet, &out.Fleet
+		*out = new(FleetStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreVerification != nil {
+		in, out := &in.RestoreVerification, &out.RestoreVerification
+		*out = new(RestoreVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagedResources != nil {
+		in, out := &in.ManagedResources, &out.ManagedResources
+		*out = make([]ManagedResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastReconcileDuration != nil {
+		in, out := &in.LastReconcileDuration, &out.LastReconcileDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]PhaseTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementConfigStatus.
+func (in *SecretsManagementConfigStatus) DeepCopy() *SecretsManagementConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsManagementConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsManagementTenant) DeepCopyInto(out *SecretsManagementTenant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementTenant.
+func (in *SecretsManagementTenant) DeepCopy() *SecretsManagementTenant {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsManagementTenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretsManagementTenant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsManagementTenantList) DeepCopyInto(out *SecretsManagementTenantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecretsManagementTenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementTenantList.
+func (in *SecretsManagementTenantList) DeepCopy() *SecretsManagementTenantList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsManagementTenantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretsManagementTenantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsManagementTenantSpec) DeepCopyInto(out *SecretsManagementTenantSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Operators = in.Operators
+	if in.RoleBindings != nil {
+		in, out := &in.RoleBindings, &out.RoleBindings
+		*out = make([]TenantRoleBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Quota.DeepCopyInto(&out.Quota)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementTenantSpec.
+func (in *SecretsManagementTenantSpec) DeepCopy() *SecretsManagementTenantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsManagementTenantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsManagementTenantStatus) DeepCopyInto(out *SecretsManagementTenantStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RejectedNamespaces != nil {
+		in, out := &in.RejectedNamespaces, &out.RejectedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.QuotaUsage != nil {
+		in, out := &in.QuotaUsage, &out.QuotaUsage
+		*out = make([]TenantQuotaUsage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsManagementTenantStatus.
+func (in *SecretsManagementTenantStatus) DeepCopy() *SecretsManagementTenantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsManagementTenantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsStoreCSIStatus) DeepCopyInto(out *SecretsStoreCSIStatus) {
+	*out = *in
+	out.DetectedOperator = in.DetectedOperator
+	if in.Driver != nil {
+		in, out := &in.Driver, &out.Driver
+		*out = new(CSIDaemonSetStatus)
+		**out = **in
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]CSIDaemonSetStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsStoreCSIStatus.
+func (in *SecretsStoreCSIStatus) DeepCopy() *SecretsStoreCSIStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsStoreCSIStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityPostureStatus) DeepCopyInto(out *SecurityPostureStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPostureStatus.
+func (in *SecurityPostureStatus) DeepCopy() *SecurityPostureStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityPostureStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServedFeature) DeepCopyInto(out *ServedFeature) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServedFeature.
+func (in *ServedFeature) DeepCopy() *ServedFeature {
+	if in == nil {
+		return nil
+	}
+	out := new(ServedFeature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountConfig) DeepCopyInto(out *ServiceAccountConfig) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TokenAudiences != nil {
+		in, out := &in.TokenAudiences, &out.TokenAudiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountConfig.
+func (in *ServiceAccountConfig) DeepCopy() *ServiceAccountConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingCertConfig) DeepCopyInto(out *ServingCertConfig) {
+	*out = *in
+	if in.FallbackAfter != nil {
+		in, out := &in.FallbackAfter, &out.FallbackAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingCertConfig.
+func (in *ServingCertConfig) DeepCopy() *ServingCertConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingCertConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreCredentialsStatus) DeepCopyInto(out *StoreCredentialsStatus) {
+	*out = *in
+	if in.Valid != nil {
+		in, out := &in.Valid, &out.Valid
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	in.LastChecked.DeepCopyInto(&out.LastChecked)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreCredentialsStatus.
+func (in *StoreCredentialsStatus) DeepCopy() *StoreCredentialsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreCredentialsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyslogSinkConfig) DeepCopyInto(out *SyslogSinkConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyslogSinkConfig.
+func (in *SyslogSinkConfig) DeepCopy() *SyslogSinkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SyslogSinkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TelemetryConfig) DeepCopyInto(out *TelemetryConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetryConfig.
+func (in *TelemetryConfig) DeepCopy() *TelemetryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TelemetryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantOperators) DeepCopyInto(out *TenantOperators) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantOperators.
+func (in *TenantOperators) DeepCopy() *TenantOperators {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantOperators)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantQuota) DeepCopyInto(out *TenantQuota) {
+	*out = *in
+	if in.MaxExternalSecrets != nil {
+		in, out := &in.MaxExternalSecrets, &out.MaxExternalSecrets
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxCertificates != nil {
+		in, out := &in.MaxCertificates, &out.MaxCertificates
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantQuota.
+func (in *TenantQuota) DeepCopy() *TenantQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantQuotaUsage) DeepCopyInto(out *TenantQuotaUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantQuotaUsage.
+func (in *TenantQuotaUsage) DeepCopy() *TenantQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantRoleBinding) DeepCopyInto(out *TenantRoleBinding) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantRoleBinding.
+func (in *TenantRoleBinding) DeepCopy() *TenantRoleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantRoleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultIssuerConfig) DeepCopyInto(out *VaultIssuerConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultIssuerConfig.
+func (in *VaultIssuerConfig) DeepCopy() *VaultIssuerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultIssuerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultServerStatus) DeepCopyInto(out *VaultServerStatus) {
+	*out = *in
+	if in.UsedBy != nil {
+		in, out := &in.UsedBy, &out.UsedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TokenValid != nil {
+		in, out := &in.TokenValid, &out.TokenValid
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultServerStatus.
+func (in *VaultServerStatus) DeepCopy() *VaultServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VeleroConfig) DeepCopyInto(out *VeleroConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VeleroConfig.
+func (in *VeleroConfig) DeepCopy() *VeleroConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VeleroConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VisibilityConfig) DeepCopyInto(out *VisibilityConfig) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(NamespaceSelectorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VisibilityConfig.
+func (in *VisibilityConfig) DeepCopy() *VisibilityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VisibilityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAlertReceiver) DeepCopyInto(out *WebhookAlertReceiver) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAlertReceiver.
+func (in *WebhookAlertReceiver) DeepCopy() *WebhookAlertReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAlertReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookSinkConfig) DeepCopyInto(out *WebhookSinkConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookSinkConfig.
+func (in *WebhookSinkConfig) DeepCopy() *WebhookSinkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookSinkConfig)
 	in.DeepCopyInto(out)
 	return out
 }
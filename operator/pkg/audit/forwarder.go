@@ -0,0 +1,214 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// sinkQueueSize is the number of messages buffered per sink; further messages are dropped
+const sinkQueueSize = 1000
+
+// DefaultBackoff is how a failed delivery is retried before the message is dropped
+var DefaultBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    5,
+	Cap:      30 * time.Second,
+}
+
+// SinkHealth reports delivery to one sink
+type SinkHealth struct {
+	// LastDelivery is when a message was last delivered
+	LastDelivery time.Time
+
+	// Dropped counts messages given up on after retries or because the queue was full
+	Dropped int64
+
+	// LastError is the last delivery error, cleared by a successful delivery
+	LastError string
+}
+
+// Forwarder delivers audit records and Warning events to external sinks. Each sink has its own
+// queue and worker, so a failing sink delays only its own messages.
+type Forwarder struct {
+	Log     logr.Logger
+	Backoff wait.Backoff
+
+	mu      sync.Mutex
+	ctx     context.Context
+	workers map[string]*sinkWorker
+}
+
+type sinkWorker struct {
+	config SinkConfig
+	sink   Sink
+	queue  chan message
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	health SinkHealth
+}
+
+// NewForwarder returns a Forwarder retrying with DefaultBackoff
+func NewForwarder(log logr.Logger) *Forwarder {
+	return &Forwarder{
+		Log:     log,
+		Backoff: DefaultBackoff,
+		workers: map[string]*sinkWorker{},
+	}
+}
+
+// NeedLeaderElection lets every replica forward the records it serves
+func (f *Forwarder) NeedLeaderElection() bool {
+	return false
+}
+
+// Start runs the sink workers until ctx is cancelled
+func (f *Forwarder) Start(ctx context.Context) error {
+	f.mu.Lock()
+	f.ctx = ctx
+	for _, w := range f.workers {
+		f.startWorker(w)
+	}
+	f.mu.Unlock()
+
+	<-ctx.Done()
+	return nil
+}
+
+// Configure replaces the set of sinks, keyed by name. Sinks whose configuration is unchanged
+// keep their queue and health; invalid configurations are reported through Health.
+func (f *Forwarder) Configure(configs map[string]SinkConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for name, w := range f.workers {
+		if cfg, ok := configs[name]; !ok || cfg != w.config {
+			if w.cancel != nil {
+				w.cancel()
+			}
+			delete(f.workers, name)
+		}
+	}
+
+	for name, cfg := range configs {
+		if _, ok := f.workers[name]; ok {
+			continue
+		}
+		w := &sinkWorker{config: cfg, queue: make(chan message, sinkQueueSize)}
+		sink, err := newSink(cfg)
+		if err != nil {
+			w.health.LastError = err.Error()
+		}
+		w.sink = sink
+		f.workers[name] = w
+		if f.ctx != nil {
+			f.startWorker(w)
+		}
+	}
+}
+
+// startWorker must be called with f.mu held
+func (f *Forwarder) startWorker(w *sinkWorker) {
+	if w.sink == nil || w.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(f.ctx)
+	w.cancel = cancel
+	go f.run(ctx, w)
+}
+
+func (f *Forwarder) run(ctx context.Context, w *sinkWorker) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-w.queue:
+			f.deliver(ctx, w, msg)
+		}
+	}
+}
+
+// deliver sends msg, retrying with backoff, and records the outcome
+func (f *Forwarder) deliver(ctx context.Context, w *sinkWorker, msg message) {
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, f.Backoff, func(ctx context.Context) (bool, error) {
+		if lastErr = w.sink.Send(ctx, msg); lastErr != nil {
+			return false, nil
+		}
+		return true, nil
+	})
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err == nil {
+		w.health.LastDelivery = time.Now()
+		w.health.LastError = ""
+		sinkDeliveriesTotal.WithLabelValues("delivered").Inc()
+		return
+	}
+	if lastErr == nil {
+		// Cancelled before the first attempt
+		lastErr = err
+	}
+	w.health.Dropped++
+	w.health.LastError = lastErr.Error()
+	sinkDeliveriesTotal.WithLabelValues("dropped").Inc()
+	f.Log.Error(lastErr, "Dropped audit message after retries")
+}
+
+// ForwardRecord queues an audit record for every sink
+func (f *Forwarder) ForwardRecord(record Record) {
+	f.forward(map[string]interface{}{"kind": "AuditRecord", "record": record}, false)
+}
+
+// ForwardEvent queues a Warning event for every sink
+func (f *Forwarder) ForwardEvent(event Event) {
+	f.forward(map[string]interface{}{"kind": "Event", "event": event}, true)
+}
+
+func (f *Forwarder) forward(v interface{}, warning bool) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		f.Log.Error(err, "Failed to encode audit message")
+		return
+	}
+	msg := message{payload: payload, warning: warning}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, w := range f.workers {
+		if w.sink == nil {
+			continue
+		}
+		select {
+		case w.queue <- msg:
+		default:
+			w.mu.Lock()
+			w.health.Dropped++
+			w.health.LastError = "queue full"
+			w.mu.Unlock()
+			sinkDeliveriesTotal.WithLabelValues("dropped").Inc()
+		}
+	}
+}
+
+// Health returns the delivery health of each configured sink
+func (f *Forwarder) Health() map[string]SinkHealth {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	health := make(map[string]SinkHealth, len(f.workers))
+	for name, w := range f.workers {
+		w.mu.Lock()
+		health[name] = w.health
+		w.mu.Unlock()
+	}
+	return health
+}
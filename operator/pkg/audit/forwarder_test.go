@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func startTestForwarder(t *testing.T) *Forwarder {
+	t.Helper()
+	f := NewForwarder(logr.Discard())
+	f.Backoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = f.Start(ctx) }()
+	return f
+}
+
+func TestForwarder_Webhook(t *testing.T) {
+	received := make(chan map[string]interface{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "Bearer s3cret", req.Header.Get("Authorization"))
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		received <- body
+	}))
+	defer srv.Close()
+
+	f := startTestForwarder(t)
+	f.Configure(map[string]SinkConfig{"siem": {Type: smv1alpha1.AuditSinkWebhook, URL: srv.URL, Token: "s3cret"}})
+	f.ForwardRecord(newTestRecord("cert"))
+
+	select {
+	case body := <-received:
+		assert.Equal(t, "AuditRecord", body["kind"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("record was not delivered")
+	}
+	require.Eventually(t, func() bool { return !f.Health()["siem"].LastDelivery.IsZero() }, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, f.Health()["siem"].LastError)
+}
+
+func TestForwarder_DropsAfterRetries(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	f := startTestForwarder(t)
+	f.Configure(map[string]SinkConfig{"siem": {Type: smv1alpha1.AuditSinkWebhook, URL: srv.URL}})
+	f.ForwardEvent(Event{Reason: "Failed", Resource: Resource{Kind: "ExternalSecret", Name: "db"}})
+
+	require.Eventually(t, func() bool { return f.Health()["siem"].Dropped == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, f.Health()["siem"].LastError, "503")
+	assert.Equal(t, 3, attempts)
+}
+
+func TestForwarder_Syslog(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	lines := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// The sink closes the connection after each message
+		line, _ := io.ReadAll(conn)
+		lines <- string(line)
+	}()
+
+	f := startTestForwarder(t)
+	f.Configure(map[string]SinkConfig{"syslog": {Type: smv1alpha1.AuditSinkSyslog, Address: ln.Addr().String()}})
+	f.ForwardEvent(Event{Reason: "Expired", Resource: Resource{Kind: "Certificate", Name: "web"}})
+
+	select {
+	case line := <-lines:
+		// Octet-counted, authpriv.warning
+		_, msg, ok := strings.Cut(line, " ")
+		require.True(t, ok)
+		assert.True(t, strings.HasPrefix(msg, "<84>1 "), msg)
+		assert.Contains(t, msg, `"kind":"Event"`)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not delivered")
+	}
+}
+
+func TestForwarder_InvalidConfigReported(t *testing.T) {
+	f := NewForwarder(logr.Discard())
+	f.Configure(map[string]SinkConfig{"broken": {Type: smv1alpha1.AuditSinkWebhook}})
+
+	assert.Contains(t, f.Health()["broken"].LastError, "requires a URL")
+
+	f.Configure(nil)
+	assert.Empty(t, f.Health())
+}
@@ -0,0 +1,154 @@
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/apiauth"
+)
+
+const (
+	// DefaultRateLimit is the sustained number of requests per second each user may send
+	DefaultRateLimit = 20
+
+	// DefaultRateBurst is the number of requests a user may send at once
+	DefaultRateBurst = 50
+
+	// DefaultMaxBodyBytes bounds the body of any request
+	DefaultMaxBodyBytes = 1 << 20
+
+	// addressLimitFactor scales the per-user limit to the limit of each client address, applied
+	// before the token is reviewed. Every console user reaches the operator through the few
+	// addresses of the console proxy, so one address carries many users.
+	addressLimitFactor = 10
+
+	// clientIdleTimeout is how long an idle client's limiter is kept
+	clientIdleTimeout = 10 * time.Minute
+
+	// maxClients bounds the number of limiters kept. Idle ones are dropped once it is reached,
+	// and all of them if that is not enough.
+	maxClients = 10000
+)
+
+// clientLimiter is the rate limiter of one client
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// limiter throttles each client, a user or an address, separately
+type limiter struct {
+	limit rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+func newLimiter(limit rate.Limit, burst int) *limiter {
+	return &limiter{limit: limit, burst: burst, clients: map[string]*clientLimiter{}}
+}
+
+// allow reports whether the client may send a request now
+func (l *limiter) allow(client string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c, ok := l.clients[client]
+	if !ok {
+		if len(l.clients) >= maxClients || now.Sub(l.lastSweep) > clientIdleTimeout {
+			l.sweep(now)
+		}
+		c = &clientLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[client] = c
+	}
+	c.lastSeen = now
+	return c.limiter.AllowN(now, 1)
+}
+
+// sweep drops the limiters of idle clients, or all of them if there are still too many. l.mu
+// must be held.
+func (l *limiter) sweep(now time.Time) {
+	for key, c := range l.clients {
+		if now.Sub(c.lastSeen) > clientIdleTimeout {
+			delete(l.clients, key)
+		}
+	}
+	if len(l.clients) >= maxClients {
+		l.clients = map[string]*clientLimiter{}
+	}
+	l.lastSweep = now
+}
+
+// remoteHost returns the address the request came from, without its port
+func remoteHost(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// authenticator returns the user owning the request's bearer token, or nil when it is missing or invalid
+type authenticator func(ctx context.Context, req *http.Request) (*authenticationv1.UserInfo, error)
+
+// withLimits rejects requests over the rate limit with 429 and bodies larger than maxBodyBytes
+// with 413, before they reach handlers that call the API server. Each address may send
+// addressLimitFactor times the per-user limit before its token is reviewed, so random tokens
+// cannot flood the API server with TokenReviews. Authenticated requests are then limited per
+// user and passed on with the user in their context; the others are rejected with 401. A zero
+// limit or maxBodyBytes disables the respective check.
+func withLimits(next http.Handler, authenticate authenticator, log logr.Logger, limit rate.Limit, burst int, maxBodyBytes int64) http.Handler {
+	var addresses, users *limiter
+	if limit > 0 {
+		addresses = newLimiter(limit*addressLimitFactor, burst*addressLimitFactor)
+		users = newLimiter(limit, burst)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if addresses != nil && !addresses.allow(remoteHost(req), time.Now()) {
+			throttle(w, "address")
+			return
+		}
+		if users != nil {
+			ctx := req.Context()
+			user, err := authenticate(ctx, req)
+			if err != nil {
+				log.Error(err, "Failed to authenticate request", "path", req.URL.Path)
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return
+			}
+			if user == nil {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if !users.allow(user.Username, time.Now()) {
+				throttle(w, "rate")
+				return
+			}
+			req = req.WithContext(apiauth.WithUser(ctx, user))
+		}
+		if maxBodyBytes > 0 {
+			if req.ContentLength > maxBodyBytes {
+				throttledTotal.WithLabelValues("size").Inc()
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			req.Body = http.MaxBytesReader(w, req.Body, maxBodyBytes)
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// throttle answers a request over a rate limit
+func throttle(w http.ResponseWriter, reason string) {
+	throttledTotal.WithLabelValues(reason).Inc()
+	w.Header().Set("Retry-After", "1")
+	http.Error(w, "too many requests", http.StatusTooManyRequests)
+}
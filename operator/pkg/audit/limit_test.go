@@ -0,0 +1,137 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/apiauth"
+)
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(1, 2)
+	now := time.Now()
+	assert.True(t, l.allow("alice", now))
+	assert.True(t, l.allow("alice", now))
+	assert.False(t, l.allow("alice", now), "burst exhausted")
+	assert.True(t, l.allow("bob", now), "clients are limited separately")
+	assert.True(t, l.allow("alice", now.Add(time.Second)), "refilled")
+
+	// Idle clients are forgotten
+	l.allow("carol", now.Add(2*clientIdleTimeout))
+	assert.NotContains(t, l.clients, "alice")
+	assert.Contains(t, l.clients, "carol")
+}
+
+func TestLimiter_Bounded(t *testing.T) {
+	l := newLimiter(1, 1)
+	now := time.Now()
+	for i := 0; i < maxClients; i++ {
+		l.allow(strconv.Itoa(i), now)
+	}
+	assert.True(t, l.allow("0", now.Add(time.Second)), "known clients are kept")
+	assert.Len(t, l.clients, maxClients)
+
+	assert.True(t, l.allow("new", now.Add(time.Second)))
+	assert.Len(t, l.clients, 1, "a full map of active clients is emptied")
+}
+
+func TestRemoteHost(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:5000"
+	assert.Equal(t, "10.0.0.1", remoteHost(req))
+	req.RemoteAddr = "10.0.0.1"
+	assert.Equal(t, "10.0.0.1", remoteHost(req))
+}
+
+func TestServer_Limits(t *testing.T) {
+	s := newTestServer(t, false)
+	s.RateLimit, s.RateBurst, s.MaxBodyBytes = 1, 2, 16
+	s.Endpoints = map[string]http.Handler{
+		"/api/v1/other": http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if _, err := io.ReadAll(req.Body); err != nil {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			}
+		}),
+	}
+	handler := s.Handler()
+	do := func(token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/other", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, do("alice-token", "{}").Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, do("alice-token", strings.Repeat("x", 17)).Code)
+	rec := do("alice-token", "{}")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, do("bob-token", "{}").Code)
+
+	// A body without a length is cut off at the limit
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/other", io.NopCloser(strings.NewReader(strings.Repeat("x", 17))))
+	req.ContentLength = -1
+	req.Header.Set("Authorization", "Bearer carol-token")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestServer_LimitsBeforeAuthentication(t *testing.T) {
+	s := newTestServer(t, false)
+	s.RateLimit, s.RateBurst = 1, 2
+	var tokenReviews int
+	s.Client = interceptor.NewClient(s.Client.(client.WithWatch), interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, ok := obj.(*authenticationv1.TokenReview); ok {
+				tokenReviews++
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	})
+	s.Tokens.Client = s.Client
+	var seen *authenticationv1.UserInfo
+	s.Endpoints = map[string]http.Handler{
+		"/api/v1/other": http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			seen, _ = apiauth.Authenticate(req.Context(), s.Client, req)
+		}),
+	}
+	handler := s.Handler()
+	do := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/other", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// The handler gets the user without reviewing the token again, and so does the next request
+	require.Equal(t, http.StatusOK, do("alice-token"))
+	assert.Equal(t, "alice", seen.Username)
+	assert.Equal(t, 1, tokenReviews)
+	require.Equal(t, http.StatusOK, do("alice-token"))
+	assert.Equal(t, 1, tokenReviews, "accepted tokens are cached")
+
+	// Random tokens are not tracked per token, and stop being reviewed once the address is over its limit
+	codes := map[int]int{}
+	for i := 0; i < 3*addressLimitFactor*s.RateBurst; i++ {
+		codes[do(fmt.Sprintf("random-%d", i))]++
+	}
+	assert.Equal(t, addressLimitFactor*s.RateBurst-2, codes[http.StatusUnauthorized])
+	assert.Equal(t, 2*addressLimitFactor*s.RateBurst+2, codes[http.StatusTooManyRequests])
+	assert.Equal(t, addressLimitFactor*s.RateBurst-1, tokenReviews)
+}
@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// recordsTotal counts audited operations by verb, resource kind and outcome
+var recordsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "secrets_management_audit_records_total",
+		Help: "Number of operations performed through the console plugin, by verb, kind and outcome",
+	},
+	[]string{"verb", "kind", "outcome"},
+)
+
+// sinkDeliveriesTotal counts messages delivered to or dropped by audit sinks
+var sinkDeliveriesTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "secrets_management_audit_sink_deliveries_total",
+		Help: "Number of audit messages delivered to or dropped by external sinks, by result",
+	},
+	[]string{"result"},
+)
+
+// throttledTotal counts requests rejected for exceeding the address or user rate limit or the
+// body size limit
+var throttledTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "secrets_management_api_requests_throttled_total",
+		Help: "Number of plugin API requests rejected by the operator, by reason (address, rate or size)",
+	},
+	[]string{"reason"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(recordsTotal, sinkDeliveriesTotal, throttledTotal)
+}
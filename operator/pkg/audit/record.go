@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"time"
+)
+
+// Verbs that can be audited
+const (
+	VerbCreate = "create"
+	VerbUpdate = "update"
+	VerbDelete = "delete"
+)
+
+// Outcome is the result of an audited operation
+type Outcome string
+
+const (
+	// OutcomeSuccess means the operation was accepted by the API server
+	OutcomeSuccess Outcome = "Success"
+
+	// OutcomeFailure means the operation was rejected or failed
+	OutcomeFailure Outcome = "Failure"
+)
+
+// Resource identifies the object an operation was performed on
+type Resource struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// Record is one operation performed through the console plugin
+type Record struct {
+	Time     time.Time `json:"time"`
+	User     string    `json:"user"`
+	Verb     string    `json:"verb"`
+	Resource Resource  `json:"resource"`
+	Outcome  Outcome   `json:"outcome"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// Event is a Warning event about a secrets-related resource, such as a failed secret sync or
+// an expired certificate
+type Event struct {
+	Time     time.Time `json:"time"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Resource Resource  `json:"resource"`
+}
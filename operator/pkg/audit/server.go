@@ -0,0 +1,248 @@
+package audit
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/apiauth"
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// RecordsPath is the endpoint the plugin reaches through the console proxy. GET lists records,
+// newest first; POST appends a record for the calling user.
+const RecordsPath = "/api/v1/records"
+
+// maxRequestBytes bounds the size of a posted record
+const maxRequestBytes = 16 * 1024
+
+// Server serves the audit endpoint over TLS. Callers are identified by the bearer token the
+// console proxy forwards, so a record's user cannot be chosen by the caller. Listing records
+// requires permission to get the SecretsManagementConfig.
+type Server struct {
+	// Client reads the SecretsManagementConfig and creates SubjectAccessReviews
+	Client client.Client
+	Store  Store
+	Log    logr.Logger
+
+	// Tokens authenticates the callers of every endpoint
+	Tokens *apiauth.TokenCache
+
+	// Forwarder, when set, forwards every stored record to the configured sinks
+	Forwarder *Forwarder
+
+	// ConfigName is the SecretsManagementConfig whose spec.audit controls the endpoint
+	ConfigName string
+
+	// Addr is the address to listen on
+	Addr string
+
+	// CertDir holds tls.crt and tls.key. They are read on each handshake so rotated
+	// serving certificates are picked up without a restart.
+	CertDir string
+
+	// Endpoints are further handlers for the plugin, by path, served on the same listener
+	Endpoints map[string]http.Handler
+
+	// RateLimit is the sustained number of requests per second each user may send to any
+	// endpoint, with bursts of up to RateBurst. When set, requests are authenticated before they
+	// reach the endpoints, which find the user in the request context. Users are not limited
+	// when zero.
+	RateLimit rate.Limit
+	RateBurst int
+
+	// MaxBodyBytes bounds the body of any request; unbounded when zero
+	MaxBodyBytes int64
+}
+
+// NeedLeaderElection lets every replica serve the endpoint
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves until ctx is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: s.getCertificate,
+		},
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	s.Log.Info("Serving audit endpoint", "addr", s.Addr)
+	if err := srv.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(filepath.Join(s.CertDir, "tls.crt"), filepath.Join(s.CertDir, "tls.key"))
+	if err != nil {
+		return nil, err
+	}
+	return &cert, nil
+}
+
+// Handler returns the HTTP handler for the audit endpoint
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(RecordsPath, s.handleRecords)
+	for path, handler := range s.Endpoints {
+		mux.Handle(path, handler)
+	}
+	return withLimits(mux, s.Tokens.Authenticate, s.Log, s.RateLimit, s.RateBurst, s.MaxBodyBytes)
+}
+
+func (s *Server) handleRecords(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	config := &smv1alpha1.SecretsManagementConfig{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Name: s.ConfigName}, config); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, "audit is disabled", http.StatusNotFound)
+			return
+		}
+		s.Log.Error(err, "Failed to get SecretsManagementConfig")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if !config.Spec.Audit.Enabled {
+		http.Error(w, "audit is disabled", http.StatusNotFound)
+		return
+	}
+
+	user, err := s.Tokens.Authenticate(ctx, req)
+	if err != nil {
+		s.Log.Error(err, "Failed to authenticate audit request")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if user == nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		s.listRecords(w, req, user)
+	case http.MethodPost:
+		s.appendRecord(w, req, user, int(config.Spec.Audit.MaxRecords))
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// listRecords writes the stored records, newest first, limited by the optional limit query parameter
+func (s *Server) listRecords(w http.ResponseWriter, req *http.Request, user *authenticationv1.UserInfo) {
+	ctx := req.Context()
+
+	allowed, err := s.canGetConfig(ctx, user)
+	if err != nil {
+		s.Log.Error(err, "Failed to authorize audit request")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if !allowed {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	records, err := s.Store.List(ctx)
+	if err != nil {
+		s.Log.Error(err, "Failed to list audit records")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	newestFirst := make([]Record, 0, len(records))
+	for i := len(records) - 1; i >= 0; i-- {
+		newestFirst = append(newestFirst, records[i])
+	}
+	if limit, err := strconv.Atoi(req.URL.Query().Get("limit")); err == nil && limit > 0 && limit < len(newestFirst) {
+		newestFirst = newestFirst[:limit]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"records": newestFirst})
+}
+
+// appendRecord stores the posted record under the authenticated user's name
+func (s *Server) appendRecord(w http.ResponseWriter, req *http.Request, user *authenticationv1.UserInfo, maxRecords int) {
+	var record Record
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRequestBytes)).Decode(&record); err != nil {
+		http.Error(w, fmt.Sprintf("invalid record: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := validateRecord(record); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	record.Time = time.Now().UTC()
+	record.User = user.Username
+	if err := s.Store.Append(req.Context(), record, maxRecords); err != nil {
+		s.Log.Error(err, "Failed to store audit record")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	recordsTotal.WithLabelValues(record.Verb, record.Resource.Kind, string(record.Outcome)).Inc()
+	if s.Forwarder != nil {
+		s.Forwarder.ForwardRecord(record)
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// validateRecord checks the fields a caller supplies
+func validateRecord(record Record) error {
+	switch record.Verb {
+	case VerbCreate, VerbUpdate, VerbDelete:
+	default:
+		return fmt.Errorf("verb must be one of %s, %s or %s", VerbCreate, VerbUpdate, VerbDelete)
+	}
+	switch record.Outcome {
+	case OutcomeSuccess, OutcomeFailure:
+	default:
+		return fmt.Errorf("outcome must be %s or %s", OutcomeSuccess, OutcomeFailure)
+	}
+	if record.Resource.Kind == "" || record.Resource.Name == "" {
+		return fmt.Errorf("resource kind and name are required")
+	}
+	return nil
+}
+
+// canGetConfig reports whether the user may get the SecretsManagementConfig
+func (s *Server) canGetConfig(ctx context.Context, user *authenticationv1.UserInfo) (bool, error) {
+	status, err := apiauth.Authorize(ctx, s.Client, user, &authorizationv1.ResourceAttributes{
+		Group:    smv1alpha1.GroupVersion.Group,
+		Resource: "secretsmanagementconfigs",
+		Verb:     "get",
+		Name:     s.ConfigName,
+	}, nil)
+	return status.Allowed, err
+}
@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/apiauth"
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// newTestServer returns a server whose TokenReviews accept "<user>-token" and whose
+// SubjectAccessReviews only allow the user "admin"
+func newTestServer(t *testing.T, auditEnabled bool) *Server {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = smv1alpha1.AddToScheme(scheme)
+
+	config := &smv1alpha1.SecretsManagementConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec:       smv1alpha1.SecretsManagementConfigSpec{Audit: smv1alpha1.AuditConfig{Enabled: auditEnabled, MaxRecords: 10}},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(config).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				switch review := obj.(type) {
+				case *authenticationv1.TokenReview:
+					if user, ok := strings.CutSuffix(review.Spec.Token, "-token"); ok {
+						review.Status.Authenticated = true
+						review.Status.User.Username = user
+					}
+					return nil
+				case *authorizationv1.SubjectAccessReview:
+					review.Status.Allowed = review.Spec.User == "admin"
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	return &Server{
+		Client:     c,
+		Store:      &ConfigMapStore{Client: c, Namespace: "plugin-ns"},
+		Log:        logr.Discard(),
+		Tokens:     &apiauth.TokenCache{Client: c},
+		ConfigName: "cluster",
+	}
+}
+
+func doRequest(s *Server, method, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, RecordsPath, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_AppendUsesAuthenticatedUser(t *testing.T) {
+	s := newTestServer(t, true)
+
+	body := `{"user":"someone-else","verb":"delete","resource":{"kind":"Secret","namespace":"team-a","name":"db"},"outcome":"Success"}`
+	rec := doRequest(s, http.MethodPost, "alice-token", body)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	records, err := s.Store.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "alice", records[0].User)
+	assert.False(t, records[0].Time.IsZero())
+}
+
+func TestServer_RejectsInvalidRecord(t *testing.T) {
+	s := newTestServer(t, true)
+
+	rec := doRequest(s, http.MethodPost, "alice-token", `{"verb":"get","resource":{"kind":"Secret","name":"db"},"outcome":"Success"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServer_RequiresAuthentication(t *testing.T) {
+	s := newTestServer(t, true)
+
+	assert.Equal(t, http.StatusUnauthorized, doRequest(s, http.MethodGet, "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, doRequest(s, http.MethodGet, "garbage", "").Code)
+}
+
+func TestServer_ListRequiresConfigAccess(t *testing.T) {
+	s := newTestServer(t, true)
+	for _, name := range []string{"first", "second"} {
+		body := `{"verb":"create","resource":{"kind":"Secret","name":"` + name + `"},"outcome":"Success"}`
+		require.Equal(t, http.StatusCreated, doRequest(s, http.MethodPost, "alice-token", body).Code)
+	}
+
+	assert.Equal(t, http.StatusForbidden, doRequest(s, http.MethodGet, "alice-token", "").Code)
+
+	rec := doRequest(s, http.MethodGet, "admin-token", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct {
+		Records []Record `json:"records"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Records, 2)
+	assert.Equal(t, "second", resp.Records[0].Resource.Name, "records are listed newest first")
+}
+
+func TestServer_DisabledReturnsNotFound(t *testing.T) {
+	s := newTestServer(t, false)
+
+	assert.Equal(t, http.StatusNotFound, doRequest(s, http.MethodGet, "admin-token", "").Code)
+}
+
+func TestServer_ServesEndpoints(t *testing.T) {
+	s := newTestServer(t, false)
+	s.Endpoints = map[string]http.Handler{
+		"/api/v1/other": http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusTeapot) }),
+	}
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/other", nil))
+	assert.Equal(t, http.StatusTeapot, rec.Code, "endpoints are served even while audit is disabled")
+}
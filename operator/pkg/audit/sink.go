@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// sinkTimeout bounds a single delivery attempt
+const sinkTimeout = 10 * time.Second
+
+// SinkConfig is the resolved configuration of a sink, with secrets already read
+type SinkConfig struct {
+	Type     smv1alpha1.AuditSinkType
+	URL      string
+	Token    string
+	Address  string
+	Protocol string
+}
+
+// Sink delivers one message to an external destination
+type Sink interface {
+	Send(ctx context.Context, msg message) error
+}
+
+// message is a JSON payload; warning marks Warning events so syslog can raise their severity
+type message struct {
+	payload []byte
+	warning bool
+}
+
+// newSink builds the sink described by cfg
+func newSink(cfg SinkConfig) (Sink, error) {
+	switch cfg.Type {
+	case smv1alpha1.AuditSinkWebhook:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("webhook sink requires a URL")
+		}
+		return &webhookSink{url: cfg.URL, token: cfg.Token, client: &http.Client{Timeout: sinkTimeout}}, nil
+	case smv1alpha1.AuditSinkSyslog:
+		if cfg.Address == "" {
+			return nil, fmt.Errorf("syslog sink requires an address")
+		}
+		protocol := cfg.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		hostname, _ := os.Hostname()
+		return &syslogSink{network: protocol, address: cfg.Address, hostname: hostname}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", cfg.Type)
+	}
+}
+
+// webhookSink POSTs each message as JSON
+type webhookSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func (s *webhookSink) Send(ctx context.Context, msg message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(msg.payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// syslogSink sends each message as an RFC 5424 syslog message from the authpriv facility,
+// using octet-counting framing (RFC 6587) over TCP
+type syslogSink struct {
+	network  string
+	address  string
+	hostname string
+}
+
+const (
+	syslogFacilityAuthpriv = 10
+	syslogSeverityWarning  = 4
+	syslogSeverityNotice   = 5
+)
+
+func (s *syslogSink) Send(ctx context.Context, msg message) error {
+	severity := syslogSeverityNotice
+	if msg.warning {
+		severity = syslogSeverityWarning
+	}
+	hostname := s.hostname
+	if hostname == "" {
+		hostname = "-"
+	}
+	line := fmt.Sprintf("<%d>1 %s %s secrets-management-operator - audit - %s",
+		syslogFacilityAuthpriv*8+severity, time.Now().UTC().Format(time.RFC3339Nano), hostname, msg.payload)
+	if s.network == "tcp" {
+		line = fmt.Sprintf("%d %s", len(line), line)
+	}
+
+	dialer := &net.Dialer{Timeout: sinkTimeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetWriteDeadline(time.Now().Add(sinkTimeout)); err != nil {
+		return err
+	}
+	_, err = conn.Write([]byte(line))
+	return err
+}
@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConfigMapName is the ConfigMap holding the audit records
+	ConfigMapName = "secrets-management-audit"
+
+	// RecordsKey is the ConfigMap key holding the records, one JSON object per line, oldest first
+	RecordsKey = "records.jsonl"
+
+	// DefaultMaxRecords is the number of records kept when spec.audit.maxRecords is unset
+	DefaultMaxRecords = 500
+)
+
+// Store persists audit records
+type Store interface {
+	// Append adds a record, dropping the oldest records beyond maxRecords
+	Append(ctx context.Context, record Record, maxRecords int) error
+
+	// List returns the stored records, oldest first
+	List(ctx context.Context) ([]Record, error)
+}
+
+// ConfigMapStore keeps audit records in a ConfigMap used as a ring buffer. Records are only
+// ever appended or dropped from the front; existing records are never rewritten.
+type ConfigMapStore struct {
+	Client    client.Client
+	Namespace string
+}
+
+var _ Store = &ConfigMapStore{}
+
+// Append adds a record, creating the ConfigMap on first use and retrying on conflict
+func (s *ConfigMapStore) Append(ctx context.Context, record Record, maxRecords int) error {
+	if maxRecords <= 0 {
+		maxRecords = DefaultMaxRecords
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		err := s.Client.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: ConfigMapName}, cm)
+		if errors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ConfigMapName,
+					Namespace: s.Namespace,
+					Labels: map[string]string{
+						"app.kubernetes.io/part-of":    "ocp-secrets-management",
+						"app.kubernetes.io/managed-by": "secrets-management-operator",
+					},
+				},
+				Data: map[string]string{RecordsKey: string(line) + "\n"},
+			}
+			err = s.Client.Create(ctx, cm)
+			if errors.IsAlreadyExists(err) {
+				// Lost the race with a concurrent append; retry against the stored object
+				return errors.NewConflict(corev1.Resource("configmaps"), ConfigMapName, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		lines := splitLines(cm.Data[RecordsKey])
+		lines = append(lines, string(line))
+		if len(lines) > maxRecords {
+			lines = lines[len(lines)-maxRecords:]
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[RecordsKey] = strings.Join(lines, "\n") + "\n"
+		return s.Client.Update(ctx, cm)
+	})
+}
+
+// List returns the stored records, oldest first. Lines that fail to decode are skipped.
+func (s *ConfigMapStore) List(ctx context.Context) ([]Record, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: ConfigMapName}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	lines := splitLines(cm.Data[RecordsKey])
+	records := make([]Record, 0, len(lines))
+	for _, line := range lines {
+		var record Record
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// splitLines returns the non-empty lines of data
+func splitLines(data string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestRecord(name string) Record {
+	return Record{
+		Time:     time.Now().UTC(),
+		User:     "alice",
+		Verb:     VerbDelete,
+		Resource: Resource{Group: "cert-manager.io", Kind: "Certificate", Namespace: "team-a", Name: name},
+		Outcome:  OutcomeSuccess,
+	}
+}
+
+func TestConfigMapStore_AppendAndList(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	store := &ConfigMapStore{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Namespace: "plugin-ns"}
+	ctx := context.Background()
+
+	records, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, store.Append(ctx, newTestRecord(fmt.Sprintf("cert-%d", i)), 3))
+	}
+
+	records, err = store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "cert-2", records[0].Resource.Name, "oldest records are dropped first")
+	assert.Equal(t, "cert-4", records[2].Resource.Name)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, store.Client.Get(ctx, types.NamespacedName{Namespace: "plugin-ns", Name: ConfigMapName}, cm))
+	assert.Equal(t, "secrets-management-operator", cm.Labels["app.kubernetes.io/managed-by"])
+}
+
+func TestConfigMapStore_SkipsCorruptLines(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	cm := &corev1.ConfigMap{Data: map[string]string{RecordsKey: "not json\n"}}
+	cm.Name = ConfigMapName
+	cm.Namespace = "plugin-ns"
+	store := &ConfigMapStore{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build(), Namespace: "plugin-ns"}
+
+	require.NoError(t, store.Append(context.Background(), newTestRecord("cert"), 0))
+
+	records, err := store.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "cert", records[0].Resource.Name)
+}
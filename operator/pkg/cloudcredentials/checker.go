@@ -0,0 +1,310 @@
+// Package cloudcredentials checks that the credentials of cloud secret stores are accepted by
+// their provider, by asking for the caller identity or an access token with them, and that
+// service account tokens federate with the provider's workload identity. No secret is read with
+// the credentials.
+package cloudcredentials
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/objectstorage"
+)
+
+const (
+	// DefaultAzureAuthorityHost is the Microsoft Entra ID endpoint of the Azure public cloud
+	DefaultAzureAuthorityHost = "https://login.microsoftonline.com"
+
+	// DefaultGoogleTokenURL is the OAuth 2.0 token endpoint of Google Cloud
+	DefaultGoogleTokenURL = "https://oauth2.googleapis.com/token"
+
+	// checkTimeout bounds each request to a provider
+	checkTimeout = 10 * time.Second
+
+	// maxResponseSize bounds how much of a provider response is read
+	maxResponseSize = 64 << 10
+
+	azureKeyVaultScope = "https://vault.azure.net/.default"
+	googleCloudScope   = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// RejectedError reports credentials the provider refused, as opposed to a provider that could
+// not be reached
+type RejectedError struct {
+	Message string
+}
+
+func (e *RejectedError) Error() string {
+	return e.Message
+}
+
+// Checker checks cloud credentials against their provider
+type Checker struct {
+	// HTTPClient sends the requests; a client with a 10s timeout is used when nil
+	HTTPClient *http.Client
+
+	// STSEndpoint is the AWS STS endpoint for every region; the regional endpoint when empty
+	STSEndpoint string
+
+	// AzureAuthorityHost is the Microsoft Entra ID endpoint; DefaultAzureAuthorityHost when empty
+	AzureAuthorityHost string
+
+	// GoogleTokenURL is the Google OAuth 2.0 token endpoint; DefaultGoogleTokenURL when empty
+	GoogleTokenURL string
+
+	// GoogleSTSURL is the Google Security Token Service endpoint; DefaultGoogleSTSURL when empty
+	GoogleSTSURL string
+
+	// GoogleIAMCredentialsURL is the Google IAM Service Account Credentials API;
+	// DefaultGoogleIAMCredentialsURL when empty
+	GoogleIAMCredentialsURL string
+
+	now func() time.Time
+}
+
+// AWS checks an IAM access key with STS GetCallerIdentity and returns the ARN it authenticates as
+func (c *Checker) AWS(ctx context.Context, region, accessKeyID, secretAccessKey string) (string, error) {
+	body := []byte("Action=GetCallerIdentity&Version=2011-06-15")
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.stsURL(region), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	objectstorage.SignV4(req, body, "sts", region, accessKeyID, secretAccessKey, c.clock())
+
+	data, err := c.doSTS(req)
+	if err != nil {
+		return "", err
+	}
+	var identity struct {
+		Arn string `xml:"GetCallerIdentityResult>Arn"`
+	}
+	if err := xml.Unmarshal(data, &identity); err != nil {
+		return "", fmt.Errorf("invalid STS response: %w", err)
+	}
+	return identity.Arn, nil
+}
+
+// stsURL returns the STS endpoint of region
+func (c *Checker) stsURL(region string) string {
+	endpoint := c.STSEndpoint
+	if endpoint == "" {
+		endpoint = "https://sts." + region + ".amazonaws.com"
+		if strings.HasPrefix(region, "cn-") {
+			endpoint += ".cn"
+		}
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/"
+}
+
+// doSTS sends an STS request and returns the body of a successful response
+func (c *Checker) doSTS(req *http.Request) ([]byte, error) {
+	data, status, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		var failure struct {
+			Code    string `xml:"Error>Code"`
+			Message string `xml:"Error>Message"`
+		}
+		if xml.Unmarshal(data, &failure) != nil || failure.Code == "" {
+			return nil, fmt.Errorf("STS responded with %d", status)
+		}
+		return nil, providerError(status, failure.Code+": "+failure.Message)
+	}
+	return data, nil
+}
+
+// Azure checks a service principal's client secret by requesting a Key Vault token, and returns
+// the client ID it authenticates as
+func (c *Checker) Azure(ctx context.Context, tenantID, clientID, clientSecret string) (string, error) {
+	host := c.AzureAuthorityHost
+	if host == "" {
+		host = DefaultAzureAuthorityHost
+	}
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"scope":         {azureKeyVaultScope},
+	}
+	tokenURL := strings.TrimSuffix(host, "/") + "/" + url.PathEscape(tenantID) + "/oauth2/v2.0/token"
+	if _, err := c.requestToken(ctx, tokenURL, form); err != nil {
+		return "", err
+	}
+	return clientID, nil
+}
+
+// GCP checks a service account key by exchanging a JWT signed with it for an access token, and
+// returns the service account email
+func (c *Checker) GCP(ctx context.Context, keyJSON []byte) (string, error) {
+	var key struct {
+		Type         string `json:"type"`
+		ClientEmail  string `json:"client_email"`
+		PrivateKeyID string `json:"private_key_id"`
+		PrivateKey   string `json:"private_key"`
+	}
+	if err := json.Unmarshal(keyJSON, &key); err != nil {
+		return "", &RejectedError{Message: fmt.Sprintf("invalid service account key: %v", err)}
+	}
+	if key.Type != "service_account" || key.ClientEmail == "" || key.PrivateKey == "" {
+		return "", &RejectedError{Message: "not a service account key"}
+	}
+	signer, err := parsePrivateKey(key.PrivateKey)
+	if err != nil {
+		return "", &RejectedError{Message: fmt.Sprintf("invalid service account key: %v", err)}
+	}
+
+	tokenURL := c.GoogleTokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultGoogleTokenURL
+	}
+	now := c.clock()
+	assertion, err := signJWT(signer, key.PrivateKeyID, map[string]interface{}{
+		"iss":   key.ClientEmail,
+		"scope": googleCloudScope,
+		"aud":   tokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	if _, err := c.requestToken(ctx, tokenURL, form); err != nil {
+		return "", err
+	}
+	return key.ClientEmail, nil
+}
+
+// requestToken posts an OAuth 2.0 token request and returns the access token
+func (c *Checker) requestToken(ctx context.Context, tokenURL string, form url.Values) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	data, status, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	if status == http.StatusOK {
+		var token struct {
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.Unmarshal(data, &token); err != nil {
+			return "", fmt.Errorf("invalid token response: %w", err)
+		}
+		return token.AccessToken, nil
+	}
+	var failure struct {
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	if json.Unmarshal(data, &failure) != nil || failure.Error == "" {
+		return "", fmt.Errorf("token endpoint responded with %d", status)
+	}
+	message := failure.Error
+	if failure.Description != "" {
+		// Entra ID appends trace and correlation IDs on the following lines
+		message += ": " + strings.TrimSpace(strings.SplitN(failure.Description, "\n", 2)[0])
+	}
+	return "", providerError(status, message)
+}
+
+// do sends req and returns the response body and status
+func (c *Checker) do(req *http.Request) ([]byte, int, error) {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: checkTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, resp.StatusCode, nil
+}
+
+func (c *Checker) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// providerError returns a RejectedError for client errors, which mean the credentials were
+// refused, and a plain error for server errors
+func providerError(status int, message string) error {
+	if status >= 400 && status < 500 {
+		return &RejectedError{Message: message}
+	}
+	return fmt.Errorf("provider responded with %d: %s", status, message)
+}
+
+// parsePrivateKey parses the PEM RSA key of a service account key, PKCS #8 or PKCS #1
+func parsePrivateKey(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("private_key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private_key is not an RSA key")
+	}
+	return key, nil
+}
+
+// signJWT returns claims as a JWT signed with RS256
+func signJWT(key *rsa.PrivateKey, keyID string, claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": keyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,135 @@
+package cloudcredentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DefaultGoogleSTSURL is the Security Token Service endpoint of Google Cloud
+	DefaultGoogleSTSURL = "https://sts.googleapis.com/v1/token"
+
+	// DefaultGoogleIAMCredentialsURL is the IAM Service Account Credentials API of Google Cloud
+	DefaultGoogleIAMCredentialsURL = "https://iamcredentials.googleapis.com"
+
+	// federationSessionName names the STS sessions of the federation checks in CloudTrail
+	federationSessionName = "secrets-management-operator"
+)
+
+// AWSWebIdentity exchanges a service account token for credentials of roleARN with STS
+// AssumeRoleWithWebIdentity, as IRSA does for the pods, and returns the assumed role ARN. The
+// request is not signed; the token is the credential.
+func (c *Checker) AWSWebIdentity(ctx context.Context, region, roleARN, token string) (string, error) {
+	form := url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {roleARN},
+		"RoleSessionName":  {federationSessionName},
+		"WebIdentityToken": {token},
+		"DurationSeconds":  {"900"},
+	}
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.stsURL(region), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	data, err := c.doSTS(req)
+	if err != nil {
+		return "", err
+	}
+	var assumed struct {
+		Arn string `xml:"AssumeRoleWithWebIdentityResult>AssumedRoleUser>Arn"`
+	}
+	if err := xml.Unmarshal(data, &assumed); err != nil {
+		return "", fmt.Errorf("invalid STS response: %w", err)
+	}
+	return assumed.Arn, nil
+}
+
+// AzureFederated exchanges a service account token for a Key Vault token of clientID, as Azure
+// Workload Identity does for the pods, and returns the client ID
+func (c *Checker) AzureFederated(ctx context.Context, tenantID, clientID, token string) (string, error) {
+	host := c.AzureAuthorityHost
+	if host == "" {
+		host = DefaultAzureAuthorityHost
+	}
+	form := url.Values{
+		"grant_type":            {"client_credentials"},
+		"client_id":             {clientID},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"client_assertion":      {token},
+		"scope":                 {azureKeyVaultScope},
+	}
+	tokenURL := strings.TrimSuffix(host, "/") + "/" + url.PathEscape(tenantID) + "/oauth2/v2.0/token"
+	if _, err := c.requestToken(ctx, tokenURL, form); err != nil {
+		return "", err
+	}
+	return clientID, nil
+}
+
+// GCPFederated exchanges a service account token with the workload identity pool provider for a
+// federated token, and has it impersonate serviceAccount, as Workload Identity does for the pods.
+// It returns the service account email.
+func (c *Checker) GCPFederated(ctx context.Context, provider, token, serviceAccount string) (string, error) {
+	stsURL := c.GoogleSTSURL
+	if stsURL == "" {
+		stsURL = DefaultGoogleSTSURL
+	}
+	form := url.Values{
+		"grant_type":           {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"audience":             {provider},
+		"scope":                {googleCloudScope},
+		"requested_token_type": {"urn:ietf:params:oauth:token-type:access_token"},
+		"subject_token":        {token},
+		"subject_token_type":   {"urn:ietf:params:oauth:token-type:jwt"},
+	}
+	federated, err := c.requestToken(ctx, stsURL, form)
+	if err != nil {
+		return "", err
+	}
+
+	credentialsURL := c.GoogleIAMCredentialsURL
+	if credentialsURL == "" {
+		credentialsURL = DefaultGoogleIAMCredentialsURL
+	}
+	body, err := json.Marshal(map[string]interface{}{"scope": []string{googleCloudScope}, "lifetime": "300s"})
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	endpoint := strings.TrimSuffix(credentialsURL, "/") + "/v1/projects/-/serviceAccounts/" + url.PathEscape(serviceAccount) + ":generateAccessToken"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+federated)
+
+	data, status, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		var failure struct {
+			Error struct {
+				Message string `json:"message"`
+				Status  string `json:"status"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &failure) != nil || failure.Error.Status == "" {
+			return "", fmt.Errorf("IAM credentials API responded with %d", status)
+		}
+		return "", providerError(status, failure.Error.Status+": "+failure.Error.Message)
+	}
+	return serviceAccount, nil
+}
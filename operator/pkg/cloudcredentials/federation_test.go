@@ -0,0 +1,99 @@
+package cloudcredentials
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_AWSWebIdentity(t *testing.T) {
+	rt := &roundTripper{status: http.StatusOK, body: `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleWithWebIdentityResult>
+    <AssumedRoleUser>
+      <Arn>arn:aws:sts::123456789012:assumed-role/eso/secrets-management-operator</Arn>
+    </AssumedRoleUser>
+  </AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`}
+
+	identity, err := newTestChecker(rt).AWSWebIdentity(context.Background(), "us-east-1", "arn:aws:iam::123456789012:role/eso", "sa-token")
+
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:sts::123456789012:assumed-role/eso/secrets-management-operator", identity)
+	require.Len(t, rt.requests, 1)
+	assert.Equal(t, "https://sts.us-east-1.amazonaws.com/", rt.requests[0].URL.String())
+	assert.Empty(t, rt.requests[0].Header.Get("Authorization"), "the token is the credential")
+	assert.Equal(t, "AssumeRoleWithWebIdentity", rt.forms[0].Get("Action"))
+	assert.Equal(t, "sa-token", rt.forms[0].Get("WebIdentityToken"))
+}
+
+func TestChecker_AWSWebIdentityRejected(t *testing.T) {
+	rt := &roundTripper{status: http.StatusBadRequest, body: `<ErrorResponse><Error>
+    <Code>InvalidIdentityToken</Code>
+    <Message>No OpenIDConnect provider found in your account for https://oidc.example.com</Message>
+  </Error></ErrorResponse>`}
+
+	_, err := newTestChecker(rt).AWSWebIdentity(context.Background(), "us-east-1", "arn:aws:iam::123456789012:role/eso", "sa-token")
+
+	var rejected *RejectedError
+	require.True(t, errors.As(err, &rejected))
+	assert.Equal(t, "InvalidIdentityToken: No OpenIDConnect provider found in your account for https://oidc.example.com", rejected.Message)
+}
+
+func TestChecker_AzureFederated(t *testing.T) {
+	rt := &roundTripper{status: http.StatusOK, body: `{"access_token":"token","expires_in":3599}`}
+
+	identity, err := newTestChecker(rt).AzureFederated(context.Background(), "tenant", "1234", "sa-token")
+
+	require.NoError(t, err)
+	assert.Equal(t, "1234", identity)
+	assert.Equal(t, "https://login.microsoftonline.com/tenant/oauth2/v2.0/token", rt.requests[0].URL.String())
+	assert.Equal(t, "sa-token", rt.forms[0].Get("client_assertion"))
+	assert.Empty(t, rt.forms[0].Get("client_secret"))
+}
+
+func TestChecker_GCPFederated(t *testing.T) {
+	rt := &roundTripper{status: http.StatusOK, body: `{"access_token":"federated","accessToken":"impersonated"}`}
+	provider := "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/openshift/providers/cluster"
+
+	identity, err := newTestChecker(rt).GCPFederated(context.Background(), provider, "sa-token", "eso@project.iam.gserviceaccount.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, "eso@project.iam.gserviceaccount.com", identity)
+	require.Len(t, rt.requests, 2)
+	assert.Equal(t, DefaultGoogleSTSURL, rt.requests[0].URL.String())
+	assert.Equal(t, provider, rt.forms[0].Get("audience"))
+	assert.Equal(t, "sa-token", rt.forms[0].Get("subject_token"))
+	assert.Equal(t, "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/eso@project.iam.gserviceaccount.com:generateAccessToken",
+		rt.requests[1].URL.String())
+	assert.Equal(t, "Bearer federated", rt.requests[1].Header.Get("Authorization"))
+}
+
+func TestChecker_GCPFederatedImpersonationDenied(t *testing.T) {
+	rt := &roundTripper{status: http.StatusForbidden, body: `{"error":{"code":403,"message":"Permission 'iam.serviceAccounts.getAccessToken' denied","status":"PERMISSION_DENIED"}}`}
+	checker := newTestChecker(rt)
+	// The STS exchange succeeds against its own endpoint; only impersonation is denied
+	sts := &roundTripper{status: http.StatusOK, body: `{"access_token":"federated"}`}
+	checker.HTTPClient = &http.Client{Transport: splitTransport{sts: sts, rest: rt}}
+
+	_, err := checker.GCPFederated(context.Background(), "//iam.googleapis.com/projects/123", "sa-token", "eso@project.iam.gserviceaccount.com")
+
+	var rejected *RejectedError
+	require.True(t, errors.As(err, &rejected))
+	assert.Equal(t, "PERMISSION_DENIED: Permission 'iam.serviceAccounts.getAccessToken' denied", rejected.Message)
+}
+
+// splitTransport sends requests to the Google STS host to sts and the others to rest
+type splitTransport struct {
+	sts, rest *roundTripper
+}
+
+func (s splitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host == "sts.googleapis.com" {
+		return s.sts.RoundTrip(req)
+	}
+	return s.rest.RoundTrip(req)
+}
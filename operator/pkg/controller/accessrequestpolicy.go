@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// AccessRequestPolicyName is the name of the ValidatingAdmissionPolicy guarding the approval of
+	// SecretsAccessRequests and its binding
+	AccessRequestPolicyName = "secrets-management-access-request-approval"
+
+	// ApproveVerb is the verb on secretsaccessrequests that lets a user approve them
+	ApproveVerb = "approve"
+)
+
+// reconcileAccessRequestPolicy ensures the admission policy guarding the approval of
+// SecretsAccessRequests is in place. It is not optional: the SecretsAccessRequest controller
+// grants nothing while the policy is missing. Only the primary config owns it.
+func (r *SecretsManagementConfigReconciler) reconcileAccessRequestPolicy(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	// An empty object selector binds the policy to every SecretsAccessRequest
+	served, err := r.applyAdmissionPolicy(ctx, config, buildAccessRequestPolicy(), buildPolicyBinding(AccessRequestPolicyName, nil))
+	if err != nil {
+		return err
+	}
+	if !served {
+		r.setCondition(config, smv1alpha1.ConditionAccessRequestApprovalEnforced, "False", smv1alpha1.ReasonAdmissionPolicyAPIUnavailable,
+			"admissionregistration.k8s.io/v1beta1 ValidatingAdmissionPolicy is not served by this cluster; SecretsAccessRequests cannot be granted")
+		return nil
+	}
+
+	r.setCondition(config, smv1alpha1.ConditionAccessRequestApprovalEnforced, "True", smv1alpha1.ReasonProtectionPolicyApplied,
+		fmt.Sprintf("Only users with the %s verb on secretsaccessrequests can approve SecretsAccessRequests, and not their own", ApproveVerb))
+	return nil
+}
+
+// buildAccessRequestPolicy creates the ValidatingAdmissionPolicy guarding SecretsAccessRequests.
+// Users can only request access for themselves. Setting or clearing spec.approved and
+// spec.approvedBy takes the approve verb on the request, approving sets spec.approvedBy to the
+// approver, and nobody approves their own access. Status writes are a subresource and not matched.
+func buildAccessRequestPolicy() *admissionregistrationv1beta1.ValidatingAdmissionPolicy {
+	failurePolicy := admissionregistrationv1beta1.Fail
+	createOrUpdate := []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+	return &admissionregistrationv1beta1.ValidatingAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: AccessRequestPolicyName,
+			Labels: map[string]string{
+				"app.kubernetes.io/part-of":    "ocp-secrets-management",
+				"app.kubernetes.io/managed-by": "secrets-management-operator",
+			},
+		},
+		Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicySpec{
+			FailurePolicy: &failurePolicy,
+			MatchConstraints: &admissionregistrationv1beta1.MatchResources{
+				ResourceRules: []admissionregistrationv1beta1.NamedRuleWithOperations{
+					admissionRule(createOrUpdate, smv1alpha1.GroupVersion.Group, "secretsaccessrequests"),
+				},
+			},
+			Variables: []admissionregistrationv1beta1.Variable{
+				{Name: "approved", Expression: "has(object.spec.approved) && object.spec.approved"},
+				{Name: "approvedBy", Expression: "has(object.spec.approvedBy) ? object.spec.approvedBy : ''"},
+				{
+					Name: "approvalChanged",
+					Expression: "request.operation == 'CREATE' ? (variables.approved || variables.approvedBy != '') : " +
+						"((has(oldObject.spec.approved) && oldObject.spec.approved) != variables.approved || " +
+						"(has(oldObject.spec.approvedBy) ? oldObject.spec.approvedBy : '') != variables.approvedBy)",
+				},
+				{
+					Name: "approver",
+					Expression: fmt.Sprintf("authorizer.group('%s').resource('secretsaccessrequests').name(object.metadata.name).check('%s').allowed()",
+						smv1alpha1.GroupVersion.Group, ApproveVerb),
+				},
+			},
+			Validations: []admissionregistrationv1beta1.Validation{
+				{
+					Expression:        "request.operation != 'CREATE' || object.spec.user == request.userInfo.username || variables.approver",
+					MessageExpression: "'spec.user must be ' + request.userInfo.username + ': access can only be requested for yourself'",
+					Reason:            reasonPtr(metav1.StatusReasonForbidden),
+				},
+				{
+					Expression: "!variables.approvalChanged || variables.approver",
+					Message: fmt.Sprintf("spec.approved and spec.approvedBy can only be changed by users with the %s verb on secretsaccessrequests",
+						ApproveVerb),
+					Reason: reasonPtr(metav1.StatusReasonForbidden),
+				},
+				{
+					Expression: "!variables.approvalChanged || !variables.approved || object.spec.user != request.userInfo.username",
+					Message:    "users cannot approve their own access requests",
+					Reason:     reasonPtr(metav1.StatusReasonForbidden),
+				},
+				{
+					Expression:        "!variables.approvalChanged || !variables.approved || variables.approvedBy == request.userInfo.username",
+					MessageExpression: "'spec.approvedBy must be set to the approving user, ' + request.userInfo.username",
+					Reason:            reasonPtr(metav1.StatusReasonInvalid),
+				},
+			},
+		},
+	}
+}
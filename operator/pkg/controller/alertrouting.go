@@ -0,0 +1,203 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// AlertmanagerConfigName is the AlertmanagerConfig in the plugin namespace routing the secrets
+	// management alerts to spec.monitoring.alertReceivers
+	AlertmanagerConfigName = "secrets-management-alerts"
+
+	// AlertNamePrefix starts the name of every alert the AlertmanagerConfig routes
+	AlertNamePrefix = "SecretsManagement"
+
+	// unroutedAlertReceiver receives the alerts no configured receiver selects, and drops them
+	unroutedAlertReceiver = "secrets-management-unrouted"
+
+	// pagerDutyRoutingKey is the key of the routing key Secret holding the integration key
+	pagerDutyRoutingKey = "routingKey"
+)
+
+var alertmanagerConfigGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1beta1",
+	Kind:    "AlertmanagerConfig",
+}
+
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=alertmanagerconfigs,verbs=get;list;watch;create;update;delete
+
+// reconcileAlertRouting renders the AlertmanagerConfig routing the secrets management alerts to
+// spec.monitoring.alertReceivers and removes it when none are configured. An invalid receiver
+// leaves the AlertmanagerConfig as it was, so alerts keep flowing. Only the primary config owns it.
+func (r *SecretsManagementConfigReconciler) reconcileAlertRouting(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	receivers := config.Spec.Monitoring.AlertReceivers
+	if len(receivers) == 0 {
+		if err := r.cleanupAlertRouting(ctx); err != nil {
+			return err
+		}
+		r.setCondition(config, smv1alpha1.ConditionAlertRoutingConfigured, "False", smv1alpha1.ReasonAlertRoutingNotConfigured, "No alert receivers are configured in spec.monitoring")
+		return nil
+	}
+	for _, receiver := range receivers {
+		if msg := validateAlertReceiver(receiver); msg != "" {
+			r.setCondition(config, smv1alpha1.ConditionAlertRoutingConfigured, "False", smv1alpha1.ReasonAlertReceiverInvalid, fmt.Sprintf("Alert receiver %q %s", receiver.Name, msg))
+			return nil
+		}
+	}
+
+	desired := buildAlertmanagerConfig(receivers)
+	applyCommonMetadata(config, desired)
+	if err := setAppliedSpecHash(desired); err != nil {
+		return err
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(alertmanagerConfigGVK)
+	err := r.Get(ctx, types.NamespacedName{Namespace: PluginNamespace, Name: AlertmanagerConfigName}, existing)
+	switch {
+	case meta.IsNoMatchError(err):
+		r.setCondition(config, smv1alpha1.ConditionAlertRoutingConfigured, "False", smv1alpha1.ReasonAlertmanagerConfigUnavailable, "The cluster does not serve monitoring.coreos.com/v1beta1 AlertmanagerConfigs")
+		return nil
+	case errors.IsNotFound(err):
+		if err := r.Create(ctx, desired); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		before := existing.DeepCopy()
+		existing.Object["spec"] = desired.Object["spec"]
+		mergeMetadata(existing, desired)
+		if err := updateIfChanged(ctx, r, before, existing); err != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(receivers))
+	for _, receiver := range receivers {
+		names = append(names, receiver.Name)
+	}
+	r.setCondition(config, smv1alpha1.ConditionAlertRoutingConfigured, "True", smv1alpha1.ReasonAlertRoutingApplied,
+		fmt.Sprintf("AlertmanagerConfig %s/%s routes %s* alerts to %s", PluginNamespace, AlertmanagerConfigName, AlertNamePrefix, strings.Join(names, ", ")))
+	return nil
+}
+
+// validateAlertReceiver returns what is missing from receiver, or "" when it is complete
+func validateAlertReceiver(receiver smv1alpha1.AlertReceiver) string {
+	if receiver.Name == unroutedAlertReceiver {
+		return "uses a reserved name"
+	}
+	switch receiver.Type {
+	case smv1alpha1.AlertReceiverEmail:
+		if receiver.Email == nil {
+			return "has type Email but no email settings"
+		}
+	case smv1alpha1.AlertReceiverPagerDuty:
+		if receiver.PagerDuty == nil {
+			return "has type PagerDuty but no pagerDuty settings"
+		}
+	case smv1alpha1.AlertReceiverWebhook:
+		if receiver.Webhook == nil {
+			return "has type Webhook but no webhook settings"
+		}
+	default:
+		return fmt.Sprintf("has unknown type %q", receiver.Type)
+	}
+	for _, alert := range receiver.Alerts {
+		if !strings.HasPrefix(alert, AlertNamePrefix) {
+			return fmt.Sprintf("selects alert %q, which does not start with %s", alert, AlertNamePrefix)
+		}
+	}
+	return ""
+}
+
+// buildAlertmanagerConfig returns the AlertmanagerConfig sending the secrets management alerts
+// to every receiver selecting them. Alerts no receiver selects go to a receiver without
+// integrations, which drops them.
+func buildAlertmanagerConfig(receivers []smv1alpha1.AlertReceiver) *unstructured.Unstructured {
+	routes := make([]interface{}, 0, len(receivers))
+	configs := []interface{}{map[string]interface{}{"name": unroutedAlertReceiver}}
+	for _, receiver := range receivers {
+		routes = append(routes, map[string]interface{}{
+			"receiver": receiver.Name,
+			"matchers": []interface{}{alertNameMatcher(receiver.Alerts)},
+			"continue": true,
+		})
+
+		entry := map[string]interface{}{"name": receiver.Name}
+		switch receiver.Type {
+		case smv1alpha1.AlertReceiverEmail:
+			entry["emailConfigs"] = []interface{}{map[string]interface{}{
+				"to":           receiver.Email.To,
+				"sendResolved": true,
+			}}
+		case smv1alpha1.AlertReceiverPagerDuty:
+			entry["pagerdutyConfigs"] = []interface{}{map[string]interface{}{
+				"routingKey":   map[string]interface{}{"name": receiver.PagerDuty.RoutingKeySecret, "key": pagerDutyRoutingKey},
+				"sendResolved": true,
+			}}
+		case smv1alpha1.AlertReceiverWebhook:
+			entry["webhookConfigs"] = []interface{}{map[string]interface{}{
+				"url":          receiver.Webhook.URL,
+				"sendResolved": true,
+			}}
+		}
+		configs = append(configs, entry)
+	}
+
+	amc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"route": map[string]interface{}{
+				"receiver": unroutedAlertReceiver,
+				"matchers": []interface{}{alertNameMatcher(nil)},
+				"routes":   routes,
+			},
+			"receivers": configs,
+		},
+	}}
+	amc.SetGroupVersionKind(alertmanagerConfigGVK)
+	amc.SetNamespace(PluginNamespace)
+	amc.SetName(AlertmanagerConfigName)
+	amc.SetLabels(map[string]string{
+		"app.kubernetes.io/part-of":    "ocp-secrets-management",
+		"app.kubernetes.io/managed-by": "secrets-management-operator",
+	})
+	return amc
+}
+
+// alertNameMatcher matches the named alerts, or every secrets management alert when none are named
+func alertNameMatcher(alerts []string) map[string]interface{} {
+	value := regexp.QuoteMeta(AlertNamePrefix) + ".*"
+	if len(alerts) > 0 {
+		quoted := make([]string, 0, len(alerts))
+		for _, alert := range alerts {
+			quoted = append(quoted, regexp.QuoteMeta(alert))
+		}
+		value = strings.Join(quoted, "|")
+	}
+	return map[string]interface{}{"name": "alertname", "matchType": "=~", "value": value}
+}
+
+// cleanupAlertRouting removes the AlertmanagerConfig, if any
+func (r *SecretsManagementConfigReconciler) cleanupAlertRouting(ctx context.Context) error {
+	amc := &unstructured.Unstructured{}
+	amc.SetGroupVersionKind(alertmanagerConfigGVK)
+	amc.SetNamespace(PluginNamespace)
+	amc.SetName(AlertmanagerConfigName)
+	if err := r.Delete(ctx, amc); err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func getTestAlertmanagerConfig(ctx context.Context, r *SecretsManagementConfigReconciler) (*unstructured.Unstructured, error) {
+	amc := &unstructured.Unstructured{}
+	amc.SetGroupVersionKind(alertmanagerConfigGVK)
+	err := r.Get(ctx, types.NamespacedName{Name: AlertmanagerConfigName, Namespace: PluginNamespace}, amc)
+	return amc, err
+}
+
+func TestReconcileAlertRouting(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Monitoring.AlertReceivers = []smv1alpha1.AlertReceiver{
+		{
+			Name:      "oncall",
+			Type:      smv1alpha1.AlertReceiverPagerDuty,
+			Alerts:    []string{"SecretsManagementCertificateExpiring"},
+			PagerDuty: &smv1alpha1.PagerDutyAlertReceiver{RoutingKeySecret: "pagerduty-key"},
+		},
+		{
+			Name:  "team-mail",
+			Type:  smv1alpha1.AlertReceiverEmail,
+			Email: &smv1alpha1.EmailAlertReceiver{To: "secrets@example.com"},
+		},
+	}
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcileAlertRouting(ctx, config))
+
+	amc, err := getTestAlertmanagerConfig(ctx, r)
+	require.NoError(t, err)
+	assert.Equal(t, "secrets-management-operator", amc.GetLabels()["app.kubernetes.io/managed-by"])
+
+	root, _, _ := unstructured.NestedString(amc.Object, "spec", "route", "receiver")
+	assert.Equal(t, unroutedAlertReceiver, root)
+	routes, _, _ := unstructured.NestedSlice(amc.Object, "spec", "route", "routes")
+	require.Len(t, routes, 2)
+	oncall := routes[0].(map[string]interface{})
+	assert.Equal(t, "oncall", oncall["receiver"])
+	assert.Equal(t, true, oncall["continue"])
+	matcher := oncall["matchers"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "SecretsManagementCertificateExpiring", matcher["value"])
+	matcher = routes[1].(map[string]interface{})["matchers"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "SecretsManagement.*", matcher["value"])
+
+	receivers, _, _ := unstructured.NestedSlice(amc.Object, "spec", "receivers")
+	require.Len(t, receivers, 3)
+	key, _, _ := unstructured.NestedString(receivers[1].(map[string]interface{})["pagerdutyConfigs"].([]interface{})[0].(map[string]interface{}), "routingKey", "name")
+	assert.Equal(t, "pagerduty-key", key)
+	to := receivers[2].(map[string]interface{})["emailConfigs"].([]interface{})[0].(map[string]interface{})["to"]
+	assert.Equal(t, "secrets@example.com", to)
+
+	cond := findCondition(config, smv1alpha1.ConditionAlertRoutingConfigured)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", string(cond.Status))
+	assert.Equal(t, smv1alpha1.ReasonAlertRoutingApplied, cond.Reason)
+
+	// An invalid receiver keeps the applied routing
+	config.Spec.Monitoring.AlertReceivers = append(config.Spec.Monitoring.AlertReceivers, smv1alpha1.AlertReceiver{
+		Name: "hook",
+		Type: smv1alpha1.AlertReceiverWebhook,
+	})
+	require.NoError(t, r.reconcileAlertRouting(ctx, config))
+	cond = findCondition(config, smv1alpha1.ConditionAlertRoutingConfigured)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonAlertReceiverInvalid, cond.Reason)
+	assert.Contains(t, cond.Message, "hook")
+	amc, err = getTestAlertmanagerConfig(ctx, r)
+	require.NoError(t, err)
+	receivers, _, _ = unstructured.NestedSlice(amc.Object, "spec", "receivers")
+	assert.Len(t, receivers, 3)
+
+	// Removing the receivers removes the AlertmanagerConfig
+	config.Spec.Monitoring.AlertReceivers = nil
+	require.NoError(t, r.reconcileAlertRouting(ctx, config))
+	_, err = getTestAlertmanagerConfig(ctx, r)
+	assert.True(t, apierrors.IsNotFound(err))
+	cond = findCondition(config, smv1alpha1.ConditionAlertRoutingConfigured)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonAlertRoutingNotConfigured, cond.Reason)
+}
+
+func TestValidateAlertReceiver(t *testing.T) {
+	tests := []struct {
+		name     string
+		receiver smv1alpha1.AlertReceiver
+		wantErr  string
+	}{
+		{
+			name:     "complete webhook",
+			receiver: smv1alpha1.AlertReceiver{Name: "hook", Type: smv1alpha1.AlertReceiverWebhook, Webhook: &smv1alpha1.WebhookAlertReceiver{URL: "https://hooks.example.com"}},
+		},
+		{
+			name:     "reserved name",
+			receiver: smv1alpha1.AlertReceiver{Name: unroutedAlertReceiver, Type: smv1alpha1.AlertReceiverWebhook, Webhook: &smv1alpha1.WebhookAlertReceiver{URL: "https://hooks.example.com"}},
+			wantErr:  "reserved",
+		},
+		{
+			name:     "missing settings",
+			receiver: smv1alpha1.AlertReceiver{Name: "mail", Type: smv1alpha1.AlertReceiverEmail},
+			wantErr:  "no email settings",
+		},
+		{
+			name:     "foreign alert",
+			receiver: smv1alpha1.AlertReceiver{Name: "mail", Type: smv1alpha1.AlertReceiverEmail, Email: &smv1alpha1.EmailAlertReceiver{To: "a@example.com"}, Alerts: []string{"KubePodCrashLooping"}},
+			wantErr:  "does not start with",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := validateAlertReceiver(tt.receiver)
+			if tt.wantErr == "" {
+				assert.Empty(t, msg)
+			} else {
+				assert.Contains(t, msg, tt.wantErr)
+			}
+		})
+	}
+}
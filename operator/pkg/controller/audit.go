@@ -0,0 +1,40 @@
+package controller
+
+// The audit endpoint identifies callers by the token the console proxy forwards, and only lets
+// users who can get the SecretsManagementConfig list records.
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+const (
+	// AuditServiceName is the Service in the operator namespace exposing the audit endpoint.
+	// It is shipped with the operator manifests and annotated for a service-ca serving certificate.
+	AuditServiceName = "secrets-management-operator-audit"
+
+	// AuditPort is the port the operator serves the audit endpoint on
+	AuditPort = 8443
+
+	// AuditProxyAlias is the console proxy alias the plugin uses to reach the audit endpoint, at
+	// /api/proxy/plugin/<plugin>/audit/
+	AuditProxyAlias = "audit"
+
+	// AccessReviewProxyAlias is the console proxy alias the plugin uses to reach the cached
+	// access review endpoint, served on the audit port, at /api/proxy/plugin/<plugin>/access/
+	AccessReviewProxyAlias = "access"
+)
+
+// operatorProxy returns the ConsolePlugin proxy entry forwarding plugin requests under alias,
+// with the user's token, to the operator's audit Service
+func (r *SecretsManagementConfigReconciler) operatorProxy(alias string) map[string]interface{} {
+	return map[string]interface{}{
+		"alias":         alias,
+		"authorization": "UserToken",
+		"endpoint": map[string]interface{}{
+			"type": "Service",
+			"service": map[string]interface{}{
+				"name":      AuditServiceName,
+				"namespace": r.operatorNamespace(),
+				"port":      int64(AuditPort),
+			},
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// consolePluginProxies returns the ConsolePlugin proxy entries by alias
+func consolePluginProxies(t *testing.T, spec map[string]interface{}) map[string]map[string]interface{} {
+	t.Helper()
+	proxies, ok := spec["proxy"].([]interface{})
+	require.True(t, ok)
+	byAlias := make(map[string]map[string]interface{}, len(proxies))
+	for _, p := range proxies {
+		proxy := p.(map[string]interface{})
+		byAlias[proxy["alias"].(string)] = proxy
+	}
+	return byAlias
+}
+
+func TestConsolePluginSpec_AuditProxy(t *testing.T) {
+	r := newTestReconciler()
+	r.OperatorNamespace = "operators"
+
+	config := newTestConfig(SingletonConfigName)
+	_, found := consolePluginProxies(t, r.consolePluginSpec(config))[AuditProxyAlias]
+	assert.False(t, found, "audit proxy is only registered when audit is enabled")
+
+	config.Spec.Audit.Enabled = true
+	proxies := consolePluginProxies(t, r.consolePluginSpec(config))
+	require.Len(t, proxies, 2)
+	for _, alias := range []string{AuditProxyAlias, AccessReviewProxyAlias} {
+		proxy, ok := proxies[alias]
+		require.True(t, ok, alias)
+		assert.Equal(t, "UserToken", proxy["authorization"])
+		namespace, _, _ := unstructured.NestedString(proxy, "endpoint", "service", "namespace")
+		assert.Equal(t, "operators", namespace)
+		port, _, _ := unstructured.NestedInt64(proxy, "endpoint", "service", "port")
+		assert.Equal(t, int64(AuditPort), port)
+	}
+
+	// The canary shares the primary's audit trail and does not proxy to it, but checks access
+	// through the operator too
+	canary := newTestConfig(CanaryConfigName)
+	canary.Spec.Audit.Enabled = true
+	proxies = consolePluginProxies(t, r.consolePluginSpec(canary))
+	assert.Len(t, proxies, 1)
+	assert.Contains(t, proxies, AccessReviewProxyAlias)
+}
@@ -0,0 +1,275 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/audit"
+)
+
+// AuditSinkTokenKey is the key of a webhook sink's token Secret holding the bearer token
+const AuditSinkTokenKey = "token"
+
+// forwardedEventGroups are the API groups whose Warning events are forwarded to audit sinks
+var forwardedEventGroups = map[string]bool{
+	"cert-manager.io":            true,
+	"external-secrets.io":        true,
+	"secrets-store.csi.x-k8s.io": true,
+}
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch
+
+// reconcileAuditSinks configures the audit forwarder from spec.audit.sinks and reports delivery
+// health. Sinks whose token Secret cannot be read are reported as failing and left out.
+func (r *SecretsManagementConfigReconciler) reconcileAuditSinks(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	if r.AuditForwarder == nil {
+		return nil
+	}
+
+	var sinks []smv1alpha1.AuditSink
+	if config.Spec.Audit.Enabled {
+		sinks = config.Spec.Audit.Sinks
+	}
+
+	configs, configErrors, err := auditSinkConfigs(ctx, r.apiReader(), sinks)
+	if err != nil {
+		return err
+	}
+	r.AuditForwarder.Configure(configs)
+
+	if len(sinks) == 0 {
+		config.Status.AuditSinks = nil
+		r.setCondition(config, smv1alpha1.ConditionAuditSinksHealthy, "False", smv1alpha1.ReasonAuditSinksNotConfigured, "No audit sinks are configured")
+		return nil
+	}
+
+	health := r.AuditForwarder.Health()
+	statuses := make([]smv1alpha1.AuditSinkStatus, 0, len(sinks))
+	var failing []string
+	for _, sink := range sinks {
+		h := health[sink.Name]
+		status := smv1alpha1.AuditSinkStatus{Name: sink.Name, Dropped: h.Dropped, LastError: h.LastError}
+		if msg, ok := configErrors[sink.Name]; ok {
+			status.LastError = msg
+		}
+		if !h.LastDelivery.IsZero() {
+			t := metav1.NewTime(h.LastDelivery)
+			status.LastDeliveryTime = &t
+		}
+		if status.LastError != "" {
+			failing = append(failing, fmt.Sprintf("%s: %s", sink.Name, status.LastError))
+		}
+		statuses = append(statuses, status)
+	}
+	config.Status.AuditSinks = statuses
+
+	if len(failing) > 0 {
+		sort.Strings(failing)
+		r.setCondition(config, smv1alpha1.ConditionAuditSinksHealthy, "False", smv1alpha1.ReasonAuditSinkFailing, strings.Join(failing, "; "))
+		return nil
+	}
+	r.setCondition(config, smv1alpha1.ConditionAuditSinksHealthy, "True", smv1alpha1.ReasonAuditSinksDelivering, fmt.Sprintf("%d audit sink(s) are delivering", len(sinks)))
+	return nil
+}
+
+// auditSinkConfigs returns the forwarder configuration of sinks, and why sinks left out of it could
+// not be configured, by sink name
+func auditSinkConfigs(ctx context.Context, reader client.Reader, sinks []smv1alpha1.AuditSink) (map[string]audit.SinkConfig, map[string]string, error) {
+	configs := make(map[string]audit.SinkConfig, len(sinks))
+	configErrors := map[string]string{}
+	for _, sink := range sinks {
+		cfg := audit.SinkConfig{Type: sink.Type}
+		if sink.Webhook != nil {
+			cfg.URL = sink.Webhook.URL
+			if sink.Webhook.TokenSecret != "" {
+				token, err := readAuditSinkToken(ctx, reader, sink.Webhook.TokenSecret)
+				if err != nil {
+					return nil, nil, err
+				}
+				if token == "" {
+					configErrors[sink.Name] = fmt.Sprintf("Secret %s/%s has no %q key", PluginNamespace, sink.Webhook.TokenSecret, AuditSinkTokenKey)
+					continue
+				}
+				cfg.Token = token
+			}
+		}
+		if sink.Syslog != nil {
+			cfg.Address = sink.Syslog.Address
+			cfg.Protocol = sink.Syslog.Protocol
+		}
+		configs[sink.Name] = cfg
+	}
+	return configs, configErrors, nil
+}
+
+// readAuditSinkToken returns the token in a Secret in the plugin namespace, or "" when the Secret
+// or key is missing. Secrets are cached as metadata only, so the data is read from the API server.
+func readAuditSinkToken(ctx context.Context, reader client.Reader, name string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: PluginNamespace, Name: name}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(secret.Data[AuditSinkTokenKey])), nil
+}
+
+// WarningEventReconciler forwards Warning events about cert-manager, External Secrets and
+// Secrets Store CSI resources to the audit sinks when spec.audit.forwardWarningEvents is set
+type WarningEventReconciler struct {
+	client.Client
+	Log       logr.Logger
+	Forwarder *audit.Forwarder
+
+	// Since skips events last seen before this time, so events replayed when the informer
+	// starts are not forwarded again after a restart
+	Since time.Time
+}
+
+// Reconcile forwards one Warning event
+func (r *WarningEventReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	config := &smv1alpha1.SecretsManagementConfig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !config.Spec.Audit.Enabled || !config.Spec.Audit.ForwardWarningEvents {
+		return ctrl.Result{}, nil
+	}
+
+	ev := &corev1.Event{}
+	if err := r.Get(ctx, req.NamespacedName, ev); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	seen := eventTime(ev)
+	if seen.Before(r.Since) {
+		return ctrl.Result{}, nil
+	}
+
+	gv, _ := schema.ParseGroupVersion(ev.InvolvedObject.APIVersion)
+	r.Forwarder.ForwardEvent(audit.Event{
+		Time:    seen,
+		Reason:  ev.Reason,
+		Message: ev.Message,
+		Resource: audit.Resource{
+			Group:     gv.Group,
+			Kind:      ev.InvolvedObject.Kind,
+			Namespace: ev.InvolvedObject.Namespace,
+			Name:      ev.InvolvedObject.Name,
+		},
+	})
+	return ctrl.Result{}, nil
+}
+
+// eventTime returns when an event was last observed
+func eventTime(ev *corev1.Event) time.Time {
+	switch {
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	default:
+		return ev.CreationTimestamp.Time
+	}
+}
+
+// isForwardedEvent selects Warning events about resources of the supported operators
+func isForwardedEvent(obj client.Object) bool {
+	ev, ok := obj.(*corev1.Event)
+	if !ok || ev.Type != corev1.EventTypeWarning {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(ev.InvolvedObject.APIVersion)
+	return err == nil && forwardedEventGroups[gv.Group]
+}
+
+// SetupWithManager sets up the controller with the Manager. Repeated events update the count of
+// an existing Event, so updates are forwarded as well as creates.
+func (r *WarningEventReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("warningevents").
+		For(&corev1.Event{}, builder.WithPredicates(predicate.Funcs{
+			CreateFunc:  func(e event.CreateEvent) bool { return isForwardedEvent(e.Object) },
+			UpdateFunc:  func(e event.UpdateEvent) bool { return isForwardedEvent(e.ObjectNew) },
+			DeleteFunc:  func(event.DeleteEvent) bool { return false },
+			GenericFunc: func(event.GenericEvent) bool { return false },
+		})).
+		Complete(r)
+}
+
+// AuditSinkConfigReconciler configures the audit forwarder of every operator replica from
+// spec.audit.sinks of the primary config. The SecretsManagementConfig reconciler only runs on the
+// leader, but each replica serves the audit endpoint and forwards the records posted to it.
+type AuditSinkConfigReconciler struct {
+	client.Client
+	Log       logr.Logger
+	Forwarder *audit.Forwarder
+
+	// APIReader reads the sink token Secrets; the cached client is used when nil
+	APIReader client.Reader
+
+	// ReconcileInterval is how often token Secrets are read again; DefaultReconcileInterval when zero
+	ReconcileInterval time.Duration
+}
+
+// Reconcile configures the forwarder, or removes every sink when audit is disabled or the config is gone
+func (r *AuditSinkConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	config := &smv1alpha1.SecretsManagementConfig{}
+	if err := r.Get(ctx, req.NamespacedName, config); err != nil {
+		if errors.IsNotFound(err) {
+			r.Forwarder.Configure(nil)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	var sinks []smv1alpha1.AuditSink
+	if config.Spec.Audit.Enabled && config.DeletionTimestamp.IsZero() {
+		sinks = config.Spec.Audit.Sinks
+	}
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	configs, _, err := auditSinkConfigs(ctx, reader, sinks)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Forwarder.Configure(configs)
+
+	interval := r.ReconcileInterval
+	if interval == 0 {
+		interval = DefaultReconcileInterval
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. It runs on every replica, not only
+// the leader.
+func (r *AuditSinkConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	needLeaderElection := false
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("auditsinkconfig").
+		For(&smv1alpha1.SecretsManagementConfig{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetName() == SingletonConfigName
+		}))).
+		WithOptions(crcontroller.Options{NeedLeaderElection: &needLeaderElection}).
+		Complete(r)
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/audit"
+)
+
+func TestReconcileAuditSinks(t *testing.T) {
+	r := newTestReconciler()
+	r.AuditForwarder = audit.NewForwarder(logr.Discard())
+
+	config := newTestConfig(SingletonConfigName)
+	require.NoError(t, r.reconcileAuditSinks(context.Background(), config))
+	cond := findCondition(config, smv1alpha1.ConditionAuditSinksHealthy)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonAuditSinksNotConfigured, cond.Reason)
+
+	config.Spec.Audit = smv1alpha1.AuditConfig{
+		Enabled: true,
+		Sinks: []smv1alpha1.AuditSink{
+			{Name: "siem", Type: smv1alpha1.AuditSinkWebhook, Webhook: &smv1alpha1.WebhookSinkConfig{URL: "https://siem.example.com", TokenSecret: "siem-token"}},
+			{Name: "syslog", Type: smv1alpha1.AuditSinkSyslog, Syslog: &smv1alpha1.SyslogSinkConfig{Address: "syslog.example.com:514"}},
+		},
+	}
+	require.NoError(t, r.reconcileAuditSinks(context.Background(), config))
+	require.Len(t, config.Status.AuditSinks, 2)
+	assert.Contains(t, config.Status.AuditSinks[0].LastError, "siem-token")
+	assert.Empty(t, config.Status.AuditSinks[1].LastError)
+	cond = findCondition(config, smv1alpha1.ConditionAuditSinksHealthy)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonAuditSinkFailing, cond.Reason)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "siem-token", Namespace: PluginNamespace},
+		Data:       map[string][]byte{AuditSinkTokenKey: []byte("s3cret\n")},
+	}
+	require.NoError(t, r.Create(context.Background(), secret))
+	require.NoError(t, r.reconcileAuditSinks(context.Background(), config))
+	cond = findCondition(config, smv1alpha1.ConditionAuditSinksHealthy)
+	assert.Equal(t, "True", cond.Status)
+	assert.Len(t, r.AuditForwarder.Health(), 2)
+}
+
+func TestAuditSinkConfigReconciler(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Audit = smv1alpha1.AuditConfig{
+		Enabled: true,
+		Sinks: []smv1alpha1.AuditSink{
+			{Name: "syslog", Type: smv1alpha1.AuditSinkSyslog, Syslog: &smv1alpha1.SyslogSinkConfig{Address: "syslog.example.com:514"}},
+		},
+	}
+	c := newTestReconciler(config).Client
+	r := &AuditSinkConfigReconciler{Client: c, Log: logr.Discard(), Forwarder: audit.NewForwarder(logr.Discard())}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: SingletonConfigName}}
+
+	result, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultReconcileInterval, result.RequeueAfter)
+	assert.Contains(t, r.Forwarder.Health(), "syslog")
+
+	// The sinks go away with the config
+	require.NoError(t, c.Delete(context.Background(), config))
+	_, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, r.Forwarder.Health())
+}
+
+func TestIsForwardedEvent(t *testing.T) {
+	ev := &corev1.Event{
+		Type:           corev1.EventTypeWarning,
+		InvolvedObject: corev1.ObjectReference{APIVersion: "external-secrets.io/v1beta1", Kind: "ExternalSecret", Name: "db"},
+		LastTimestamp:  metav1.NewTime(time.Now()),
+	}
+	assert.True(t, isForwardedEvent(ev))
+
+	ev.Type = corev1.EventTypeNormal
+	assert.False(t, isForwardedEvent(ev))
+
+	ev.Type = corev1.EventTypeWarning
+	ev.InvolvedObject.APIVersion = "v1"
+	assert.False(t, isForwardedEvent(ev))
+}
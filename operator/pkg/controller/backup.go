@@ -0,0 +1,382 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/objectstorage"
+)
+
+const (
+	// BackupName names the backup CronJob and the identity it runs under, and starts the name of
+	// every bundle
+	BackupName = "secrets-management-backup"
+
+	// BackupFlag makes the manager binary take a backup once and exit
+	BackupFlag = "backup"
+
+	// BackupBundleFormat identifies the layout of a bundle; restores refuse other formats
+	BackupBundleFormat = "secrets-management.openshift.io/backup/v1"
+
+	// BackupBundleKey is the key of a bundle Secret holding the bundle
+	BackupBundleKey = "bundle.yaml"
+
+	// DefaultBackupRetain is how many bundle Secrets are kept when spec.backup.destination.retain is unset
+	DefaultBackupRetain = 7
+
+	// backupTimeFormat orders bundle names by the time they were taken
+	backupTimeFormat = "20060102-150405"
+)
+
+// backupKinds are the kinds exported, in the order they are restored: the configs first, then
+// what the operators they configure read
+var backupKinds = []struct {
+	gvk        schema.GroupVersionKind
+	namespaced bool
+}{
+	{gvk: smv1alpha1.GroupVersion.WithKind("SecretsManagementConfig")},
+	{gvk: clusterSecretStoreGVK},
+	{gvk: clusterSecretStoreGVK.GroupVersion().WithKind("SecretStore"), namespaced: true},
+	{gvk: clusterIssuerGVK},
+	{gvk: clusterIssuerGVK.GroupVersion().WithKind("Issuer"), namespaced: true},
+	{gvk: secretProviderClassGVK, namespaced: true},
+}
+
+// BackupBundle is an export of the secrets management configuration. It holds no Secret data,
+// only the objects naming the Secrets.
+type BackupBundle struct {
+	// Format is BackupBundleFormat
+	Format string `json:"format"`
+
+	// CreatedAt is when the bundle was exported
+	CreatedAt metav1.Time `json:"createdAt"`
+
+	// Items are the exported objects, without status and server-set metadata
+	Items []unstructured.Unstructured `json:"items"`
+}
+
+// BackupOptions configures Backup
+type BackupOptions struct {
+	// Restricted exports namespaced kinds from the plugin namespace only
+	Restricted bool
+
+	// Now returns the time the bundle is taken at; time.Now when nil
+	Now func() time.Time
+}
+
+// Backup exports the bundle and writes it to spec.backup.destination of the primary config,
+// returning the name of the bundle. It is what the backup CronJob runs, and does nothing while
+// spec.backup.schedule is unset.
+func Backup(ctx context.Context, c client.Client, opts BackupOptions) (string, error) {
+	config := &smv1alpha1.SecretsManagementConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	if !config.DeletionTimestamp.IsZero() || config.Spec.Backup.Schedule == "" {
+		return "", nil
+	}
+
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	bundle, err := ExportBundle(ctx, c, opts.Restricted, now())
+	if err != nil {
+		return "", err
+	}
+	data, err := yaml.Marshal(bundle)
+	if err != nil {
+		return "", err
+	}
+
+	name := BackupName + "-" + bundle.CreatedAt.UTC().Format(backupTimeFormat)
+	destination := config.Spec.Backup.Destination
+	if destination.Type == smv1alpha1.BackupDestinationObjectStorage {
+		return name, uploadBackup(ctx, c, destination.ObjectStorage, name, data)
+	}
+	labels := backupLabels()
+	if config.Spec.Backup.Velero.Enabled {
+		labels[BackupTierLabel] = BackupTierBundle
+	}
+	return name, writeBackupSecret(ctx, c, name, data, labels, destination.Retain)
+}
+
+// ExportBundle lists the objects of backupKinds into a bundle. Kinds the cluster does not serve
+// are left out, and so are the objects the operator generates, which the configs recreate.
+func ExportBundle(ctx context.Context, c client.Reader, restricted bool, now time.Time) (*BackupBundle, error) {
+	bundle := &BackupBundle{Format: BackupBundleFormat, CreatedAt: metav1.NewTime(now.UTC().Truncate(time.Second))}
+	for _, kind := range backupKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(kind.gvk.GroupVersion().WithKind(kind.gvk.Kind + "List"))
+		var opts []client.ListOption
+		if kind.namespaced && restricted {
+			opts = append(opts, client.InNamespace(PluginNamespace))
+		}
+		if err := c.List(ctx, list, opts...); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("listing %s: %w", kind.gvk.Kind, err)
+		}
+		sort.Slice(list.Items, func(i, j int) bool {
+			a, b := list.Items[i], list.Items[j]
+			if a.GetNamespace() != b.GetNamespace() {
+				return a.GetNamespace() < b.GetNamespace()
+			}
+			return a.GetName() < b.GetName()
+		})
+		for i := range list.Items {
+			item := &list.Items[i]
+			if item.GetLabels()["app.kubernetes.io/managed-by"] == "secrets-management-operator" {
+				continue
+			}
+			item.SetGroupVersionKind(kind.gvk)
+			bundle.Items = append(bundle.Items, *stripForBackup(item))
+		}
+	}
+	return bundle, nil
+}
+
+// stripForBackup drops the status and the metadata the API server sets, which a restore cannot
+// carry over
+func stripForBackup(u *unstructured.Unstructured) *unstructured.Unstructured {
+	out := u.DeepCopy()
+	delete(out.Object, "status")
+	for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp",
+		"deletionGracePeriodSeconds", "managedFields", "ownerReferences", "finalizers", "selfLink"} {
+		unstructured.RemoveNestedField(out.Object, "metadata", field)
+	}
+	annotations := out.GetAnnotations()
+	delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	out.SetAnnotations(annotations)
+	return out
+}
+
+// writeBackupSecret stores the bundle in a Secret in the plugin namespace and deletes the oldest
+// bundle Secrets beyond retain
+func writeBackupSecret(ctx context.Context, c client.Client, name string, data []byte, labels map[string]string, retain int32) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   PluginNamespace,
+			Labels:      labels,
+			Annotations: map[string]string{"secrets-management.openshift.io/backup-format": BackupBundleFormat},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{BackupBundleKey: data},
+	}
+	if err := c.Create(ctx, secret); err != nil {
+		return err
+	}
+
+	if retain <= 0 {
+		retain = DefaultBackupRetain
+	}
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(PluginNamespace), client.MatchingLabels{"app.kubernetes.io/name": BackupName}); err != nil {
+		return err
+	}
+	// Names end in the time the bundle was taken, so they sort oldest first
+	sort.Slice(secrets.Items, func(i, j int) bool { return secrets.Items[i].Name < secrets.Items[j].Name })
+	var errs []error
+	for i := 0; i < len(secrets.Items)-int(retain); i++ {
+		if err := c.Delete(ctx, &secrets.Items[i]); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// uploadBackup puts the bundle in the bucket of destination as <prefix><name>.yaml
+func uploadBackup(ctx context.Context, c client.Reader, destination *smv1alpha1.ObjectStorageBackupDestination, name string, data []byte) error {
+	if destination == nil {
+		return fmt.Errorf("spec.backup.destination.objectStorage is required for the ObjectStorage destination")
+	}
+	store, err := objectStorageClient(ctx, c, destination)
+	if err != nil {
+		return err
+	}
+	return store.Put(ctx, destination.Prefix+name+".yaml", data, "application/yaml")
+}
+
+// objectStorageClient returns a client for the bucket of destination, signing with the keys of
+// its credentials Secret
+func objectStorageClient(ctx context.Context, c client.Reader, destination *smv1alpha1.ObjectStorageBackupDestination) (*objectstorage.Client, error) {
+	credentials := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: PluginNamespace, Name: destination.CredentialsSecret}, credentials); err != nil {
+		return nil, fmt.Errorf("reading credentials Secret %s: %w", destination.CredentialsSecret, err)
+	}
+	for _, key := range secretStoreAuthKeys[smv1alpha1.SecretStoreProviderAWS] {
+		if len(credentials.Data[key]) == 0 {
+			return nil, fmt.Errorf("credentials Secret %s has no %q key", destination.CredentialsSecret, key)
+		}
+	}
+	return &objectstorage.Client{
+		Endpoint:        destination.Endpoint,
+		Bucket:          destination.Bucket,
+		Region:          destination.Region,
+		AccessKeyID:     strings.TrimSpace(string(credentials.Data["access-key-id"])),
+		SecretAccessKey: strings.TrimSpace(string(credentials.Data["secret-access-key"])),
+	}, nil
+}
+
+// ReadBackupBundle parses a bundle, refusing formats this version cannot restore
+func ReadBackupBundle(data []byte) (*BackupBundle, error) {
+	bundle := &BackupBundle{}
+	if err := yaml.Unmarshal(data, bundle); err != nil {
+		return nil, fmt.Errorf("parsing backup bundle: %w", err)
+	}
+	if bundle.Format != BackupBundleFormat {
+		return nil, fmt.Errorf("unsupported backup bundle format %q, expected %q", bundle.Format, BackupBundleFormat)
+	}
+	return bundle, nil
+}
+
+// ReadBackupSecret reads the bundle stored in the bundle Secret name in the plugin namespace
+func ReadBackupSecret(ctx context.Context, c client.Reader, name string) (*BackupBundle, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: PluginNamespace, Name: name}, secret); err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[BackupBundleKey]
+	if !ok {
+		return nil, fmt.Errorf("Secret %s/%s has no %q key", PluginNamespace, name, BackupBundleKey)
+	}
+	return ReadBackupBundle(data)
+}
+
+// RestoreOptions configures Restore
+type RestoreOptions struct {
+	// DryRun sends the writes as server-side dry runs
+	DryRun bool
+}
+
+// RestoreAction is what Restore did with an object
+type RestoreAction string
+
+const (
+	// RestoreCreated means the object was missing and was created
+	RestoreCreated RestoreAction = "Created"
+
+	// RestoreUpdated means the object existed and was overwritten with the bundle
+	RestoreUpdated RestoreAction = "Updated"
+
+	// RestoreUnchanged means the object already matched the bundle
+	RestoreUnchanged RestoreAction = "Unchanged"
+
+	// RestoreSkipped means the cluster does not serve the object's kind
+	RestoreSkipped RestoreAction = "Skipped"
+
+	// RestoreFailed means the object could not be written
+	RestoreFailed RestoreAction = "Failed"
+)
+
+// RestoreResult reports the restore of one object of a bundle
+type RestoreResult struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Action    RestoreAction
+	Message   string
+}
+
+// Restore creates the objects of bundle that are missing and overwrites the spec of those that
+// exist, in bundle order. Labels and annotations are merged into the existing ones, and
+// SecretsManagementConfigs are annotated with RestoredAtAnnotation. Kinds the cluster does not
+// serve are skipped; restoring continues past errors, which are returned together at the end.
+func Restore(ctx context.Context, c client.Client, bundle *BackupBundle, opts RestoreOptions) ([]RestoreResult, error) {
+	var writeOpts []client.CreateOption
+	var updateOpts []client.UpdateOption
+	if opts.DryRun {
+		writeOpts = append(writeOpts, client.DryRunAll)
+		updateOpts = append(updateOpts, client.DryRunAll)
+	}
+
+	var results []RestoreResult
+	var errs []error
+	restoredAt := time.Now().UTC().Format(time.RFC3339)
+	for i := range bundle.Items {
+		desired := bundle.Items[i].DeepCopy()
+		// Restored configs get their stores and issuers verified by the operator
+		if desired.GroupVersionKind() == smv1alpha1.GroupVersion.WithKind("SecretsManagementConfig") {
+			desired.SetAnnotations(mergeStringMaps(desired.GetAnnotations(), map[string]string{RestoredAtAnnotation: restoredAt}))
+		}
+		result := RestoreResult{Kind: desired.GetKind(), Namespace: desired.GetNamespace(), Name: desired.GetName()}
+		action, err := restoreObject(ctx, c, desired, writeOpts, updateOpts)
+		switch {
+		case meta.IsNoMatchError(err):
+			result.Action, result.Message = RestoreSkipped, "kind not served by the cluster"
+		case err != nil:
+			result.Action, result.Message = RestoreFailed, err.Error()
+			errs = append(errs, fmt.Errorf("%s %s: %w", result.Kind, client.ObjectKeyFromObject(desired), err))
+		default:
+			result.Action = action
+		}
+		results = append(results, result)
+	}
+	return results, utilerrors.NewAggregate(errs)
+}
+
+// PrintRestore writes the result of a restore as a table for a terminal
+func PrintRestore(w io.Writer, results []RestoreResult) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACTION\tKIND\tNAMESPACE\tNAME\tMESSAGE")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", result.Action, result.Kind, valueOr(result.Namespace, "-"), result.Name, result.Message)
+	}
+	return tw.Flush()
+}
+
+func restoreObject(ctx context.Context, c client.Client, desired *unstructured.Unstructured, createOpts []client.CreateOption, updateOpts []client.UpdateOption) (RestoreAction, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	err := c.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if errors.IsNotFound(err) {
+		return RestoreCreated, c.Create(ctx, desired, createOpts...)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	before := existing.DeepCopy()
+	for field, value := range desired.Object {
+		if field != "metadata" && field != "status" && field != "apiVersion" && field != "kind" {
+			existing.Object[field] = value
+		}
+	}
+	existing.SetLabels(mergeStringMaps(existing.GetLabels(), desired.GetLabels()))
+	existing.SetAnnotations(mergeStringMaps(existing.GetAnnotations(), desired.GetAnnotations()))
+	if equality.Semantic.DeepEqual(before, existing) {
+		return RestoreUnchanged, nil
+	}
+	return RestoreUpdated, c.Update(ctx, existing, updateOpts...)
+}
+
+func backupLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       BackupName,
+		"app.kubernetes.io/part-of":    "ocp-secrets-management",
+		"app.kubernetes.io/managed-by": "secrets-management-operator",
+	}
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestBackupObject(gvk schema.GroupVersionKind, namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func TestExportBundle(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Backup.Schedule = "0 2 * * *"
+	store := newTestBackupObject(clusterSecretStoreGVK, "", "vault", map[string]interface{}{"provider": map[string]interface{}{}})
+	store.SetResourceVersion("42")
+	store.SetAnnotations(map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"})
+	store.Object["status"] = map[string]interface{}{"ready": true}
+	generated := newTestBackupObject(clusterIssuerGVK, "", "generated", map[string]interface{}{})
+	generated.SetLabels(map[string]string{"app.kubernetes.io/managed-by": "secrets-management-operator"})
+	spcTenant := newTestBackupObject(secretProviderClassGVK, "tenant", "db", map[string]interface{}{"provider": "vault"})
+	spcPlugin := newTestBackupObject(secretProviderClassGVK, PluginNamespace, "plugin", map[string]interface{}{"provider": "vault"})
+	r := newTestReconciler(config, store, generated, spcTenant, spcPlugin)
+
+	now := time.Date(2026, 10, 16, 2, 0, 0, 0, time.UTC)
+	bundle, err := ExportBundle(ctx, r.Client, false, now)
+	require.NoError(t, err)
+	assert.Equal(t, BackupBundleFormat, bundle.Format)
+	assert.True(t, bundle.CreatedAt.Time.Equal(now))
+
+	var names []string
+	for _, item := range bundle.Items {
+		names = append(names, item.GetKind()+"/"+item.GetNamespace()+"/"+item.GetName())
+	}
+	// Configs first, then sorted by namespace and name within each kind; generated objects are left out
+	assert.Equal(t, []string{
+		"SecretsManagementConfig//cluster",
+		"ClusterSecretStore//vault",
+		"SecretProviderClass/" + PluginNamespace + "/plugin",
+		"SecretProviderClass/tenant/db",
+	}, names)
+
+	exported := bundle.Items[1]
+	assert.Empty(t, exported.GetResourceVersion())
+	assert.Empty(t, exported.GetAnnotations())
+	_, hasStatus := exported.Object["status"]
+	assert.False(t, hasStatus)
+
+	// Restricted exports namespaced kinds from the plugin namespace only
+	bundle, err = ExportBundle(ctx, r.Client, true, now)
+	require.NoError(t, err)
+	require.Len(t, bundle.Items, 3)
+	assert.Equal(t, PluginNamespace, bundle.Items[2].GetNamespace())
+}
+
+func TestBackup_WritesSecretsAndPrunes(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Backup.Schedule = "0 2 * * *"
+	config.Spec.Backup.Destination.Retain = 2
+	r := newTestReconciler(config)
+
+	start := time.Date(2026, 10, 16, 2, 0, 0, 0, time.UTC)
+	var names []string
+	for i := 0; i < 3; i++ {
+		at := start.Add(time.Duration(i) * 24 * time.Hour)
+		name, err := Backup(ctx, r.Client, BackupOptions{Now: func() time.Time { return at }})
+		require.NoError(t, err)
+		names = append(names, name)
+	}
+	assert.Equal(t, "secrets-management-backup-20261016-020000", names[0])
+
+	secrets := &corev1.SecretList{}
+	require.NoError(t, r.List(ctx, secrets, client.InNamespace(PluginNamespace), client.MatchingLabels{"app.kubernetes.io/name": BackupName}))
+	var kept []string
+	for _, secret := range secrets.Items {
+		kept = append(kept, secret.Name)
+	}
+	assert.ElementsMatch(t, names[1:], kept)
+
+	bundle, err := ReadBackupSecret(ctx, r.Client, names[2])
+	require.NoError(t, err)
+	require.Len(t, bundle.Items, 1)
+	assert.Equal(t, "cluster", bundle.Items[0].GetName())
+}
+
+func TestBackup_NotScheduled(t *testing.T) {
+	r := newTestReconciler(newTestConfig("cluster"))
+	name, err := Backup(context.Background(), r.Client, BackupOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, name)
+}
+
+func TestReadBackupBundle_RejectsOtherFormats(t *testing.T) {
+	_, err := ReadBackupBundle([]byte("format: example.com/backup/v9\nitems: []\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported")
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	existing := newTestBackupObject(clusterSecretStoreGVK, "", "vault", map[string]interface{}{"provider": "old"})
+	existing.SetLabels(map[string]string{"team": "platform"})
+	same := newTestBackupObject(secretProviderClassGVK, "tenant", "db", map[string]interface{}{"provider": "vault"})
+	r := newTestReconciler(existing, same)
+
+	restored := newTestBackupObject(clusterSecretStoreGVK, "", "vault", map[string]interface{}{"provider": "new"})
+	restored.SetLabels(map[string]string{"env": "prod"})
+	bundle := &BackupBundle{
+		Format: BackupBundleFormat,
+		Items: []unstructured.Unstructured{
+			*newTestBackupObject(clusterIssuerGVK, "", "letsencrypt", map[string]interface{}{"acme": map[string]interface{}{}}),
+			*restored,
+			*newTestBackupObject(secretProviderClassGVK, "tenant", "db", map[string]interface{}{"provider": "vault"}),
+			*newTestBackupObject(smv1alpha1.GroupVersion.WithKind("SecretsManagementConfig"), "", "cluster", map[string]interface{}{}),
+		},
+	}
+
+	results, err := Restore(ctx, r.Client, bundle, RestoreOptions{})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, RestoreCreated, results[0].Action)
+	assert.Equal(t, RestoreUpdated, results[1].Action)
+	assert.Equal(t, RestoreUnchanged, results[2].Action)
+
+	store := &unstructured.Unstructured{}
+	store.SetGroupVersionKind(clusterSecretStoreGVK)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "vault"}, store))
+	provider, _, _ := unstructured.NestedString(store.Object, "spec", "provider")
+	assert.Equal(t, "new", provider)
+	assert.Equal(t, map[string]string{"team": "platform", "env": "prod"}, store.GetLabels())
+
+	issuer := &unstructured.Unstructured{}
+	issuer.SetGroupVersionKind(clusterIssuerGVK)
+	assert.NoError(t, r.Get(ctx, types.NamespacedName{Name: "letsencrypt"}, issuer))
+
+	// Restored configs are marked for verification
+	config := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "cluster"}, config))
+	assert.NotEmpty(t, config.Annotations[RestoredAtAnnotation])
+
+	var out bytes.Buffer
+	require.NoError(t, PrintRestore(&out, results))
+	assert.Contains(t, out.String(), "Created")
+}
+
+func TestReconcileBackup(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Backup.Schedule = "0 2 * * *"
+	r := newTestReconciler(config)
+
+	require.NoError(t, r.reconcileBackup(ctx, config))
+
+	cronJob := &batchv1.CronJob{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: BackupName, Namespace: PluginNamespace}, cronJob))
+	assert.Equal(t, "0 2 * * *", cronJob.Spec.Schedule)
+	container := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []string{"--" + BackupFlag}, container.Args)
+	assert.Equal(t, BackupName, cronJob.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName)
+
+	role := &rbacv1.Role{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: BackupName, Namespace: PluginNamespace}, role))
+	assert.Equal(t, []string{"secrets"}, role.Rules[0].Resources)
+	clusterRole := &rbacv1.ClusterRole{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: BackupName}, clusterRole))
+	assert.Contains(t, clusterRole.Rules, rbacv1.PolicyRule{
+		APIGroups: []string{"cert-manager.io"},
+		Resources: []string{"clusterissuers", "issuers"},
+		Verbs:     []string{"list"},
+	})
+
+	cond := findCondition(config, smv1alpha1.ConditionBackupScheduled)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonBackupScheduled, cond.Reason)
+
+	// The last successful run is reported
+	cronJob.Status.LastSuccessfulTime = &metav1.Time{Time: time.Date(2026, 10, 16, 2, 0, 5, 0, time.UTC)}
+	require.NoError(t, r.Status().Update(ctx, cronJob))
+	require.NoError(t, r.reconcileBackup(ctx, config))
+	cond = findCondition(config, smv1alpha1.ConditionBackupScheduled)
+	assert.Contains(t, cond.Message, "2026-10-16T02:00:05Z")
+
+	// An incomplete destination keeps the CronJob
+	config.Spec.Backup.Destination.Type = smv1alpha1.BackupDestinationObjectStorage
+	require.NoError(t, r.reconcileBackup(ctx, config))
+	cond = findCondition(config, smv1alpha1.ConditionBackupScheduled)
+	assert.Equal(t, smv1alpha1.ReasonBackupDestinationInvalid, cond.Reason)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: BackupName, Namespace: PluginNamespace}, cronJob))
+
+	// Clearing the schedule removes the CronJob and its identity
+	config.Spec.Backup = smv1alpha1.BackupConfig{}
+	require.NoError(t, r.reconcileBackup(ctx, config))
+	err := r.Get(ctx, types.NamespacedName{Name: BackupName, Namespace: PluginNamespace}, cronJob)
+	assert.True(t, apierrors.IsNotFound(err))
+	err = r.Get(ctx, types.NamespacedName{Name: BackupName}, clusterRole)
+	assert.True(t, apierrors.IsNotFound(err))
+	cond = findCondition(config, smv1alpha1.ConditionBackupScheduled)
+	assert.Equal(t, smv1alpha1.ReasonBackupNotConfigured, cond.Reason)
+}
@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// reconcileBackup runs the backup CronJob on spec.backup.schedule and removes it when the schedule
+// is cleared. An incomplete destination leaves the CronJob as it was. Only the primary config
+// takes backups.
+func (r *SecretsManagementConfigReconciler) reconcileBackup(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	backup := config.Spec.Backup
+	if backup.Schedule == "" {
+		if err := r.cleanupBackupJob(ctx); err != nil {
+			return err
+		}
+		r.setCondition(config, smv1alpha1.ConditionBackupScheduled, "False", smv1alpha1.ReasonBackupNotConfigured, "No backup schedule is set in spec.backup")
+		return nil
+	}
+	if backup.Destination.Type == smv1alpha1.BackupDestinationObjectStorage && backup.Destination.ObjectStorage == nil {
+		r.setCondition(config, smv1alpha1.ConditionBackupScheduled, "False", smv1alpha1.ReasonBackupDestinationInvalid, "spec.backup.destination.objectStorage is required for the ObjectStorage destination")
+		return nil
+	}
+
+	cronJob, err := r.reconcileBackupJob(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	destination := fmt.Sprintf("Secrets in %s", PluginNamespace)
+	if storage := backup.Destination.ObjectStorage; backup.Destination.Type == smv1alpha1.BackupDestinationObjectStorage {
+		destination = fmt.Sprintf("bucket %s", storage.Bucket)
+	}
+	msg := fmt.Sprintf("Backups run on schedule %q to %s", backup.Schedule, destination)
+	if last := cronJob.Status.LastSuccessfulTime; last != nil {
+		msg += fmt.Sprintf("; the last one succeeded at %s", last.UTC().Format("2006-01-02T15:04:05Z"))
+	}
+	r.setCondition(config, smv1alpha1.ConditionBackupScheduled, "True", smv1alpha1.ReasonBackupScheduled, msg)
+	return nil
+}
+
+// reconcileBackupJob creates or updates the backup CronJob and the identity it runs under, which
+// reads the exported kinds cluster-wide and writes Secrets in the plugin namespace only. All of
+// them are owned by the config. It returns the CronJob as found on the cluster.
+func (r *SecretsManagementConfigReconciler) reconcileBackupJob(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) (*batchv1.CronJob, error) {
+	labels := backupLabels()
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: BackupName, Namespace: PluginNamespace, Labels: labels},
+	}
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: BackupName, Labels: labels},
+		Rules:      backupClusterRules(),
+	}
+	clusterBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: BackupName, Labels: labels},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: BackupName},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: BackupName, Namespace: PluginNamespace}},
+	}
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: BackupName, Namespace: PluginNamespace, Labels: labels},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+			Verbs:     []string{"get", "list", "create", "delete"},
+		}},
+	}
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: BackupName, Namespace: PluginNamespace, Labels: labels},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: BackupName},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: BackupName, Namespace: PluginNamespace}},
+	}
+	cronJob := buildOperatorCronJob(BackupName, "backup", config.Spec.Backup.Schedule, BackupFlag, r.Restricted, labels)
+
+	for _, obj := range []client.Object{sa, clusterRole, clusterBinding, role, binding, cronJob} {
+		if err := controllerutil.SetControllerReference(config, obj, r.Scheme); err != nil {
+			return nil, err
+		}
+		applyCommonMetadata(config, obj)
+	}
+	if err := setAppliedSpecHash(cronJob); err != nil {
+		return nil, err
+	}
+
+	if err := createIfMissing(ctx, r, sa); err != nil {
+		return nil, err
+	}
+
+	existingClusterRole := &rbacv1.ClusterRole{}
+	if err := r.Get(ctx, types.NamespacedName{Name: clusterRole.Name}, existingClusterRole); errors.IsNotFound(err) {
+		if err := r.Create(ctx, clusterRole); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	} else {
+		before := existingClusterRole.DeepCopy()
+		existingClusterRole.Rules = clusterRole.Rules
+		mergeMetadata(existingClusterRole, clusterRole)
+		if err := updateIfChanged(ctx, r, before, existingClusterRole); err != nil {
+			return nil, err
+		}
+	}
+
+	existingRole := &rbacv1.Role{}
+	if err := r.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, existingRole); errors.IsNotFound(err) {
+		if err := r.Create(ctx, role); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	} else {
+		before := existingRole.DeepCopy()
+		existingRole.Rules = role.Rules
+		mergeMetadata(existingRole, role)
+		if err := updateIfChanged(ctx, r, before, existingRole); err != nil {
+			return nil, err
+		}
+	}
+
+	// The role reference of a binding cannot change, so existing bindings are left as they are
+	for _, obj := range []client.Object{clusterBinding, binding} {
+		if err := createIfMissing(ctx, r, obj); err != nil {
+			return nil, err
+		}
+	}
+
+	existing := &batchv1.CronJob{}
+	err := r.Get(ctx, types.NamespacedName{Name: cronJob.Name, Namespace: cronJob.Namespace}, existing)
+	if errors.IsNotFound(err) {
+		return cronJob, r.Create(ctx, cronJob)
+	}
+	if err != nil {
+		return nil, err
+	}
+	before := existing.DeepCopy()
+	if specChanged(existing, cronJob, existing.Spec, cronJob.Spec) {
+		existing.Spec = cronJob.Spec
+	}
+	mergeMetadata(existing, cronJob)
+	return existing, updateIfChanged(ctx, r, before, existing)
+}
+
+// backupClusterRules let the backup read the primary config and list the exported kinds
+func backupClusterRules() []rbacv1.PolicyRule {
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{smv1alpha1.GroupVersion.Group},
+			Resources: []string{"secretsmanagementconfigs"},
+			Verbs:     []string{"get", "list"},
+		},
+	}
+	resources := map[string][]string{}
+	var groups []string
+	for _, kind := range backupKinds[1:] {
+		if _, ok := resources[kind.gvk.Group]; !ok {
+			groups = append(groups, kind.gvk.Group)
+		}
+		resources[kind.gvk.Group] = append(resources[kind.gvk.Group], strings.ToLower(kind.gvk.Kind)+"s")
+	}
+	for _, group := range groups {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{group},
+			Resources: resources[group],
+			Verbs:     []string{"list"},
+		})
+	}
+	return rules
+}
+
+// cleanupBackupJob deletes the backup CronJob, its Jobs and its identity. Bundle Secrets are kept.
+func (r *SecretsManagementConfigReconciler) cleanupBackupJob(ctx context.Context) error {
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: BackupName, Namespace: PluginNamespace}}
+	if err := r.Delete(ctx, cronJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	for _, obj := range []client.Object{
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: BackupName}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: BackupName}},
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: BackupName, Namespace: PluginNamespace}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: BackupName, Namespace: PluginNamespace}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: BackupName, Namespace: PluginNamespace}},
+	} {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
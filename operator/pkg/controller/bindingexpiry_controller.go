@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// BindingExpiresAtAnnotation marks an operator-created RoleBinding or ClusterRoleBinding for
+// removal at the given RFC 3339 time
+const BindingExpiresAtAnnotation = "secrets-management.openshift.io/expires-at"
+
+// BindingExpiryReconciler deletes operator-managed RoleBindings and ClusterRoleBindings once the
+// time in their expires-at annotation has passed. ClusterRoleBindings are requested by name only;
+// RoleBindings by namespace and name.
+type BindingExpiryReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+}
+
+// Reconcile removes an expired binding, or requeues until it expires
+func (r *BindingExpiryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("binding", req.NamespacedName)
+
+	var binding client.Object = &rbacv1.RoleBinding{}
+	if req.Namespace == "" {
+		binding = &rbacv1.ClusterRoleBinding{}
+	}
+	if err := r.Get(ctx, req.NamespacedName, binding); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !binding.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	value, ok := binding.GetAnnotations()[BindingExpiresAtAnnotation]
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		// Not retried; the binding is requeued when the annotation changes
+		log.Info("Ignoring invalid expiry annotation", "value", value)
+		r.Recorder.Eventf(binding, corev1.EventTypeWarning, "InvalidExpiry", "Annotation %s=%q is not an RFC 3339 time", BindingExpiresAtAnnotation, value)
+		return ctrl.Result{}, nil
+	}
+
+	if remaining := time.Until(expiresAt); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	// Only delete the binding that was checked, not one recreated under the same name
+	uid := binding.GetUID()
+	if err := r.Delete(ctx, binding, client.Preconditions{UID: &uid}); err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	log.Info("Deleted expired binding", "expiresAt", value)
+	r.Recorder.Eventf(binding, corev1.EventTypeNormal, "BindingExpired", "Deleted binding expired at %s", value)
+	return ctrl.Result{}, nil
+}
+
+// hasExpiryAnnotation selects bindings carrying the expires-at annotation
+func hasExpiryAnnotation() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		_, ok := obj.GetAnnotations()[BindingExpiresAtAnnotation]
+		return ok
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager. The cache only holds bindings
+// labeled as managed by the operator, so unrelated bindings are never seen.
+func (r *BindingExpiryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("bindingexpiry").
+		For(&rbacv1.ClusterRoleBinding{}, builder.WithPredicates(hasExpiryAnnotation())).
+		Watches(&rbacv1.RoleBinding{}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(hasExpiryAnnotation())).
+		Complete(r)
+}
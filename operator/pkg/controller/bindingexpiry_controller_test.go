@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestBindingExpiryReconciler(objs ...client.Object) (*BindingExpiryReconciler, *record.FakeRecorder) {
+	recorder := record.NewFakeRecorder(10)
+	return &BindingExpiryReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(objs...).Build(),
+		Log:      logr.Discard(),
+		Recorder: recorder,
+	}, recorder
+}
+
+func expiringMeta(namespace, name string, expiresAt string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   namespace,
+		Labels:      map[string]string{"app.kubernetes.io/managed-by": "secrets-management-operator"},
+		Annotations: map[string]string{BindingExpiresAtAnnotation: expiresAt},
+	}
+}
+
+func TestBindingExpiry_DeletesExpiredRoleBinding(t *testing.T) {
+	binding := &rbacv1.RoleBinding{ObjectMeta: expiringMeta("team-a", "jit", time.Now().Add(-time.Minute).UTC().Format(time.RFC3339))}
+	r, recorder := newTestBindingExpiryReconciler(binding)
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "jit"}})
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	err = r.Get(context.Background(), client.ObjectKeyFromObject(binding), &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "BindingExpired")
+}
+
+func TestBindingExpiry_RequeuesUntilExpiry(t *testing.T) {
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: expiringMeta("", "jit", time.Now().Add(time.Hour).UTC().Format(time.RFC3339))}
+	r, _ := newTestBindingExpiryReconciler(binding)
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "jit"}})
+	require.NoError(t, err)
+	assert.Greater(t, result.RequeueAfter, 59*time.Minute)
+
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(binding), &rbacv1.ClusterRoleBinding{}))
+}
+
+func TestBindingExpiry_InvalidAnnotationKeepsBinding(t *testing.T) {
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: expiringMeta("", "jit", "tomorrow")}
+	r, recorder := newTestBindingExpiryReconciler(binding)
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "jit"}})
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(binding), &rbacv1.ClusterRoleBinding{}))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "InvalidExpiry")
+}
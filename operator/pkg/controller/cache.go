@@ -0,0 +1,99 @@
+package controller
+
+import (
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// CacheOptions restricts the manager's cache to the objects the operator actually manages.
+// Namespaced kinds are only cached in the plugin namespace, cluster-scoped kinds are selected
+// by name or label, and CRDs are cached without their schemas. Secrets and Nodes are read as
+// metadata only, so only their metadata informers are started. Managed RoleBindings are cached in
+// every namespace so expiring bindings are seen wherever they were granted, as are the managed
+// Roles generated for visible namespaces, and only Warning Events are cached, for forwarding to
+// audit sinks. SecretsManagementTenants are cached in every namespace. In restricted mode no Namespace informer is configured, since the operator never
+// reads Namespaces, and Roles, RoleBindings and Events are only cached in the plugin namespace.
+func CacheOptions(restricted bool) cache.Options {
+	opts := cache.Options{
+		DefaultNamespaces: map[string]cache.Config{
+			PluginNamespace: {},
+		},
+		DefaultTransform: stripManagedFields,
+		ByObject: map[client.Object]cache.ByObject{
+			&rbacv1.ClusterRole{}: {
+				Label: labels.SelectorFromSet(labels.Set{"app.kubernetes.io/managed-by": "secrets-management-operator"}),
+			},
+			&rbacv1.ClusterRoleBinding{}: {
+				Label: labels.SelectorFromSet(labels.Set{"app.kubernetes.io/managed-by": "secrets-management-operator"}),
+			},
+			&admissionregistrationv1beta1.ValidatingAdmissionPolicy{}: {
+				Label: labels.SelectorFromSet(labels.Set{"app.kubernetes.io/managed-by": "secrets-management-operator"}),
+			},
+			&admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}: {
+				Label: labels.SelectorFromSet(labels.Set{"app.kubernetes.io/managed-by": "secrets-management-operator"}),
+			},
+			&apiextensionsv1.CustomResourceDefinition{}: {
+				Transform: stripCRDSchemas,
+			},
+		},
+	}
+	events := cache.ByObject{
+		Field: fields.OneTermEqualSelector("type", corev1.EventTypeWarning),
+	}
+	roleBindings := cache.ByObject{
+		Label: labels.SelectorFromSet(labels.Set{"app.kubernetes.io/managed-by": "secrets-management-operator"}),
+	}
+	roles := cache.ByObject{
+		Label: labels.SelectorFromSet(labels.Set{"app.kubernetes.io/managed-by": "secrets-management-operator"}),
+	}
+	tenants := cache.ByObject{}
+	if !restricted {
+		opts.ByObject[&corev1.Namespace{}] = cache.ByObject{
+			Field: fields.OneTermEqualSelector("metadata.name", PluginNamespace),
+		}
+		roleBindings.Namespaces = map[string]cache.Config{cache.AllNamespaces: {}}
+		roles.Namespaces = map[string]cache.Config{cache.AllNamespaces: {}}
+		events.Namespaces = map[string]cache.Config{cache.AllNamespaces: {}}
+		tenants.Namespaces = map[string]cache.Config{cache.AllNamespaces: {}}
+	}
+	opts.ByObject[&rbacv1.RoleBinding{}] = roleBindings
+	opts.ByObject[&rbacv1.Role{}] = roles
+	opts.ByObject[&corev1.Event{}] = events
+	opts.ByObject[&smv1alpha1.SecretsManagementTenant{}] = tenants
+	return opts
+}
+
+// stripManagedFields drops managedFields, which the operator never reads, from cached objects
+func stripManagedFields(obj interface{}) (interface{}, error) {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+	return obj, nil
+}
+
+// stripCRDSchemas keeps only what operator detection needs from a CRD: its name and served versions
+func stripCRDSchemas(obj interface{}) (interface{}, error) {
+	crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
+	if !ok {
+		return obj, nil
+	}
+	crd.ManagedFields = nil
+	crd.Annotations = nil
+	crd.Spec.Conversion = nil
+	for i := range crd.Spec.Versions {
+		crd.Spec.Versions[i].Schema = nil
+		crd.Spec.Versions[i].AdditionalPrinterColumns = nil
+		crd.Spec.Versions[i].Subresources = nil
+	}
+	crd.Status = apiextensionsv1.CustomResourceDefinitionStatus{}
+	return crd, nil
+}
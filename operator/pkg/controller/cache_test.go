@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestCacheOptions(t *testing.T) {
+	opts := CacheOptions(false)
+
+	assert.Contains(t, opts.DefaultNamespaces, PluginNamespace)
+	assert.Len(t, opts.DefaultNamespaces, 1)
+
+	for obj, byObject := range opts.ByObject {
+		switch obj.(type) {
+		case *corev1.Namespace:
+			require.NotNil(t, byObject.Field)
+			assert.Equal(t, "metadata.name="+PluginNamespace, byObject.Field.String())
+		case *apiextensionsv1.CustomResourceDefinition:
+			assert.NotNil(t, byObject.Transform)
+		case *rbacv1.RoleBinding:
+			assert.Contains(t, byObject.Namespaces, cache.AllNamespaces)
+			assert.Equal(t, "app.kubernetes.io/managed-by=secrets-management-operator", byObject.Label.String())
+		case *smv1alpha1.SecretsManagementTenant:
+			assert.Contains(t, byObject.Namespaces, cache.AllNamespaces)
+		}
+	}
+}
+
+func TestCacheOptions_Restricted(t *testing.T) {
+	opts := CacheOptions(true)
+
+	assert.Contains(t, opts.DefaultNamespaces, PluginNamespace)
+	for obj, byObject := range opts.ByObject {
+		_, isNamespace := obj.(*corev1.Namespace)
+		assert.False(t, isNamespace, "restricted mode must not watch Namespaces")
+		if _, ok := obj.(*rbacv1.RoleBinding); ok {
+			assert.Nil(t, byObject.Namespaces, "restricted mode must only cache RoleBindings in the plugin namespace")
+		}
+	}
+}
+
+func TestStripCRDSchemas(t *testing.T) {
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "certificates.cert-manager.io",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kube-apiserver"}},
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:   "v1",
+					Served: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"},
+					},
+				},
+			},
+		},
+	}
+
+	out, err := stripCRDSchemas(crd)
+	require.NoError(t, err)
+	stripped := out.(*apiextensionsv1.CustomResourceDefinition)
+	assert.Equal(t, "certificates.cert-manager.io", stripped.Name)
+	assert.Empty(t, stripped.ManagedFields)
+	require.Len(t, stripped.Spec.Versions, 1)
+	assert.Equal(t, "v1", stripped.Spec.Versions[0].Name)
+	assert.True(t, stripped.Spec.Versions[0].Served)
+	assert.Nil(t, stripped.Spec.Versions[0].Schema)
+
+	// Other types pass through untouched
+	cm := &corev1.ConfigMap{}
+	out, err = stripCRDSchemas(cm)
+	require.NoError(t, err)
+	assert.Same(t, cm, out)
+}
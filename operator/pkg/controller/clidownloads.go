@@ -0,0 +1,343 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// CLIDownloadsName names the Deployment, Service and Route serving the smcctl binaries
+	CLIDownloadsName = "secrets-management-cli-downloads"
+
+	// CLIDownloadName is the ConsoleCLIDownload listing smcctl on the console's Command Line
+	// Tools page
+	CLIDownloadName = "smcctl"
+
+	// ServeCLIDownloadsFlag makes the manager binary serve the smcctl binaries instead of running
+	// the controllers
+	ServeCLIDownloadsFlag = "serve-cli-downloads"
+
+	// CLIDownloadsDir holds the smcctl binaries in the operator image, as <os>/<arch>/smcctl
+	CLIDownloadsDir = "/usr/share/smcctl"
+
+	// CLIDownloadsPort is the plain HTTP port the binaries are served on; the Route terminates TLS
+	CLIDownloadsPort = 8080
+)
+
+var consoleCLIDownloadGVK = schema.GroupVersionKind{
+	Group:   "console.openshift.io",
+	Version: "v1",
+	Kind:    "ConsoleCLIDownload",
+}
+
+// cliDownloadPlatform is an operating system and architecture smcctl is built for
+type cliDownloadPlatform struct {
+	OS    string
+	Arch  string
+	Label string
+}
+
+// Path returns the path of the platform's binary, relative to CLIDownloadsDir and the Route
+func (p cliDownloadPlatform) Path() string {
+	binary := "smcctl"
+	if p.OS == "windows" {
+		binary += ".exe"
+	}
+	return p.OS + "/" + p.Arch + "/" + binary
+}
+
+// cliDownloadPlatforms are the smcctl builds the operator image carries; the Dockerfile builds
+// the same list
+var cliDownloadPlatforms = []cliDownloadPlatform{
+	{OS: "linux", Arch: "amd64", Label: "Linux for x86_64"},
+	{OS: "linux", Arch: "arm64", Label: "Linux for ARM 64"},
+	{OS: "darwin", Arch: "amd64", Label: "Mac for x86_64"},
+	{OS: "darwin", Arch: "arm64", Label: "Mac for ARM 64"},
+	{OS: "windows", Arch: "amd64", Label: "Windows for x86_64"},
+}
+
+// +kubebuilder:rbac:groups=console.openshift.io,resources=consoleclidownloads,verbs=get;list;watch;create;update;patch;delete
+
+// reconcileCLIDownloads serves the smcctl binaries from the operator image behind a Route and
+// lists them on the console's Command Line Tools page with a ConsoleCLIDownload. The
+// ConsoleCLIDownload is created once the router admits the Route, since its links need the host.
+// ConsoleCLIDownloads are cluster-scoped, so nothing is published in restricted mode, and
+// clusters without the API only get the Route.
+func (r *SecretsManagementConfigReconciler) reconcileCLIDownloads(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	if r.Restricted {
+		return nil
+	}
+
+	labels := cliDownloadsLabels()
+	deployment := buildCLIDownloadsDeployment(labels)
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: CLIDownloadsName, Namespace: PluginNamespace, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app.kubernetes.io/name": CLIDownloadsName},
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       CLIDownloadsPort,
+				TargetPort: intstr.FromString("http"),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(routeGVK)
+	route.SetName(CLIDownloadsName)
+	route.SetNamespace(PluginNamespace)
+	route.SetLabels(labels)
+	if err := unstructured.SetNestedField(route.Object, map[string]interface{}{
+		"to": map[string]interface{}{
+			"kind":   "Service",
+			"name":   CLIDownloadsName,
+			"weight": int64(100),
+		},
+		"port": map[string]interface{}{
+			"targetPort": "http",
+		},
+		"tls": map[string]interface{}{
+			"termination":                   "edge",
+			"insecureEdgeTerminationPolicy": "Redirect",
+		},
+		"wildcardPolicy": "None",
+	}, "spec"); err != nil {
+		return err
+	}
+
+	for _, obj := range []client.Object{deployment, svc, route} {
+		if err := controllerutil.SetControllerReference(config, obj, r.Scheme); err != nil {
+			return err
+		}
+		applyCommonMetadata(config, obj)
+		if err := setAppliedSpecHash(obj); err != nil {
+			return err
+		}
+	}
+
+	existingDeployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, existingDeployment); errors.IsNotFound(err) {
+		if err := r.Create(ctx, deployment); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		before := existingDeployment.DeepCopy()
+		if specChanged(existingDeployment, deployment, existingDeployment.Spec, deployment.Spec) {
+			existingDeployment.Spec = deployment.Spec
+		}
+		mergeMetadata(existingDeployment, deployment)
+		if err := updateIfChanged(ctx, r, before, existingDeployment); err != nil {
+			return err
+		}
+	}
+
+	existingService := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, existingService); errors.IsNotFound(err) {
+		if err := r.Create(ctx, svc); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		// The API server fills in cluster IPs the desired spec leaves unset
+		before := existingService.DeepCopy()
+		if specChanged(existingService, svc, existingService.Spec, svc.Spec) {
+			existingService.Spec.Ports = svc.Spec.Ports
+			existingService.Spec.Selector = svc.Spec.Selector
+		}
+		mergeMetadata(existingService, svc)
+		if err := updateIfChanged(ctx, r, before, existingService); err != nil {
+			return err
+		}
+	}
+
+	existingRoute := &unstructured.Unstructured{}
+	existingRoute.SetGroupVersionKind(routeGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: route.GetName(), Namespace: route.GetNamespace()}, existingRoute); errors.IsNotFound(err) {
+		// Created without a host; the router assigns one and admits it on a later reconcile
+		return r.Create(ctx, route)
+	} else if err != nil {
+		return err
+	}
+	before := existingRoute.DeepCopy()
+	if specChanged(existingRoute, route, existingRoute.Object["spec"], route.Object["spec"]) {
+		// Keep the router-generated host stable across updates
+		spec, _, _ := unstructured.NestedMap(route.Object, "spec")
+		if host, found, _ := unstructured.NestedString(existingRoute.Object, "spec", "host"); found {
+			spec["host"] = host
+		}
+		if err := unstructured.SetNestedField(existingRoute.Object, spec, "spec"); err != nil {
+			return err
+		}
+	}
+	mergeMetadata(existingRoute, route)
+	if err := updateIfChanged(ctx, r, before, existingRoute); err != nil {
+		return err
+	}
+
+	host := admittedRouteHost(existingRoute)
+	if host == "" {
+		return nil
+	}
+	return r.reconcileConsoleCLIDownload(ctx, config, host)
+}
+
+// reconcileConsoleCLIDownload creates or updates the ConsoleCLIDownload linking the smcctl
+// binaries served on host
+func (r *SecretsManagementConfigReconciler) reconcileConsoleCLIDownload(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, host string) error {
+	download := &unstructured.Unstructured{}
+	download.SetGroupVersionKind(consoleCLIDownloadGVK)
+	download.SetName(CLIDownloadName)
+	download.SetLabels(cliDownloadsLabels())
+	if err := unstructured.SetNestedField(download.Object, consoleCLIDownloadSpec(host), "spec"); err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(config, download, r.Scheme); err != nil {
+		return err
+	}
+	applyCommonMetadata(config, download)
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(consoleCLIDownloadGVK)
+	err := r.Get(ctx, types.NamespacedName{Name: CLIDownloadName}, existing)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		return r.Create(ctx, download)
+	}
+
+	before := existing.DeepCopy()
+	existing.Object["spec"] = download.Object["spec"]
+	mergeMetadata(existing, download)
+	return updateIfChanged(ctx, r, before, existing)
+}
+
+// consoleCLIDownloadSpec returns the ConsoleCLIDownload spec with a link per platform on host
+func consoleCLIDownloadSpec(host string) map[string]interface{} {
+	links := make([]interface{}, 0, len(cliDownloadPlatforms))
+	for _, platform := range cliDownloadPlatforms {
+		links = append(links, map[string]interface{}{
+			"href": fmt.Sprintf("https://%s/%s", host, platform.Path()),
+			"text": "Download smcctl for " + platform.Label,
+		})
+	}
+	return map[string]interface{}{
+		"displayName": "smcctl - Secrets Management CLI",
+		"description": "With `smcctl`, you can report the phase, conditions and detected operators of a " +
+			"SecretsManagementConfig, check what stops the console from loading the Secrets Management " +
+			"plugin, and restore the configuration from a backup. It uses your kubeconfig, like `oc`.",
+		"links": links,
+	}
+}
+
+// buildCLIDownloadsDeployment returns the Deployment running the operator image with
+// --serve-cli-downloads. The server only reads files from the image, so the pod runs with the
+// restricted security profile and without a service account token.
+func buildCLIDownloadsDeployment(labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: CLIDownloadsName, Namespace: PluginNamespace, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(1),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": CLIDownloadsName}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					AutomountServiceAccountToken: boolPtr(false),
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: boolPtr(true),
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:    "downloads",
+							Image:   operatorImage(),
+							Command: []string{"/manager"},
+							Args:    []string{"--" + ServeCLIDownloadsFlag},
+							Ports: []corev1.ContainerPort{{
+								Name:          "http",
+								ContainerPort: CLIDownloadsPort,
+								Protocol:      corev1.ProtocolTCP,
+							}},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("http")},
+								},
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("10m"),
+									corev1.ResourceMemory: resource.MustParse("32Mi"),
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceMemory: resource.MustParse("128Mi"),
+								},
+							},
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: boolPtr(false),
+								ReadOnlyRootFilesystem:   boolPtr(true),
+								Capabilities: &corev1.Capabilities{
+									Drop: []corev1.Capability{"ALL"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// cleanupCLIDownloads deletes the ConsoleCLIDownload and what serves the binaries
+func (r *SecretsManagementConfigReconciler) cleanupCLIDownloads(ctx context.Context) error {
+	download := &unstructured.Unstructured{}
+	download.SetGroupVersionKind(consoleCLIDownloadGVK)
+	download.SetName(CLIDownloadName)
+	if err := r.Delete(ctx, download); client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(routeGVK)
+	route.SetName(CLIDownloadsName)
+	route.SetNamespace(PluginNamespace)
+	for _, obj := range []client.Object{
+		route,
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: CLIDownloadsName, Namespace: PluginNamespace}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: CLIDownloadsName, Namespace: PluginNamespace}},
+	} {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func cliDownloadsLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       CLIDownloadsName,
+		"app.kubernetes.io/part-of":    "ocp-secrets-management",
+		"app.kubernetes.io/managed-by": "secrets-management-operator",
+	}
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func getTestConsoleCLIDownload(ctx context.Context, r *SecretsManagementConfigReconciler) (*unstructured.Unstructured, error) {
+	download := &unstructured.Unstructured{}
+	download.SetGroupVersionKind(consoleCLIDownloadGVK)
+	err := r.Get(ctx, types.NamespacedName{Name: CLIDownloadName}, download)
+	return download, err
+}
+
+func TestReconcileCLIDownloads(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler(config)
+
+	require.NoError(t, r.reconcileCLIDownloads(ctx, config))
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: CLIDownloadsName, Namespace: PluginNamespace}, deployment))
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, DefaultOperatorImage, container.Image)
+	assert.Equal(t, []string{"--" + ServeCLIDownloadsFlag}, container.Args)
+	assert.False(t, *deployment.Spec.Template.Spec.AutomountServiceAccountToken)
+	require.Len(t, deployment.OwnerReferences, 1)
+	assert.Equal(t, SingletonConfigName, deployment.OwnerReferences[0].Name)
+	svc := &corev1.Service{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: CLIDownloadsName, Namespace: PluginNamespace}, svc))
+	assert.Equal(t, CLIDownloadsName, svc.Spec.Selector["app.kubernetes.io/name"])
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(routeGVK)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: CLIDownloadsName, Namespace: PluginNamespace}, route))
+	termination, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "termination")
+	assert.Equal(t, "edge", termination)
+
+	// No link until the router admits the Route
+	_, err := getTestConsoleCLIDownload(ctx, r)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	require.NoError(t, unstructured.SetNestedField(route.Object, "downloads.apps.example.com", "spec", "host"))
+	require.NoError(t, unstructured.SetNestedSlice(route.Object, []interface{}{
+		map[string]interface{}{
+			"host": "downloads.apps.example.com",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Admitted", "status": "True"},
+			},
+		},
+	}, "status", "ingress"))
+	require.NoError(t, r.Update(ctx, route))
+	require.NoError(t, r.reconcileCLIDownloads(ctx, config))
+
+	download, err := getTestConsoleCLIDownload(ctx, r)
+	require.NoError(t, err)
+	links, _, _ := unstructured.NestedSlice(download.Object, "spec", "links")
+	require.Len(t, links, len(cliDownloadPlatforms))
+	assert.Equal(t, map[string]interface{}{
+		"href": "https://downloads.apps.example.com/linux/amd64/smcctl",
+		"text": "Download smcctl for Linux for x86_64",
+	}, links[0])
+	assert.Equal(t, "https://downloads.apps.example.com/windows/amd64/smcctl.exe", links[4].(map[string]interface{})["href"])
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: CLIDownloadsName, Namespace: PluginNamespace}, route))
+	host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+	assert.Equal(t, "downloads.apps.example.com", host, "the generated host is kept")
+
+	// Deleting the config removes the download and what serves it
+	require.NoError(t, r.cleanupCLIDownloads(ctx))
+	_, err = getTestConsoleCLIDownload(ctx, r)
+	assert.True(t, apierrors.IsNotFound(err))
+	err = r.Get(ctx, types.NamespacedName{Name: CLIDownloadsName, Namespace: PluginNamespace}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileCLIDownloads_Restricted(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler(config)
+	r.Restricted = true
+
+	require.NoError(t, r.reconcileCLIDownloads(ctx, config))
+
+	err := r.Get(ctx, types.NamespacedName{Name: CLIDownloadsName, Namespace: PluginNamespace}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
@@ -0,0 +1,327 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/cloudcredentials"
+)
+
+const (
+	// awsRoleARNAnnotation has the pod identity webhook of EKS and of OpenShift on AWS with STS
+	// project a web identity token into the service account's pods
+	awsRoleARNAnnotation = "eks.amazonaws.com/role-arn"
+
+	// awsAudienceAnnotation overrides the audience of the projected token
+	awsAudienceAnnotation = "eks.amazonaws.com/audience"
+
+	// gcpServiceAccountAnnotation names the Google service account the pods impersonate
+	gcpServiceAccountAnnotation = "iam.gke.io/gcp-service-account"
+
+	// azureClientIDAnnotation and azureTenantIDAnnotation name the identity the pods federate with
+	azureClientIDAnnotation = "azure.workload.identity/client-id"
+	azureTenantIDAnnotation = "azure.workload.identity/tenant-id"
+
+	// defaultAWSAudience is the audience IRSA tokens are issued for
+	defaultAWSAudience = "sts.amazonaws.com"
+
+	// defaultAWSFederationRegion is the STS region the federation is validated against
+	defaultAWSFederationRegion = "us-east-1"
+
+	// azureTokenAudience is the audience Microsoft Entra ID accepts federated tokens for
+	azureTokenAudience = "api://AzureADTokenExchange"
+
+	// cloudAuthTokenExpiration is the lifetime of the tokens requested to validate the
+	// federation, the shortest the API server issues
+	cloudAuthTokenExpiration = 10 * time.Minute
+)
+
+// cloudAuthAnnotations are every annotation the operator sets for spec.cloudAuth; the ones of
+// providers not selected are removed
+var cloudAuthAnnotations = []string{
+	awsRoleARNAnnotation,
+	awsAudienceAnnotation,
+	gcpServiceAccountAnnotation,
+	azureClientIDAnnotation,
+	azureTenantIDAnnotation,
+}
+
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts/token,verbs=create
+
+// reconcileCloudAuth annotates the service accounts in spec.cloudAuth for the selected cloud
+// workload identity and removes the annotations from the ones dropped from the list, so the
+// External Secrets Operator and CSI providers authenticate without static credentials. The
+// federation is validated by exchanging a short-lived token of each service account with the
+// provider, once per spec change and at most hourly otherwise. Service accounts live in other
+// namespaces, so nothing is annotated in restricted mode.
+func (r *SecretsManagementConfigReconciler) reconcileCloudAuth(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	cloudAuth := &config.Spec.CloudAuth
+	previous := config.Status.CloudAuth
+	if cloudAuth.Provider == "" {
+		config.Status.CloudAuth = nil
+		r.removeCondition(config, smv1alpha1.ConditionCloudAuthFederated)
+		return r.pruneCloudAuthAnnotations(ctx, previous, nil)
+	}
+	if r.Restricted {
+		config.Status.CloudAuth = nil
+		r.setCondition(config, smv1alpha1.ConditionCloudAuthFederated, "False", smv1alpha1.ReasonCloudAuthUnavailable,
+			"Annotating service accounts for cloud workload identity needs cluster-wide access and is not available in restricted mode")
+		return nil
+	}
+	annotations, err := cloudAuthAnnotationValues(cloudAuth)
+	if err != nil {
+		return err
+	}
+
+	recheck := previous == nil || previous.Provider != cloudAuth.Provider || previous.ObservedGeneration != config.Generation ||
+		previous.LastChecked == nil || time.Since(previous.LastChecked.Time) >= credentialsCheckInterval
+	checked := map[types.NamespacedName]smv1alpha1.CloudAuthServiceAccountStatus{}
+	if previous != nil {
+		for _, status := range previous.ServiceAccounts {
+			checked[types.NamespacedName{Namespace: status.Namespace, Name: status.Name}] = status
+		}
+	}
+	status := &smv1alpha1.CloudAuthStatus{Provider: cloudAuth.Provider, ObservedGeneration: config.Generation}
+	if recheck {
+		now := metav1.Now()
+		status.LastChecked = &now
+	} else {
+		status.LastChecked = previous.LastChecked
+	}
+
+	desired := make(map[types.NamespacedName]bool, len(cloudAuth.ServiceAccounts))
+	for _, ref := range cloudAuth.ServiceAccounts {
+		key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+		desired[key] = true
+		saStatus := smv1alpha1.CloudAuthServiceAccountStatus{Namespace: ref.Namespace, Name: ref.Name}
+
+		// Service accounts live in namespaces the cache does not cover
+		sa := &corev1.ServiceAccount{}
+		if err := r.apiReader().Get(ctx, key, sa); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			saStatus.Message = "the service account does not exist"
+			status.ServiceAccounts = append(status.ServiceAccounts, saStatus)
+			continue
+		}
+		if err := r.annotateServiceAccount(ctx, sa, annotations); err != nil {
+			return err
+		}
+		saStatus.Annotated = true
+
+		if last, ok := checked[key]; ok && last.Annotated && !recheck {
+			saStatus.Federated, saStatus.Identity, saStatus.Message = last.Federated, last.Identity, last.Message
+		} else {
+			r.checkFederation(ctx, cloudAuth, sa, &saStatus)
+		}
+		status.ServiceAccounts = append(status.ServiceAccounts, saStatus)
+	}
+
+	if err := r.pruneCloudAuthAnnotations(ctx, previous, desired); err != nil {
+		return err
+	}
+	config.Status.CloudAuth = status
+	r.setCloudAuthCondition(config, status)
+	return nil
+}
+
+// cloudAuthAnnotationValues returns the annotations of the selected provider
+func cloudAuthAnnotationValues(cloudAuth *smv1alpha1.CloudAuthConfig) (map[string]string, error) {
+	switch cloudAuth.Provider {
+	case smv1alpha1.CloudAuthProviderAWS:
+		if cloudAuth.AWS == nil {
+			return nil, fmt.Errorf("spec.cloudAuth.aws is required for provider AWS")
+		}
+		annotations := map[string]string{awsRoleARNAnnotation: cloudAuth.AWS.RoleARN}
+		if cloudAuth.AWS.Audience != "" && cloudAuth.AWS.Audience != defaultAWSAudience {
+			annotations[awsAudienceAnnotation] = cloudAuth.AWS.Audience
+		}
+		return annotations, nil
+	case smv1alpha1.CloudAuthProviderGCP:
+		if cloudAuth.GCP == nil {
+			return nil, fmt.Errorf("spec.cloudAuth.gcp is required for provider GCP")
+		}
+		return map[string]string{gcpServiceAccountAnnotation: cloudAuth.GCP.ServiceAccount}, nil
+	case smv1alpha1.CloudAuthProviderAzure:
+		if cloudAuth.Azure == nil {
+			return nil, fmt.Errorf("spec.cloudAuth.azure is required for provider Azure")
+		}
+		return map[string]string{
+			azureClientIDAnnotation: cloudAuth.Azure.ClientID,
+			azureTenantIDAnnotation: cloudAuth.Azure.TenantID,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown cloud auth provider %q", cloudAuth.Provider)
+	}
+}
+
+// annotateServiceAccount sets annotations on sa and removes the other providers' annotations.
+// Only the annotations are patched; the service account belongs to the operator deploying it.
+func (r *SecretsManagementConfigReconciler) annotateServiceAccount(ctx context.Context, sa *corev1.ServiceAccount, annotations map[string]string) error {
+	base := sa.DeepCopy()
+	changed := false
+	for _, key := range cloudAuthAnnotations {
+		value, want := annotations[key]
+		current, has := sa.Annotations[key]
+		switch {
+		case want && (!has || current != value):
+			if sa.Annotations == nil {
+				sa.Annotations = map[string]string{}
+			}
+			sa.Annotations[key] = value
+			changed = true
+		case !want && has:
+			delete(sa.Annotations, key)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return r.Patch(ctx, sa, client.MergeFrom(base))
+}
+
+// pruneCloudAuthAnnotations removes the cloud auth annotations from the service accounts in
+// previous that are not desired
+func (r *SecretsManagementConfigReconciler) pruneCloudAuthAnnotations(ctx context.Context, previous *smv1alpha1.CloudAuthStatus, desired map[types.NamespacedName]bool) error {
+	if previous == nil {
+		return nil
+	}
+	for _, status := range previous.ServiceAccounts {
+		key := types.NamespacedName{Namespace: status.Namespace, Name: status.Name}
+		if !status.Annotated || desired[key] {
+			continue
+		}
+		sa := &corev1.ServiceAccount{}
+		if err := r.apiReader().Get(ctx, key, sa); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := r.annotateServiceAccount(ctx, sa, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFederation requests a token of sa for the provider's audience and exchanges it for cloud
+// credentials, recording the outcome in status
+func (r *SecretsManagementConfigReconciler) checkFederation(ctx context.Context, cloudAuth *smv1alpha1.CloudAuthConfig, sa *corev1.ServiceAccount, status *smv1alpha1.CloudAuthServiceAccountStatus) {
+	if r.CredentialChecker == nil {
+		return
+	}
+	audience := cloudAuthAudience(cloudAuth)
+	if audience == "" {
+		status.Message = "set spec.cloudAuth.gcp.workloadIdentityProvider to validate the federation"
+		return
+	}
+	expiration := int64(cloudAuthTokenExpiration.Seconds())
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{Audiences: []string{audience}, ExpirationSeconds: &expiration},
+	}
+	if err := r.SubResource("token").Create(ctx, sa, tokenRequest); err != nil {
+		status.Message = fmt.Sprintf("could not request a token for the service account: %v", err)
+		return
+	}
+
+	identity, err := r.exchangeToken(ctx, cloudAuth, tokenRequest.Status.Token)
+	var rejected *cloudcredentials.RejectedError
+	switch {
+	case errors.As(err, &rejected):
+		federated := false
+		status.Federated = &federated
+		status.Message = rejected.Message
+	case err != nil:
+		status.Message = fmt.Sprintf("could not validate the federation: %v", err)
+	default:
+		federated := true
+		status.Federated = &federated
+		status.Identity = identity
+	}
+}
+
+// cloudAuthAudience returns the audience of the tokens the provider accepts, or "" when it cannot
+// be known
+func cloudAuthAudience(cloudAuth *smv1alpha1.CloudAuthConfig) string {
+	switch cloudAuth.Provider {
+	case smv1alpha1.CloudAuthProviderAWS:
+		if cloudAuth.AWS.Audience != "" {
+			return cloudAuth.AWS.Audience
+		}
+		return defaultAWSAudience
+	case smv1alpha1.CloudAuthProviderGCP:
+		if cloudAuth.GCP.WorkloadIdentityProvider == "" {
+			return ""
+		}
+		if cloudAuth.GCP.Audience != "" {
+			return cloudAuth.GCP.Audience
+		}
+		return "https:" + cloudAuth.GCP.WorkloadIdentityProvider
+	default:
+		return azureTokenAudience
+	}
+}
+
+// exchangeToken asks the provider to exchange a service account token for cloud credentials
+func (r *SecretsManagementConfigReconciler) exchangeToken(ctx context.Context, cloudAuth *smv1alpha1.CloudAuthConfig, token string) (string, error) {
+	switch cloudAuth.Provider {
+	case smv1alpha1.CloudAuthProviderAWS:
+		region := cloudAuth.AWS.Region
+		if region == "" {
+			region = defaultAWSFederationRegion
+		}
+		return r.CredentialChecker.AWSWebIdentity(ctx, region, cloudAuth.AWS.RoleARN, token)
+	case smv1alpha1.CloudAuthProviderGCP:
+		return r.CredentialChecker.GCPFederated(ctx, cloudAuth.GCP.WorkloadIdentityProvider, token, cloudAuth.GCP.ServiceAccount)
+	default:
+		return r.CredentialChecker.AzureFederated(ctx, cloudAuth.Azure.TenantID, cloudAuth.Azure.ClientID, token)
+	}
+}
+
+// setCloudAuthCondition reports missing service accounts first, then rejected tokens, then
+// federations that could not be validated
+func (r *SecretsManagementConfigReconciler) setCloudAuthCondition(config *smv1alpha1.SecretsManagementConfig, status *smv1alpha1.CloudAuthStatus) {
+	var missing, rejected, unverified []string
+	for _, sa := range status.ServiceAccounts {
+		name := sa.Namespace + "/" + sa.Name
+		switch {
+		case !sa.Annotated:
+			missing = append(missing, name)
+		case sa.Federated != nil && !*sa.Federated:
+			rejected = append(rejected, fmt.Sprintf("%s: %s", name, sa.Message))
+		case sa.Federated == nil:
+			unverified = append(unverified, name)
+		}
+	}
+	switch {
+	case len(status.ServiceAccounts) == 0:
+		r.setCondition(config, smv1alpha1.ConditionCloudAuthFederated, "False", smv1alpha1.ReasonServiceAccountNotFound,
+			"spec.cloudAuth.serviceAccounts lists no service account")
+	case len(missing) > 0:
+		r.setCondition(config, smv1alpha1.ConditionCloudAuthFederated, "False", smv1alpha1.ReasonServiceAccountNotFound,
+			"Service accounts not found: "+strings.Join(missing, ", "))
+	case len(rejected) > 0:
+		r.setCondition(config, smv1alpha1.ConditionCloudAuthFederated, "False", smv1alpha1.ReasonFederationRejected,
+			"The provider rejected the token of "+strings.Join(rejected, "; "))
+	case len(unverified) > 0:
+		r.setCondition(config, smv1alpha1.ConditionCloudAuthFederated, "Unknown", smv1alpha1.ReasonFederationUnverified,
+			fmt.Sprintf("Annotated for %s; the federation of %s could not be validated", status.Provider, strings.Join(unverified, ", ")))
+	default:
+		r.setCondition(config, smv1alpha1.ConditionCloudAuthFederated, "True", smv1alpha1.ReasonCloudAuthFederated,
+			fmt.Sprintf("%d service account(s) federate with %s workload identity", len(status.ServiceAccounts), status.Provider))
+	}
+}
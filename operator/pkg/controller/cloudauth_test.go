@@ -0,0 +1,203 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/cloudcredentials"
+)
+
+// newCloudAuthTestReconciler answers TokenRequests with a token naming the service account and
+// its audience, since the fake client does not serve the token subresource
+func newCloudAuthTestReconciler(objs ...client.Object) *SecretsManagementConfigReconciler {
+	r := newTestReconciler(objs...)
+	r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+		SubResourceCreate: func(ctx context.Context, c client.Client, subResource string, obj, sub client.Object, opts ...client.SubResourceCreateOption) error {
+			if tokenRequest, ok := sub.(*authenticationv1.TokenRequest); ok && subResource == "token" {
+				tokenRequest.Status.Token = obj.GetNamespace() + "/" + obj.GetName() + "@" + tokenRequest.Spec.Audiences[0]
+				return nil
+			}
+			return c.SubResource(subResource).Create(ctx, obj, sub, opts...)
+		},
+	})
+	return r
+}
+
+// newTestWebIdentitySTS answers AssumeRoleWithWebIdentity, rejecting tokens other than accepted
+func newTestWebIdentitySTS(t *testing.T, accepted string, calls *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(calls, 1)
+		_ = req.ParseForm()
+		if req.PostForm.Get("WebIdentityToken") != accepted {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<ErrorResponse><Error><Code>AccessDenied</Code><Message>Not authorized to perform sts:AssumeRoleWithWebIdentity</Message></Error></ErrorResponse>`))
+			return
+		}
+		_, _ = w.Write([]byte(`<AssumeRoleWithWebIdentityResponse><AssumeRoleWithWebIdentityResult><AssumedRoleUser>` +
+			`<Arn>arn:aws:sts::123456789012:assumed-role/eso/secrets-management-operator</Arn>` +
+			`</AssumedRoleUser></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestServiceAccount(namespace, name string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+}
+
+func newTestCloudAuthConfig(refs ...smv1alpha1.ServiceAccountReference) *smv1alpha1.SecretsManagementConfig {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.CloudAuth = smv1alpha1.CloudAuthConfig{
+		Provider:        smv1alpha1.CloudAuthProviderAWS,
+		ServiceAccounts: refs,
+		AWS:             &smv1alpha1.AWSCloudAuthConfig{RoleARN: "arn:aws:iam::123456789012:role/eso"},
+	}
+	return config
+}
+
+func getServiceAccount(t *testing.T, r *SecretsManagementConfigReconciler, namespace, name string) *corev1.ServiceAccount {
+	t.Helper()
+	sa := &corev1.ServiceAccount{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, sa))
+	return sa
+}
+
+func TestReconcileCloudAuth_AnnotatesAndValidates(t *testing.T) {
+	var calls int32
+	sts := newTestWebIdentitySTS(t, "external-secrets/external-secrets@sts.amazonaws.com", &calls)
+	config := newTestCloudAuthConfig(smv1alpha1.ServiceAccountReference{Namespace: "external-secrets", Name: "external-secrets"})
+	sa := newTestServiceAccount("external-secrets", "external-secrets")
+	sa.Annotations = map[string]string{gcpServiceAccountAnnotation: "stale@project.iam.gserviceaccount.com", "keep": "me"}
+	r := newCloudAuthTestReconciler(sa)
+	r.CredentialChecker = &cloudcredentials.Checker{STSEndpoint: sts.URL}
+
+	require.NoError(t, r.reconcileCloudAuth(context.Background(), config))
+
+	annotated := getServiceAccount(t, r, "external-secrets", "external-secrets")
+	assert.Equal(t, map[string]string{awsRoleARNAnnotation: "arn:aws:iam::123456789012:role/eso", "keep": "me"}, annotated.Annotations,
+		"other providers' annotations are removed, unrelated ones kept")
+	require.NotNil(t, config.Status.CloudAuth)
+	require.Len(t, config.Status.CloudAuth.ServiceAccounts, 1)
+	status := config.Status.CloudAuth.ServiceAccounts[0]
+	assert.True(t, status.Annotated)
+	require.NotNil(t, status.Federated)
+	assert.True(t, *status.Federated)
+	assert.Equal(t, "arn:aws:sts::123456789012:assumed-role/eso/secrets-management-operator", status.Identity)
+	cond := findCondition(config, smv1alpha1.ConditionCloudAuthFederated)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonCloudAuthFederated, cond.Reason)
+
+	// An unchanged spec is not validated again within the interval
+	require.NoError(t, r.reconcileCloudAuth(context.Background(), config))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestReconcileCloudAuth_FederationRejected(t *testing.T) {
+	var calls int32
+	sts := newTestWebIdentitySTS(t, "someone-else", &calls)
+	config := newTestCloudAuthConfig(smv1alpha1.ServiceAccountReference{Namespace: "external-secrets", Name: "external-secrets"})
+	r := newCloudAuthTestReconciler(newTestServiceAccount("external-secrets", "external-secrets"))
+	r.CredentialChecker = &cloudcredentials.Checker{STSEndpoint: sts.URL}
+
+	require.NoError(t, r.reconcileCloudAuth(context.Background(), config))
+
+	status := config.Status.CloudAuth.ServiceAccounts[0]
+	assert.True(t, status.Annotated)
+	require.NotNil(t, status.Federated)
+	assert.False(t, *status.Federated)
+	cond := findCondition(config, smv1alpha1.ConditionCloudAuthFederated)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonFederationRejected, cond.Reason)
+	assert.Contains(t, cond.Message, "external-secrets/external-secrets: AccessDenied")
+}
+
+func TestReconcileCloudAuth_MissingServiceAccount(t *testing.T) {
+	config := newTestCloudAuthConfig(smv1alpha1.ServiceAccountReference{Namespace: "csi", Name: "vault-csi-provider"})
+	r := newCloudAuthTestReconciler()
+
+	require.NoError(t, r.reconcileCloudAuth(context.Background(), config))
+
+	assert.False(t, config.Status.CloudAuth.ServiceAccounts[0].Annotated)
+	cond := findCondition(config, smv1alpha1.ConditionCloudAuthFederated)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonServiceAccountNotFound, cond.Reason)
+	assert.Equal(t, "Service accounts not found: csi/vault-csi-provider", cond.Message)
+}
+
+func TestReconcileCloudAuth_GCPWithoutProviderIsUnverified(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.CloudAuth = smv1alpha1.CloudAuthConfig{
+		Provider:        smv1alpha1.CloudAuthProviderGCP,
+		ServiceAccounts: []smv1alpha1.ServiceAccountReference{{Namespace: "external-secrets", Name: "external-secrets"}},
+		GCP:             &smv1alpha1.GCPCloudAuthConfig{ServiceAccount: "eso@project.iam.gserviceaccount.com"},
+	}
+	r := newCloudAuthTestReconciler(newTestServiceAccount("external-secrets", "external-secrets"))
+	r.CredentialChecker = &cloudcredentials.Checker{}
+
+	require.NoError(t, r.reconcileCloudAuth(context.Background(), config))
+
+	sa := getServiceAccount(t, r, "external-secrets", "external-secrets")
+	assert.Equal(t, "eso@project.iam.gserviceaccount.com", sa.Annotations[gcpServiceAccountAnnotation])
+	status := config.Status.CloudAuth.ServiceAccounts[0]
+	assert.Nil(t, status.Federated)
+	assert.Contains(t, status.Message, "workloadIdentityProvider")
+	cond := findCondition(config, smv1alpha1.ConditionCloudAuthFederated)
+	require.NotNil(t, cond)
+	assert.Equal(t, "Unknown", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonFederationUnverified, cond.Reason)
+}
+
+func TestReconcileCloudAuth_RemovesAnnotations(t *testing.T) {
+	config := newTestCloudAuthConfig(
+		smv1alpha1.ServiceAccountReference{Namespace: "external-secrets", Name: "external-secrets"},
+		smv1alpha1.ServiceAccountReference{Namespace: "csi", Name: "provider-aws"},
+	)
+	r := newCloudAuthTestReconciler(
+		newTestServiceAccount("external-secrets", "external-secrets"),
+		newTestServiceAccount("csi", "provider-aws"),
+	)
+	require.NoError(t, r.reconcileCloudAuth(context.Background(), config))
+	assert.Contains(t, getServiceAccount(t, r, "csi", "provider-aws").Annotations, awsRoleARNAnnotation)
+
+	// Dropped from the list
+	config.Spec.CloudAuth.ServiceAccounts = config.Spec.CloudAuth.ServiceAccounts[:1]
+	config.Generation++
+	require.NoError(t, r.reconcileCloudAuth(context.Background(), config))
+	assert.NotContains(t, getServiceAccount(t, r, "csi", "provider-aws").Annotations, awsRoleARNAnnotation)
+	assert.Contains(t, getServiceAccount(t, r, "external-secrets", "external-secrets").Annotations, awsRoleARNAnnotation)
+
+	// Disabled
+	config.Spec.CloudAuth.Provider = ""
+	require.NoError(t, r.reconcileCloudAuth(context.Background(), config))
+	assert.NotContains(t, getServiceAccount(t, r, "external-secrets", "external-secrets").Annotations, awsRoleARNAnnotation)
+	assert.Nil(t, config.Status.CloudAuth)
+	assert.Nil(t, findCondition(config, smv1alpha1.ConditionCloudAuthFederated))
+}
+
+func TestReconcileCloudAuth_Restricted(t *testing.T) {
+	config := newTestCloudAuthConfig(smv1alpha1.ServiceAccountReference{Namespace: "external-secrets", Name: "external-secrets"})
+	r := newCloudAuthTestReconciler(newTestServiceAccount("external-secrets", "external-secrets"))
+	r.Restricted = true
+
+	require.NoError(t, r.reconcileCloudAuth(context.Background(), config))
+
+	assert.Empty(t, getServiceAccount(t, r, "external-secrets", "external-secrets").Annotations)
+	cond := findCondition(config, smv1alpha1.ConditionCloudAuthFederated)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonCloudAuthUnavailable, cond.Reason)
+}
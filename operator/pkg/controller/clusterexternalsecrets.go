@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// MaxDegradedClusterExternalSecrets bounds the ClusterExternalSecrets listed in status
+	MaxDegradedClusterExternalSecrets = 50
+
+	// maxLaggingNamespaces is the number of lagging namespaces named per ClusterExternalSecret
+	maxLaggingNamespaces = 10
+)
+
+// ClusterExternalSecret GroupVersionKind for the External Secrets Operator
+var clusterExternalSecretGVK = schema.GroupVersionKind{
+	Group:   "external-secrets.io",
+	Version: "v1beta1",
+	Kind:    "ClusterExternalSecret",
+}
+
+// reconcileClusterExternalSecrets summarizes the namespaces each ClusterExternalSecret fans out
+// to, since its own status only lists the namespace names and says nothing of whether the
+// ExternalSecrets it created sync. A namespace lags when the ClusterExternalSecret failed to
+// provision it or its ExternalSecret there reports Ready False. The lagging count of each
+// ClusterExternalSecret is exported as a metric. They target workload namespaces, so they are not
+// reported in restricted mode.
+func (r *SecretsManagementConfigReconciler) reconcileClusterExternalSecrets(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	clusterExternalSecretLaggingNamespaces.Reset()
+	if r.Restricted || !config.Status.DetectedOperators.ExternalSecrets.Installed {
+		config.Status.ClusterExternalSecrets = smv1alpha1.ClusterExternalSecretsStatus{}
+		r.removeCondition(config, smv1alpha1.ConditionClusterExternalSecretsProvisioned)
+		return nil
+	}
+
+	// The Ready condition of the ExternalSecrets created by ClusterExternalSecrets, by namespace/name
+	failing := map[string]string{}
+	err := r.forEachResource(ctx, externalSecretGVK, func(es *unstructured.Unstructured) {
+		if !ownedByKind(es, clusterExternalSecretGVK.Kind) {
+			return
+		}
+		if state, message := readyCondition(es); state == string(corev1.ConditionFalse) {
+			failing[es.GetNamespace()+"/"+es.GetName()] = valueOr(message, "ExternalSecret is not ready")
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	status := smv1alpha1.ClusterExternalSecretsStatus{}
+	err = r.forEachResource(ctx, clusterExternalSecretGVK, func(ces *unstructured.Unstructured) {
+		summary := summarizeClusterExternalSecret(ces, failing)
+		status.Total++
+		status.Provisioned += summary.Provisioned
+		status.Lagging += summary.Lagging
+		clusterExternalSecretLaggingNamespaces.WithLabelValues(summary.Name).Set(float64(summary.Lagging))
+		if summary.Lagging > 0 {
+			status.Degraded = append(status.Degraded, summary)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	status.Namespaces = status.Provisioned + status.Lagging
+	sort.Slice(status.Degraded, func(i, j int) bool {
+		if status.Degraded[i].Lagging != status.Degraded[j].Lagging {
+			return status.Degraded[i].Lagging > status.Degraded[j].Lagging
+		}
+		return status.Degraded[i].Name < status.Degraded[j].Name
+	})
+	if len(status.Degraded) > MaxDegradedClusterExternalSecrets {
+		status.Degraded = status.Degraded[:MaxDegradedClusterExternalSecrets]
+	}
+	config.Status.ClusterExternalSecrets = status
+
+	if status.Lagging > 0 {
+		worst := status.Degraded[0]
+		lagging := worst.LaggingNamespaces[0]
+		r.setCondition(config, smv1alpha1.ConditionClusterExternalSecretsProvisioned, "False", smv1alpha1.ReasonNamespacesLagging,
+			fmt.Sprintf("%d of %d namespace(s) targeted by ClusterExternalSecrets are lagging, including %d of ClusterExternalSecret %s (%s: %s)",
+				status.Lagging, status.Namespaces, worst.Lagging, worst.Name, lagging.Namespace, lagging.Reason))
+		return nil
+	}
+	r.setCondition(config, smv1alpha1.ConditionClusterExternalSecretsProvisioned, "True", smv1alpha1.ReasonClusterExternalSecretsProvisioned,
+		fmt.Sprintf("%d ClusterExternalSecret(s) have provisioned their %d namespace(s)", status.Total, status.Namespaces))
+	return nil
+}
+
+// summarizeClusterExternalSecret counts the provisioned and lagging namespaces of ces, given the
+// Ready False messages of the ExternalSecrets created by ClusterExternalSecrets
+func summarizeClusterExternalSecret(ces *unstructured.Unstructured, failing map[string]string) smv1alpha1.ClusterExternalSecretSummary {
+	summary := smv1alpha1.ClusterExternalSecretSummary{Name: ces.GetName()}
+	// The ExternalSecrets are named after the ClusterExternalSecret unless spec.externalSecretName is set
+	esName, _, _ := unstructured.NestedString(ces.Object, "spec", "externalSecretName")
+	esName = valueOr(esName, ces.GetName())
+
+	var lagging []smv1alpha1.LaggingNamespace
+	failed, _, _ := unstructured.NestedSlice(ces.Object, "status", "failedNamespaces")
+	for _, f := range failed {
+		failure, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		namespace, _ := failure["namespace"].(string)
+		reason, _ := failure["reason"].(string)
+		lagging = append(lagging, smv1alpha1.LaggingNamespace{Namespace: namespace, Reason: valueOr(reason, "provisioning failed")})
+	}
+	provisioned, _, _ := unstructured.NestedStringSlice(ces.Object, "status", "provisionedNamespaces")
+	for _, namespace := range provisioned {
+		if message, ok := failing[namespace+"/"+esName]; ok {
+			lagging = append(lagging, smv1alpha1.LaggingNamespace{Namespace: namespace, Reason: message})
+			continue
+		}
+		summary.Provisioned++
+	}
+
+	sort.Slice(lagging, func(i, j int) bool { return lagging[i].Namespace < lagging[j].Namespace })
+	summary.Lagging = int32(len(lagging))
+	if len(lagging) > maxLaggingNamespaces {
+		lagging = lagging[:maxLaggingNamespaces]
+	}
+	summary.LaggingNamespaces = lagging
+	return summary
+}
+
+// ownedByKind reports whether obj has an owner reference of the given kind
+func ownedByKind(obj *unstructured.Unstructured, kind string) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestClusterExternalSecret(name string, provisioned []interface{}, failed ...map[string]interface{}) *unstructured.Unstructured {
+	ces := &unstructured.Unstructured{}
+	ces.SetGroupVersionKind(clusterExternalSecretGVK)
+	ces.SetName(name)
+	_ = unstructured.SetNestedSlice(ces.Object, provisioned, "status", "provisionedNamespaces")
+	if len(failed) > 0 {
+		failures := make([]interface{}, len(failed))
+		for i := range failed {
+			failures[i] = failed[i]
+		}
+		_ = unstructured.SetNestedSlice(ces.Object, failures, "status", "failedNamespaces")
+	}
+	return ces
+}
+
+// newTestFanoutExternalSecret returns an ExternalSecret created by the named ClusterExternalSecret
+func newTestFanoutExternalSecret(namespace, name, owner string, ready map[string]interface{}) *unstructured.Unstructured {
+	es := &unstructured.Unstructured{}
+	es.SetGroupVersionKind(externalSecretGVK)
+	es.SetNamespace(namespace)
+	es.SetName(name)
+	if owner != "" {
+		es.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: clusterExternalSecretGVK.GroupVersion().String(), Kind: clusterExternalSecretGVK.Kind, Name: owner, UID: "uid",
+		}})
+	}
+	ready["type"] = "Ready"
+	_ = unstructured.SetNestedSlice(es.Object, []interface{}{ready}, "status", "conditions")
+	return es
+}
+
+func TestReconcileClusterExternalSecrets(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	notReady := map[string]interface{}{"status": "False", "reason": "SecretSyncedError", "message": "could not get secret data from provider"}
+	registry := newTestClusterExternalSecret("registry-pull", []interface{}{"app", "billing", "web"},
+		map[string]interface{}{"namespace": "legacy", "reason": "namespace is terminating"})
+	_ = unstructured.SetNestedField(registry.Object, "pull-secret", "spec", "externalSecretName")
+	r := newTestReconciler(
+		registry,
+		newTestClusterExternalSecret("tls-ca", []interface{}{"app", "web"}),
+		newTestFanoutExternalSecret("app", "pull-secret", "registry-pull", map[string]interface{}{"status": "True"}),
+		newTestFanoutExternalSecret("billing", "pull-secret", "registry-pull", notReady),
+		newTestFanoutExternalSecret("web", "tls-ca", "tls-ca", map[string]interface{}{"status": "True"}),
+		// Not created by a ClusterExternalSecret, so it does not count against tls-ca
+		newTestFanoutExternalSecret("app", "tls-ca", "", notReady),
+	)
+
+	require.NoError(t, r.reconcileClusterExternalSecrets(ctx, config))
+
+	status := config.Status.ClusterExternalSecrets
+	assert.Equal(t, int32(2), status.Total)
+	assert.Equal(t, int32(6), status.Namespaces)
+	assert.Equal(t, int32(4), status.Provisioned)
+	assert.Equal(t, int32(2), status.Lagging)
+	require.Len(t, status.Degraded, 1)
+	assert.Equal(t, smv1alpha1.ClusterExternalSecretSummary{
+		Name:        "registry-pull",
+		Provisioned: 2,
+		Lagging:     2,
+		LaggingNamespaces: []smv1alpha1.LaggingNamespace{
+			{Namespace: "billing", Reason: "could not get secret data from provider"},
+			{Namespace: "legacy", Reason: "namespace is terminating"},
+		},
+	}, status.Degraded[0])
+	assert.Equal(t, float64(2), testutil.ToFloat64(clusterExternalSecretLaggingNamespaces.WithLabelValues("registry-pull")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(clusterExternalSecretLaggingNamespaces.WithLabelValues("tls-ca")))
+	cond := findCondition(config, smv1alpha1.ConditionClusterExternalSecretsProvisioned)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonNamespacesLagging, cond.Reason)
+	assert.Contains(t, cond.Message, "2 of 6 namespace(s)")
+	assert.Contains(t, cond.Message, "registry-pull (billing: could not get secret data from provider)")
+
+	// Once the namespaces catch up, the summary and the metric clear
+	require.NoError(t, r.Delete(ctx, registry))
+	require.NoError(t, r.reconcileClusterExternalSecrets(ctx, config))
+	assert.Equal(t, smv1alpha1.ClusterExternalSecretsStatus{Total: 1, Namespaces: 2, Provisioned: 2}, config.Status.ClusterExternalSecrets)
+	assert.Equal(t, 1, testutil.CollectAndCount(clusterExternalSecretLaggingNamespaces))
+	cond = findCondition(config, smv1alpha1.ConditionClusterExternalSecretsProvisioned)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+}
+
+func TestReconcileClusterExternalSecrets_CapsLaggingNamespaces(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	var failed []map[string]interface{}
+	for i := 0; i < maxLaggingNamespaces+5; i++ {
+		failed = append(failed, map[string]interface{}{"namespace": fmt.Sprintf("team-%02d", i), "reason": "forbidden"})
+	}
+	r := newTestReconciler(newTestClusterExternalSecret("shared", nil, failed...))
+
+	require.NoError(t, r.reconcileClusterExternalSecrets(context.Background(), config))
+
+	degraded := config.Status.ClusterExternalSecrets.Degraded
+	require.Len(t, degraded, 1)
+	assert.Equal(t, int32(maxLaggingNamespaces+5), degraded[0].Lagging)
+	assert.Len(t, degraded[0].LaggingNamespaces, maxLaggingNamespaces)
+}
+
+func TestReconcileClusterExternalSecrets_ExternalSecretsNotInstalled(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.ClusterExternalSecrets = smv1alpha1.ClusterExternalSecretsStatus{Total: 1, Lagging: 1}
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcileClusterExternalSecrets(context.Background(), config))
+	assert.Empty(t, config.Status.ClusterExternalSecrets)
+	assert.Nil(t, findCondition(config, smv1alpha1.ConditionClusterExternalSecretsProvisioned))
+}
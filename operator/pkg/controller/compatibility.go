@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// releaseVersionLabel is set on their CRDs by the operators that publish their release there
+const releaseVersionLabel = "app.kubernetes.io/version"
+
+// operatorRequirement is what the console needs from a supported operator
+type operatorRequirement struct {
+	// name is how the operator is called in messages
+	name string
+
+	// apiVersion is the version of the operator's API the console and the operator read
+	apiVersion string
+
+	// minRelease is the oldest supported release, checked when the CRD carries releaseVersionLabel
+	minRelease string
+}
+
+// operatorCompatibility maps the keys of operatorCRDs to the requirements of their operators.
+// Older releases miss fields the plugin forms and the status reporting rely on.
+var operatorCompatibility = map[string]operatorRequirement{
+	"certManager":     {name: "cert-manager", apiVersion: certificateGVK.Version, minRelease: "v1.12.0"},
+	"externalSecrets": {name: "external-secrets", apiVersion: externalSecretGVK.Version, minRelease: "v0.9.0"},
+	"secretsStoreCSI": {name: "secrets-store-csi", apiVersion: secretProviderClassGVK.Version, minRelease: "v1.3.0"},
+}
+
+// checkCompatibility returns the support level of the operator owning crd and, when it is
+// unsupported, why
+func checkCompatibility(requirement operatorRequirement, crd *apiextensionsv1.CustomResourceDefinition) (smv1alpha1.SupportLevel, string) {
+	served := false
+	for _, v := range crd.Spec.Versions {
+		if v.Name == requirement.apiVersion && v.Served {
+			served = true
+		}
+	}
+	if !served {
+		return smv1alpha1.SupportLevelUnsupported, fmt.Sprintf("%s does not serve %s/%s, which the console uses",
+			requirement.name, crd.Spec.Group, requirement.apiVersion)
+	}
+
+	release, err := utilversion.ParseGeneric(crd.Labels[releaseVersionLabel])
+	if err != nil {
+		return smv1alpha1.SupportLevelUnknown, ""
+	}
+	if release.LessThan(utilversion.MustParseGeneric(requirement.minRelease)) {
+		return smv1alpha1.SupportLevelUnsupported, fmt.Sprintf("%s %s is older than %s, the oldest supported release",
+			requirement.name, crd.Labels[releaseVersionLabel], requirement.minRelease)
+	}
+	return smv1alpha1.SupportLevelSupported, ""
+}
+
+// setCompatibilityCondition reports the config Degraded while an installed operator is unsupported,
+// so the mismatch is explained up front instead of surfacing as console pages that half work
+func (r *SecretsManagementConfigReconciler) setCompatibilityCondition(config *smv1alpha1.SecretsManagementConfig, unsupported []string) {
+	if len(unsupported) == 0 {
+		r.setCondition(config, smv1alpha1.ConditionDegraded, "False", smv1alpha1.ReasonSupportedOperatorVersions,
+			"Every detected operator is supported")
+		return
+	}
+	sort.Strings(unsupported)
+	r.setCondition(config, smv1alpha1.ConditionDegraded, "True", smv1alpha1.ReasonUnsupportedOperatorVersion,
+		"Unsupported operators: "+strings.Join(unsupported, "; "))
+}
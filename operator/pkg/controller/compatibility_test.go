@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestOperatorCRD(name, group, release string, versions ...string) *apiextensionsv1.CustomResourceDefinition {
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       apiextensionsv1.CustomResourceDefinitionSpec{Group: group},
+	}
+	if release != "" {
+		crd.Labels = map[string]string{releaseVersionLabel: release}
+	}
+	for _, v := range versions {
+		crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{Name: v, Served: true})
+	}
+	return crd
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	requirement := operatorCompatibility["certManager"]
+	tests := []struct {
+		name    string
+		crd     *apiextensionsv1.CustomResourceDefinition
+		level   smv1alpha1.SupportLevel
+		problem string
+	}{
+		{"supported release", newTestOperatorCRD("certificates.cert-manager.io", "cert-manager.io", "v1.14.4", "v1"), smv1alpha1.SupportLevelSupported, ""},
+		{"minimum release", newTestOperatorCRD("certificates.cert-manager.io", "cert-manager.io", "v1.12.0", "v1"), smv1alpha1.SupportLevelSupported, ""},
+		{"old release", newTestOperatorCRD("certificates.cert-manager.io", "cert-manager.io", "v1.11.2", "v1"), smv1alpha1.SupportLevelUnsupported,
+			"cert-manager v1.11.2 is older than v1.12.0, the oldest supported release"},
+		{"unlabelled", newTestOperatorCRD("certificates.cert-manager.io", "cert-manager.io", "", "v1"), smv1alpha1.SupportLevelUnknown, ""},
+		{"missing API version", newTestOperatorCRD("certificates.cert-manager.io", "cert-manager.io", "", "v1alpha2"), smv1alpha1.SupportLevelUnsupported,
+			"cert-manager does not serve cert-manager.io/v1, which the console uses"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, problem := checkCompatibility(requirement, tt.crd)
+			assert.Equal(t, tt.level, level)
+			assert.Equal(t, tt.problem, problem)
+		})
+	}
+}
+
+func TestDetectOperators_Unsupported(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	r := newTestReconciler(
+		newTestOperatorCRD("certificates.cert-manager.io", "cert-manager.io", "v1.10.1", "v1"),
+		newTestOperatorCRD("externalsecrets.external-secrets.io", "external-secrets.io", "", "v1beta1"),
+	)
+
+	require.NoError(t, r.detectOperators(ctx, config))
+
+	certManager := config.Status.DetectedOperators.CertManager
+	assert.Equal(t, "v1.10.1", certManager.ReleaseVersion)
+	assert.Equal(t, smv1alpha1.SupportLevelUnsupported, certManager.SupportLevel)
+	assert.Equal(t, smv1alpha1.SupportLevelUnknown, config.Status.DetectedOperators.ExternalSecrets.SupportLevel)
+	assert.Empty(t, config.Status.DetectedOperators.SecretsStoreCSI.SupportLevel)
+
+	cond := findCondition(config, smv1alpha1.ConditionDegraded)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonUnsupportedOperatorVersion, cond.Reason)
+	assert.Contains(t, cond.Message, "cert-manager v1.10.1 is older than v1.12.0")
+}
+
+func TestDetectOperators_Supported(t *testing.T) {
+	config := newTestConfig("cluster")
+	r := newTestReconciler(newTestOperatorCRD("certificates.cert-manager.io", "cert-manager.io", "v1.14.4", "v1"))
+
+	require.NoError(t, r.detectOperators(context.Background(), config))
+
+	assert.Equal(t, smv1alpha1.SupportLevelSupported, config.Status.DetectedOperators.CertManager.SupportLevel)
+	cond := findCondition(config, smv1alpha1.ConditionDegraded)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonSupportedOperatorVersions, cond.Reason)
+}
@@ -0,0 +1,512 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// ComplianceReportName is the name of the SecretsComplianceReport the operator writes
+	ComplianceReportName = "cluster"
+
+	// DefaultComplianceInterval is how often the controls are evaluated unless
+	// spec.compliance.interval is set
+	DefaultComplianceInterval = time.Hour
+
+	// maxComplianceFindings is the number of findings kept per control
+	maxComplianceFindings = 20
+)
+
+// Compliance control IDs
+const (
+	ControlSecretsEncryptedAtRest  = "secrets-encrypted-at-rest"
+	ControlNoLongLivedTokens       = "no-long-lived-service-account-tokens"
+	ControlTLSSecretsNotExpired    = "tls-secrets-not-expired"
+	ControlNoBroadSecretReadAccess = "no-broad-secret-read-access"
+)
+
+// complianceRestrictedMessage is reported by controls that need to list Secrets cluster-wide
+const complianceRestrictedMessage = "Secrets cannot be listed cluster-wide in restricted mode; check this control manually"
+
+// broadSubjects are the groups and users that stand for everyone, every authenticated user or
+// every service account
+var broadSubjects = map[string]bool{
+	rbacv1.GroupKind + "/system:authenticated":   true,
+	rbacv1.GroupKind + "/system:unauthenticated": true,
+	rbacv1.GroupKind + "/system:serviceaccounts": true,
+	rbacv1.UserKind + "/system:anonymous":        true,
+}
+
+// complianceSeverityWeights are how much each severity weighs in the report score
+var complianceSeverityWeights = map[smv1alpha1.ComplianceSeverity]int32{
+	smv1alpha1.ComplianceSeverityHigh:   3,
+	smv1alpha1.ComplianceSeverityMedium: 2,
+	smv1alpha1.ComplianceSeverityLow:    1,
+}
+
+// ComplianceReconciler evaluates secret-related controls and writes the results to the
+// SecretsComplianceReport named cluster while spec.compliance.enabled is set on the primary config
+type ComplianceReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// APIReader lists Secrets and bindings across the cluster, which are not cached
+	APIReader client.Reader
+
+	// Restricted skips the controls that list Secrets cluster-wide and only checks
+	// ClusterRoleBindings for broad read access
+	Restricted bool
+}
+
+// +kubebuilder:rbac:groups=secrets-management.openshift.io,resources=secretscompliancereports,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets-management.openshift.io,resources=secretscompliancereports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get
+
+// Reconcile evaluates the controls for the primary config and writes the report
+func (r *ComplianceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if req.Name != SingletonConfigName {
+		return ctrl.Result{}, nil
+	}
+
+	// The report is owned by the config and garbage collected with it
+	config := &smv1alpha1.SecretsManagementConfig{}
+	if err := r.Get(ctx, req.NamespacedName, config); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !config.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if !config.Spec.Compliance.Enabled {
+		if err := r.cleanupScanJob(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.deletePolicyReport(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+		report := &smv1alpha1.SecretsComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: ComplianceReportName}}
+		return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, report))
+	}
+
+	if !config.Spec.Compliance.PolicyReport {
+		if err := r.deletePolicyReport(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// A scheduled scan runs in its own pod and writes the report itself
+	if config.Spec.Scan.Schedule != "" {
+		return ctrl.Result{}, r.reconcileScanJob(ctx, config)
+	}
+	if err := r.cleanupScanJob(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.scan(ctx, config); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: complianceInterval(config)}, nil
+}
+
+// evaluate runs every control. A control whose objects cannot be read reports ERROR rather than
+// failing the whole report.
+func (r *ComplianceReconciler) evaluate(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) ([]smv1alpha1.ComplianceControlStatus, error) {
+	controls := []smv1alpha1.ComplianceControlStatus{encryptionAtRestControl(config)}
+	for _, check := range []func(context.Context) (smv1alpha1.ComplianceControlStatus, error){
+		r.longLivedTokensControl,
+		r.tlsSecretsControl,
+		r.broadSecretReadControl,
+	} {
+		control, err := check(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			control.Result = smv1alpha1.ComplianceResultError
+			control.Message = err.Error()
+		}
+		controls = append(controls, control)
+	}
+	return controls, nil
+}
+
+// encryptionAtRestControl checks the etcd encryption the SecretsManagementConfig controller
+// reports in status.securityPosture
+func encryptionAtRestControl(config *smv1alpha1.SecretsManagementConfig) smv1alpha1.ComplianceControlStatus {
+	control := smv1alpha1.ComplianceControlStatus{
+		ID:         ControlSecretsEncryptedAtRest,
+		Title:      "Secrets are encrypted at rest in etcd",
+		Severity:   smv1alpha1.ComplianceSeverityHigh,
+		References: []string{"CIS Kubernetes 1.2.27", "NIST SP 800-53 SC-28"},
+	}
+	posture := config.Status.SecurityPosture
+	switch {
+	case posture.EtcdEncryption == "":
+		control.Result = smv1alpha1.ComplianceResultNotApplicable
+		control.Message = "The cluster does not report an etcd encryption type"
+	case posture.EtcdEncryption == EtcdEncryptionIdentity:
+		control.Result = smv1alpha1.ComplianceResultFail
+		control.Message = "Secrets are stored unencrypted in etcd"
+	case posture.EtcdEncryptionProgress != "" && posture.EtcdEncryptionProgress != "EncryptionCompleted":
+		control.Result = smv1alpha1.ComplianceResultFail
+		control.Message = fmt.Sprintf("etcd encryption is set to %s but existing resources are not yet encrypted (%s)", posture.EtcdEncryption, posture.EtcdEncryptionProgress)
+	default:
+		control.Result = smv1alpha1.ComplianceResultPass
+		control.Message = fmt.Sprintf("Secrets are encrypted at rest with %s", posture.EtcdEncryption)
+	}
+	return control
+}
+
+// longLivedTokensControl looks for service account token Secrets outside platform namespaces.
+// Such tokens never expire; workloads should use projected, time-bound tokens instead.
+func (r *ComplianceReconciler) longLivedTokensControl(ctx context.Context) (smv1alpha1.ComplianceControlStatus, error) {
+	control := smv1alpha1.ComplianceControlStatus{
+		ID:         ControlNoLongLivedTokens,
+		Title:      "No long-lived service account token Secrets",
+		Severity:   smv1alpha1.ComplianceSeverityMedium,
+		References: []string{"CIS Kubernetes 5.1.6", "NIST SP 800-53 IA-5"},
+	}
+	if r.Restricted {
+		control.Result = smv1alpha1.ComplianceResultManual
+		control.Message = complianceRestrictedMessage
+		return control, nil
+	}
+
+	var findings []string
+	err := r.listSecrets(ctx, corev1.SecretTypeServiceAccountToken, func(secret *corev1.Secret) {
+		if !isPlatformNamespace(secret.Namespace) {
+			findings = append(findings, secret.Namespace+"/"+secret.Name)
+		}
+	})
+	if err != nil {
+		return control, err
+	}
+	if len(findings) == 0 {
+		control.Result = smv1alpha1.ComplianceResultPass
+		control.Message = "No service account token Secrets exist outside platform namespaces"
+		return control, nil
+	}
+	control.Result = smv1alpha1.ComplianceResultFail
+	control.Message = fmt.Sprintf("%d service account token Secret(s) hold tokens that never expire", len(findings))
+	control.Findings = limitFindings(findings)
+	return control, nil
+}
+
+// tlsSecretsControl looks for TLS Secrets whose certificate has expired. Secrets whose tls.crt
+// cannot be parsed are not judged.
+func (r *ComplianceReconciler) tlsSecretsControl(ctx context.Context) (smv1alpha1.ComplianceControlStatus, error) {
+	control := smv1alpha1.ComplianceControlStatus{
+		ID:         ControlTLSSecretsNotExpired,
+		Title:      "TLS Secrets hold unexpired certificates",
+		Severity:   smv1alpha1.ComplianceSeverityMedium,
+		References: []string{"NIST SP 800-53 SC-12", "NIST SP 800-53 SC-17"},
+	}
+	if r.Restricted {
+		control.Result = smv1alpha1.ComplianceResultManual
+		control.Message = complianceRestrictedMessage
+		return control, nil
+	}
+
+	now := time.Now()
+	var findings []string
+	err := r.listSecrets(ctx, corev1.SecretTypeTLS, func(secret *corev1.Secret) {
+		notAfter, ok := certificateNotAfter(secret.Data[corev1.TLSCertKey])
+		if ok && notAfter.Before(now) {
+			findings = append(findings, fmt.Sprintf("%s/%s expired %s", secret.Namespace, secret.Name, notAfter.UTC().Format(time.RFC3339)))
+		}
+	})
+	if err != nil {
+		return control, err
+	}
+	if len(findings) == 0 {
+		control.Result = smv1alpha1.ComplianceResultPass
+		control.Message = "No TLS Secret holds an expired certificate"
+		return control, nil
+	}
+	control.Result = smv1alpha1.ComplianceResultFail
+	control.Message = fmt.Sprintf("%d TLS Secret(s) hold an expired certificate", len(findings))
+	control.Findings = limitFindings(findings)
+	return control, nil
+}
+
+// broadSecretReadControl looks for bindings that let every user, every authenticated user or
+// every service account read Secrets. RoleBindings are only checked outside restricted mode.
+func (r *ComplianceReconciler) broadSecretReadControl(ctx context.Context) (smv1alpha1.ComplianceControlStatus, error) {
+	control := smv1alpha1.ComplianceControlStatus{
+		ID:         ControlNoBroadSecretReadAccess,
+		Title:      "Secrets are not readable by all users or service accounts",
+		Severity:   smv1alpha1.ComplianceSeverityHigh,
+		References: []string{"CIS Kubernetes 5.1.2", "NIST SP 800-53 AC-6"},
+	}
+
+	var findings []string
+	clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+	if err := r.apiReader().List(ctx, clusterRoleBindings); err != nil {
+		return control, err
+	}
+	for i := range clusterRoleBindings.Items {
+		binding := &clusterRoleBindings.Items[i]
+		subject := broadSubject(binding.Subjects)
+		if subject == "" {
+			continue
+		}
+		grants, err := r.roleGrantsSecretRead(ctx, "", binding.RoleRef)
+		if err != nil {
+			return control, err
+		}
+		if grants {
+			findings = append(findings, fmt.Sprintf("ClusterRoleBinding %s grants %s to %s", binding.Name, binding.RoleRef.Name, subject))
+		}
+	}
+
+	if !r.Restricted {
+		roleBindings := &rbacv1.RoleBindingList{}
+		if err := r.apiReader().List(ctx, roleBindings); err != nil {
+			return control, err
+		}
+		for i := range roleBindings.Items {
+			binding := &roleBindings.Items[i]
+			subject := broadSubject(binding.Subjects)
+			if subject == "" {
+				continue
+			}
+			grants, err := r.roleGrantsSecretRead(ctx, binding.Namespace, binding.RoleRef)
+			if err != nil {
+				return control, err
+			}
+			if grants {
+				findings = append(findings, fmt.Sprintf("RoleBinding %s/%s grants %s to %s", binding.Namespace, binding.Name, binding.RoleRef.Name, subject))
+			}
+		}
+	}
+
+	if len(findings) == 0 {
+		control.Result = smv1alpha1.ComplianceResultPass
+		control.Message = "No binding grants read access to Secrets to all users or service accounts"
+		return control, nil
+	}
+	control.Result = smv1alpha1.ComplianceResultFail
+	control.Message = fmt.Sprintf("%d binding(s) grant read access to Secrets to all users or service accounts", len(findings))
+	control.Findings = limitFindings(findings)
+	return control, nil
+}
+
+// roleGrantsSecretRead reports whether the Role or ClusterRole a binding refers to allows
+// reading Secrets. A missing role grants nothing.
+func (r *ComplianceReconciler) roleGrantsSecretRead(ctx context.Context, namespace string, ref rbacv1.RoleRef) (bool, error) {
+	var rules []rbacv1.PolicyRule
+	switch ref.Kind {
+	case "ClusterRole":
+		role := &rbacv1.ClusterRole{}
+		if err := r.apiReader().Get(ctx, types.NamespacedName{Name: ref.Name}, role); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		rules = role.Rules
+	case "Role":
+		role := &rbacv1.Role{}
+		if err := r.apiReader().Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, role); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		rules = role.Rules
+	}
+	return rulesGrantSecretRead(rules), nil
+}
+
+// rulesGrantSecretRead reports whether any rule allows get, list or watch on core Secrets
+func rulesGrantSecretRead(rules []rbacv1.PolicyRule) bool {
+	for _, rule := range rules {
+		if containsAny(rule.APIGroups, "", rbacv1.APIGroupAll) &&
+			containsAny(rule.Resources, "secrets", rbacv1.ResourceAll) &&
+			containsAny(rule.Verbs, "get", "list", "watch", rbacv1.VerbAll) {
+			return true
+		}
+	}
+	return false
+}
+
+// broadSubject returns the first subject standing for all users or service accounts, or ""
+func broadSubject(subjects []rbacv1.Subject) string {
+	for _, s := range subjects {
+		if broadSubjects[s.Kind+"/"+s.Name] {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+func containsAny(values []string, wanted ...string) bool {
+	for _, v := range values {
+		for _, w := range wanted {
+			if v == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// listSecrets calls fn for every Secret of the given type in the cluster, a page at a time.
+// Secrets are cached as metadata only, so they are read from the API server.
+func (r *ComplianceReconciler) listSecrets(ctx context.Context, secretType corev1.SecretType, fn func(*corev1.Secret)) error {
+	secrets := &corev1.SecretList{}
+	opts := []client.ListOption{client.MatchingFields{"type": string(secretType)}, client.Limit(podListPageSize)}
+	for {
+		if err := r.apiReader().List(ctx, secrets, opts...); err != nil {
+			return err
+		}
+		for i := range secrets.Items {
+			fn(&secrets.Items[i])
+		}
+		if secrets.Continue == "" {
+			return nil
+		}
+		opts = []client.ListOption{client.MatchingFields{"type": string(secretType)}, client.Limit(podListPageSize), client.Continue(secrets.Continue)}
+	}
+}
+
+// certificateNotAfter returns the expiry of the first certificate in a PEM bundle
+func certificateNotAfter(data []byte) (time.Time, bool) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return time.Time{}, false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return cert.NotAfter, true
+}
+
+// isPlatformNamespace reports whether a namespace belongs to OpenShift or Kubernetes itself
+func isPlatformNamespace(namespace string) bool {
+	return namespace == "openshift" || strings.HasPrefix(namespace, "openshift-") || strings.HasPrefix(namespace, "kube-")
+}
+
+// limitFindings sorts findings and keeps the first maxComplianceFindings
+func limitFindings(findings []string) []string {
+	sort.Strings(findings)
+	if len(findings) > maxComplianceFindings {
+		findings = findings[:maxComplianceFindings]
+	}
+	return findings
+}
+
+// complianceReportStatus scores the controls. Each passed control adds its severity weight; the
+// score is the share of the weight of all passed and failed controls.
+func complianceReportStatus(controls []smv1alpha1.ComplianceControlStatus, now time.Time) smv1alpha1.SecretsComplianceReportStatus {
+	status := smv1alpha1.SecretsComplianceReportStatus{
+		GeneratedAt: &metav1.Time{Time: now},
+		Controls:    controls,
+	}
+	var passedWeight, totalWeight int32
+	for _, c := range controls {
+		weight := complianceSeverityWeights[c.Severity]
+		switch c.Result {
+		case smv1alpha1.ComplianceResultPass:
+			status.Passed++
+			passedWeight += weight
+			totalWeight += weight
+		case smv1alpha1.ComplianceResultFail:
+			status.Failed++
+			totalWeight += weight
+		}
+	}
+	if totalWeight > 0 {
+		status.Score = passedWeight * 100 / totalWeight
+	}
+	return status
+}
+
+// writeReport creates the report owned by the config if needed and replaces its status, then
+// mirrors it to the policy report
+func (r *ComplianceReconciler) writeReport(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, status smv1alpha1.SecretsComplianceReportStatus) error {
+	report := &smv1alpha1.SecretsComplianceReport{}
+	err := r.Get(ctx, types.NamespacedName{Name: ComplianceReportName}, report)
+	if errors.IsNotFound(err) {
+		report = &smv1alpha1.SecretsComplianceReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: ComplianceReportName,
+				Labels: map[string]string{
+					"app.kubernetes.io/part-of":    "ocp-secrets-management",
+					"app.kubernetes.io/managed-by": "secrets-management-operator",
+				},
+			},
+		}
+		if err := controllerutil.SetControllerReference(config, report, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, report); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	report.Status = status
+	if err := r.Status().Update(ctx, report); err != nil {
+		return err
+	}
+	return r.writePolicyReport(ctx, config, status)
+}
+
+// complianceInterval returns spec.compliance.interval or DefaultComplianceInterval
+func complianceInterval(config *smv1alpha1.SecretsManagementConfig) time.Duration {
+	if i := config.Spec.Compliance.Interval; i != nil && i.Duration > 0 {
+		return i.Duration
+	}
+	return DefaultComplianceInterval
+}
+
+// apiReader returns the uncached reader, falling back to the cached client
+func (r *ComplianceReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// SetupWithManager sets up the controller with the Manager. Besides spec changes, the primary
+// config is reconciled when status.securityPosture changes so the encryption control is current.
+// The report itself is not watched, since every evaluation updates it; the scan CronJob is, so
+// edits to it are reverted.
+func (r *ComplianceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("compliance").
+		For(&smv1alpha1.SecretsManagementConfig{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldConfig, ok := e.ObjectOld.(*smv1alpha1.SecretsManagementConfig)
+				newConfig, ok2 := e.ObjectNew.(*smv1alpha1.SecretsManagementConfig)
+				if !ok || !ok2 {
+					return false
+				}
+				return oldConfig.Generation != newConfig.Generation ||
+					!equality.Semantic.DeepEqual(oldConfig.Status.SecurityPosture, newConfig.Status.SecurityPosture)
+			},
+		})).
+		Owns(&batchv1.CronJob{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
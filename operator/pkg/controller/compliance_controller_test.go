@@ -0,0 +1,331 @@
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestComplianceReconciler(objs ...client.Object) *ComplianceReconciler {
+	scheme := newTestScheme()
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&smv1alpha1.SecretsComplianceReport{}).
+		WithIndex(&corev1.Secret{}, "type", func(obj client.Object) []string {
+			return []string{string(obj.(*corev1.Secret).Type)}
+		}).
+		Build()
+
+	return &ComplianceReconciler{
+		Client: fakeClient,
+		Log:    logr.Discard(),
+		Scheme: scheme,
+	}
+}
+
+func newTestComplianceConfig() *smv1alpha1.SecretsManagementConfig {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Compliance.Enabled = true
+	config.Status.SecurityPosture = smv1alpha1.SecurityPostureStatus{
+		EtcdEncryption:         "aesgcm",
+		EtcdEncryptionProgress: "EncryptionCompleted",
+	}
+	return config
+}
+
+// newTestTLSSecret returns a TLS Secret holding a self-signed certificate valid until notAfter
+func newTestTLSSecret(t *testing.T, namespace, name string, notAfter time.Time) *corev1.Secret {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		},
+	}
+}
+
+func reconcileCompliance(t *testing.T, r *ComplianceReconciler) ctrl.Result {
+	t.Helper()
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: SingletonConfigName}})
+	require.NoError(t, err)
+	return result
+}
+
+func getComplianceReport(t *testing.T, r *ComplianceReconciler) (*smv1alpha1.SecretsComplianceReport, error) {
+	t.Helper()
+	report := &smv1alpha1.SecretsComplianceReport{}
+	err := r.Get(context.Background(), types.NamespacedName{Name: ComplianceReportName}, report)
+	return report, err
+}
+
+func findControl(report *smv1alpha1.SecretsComplianceReport, id string) *smv1alpha1.ComplianceControlStatus {
+	for i := range report.Status.Controls {
+		if report.Status.Controls[i].ID == id {
+			return &report.Status.Controls[i]
+		}
+	}
+	return nil
+}
+
+func TestComplianceReconciler_CompliantCluster(t *testing.T) {
+	r := newTestComplianceReconciler(
+		newTestComplianceConfig(),
+		newTestTLSSecret(t, "app", "serving-cert", time.Now().Add(30*24*time.Hour)),
+	)
+
+	result := reconcileCompliance(t, r)
+	assert.Equal(t, DefaultComplianceInterval, result.RequeueAfter)
+
+	report, err := getComplianceReport(t, r)
+	require.NoError(t, err)
+	require.Len(t, report.OwnerReferences, 1)
+	assert.Equal(t, SingletonConfigName, report.OwnerReferences[0].Name)
+	require.NotNil(t, report.Status.GeneratedAt)
+	require.Len(t, report.Status.Controls, 4)
+	for _, control := range report.Status.Controls {
+		assert.Equal(t, smv1alpha1.ComplianceResultPass, control.Result, control.ID)
+		assert.NotEmpty(t, control.References, control.ID)
+	}
+	assert.Equal(t, int32(4), report.Status.Passed)
+	assert.Equal(t, int32(100), report.Status.Score)
+}
+
+func TestComplianceReconciler_ReportsViolations(t *testing.T) {
+	config := newTestComplianceConfig()
+	config.Status.SecurityPosture = smv1alpha1.SecurityPostureStatus{EtcdEncryption: EtcdEncryptionIdentity}
+	r := newTestComplianceReconciler(
+		config,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "builder-token"},
+			Type:       corev1.SecretTypeServiceAccountToken,
+		},
+		// Platform tokens are left to OpenShift
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-monitoring", Name: "prometheus-token"},
+			Type:       corev1.SecretTypeServiceAccountToken,
+		},
+		newTestTLSSecret(t, "app", "old-cert", time.Now().Add(-time.Hour)),
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "read-secrets"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"list"}},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "everyone-reads-secrets"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "read-secrets"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:authenticated"}},
+		},
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "all"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "all-service-accounts"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "all"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts"}},
+		},
+		// Bindings to individual subjects are not broad
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-reads-secrets"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "read-secrets"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		},
+	)
+
+	reconcileCompliance(t, r)
+	report, err := getComplianceReport(t, r)
+	require.NoError(t, err)
+
+	for _, id := range []string{ControlSecretsEncryptedAtRest, ControlNoLongLivedTokens, ControlTLSSecretsNotExpired, ControlNoBroadSecretReadAccess} {
+		control := findControl(report, id)
+		require.NotNil(t, control, id)
+		assert.Equal(t, smv1alpha1.ComplianceResultFail, control.Result, id)
+	}
+	assert.Equal(t, []string{"app/builder-token"}, findControl(report, ControlNoLongLivedTokens).Findings)
+	assert.Contains(t, findControl(report, ControlTLSSecretsNotExpired).Findings[0], "app/old-cert expired")
+	assert.Equal(t, []string{
+		"ClusterRoleBinding everyone-reads-secrets grants read-secrets to system:authenticated",
+		"RoleBinding team/all-service-accounts grants all to system:serviceaccounts",
+	}, findControl(report, ControlNoBroadSecretReadAccess).Findings)
+	assert.Equal(t, int32(4), report.Status.Failed)
+	assert.Equal(t, int32(0), report.Status.Score)
+}
+
+func TestComplianceReconciler_RestrictedMode(t *testing.T) {
+	config := newTestComplianceConfig()
+	config.Status.SecurityPosture = smv1alpha1.SecurityPostureStatus{EtcdEncryption: EtcdEncryptionIdentity}
+	r := newTestComplianceReconciler(config)
+	r.Restricted = true
+
+	reconcileCompliance(t, r)
+	report, err := getComplianceReport(t, r)
+	require.NoError(t, err)
+
+	assert.Equal(t, smv1alpha1.ComplianceResultManual, findControl(report, ControlNoLongLivedTokens).Result)
+	assert.Equal(t, smv1alpha1.ComplianceResultManual, findControl(report, ControlTLSSecretsNotExpired).Result)
+	// Encryption (high, failing) and broad access (high, passing) are the only scored controls
+	assert.Equal(t, int32(1), report.Status.Passed)
+	assert.Equal(t, int32(1), report.Status.Failed)
+	assert.Equal(t, int32(50), report.Status.Score)
+}
+
+func TestComplianceReconciler_DeletesReportWhenDisabled(t *testing.T) {
+	config := newTestComplianceConfig()
+	config.Spec.Compliance.Interval = &metav1.Duration{Duration: 10 * time.Minute}
+	r := newTestComplianceReconciler(config)
+
+	result := reconcileCompliance(t, r)
+	assert.Equal(t, 10*time.Minute, result.RequeueAfter)
+	_, err := getComplianceReport(t, r)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: SingletonConfigName}, config))
+	config.Spec.Compliance.Enabled = false
+	require.NoError(t, r.Update(context.Background(), config))
+
+	result = reconcileCompliance(t, r)
+	assert.Zero(t, result.RequeueAfter)
+	_, err = getComplianceReport(t, r)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestComplianceReportStatus_WeightsSeverity(t *testing.T) {
+	status := complianceReportStatus([]smv1alpha1.ComplianceControlStatus{
+		{ID: "a", Severity: smv1alpha1.ComplianceSeverityHigh, Result: smv1alpha1.ComplianceResultPass},
+		{ID: "b", Severity: smv1alpha1.ComplianceSeverityLow, Result: smv1alpha1.ComplianceResultFail},
+		{ID: "c", Severity: smv1alpha1.ComplianceSeverityMedium, Result: smv1alpha1.ComplianceResultError},
+		{ID: "d", Severity: smv1alpha1.ComplianceSeverityHigh, Result: smv1alpha1.ComplianceResultNotApplicable},
+	}, time.Now())
+
+	assert.Equal(t, int32(1), status.Passed)
+	assert.Equal(t, int32(1), status.Failed)
+	assert.Equal(t, int32(75), status.Score)
+}
+
+func TestComplianceReconciler_ScheduledScan(t *testing.T) {
+	ctx := context.Background()
+	config := newTestComplianceConfig()
+	config.Spec.Scan.Schedule = "0 */6 * * *"
+	r := newTestComplianceReconciler(config)
+
+	result := reconcileCompliance(t, r)
+	assert.Zero(t, result.RequeueAfter)
+	// The scan pod writes the report, not the operator
+	_, err := getComplianceReport(t, r)
+	assert.True(t, errors.IsNotFound(err))
+
+	cronJob := &batchv1.CronJob{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: PluginNamespace, Name: ComplianceScanName}, cronJob))
+	assert.Equal(t, "0 */6 * * *", cronJob.Spec.Schedule)
+	assert.Equal(t, batchv1.ForbidConcurrent, cronJob.Spec.ConcurrencyPolicy)
+	require.Len(t, cronJob.OwnerReferences, 1)
+	assert.Equal(t, SingletonConfigName, cronJob.OwnerReferences[0].Name)
+	pod := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	assert.Equal(t, ComplianceScanName, pod.ServiceAccountName)
+	require.Len(t, pod.Containers, 1)
+	assert.Equal(t, DefaultOperatorImage, pod.Containers[0].Image)
+	assert.Equal(t, []string{"--" + ScanFlag}, pod.Containers[0].Args)
+	assert.Empty(t, pod.Containers[0].Env)
+
+	role := &rbacv1.ClusterRole{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: ComplianceScanName}, role))
+	assert.True(t, rulesGrantSecretRead(role.Rules))
+	for _, rule := range role.Rules {
+		assert.NotContains(t, rule.Verbs, "delete", rule.Resources)
+	}
+	binding := &rbacv1.ClusterRoleBinding{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: ComplianceScanName}, binding))
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: ComplianceScanName, Namespace: PluginNamespace}}, binding.Subjects)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: PluginNamespace, Name: ComplianceScanName}, &corev1.ServiceAccount{}))
+
+	// Clearing the schedule brings the scan back into the operator
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config))
+	config.Spec.Scan.Schedule = ""
+	require.NoError(t, r.Update(ctx, config))
+
+	result = reconcileCompliance(t, r)
+	assert.Equal(t, DefaultComplianceInterval, result.RequeueAfter)
+	_, err = getComplianceReport(t, r)
+	assert.NoError(t, err)
+	for _, obj := range []client.Object{&batchv1.CronJob{}, &corev1.ServiceAccount{}} {
+		err := r.Get(ctx, types.NamespacedName{Namespace: PluginNamespace, Name: ComplianceScanName}, obj)
+		assert.True(t, errors.IsNotFound(err))
+	}
+	for _, obj := range []client.Object{&rbacv1.ClusterRole{}, &rbacv1.ClusterRoleBinding{}} {
+		err := r.Get(ctx, types.NamespacedName{Name: ComplianceScanName}, obj)
+		assert.True(t, errors.IsNotFound(err))
+	}
+}
+
+func TestComplianceReconciler_ScheduledScanRestricted(t *testing.T) {
+	config := newTestComplianceConfig()
+	config.Spec.Scan.Schedule = "@daily"
+	r := newTestComplianceReconciler(config)
+	r.Restricted = true
+
+	reconcileCompliance(t, r)
+
+	cronJob := &batchv1.CronJob{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Namespace: PluginNamespace, Name: ComplianceScanName}, cronJob))
+	assert.Equal(t, []corev1.EnvVar{{Name: WatchNamespaceEnv, Value: PluginNamespace}}, cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Env)
+	role := &rbacv1.ClusterRole{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: ComplianceScanName}, role))
+	assert.False(t, rulesGrantSecretRead(role.Rules))
+}
+
+func TestComplianceReconciler_Scan(t *testing.T) {
+	config := newTestComplianceConfig()
+	config.Spec.Compliance.Enabled = false
+	r := newTestComplianceReconciler(config)
+
+	// Nothing is written while compliance is disabled
+	require.NoError(t, r.Scan(context.Background()))
+	_, err := getComplianceReport(t, r)
+	assert.True(t, errors.IsNotFound(err))
+
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: SingletonConfigName}, config))
+	config.Spec.Compliance.Enabled = true
+	require.NoError(t, r.Update(context.Background(), config))
+
+	require.NoError(t, r.Scan(context.Background()))
+	report, err := getComplianceReport(t, r)
+	require.NoError(t, err)
+	assert.Len(t, report.Status.Controls, 4)
+}
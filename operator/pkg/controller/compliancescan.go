@@ -0,0 +1,307 @@
+package controller
+
+import (
+	"context"
+	"os"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// ComplianceScanName names the CronJob that runs the scheduled compliance scan, and the
+	// ServiceAccount, ClusterRole and ClusterRoleBinding it runs under
+	ComplianceScanName = "secrets-management-compliance-scan"
+
+	// ScanFlag makes the manager binary run the compliance scan once and exit
+	ScanFlag = "scan"
+
+	// DefaultOperatorImage is the image the scan runs from when RELATED_IMAGE_OPERATOR is unset
+	DefaultOperatorImage = "openshift.io/ocp-secrets-management-operator:latest"
+
+	// RelatedImageOperatorEnv is set on the operator Deployment to its own (possibly mirrored) image
+	RelatedImageOperatorEnv = "RELATED_IMAGE_OPERATOR"
+)
+
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=delete
+
+// Scan evaluates the controls once for the primary config and writes the report. It is what
+// the scheduled scan CronJob runs, in place of Reconcile, and does nothing while
+// spec.compliance.enabled is unset.
+func (r *ComplianceReconciler) Scan(ctx context.Context) error {
+	config := &smv1alpha1.SecretsManagementConfig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !config.DeletionTimestamp.IsZero() || !config.Spec.Compliance.Enabled {
+		return nil
+	}
+	return r.scan(ctx, config)
+}
+
+// scan evaluates the controls and writes the report
+func (r *ComplianceReconciler) scan(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	controls, err := r.evaluate(ctx, config)
+	if err != nil {
+		return err
+	}
+	return r.writeReport(ctx, config, complianceReportStatus(controls, time.Now()))
+}
+
+// reconcileScanJob creates or updates the CronJob running the scan on spec.scan.schedule and the
+// read-only identity it runs under. All of them are owned by the config.
+func (r *ComplianceReconciler) reconcileScanJob(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	labels := complianceScanLabels()
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: ComplianceScanName, Namespace: PluginNamespace, Labels: labels},
+	}
+	role := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: ComplianceScanName, Labels: labels},
+		Rules:      r.complianceScanRules(),
+	}
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: ComplianceScanName, Labels: labels},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: ComplianceScanName},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: ComplianceScanName, Namespace: PluginNamespace}},
+	}
+	cronJob := r.buildScanCronJob(config)
+
+	for _, obj := range []client.Object{sa, role, binding, cronJob} {
+		if err := controllerutil.SetControllerReference(config, obj, r.Scheme); err != nil {
+			return err
+		}
+		applyCommonMetadata(config, obj)
+	}
+	if err := setAppliedSpecHash(cronJob); err != nil {
+		return err
+	}
+
+	if err := createIfMissing(ctx, r, sa); err != nil {
+		return err
+	}
+
+	existingRole := &rbacv1.ClusterRole{}
+	if err := r.Get(ctx, types.NamespacedName{Name: role.Name}, existingRole); errors.IsNotFound(err) {
+		if err := r.Create(ctx, role); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		before := existingRole.DeepCopy()
+		existingRole.Rules = role.Rules
+		mergeMetadata(existingRole, role)
+		if err := updateIfChanged(ctx, r, before, existingRole); err != nil {
+			return err
+		}
+	}
+
+	// The role reference of a binding cannot change, so an existing binding is left as is
+	if err := createIfMissing(ctx, r, binding); err != nil {
+		return err
+	}
+
+	existing := &batchv1.CronJob{}
+	err := r.Get(ctx, types.NamespacedName{Name: cronJob.Name, Namespace: cronJob.Namespace}, existing)
+	if errors.IsNotFound(err) {
+		return r.Create(ctx, cronJob)
+	}
+	if err != nil {
+		return err
+	}
+	before := existing.DeepCopy()
+	if specChanged(existing, cronJob, existing.Spec, cronJob.Spec) {
+		existing.Spec = cronJob.Spec
+	}
+	mergeMetadata(existing, cronJob)
+	return updateIfChanged(ctx, r, before, existing)
+}
+
+// buildScanCronJob returns the CronJob running the operator image with --scan
+func (r *ComplianceReconciler) buildScanCronJob(config *smv1alpha1.SecretsManagementConfig) *batchv1.CronJob {
+	return buildOperatorCronJob(ComplianceScanName, "scan", config.Spec.Scan.Schedule, ScanFlag, r.Restricted, complianceScanLabels())
+}
+
+// buildOperatorCronJob returns a CronJob in the plugin namespace running the operator image with
+// --flag under the ServiceAccount named name. Only one job runs at a time, and the pod runs with
+// the restricted security profile.
+func buildOperatorCronJob(name, container, schedule, flag string, restricted bool, labels map[string]string) *batchv1.CronJob {
+	var env []corev1.EnvVar
+	if restricted {
+		env = append(env, corev1.EnvVar{Name: WatchNamespaceEnv, Value: PluginNamespace})
+	}
+
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: PluginNamespace, Labels: labels},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   schedule,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: int32Ptr(1),
+			FailedJobsHistoryLimit:     int32Ptr(3),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: int32Ptr(2),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{
+							ServiceAccountName: name,
+							RestartPolicy:      corev1.RestartPolicyNever,
+							SecurityContext: &corev1.PodSecurityContext{
+								RunAsNonRoot: boolPtr(true),
+								SeccompProfile: &corev1.SeccompProfile{
+									Type: corev1.SeccompProfileTypeRuntimeDefault,
+								},
+							},
+							Containers: []corev1.Container{
+								{
+									Name:    container,
+									Image:   operatorImage(),
+									Command: []string{"/manager"},
+									Args:    []string{"--" + flag},
+									Env:     env,
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("100m"),
+											corev1.ResourceMemory: resource.MustParse("128Mi"),
+										},
+										Limits: corev1.ResourceList{
+											corev1.ResourceMemory: resource.MustParse("512Mi"),
+										},
+									},
+									SecurityContext: &corev1.SecurityContext{
+										AllowPrivilegeEscalation: boolPtr(false),
+										ReadOnlyRootFilesystem:   boolPtr(true),
+										Capabilities: &corev1.Capabilities{
+											Drop: []corev1.Capability{"ALL"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// operatorImage returns the operator's own image, which the CronJobs and the smcctl downloads
+// server run from
+func operatorImage() string {
+	if image := os.Getenv(RelatedImageOperatorEnv); image != "" {
+		return image
+	}
+	return DefaultOperatorImage
+}
+
+// complianceScanRules are what the scan reads and writes: the config, the report and the objects
+// the controls inspect. Restricted scans do not list Secrets or RoleBindings, so those rules are
+// left out in restricted mode.
+func (r *ComplianceReconciler) complianceScanRules() []rbacv1.PolicyRule {
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{smv1alpha1.GroupVersion.Group},
+			Resources: []string{"secretsmanagementconfigs"},
+			Verbs:     []string{"get"},
+		},
+		{
+			// Setting the config as the report's controller needs update on its finalizers
+			APIGroups: []string{smv1alpha1.GroupVersion.Group},
+			Resources: []string{"secretsmanagementconfigs/finalizers"},
+			Verbs:     []string{"update"},
+		},
+		{
+			APIGroups: []string{smv1alpha1.GroupVersion.Group},
+			Resources: []string{"secretscompliancereports"},
+			Verbs:     []string{"get", "create"},
+		},
+		{
+			APIGroups: []string{smv1alpha1.GroupVersion.Group},
+			Resources: []string{"secretscompliancereports/status"},
+			Verbs:     []string{"update"},
+		},
+		{
+			APIGroups: []string{clusterPolicyReportGVK.Group},
+			Resources: []string{"clusterpolicyreports"},
+			Verbs:     []string{"get", "create", "update"},
+		},
+		{
+			APIGroups: []string{rbacv1.GroupName},
+			Resources: []string{"clusterrolebindings"},
+			Verbs:     []string{"list"},
+		},
+		{
+			APIGroups: []string{rbacv1.GroupName},
+			Resources: []string{"clusterroles"},
+			Verbs:     []string{"get"},
+		},
+	}
+	if !r.Restricted {
+		rules = append(rules,
+			rbacv1.PolicyRule{
+				APIGroups: []string{rbacv1.GroupName},
+				Resources: []string{"rolebindings"},
+				Verbs:     []string{"list"},
+			},
+			rbacv1.PolicyRule{
+				APIGroups: []string{rbacv1.GroupName},
+				Resources: []string{"roles"},
+				Verbs:     []string{"get"},
+			},
+			rbacv1.PolicyRule{
+				APIGroups: []string{""},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"list"},
+			},
+		)
+	}
+	return rules
+}
+
+// cleanupScanJob deletes the scan CronJob, its Jobs and its identity
+func (r *ComplianceReconciler) cleanupScanJob(ctx context.Context) error {
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: ComplianceScanName, Namespace: PluginNamespace}}
+	if err := r.Delete(ctx, cronJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	for _, obj := range []client.Object{
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: ComplianceScanName}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: ComplianceScanName}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: ComplianceScanName, Namespace: PluginNamespace}},
+	} {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createIfMissing creates obj unless an object with its name already exists
+func createIfMissing(ctx context.Context, c client.Writer, obj client.Object) error {
+	err := c.Create(ctx, obj)
+	if errors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+func complianceScanLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       ComplianceScanName,
+		"app.kubernetes.io/part-of":    "ocp-secrets-management",
+		"app.kubernetes.io/managed-by": "secrets-management-operator",
+	}
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// ConsoleCapability is the cluster capability that installs the web console
+const ConsoleCapability = "Console"
+
+var clusterVersionGVK = schema.GroupVersionKind{
+	Group:   "config.openshift.io",
+	Version: "v1",
+	Kind:    "ClusterVersion",
+}
+
+// +kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get
+
+// ConsoleCapabilityEnabled reports whether the cluster runs the web console. It is false only
+// when the ClusterVersion lists the enabled capabilities without Console, as on minimal
+// single-node installs; clusters without ClusterVersion, or from before capabilities, have it.
+// A capability cannot be disabled once enabled, so this is checked once on startup.
+func ConsoleCapabilityEnabled(ctx context.Context, c client.Reader) (bool, error) {
+	cv := &unstructured.Unstructured{}
+	cv.SetGroupVersionKind(clusterVersionGVK)
+	if err := c.Get(ctx, types.NamespacedName{Name: "version"}, cv); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	enabled, found, err := unstructured.NestedStringSlice(cv.Object, "status", "capabilities", "enabledCapabilities")
+	if err != nil || !found {
+		return true, err
+	}
+	for _, capability := range enabled {
+		if capability == ConsoleCapability {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// setConsoleDisabledCondition reports that the plugin is not deployed because the cluster has no
+// console. It replaces the operator compatibility result in Degraded, which the phase follows.
+func (r *SecretsManagementConfigReconciler) setConsoleDisabledCondition(config *smv1alpha1.SecretsManagementConfig) {
+	r.setCondition(config, smv1alpha1.ConditionDegraded, "True", smv1alpha1.ReasonConsoleDisabled,
+		"The Console capability is disabled on this cluster, so the plugin is not deployed")
+}
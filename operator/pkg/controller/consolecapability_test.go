@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestClusterVersion(capabilities ...string) *unstructured.Unstructured {
+	cv := &unstructured.Unstructured{}
+	cv.SetGroupVersionKind(clusterVersionGVK)
+	cv.SetName("version")
+	if capabilities != nil {
+		_ = unstructured.SetNestedStringSlice(cv.Object, capabilities, "status", "capabilities", "enabledCapabilities")
+	}
+	return cv
+}
+
+func TestConsoleCapabilityEnabled(t *testing.T) {
+	ctx := context.Background()
+
+	// Clusters without a ClusterVersion, or without capabilities, have a console
+	enabled, err := ConsoleCapabilityEnabled(ctx, newTestReconciler().Client)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	enabled, err = ConsoleCapabilityEnabled(ctx, newTestReconciler(newTestClusterVersion()).Client)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	enabled, err = ConsoleCapabilityEnabled(ctx, newTestReconciler(newTestClusterVersion("baremetal", "Console", "Insights")).Client)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	enabled, err = ConsoleCapabilityEnabled(ctx, newTestReconciler(newTestClusterVersion("baremetal", "Insights")).Client)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+}
+
+func TestReconcile_ConsoleDisabled(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler(config)
+	r.ConsoleDisabled = true
+	reconcileTestConfig(t, r)
+	reconcileTestConfig(t, r)
+
+	err := r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, &appsv1.Deployment{})
+	assert.True(t, errors.IsNotFound(err))
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config))
+	assert.Equal(t, smv1alpha1.PhaseDegraded, config.Status.Phase)
+	degraded := findCondition(config, smv1alpha1.ConditionDegraded)
+	require.NotNil(t, degraded)
+	assert.Equal(t, "True", degraded.Status)
+	assert.Equal(t, smv1alpha1.ReasonConsoleDisabled, degraded.Reason)
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// MissingOperatorsNotificationName is the ConsoleNotification shown while none of the supported
+// operators are installed
+const MissingOperatorsNotificationName = "secrets-management-missing-operators"
+
+// missingOperatorsNotificationText is the banner shown to every console user
+const missingOperatorsNotificationText = "Secrets management: none of cert-manager, External Secrets Operator or the Secrets Store CSI driver is installed, so the Secrets Management pages will be empty until one is installed."
+
+var consoleNotificationGVK = schema.GroupVersionKind{
+	Group:   "console.openshift.io",
+	Version: "v1",
+	Kind:    "ConsoleNotification",
+}
+
+// reconcileMissingOperatorsNotification shows a console banner while none of the supported
+// operators are detected and removes it once one is installed. Clusters without the
+// ConsoleNotification API are skipped.
+func (r *SecretsManagementConfigReconciler) reconcileMissingOperatorsNotification(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	detected := config.Status.DetectedOperators
+	if detected.CertManager.Installed || detected.ExternalSecrets.Installed || detected.SecretsStoreCSI.Installed {
+		return r.cleanupMissingOperatorsNotification(ctx)
+	}
+
+	spec := map[string]interface{}{
+		"text":            missingOperatorsNotificationText,
+		"location":        "BannerTop",
+		"color":           "#fff",
+		"backgroundColor": "#0066cc",
+		"link": map[string]interface{}{
+			"href": "/operatorhub/all-namespaces?keyword=secrets",
+			"text": "Install from OperatorHub",
+		},
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(consoleNotificationGVK)
+	u.SetName(MissingOperatorsNotificationName)
+	u.SetLabels(map[string]string{
+		"app.kubernetes.io/name":       PluginName,
+		"app.kubernetes.io/part-of":    "ocp-secrets-management",
+		"app.kubernetes.io/managed-by": "secrets-management-operator",
+	})
+	if err := unstructured.SetNestedField(u.Object, spec, "spec"); err != nil {
+		return err
+	}
+	applyCommonMetadata(config, u)
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(consoleNotificationGVK)
+	err := r.Get(ctx, types.NamespacedName{Name: MissingOperatorsNotificationName}, existing)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		return r.Create(ctx, u)
+	}
+
+	before := existing.DeepCopy()
+	if err := unstructured.SetNestedField(existing.Object, spec, "spec"); err != nil {
+		return err
+	}
+	mergeMetadata(existing, u)
+	return updateIfChanged(ctx, r, before, existing)
+}
+
+// cleanupMissingOperatorsNotification removes the missing operators banner
+func (r *SecretsManagementConfigReconciler) cleanupMissingOperatorsNotification(ctx context.Context) error {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(consoleNotificationGVK)
+	u.SetName(MissingOperatorsNotificationName)
+
+	if err := r.Delete(ctx, u); err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
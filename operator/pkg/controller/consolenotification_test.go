@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func getMissingOperatorsNotification(r *SecretsManagementConfigReconciler) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(consoleNotificationGVK)
+	err := r.Get(context.Background(), types.NamespacedName{Name: MissingOperatorsNotificationName}, u)
+	return u, err
+}
+
+func TestReconcileMissingOperatorsNotification(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler()
+
+	// No operators detected: the banner is shown
+	require.NoError(t, r.reconcileMissingOperatorsNotification(ctx, config))
+	banner, err := getMissingOperatorsNotification(r)
+	require.NoError(t, err)
+	text, _, _ := unstructured.NestedString(banner.Object, "spec", "text")
+	assert.Contains(t, text, "cert-manager")
+	location, _, _ := unstructured.NestedString(banner.Object, "spec", "location")
+	assert.Equal(t, "BannerTop", location)
+	assert.Equal(t, "secrets-management-operator", banner.GetLabels()["app.kubernetes.io/managed-by"])
+
+	// Reconciling again leaves the banner unchanged
+	resourceVersion := banner.GetResourceVersion()
+	require.NoError(t, r.reconcileMissingOperatorsNotification(ctx, config))
+	banner, err = getMissingOperatorsNotification(r)
+	require.NoError(t, err)
+	assert.Equal(t, resourceVersion, banner.GetResourceVersion())
+
+	// Once an operator is installed the banner is removed
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	require.NoError(t, r.reconcileMissingOperatorsNotification(ctx, config))
+	_, err = getMissingOperatorsNotification(r)
+	assert.True(t, errors.IsNotFound(err))
+
+	// Removing an absent banner is not an error
+	require.NoError(t, r.reconcileMissingOperatorsNotification(ctx, config))
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// csiProviderDaemonSets maps a name fragment of each provider's DaemonSet, as deployed by the
+// providers' Helm charts and operators, to the provider it belongs to
+var csiProviderDaemonSets = []struct {
+	fragment string
+	provider string
+}{
+	{fragment: "vault-csi-provider", provider: "vault"},
+	{fragment: "provider-aws", provider: "aws"},
+	{fragment: "provider-azure", provider: "azure"},
+	{fragment: "provider-gcp", provider: "gcp"},
+}
+
+// csiDriverDaemonSetNames are the names the Secrets Store CSI driver DaemonSet is deployed under
+// by its Helm chart, its manifests and the OpenShift operator
+var csiDriverDaemonSetNames = map[string]bool{
+	"secrets-store-csi-driver":      true,
+	"csi-secrets-store":             true,
+	"secrets-store-csi-driver-node": true,
+}
+
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list
+
+// detectCSIDaemonSets reports the Secrets Store CSI driver and provider DaemonSets and whether
+// each has a ready pod on every node it is scheduled to. They may run in any namespace, which
+// restricted mode cannot list, so nothing is reported there.
+func (r *SecretsManagementConfigReconciler) detectCSIDaemonSets(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	status := &config.Status.DetectedOperators.SecretsStoreCSI
+	if r.Restricted || !status.Installed {
+		status.Driver = nil
+		status.Providers = nil
+		return nil
+	}
+
+	var driver *smv1alpha1.CSIDaemonSetStatus
+	providers := map[string]smv1alpha1.CSIDaemonSetStatus{}
+
+	// DaemonSets live in namespaces the cache does not cover
+	daemonSets := &appsv1.DaemonSetList{}
+	opts := []client.ListOption{client.Limit(podListPageSize)}
+	for {
+		if err := r.apiReader().List(ctx, daemonSets, opts...); err != nil {
+			return err
+		}
+		for i := range daemonSets.Items {
+			ds := &daemonSets.Items[i]
+			if provider := csiProvider(ds.Name); provider != "" {
+				// Prefer a ready DaemonSet when a provider is deployed more than once
+				if existing, ok := providers[provider]; !ok || (!existing.Ready && daemonSetReady(ds)) {
+					providers[provider] = csiDaemonSetStatus(ds, provider)
+				}
+				continue
+			}
+			if csiDriverDaemonSetNames[ds.Name] && (driver == nil || (!driver.Ready && daemonSetReady(ds))) {
+				s := csiDaemonSetStatus(ds, "")
+				driver = &s
+			}
+		}
+		if daemonSets.Continue == "" {
+			break
+		}
+		opts = []client.ListOption{client.Limit(podListPageSize), client.Continue(daemonSets.Continue)}
+	}
+
+	status.Driver = driver
+	status.Providers = nil
+	for _, p := range providers {
+		status.Providers = append(status.Providers, p)
+	}
+	sort.Slice(status.Providers, func(i, j int) bool { return status.Providers[i].Provider < status.Providers[j].Provider })
+	return nil
+}
+
+// csiProvider returns the provider a DaemonSet name belongs to, or ""
+func csiProvider(name string) string {
+	for _, p := range csiProviderDaemonSets {
+		if strings.Contains(name, p.fragment) {
+			return p.provider
+		}
+	}
+	return ""
+}
+
+// daemonSetReady reports whether every node the DaemonSet is scheduled to runs a ready, current pod
+func daemonSetReady(ds *appsv1.DaemonSet) bool {
+	return ds.Status.DesiredNumberScheduled > 0 &&
+		ds.Status.ObservedGeneration >= ds.Generation &&
+		ds.Status.NumberReady >= ds.Status.DesiredNumberScheduled
+}
+
+func csiDaemonSetStatus(ds *appsv1.DaemonSet, provider string) smv1alpha1.CSIDaemonSetStatus {
+	return smv1alpha1.CSIDaemonSetStatus{
+		Provider:               provider,
+		Namespace:              ds.Namespace,
+		Name:                   ds.Name,
+		DesiredNumberScheduled: ds.Status.DesiredNumberScheduled,
+		NumberReady:            ds.Status.NumberReady,
+		Ready:                  daemonSetReady(ds),
+	}
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestDaemonSet(namespace, name string, desired, ready int32) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: desired,
+			NumberReady:            ready,
+		},
+	}
+}
+
+func TestDetectCSIDaemonSets(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.SecretsStoreCSI.Installed = true
+	r := newTestReconciler(
+		newTestDaemonSet("openshift-cluster-csi-drivers", "secrets-store-csi-driver-node", 3, 3),
+		newTestDaemonSet("vault", "vault-csi-provider", 3, 3),
+		newTestDaemonSet("kube-system", "secrets-store-csi-driver-provider-aws", 3, 1),
+		newTestDaemonSet("monitoring", "node-exporter", 3, 3),
+	)
+
+	require.NoError(t, r.detectCSIDaemonSets(context.Background(), config))
+
+	status := config.Status.DetectedOperators.SecretsStoreCSI
+	require.NotNil(t, status.Driver)
+	assert.Equal(t, "secrets-store-csi-driver-node", status.Driver.Name)
+	assert.True(t, status.Driver.Ready)
+
+	require.Len(t, status.Providers, 2)
+	assert.Equal(t, "aws", status.Providers[0].Provider)
+	assert.False(t, status.Providers[0].Ready)
+	assert.Equal(t, int32(1), status.Providers[0].NumberReady)
+	assert.Equal(t, "vault", status.Providers[1].Provider)
+	assert.Equal(t, "vault", status.Providers[1].Namespace)
+	assert.True(t, status.Providers[1].Ready)
+}
+
+func TestDetectCSIDaemonSets_NotInstalled(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.SecretsStoreCSI.Providers = []smv1alpha1.CSIDaemonSetStatus{{Provider: "vault"}}
+	r := newTestReconciler(newTestDaemonSet("vault", "vault-csi-provider", 1, 1))
+
+	require.NoError(t, r.detectCSIDaemonSets(context.Background(), config))
+	assert.Nil(t, config.Status.DetectedOperators.SecretsStoreCSI.Providers)
+	assert.Nil(t, config.Status.DetectedOperators.SecretsStoreCSI.Driver)
+}
+
+func TestDetectCSIDaemonSets_PrefersReadyDaemonSet(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.SecretsStoreCSI.Installed = true
+	r := newTestReconciler(
+		newTestDaemonSet("a", "csi-secrets-store-provider-azure", 2, 0),
+		newTestDaemonSet("b", "csi-secrets-store-provider-azure", 2, 2),
+	)
+
+	require.NoError(t, r.detectCSIDaemonSets(context.Background(), config))
+	require.Len(t, config.Status.DetectedOperators.SecretsStoreCSI.Providers, 1)
+	assert.Equal(t, "b", config.Status.DetectedOperators.SecretsStoreCSI.Providers[0].Namespace)
+}
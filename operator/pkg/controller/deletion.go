@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// ForceDeleteAnnotation set to "true" on a config removes its finalizer on deletion without
+	// cleaning up the managed resources, for when an API they depend on is down
+	ForceDeleteAnnotation = "secrets-management.openshift.io/force-delete"
+
+	// DefaultDeletionRetryBudget is how long the cleanup of a deleted config is retried before its
+	// finalizer is removed with the remaining resources left in place
+	DefaultDeletionRetryBudget = 5 * time.Minute
+
+	// deletionCleanupTimeout bounds each cleanup attempt, so an API that accepts connections but
+	// never answers fails the attempt instead of holding the worker
+	deletionCleanupTimeout = 30 * time.Second
+)
+
+// reconcileDelete handles the deletion of the SecretsManagementConfig. Failed cleanups are retried
+// until the retry budget is spent, counted from the deletion timestamp; the finalizer is then
+// removed anyway so a wedged API cannot hold up the deletion indefinitely.
+func (r *SecretsManagementConfigReconciler) reconcileDelete(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) (ctrl.Result, error) {
+	log := r.Log.WithValues("secretsmanagementconfig", config.Name)
+
+	if config.Annotations[ForceDeleteAnnotation] == "true" {
+		log.Info("Force deletion requested, removing the finalizer without cleaning up managed resources", "annotation", ForceDeleteAnnotation)
+		return ctrl.Result{}, r.removeFinalizer(ctx, config)
+	}
+
+	log.Info("Reconciling deletion")
+	cleanupCtx, cancel := context.WithTimeout(ctx, deletionCleanupTimeout)
+	defer cancel()
+	if err := r.cleanupManagedResources(cleanupCtx, config); err != nil {
+		budget := r.DeletionRetryBudget
+		if budget == 0 {
+			budget = DefaultDeletionRetryBudget
+		}
+		elapsed := time.Since(config.DeletionTimestamp.Time)
+		if elapsed < budget {
+			log.Error(err, "Failed to clean up managed resources, retrying", "remaining", (budget - elapsed).Round(time.Second))
+			return ctrl.Result{}, err
+		}
+		message := fmt.Sprintf("Cleanup still failing after %s, removing the finalizer and leaving the remaining resources in place: %v", budget, err)
+		log.Error(err, "Cleanup retry budget exhausted, removing the finalizer and leaving the remaining resources in place")
+		if r.Recorder != nil {
+			r.Recorder.Event(config, corev1.EventTypeWarning, "CleanupAbandoned", message)
+		}
+	}
+
+	return ctrl.Result{}, r.removeFinalizer(ctx, config)
+}
+
+// cleanupManagedResources deletes everything config manages and returns the failures. Every
+// cleanup is attempted, so one failing does not leave the others in place.
+func (r *SecretsManagementConfigReconciler) cleanupManagedResources(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	var errs []error
+	cleanup := func(what string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to cleanup %s: %w", what, err))
+		}
+	}
+
+	// The protection policy goes first so it cannot reject the cleanup of the other resources
+	if isPrimaryConfig(config) {
+		cleanup("protection policy", r.cleanupProtection(ctx))
+		cleanup("secret deletion protection policy", r.cleanupAdmissionPolicy(ctx, SecretProtectionPolicyName))
+		cleanup("read-only policy", r.cleanupAdmissionPolicy(ctx, ReadOnlyPolicyName))
+		cleanup("access request approval policy", r.cleanupAdmissionPolicy(ctx, AccessRequestPolicyName))
+		cleanup("policy bundle", r.prunePolicies(ctx, nil))
+		cleanup("alert routing", r.cleanupAlertRouting(ctx))
+		cleanup("backup schedule", r.cleanupBackupJob(ctx))
+		if r.AuditForwarder != nil {
+			r.AuditForwarder.Configure(nil)
+		}
+		if r.Telemetry != nil {
+			r.Telemetry.Report(nil)
+		}
+		if r.ResourceMetrics != nil {
+			r.ResourceMetrics.Report(nil)
+		}
+		cleanup("missing operators ConsoleNotification", r.cleanupMissingOperatorsNotification(ctx))
+		cleanup("smcctl downloads", r.cleanupCLIDownloads(ctx))
+		cleanup("secret stores", r.pruneSecretStores(ctx, nil))
+		cleanup("cloud auth annotations", r.pruneCloudAuthAnnotations(ctx, config.Status.CloudAuth, nil))
+		cleanup("issuers", r.pruneIssuers(ctx, nil))
+		cleanup("managed cluster ManifestWorks", r.pruneManifestWorks(ctx, nil))
+	}
+	cleanup("ConsolePlugin", r.cleanupConsolePlugin(ctx, config))
+	cleanup("plugin Route", r.cleanupRoute(ctx, config))
+	cleanup("plugin deployment", r.cleanupPluginDeployment(ctx, config))
+	cleanup("RBAC", r.cleanupRBAC(ctx, config))
+	if isPrimaryConfig(config) {
+		cleanup("namespace quota", r.cleanupNamespaceQuota(ctx, config))
+	}
+	// Upgradeable is reported best effort; a stale condition does not leave anything behind
+	if err := r.reconcileOperatorCondition(ctx); err != nil {
+		r.Log.Error(err, "Failed to report Upgradeable on the OperatorCondition", "secretsmanagementconfig", config.Name)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// removeFinalizer removes the finalizer from the latest version of config, retrying on conflicts
+// with writers that changed it during the cleanup
+func (r *SecretsManagementConfigReconciler) removeFinalizer(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(config), config); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if !controllerutil.RemoveFinalizer(config, FinalizerName) {
+			return nil
+		}
+		err := r.Update(ctx, config)
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// newDeletingTestReconciler returns a reconciler for a deleted primary config, whose ConsolePlugin
+// API is down
+func newDeletingTestReconciler(t *testing.T, annotations map[string]string) *SecretsManagementConfigReconciler {
+	t.Helper()
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler(config)
+	reconcileTestConfig(t, r)
+
+	ctx := context.Background()
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config))
+	config.Annotations = annotations
+	require.NoError(t, r.Update(ctx, config))
+	require.NoError(t, r.Delete(ctx, config))
+
+	r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if obj.GetObjectKind().GroupVersionKind() == consolePluginGVK {
+				return apierrors.NewServiceUnavailable("the console API is unavailable")
+			}
+			return c.Delete(ctx, obj, opts...)
+		},
+	})
+	return r
+}
+
+func TestReconcileDelete_RetriesFailedCleanup(t *testing.T) {
+	r := newDeletingTestReconciler(t, nil)
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: SingletonConfigName}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to cleanup ConsolePlugin")
+
+	// The finalizer stays while the retry budget lasts, and the other resources are cleaned up
+	ctx := context.Background()
+	config := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config))
+	assert.Contains(t, config.Finalizers, FinalizerName)
+	err = r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileDelete_RetryBudgetExhausted(t *testing.T) {
+	r := newDeletingTestReconciler(t, nil)
+	r.DeletionRetryBudget = time.Nanosecond
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: SingletonConfigName}})
+	require.NoError(t, err)
+
+	err = r.Get(context.Background(), types.NamespacedName{Name: SingletonConfigName}, &smv1alpha1.SecretsManagementConfig{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileDelete_ForceDelete(t *testing.T) {
+	r := newDeletingTestReconciler(t, map[string]string{ForceDeleteAnnotation: "true"})
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: SingletonConfigName}})
+	require.NoError(t, err)
+
+	// The config is gone and the managed resources are left in place
+	ctx := context.Background()
+	err = r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, &smv1alpha1.SecretsManagementConfig{})
+	assert.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, &appsv1.Deployment{}))
+}
+
+func TestReconcileDelete_ForceDeleteAnnotatedWhileStuck(t *testing.T) {
+	r := newDeletingTestReconciler(t, nil)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: SingletonConfigName}}
+	_, err := r.Reconcile(ctx, req)
+	require.Error(t, err)
+
+	// Annotating the stuck config does not bump its generation, but must still reach Reconcile
+	old := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, req.NamespacedName, old))
+	config := old.DeepCopy()
+	config.Annotations = map[string]string{ForceDeleteAnnotation: "true"}
+	require.NoError(t, r.Update(ctx, config))
+	require.NoError(t, r.Get(ctx, req.NamespacedName, config))
+	assert.Equal(t, old.Generation, config.Generation)
+	assert.True(t, configChangedPredicate().Update(event.UpdateEvent{ObjectOld: old, ObjectNew: config}))
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	err = r.Get(ctx, req.NamespacedName, &smv1alpha1.SecretsManagementConfig{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
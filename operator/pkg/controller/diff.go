@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// maxDiffValueLength caps how much of a changed value a diff line shows
+const maxDiffValueLength = 120
+
+// diffIgnoredMetadata are metadata fields the API server maintains, left out of diffs
+var diffIgnoredMetadata = []string{"resourceVersion", "managedFields", "generation", "creationTimestamp", "uid"}
+
+// updateReporter is implemented by writers that report what an update changes before it is sent
+type updateReporter interface {
+	reportUpdate(before, existing client.Object)
+}
+
+// objectDiff returns one line per field that differs between before and after, such as
+// `spec.template.spec.containers[0].image: "a" -> "b"`, sorted by path. Status and the metadata
+// the API server maintains are ignored, and Secret values are never shown.
+func objectDiff(before, after client.Object) ([]string, error) {
+	// The converter returns the content of unstructured objects itself, so they are copied first
+	beforeMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(before.DeepCopyObject())
+	if err != nil {
+		return nil, err
+	}
+	afterMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(after.DeepCopyObject())
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range []map[string]interface{}{beforeMap, afterMap} {
+		delete(obj, "status")
+		if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+			for _, field := range diffIgnoredMetadata {
+				delete(metadata, field)
+			}
+		}
+	}
+	_, secret := after.(*corev1.Secret)
+
+	var lines []string
+	diffValues("", beforeMap, afterMap, secret, &lines)
+	sort.Strings(lines)
+	return lines, nil
+}
+
+// diffValues appends the differences between a and b below path to lines
+func diffValues(path string, a, b interface{}, redact bool, lines *[]string) {
+	if reflect.DeepEqual(a, b) {
+		return
+	}
+	aMap, aIsMap := a.(map[string]interface{})
+	bMap, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		keys := map[string]bool{}
+		for k := range aMap {
+			keys[k] = true
+		}
+		for k := range bMap {
+			keys[k] = true
+		}
+		for k := range keys {
+			diffValues(joinDiffPath(path, k), aMap[k], bMap[k], redact, lines)
+		}
+		return
+	}
+	aList, aIsList := a.([]interface{})
+	bList, bIsList := b.([]interface{})
+	if aIsList && bIsList {
+		for i := 0; i < len(aList) || i < len(bList); i++ {
+			var aItem, bItem interface{}
+			if i < len(aList) {
+				aItem = aList[i]
+			}
+			if i < len(bList) {
+				bItem = bList[i]
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), aItem, bItem, redact, lines)
+		}
+		return
+	}
+	if redact && (strings.HasPrefix(path, "data") || strings.HasPrefix(path, "stringData")) {
+		*lines = append(*lines, path+": <redacted>")
+		return
+	}
+	*lines = append(*lines, fmt.Sprintf("%s: %s -> %s", path, diffValue(a), diffValue(b)))
+}
+
+func joinDiffPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	return path + "." + key
+}
+
+// diffValue formats v as JSON, <none> when absent, cut to maxDiffValueLength
+func diffValue(v interface{}) string {
+	if v == nil {
+		return "<none>"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	if len(data) > maxDiffValueLength {
+		return string(data[:maxDiffValueLength]) + "..."
+	}
+	return string(data)
+}
+
+// reportUpdate logs the fields an update of a managed object changes, and emits them as an Event
+// on the object when UpdateEvents is set, so that a plugin restart can be traced to the field
+// that caused it
+func (r *SecretsManagementConfigReconciler) reportUpdate(before, existing client.Object) {
+	lines, err := objectDiff(before, existing)
+	if err != nil || len(lines) == 0 {
+		return
+	}
+	kind := existing.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(existing, r.Scheme); err == nil {
+		kind = gvk.Kind
+	}
+	r.Log.Info("Updating managed object", "kind", kind, "namespace", existing.GetNamespace(), "name", existing.GetName(), "changes", lines)
+	if r.UpdateEvents && r.Recorder != nil {
+		r.Recorder.Eventf(existing, corev1.EventTypeNormal, "Updated", "Updated by the operator: %s", strings.Join(lines, "; "))
+	}
+}
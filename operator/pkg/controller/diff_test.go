@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestObjectDiff(t *testing.T) {
+	before := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "plugin", ResourceVersion: "1", Labels: map[string]string{"app": "plugin"}},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "plugin", Image: "plugin:v1"}}},
+			},
+		},
+	}
+	after := before.DeepCopy()
+	after.ResourceVersion = "2"
+	after.Status.Replicas = 3
+	after.Labels["app.kubernetes.io/version"] = "v2"
+	after.Spec.Template.Spec.Containers[0].Image = "plugin:v2"
+
+	lines, err := objectDiff(before, after)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`metadata.labels["app.kubernetes.io/version"]: <none> -> "v2"`,
+		`spec.template.spec.containers[0].image: "plugin:v1" -> "plugin:v2"`,
+	}, lines)
+
+	lines, err = objectDiff(before, before.DeepCopy())
+	require.NoError(t, err)
+	assert.Empty(t, lines)
+}
+
+func TestObjectDiff_RedactsSecrets(t *testing.T) {
+	before := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cert"}, Data: map[string][]byte{"tls.key": []byte("old")}}
+	after := before.DeepCopy()
+	after.Data["tls.key"] = []byte("new")
+
+	lines, err := objectDiff(before, after)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`data["tls.key"]: <redacted>`}, lines)
+}
+
+func TestReconcile_ReportsUpdates(t *testing.T) {
+	r := newTestReconciler(newTestConfig(SingletonConfigName))
+	reconcileTestConfig(t, r)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	r.UpdateEvents = true
+	ctx := context.Background()
+
+	// Someone changes the plugin image by hand; the operator puts it back and says so
+	key := types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, key, deployment))
+	deployment.Spec.Template.Spec.Containers[0].Image = "example.com/other:latest"
+	require.NoError(t, r.Update(ctx, deployment))
+
+	reconcileTestConfig(t, r)
+	require.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.Contains(t, event, "Normal Updated")
+	assert.Contains(t, event, `spec.template.spec.containers[0].image: "example.com/other:latest" -> "openshift.io/ocp-secrets-management:test"`)
+
+	// Nothing changes on the next pass
+	reconcileTestConfig(t, r)
+	assert.Empty(t, recorder.Events)
+}
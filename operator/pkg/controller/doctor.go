@@ -0,0 +1,341 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// DoctorResult is the outcome of a doctor check
+type DoctorResult string
+
+const (
+	// DoctorPass indicates the check found nothing wrong
+	DoctorPass DoctorResult = "PASS"
+
+	// DoctorWarn indicates something an admin should look at that does not break the plugin yet
+	DoctorWarn DoctorResult = "WARN"
+
+	// DoctorFail indicates the plugin does not work for the reason given
+	DoctorFail DoctorResult = "FAIL"
+)
+
+// certExpiryWarning is how long before its expiry the serving certificate is reported
+const certExpiryWarning = 30 * 24 * time.Hour
+
+// consoleOperatorGVK is the cluster console configuration, which lists the enabled plugins
+var consoleOperatorGVK = schema.GroupVersionKind{
+	Group:   "operator.openshift.io",
+	Version: "v1",
+	Kind:    "Console",
+}
+
+// DoctorCheck is the result of one doctor check
+type DoctorCheck struct {
+	Name    string
+	Result  DoctorResult
+	Message string
+}
+
+// Doctor checks, with the caller's permissions, what stops the console from loading the plugin of
+// a SecretsManagementConfig: the reconcile state, the plugin Deployment, the path from the console
+// to the plugin Service, the serving certificate, the console plugin list and the plugin roles.
+// It returns an error only when the config cannot be read.
+func Doctor(ctx context.Context, c client.Reader, name string, now time.Time) ([]DoctorCheck, error) {
+	config := &smv1alpha1.SecretsManagementConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, config); err != nil {
+		return nil, err
+	}
+
+	checks := []func(context.Context, client.Reader, *smv1alpha1.SecretsManagementConfig, time.Time) DoctorCheck{
+		checkConfigReconciled,
+		checkPluginDeployment,
+		checkPluginServiceReachable,
+		checkServingCert,
+		checkPluginEnabled,
+		checkPluginRoles,
+	}
+	results := make([]DoctorCheck, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, check(ctx, c, config, now))
+	}
+	return results, nil
+}
+
+func checkConfigReconciled(_ context.Context, _ client.Reader, config *smv1alpha1.SecretsManagementConfig, _ time.Time) DoctorCheck {
+	check := DoctorCheck{Name: "config reconciled"}
+	switch {
+	case config.Status.Phase == smv1alpha1.PhaseError:
+		check.Result, check.Message = DoctorFail, fmt.Sprintf("phase is Error: %s", config.Status.LastError)
+	case config.Status.ObservedGeneration != config.Generation:
+		check.Result, check.Message = DoctorWarn, fmt.Sprintf("generation %d is not reconciled yet, is the operator running?", config.Generation)
+	case config.Status.Phase != smv1alpha1.PhaseReady && config.Status.Reason != "":
+		check.Result, check.Message = DoctorWarn, fmt.Sprintf("phase is %s: %s", config.Status.Phase, config.Status.Reason)
+	case config.Status.Phase != smv1alpha1.PhaseReady:
+		check.Result, check.Message = DoctorWarn, fmt.Sprintf("phase is %s", config.Status.Phase)
+	default:
+		check.Result, check.Message = DoctorPass, "phase is Ready"
+	}
+	return check
+}
+
+func checkPluginDeployment(ctx context.Context, c client.Reader, config *smv1alpha1.SecretsManagementConfig, _ time.Time) DoctorCheck {
+	check := DoctorCheck{Name: "plugin deployment available"}
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: fmt.Sprintf("%s-plugin", instanceName(config)), Namespace: PluginNamespace}
+	if err := c.Get(ctx, key, deployment); err != nil {
+		return failed(check, err)
+	}
+	switch {
+	case deployment.Status.AvailableReplicas == 0:
+		check.Result, check.Message = DoctorFail, fmt.Sprintf("Deployment %s has no available replicas", key)
+	case deploymentRollingOut(deployment):
+		check.Result, check.Message = DoctorWarn, fmt.Sprintf("Deployment %s is rolling out", key)
+	default:
+		check.Result, check.Message = DoctorPass, fmt.Sprintf("%d replicas available", deployment.Status.AvailableReplicas)
+	}
+	return check
+}
+
+// checkPluginServiceReachable follows the console's path to the plugin: the ConsolePlugin backend
+// must name the plugin Service and port, and the Service must have ready endpoints on that port
+func checkPluginServiceReachable(ctx context.Context, c client.Reader, config *smv1alpha1.SecretsManagementConfig, _ time.Time) DoctorCheck {
+	check := DoctorCheck{Name: "console can reach plugin service"}
+	plugin := &unstructured.Unstructured{}
+	plugin.SetGroupVersionKind(consolePluginGVK)
+	if err := c.Get(ctx, types.NamespacedName{Name: instanceName(config)}, plugin); err != nil {
+		return failed(check, err)
+	}
+	backend, _, _ := unstructured.NestedMap(plugin.Object, "spec", "backend", "service")
+	serviceName, _ := backend["name"].(string)
+	serviceNamespace, _ := backend["namespace"].(string)
+	port, _ := backend["port"].(int64)
+	expected := fmt.Sprintf("%s-plugin", instanceName(config))
+	if serviceName != expected || serviceNamespace != PluginNamespace || port != int64(pluginPort(config)) {
+		check.Result = DoctorFail
+		check.Message = fmt.Sprintf("ConsolePlugin %s points at %s/%s:%d, not %s/%s:%d", plugin.GetName(),
+			serviceNamespace, serviceName, port, PluginNamespace, expected, pluginPort(config))
+		return check
+	}
+
+	key := types.NamespacedName{Name: serviceName, Namespace: serviceNamespace}
+	service := &corev1.Service{}
+	if err := c.Get(ctx, key, service); err != nil {
+		return failed(check, err)
+	}
+	endpoints := &corev1.Endpoints{}
+	if err := c.Get(ctx, key, endpoints); err != nil {
+		return failed(check, err)
+	}
+	ready := 0
+	for _, subset := range endpoints.Subsets {
+		for _, p := range subset.Ports {
+			// Endpoint ports carry the name of the Service port they back
+			if p.Name == "https" {
+				ready += len(subset.Addresses)
+				break
+			}
+		}
+	}
+	if ready == 0 {
+		check.Result, check.Message = DoctorFail, fmt.Sprintf("Service %s has no ready endpoints on port %d", key, port)
+		return check
+	}
+	check.Result, check.Message = DoctorPass, fmt.Sprintf("Service %s has %d ready endpoints", key, ready)
+	return check
+}
+
+// checkServingCert checks that the certificate the service CA issued for the plugin Service is
+// valid now, for the Service's DNS name, and not about to expire
+func checkServingCert(ctx context.Context, c client.Reader, config *smv1alpha1.SecretsManagementConfig, now time.Time) DoctorCheck {
+	check := DoctorCheck{Name: "serving cert valid"}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: fmt.Sprintf("%s-plugin-cert", instanceName(config)), Namespace: PluginNamespace}
+	if err := c.Get(ctx, key, secret); err != nil {
+		return failed(check, err)
+	}
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		check.Result, check.Message = DoctorFail, fmt.Sprintf("Secret %s holds no PEM certificate", key)
+		return check
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		check.Result, check.Message = DoctorFail, fmt.Sprintf("parsing the certificate in Secret %s: %v", key, err)
+		return check
+	}
+
+	host := fmt.Sprintf("%s-plugin.%s.svc", instanceName(config), PluginNamespace)
+	switch {
+	case now.Before(cert.NotBefore):
+		check.Result, check.Message = DoctorFail, fmt.Sprintf("certificate is not valid before %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	case !now.Before(cert.NotAfter):
+		check.Result, check.Message = DoctorFail, fmt.Sprintf("certificate expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	case cert.VerifyHostname(host) != nil:
+		check.Result, check.Message = DoctorFail, fmt.Sprintf("certificate is not valid for %s", host)
+	case cert.NotAfter.Sub(now) < certExpiryWarning:
+		check.Result, check.Message = DoctorWarn, fmt.Sprintf("certificate expires at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	case secret.Annotations[SelfSignedCertAnnotation] == "true":
+		check.Result, check.Message = DoctorWarn, fmt.Sprintf("self-signed certificate written because the service CA did not issue one; delete Secret %s to retry", key)
+	default:
+		check.Result, check.Message = DoctorPass, fmt.Sprintf("valid for %s until %s", host, cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return check
+}
+
+// checkPluginEnabled checks that the plugin is in the console's list of enabled plugins; without
+// it the console registers the plugin but shows it as disabled
+func checkPluginEnabled(ctx context.Context, c client.Reader, config *smv1alpha1.SecretsManagementConfig, _ time.Time) DoctorCheck {
+	check := DoctorCheck{Name: "plugin enabled in console"}
+	console := &unstructured.Unstructured{}
+	console.SetGroupVersionKind(consoleOperatorGVK)
+	err := c.Get(ctx, types.NamespacedName{Name: "cluster"}, console)
+	if meta.IsNoMatchError(err) {
+		check.Result, check.Message = DoctorWarn, "the cluster has no OpenShift console"
+		return check
+	}
+	if err != nil {
+		return failed(check, err)
+	}
+	plugins, _, _ := unstructured.NestedStringSlice(console.Object, "spec", "plugins")
+	for _, plugin := range plugins {
+		if plugin == instanceName(config) {
+			check.Result, check.Message = DoctorPass, fmt.Sprintf("%s is enabled", plugin)
+			return check
+		}
+	}
+	check.Result = DoctorWarn
+	check.Message = fmt.Sprintf("%s is not in spec.plugins of consoles.operator.openshift.io/cluster, the console shows it as disabled", instanceName(config))
+	return check
+}
+
+// checkPluginRoles checks that the default roles the operator creates for plugin users exist
+func checkPluginRoles(ctx context.Context, c client.Reader, config *smv1alpha1.SecretsManagementConfig, _ time.Time) DoctorCheck {
+	check := DoctorCheck{Name: "plugin roles present"}
+	if !config.Spec.RBAC.CreateDefaultRoles {
+		check.Result, check.Message = DoctorPass, "spec.rbac.createDefaultRoles is disabled"
+		return check
+	}
+	var missing []string
+	for _, suffix := range []string{"view", "delete", "admin"} {
+		name := fmt.Sprintf("%s-%s", rolePrefix(config), suffix)
+		err := c.Get(ctx, types.NamespacedName{Name: name}, &rbacv1.ClusterRole{})
+		if errors.IsNotFound(err) {
+			missing = append(missing, name)
+			continue
+		}
+		if err != nil {
+			return failed(check, err)
+		}
+	}
+	if len(missing) > 0 {
+		check.Result, check.Message = DoctorFail, fmt.Sprintf("missing ClusterRoles: %s", strings.Join(missing, ", "))
+		return check
+	}
+	check.Result, check.Message = DoctorPass, fmt.Sprintf("ClusterRoles %s-{view,delete,admin} exist", rolePrefix(config))
+	return check
+}
+
+// failed reports a check that could not read what it checks. A Forbidden error is the caller's
+// RBAC, not the plugin's, and is reported as a warning.
+func failed(check DoctorCheck, err error) DoctorCheck {
+	check.Result, check.Message = DoctorFail, err.Error()
+	if errors.IsForbidden(err) {
+		check.Result = DoctorWarn
+	}
+	return check
+}
+
+// PrintDoctor writes doctor checks as a table
+func PrintDoctor(w io.Writer, checks []DoctorCheck) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "RESULT\tCHECK\tMESSAGE")
+	for _, check := range checks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", check.Result, check.Name, check.Message)
+	}
+	return tw.Flush()
+}
+
+// PrintStatus writes the phase, conditions and detected operators of a SecretsManagementConfig
+// for a terminal
+func PrintStatus(w io.Writer, config *smv1alpha1.SecretsManagementConfig, now time.Time) error {
+	status := config.Status
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Name:\t%s\n", config.Name)
+	fmt.Fprintf(tw, "Phase:\t%s\n", valueOr(string(status.Phase), "Unknown"))
+	if status.Reason != "" {
+		fmt.Fprintf(tw, "Reason:\t%s\n", status.Reason)
+	}
+	fmt.Fprintf(tw, "Observed generation:\t%d/%d\n", status.ObservedGeneration, config.Generation)
+	if status.LastReconcileTime != nil {
+		fmt.Fprintf(tw, "Last reconcile:\t%s ago\n", now.Sub(status.LastReconcileTime.Time).Round(time.Second))
+	}
+	if status.LastError != "" {
+		fmt.Fprintf(tw, "Last error:\t%s\n", status.LastError)
+	}
+	fmt.Fprintf(tw, "Plugin:\t%s, %d available, ready=%t\n", valueOr(status.Plugin.DeploymentName, "-"), status.Plugin.AvailableReplicas, status.Plugin.Ready)
+	if status.Plugin.ResolvedImage != "" {
+		fmt.Fprintf(tw, "Image:\t%s\n", status.Plugin.ResolvedImage)
+	}
+	if status.Plugin.RouteHost != "" {
+		fmt.Fprintf(tw, "Route:\t%s\n", status.Plugin.RouteHost)
+	}
+
+	fmt.Fprintln(tw, "\nDetected operators:")
+	operators := []struct {
+		name     string
+		detected smv1alpha1.DetectedOperator
+	}{
+		{"cert-manager", status.DetectedOperators.CertManager},
+		{"external-secrets", status.DetectedOperators.ExternalSecrets},
+		{"secrets-store-csi", status.DetectedOperators.SecretsStoreCSI.DetectedOperator},
+	}
+	for _, o := range operators {
+		state := "not installed"
+		if o.detected.Installed {
+			state = "installed " + o.detected.Version
+			if o.detected.ReleaseVersion != "" {
+				state += ", release " + o.detected.ReleaseVersion
+			}
+			if o.detected.SupportLevel == smv1alpha1.SupportLevelUnsupported {
+				state += ", unsupported"
+			}
+		}
+		fmt.Fprintf(tw, "  %s\t%s\n", o.name, strings.TrimSpace(state))
+	}
+
+	fmt.Fprintln(tw, "\nConditions:")
+	fmt.Fprintln(tw, "  TYPE\tSTATUS\tREASON\tAGE\tMESSAGE")
+	for _, c := range status.Conditions {
+		age := "-"
+		if !c.LastTransitionTime.IsZero() {
+			age = now.Sub(c.LastTransitionTime.Time).Round(time.Second).String()
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, age, c.Message)
+	}
+	return tw.Flush()
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// testServingCert returns a PEM certificate for host valid from notBefore to notAfter
+func testServingCert(t *testing.T, host string, notBefore, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func doctorResults(checks []DoctorCheck) map[string]DoctorResult {
+	results := map[string]DoctorResult{}
+	for _, check := range checks {
+		results[check.Name] = check.Result
+	}
+	return results
+}
+
+func TestDoctor(t *testing.T) {
+	r := newTestReconciler(newTestConfig(SingletonConfigName))
+	reconcileTestConfig(t, r)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	checks, err := Doctor(ctx, r, SingletonConfigName, now)
+	require.NoError(t, err)
+	results := doctorResults(checks)
+	assert.Equal(t, DoctorFail, results["plugin deployment available"])
+	assert.Equal(t, DoctorFail, results["console can reach plugin service"])
+	assert.Equal(t, DoctorFail, results["serving cert valid"])
+	assert.Equal(t, DoctorPass, results["plugin roles present"])
+
+	// The plugin comes up and the console enables it
+	key := types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, key, deployment))
+	deployment.Status = appsv1.DeploymentStatus{ObservedGeneration: deployment.Generation, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+	require.NoError(t, r.Status().Update(ctx, deployment))
+	reconcileTestConfig(t, r)
+	require.NoError(t, r.Create(ctx, &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}},
+			Ports:     []corev1.EndpointPort{{Name: "https", Port: PluginPort}},
+		}},
+	}))
+	host := "ocp-secrets-management-plugin." + PluginNamespace + ".svc"
+	certSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ocp-secrets-management-plugin-cert", Namespace: PluginNamespace},
+		Data:       map[string][]byte{corev1.TLSCertKey: testServingCert(t, host, now.AddDate(0, -1, 0), now.AddDate(1, 0, 0))},
+	}
+	require.NoError(t, r.Create(ctx, certSecret))
+	console := &unstructured.Unstructured{}
+	console.SetGroupVersionKind(consoleOperatorGVK)
+	console.SetName("cluster")
+	require.NoError(t, unstructured.SetNestedStringSlice(console.Object, []string{"other", PluginName}, "spec", "plugins"))
+	require.NoError(t, r.Create(ctx, console))
+
+	checks, err = Doctor(ctx, r, SingletonConfigName, now)
+	require.NoError(t, err)
+	for _, check := range checks {
+		assert.Equal(t, DoctorPass, check.Result, "%s: %s", check.Name, check.Message)
+	}
+
+	// A certificate close to expiry is a warning, one for another host a failure
+	certSecret.Data[corev1.TLSCertKey] = testServingCert(t, host, now.AddDate(0, -1, 0), now.AddDate(0, 0, 7))
+	require.NoError(t, r.Update(ctx, certSecret))
+	checks, err = Doctor(ctx, r, SingletonConfigName, now)
+	require.NoError(t, err)
+	assert.Equal(t, DoctorWarn, doctorResults(checks)["serving cert valid"])
+
+	certSecret.Data[corev1.TLSCertKey] = testServingCert(t, "other.svc", now.AddDate(0, -1, 0), now.AddDate(1, 0, 0))
+	require.NoError(t, r.Update(ctx, certSecret))
+	checks, err = Doctor(ctx, r, SingletonConfigName, now)
+	require.NoError(t, err)
+	assert.Equal(t, DoctorFail, doctorResults(checks)["serving cert valid"])
+
+	var out bytes.Buffer
+	require.NoError(t, PrintDoctor(&out, checks))
+	assert.Regexp(t, `FAIL\s+serving cert valid\s+certificate is not valid for`, out.String())
+}
+
+func TestPrintStatus(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	config := newTestConfig(SingletonConfigName)
+	config.Generation = 3
+	config.Status = smv1alpha1.SecretsManagementConfigStatus{
+		Phase:              smv1alpha1.PhaseReady,
+		ObservedGeneration: 3,
+		DetectedOperators: smv1alpha1.DetectedOperatorsStatus{
+			CertManager: smv1alpha1.DetectedOperator{Installed: true, Version: "v1"},
+		},
+		Conditions: []smv1alpha1.Condition{{
+			Type:               smv1alpha1.ConditionDeploymentAvailable,
+			Status:             "True",
+			Reason:             "Available",
+			LastTransitionTime: metav1.NewTime(now.Add(-time.Minute)),
+		}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, PrintStatus(&out, config, now))
+	assert.Contains(t, out.String(), "Phase:")
+	assert.Contains(t, out.String(), "Ready")
+	assert.Regexp(t, `cert-manager\s+installed v1`, out.String())
+	assert.Regexp(t, `external-secrets\s+not installed`, out.String())
+	assert.Regexp(t, `DeploymentAvailable\s+True\s+Available\s+1m0s`, out.String())
+}
+
+func TestCheckConfigReconciled_Reason(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.Phase = smv1alpha1.PhaseDegraded
+	config.Status.Reason = smv1alpha1.ReasonMinimumReplicasUnavailable
+
+	check := checkConfigReconciled(context.Background(), nil, config, time.Now())
+	assert.Equal(t, DoctorWarn, check.Result)
+	assert.Equal(t, "phase is Degraded: MinimumReplicasUnavailable", check.Message)
+
+	var out bytes.Buffer
+	require.NoError(t, PrintStatus(&out, config, time.Now()))
+	assert.Regexp(t, `Reason:\s+MinimumReplicasUnavailable`, out.String())
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// recordingClient records the objects written through it, as returned by the API server, and those
+// updateIfChanged found already up to date. Status writes go through Status() and are not recorded.
+// The reconcile step groups write through it concurrently.
+type recordingClient struct {
+	client.Client
+	mu      sync.Mutex
+	written map[renderedObject]client.Object
+}
+
+func (c *recordingClient) record(obj client.Object, present bool) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil || gvk.Group == smv1alpha1.GroupVersion.Group {
+		return
+	}
+	key := renderedObject{gvk: gvk, namespace: obj.GetNamespace(), name: obj.GetName()}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !present {
+		delete(c.written, key)
+		return
+	}
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	c.written[key] = obj
+}
+
+func (c *recordingClient) recordUnchanged(obj client.Object) {
+	c.record(obj, true)
+}
+
+// recordUnchanged passes objects updateIfChanged left alone to the client when it records them
+func (r *SecretsManagementConfigReconciler) recordUnchanged(obj client.Object) {
+	if recorder, ok := r.Client.(unchangedRecorder); ok {
+		recorder.recordUnchanged(obj)
+	}
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record(obj, true)
+	return nil
+}
+
+func (c *recordingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record(obj, true)
+	return nil
+}
+
+func (c *recordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	c.record(obj, true)
+	return nil
+}
+
+func (c *recordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record(obj, false)
+	return nil
+}
+
+// DesiredObjects runs a dry-run reconcile of the config named name and returns the objects the
+// operator would create or update, as the API server would store them, in the order Render writes
+// them, including those already up to date. Objects it would delete are left out. The dry run fails on a cluster without the plugin
+// namespace, since the API server rejects objects in a namespace that does not exist; Render covers
+// a first install.
+func (r *SecretsManagementConfigReconciler) DesiredObjects(ctx context.Context, name string) ([]client.Object, error) {
+	recorder := &recordingClient{Client: client.NewDryRunClient(r.Client), written: map[renderedObject]client.Object{}}
+	dry := *r
+	dry.Client = recorder
+	dry.DryRun = false
+	dry.AuditForwarder = nil
+	dry.UpdateEvents = false
+	if _, err := dry.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}}); err != nil {
+		return nil, err
+	}
+
+	keys := make([]renderedObject, 0, len(recorder.written))
+	for key := range recorder.written {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return renderLess(keys[i], keys[j]) })
+	objects := make([]client.Object, 0, len(keys))
+	for _, key := range keys {
+		objects = append(objects, recorder.written[key])
+	}
+	return objects, nil
+}
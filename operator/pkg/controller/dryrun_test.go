@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func objectNames(objects []client.Object) []string {
+	names := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		names = append(names, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+	}
+	return names
+}
+
+func TestReconcile_DryRunWritesNothing(t *testing.T) {
+	r := newTestReconciler(newTestConfig(SingletonConfigName))
+	r.DryRun = true
+	ctx := context.Background()
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: SingletonConfigName}})
+	require.NoError(t, err)
+
+	err = r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, &appsv1.Deployment{})
+	assert.True(t, errors.IsNotFound(err), "expected no plugin Deployment, got %v", err)
+	config := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config))
+	assert.Empty(t, config.Finalizers)
+	assert.Empty(t, config.Status.Phase)
+}
+
+func TestDesiredObjects(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(config *smv1alpha1.SecretsManagementConfig)
+		replicas int32
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "unchanged",
+			replicas: 2,
+			contains: []string{"Deployment/ocp-secrets-management-plugin", "ConsolePlugin/ocp-secrets-management", "ClusterRole/secrets-management-view"},
+		},
+		{
+			name:     "scaled plugin",
+			mutate:   func(config *smv1alpha1.SecretsManagementConfig) { config.Spec.Plugin.Replicas = 3 },
+			replicas: 3,
+			contains: []string{"Deployment/ocp-secrets-management-plugin"},
+		},
+		{
+			name:     "default roles disabled",
+			mutate:   func(config *smv1alpha1.SecretsManagementConfig) { config.Spec.RBAC.CreateDefaultRoles = false },
+			replicas: 2,
+			contains: []string{"Deployment/ocp-secrets-management-plugin"},
+			excludes: []string{"ClusterRole/secrets-management-view"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(newTestConfig(SingletonConfigName))
+			reconcileTestConfig(t, r)
+			ctx := context.Background()
+
+			if tt.mutate != nil {
+				config := &smv1alpha1.SecretsManagementConfig{}
+				require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config))
+				tt.mutate(config)
+				require.NoError(t, r.Update(ctx, config))
+			}
+			before := &smv1alpha1.SecretsManagementConfig{}
+			require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, before))
+
+			objects, err := r.DesiredObjects(ctx, SingletonConfigName)
+			require.NoError(t, err)
+			names := objectNames(objects)
+			for _, name := range tt.contains {
+				assert.Contains(t, names, name)
+			}
+			for _, name := range tt.excludes {
+				assert.NotContains(t, names, name)
+			}
+			assert.NotContains(t, names, "SecretsManagementConfig/"+SingletonConfigName)
+			assert.Less(t, indexOf(names, "ServiceAccount/ocp-secrets-management-plugin"), indexOf(names, "Deployment/ocp-secrets-management-plugin"))
+
+			desired := objects[indexOf(names, "Deployment/ocp-secrets-management-plugin")].(*appsv1.Deployment)
+			assert.Equal(t, tt.replicas, *desired.Spec.Replicas)
+
+			// The cluster is untouched
+			stored := &appsv1.Deployment{}
+			require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, stored))
+			assert.Equal(t, int32(2), *stored.Spec.Replicas)
+			after := &smv1alpha1.SecretsManagementConfig{}
+			require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, after))
+			assert.Equal(t, before.ResourceVersion, after.ResourceVersion)
+		})
+	}
+}
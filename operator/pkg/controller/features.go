@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// FeaturesHashAnnotation records spec.features on the plugin pod template, so toggling a feature
+// restarts the plugin pods through a rolling update that honors spec.plugin.strategy
+const FeaturesHashAnnotation = "secrets-management.openshift.io/features-hash"
+
+// featuresHash returns a short hash identifying a feature set
+func featuresHash(features smv1alpha1.FeaturesConfig) (string, error) {
+	data, err := json.Marshal(features)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:16], nil
+}
+
+// setFeaturesHash annotates the pod template of the plugin Deployment with the hash of spec.features
+func setFeaturesHash(config *smv1alpha1.SecretsManagementConfig, deployment *appsv1.Deployment) error {
+	hash, err := featuresHash(config.Spec.Features)
+	if err != nil {
+		return err
+	}
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations[FeaturesHashAnnotation] = hash
+	return nil
+}
+
+// setFeaturesStatus reports spec.features as served once the plugin Deployment has rolled out a
+// pod template carrying its hash. Until then status.features keeps the set the pods still run.
+func setFeaturesStatus(config *smv1alpha1.SecretsManagementConfig, deployment *appsv1.Deployment) {
+	desired, err := featuresHash(config.Spec.Features)
+	if err != nil {
+		return
+	}
+	status := &config.Status.Features
+	_, done := rolloutProgress(deployment)
+	if done && deploymentStalled(deployment) == nil && deployment.Spec.Template.Annotations[FeaturesHashAnnotation] == desired {
+		if status.Hash != desired {
+			now := metav1.Now()
+			status.AppliedTime = &now
+		}
+		status.Delete = servedFeature(config.Spec.Features.Delete.FeatureConfig)
+		status.Create = servedFeature(config.Spec.Features.Create)
+		status.Edit = servedFeature(config.Spec.Features.Edit)
+		status.ReadOnly = config.Spec.Features.ReadOnly.Enabled
+		status.Hash = desired
+	}
+	status.UpToDate = status.Hash == desired
+}
+
+// servedFeature returns the status form of a feature toggle
+func servedFeature(feature smv1alpha1.FeatureConfig) smv1alpha1.ServedFeature {
+	return smv1alpha1.ServedFeature{Enabled: feature.Enabled, CheckRBAC: feature.CheckRBAC}
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestFeatureToggleRollsOutPlugin(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Features.Delete.Enabled = true
+	r := newTestReconciler(config)
+	reconcileTestConfig(t, r)
+
+	key := types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, key, deployment))
+	firstHash := deployment.Spec.Template.Annotations[FeaturesHashAnnotation]
+	require.NotEmpty(t, firstHash)
+
+	current := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, current))
+	assert.False(t, current.Status.Features.UpToDate, "not served before the first rollout")
+	assert.Empty(t, current.Status.Features.Hash)
+
+	deployment.Status = appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+	require.NoError(t, r.Status().Update(ctx, deployment))
+	reconcileTestConfig(t, r)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, current))
+	assert.True(t, current.Status.Features.UpToDate)
+	assert.Equal(t, firstHash, current.Status.Features.Hash)
+	assert.True(t, current.Status.Features.Delete.Enabled)
+	require.NotNil(t, current.Status.Features.AppliedTime)
+
+	// Disabling delete bumps the pod template; the Deployment controller starts replacing pods
+	current.Spec.Features.Delete.Enabled = false
+	require.NoError(t, r.Update(ctx, current))
+	require.NoError(t, r.Get(ctx, key, deployment))
+	deployment.Status = appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2}
+	require.NoError(t, r.Status().Update(ctx, deployment))
+	reconcileTestConfig(t, r)
+
+	require.NoError(t, r.Get(ctx, key, deployment))
+	assert.NotEqual(t, firstHash, deployment.Spec.Template.Annotations[FeaturesHashAnnotation])
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, current))
+	assert.False(t, current.Status.Features.UpToDate)
+	assert.Equal(t, firstHash, current.Status.Features.Hash)
+	assert.True(t, current.Status.Features.Delete.Enabled, "old pods still serve delete")
+
+	deployment.Status = appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+	require.NoError(t, r.Status().Update(ctx, deployment))
+	reconcileTestConfig(t, r)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, current))
+	assert.True(t, current.Status.Features.UpToDate)
+	assert.Equal(t, deployment.Spec.Template.Annotations[FeaturesHashAnnotation], current.Status.Features.Hash)
+	assert.False(t, current.Status.Features.Delete.Enabled)
+}
@@ -0,0 +1,294 @@
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// RedactedValue replaces the values of Secret data in gathered objects
+const RedactedValue = "REDACTED"
+
+// PodLogsFunc streams the logs of a pod container, of its previous instance when previous is set
+type PodLogsFunc func(ctx context.Context, namespace, pod, container string, previous bool) (io.ReadCloser, error)
+
+// GatherOptions configures Gather
+type GatherOptions struct {
+	// Dest is the directory the must-gather layout is written under
+	Dest string
+
+	// OperatorNamespace is the namespace the operator runs in; its pods, logs and events are
+	// gathered as well when set
+	OperatorNamespace string
+
+	// PodLogs reads container logs; logs are not gathered when nil
+	PodLogs PodLogsFunc
+}
+
+// gatherClusterKinds are the cluster-scoped kinds gathered: every object of the operator's API
+// group, the objects the operator manages, selected by label, and the CRDs of the supported operators
+var gatherClusterKinds = []struct {
+	gvk     schema.GroupVersionKind
+	managed bool
+}{
+	{gvk: smv1alpha1.GroupVersion.WithKind("SecretsManagementConfig")},
+	{gvk: smv1alpha1.GroupVersion.WithKind("SecretsAccessRequest")},
+	{gvk: smv1alpha1.GroupVersion.WithKind("SecretRotationPolicy")},
+	{gvk: smv1alpha1.GroupVersion.WithKind("SecretsComplianceReport")},
+	{gvk: schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, managed: true},
+	{gvk: schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}, managed: true},
+	{gvk: schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding"}, managed: true},
+	{gvk: schema.GroupVersionKind{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "ValidatingAdmissionPolicy"}, managed: true},
+	{gvk: schema.GroupVersionKind{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "ValidatingAdmissionPolicyBinding"}, managed: true},
+	{gvk: consolePluginGVK, managed: true},
+	{gvk: consoleNotificationGVK, managed: true},
+	{gvk: clusterSecretStoreGVK, managed: true},
+	{gvk: clusterIssuerGVK, managed: true},
+}
+
+// gatherNamespacedKinds are the kinds gathered in the plugin namespace, whoever created them
+var gatherNamespacedKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+	{Group: "batch", Version: "v1", Kind: "CronJob"},
+	{Group: "batch", Version: "v1", Kind: "Job"},
+	{Version: "v1", Kind: "Service"},
+	{Version: "v1", Kind: "ServiceAccount"},
+	{Version: "v1", Kind: "ConfigMap"},
+	{Version: "v1", Kind: "Secret"},
+	{Version: "v1", Kind: "ResourceQuota"},
+	{Version: "v1", Kind: "LimitRange"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"},
+	routeGVK,
+}
+
+// Gather writes what support needs to debug the operator offline into opts.Dest, in the
+// must-gather layout: the operator's custom resources, the managed objects, the CRDs of the
+// supported operators, and the pods, container logs and events of the plugin namespace and the
+// operator namespace. Secret data is redacted. Gathering continues past errors, which are
+// returned together at the end; kinds the cluster does not serve are skipped.
+func Gather(ctx context.Context, c client.Reader, opts GatherOptions) error {
+	g := &gatherer{client: c, opts: opts}
+
+	for _, kind := range gatherClusterKinds {
+		var selector []client.ListOption
+		if kind.managed {
+			selector = append(selector, client.MatchingLabels{"app.kubernetes.io/managed-by": "secrets-management-operator"})
+		}
+		g.gatherList(ctx, kind.gvk, selector...)
+	}
+
+	crd := schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+	for _, name := range sortedValues(operatorCRDs) {
+		g.gatherObject(ctx, crd, name)
+	}
+
+	for _, gvk := range gatherNamespacedKinds {
+		g.gatherList(ctx, gvk, client.InNamespace(PluginNamespace))
+	}
+
+	namespaces := []string{PluginNamespace}
+	if opts.OperatorNamespace != "" && opts.OperatorNamespace != PluginNamespace {
+		namespaces = append(namespaces, opts.OperatorNamespace)
+	}
+	for _, namespace := range namespaces {
+		g.gatherPods(ctx, namespace)
+		g.gatherEvents(ctx, namespace)
+	}
+
+	return utilerrors.NewAggregate(g.errs)
+}
+
+type gatherer struct {
+	client client.Reader
+	opts   GatherOptions
+	errs   []error
+}
+
+func (g *gatherer) fail(err error) {
+	if err != nil && !meta.IsNoMatchError(err) {
+		g.errs = append(g.errs, err)
+	}
+}
+
+func (g *gatherer) list(ctx context.Context, gvk schema.GroupVersionKind, opts ...client.ListOption) []unstructured.Unstructured {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := g.client.List(ctx, list, opts...); err != nil {
+		g.fail(err)
+		return nil
+	}
+	return list.Items
+}
+
+// gatherList writes every listed object to its own file
+func (g *gatherer) gatherList(ctx context.Context, gvk schema.GroupVersionKind, opts ...client.ListOption) {
+	items := g.list(ctx, gvk, opts...)
+	for i := range items {
+		g.fail(g.writeObject(gvk, &items[i]))
+	}
+}
+
+// gatherObject writes a single cluster-scoped object, if it exists
+func (g *gatherer) gatherObject(ctx context.Context, gvk schema.GroupVersionKind, name string) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	if err := g.client.Get(ctx, types.NamespacedName{Name: name}, u); err != nil {
+		g.fail(client.IgnoreNotFound(err))
+		return
+	}
+	g.fail(g.writeObject(gvk, u))
+}
+
+// gatherPods writes each pod of namespace and the current and previous logs of its containers
+func (g *gatherer) gatherPods(ctx context.Context, namespace string) {
+	for _, pod := range g.list(ctx, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, client.InNamespace(namespace)) {
+		dir := filepath.Join(g.opts.Dest, "namespaces", namespace, "pods", pod.GetName())
+		g.fail(writeYAML(filepath.Join(dir, pod.GetName()+".yaml"), gatherable(&pod)))
+		if g.opts.PodLogs == nil {
+			continue
+		}
+
+		for _, field := range []string{"initContainers", "containers"} {
+			containers, _, _ := unstructured.NestedSlice(pod.Object, "spec", field)
+			for _, container := range containers {
+				name, _, _ := unstructured.NestedString(container.(map[string]interface{}), "name")
+				logDir := filepath.Join(dir, name, name, "logs")
+				g.fail(g.writeLogs(ctx, namespace, pod.GetName(), name, false, filepath.Join(logDir, "current.log")))
+				if restarted(&pod, name) {
+					g.fail(g.writeLogs(ctx, namespace, pod.GetName(), name, true, filepath.Join(logDir, "previous.log")))
+				}
+			}
+		}
+	}
+}
+
+// gatherEvents writes the events of namespace to a single list
+func (g *gatherer) gatherEvents(ctx context.Context, namespace string) {
+	events := g.list(ctx, schema.GroupVersionKind{Version: "v1", Kind: "Event"}, client.InNamespace(namespace))
+	items := make([]interface{}, 0, len(events))
+	for i := range events {
+		items = append(items, gatherable(&events[i]))
+	}
+	list := map[string]interface{}{"apiVersion": "v1", "kind": "EventList", "items": items}
+	g.fail(writeYAML(filepath.Join(g.opts.Dest, "namespaces", namespace, "core", "events.yaml"), list))
+}
+
+func (g *gatherer) writeLogs(ctx context.Context, namespace, pod, container string, previous bool, path string) error {
+	logs, err := g.opts.PodLogs(ctx, namespace, pod, container, previous)
+	if err != nil {
+		return fmt.Errorf("reading logs of %s/%s container %s: %w", namespace, pod, container, err)
+	}
+	defer logs.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, logs); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeObject writes obj to the must-gather path of its kind
+func (g *gatherer) writeObject(gvk schema.GroupVersionKind, obj *unstructured.Unstructured) error {
+	group := gvk.Group
+	if group == "" {
+		group = "core"
+	}
+	resource, _ := meta.UnsafeGuessKindToResource(gvk)
+	dir := filepath.Join(g.opts.Dest, "cluster-scoped-resources", group, resource.Resource)
+	if obj.GetNamespace() != "" {
+		dir = filepath.Join(g.opts.Dest, "namespaces", obj.GetNamespace(), group, resource.Resource)
+	}
+	return writeYAML(filepath.Join(dir, obj.GetName()+".yaml"), gatherable(obj))
+}
+
+// gatherable drops managed fields and redacts Secret data, including the copy kubectl keeps in
+// the last-applied-configuration annotation
+func gatherable(obj *unstructured.Unstructured) map[string]interface{} {
+	u := obj.DeepCopy()
+	u.SetManagedFields(nil)
+	if u.GetKind() == "Secret" && u.GetAPIVersion() == "v1" {
+		// data stays base64 encoded so the Secret still parses
+		redacted := map[string]string{
+			"data":       base64.StdEncoding.EncodeToString([]byte(RedactedValue)),
+			"stringData": RedactedValue,
+		}
+		for field, value := range redacted {
+			data, _, _ := unstructured.NestedMap(u.Object, field)
+			for key := range data {
+				data[key] = value
+			}
+			if len(data) > 0 {
+				_ = unstructured.SetNestedMap(u.Object, data, field)
+			}
+		}
+		annotations := u.GetAnnotations()
+		if _, ok := annotations["kubectl.kubernetes.io/last-applied-configuration"]; ok {
+			annotations["kubectl.kubernetes.io/last-applied-configuration"] = RedactedValue
+			u.SetAnnotations(annotations)
+		}
+	}
+	return u.Object
+}
+
+// restarted reports whether a container of pod has a previous instance with logs
+func restarted(pod *unstructured.Unstructured, container string) bool {
+	for _, field := range []string{"initContainerStatuses", "containerStatuses"} {
+		statuses, _, _ := unstructured.NestedSlice(pod.Object, "status", field)
+		for _, s := range statuses {
+			status, ok := s.(map[string]interface{})
+			if !ok || status["name"] != container {
+				continue
+			}
+			count, _, _ := unstructured.NestedInt64(status, "restartCount")
+			return count > 0
+		}
+	}
+	return false
+}
+
+func writeYAML(path string, obj interface{}) error {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// sortedValues returns the values of m in key order
+func sortedValues(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, m[k])
+	}
+	return values
+}
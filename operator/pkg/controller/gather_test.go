@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func TestGather(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "plugin-cert",
+			Namespace:   PluginNamespace,
+			Annotations: map[string]string{"kubectl.kubernetes.io/last-applied-configuration": `{"data":{"tls.key":"c2VjcmV0"}}`},
+		},
+		Data: map[string][]byte{"tls.key": []byte("secret")},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "plugin-1", Namespace: PluginNamespace},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "plugin"}}},
+		Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "plugin", RestartCount: 1}}},
+	}
+	operatorPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "operator-1", Namespace: "operators"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "manager"}}},
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "plugin-1.1", Namespace: PluginNamespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "plugin-1"},
+		Reason:         "BackOff",
+	}
+	managedRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{
+		Name:   "secrets-management-view",
+		Labels: map[string]string{"app.kubernetes.io/managed-by": "secrets-management-operator"},
+	}}
+	otherRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "admin"}}
+	crd := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "certificates.cert-manager.io"}}
+
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithObjects(newTestConfig(SingletonConfigName), secret, pod, operatorPod, event, managedRole, otherRole, crd).
+		Build()
+
+	dest := t.TempDir()
+	var logsRead []string
+	err := Gather(context.Background(), c, GatherOptions{
+		Dest:              dest,
+		OperatorNamespace: "operators",
+		PodLogs: func(_ context.Context, namespace, pod, container string, previous bool) (io.ReadCloser, error) {
+			logsRead = append(logsRead, strings.Join([]string{namespace, pod, container}, "/"))
+			return io.NopCloser(strings.NewReader("log line\n")), nil
+		},
+	})
+	require.NoError(t, err)
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dest, path))
+		require.NoError(t, err)
+		return string(data)
+	}
+	assert.Contains(t, read("cluster-scoped-resources/secrets-management.openshift.io/secretsmanagementconfigs/cluster.yaml"), "kind: SecretsManagementConfig")
+	assert.Contains(t, read("cluster-scoped-resources/rbac.authorization.k8s.io/clusterroles/secrets-management-view.yaml"), "secrets-management-view")
+	assert.NoFileExists(t, filepath.Join(dest, "cluster-scoped-resources/rbac.authorization.k8s.io/clusterroles/admin.yaml"))
+	assert.FileExists(t, filepath.Join(dest, "cluster-scoped-resources/apiextensions.k8s.io/customresourcedefinitions/certificates.cert-manager.io.yaml"))
+
+	gathered := &corev1.Secret{}
+	require.NoError(t, yaml.Unmarshal([]byte(read("namespaces/"+PluginNamespace+"/core/secrets/plugin-cert.yaml")), gathered))
+	assert.Equal(t, RedactedValue, string(gathered.Data["tls.key"]))
+	assert.Equal(t, RedactedValue, gathered.Annotations["kubectl.kubernetes.io/last-applied-configuration"])
+
+	assert.Contains(t, read("namespaces/"+PluginNamespace+"/pods/plugin-1/plugin-1.yaml"), "name: plugin-1")
+	assert.Equal(t, "log line\n", read("namespaces/"+PluginNamespace+"/pods/plugin-1/plugin/plugin/logs/current.log"))
+	assert.FileExists(t, filepath.Join(dest, "namespaces/"+PluginNamespace+"/pods/plugin-1/plugin/plugin/logs/previous.log"))
+	assert.FileExists(t, filepath.Join(dest, "namespaces/operators/pods/operator-1/manager/manager/logs/current.log"))
+	assert.NoFileExists(t, filepath.Join(dest, "namespaces/operators/pods/operator-1/manager/manager/logs/previous.log"))
+	assert.ElementsMatch(t, []string{PluginNamespace + "/plugin-1/plugin", PluginNamespace + "/plugin-1/plugin", "operators/operator-1/manager"}, logsRead)
+
+	assert.Contains(t, read("namespaces/"+PluginNamespace+"/core/events.yaml"), "reason: BackOff")
+}
@@ -0,0 +1,310 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// FleetManifestWorkName is the name of the ManifestWork created in each managed cluster's namespace
+const FleetManifestWorkName = "secrets-management"
+
+// FleetLabel marks the ManifestWorks distributing the config, so they are found when pruned
+const FleetLabel = "secrets-management.openshift.io/fleet"
+
+// Open Cluster Management GroupVersionKinds, served on ACM hubs
+var (
+	managedClusterGVK = schema.GroupVersionKind{
+		Group:   "cluster.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "ManagedCluster",
+	}
+	manifestWorkGVK = schema.GroupVersionKind{
+		Group:   "work.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "ManifestWork",
+	}
+)
+
+// fleetFeedback are the status fields the work agent on each managed cluster reports back in the
+// ManifestWork status. The agent only supports scalar paths, hence status.health.
+var fleetFeedback = []struct {
+	name string
+	path string
+}{
+	{"phase", ".status.phase"},
+	{"certManager", ".status.detectedOperators.certManager.installed"},
+	{"externalSecrets", ".status.detectedOperators.externalSecrets.installed"},
+	{"secretsStoreCSI", ".status.detectedOperators.secretsStoreCSI.installed"},
+	{"etcdEncryption", ".status.securityPosture.etcdEncryption"},
+	{"stores", ".status.health.stores"},
+	{"storesReady", ".status.health.storesReady"},
+	{"issuers", ".status.health.issuers"},
+	{"issuersReady", ".status.health.issuersReady"},
+}
+
+// +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list
+// +kubebuilder:rbac:groups=work.open-cluster-management.io,resources=manifestworks,verbs=get;list;create;update;delete
+
+// setHealthStatus counts the ready stores and issuers, for a hub to collect
+func setHealthStatus(config *smv1alpha1.SecretsManagementConfig) {
+	health := smv1alpha1.SecretsHealthStatus{
+		Stores:  int32(len(config.Status.Stores)),
+		Issuers: int32(len(config.Status.Issuers)),
+	}
+	for _, store := range config.Status.Stores {
+		if store.Ready {
+			health.StoresReady++
+		}
+	}
+	for _, issuer := range config.Status.Issuers {
+		if issuer.Ready {
+			health.IssuersReady++
+		}
+	}
+	config.Status.Health = health
+}
+
+// reconcileHub distributes the config to the managed clusters selected by spec.hub as
+// ManifestWorks, deletes the ManifestWorks of clusters no longer selected, and aggregates the
+// status each cluster's work agent reports back into status.fleet
+func (r *SecretsManagementConfigReconciler) reconcileHub(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	if !config.Spec.Hub.Enabled {
+		config.Status.Fleet = nil
+		r.setCondition(config, smv1alpha1.ConditionFleetReporting, "False", smv1alpha1.ReasonHubDisabled, "Hub mode is disabled")
+		return r.pruneManifestWorks(ctx, nil)
+	}
+	if r.Restricted {
+		config.Status.Fleet = nil
+		r.setCondition(config, smv1alpha1.ConditionFleetReporting, "False", smv1alpha1.ReasonHubAPIUnavailable, "Hub mode needs cluster-wide access and is not available in restricted mode")
+		return nil
+	}
+
+	clusters := &unstructured.UnstructuredList{}
+	clusters.SetGroupVersionKind(managedClusterGVK.GroupVersion().WithKind(managedClusterGVK.Kind + "List"))
+	if err := r.apiReader().List(ctx, clusters, client.MatchingLabels(config.Spec.Hub.ClusterSelector)); err != nil {
+		if meta.IsNoMatchError(err) {
+			config.Status.Fleet = nil
+			r.setCondition(config, smv1alpha1.ConditionFleetReporting, "False", smv1alpha1.ReasonHubAPIUnavailable, "The ManagedCluster API is not served; the cluster is not an ACM hub")
+			return nil
+		}
+		return err
+	}
+
+	fleet := &smv1alpha1.FleetStatus{}
+	desired := make(map[string]bool, len(clusters.Items))
+	var notReporting []string
+	for _, cluster := range clusters.Items {
+		name := cluster.GetName()
+		desired[name] = true
+		work, err := r.applyManifestWork(ctx, config, name)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				config.Status.Fleet = nil
+				r.setCondition(config, smv1alpha1.ConditionFleetReporting, "False", smv1alpha1.ReasonHubAPIUnavailable, "The ManifestWork API is not served; the cluster is not an ACM hub")
+				return nil
+			}
+			return err
+		}
+
+		member := fleetClusterStatus(name, work)
+		fleet.Clusters++
+		if member.Reported {
+			fleet.Reporting++
+		} else {
+			notReporting = append(notReporting, name)
+		}
+		if member.Phase == smv1alpha1.PhaseReady {
+			fleet.Ready++
+		}
+		fleet.Members = append(fleet.Members, member)
+	}
+	sort.Slice(fleet.Members, func(i, j int) bool { return fleet.Members[i].Name < fleet.Members[j].Name })
+
+	if err := r.pruneManifestWorks(ctx, desired); err != nil {
+		return err
+	}
+	config.Status.Fleet = fleet
+
+	switch {
+	case fleet.Clusters == 0:
+		r.setCondition(config, smv1alpha1.ConditionFleetReporting, "False", smv1alpha1.ReasonNoClustersSelected, "No managed cluster matches spec.hub.clusterSelector")
+	case len(notReporting) > 0:
+		sort.Strings(notReporting)
+		r.setCondition(config, smv1alpha1.ConditionFleetReporting, "False", smv1alpha1.ReasonClustersNotReporting, fmt.Sprintf("Waiting for status from %s", strings.Join(notReporting, ", ")))
+	default:
+		r.setCondition(config, smv1alpha1.ConditionFleetReporting, "True", smv1alpha1.ReasonClustersReporting, fmt.Sprintf("%d managed cluster(s) are reporting, %d ready", fleet.Reporting, fleet.Ready))
+	}
+	return nil
+}
+
+// applyManifestWork creates or updates the ManifestWork applying config to the managed cluster
+// and returns it, with the status its work agent reported
+func (r *SecretsManagementConfigReconciler) applyManifestWork(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, cluster string) (*unstructured.Unstructured, error) {
+	spec, err := buildManifestWorkSpec(config)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(manifestWorkGVK)
+	err = r.apiReader().Get(ctx, types.NamespacedName{Namespace: cluster, Name: FleetManifestWorkName}, existing)
+	if errors.IsNotFound(err) {
+		work := &unstructured.Unstructured{}
+		work.SetGroupVersionKind(manifestWorkGVK)
+		work.SetNamespace(cluster)
+		work.SetName(FleetManifestWorkName)
+		work.SetLabels(map[string]string{
+			"app.kubernetes.io/part-of":    "ocp-secrets-management",
+			"app.kubernetes.io/managed-by": "secrets-management-operator",
+			FleetLabel:                     "true",
+		})
+		work.Object["spec"] = spec
+		applyCommonMetadata(config, work)
+		if err := controllerutil.SetControllerReference(config, work, r.Scheme); err != nil {
+			return nil, err
+		}
+		return work, r.Create(ctx, work)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	before := existing.DeepCopy()
+	mergeMetadata(existing, commonMetadata(config))
+	// Only the fields the operator sets are compared, so fields the hub defaults are not drift
+	for _, field := range []string{"workload", "manifestConfigs"} {
+		if current, _, _ := unstructured.NestedFieldNoCopy(existing.Object, "spec", field); !equality.Semantic.DeepEqual(current, spec[field]) {
+			if err := unstructured.SetNestedField(existing.Object, spec[field], "spec", field); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return existing, updateIfChanged(ctx, r, before, existing)
+}
+
+// buildManifestWorkSpec returns the ManifestWork spec applying config, without spec.hub, as the
+// cluster config of a managed cluster, with feedback rules returning its status
+func buildManifestWorkSpec(config *smv1alpha1.SecretsManagementConfig) (map[string]interface{}, error) {
+	specObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&config.Spec)
+	if err != nil {
+		return nil, err
+	}
+	delete(specObject, "hub")
+	gvk := smv1alpha1.GroupVersion.WithKind("SecretsManagementConfig")
+	manifest := map[string]interface{}{
+		"apiVersion": gvk.GroupVersion().String(),
+		"kind":       gvk.Kind,
+		"metadata":   map[string]interface{}{"name": SingletonConfigName},
+		"spec":       specObject,
+	}
+
+	paths := make([]interface{}, 0, len(fleetFeedback))
+	for _, feedback := range fleetFeedback {
+		paths = append(paths, map[string]interface{}{"name": feedback.name, "path": feedback.path})
+	}
+	return map[string]interface{}{
+		"workload": map[string]interface{}{
+			"manifests": []interface{}{manifest},
+		},
+		"manifestConfigs": []interface{}{
+			map[string]interface{}{
+				"resourceIdentifier": map[string]interface{}{
+					"group":    gvk.Group,
+					"resource": "secretsmanagementconfigs",
+					"name":     SingletonConfigName,
+				},
+				"feedbackRules": []interface{}{
+					map[string]interface{}{"type": "JSONPaths", "jsonPaths": paths},
+				},
+			},
+		},
+	}, nil
+}
+
+// fleetClusterStatus reads the status feedback the work agent of a managed cluster reported in
+// its ManifestWork. A cluster has reported once the phase of its config is known.
+func fleetClusterStatus(cluster string, work *unstructured.Unstructured) smv1alpha1.FleetClusterStatus {
+	member := smv1alpha1.FleetClusterStatus{Name: cluster}
+	manifests, _, _ := unstructured.NestedSlice(work.Object, "status", "resourceStatus", "manifests")
+	for _, m := range manifests {
+		manifest, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if kind, _, _ := unstructured.NestedString(manifest, "resourceMeta", "kind"); kind != "SecretsManagementConfig" {
+			continue
+		}
+		values, _, _ := unstructured.NestedSlice(manifest, "statusFeedback", "values")
+		for _, v := range values {
+			value, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(value, "name")
+			str, _, _ := unstructured.NestedString(value, "fieldValue", "string")
+			boolean, _, _ := unstructured.NestedBool(value, "fieldValue", "boolean")
+			integer, _, _ := unstructured.NestedInt64(value, "fieldValue", "integer")
+			switch name {
+			case "phase":
+				member.Phase = smv1alpha1.ConfigPhase(str)
+			case "certManager":
+				member.CertManager = boolean
+			case "externalSecrets":
+				member.ExternalSecrets = boolean
+			case "secretsStoreCSI":
+				member.SecretsStoreCSI = boolean
+			case "etcdEncryption":
+				member.EtcdEncryption = str
+			case "stores":
+				member.Health.Stores = int32(integer)
+			case "storesReady":
+				member.Health.StoresReady = int32(integer)
+			case "issuers":
+				member.Health.Issuers = int32(integer)
+			case "issuersReady":
+				member.Health.IssuersReady = int32(integer)
+			}
+		}
+	}
+	member.Reported = member.Phase != ""
+	return member
+}
+
+// pruneManifestWorks deletes the operator's ManifestWorks for clusters not in desired
+func (r *SecretsManagementConfigReconciler) pruneManifestWorks(ctx context.Context, desired map[string]bool) error {
+	if r.Restricted {
+		return nil
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(manifestWorkGVK.GroupVersion().WithKind(manifestWorkGVK.Kind + "List"))
+	if err := r.apiReader().List(ctx, list, client.MatchingLabels{FleetLabel: "true"}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	for i := range list.Items {
+		work := &list.Items[i]
+		if desired[work.GetNamespace()] {
+			continue
+		}
+		if err := r.Delete(ctx, work); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestManagedCluster(name string, labels map[string]string) *unstructured.Unstructured {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(managedClusterGVK)
+	cluster.SetName(name)
+	cluster.SetLabels(labels)
+	return cluster
+}
+
+func newTestManifestWork(cluster string) *unstructured.Unstructured {
+	work := &unstructured.Unstructured{}
+	work.SetGroupVersionKind(manifestWorkGVK)
+	work.SetNamespace(cluster)
+	work.SetName(FleetManifestWorkName)
+	work.SetLabels(map[string]string{FleetLabel: "true"})
+	return work
+}
+
+func feedbackValue(name, valueType string, value interface{}) interface{} {
+	key := map[string]string{"String": "string", "Boolean": "boolean", "Integer": "integer"}[valueType]
+	return map[string]interface{}{
+		"name":       name,
+		"fieldValue": map[string]interface{}{"type": valueType, key: value},
+	}
+}
+
+func TestSetHealthStatus(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.Stores = []smv1alpha1.SecretStoreStatus{{Name: "vault", Ready: true}, {Name: "aws"}}
+	config.Status.Issuers = []smv1alpha1.IssuerStatus{{Name: "ca", Ready: true}}
+
+	setHealthStatus(config)
+	assert.Equal(t, smv1alpha1.SecretsHealthStatus{Stores: 2, StoresReady: 1, Issuers: 1, IssuersReady: 1}, config.Status.Health)
+}
+
+func TestReconcileHub(t *testing.T) {
+	ctx := context.Background()
+	east := newTestManifestWork("east")
+	east.Object["status"] = map[string]interface{}{
+		"resourceStatus": map[string]interface{}{
+			"manifests": []interface{}{
+				map[string]interface{}{
+					"resourceMeta": map[string]interface{}{"kind": "SecretsManagementConfig", "name": SingletonConfigName},
+					"statusFeedback": map[string]interface{}{
+						"values": []interface{}{
+							feedbackValue("phase", "String", "Ready"),
+							feedbackValue("certManager", "Boolean", true),
+							feedbackValue("etcdEncryption", "String", "aescbc"),
+							feedbackValue("stores", "Integer", int64(2)),
+							feedbackValue("storesReady", "Integer", int64(1)),
+						},
+					},
+				},
+			},
+		},
+	}
+	r := newTestReconciler(
+		newTestManagedCluster("east", map[string]string{"env": "prod"}),
+		newTestManagedCluster("west", map[string]string{"env": "prod"}),
+		newTestManagedCluster("lab", nil),
+		east,
+		newTestManifestWork("lab"),
+	)
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Hub = smv1alpha1.HubConfig{Enabled: true, ClusterSelector: map[string]string{"env": "prod"}}
+
+	require.NoError(t, r.reconcileHub(ctx, config))
+
+	// The config is distributed to the selected clusters, without spec.hub
+	for _, cluster := range []string{"east", "west"} {
+		work := &unstructured.Unstructured{}
+		work.SetGroupVersionKind(manifestWorkGVK)
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: cluster, Name: FleetManifestWorkName}, work))
+		manifests, _, err := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+		require.NoError(t, err)
+		require.Len(t, manifests, 1)
+		manifest := manifests[0].(map[string]interface{})
+		assert.Equal(t, "SecretsManagementConfig", manifest["kind"])
+		assert.Equal(t, SingletonConfigName, manifest["metadata"].(map[string]interface{})["name"])
+		_, hasHub := manifest["spec"].(map[string]interface{})["hub"]
+		assert.False(t, hasHub)
+		configs, _, err := unstructured.NestedSlice(work.Object, "spec", "manifestConfigs")
+		require.NoError(t, err)
+		assert.Len(t, configs, 1)
+	}
+
+	// The cluster no longer selected loses its ManifestWork
+	err := r.Get(ctx, types.NamespacedName{Namespace: "lab", Name: FleetManifestWorkName}, newTestManifestWork("lab"))
+	assert.True(t, apierrors.IsNotFound(err))
+
+	fleet := config.Status.Fleet
+	require.NotNil(t, fleet)
+	assert.Equal(t, int32(2), fleet.Clusters)
+	assert.Equal(t, int32(1), fleet.Reporting)
+	assert.Equal(t, int32(1), fleet.Ready)
+	require.Len(t, fleet.Members, 2)
+	assert.Equal(t, smv1alpha1.FleetClusterStatus{
+		Name:           "east",
+		Reported:       true,
+		Phase:          smv1alpha1.PhaseReady,
+		CertManager:    true,
+		EtcdEncryption: "aescbc",
+		Health:         smv1alpha1.SecretsHealthStatus{Stores: 2, StoresReady: 1},
+	}, fleet.Members[0])
+	assert.Equal(t, smv1alpha1.FleetClusterStatus{Name: "west"}, fleet.Members[1])
+
+	cond := findCondition(config, smv1alpha1.ConditionFleetReporting)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonClustersNotReporting, cond.Reason)
+	assert.Contains(t, cond.Message, "west")
+
+	// Disabling hub mode removes every ManifestWork
+	config.Spec.Hub.Enabled = false
+	require.NoError(t, r.reconcileHub(ctx, config))
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(manifestWorkGVK.GroupVersion().WithKind("ManifestWorkList"))
+	require.NoError(t, r.List(ctx, list))
+	assert.Empty(t, list.Items)
+	assert.Nil(t, config.Status.Fleet)
+	cond = findCondition(config, smv1alpha1.ConditionFleetReporting)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonHubDisabled, cond.Reason)
+}
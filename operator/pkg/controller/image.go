@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// ImageDigestMirrorSet and ImageContentSourcePolicy list kinds for OpenShift mirror configuration
+var (
+	imageDigestMirrorSetListGVK = schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "ImageDigestMirrorSetList",
+	}
+	imageContentSourcePolicyListGVK = schema.GroupVersionKind{
+		Group:   "operator.openshift.io",
+		Version: "v1alpha1",
+		Kind:    "ImageContentSourcePolicyList",
+	}
+)
+
+// resolvePluginImage selects the plugin image for the cluster. A per-architecture image from
+// spec.plugin.images wins when the nodes run an architecture it covers; the returned arch is then
+// non-empty and the pods must be pinned to it. Otherwise the precedence is:
+//
+//  1. spec.plugin.image
+//  2. the RELATED_IMAGE_PLUGIN environment variable (set by OLM, rewritten for mirrored catalogs)
+//  3. DefaultPluginImage compiled into the operator
+func (r *SecretsManagementConfigReconciler) resolvePluginImage(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) (string, string, error) {
+	image := config.Spec.Plugin.Image
+	if image == "" {
+		image = os.Getenv(RelatedImagePluginEnv)
+	}
+	if image == "" {
+		image = DefaultPluginImage
+	}
+
+	arch := ""
+	// Restricted mode cannot list Nodes; per-architecture images are ignored
+	if len(config.Spec.Plugin.Images) > 0 && !r.Restricted {
+		archs, err := r.nodeArchitectures(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		for _, a := range archs {
+			if archImage, ok := config.Spec.Plugin.Images[a]; ok && archImage != "" {
+				image = archImage
+				arch = a
+				break
+			}
+		}
+	}
+
+	if config.Spec.Plugin.RequireDigest && !isDigestReference(image) {
+		return "", "", fmt.Errorf("spec.plugin.requireDigest is set but image %q is not pinned by digest", image)
+	}
+
+	return image, arch, nil
+}
+
+// nodeArchitectures returns the distinct architectures of the cluster nodes, most common first
+func (r *SecretsManagementConfigReconciler) nodeArchitectures(ctx context.Context) ([]string, error) {
+	// Only the kubelet-managed arch label is needed, so list metadata rather than full Nodes
+	nodes := &metav1.PartialObjectMetadataList{}
+	nodes.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NodeList"))
+	if err := r.List(ctx, nodes); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, node := range nodes.Items {
+		if arch := node.Labels[corev1.LabelArchStable]; arch != "" {
+			counts[arch]++
+		}
+	}
+
+	archs := make([]string, 0, len(counts))
+	for a := range counts {
+		archs = append(archs, a)
+	}
+	sort.Slice(archs, func(i, j int) bool {
+		if counts[archs[i]] != counts[archs[j]] {
+			return counts[archs[i]] > counts[archs[j]]
+		}
+		return archs[i] < archs[j]
+	})
+	return archs, nil
+}
+
+// isDigestReference reports whether image is pinned by digest
+func isDigestReference(image string) bool {
+	_, digest, found := strings.Cut(image, "@")
+	return found && strings.HasPrefix(digest, "sha256:") && len(digest) == len("sha256:")+64
+}
+
+// resolveMirroredImage returns the image the container runtime will pull for image once cluster
+// mirror configuration is applied. Mirrors only apply to digest references; the first mirror of
+// the most specific matching source wins, as it is tried first by CRI-O.
+func (r *SecretsManagementConfigReconciler) resolveMirroredImage(ctx context.Context, image string) (string, error) {
+	if !isDigestReference(image) {
+		return image, nil
+	}
+
+	mirrors := make(map[string][]string)
+	collect := func(gvk schema.GroupVersionKind, field string) error {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		if err := r.List(ctx, list); err != nil {
+			// Mirror APIs are not installed (non-OpenShift cluster)
+			if meta.IsNoMatchError(err) {
+				return nil
+			}
+			return err
+		}
+		for _, item := range list.Items {
+			entries, _, _ := unstructured.NestedSlice(item.Object, "spec", field)
+			for _, e := range entries {
+				entry, ok := e.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				source, _, _ := unstructured.NestedString(entry, "source")
+				targets, _, _ := unstructured.NestedStringSlice(entry, "mirrors")
+				if source != "" && len(targets) > 0 {
+					mirrors[source] = append(mirrors[source], targets...)
+				}
+			}
+		}
+		return nil
+	}
+	if err := collect(imageDigestMirrorSetListGVK, "imageDigestMirrors"); err != nil {
+		return "", err
+	}
+	if err := collect(imageContentSourcePolicyListGVK, "repositoryDigestMirrors"); err != nil {
+		return "", err
+	}
+
+	repository, digest, _ := strings.Cut(image, "@")
+	// Drop a tag; the digest alone identifies the image
+	if slash := strings.LastIndex(repository, "/"); strings.LastIndex(repository, ":") > slash {
+		repository = repository[:strings.LastIndex(repository, ":")]
+	}
+
+	bestSource := ""
+	for source := range mirrors {
+		if (repository == source || strings.HasPrefix(repository, source+"/")) && len(source) > len(bestSource) {
+			bestSource = source
+		}
+	}
+	if bestSource == "" {
+		return image, nil
+	}
+
+	return mirrors[bestSource][0] + strings.TrimPrefix(repository, bestSource) + "@" + digest, nil
+}
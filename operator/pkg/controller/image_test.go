@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func newTestNode(name, arch string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{corev1.LabelArchStable: arch},
+		},
+	}
+}
+
+func TestResolvePluginImage_Default(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.Image = ""
+	r := newTestReconciler()
+
+	image, arch, err := r.resolvePluginImage(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPluginImage, image)
+	assert.Empty(t, arch)
+}
+
+func TestResolvePluginImage_RelatedImageEnv(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(RelatedImagePluginEnv, "mirror.example.com/ocp-secrets-management@"+testDigest)
+	config := newTestConfig("cluster")
+	r := newTestReconciler()
+
+	// spec.plugin.image takes precedence over the environment
+	image, _, err := r.resolvePluginImage(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, "openshift.io/ocp-secrets-management:test", image)
+
+	// The environment takes precedence over the compiled default
+	config.Spec.Plugin.Image = ""
+	image, _, err = r.resolvePluginImage(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, "mirror.example.com/ocp-secrets-management@"+testDigest, image)
+}
+
+func TestResolvePluginImage_PerArchitecture(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.Images = map[string]string{
+		"arm64": "openshift.io/ocp-secrets-management:test-arm64",
+		"s390x": "openshift.io/ocp-secrets-management:test-s390x",
+	}
+	r := newTestReconciler(
+		newTestNode("node-a", "arm64"),
+		newTestNode("node-b", "arm64"),
+		newTestNode("node-c", "amd64"),
+	)
+
+	image, arch, err := r.resolvePluginImage(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, "openshift.io/ocp-secrets-management:test-arm64", image)
+	assert.Equal(t, "arm64", arch)
+
+	// Deployment pods are pinned to the selected architecture
+	err = r.reconcileDeployment(ctx, config)
+	require.NoError(t, err)
+	deployment := &appsv1.Deployment{}
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      "ocp-secrets-management-plugin",
+		Namespace: PluginNamespace,
+	}, deployment)
+	require.NoError(t, err)
+	assert.Equal(t, "openshift.io/ocp-secrets-management:test-arm64", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "arm64", deployment.Spec.Template.Spec.NodeSelector[corev1.LabelArchStable])
+}
+
+func TestResolvePluginImage_NoMatchingArchitecture(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.Images = map[string]string{"ppc64le": "openshift.io/ocp-secrets-management:test-ppc64le"}
+	r := newTestReconciler(newTestNode("node-a", "amd64"))
+
+	image, arch, err := r.resolvePluginImage(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, "openshift.io/ocp-secrets-management:test", image)
+	assert.Empty(t, arch)
+}
+
+func TestResolvePluginImage_RequireDigest(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.RequireDigest = true
+	r := newTestReconciler()
+
+	// Tags are rejected
+	_, _, err := r.resolvePluginImage(ctx, config)
+	assert.Error(t, err)
+
+	// Digests are accepted
+	config.Spec.Plugin.Image = "openshift.io/ocp-secrets-management@" + testDigest
+	image, _, err := r.resolvePluginImage(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, config.Spec.Plugin.Image, image)
+}
+
+func TestIsDigestReference(t *testing.T) {
+	assert.True(t, isDigestReference("quay.io/org/plugin@"+testDigest))
+	assert.True(t, isDigestReference("quay.io/org/plugin:v1@"+testDigest))
+	assert.False(t, isDigestReference("quay.io/org/plugin:latest"))
+	assert.False(t, isDigestReference("quay.io/org/plugin@sha256:abc"))
+}
+
+func newTestIDMS(name, source string, mirrors ...string) *unstructured.Unstructured {
+	targets := make([]interface{}, 0, len(mirrors))
+	for _, m := range mirrors {
+		targets = append(targets, m)
+	}
+	idms := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"imageDigestMirrors": []interface{}{
+				map[string]interface{}{"source": source, "mirrors": targets},
+			},
+		},
+	}}
+	idms.SetAPIVersion("config.openshift.io/v1")
+	idms.SetKind("ImageDigestMirrorSet")
+	idms.SetName(name)
+	return idms
+}
+
+func TestResolveMirroredImage(t *testing.T) {
+	ctx := context.Background()
+	r := newTestReconciler(
+		newTestIDMS("registry", "openshift.io", "mirror.example.com/openshift"),
+		newTestIDMS("repo", "openshift.io/ocp-secrets-management", "local.example.com/plugin", "other.example.com/plugin"),
+	)
+
+	tests := []struct {
+		name  string
+		image string
+		want  string
+	}{
+		{"most specific source wins", "openshift.io/ocp-secrets-management@" + testDigest, "local.example.com/plugin@" + testDigest},
+		{"tag is dropped", "openshift.io/ocp-secrets-management:v1@" + testDigest, "local.example.com/plugin@" + testDigest},
+		{"registry prefix", "openshift.io/other/image@" + testDigest, "mirror.example.com/openshift/other/image@" + testDigest},
+		{"no matching source", "quay.io/example/image@" + testDigest, "quay.io/example/image@" + testDigest},
+		{"tag references are not mirrored", "openshift.io/ocp-secrets-management:latest", "openshift.io/ocp-secrets-management:latest"},
+		{"partial path is not a match", "openshift.io/ocp-secrets-management-extra@" + testDigest, "mirror.example.com/openshift/ocp-secrets-management-extra@" + testDigest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.resolveMirroredImage(ctx, tt.image)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveMirroredImage_ImageContentSourcePolicy(t *testing.T) {
+	ctx := context.Background()
+	icsp := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"repositoryDigestMirrors": []interface{}{
+				map[string]interface{}{
+					"source":  "openshift.io/ocp-secrets-management",
+					"mirrors": []interface{}{"icsp.example.com/plugin"},
+				},
+			},
+		},
+	}}
+	icsp.SetAPIVersion("operator.openshift.io/v1alpha1")
+	icsp.SetKind("ImageContentSourcePolicy")
+	icsp.SetName("legacy")
+	r := newTestReconciler(icsp)
+
+	got, err := r.resolveMirroredImage(ctx, "openshift.io/ocp-secrets-management@"+testDigest)
+	require.NoError(t, err)
+	assert.Equal(t, "icsp.example.com/plugin@"+testDigest, got)
+}
+
+func TestReconcileDeployment_RecordsResolvedImage(t *testing.T) {
+	ctx := context.Background()
+	image := "openshift.io/ocp-secrets-management@" + testDigest
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.Image = image
+	r := newTestReconciler(config, newTestIDMS("repo", "openshift.io/ocp-secrets-management", "local.example.com/plugin"))
+
+	// First pass creates the deployment, second records status
+	require.NoError(t, r.reconcileDeployment(ctx, config))
+	require.NoError(t, r.reconcileDeployment(ctx, config))
+
+	assert.Equal(t, "local.example.com/plugin@"+testDigest, config.Status.Plugin.ResolvedImage)
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, deployment))
+	assert.Equal(t, image, deployment.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestResolvePluginImage_RestrictedIgnoresArchImages(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.Image = "quay.io/example/plugin:v1"
+	config.Spec.Plugin.Images = map[string]string{"arm64": "quay.io/example/plugin:v1-arm64"}
+	r := newTestReconciler(newTestNode("node-1", "arm64"))
+	r.Restricted = true
+
+	image, arch, err := r.resolvePluginImage(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, "quay.io/example/plugin:v1", image)
+	assert.Empty(t, arch)
+}
@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// managedObject is an object the operator is expected to have created for the current spec
+type managedObject struct {
+	kind string
+	obj  client.Object
+}
+
+// reconcileInventory records every object the operator owns in status.managedResources
+func (r *SecretsManagementConfigReconciler) reconcileInventory(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	resources := make([]smv1alpha1.ManagedResource, 0)
+	for _, m := range expectedManagedObjects(config) {
+		if r.Restricted && m.kind == "Namespace" {
+			continue
+		}
+		resource := smv1alpha1.ManagedResource{
+			Kind:      m.kind,
+			Name:      m.obj.GetName(),
+			Namespace: m.obj.GetNamespace(),
+		}
+
+		err := r.Get(ctx, types.NamespacedName{Name: resource.Name, Namespace: resource.Namespace}, m.obj)
+		if err != nil {
+			if !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+				return err
+			}
+			resource.Health = smv1alpha1.ResourceMissing
+			resources = append(resources, resource)
+			continue
+		}
+
+		hash, err := contentHash(m.obj)
+		if err != nil {
+			return err
+		}
+		resource.LastAppliedHash = hash
+		resource.Health = managedObjectHealth(m.obj)
+		resources = append(resources, resource)
+	}
+
+	config.Status.ManagedResources = resources
+	return nil
+}
+
+// expectedManagedObjects returns empty objects, keyed by name, for everything the spec asks the operator to create
+func expectedManagedObjects(config *smv1alpha1.SecretsManagementConfig) []managedObject {
+	inNamespace := func(obj client.Object, name string) client.Object {
+		obj.SetName(name)
+		obj.SetNamespace(PluginNamespace)
+		return obj
+	}
+	unstructuredObject := func(gvk schema.GroupVersionKind, name, namespace string) client.Object {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		u.SetName(name)
+		u.SetNamespace(namespace)
+		return u
+	}
+
+	ns := &corev1.Namespace{}
+	ns.SetName(PluginNamespace)
+	objects := []managedObject{{kind: "Namespace", obj: ns}}
+
+	if config.Spec.Plugin.NamespaceQuota.Enabled && isPrimaryConfig(config) {
+		objects = append(objects,
+			managedObject{kind: "ResourceQuota", obj: inNamespace(&corev1.ResourceQuota{}, fmt.Sprintf("%s-quota", PluginName))},
+			managedObject{kind: "LimitRange", obj: inNamespace(&corev1.LimitRange{}, fmt.Sprintf("%s-limits", PluginName))},
+		)
+	}
+
+	if config.Spec.RBAC.CreateDefaultRoles {
+		prefix := rolePrefix(config)
+		for _, suffix := range []string{"view", "delete", "admin"} {
+			role := &rbacv1.ClusterRole{}
+			role.SetName(fmt.Sprintf("%s-%s", prefix, suffix))
+			objects = append(objects, managedObject{kind: "ClusterRole", obj: role})
+		}
+	}
+
+	if config.Spec.Protection.Enabled && isPrimaryConfig(config) {
+		policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+		policy.SetName(ProtectionPolicyName)
+		binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+		binding.SetName(ProtectionPolicyName)
+		objects = append(objects,
+			managedObject{kind: "ValidatingAdmissionPolicy", obj: policy},
+			managedObject{kind: "ValidatingAdmissionPolicyBinding", obj: binding},
+		)
+	}
+
+	if config.Spec.Features.Delete.BlockProtected && isPrimaryConfig(config) {
+		policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+		policy.SetName(SecretProtectionPolicyName)
+		binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+		binding.SetName(SecretProtectionPolicyName)
+		objects = append(objects,
+			managedObject{kind: "ValidatingAdmissionPolicy", obj: policy},
+			managedObject{kind: "ValidatingAdmissionPolicyBinding", obj: binding},
+		)
+	}
+
+	if config.Spec.Features.ReadOnly.Enabled && config.Spec.Features.ReadOnly.AdmissionPolicy && isPrimaryConfig(config) {
+		policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+		policy.SetName(ReadOnlyPolicyName)
+		binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+		binding.SetName(ReadOnlyPolicyName)
+		objects = append(objects,
+			managedObject{kind: "ValidatingAdmissionPolicy", obj: policy},
+			managedObject{kind: "ValidatingAdmissionPolicyBinding", obj: binding},
+		)
+	}
+
+	if isPrimaryConfig(config) {
+		policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+		policy.SetName(AccessRequestPolicyName)
+		binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+		binding.SetName(AccessRequestPolicyName)
+		objects = append(objects,
+			managedObject{kind: "ValidatingAdmissionPolicy", obj: policy},
+			managedObject{kind: "ValidatingAdmissionPolicyBinding", obj: binding},
+		)
+	}
+
+	if isPrimaryConfig(config) {
+		for _, store := range config.Spec.Stores {
+			objects = append(objects, managedObject{kind: "ClusterSecretStore", obj: unstructuredObject(clusterSecretStoreGVK, store.Name, "")})
+		}
+		for _, issuer := range config.Spec.Issuers {
+			objects = append(objects, managedObject{kind: "ClusterIssuer", obj: unstructuredObject(clusterIssuerGVK, issuer.Name, "")})
+		}
+	}
+
+	objects = append(objects,
+		managedObject{kind: "ServiceAccount", obj: inNamespace(&corev1.ServiceAccount{}, fmt.Sprintf("%s-plugin", instanceName(config)))},
+		managedObject{kind: "Service", obj: inNamespace(&corev1.Service{}, fmt.Sprintf("%s-plugin", instanceName(config)))},
+		managedObject{kind: "ConfigMap", obj: inNamespace(&corev1.ConfigMap{}, fmt.Sprintf("%s-nginx-conf", instanceName(config)))},
+		managedObject{kind: "ConfigMap", obj: inNamespace(&corev1.ConfigMap{}, fmt.Sprintf("%s-runtime-config", instanceName(config)))},
+		managedObject{kind: "Deployment", obj: inNamespace(&appsv1.Deployment{}, fmt.Sprintf("%s-plugin", instanceName(config)))},
+	)
+	if config.Spec.Plugin.InjectTrustedCABundle {
+		objects = append(objects, managedObject{kind: "ConfigMap", obj: inNamespace(&corev1.ConfigMap{}, fmt.Sprintf("%s-trusted-ca-bundle", instanceName(config)))})
+	}
+	if config.Spec.Plugin.Route.Enabled {
+		objects = append(objects, managedObject{kind: "Route", obj: unstructuredObject(routeGVK, fmt.Sprintf("%s-plugin", instanceName(config)), PluginNamespace)})
+	}
+	objects = append(objects, managedObject{kind: "ConsolePlugin", obj: unstructuredObject(consolePluginGVK, instanceName(config), "")})
+
+	return objects
+}
+
+// managedObjectHealth reports whether an existing object is serving
+func managedObjectHealth(obj client.Object) smv1alpha1.ResourceHealth {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		desired := int32(1)
+		if o.Spec.Replicas != nil {
+			desired = *o.Spec.Replicas
+		}
+		if o.Status.ObservedGeneration < o.Generation || o.Status.UpdatedReplicas < desired || o.Status.AvailableReplicas < desired {
+			return smv1alpha1.ResourceProgressing
+		}
+	case *unstructured.Unstructured:
+		if o.GroupVersionKind() == routeGVK && admittedRouteHost(o) == "" {
+			return smv1alpha1.ResourceProgressing
+		}
+	}
+	return smv1alpha1.ResourceHealthy
+}
+
+// contentHash returns a short hash of an object's content, excluding metadata and status
+func contentHash(obj client.Object) (string, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+	delete(content, "metadata")
+	delete(content, "status")
+	delete(content, "apiVersion")
+	delete(content, "kind")
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:16], nil
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func findManagedResource(resources []smv1alpha1.ManagedResource, kind, name string) *smv1alpha1.ManagedResource {
+	for i := range resources {
+		if resources[i].Kind == kind && resources[i].Name == name {
+			return &resources[i]
+		}
+	}
+	return nil
+}
+
+func TestReconcileInventory(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: PluginNamespace}}
+	r := newTestReconciler(config, ns)
+
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	require.NoError(t, r.reconcilePluginDeployment(ctx, config))
+	require.NoError(t, r.reconcileInventory(ctx, config))
+
+	resources := config.Status.ManagedResources
+	assert.Len(t, resources, len(expectedManagedObjects(config)))
+
+	namespace := findManagedResource(resources, "Namespace", PluginNamespace)
+	require.NotNil(t, namespace)
+	assert.Equal(t, smv1alpha1.ResourceHealthy, namespace.Health)
+
+	service := findManagedResource(resources, "Service", "ocp-secrets-management-plugin")
+	require.NotNil(t, service)
+	assert.Equal(t, PluginNamespace, service.Namespace)
+	assert.Equal(t, smv1alpha1.ResourceHealthy, service.Health)
+	assert.Len(t, service.LastAppliedHash, 16)
+
+	// The fake client never rolls out pods
+	deployment := findManagedResource(resources, "Deployment", "ocp-secrets-management-plugin")
+	require.NotNil(t, deployment)
+	assert.Equal(t, smv1alpha1.ResourceProgressing, deployment.Health)
+
+	role := findManagedResource(resources, "ClusterRole", "secrets-management-view")
+	require.NotNil(t, role)
+	assert.Empty(t, role.Namespace)
+	assert.Equal(t, smv1alpha1.ResourceHealthy, role.Health)
+
+	consolePlugin := findManagedResource(resources, "ConsolePlugin", PluginName)
+	require.NotNil(t, consolePlugin)
+	assert.Equal(t, smv1alpha1.ResourceMissing, consolePlugin.Health)
+	assert.Empty(t, consolePlugin.LastAppliedHash)
+}
+
+func TestReconcileInventory_OptionalResources(t *testing.T) {
+	config := newTestConfig("cluster")
+	config.Spec.RBAC.CreateDefaultRoles = false
+	base := len(expectedManagedObjects(config))
+
+	config.Spec.Plugin.NamespaceQuota.Enabled = true
+	config.Spec.Plugin.Route.Enabled = true
+	config.Spec.Plugin.InjectTrustedCABundle = true
+	objects := expectedManagedObjects(config)
+	assert.Len(t, objects, base+4)
+
+	kinds := make(map[string]int)
+	for _, o := range objects {
+		kinds[o.kind]++
+	}
+	assert.Equal(t, 0, kinds["ClusterRole"])
+	assert.Equal(t, 1, kinds["ResourceQuota"])
+	assert.Equal(t, 1, kinds["LimitRange"])
+	assert.Equal(t, 1, kinds["Route"])
+	assert.Equal(t, 3, kinds["ConfigMap"])
+}
+
+func TestContentHash(t *testing.T) {
+	a := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a", ResourceVersion: "1"}, Data: map[string]string{"k": "v"}}
+	b := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "b", ResourceVersion: "2"}, Data: map[string]string{"k": "v"}}
+	c := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Data: map[string]string{"k": "other"}}
+
+	hashA, err := contentHash(a)
+	require.NoError(t, err)
+	hashB, err := contentHash(b)
+	require.NoError(t, err)
+	hashC, err := contentHash(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, hashA, hashB, "metadata must not affect the hash")
+	assert.NotEqual(t, hashA, hashC)
+}
+
+func TestManagedObjectHealth_Deployment(t *testing.T) {
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 3, UpdatedReplicas: 2, AvailableReplicas: 1},
+	}
+	assert.Equal(t, smv1alpha1.ResourceProgressing, managedObjectHealth(deployment))
+
+	deployment.Status.AvailableReplicas = 2
+	assert.Equal(t, smv1alpha1.ResourceHealthy, managedObjectHealth(deployment))
+
+	deployment.Generation = 4
+	assert.Equal(t, smv1alpha1.ResourceProgressing, managedObjectHealth(deployment))
+}
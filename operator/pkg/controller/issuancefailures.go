@@ -0,0 +1,278 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// MaxIssuanceFailures bounds the issuers kept in status.issuanceFailures
+	MaxIssuanceFailures = 50
+
+	// acmeStuckAfter is how long an ACME Order or Challenge may stay pending before it counts as stuck
+	acmeStuckAfter = 30 * time.Minute
+
+	// certificateRevisionAnnotation is set by cert-manager on the CertificateRequests it creates,
+	// to the revision of the Certificate they issue
+	certificateRevisionAnnotation = "cert-manager.io/certificate-revision"
+)
+
+var (
+	certificateRequestGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "CertificateRequest"}
+	acmeOrderGVK          = schema.GroupVersionKind{Group: "acme.cert-manager.io", Version: "v1", Kind: "Order"}
+	acmeChallengeGVK      = schema.GroupVersionKind{Group: "acme.cert-manager.io", Version: "v1", Kind: "Challenge"}
+)
+
+// issuerKey identifies an Issuer or ClusterIssuer referenced by cert-manager resources
+type issuerKey struct {
+	kind, namespace, name string
+}
+
+// issuanceFailures accumulates the summary of one issuer and when its message was reported
+type issuanceFailures struct {
+	summary  smv1alpha1.IssuanceFailureSummary
+	reported time.Time
+}
+
+// reconcileIssuanceFailures summarizes, per issuer, the Certificates whose latest CertificateRequest
+// failed or was denied and the ACME Orders and Challenges stuck pending, so rate limits and
+// failing solvers show up without reading cert-manager's logs. A Warning Event is emitted on the
+// config when an issuer starts failing or reports a new failure. Like the other cluster-wide
+// listings, the summary is not available in restricted mode.
+func (r *SecretsManagementConfigReconciler) reconcileIssuanceFailures(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	if r.Restricted || !config.Status.DetectedOperators.CertManager.Installed {
+		config.Status.IssuanceFailures = nil
+		return nil
+	}
+
+	failures := make(map[issuerKey]*issuanceFailures)
+	record := func(key issuerKey, at time.Time, message string) *smv1alpha1.IssuanceFailureSummary {
+		f := failures[key]
+		if f == nil {
+			f = &issuanceFailures{summary: smv1alpha1.IssuanceFailureSummary{Kind: key.kind, Namespace: key.namespace, Name: key.name}}
+			failures[key] = f
+		}
+		if message != "" && !at.Before(f.reported) {
+			f.summary.Message = message
+			f.reported = at
+		}
+		return &f.summary
+	}
+
+	// Only the latest revision of each Certificate counts, since cert-manager keeps failed
+	// CertificateRequests after a later one succeeds
+	latest := make(map[types.NamespacedName]*unstructured.Unstructured)
+	var standalone []*unstructured.Unstructured
+	err := r.forEachResource(ctx, certificateRequestGVK, func(request *unstructured.Unstructured) {
+		certificate := request.GetAnnotations()[certificateNameAnnotation]
+		if certificate == "" {
+			standalone = append(standalone, request)
+			return
+		}
+		key := types.NamespacedName{Namespace: request.GetNamespace(), Name: certificate}
+		if current, ok := latest[key]; !ok || certificateRevision(request) > certificateRevision(current) {
+			latest[key] = request
+		}
+	})
+	if err != nil {
+		return err
+	}
+	// pendingRequests are the current CertificateRequests still waiting on their Orders
+	pendingRequests := make(map[types.NamespacedName]bool)
+	for _, request := range append(standalone, latestRequests(latest)...) {
+		key := types.NamespacedName{Namespace: request.GetNamespace(), Name: request.GetName()}
+		issuer := issuerRefKey(request)
+		if status, _, message, at := findConditionOf(request, "Denied"); status == string(corev1.ConditionTrue) {
+			record(issuer, at, message).Denied++
+			continue
+		}
+		status, reason, message, at := findConditionOf(request, "Ready")
+		switch {
+		case status == string(corev1.ConditionFalse) && reason == "Failed":
+			record(issuer, at, message).Failed++
+		case status != string(corev1.ConditionTrue):
+			pendingRequests[key] = true
+		}
+	}
+
+	now := time.Now()
+	err = r.forEachResource(ctx, acmeOrderGVK, func(order *unstructured.Unstructured) {
+		// Orders of superseded or issued requests are kept until their request is deleted
+		if owner := ownerOfKind(order, "CertificateRequest"); owner != "" && !pendingRequests[types.NamespacedName{Namespace: order.GetNamespace(), Name: owner}] {
+			return
+		}
+		state, _, _ := unstructured.NestedString(order.Object, "status", "state")
+		created := order.GetCreationTimestamp().Time
+		switch state {
+		case "valid":
+			return
+		case "errored", "invalid", "expired":
+		default:
+			if now.Sub(created) < acmeStuckAfter {
+				return
+			}
+		}
+		reason, _, _ := unstructured.NestedString(order.Object, "status", "reason")
+		at := created
+		if failed, _, _ := unstructured.NestedString(order.Object, "status", "failureTime"); failed != "" {
+			if t, err := time.Parse(time.RFC3339, failed); err == nil {
+				at = t
+			}
+		}
+		record(issuerRefKey(order), at, reason).StuckOrders++
+	})
+	if err != nil {
+		return err
+	}
+	err = r.forEachResource(ctx, acmeChallengeGVK, func(challenge *unstructured.Unstructured) {
+		state, _, _ := unstructured.NestedString(challenge.Object, "status", "state")
+		created := challenge.GetCreationTimestamp().Time
+		if state == "valid" || now.Sub(created) < acmeStuckAfter {
+			return
+		}
+		reason, _, _ := unstructured.NestedString(challenge.Object, "status", "reason")
+		record(issuerRefKey(challenge), created, reason).StuckChallenges++
+	})
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]smv1alpha1.IssuanceFailureSummary, 0, len(failures))
+	for _, f := range failures {
+		summaries = append(summaries, f.summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if ci, cj := issuanceFailureCount(summaries[i]), issuanceFailureCount(summaries[j]); ci != cj {
+			return ci > cj
+		}
+		if summaries[i].Kind != summaries[j].Kind {
+			return summaries[i].Kind < summaries[j].Kind
+		}
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+	if len(summaries) > MaxIssuanceFailures {
+		summaries = summaries[:MaxIssuanceFailures]
+	}
+	r.recordIssuanceFailureEvents(config, summaries)
+	if len(summaries) == 0 {
+		summaries = nil
+	}
+	config.Status.IssuanceFailures = summaries
+	return nil
+}
+
+// recordIssuanceFailureEvents emits a Warning Event for each issuer that started failing or
+// reports a new message since the previous reconcile, and a Normal Event for each that recovered
+func (r *SecretsManagementConfigReconciler) recordIssuanceFailureEvents(config *smv1alpha1.SecretsManagementConfig, summaries []smv1alpha1.IssuanceFailureSummary) {
+	if r.Recorder == nil {
+		return
+	}
+	previous := make(map[issuerKey]smv1alpha1.IssuanceFailureSummary, len(config.Status.IssuanceFailures))
+	for _, summary := range config.Status.IssuanceFailures {
+		previous[issuerKey{kind: summary.Kind, namespace: summary.Namespace, name: summary.Name}] = summary
+	}
+	for _, summary := range summaries {
+		key := issuerKey{kind: summary.Kind, namespace: summary.Namespace, name: summary.Name}
+		before, ok := previous[key]
+		delete(previous, key)
+		if ok && before.Message == summary.Message {
+			continue
+		}
+		r.Recorder.Eventf(config, corev1.EventTypeWarning, "IssuanceFailing",
+			"%s: %d failed and %d denied CertificateRequest(s), %d stuck ACME Order(s) and %d stuck Challenge(s): %s",
+			issuerDisplayName(key), summary.Failed, summary.Denied, summary.StuckOrders, summary.StuckChallenges, valueOr(summary.Message, "no message reported"))
+	}
+	for key := range previous {
+		r.Recorder.Eventf(config, corev1.EventTypeNormal, "IssuanceRecovered", "%s has no failing certificates", issuerDisplayName(key))
+	}
+}
+
+// issuanceFailureCount is the number of failing resources in a summary
+func issuanceFailureCount(summary smv1alpha1.IssuanceFailureSummary) int32 {
+	return summary.Failed + summary.Denied + summary.StuckOrders + summary.StuckChallenges
+}
+
+// issuerDisplayName formats an issuer as Kind namespace/name, or Kind name for ClusterIssuers
+func issuerDisplayName(key issuerKey) string {
+	if key.namespace == "" {
+		return fmt.Sprintf("%s %s", key.kind, key.name)
+	}
+	return fmt.Sprintf("%s %s/%s", key.kind, key.namespace, key.name)
+}
+
+// issuerRefKey returns the issuer in obj's spec.issuerRef; the kind defaults to Issuer, which is
+// namespaced like obj
+func issuerRefKey(obj *unstructured.Unstructured) issuerKey {
+	name, _, _ := unstructured.NestedString(obj.Object, "spec", "issuerRef", "name")
+	kind, _, _ := unstructured.NestedString(obj.Object, "spec", "issuerRef", "kind")
+	if kind == "" {
+		kind = "Issuer"
+	}
+	if kind == "ClusterIssuer" {
+		return issuerKey{kind: kind, name: name}
+	}
+	return issuerKey{kind: kind, namespace: obj.GetNamespace(), name: name}
+}
+
+// certificateRevision returns the revision cert-manager recorded on a CertificateRequest, 0 when unset
+func certificateRevision(request *unstructured.Unstructured) int {
+	revision, _ := strconv.Atoi(request.GetAnnotations()[certificateRevisionAnnotation])
+	return revision
+}
+
+// latestRequests returns the CertificateRequests in a stable order
+func latestRequests(latest map[types.NamespacedName]*unstructured.Unstructured) []*unstructured.Unstructured {
+	requests := make([]*unstructured.Unstructured, 0, len(latest))
+	for _, request := range latest {
+		requests = append(requests, request)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		if requests[i].GetNamespace() != requests[j].GetNamespace() {
+			return requests[i].GetNamespace() < requests[j].GetNamespace()
+		}
+		return requests[i].GetName() < requests[j].GetName()
+	})
+	return requests
+}
+
+// ownerOfKind returns the name of obj's owner of the given kind, or ""
+func ownerOfKind(obj *unstructured.Unstructured, kind string) string {
+	for _, owner := range obj.GetOwnerReferences() {
+		if owner.Kind == kind {
+			return owner.Name
+		}
+	}
+	return ""
+}
+
+// findConditionOf returns the status, reason, message and last transition time of obj's
+// condition of the given type, or empty values when it has none
+func findConditionOf(obj *unstructured.Unstructured, conditionType string) (string, string, string, time.Time) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != conditionType {
+			continue
+		}
+		status, _ := cond["status"].(string)
+		reason, _ := cond["reason"].(string)
+		message, _ := cond["message"].(string)
+		transitioned, _ := cond["lastTransitionTime"].(string)
+		at, _ := time.Parse(time.RFC3339, transitioned)
+		return status, reason, message, at
+	}
+	return "", "", "", time.Time{}
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestCertificateRequest(namespace, name, certificate string, revision int, issuerKind, issuer string, conditions ...interface{}) *unstructured.Unstructured {
+	request := &unstructured.Unstructured{}
+	request.SetGroupVersionKind(certificateRequestGVK)
+	request.SetNamespace(namespace)
+	request.SetName(name)
+	if certificate != "" {
+		request.SetAnnotations(map[string]string{
+			certificateNameAnnotation:     certificate,
+			certificateRevisionAnnotation: strconv.Itoa(revision),
+		})
+	}
+	_ = unstructured.SetNestedMap(request.Object, map[string]interface{}{"name": issuer, "kind": issuerKind}, "spec", "issuerRef")
+	_ = unstructured.SetNestedSlice(request.Object, conditions, "status", "conditions")
+	return request
+}
+
+func newTestACMEResource(gvk schema.GroupVersionKind, namespace, name, issuer, state, reason string, age time.Duration) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-age)))
+	_ = unstructured.SetNestedMap(obj.Object, map[string]interface{}{"name": issuer, "kind": "ClusterIssuer"}, "spec", "issuerRef")
+	_ = unstructured.SetNestedMap(obj.Object, map[string]interface{}{"state": state, "reason": reason}, "status")
+	return obj
+}
+
+func TestReconcileIssuanceFailures(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.CertManager.Installed = true
+	failed := map[string]interface{}{"type": "Ready", "status": "False", "reason": "Failed", "message": "issuer is not ready", "lastTransitionTime": "2026-01-01T00:00:00Z"}
+	ready := map[string]interface{}{"type": "Ready", "status": "True", "reason": "Issued"}
+	denied := map[string]interface{}{"type": "Denied", "status": "True", "reason": "PolicyDenied", "message": "dns name not allowed"}
+	rateLimited := "429 urn:ietf:params:acme:error:rateLimited: too many certificates already issued"
+	erroredOrder := newTestACMEResource(acmeOrderGVK, "web", "shop-1-order", "letsencrypt", "errored", rateLimited, time.Minute)
+	erroredOrder.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "cert-manager.io/v1", Kind: "CertificateRequest", Name: "shop-2", UID: "uid"}})
+	r := newTestReconciler(
+		// Superseded by a later revision
+		newTestCertificateRequest("web", "shop-1", "shop", 1, "ClusterIssuer", "letsencrypt", failed),
+		newTestCertificateRequest("web", "blog-1", "blog", 1, "ClusterIssuer", "letsencrypt", ready),
+		newTestCertificateRequest("web", "blog-2", "blog", 2, "ClusterIssuer", "letsencrypt", failed),
+		newTestCertificateRequest("web", "shop-2", "shop", 2, "ClusterIssuer", "letsencrypt"),
+		newTestCertificateRequest("team-a", "api-1", "api", 1, "", "internal-ca", denied),
+		erroredOrder,
+		newTestACMEResource(acmeChallengeGVK, "web", "shop-challenge", "letsencrypt", "pending", "Waiting for HTTP-01 challenge propagation", time.Hour),
+		// Still within the grace period
+		newTestACMEResource(acmeChallengeGVK, "web", "new-challenge", "letsencrypt", "pending", "", time.Minute),
+	)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	require.NoError(t, r.reconcileIssuanceFailures(ctx, config))
+
+	assert.Equal(t, []smv1alpha1.IssuanceFailureSummary{
+		{Kind: "ClusterIssuer", Name: "letsencrypt", Failed: 1, StuckOrders: 1, StuckChallenges: 1, Message: rateLimited},
+		{Kind: "Issuer", Namespace: "team-a", Name: "internal-ca", Denied: 1, Message: "dns name not allowed"},
+	}, config.Status.IssuanceFailures)
+	require.Len(t, recorder.Events, 2)
+	assert.Contains(t, <-recorder.Events, "Warning IssuanceFailing ClusterIssuer letsencrypt: 1 failed and 0 denied CertificateRequest(s), 1 stuck ACME Order(s) and 1 stuck Challenge(s): 429")
+	assert.Contains(t, <-recorder.Events, "Warning IssuanceFailing Issuer team-a/internal-ca")
+
+	// Unchanged failures are not reported again
+	require.NoError(t, r.reconcileIssuanceFailures(ctx, config))
+	assert.Empty(t, recorder.Events)
+
+	// Deleting the denied request clears its issuer
+	require.NoError(t, r.Delete(ctx, newTestCertificateRequest("team-a", "api-1", "api", 1, "", "internal-ca")))
+	require.NoError(t, r.reconcileIssuanceFailures(ctx, config))
+	require.Len(t, config.Status.IssuanceFailures, 1)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Normal IssuanceRecovered Issuer team-a/internal-ca has no failing certificates")
+}
+
+func TestReconcileIssuanceFailures_CertManagerNotInstalled(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.IssuanceFailures = []smv1alpha1.IssuanceFailureSummary{{Kind: "ClusterIssuer", Name: "letsencrypt", Failed: 1}}
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcileIssuanceFailures(context.Background(), config))
+	assert.Nil(t, config.Status.IssuanceFailures)
+}
@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// DefaultACMEServer is the ACME directory used when an ACME issuer sets no server
+	DefaultACMEServer = "https://acme-v02.api.letsencrypt.org/directory"
+
+	// DefaultACMEIngressClass serves HTTP-01 challenges through the OpenShift router
+	DefaultACMEIngressClass = "openshift-default"
+
+	// VaultIssuerTokenKey is the key of a Vault issuer's token Secret holding the token
+	VaultIssuerTokenKey = "token"
+
+	// issuerWaitingMessage is reported until cert-manager reports an issuer's Ready condition
+	issuerWaitingMessage = "Waiting for cert-manager to verify the issuer"
+)
+
+// ClusterIssuer GroupVersionKind for cert-manager
+var clusterIssuerGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "ClusterIssuer",
+}
+
+// reconcileIssuers creates the ClusterIssuers in spec.issuers, deletes the ones removed from it,
+// and reports whether cert-manager considers each issuer Ready. cert-manager may not be installed
+// when the operator starts, so issuers are not watched; their health is refreshed on every periodic
+// reconcile. The plugin's issuers table shows the same Ready condition as for any other issuer.
+func (r *SecretsManagementConfigReconciler) reconcileIssuers(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	issuers := config.Spec.Issuers
+	if len(issuers) > 0 && !config.Status.DetectedOperators.CertManager.Installed {
+		config.Status.Issuers = nil
+		r.setCondition(config, smv1alpha1.ConditionIssuersReady, "False", smv1alpha1.ReasonCertManagerNotInstalled, "cert-manager is not installed")
+		return nil
+	}
+
+	statuses := make([]smv1alpha1.IssuerStatus, 0, len(issuers))
+	desired := make(map[string]bool, len(issuers))
+	var notReady []string
+	for i := range issuers {
+		issuer := &issuers[i]
+		desired[issuer.Name] = true
+
+		status := smv1alpha1.IssuerStatus{Name: issuer.Name, Type: issuer.Type}
+		message, err := r.applyIssuer(ctx, config, issuer)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				config.Status.Issuers = nil
+				r.setCondition(config, smv1alpha1.ConditionIssuersReady, "False", smv1alpha1.ReasonCertManagerNotInstalled, "cert-manager is not installed")
+				return nil
+			}
+			return err
+		}
+		status.Ready = message == ""
+		status.Message = message
+		if !status.Ready {
+			notReady = append(notReady, fmt.Sprintf("%s: %s", issuer.Name, message))
+		}
+		statuses = append(statuses, status)
+	}
+
+	if err := r.pruneIssuers(ctx, desired); err != nil {
+		return err
+	}
+
+	if len(issuers) == 0 {
+		config.Status.Issuers = nil
+		r.setCondition(config, smv1alpha1.ConditionIssuersReady, "False", smv1alpha1.ReasonIssuersNotConfigured, "No issuers are configured")
+		return nil
+	}
+	config.Status.Issuers = statuses
+
+	if len(notReady) > 0 {
+		sort.Strings(notReady)
+		r.setCondition(config, smv1alpha1.ConditionIssuersReady, "False", smv1alpha1.ReasonIssuerNotReady, strings.Join(notReady, "; "))
+		return nil
+	}
+	r.setCondition(config, smv1alpha1.ConditionIssuersReady, "True", smv1alpha1.ReasonIssuersReady, fmt.Sprintf("%d issuer(s) are ready", len(issuers)))
+	return nil
+}
+
+// applyIssuer creates or updates the ClusterIssuer for issuer and returns why it is not ready,
+// or "" when cert-manager reports it Ready
+func (r *SecretsManagementConfigReconciler) applyIssuer(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, issuer *smv1alpha1.IssuerConfig) (string, error) {
+	spec, msg := clusterIssuerSpec(issuer)
+	if msg != "" {
+		return msg, nil
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(clusterIssuerGVK)
+	err := r.Get(ctx, types.NamespacedName{Name: issuer.Name}, existing)
+	if errors.IsNotFound(err) {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(clusterIssuerGVK)
+		u.SetName(issuer.Name)
+		u.SetLabels(map[string]string{
+			"app.kubernetes.io/part-of":    "ocp-secrets-management",
+			"app.kubernetes.io/managed-by": "secrets-management-operator",
+		})
+		if err := unstructured.SetNestedField(u.Object, spec, "spec"); err != nil {
+			return "", err
+		}
+		applyCommonMetadata(config, u)
+		if err := r.Create(ctx, u); err != nil {
+			return "", err
+		}
+		return issuerWaitingMessage, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if existing.GetLabels()["app.kubernetes.io/managed-by"] != "secrets-management-operator" {
+		return fmt.Sprintf("ClusterIssuer %s already exists and is not managed by the operator", issuer.Name), nil
+	}
+
+	before := existing.DeepCopy()
+	mergeMetadata(existing, commonMetadata(config))
+	current, _, _ := unstructured.NestedMap(existing.Object, "spec")
+	specDrifted := !equality.Semantic.DeepEqual(current, spec)
+	if specDrifted {
+		if err := unstructured.SetNestedField(existing.Object, spec, "spec"); err != nil {
+			return "", err
+		}
+	}
+	if err := updateIfChanged(ctx, r, before, existing); err != nil {
+		return "", err
+	}
+	if specDrifted {
+		return issuerWaitingMessage, nil
+	}
+
+	return readyConditionMessage(existing, issuerWaitingMessage), nil
+}
+
+// clusterIssuerSpec returns the spec of the ClusterIssuer for issuer, or why it cannot be built
+func clusterIssuerSpec(issuer *smv1alpha1.IssuerConfig) (map[string]interface{}, string) {
+	switch issuer.Type {
+	case smv1alpha1.IssuerTypeACME:
+		if issuer.ACME == nil {
+			return nil, "acme is required for ACME issuers"
+		}
+		server := issuer.ACME.Server
+		if server == "" {
+			server = DefaultACMEServer
+		}
+		ingressClass := issuer.ACME.IngressClass
+		if ingressClass == "" {
+			ingressClass = DefaultACMEIngressClass
+		}
+		acme := map[string]interface{}{
+			"server":              server,
+			"privateKeySecretRef": map[string]interface{}{"name": issuer.ACME.PrivateKeySecret},
+			"solvers": []interface{}{
+				map[string]interface{}{"http01": map[string]interface{}{
+					"ingress": map[string]interface{}{"ingressClassName": ingressClass},
+				}},
+			},
+		}
+		if issuer.ACME.Email != "" {
+			acme["email"] = issuer.ACME.Email
+		}
+		return map[string]interface{}{"acme": acme}, ""
+	case smv1alpha1.IssuerTypeCA:
+		if issuer.CA == nil {
+			return nil, "ca is required for CA issuers"
+		}
+		return map[string]interface{}{"ca": map[string]interface{}{
+			"secretName": issuer.CA.SecretName,
+		}}, ""
+	case smv1alpha1.IssuerTypeVault:
+		if issuer.Vault == nil {
+			return nil, "vault is required for Vault issuers"
+		}
+		return map[string]interface{}{"vault": map[string]interface{}{
+			"server": issuer.Vault.Server,
+			"path":   issuer.Vault.Path,
+			"auth": map[string]interface{}{"tokenSecretRef": map[string]interface{}{
+				"name": issuer.Vault.TokenSecret,
+				"key":  VaultIssuerTokenKey,
+			}},
+		}}, ""
+	default:
+		return nil, fmt.Sprintf("unsupported issuer type %q", issuer.Type)
+	}
+}
+
+// pruneIssuers deletes operator-managed ClusterIssuers no longer in spec.issuers
+func (r *SecretsManagementConfigReconciler) pruneIssuers(ctx context.Context, desired map[string]bool) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(clusterIssuerGVK.GroupVersion().WithKind("ClusterIssuerList"))
+	if err := r.List(ctx, list, client.MatchingLabels{"app.kubernetes.io/managed-by": "secrets-management-operator"}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	for i := range list.Items {
+		issuer := &list.Items[i]
+		if desired[issuer.GetName()] {
+			continue
+		}
+		if err := r.Delete(ctx, issuer); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func getClusterIssuer(t *testing.T, r *SecretsManagementConfigReconciler, name string) (*unstructured.Unstructured, error) {
+	t.Helper()
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(clusterIssuerGVK)
+	err := r.Get(context.Background(), types.NamespacedName{Name: name}, u)
+	return u, err
+}
+
+func TestReconcileIssuers_CreatesIssuers(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.CertManager.Installed = true
+	config.Spec.Issuers = []smv1alpha1.IssuerConfig{
+		{Name: "letsencrypt", Type: smv1alpha1.IssuerTypeACME, ACME: &smv1alpha1.ACMEIssuerConfig{Email: "ops@example.com", PrivateKeySecret: "letsencrypt-account"}},
+		{Name: "internal-ca", Type: smv1alpha1.IssuerTypeCA, CA: &smv1alpha1.CAIssuerConfig{SecretName: "internal-ca"}},
+		{Name: "vault", Type: smv1alpha1.IssuerTypeVault, Vault: &smv1alpha1.VaultIssuerConfig{Server: "https://vault.example.com", Path: "pki/sign/example", TokenSecret: "vault-token"}},
+	}
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcileIssuers(ctx, config))
+
+	acme, err := getClusterIssuer(t, r, "letsencrypt")
+	require.NoError(t, err)
+	server, _, _ := unstructured.NestedString(acme.Object, "spec", "acme", "server")
+	assert.Equal(t, DefaultACMEServer, server)
+	solvers, _, _ := unstructured.NestedSlice(acme.Object, "spec", "acme", "solvers")
+	require.Len(t, solvers, 1)
+	ingressClass, _, _ := unstructured.NestedString(solvers[0].(map[string]interface{}), "http01", "ingress", "ingressClassName")
+	assert.Equal(t, DefaultACMEIngressClass, ingressClass)
+
+	ca, err := getClusterIssuer(t, r, "internal-ca")
+	require.NoError(t, err)
+	secretName, _, _ := unstructured.NestedString(ca.Object, "spec", "ca", "secretName")
+	assert.Equal(t, "internal-ca", secretName)
+
+	vault, err := getClusterIssuer(t, r, "vault")
+	require.NoError(t, err)
+	key, _, _ := unstructured.NestedString(vault.Object, "spec", "vault", "auth", "tokenSecretRef", "key")
+	assert.Equal(t, VaultIssuerTokenKey, key)
+
+	require.Len(t, config.Status.Issuers, 3)
+	assert.False(t, config.Status.Issuers[0].Ready)
+	assert.Equal(t, issuerWaitingMessage, config.Status.Issuers[0].Message)
+
+	// cert-manager reports one issuer Ready and one failing
+	require.NoError(t, unstructured.SetNestedSlice(ca.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True", "reason": "KeyPairVerified"},
+	}, "status", "conditions"))
+	require.NoError(t, r.Update(ctx, ca))
+	require.NoError(t, unstructured.SetNestedSlice(vault.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "False", "reason": "VaultError", "message": "permission denied"},
+	}, "status", "conditions"))
+	require.NoError(t, r.Update(ctx, vault))
+
+	require.NoError(t, r.reconcileIssuers(ctx, config))
+	assert.True(t, config.Status.Issuers[1].Ready)
+	assert.False(t, config.Status.Issuers[2].Ready)
+	assert.Equal(t, "permission denied", config.Status.Issuers[2].Message)
+	cond := findCondition(config, smv1alpha1.ConditionIssuersReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonIssuerNotReady, cond.Reason)
+	assert.Contains(t, cond.Message, "vault: permission denied")
+}
+
+func TestReconcileIssuers_InvalidIssuerNotCreated(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.CertManager.Installed = true
+	config.Spec.Issuers = []smv1alpha1.IssuerConfig{
+		{Name: "broken", Type: smv1alpha1.IssuerTypeCA},
+	}
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcileIssuers(context.Background(), config))
+	require.Len(t, config.Status.Issuers, 1)
+	assert.Contains(t, config.Status.Issuers[0].Message, "ca is required")
+
+	_, err := getClusterIssuer(t, r, "broken")
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileIssuers_PrunesRemovedIssuers(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.CertManager.Installed = true
+	config.Spec.Issuers = []smv1alpha1.IssuerConfig{
+		{Name: "internal-ca", Type: smv1alpha1.IssuerTypeCA, CA: &smv1alpha1.CAIssuerConfig{SecretName: "internal-ca"}},
+	}
+	unmanaged := &unstructured.Unstructured{}
+	unmanaged.SetGroupVersionKind(clusterIssuerGVK)
+	unmanaged.SetName("selfsigned")
+	r := newTestReconciler(unmanaged)
+
+	require.NoError(t, r.reconcileIssuers(ctx, config))
+	_, err := getClusterIssuer(t, r, "internal-ca")
+	require.NoError(t, err)
+
+	config.Spec.Issuers = nil
+	require.NoError(t, r.reconcileIssuers(ctx, config))
+	_, err = getClusterIssuer(t, r, "internal-ca")
+	assert.True(t, errors.IsNotFound(err))
+	_, err = getClusterIssuer(t, r, "selfsigned")
+	assert.NoError(t, err)
+
+	cond := findCondition(config, smv1alpha1.ConditionIssuersReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonIssuersNotConfigured, cond.Reason)
+}
+
+func TestReconcileIssuers_CertManagerNotInstalled(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Issuers = []smv1alpha1.IssuerConfig{
+		{Name: "internal-ca", Type: smv1alpha1.IssuerTypeCA, CA: &smv1alpha1.CAIssuerConfig{SecretName: "internal-ca"}},
+	}
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcileIssuers(context.Background(), config))
+	cond := findCondition(config, smv1alpha1.ConditionIssuersReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonCertManagerNotInstalled, cond.Reason)
+	assert.Nil(t, config.Status.Issuers)
+}
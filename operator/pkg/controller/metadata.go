@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// AppliedSpecHashAnnotation records a hash of the content the operator last wrote to an object
+	AppliedSpecHashAnnotation = "secrets-management.openshift.io/applied-spec-hash"
+
+	// CommonLabelsAnnotation lists the spec.commonLabels keys applied to an object, so that
+	// labels removed from the spec are removed from the object
+	CommonLabelsAnnotation = "secrets-management.openshift.io/common-labels"
+
+	// CommonAnnotationsAnnotation lists the spec.commonAnnotations keys applied to an object
+	CommonAnnotationsAnnotation = "secrets-management.openshift.io/common-annotations"
+)
+
+// applyCommonMetadata adds spec.commonLabels, the backup tier labels of spec.backup.velero and
+// spec.commonAnnotations to a desired object, recording the keys it added for mergeMetadata.
+// Labels and annotations the operator sets itself take precedence.
+func applyCommonMetadata(config *smv1alpha1.SecretsManagementConfig, obj metav1.Object) {
+	labels, appliedLabels := withDefaults(obj.GetLabels(), commonLabels(config))
+	annotations, appliedAnnotations := withDefaults(obj.GetAnnotations(), config.Spec.CommonAnnotations)
+	if appliedLabels != "" {
+		annotations[CommonLabelsAnnotation] = appliedLabels
+	}
+	if appliedAnnotations != "" {
+		annotations[CommonAnnotationsAnnotation] = appliedAnnotations
+	}
+	// Empty maps would show up in unstructured objects
+	if len(labels) > 0 {
+		obj.SetLabels(labels)
+	}
+	if len(annotations) > 0 {
+		obj.SetAnnotations(annotations)
+	}
+}
+
+// commonMetadata returns metadata holding only the common labels and annotations, for objects
+// whose other metadata the operator does not manage
+func commonMetadata(config *smv1alpha1.SecretsManagementConfig) *metav1.ObjectMeta {
+	meta := &metav1.ObjectMeta{}
+	applyCommonMetadata(config, meta)
+	return meta
+}
+
+// withDefaults returns a copy of values with the entries of defaults it does not set, and the
+// sorted keys of those entries, comma separated. The copy matters when values is shared with the
+// spec, as for service accounts.
+func withDefaults(values, defaults map[string]string) (map[string]string, string) {
+	merged := make(map[string]string, len(values)+len(defaults))
+	for k, v := range values {
+		merged[k] = v
+	}
+	var added []string
+	for k, v := range defaults {
+		if _, ok := merged[k]; !ok {
+			merged[k] = v
+			added = append(added, k)
+		}
+	}
+	sort.Strings(added)
+	return merged, strings.Join(added, ",")
+}
+
+// mergeMetadata sets the labels and annotations of desired on existing. Labels and annotations
+// added by others are kept, notably Argo CD's tracking label and its argocd.argoproj.io
+// annotations for sync waves, sync options and compare options. Common labels and annotations
+// applied earlier but no longer desired are removed.
+func mergeMetadata(existing, desired metav1.Object) {
+	existing.SetLabels(mergeTracked(existing.GetLabels(), desired.GetLabels(), existing.GetAnnotations()[CommonLabelsAnnotation]))
+	annotations := mergeTracked(existing.GetAnnotations(), desired.GetAnnotations(), existing.GetAnnotations()[CommonAnnotationsAnnotation])
+	for _, tracking := range []string{CommonLabelsAnnotation, CommonAnnotationsAnnotation} {
+		if _, ok := desired.GetAnnotations()[tracking]; !ok {
+			delete(annotations, tracking)
+		}
+	}
+	existing.SetAnnotations(annotations)
+}
+
+// mergeTracked returns current with every entry of desired set, and without the comma separated
+// keys in applied that desired no longer sets
+func mergeTracked(current, desired map[string]string, applied string) map[string]string {
+	if applied == "" {
+		return mergeStringMaps(current, desired)
+	}
+	merged := make(map[string]string, len(current)+len(desired))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for _, key := range strings.Split(applied, ",") {
+		delete(merged, key)
+	}
+	for k, v := range desired {
+		merged[k] = v
+	}
+	return merged
+}
+
+// mergeStringMaps returns current with every entry of desired set
+func mergeStringMaps(current, desired map[string]string) map[string]string {
+	if len(desired) == 0 {
+		return current
+	}
+	merged := make(map[string]string, len(current)+len(desired))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range desired {
+		merged[k] = v
+	}
+	return merged
+}
+
+// setAppliedSpecHash records on a desired object a hash of its content, for specChanged
+func setAppliedSpecHash(desired client.Object) error {
+	// contentHash drops the metadata of the object it is given, which for unstructured objects
+	// is not a copy
+	hash, err := contentHash(desired.DeepCopyObject().(client.Object))
+	if err != nil {
+		return err
+	}
+	annotations := desired.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AppliedSpecHashAnnotation] = hash
+	desired.SetAnnotations(annotations)
+	return nil
+}
+
+// specChanged reports whether desiredSpec must be written over existingSpec: either it differs
+// from the spec last applied, or a field it sets was changed on the cluster. Fields desiredSpec
+// leaves unset are not compared, since the API server fills them with defaults; comparing whole
+// specs would rewrite the object on every reconcile.
+func specChanged(existing, desired metav1.Object, existingSpec, desiredSpec interface{}) bool {
+	return existing.GetAnnotations()[AppliedSpecHashAnnotation] != desired.GetAnnotations()[AppliedSpecHashAnnotation] ||
+		!equality.Semantic.DeepDerivative(desiredSpec, existingSpec)
+}
+
+// unchangedRecorder is implemented by writers that track objects already in their desired state,
+// for DesiredObjects
+type unchangedRecorder interface {
+	recordUnchanged(obj client.Object)
+}
+
+// updateIfChanged updates existing unless it still equals before, its state when read, so that
+// unchanged objects keep their resourceVersion and GitOps tools see no churn. Writers that report
+// updates are given the change first.
+func updateIfChanged(ctx context.Context, c client.Writer, before, existing client.Object) error {
+	if equality.Semantic.DeepEqual(before, existing) {
+		if recorder, ok := c.(unchangedRecorder); ok {
+			recorder.recordUnchanged(existing)
+		}
+		return nil
+	}
+	if reporter, ok := c.(updateReporter); ok {
+		reporter.reportUpdate(before, existing)
+	}
+	return c.Update(ctx, existing)
+}
@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func reconcileTestConfig(t *testing.T, r *SecretsManagementConfigReconciler) {
+	t.Helper()
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: SingletonConfigName}})
+	require.NoError(t, err)
+}
+
+func TestCommonAnnotations_AppliedToManagedObjects(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.CommonAnnotations = map[string]string{
+		"argocd.argoproj.io/sync-wave": "1",
+		// The operator's own annotations take precedence
+		"service.alpha.openshift.io/serving-cert-secret-name": "other",
+	}
+	r := newTestReconciler(config)
+	reconcileTestConfig(t, r)
+
+	ctx := context.Background()
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, deployment))
+	assert.Equal(t, "1", deployment.Annotations["argocd.argoproj.io/sync-wave"])
+	assert.NotEmpty(t, deployment.Annotations[AppliedSpecHashAnnotation])
+
+	svc := &corev1.Service{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, svc))
+	assert.Equal(t, "1", svc.Annotations["argocd.argoproj.io/sync-wave"])
+	assert.Equal(t, "ocp-secrets-management-plugin-cert", svc.Annotations["service.alpha.openshift.io/serving-cert-secret-name"])
+
+	role := &rbacv1.ClusterRole{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-view"}, role))
+	assert.Equal(t, "1", role.Annotations["argocd.argoproj.io/sync-wave"])
+
+	// The spec is not modified
+	assert.Len(t, config.Spec.CommonAnnotations, 2)
+}
+
+func TestMergeMetadata_PreservesForeignMetadata(t *testing.T) {
+	r := newTestReconciler(newTestConfig(SingletonConfigName))
+	reconcileTestConfig(t, r)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}
+	svc := &corev1.Service{}
+	require.NoError(t, r.Get(ctx, key, svc))
+	svc.Labels["app.kubernetes.io/instance"] = "secrets-management"
+	svc.Annotations["argocd.argoproj.io/compare-options"] = "IgnoreExtraneous"
+	require.NoError(t, r.Update(ctx, svc))
+
+	reconcileTestConfig(t, r)
+
+	require.NoError(t, r.Get(ctx, key, svc))
+	assert.Equal(t, "secrets-management", svc.Labels["app.kubernetes.io/instance"])
+	assert.Equal(t, "IgnoreExtraneous", svc.Annotations["argocd.argoproj.io/compare-options"])
+	assert.Equal(t, "secrets-management-operator", svc.Labels["app.kubernetes.io/managed-by"])
+}
+
+func TestReconcile_LeavesUnchangedObjectsAlone(t *testing.T) {
+	r := newTestReconciler(newTestConfig(SingletonConfigName))
+	reconcileTestConfig(t, r)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, key, deployment))
+	svc := &corev1.Service{}
+	require.NoError(t, r.Get(ctx, key, svc))
+	role := &rbacv1.ClusterRole{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-view"}, role))
+
+	// A field the API server defaults does not make the spec differ
+	deployment.Spec.RevisionHistoryLimit = int32Ptr(10)
+	require.NoError(t, r.Update(ctx, deployment))
+	deploymentVersion := deployment.ResourceVersion
+
+	reconcileTestConfig(t, r)
+
+	require.NoError(t, r.Get(ctx, key, deployment))
+	assert.Equal(t, deploymentVersion, deployment.ResourceVersion)
+	assert.Equal(t, int32(10), *deployment.Spec.RevisionHistoryLimit)
+	versions := []string{svc.ResourceVersion, role.ResourceVersion}
+	require.NoError(t, r.Get(ctx, key, svc))
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-view"}, role))
+	assert.Equal(t, versions, []string{svc.ResourceVersion, role.ResourceVersion})
+
+	// A managed field changed on the cluster is restored
+	deployment.Spec.Replicas = int32Ptr(5)
+	require.NoError(t, r.Update(ctx, deployment))
+	reconcileTestConfig(t, r)
+	require.NoError(t, r.Get(ctx, key, deployment))
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+}
+
+func TestReconcile_SkipsServiceAndConfigMapUpdatesWithoutChanges(t *testing.T) {
+	r := newTestReconciler(newTestConfig(SingletonConfigName))
+	reconcileTestConfig(t, r)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}
+	svc := &corev1.Service{}
+	require.NoError(t, r.Get(ctx, key, svc))
+	assert.NotEmpty(t, svc.Annotations[AppliedSpecHashAnnotation])
+	cm := &corev1.ConfigMap{}
+	cmKey := types.NamespacedName{Name: "ocp-secrets-management-nginx-conf", Namespace: PluginNamespace}
+	require.NoError(t, r.Get(ctx, cmKey, cm))
+	assert.NotEmpty(t, cm.Annotations[AppliedSpecHashAnnotation])
+
+	// Fields the API server fills in do not make the spec differ
+	svc.Spec.ClusterIP = "172.30.0.10"
+	svc.Spec.ClusterIPs = []string{"172.30.0.10"}
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	require.NoError(t, r.Update(ctx, svc))
+	versions := []string{svc.ResourceVersion, cm.ResourceVersion}
+
+	reconcileTestConfig(t, r)
+
+	require.NoError(t, r.Get(ctx, key, svc))
+	require.NoError(t, r.Get(ctx, cmKey, cm))
+	assert.Equal(t, versions, []string{svc.ResourceVersion, cm.ResourceVersion})
+	assert.Equal(t, "172.30.0.10", svc.Spec.ClusterIP)
+
+	// Managed content changed on the cluster is restored
+	nginxConf := cm.Data["nginx.conf"]
+	cm.Data["nginx.conf"] = "events {}"
+	require.NoError(t, r.Update(ctx, cm))
+	svc.Spec.Ports[0].Port = 8443
+	require.NoError(t, r.Update(ctx, svc))
+
+	reconcileTestConfig(t, r)
+
+	require.NoError(t, r.Get(ctx, key, svc))
+	require.NoError(t, r.Get(ctx, cmKey, cm))
+	assert.Equal(t, nginxConf, cm.Data["nginx.conf"])
+	assert.Equal(t, int32(9443), svc.Spec.Ports[0].Port)
+}
+
+func TestCommonLabels_ReconciledOnChange(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.CommonLabels = map[string]string{
+		"cost-center": "platform",
+		"backup":      "daily",
+		// The operator's own labels take precedence
+		"app.kubernetes.io/managed-by": "someone-else",
+	}
+	config.Spec.CommonAnnotations = map[string]string{"owner": "team-a"}
+	r := newTestReconciler(config)
+	reconcileTestConfig(t, r)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, key, deployment))
+	assert.Equal(t, "platform", deployment.Labels["cost-center"])
+	assert.Equal(t, "secrets-management-operator", deployment.Labels["app.kubernetes.io/managed-by"])
+	assert.Equal(t, "platform", deployment.Spec.Template.Labels["cost-center"])
+	assert.NotContains(t, deployment.Spec.Selector.MatchLabels, "cost-center")
+
+	ns := &corev1.Namespace{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: PluginNamespace}, ns))
+	assert.Equal(t, "daily", ns.Labels["backup"])
+	role := &rbacv1.ClusterRole{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-admin"}, role))
+	assert.Equal(t, "daily", role.Labels["backup"])
+	plugin := &unstructured.Unstructured{}
+	plugin.SetGroupVersionKind(consolePluginGVK)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management"}, plugin))
+	assert.Equal(t, "platform", plugin.GetLabels()["cost-center"])
+
+	// Another controller labels the Service
+	svc := &corev1.Service{}
+	require.NoError(t, r.Get(ctx, key, svc))
+	svc.Labels["team"] = "payments"
+	require.NoError(t, r.Update(ctx, svc))
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config))
+	config.Spec.CommonLabels = map[string]string{"cost-center": "security"}
+	config.Spec.CommonAnnotations = nil
+	require.NoError(t, r.Update(ctx, config))
+	reconcileTestConfig(t, r)
+
+	require.NoError(t, r.Get(ctx, key, svc))
+	assert.Equal(t, "security", svc.Labels["cost-center"])
+	assert.NotContains(t, svc.Labels, "backup")
+	assert.Equal(t, "payments", svc.Labels["team"])
+	assert.Equal(t, "secrets-management-operator", svc.Labels["app.kubernetes.io/managed-by"])
+	assert.NotContains(t, svc.Annotations, "owner")
+	assert.NotContains(t, svc.Annotations, CommonAnnotationsAnnotation)
+	assert.Equal(t, "cost-center", svc.Annotations[CommonLabelsAnnotation])
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: PluginNamespace}, ns))
+	assert.NotContains(t, ns.Labels, "backup")
+	assert.Equal(t, "secrets-management-operator", ns.Labels["app.kubernetes.io/managed-by"])
+	require.NoError(t, r.Get(ctx, key, deployment))
+	assert.NotContains(t, deployment.Spec.Template.Labels, "backup")
+}
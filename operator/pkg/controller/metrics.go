@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// csiMountFailures is the number of pods per namespace whose SecretProviderClasses the CSI driver has not mounted
+var csiMountFailures = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "secrets_management_csi_mount_failures",
+		Help: "Number of pods pending because the Secrets Store CSI driver has not mounted their SecretProviderClasses, by namespace",
+	},
+	[]string{"namespace"},
+)
+
+// pushSecretsFailing is the number of PushSecrets whose Ready condition is False
+var pushSecretsFailing = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "secrets_management_pushsecrets_failing",
+		Help: "Number of External Secrets Operator PushSecrets failing to push to their external store",
+	},
+)
+
+// clusterExternalSecretLaggingNamespaces is the number of namespaces per ClusterExternalSecret
+// that failed to provision or whose ExternalSecret reports Ready False
+var clusterExternalSecretLaggingNamespaces = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "secrets_management_clusterexternalsecret_lagging_namespaces",
+		Help: "Number of namespaces targeted by a ClusterExternalSecret that failed to provision or whose ExternalSecret is not syncing",
+	},
+	[]string{"name"},
+)
+
+// vaultUp is 1 for each probed Vault server that is reachable, unsealed and accepts the store tokens
+var vaultUp = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "secrets_management_vault_up",
+		Help: "Whether a Vault server used by ClusterSecretStores or SecretProviderClasses is reachable, unsealed and accepts their tokens",
+	},
+	[]string{"server"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(csiMountFailures, pushSecretsFailing, clusterExternalSecretLaggingNamespaces, vaultUp)
+}
@@ -0,0 +1,366 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// reconcileNamespaceQuota ensures the plugin namespace ResourceQuota and LimitRange match
+// spec.plugin.namespaceQuota, removing them when the quota is disabled
+func (r *SecretsManagementConfigReconciler) reconcileNamespaceQuota(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	quotaConfig := config.Spec.Plugin.NamespaceQuota
+	if !quotaConfig.Enabled {
+		return r.cleanupNamespaceQuota(ctx, config)
+	}
+
+	defaults, err := containerDefaults(quotaConfig)
+	if err != nil {
+		return err
+	}
+	canary, err := r.canaryConfig(ctx)
+	if err != nil {
+		return err
+	}
+	quota, err := buildResourceQuota(config, canary, defaults)
+	if err != nil {
+		return err
+	}
+	limitRange := buildLimitRange(defaults)
+
+	applyCommonMetadata(config, quota)
+	applyCommonMetadata(config, limitRange)
+
+	existingQuota := &corev1.ResourceQuota{}
+	err = r.Get(ctx, types.NamespacedName{Name: quota.Name, Namespace: quota.Namespace}, existingQuota)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := r.Create(ctx, quota); err != nil {
+			return err
+		}
+	} else {
+		before := existingQuota.DeepCopy()
+		mergeMetadata(existingQuota, quota)
+		existingQuota.Spec = quota.Spec
+		if err := updateIfChanged(ctx, r, before, existingQuota); err != nil {
+			return err
+		}
+	}
+
+	existingLimitRange := &corev1.LimitRange{}
+	err = r.Get(ctx, types.NamespacedName{Name: limitRange.Name, Namespace: limitRange.Namespace}, existingLimitRange)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return r.Create(ctx, limitRange)
+		}
+		return err
+	}
+
+	before := existingLimitRange.DeepCopy()
+	mergeMetadata(existingLimitRange, limitRange)
+	existingLimitRange.Spec = limitRange.Spec
+	return updateIfChanged(ctx, r, before, existingLimitRange)
+}
+
+// canaryConfig returns the canary config, or nil when there is none or it is being deleted
+func (r *SecretsManagementConfigReconciler) canaryConfig(ctx context.Context) (*smv1alpha1.SecretsManagementConfig, error) {
+	canary := &smv1alpha1.SecretsManagementConfig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: CanaryConfigName}, canary); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !canary.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	return canary, nil
+}
+
+// primaryConfigForCanary enqueues the primary config when the canary changes, as the quota it
+// owns makes room for the canary plugin
+func primaryConfigForCanary(_ context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetName() != CanaryConfigName {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: SingletonConfigName}}}
+}
+
+// operatorReplicas is the number of replicas of the operator Deployment, which runs in the plugin
+// namespace. The manifests in config/manager and bundle/manifests are checked against it.
+const operatorReplicas = 2
+
+// operatorPodResources are the requests and limits of an operator pod; the manifests are checked
+// against them too
+var operatorPodResources = corev1.ResourceRequirements{
+	Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("128Mi")},
+	Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+}
+
+// quotaWorkload is a workload in the plugin namespace: the most pods it runs at once and the
+// containers of each
+type quotaWorkload struct {
+	pods       int64
+	containers []corev1.Container
+}
+
+// namespaceWorkloads returns the workloads the default quota makes room for: the plugin, and its
+// canary instance when canary is not nil, the operator and the smcctl downloads, each while a
+// rolling update surges it, and the compliance scan and backup CronJobs, which run one pod at a time
+func namespaceWorkloads(config, canary *smv1alpha1.SecretsManagementConfig) ([]quotaWorkload, error) {
+	plugin, err := pluginWorkload(config)
+	if err != nil {
+		return nil, err
+	}
+	operatorPods, err := surgedPods(operatorReplicas, appsv1.DeploymentStrategy{})
+	if err != nil {
+		return nil, err
+	}
+	downloads := buildCLIDownloadsDeployment(nil)
+	downloadsPods, err := surgedPods(*downloads.Spec.Replicas, downloads.Spec.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := []quotaWorkload{
+		plugin,
+		{pods: operatorPods, containers: []corev1.Container{{Resources: operatorPodResources}}},
+		{pods: downloadsPods, containers: downloads.Spec.Template.Spec.Containers},
+		{pods: 1, containers: buildOperatorCronJob(ComplianceScanName, "scan", "", ScanFlag, false, nil).Spec.JobTemplate.Spec.Template.Spec.Containers},
+		{pods: 1, containers: buildOperatorCronJob(BackupName, "backup", "", BackupFlag, false, nil).Spec.JobTemplate.Spec.Template.Spec.Containers},
+	}
+	if canary != nil {
+		canaryPlugin, err := pluginWorkload(canary)
+		if err != nil {
+			return nil, fmt.Errorf("canary config: %w", err)
+		}
+		workloads = append(workloads, canaryPlugin)
+	}
+	return workloads, nil
+}
+
+// pluginWorkload returns the plugin instance config deploys, sized by its replicas, strategy and
+// resources
+func pluginWorkload(config *smv1alpha1.SecretsManagementConfig) (quotaWorkload, error) {
+	replicas := pluginReplicas(config)
+	strategy, err := buildDeploymentStrategy(config.Spec.Plugin.Strategy, replicas)
+	if err != nil {
+		return quotaWorkload{}, err
+	}
+	pods, err := surgedPods(replicas, strategy)
+	if err != nil {
+		return quotaWorkload{}, err
+	}
+	resources, err := pluginResources(config)
+	if err != nil {
+		return quotaWorkload{}, err
+	}
+	return quotaWorkload{pods: pods, containers: []corev1.Container{{Resources: resources}}}, nil
+}
+
+// surgedPods returns the most pods a Deployment with replicas and strategy runs during a rolling
+// update. An unset maxSurge is the API server's default of 25%.
+func surgedPods(replicas int32, strategy appsv1.DeploymentStrategy) (int64, error) {
+	if strategy.Type == appsv1.RecreateDeploymentStrategyType {
+		return int64(replicas), nil
+	}
+	maxSurge := intstr.FromString("25%")
+	if strategy.RollingUpdate != nil && strategy.RollingUpdate.MaxSurge != nil {
+		maxSurge = *strategy.RollingUpdate.MaxSurge
+	}
+	surge, err := intstr.GetScaledValueFromIntOrPercent(&maxSurge, int(replicas), true)
+	if err != nil {
+		return 0, fmt.Errorf("spec.plugin.strategy.maxSurge: %w", err)
+	}
+	return int64(replicas) + int64(surge), nil
+}
+
+// defaultQuota returns the pods and the total requests and limits of workloads. Values a
+// container leaves unset are counted at the LimitRange defaults, as admission fills them in.
+func defaultQuota(workloads []quotaWorkload, defaults corev1.ResourceRequirements) corev1.ResourceList {
+	hard := corev1.ResourceList{}
+	add := func(name corev1.ResourceName, q resource.Quantity, times int64) {
+		for i := int64(0); i < times; i++ {
+			total, ok := hard[name]
+			if !ok {
+				hard[name] = q.DeepCopy()
+				continue
+			}
+			total.Add(q)
+			hard[name] = total
+		}
+	}
+
+	var pods int64
+	for _, workload := range workloads {
+		pods += workload.pods
+		for _, container := range workload.containers {
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				request, ok := container.Resources.Requests[name]
+				if !ok {
+					request = defaults.Requests[name]
+				}
+				limit, ok := container.Resources.Limits[name]
+				if !ok {
+					limit = defaults.Limits[name]
+				}
+				add(corev1.ResourceName("requests."+name), request, workload.pods)
+				add(corev1.ResourceName("limits."+name), limit, workload.pods)
+			}
+		}
+	}
+	hard[corev1.ResourcePods] = *resource.NewQuantity(pods, resource.DecimalSI)
+	return hard
+}
+
+// buildResourceQuota creates the plugin namespace ResourceQuota. Totals that are not configured
+// default to what every workload in the namespace needs at once, see namespaceWorkloads.
+func buildResourceQuota(config, canary *smv1alpha1.SecretsManagementConfig, defaults corev1.ResourceRequirements) (*corev1.ResourceQuota, error) {
+	cfg := config.Spec.Plugin.NamespaceQuota
+	workloads, err := namespaceWorkloads(config, canary)
+	if err != nil {
+		return nil, err
+	}
+	hard := defaultQuota(workloads, defaults)
+	if cfg.Pods != 0 {
+		hard[corev1.ResourcePods] = *resource.NewQuantity(int64(cfg.Pods), resource.DecimalSI)
+	}
+
+	quantities := []struct {
+		field string
+		name  corev1.ResourceName
+		value string
+	}{
+		{"spec.plugin.namespaceQuota.requests.cpu", corev1.ResourceRequestsCPU, cfg.Requests.CPU},
+		{"spec.plugin.namespaceQuota.requests.memory", corev1.ResourceRequestsMemory, cfg.Requests.Memory},
+		{"spec.plugin.namespaceQuota.limits.cpu", corev1.ResourceLimitsCPU, cfg.Limits.CPU},
+		{"spec.plugin.namespaceQuota.limits.memory", corev1.ResourceLimitsMemory, cfg.Limits.Memory},
+	}
+	for _, q := range quantities {
+		if q.value == "" {
+			continue
+		}
+		parsed, err := resource.ParseQuantity(q.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid quantity %q: %w", q.field, q.value, err)
+		}
+		hard[q.name] = parsed
+	}
+
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-quota", PluginName),
+			Namespace: PluginNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       PluginName,
+				"app.kubernetes.io/part-of":    "ocp-secrets-management",
+				"app.kubernetes.io/managed-by": "secrets-management-operator",
+			},
+		},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: hard,
+		},
+	}, nil
+}
+
+// containerDefaults returns the requests and limits the LimitRange gives containers that do not
+// set their own; they match the plugin container defaults
+func containerDefaults(cfg smv1alpha1.NamespaceQuotaConfig) (corev1.ResourceRequirements, error) {
+	defaults := cfg.ContainerDefaults
+
+	requestCPU, err := parseQuantityOrDefault("spec.plugin.namespaceQuota.containerDefaults.requests.cpu", defaults.Requests.CPU, "10m")
+	if err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+	requestMemory, err := parseQuantityOrDefault("spec.plugin.namespaceQuota.containerDefaults.requests.memory", defaults.Requests.Memory, "50Mi")
+	if err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+	limitCPU, err := parseQuantityOrDefault("spec.plugin.namespaceQuota.containerDefaults.limits.cpu", defaults.Limits.CPU, "100m")
+	if err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+	limitMemory, err := parseQuantityOrDefault("spec.plugin.namespaceQuota.containerDefaults.limits.memory", defaults.Limits.Memory, "128Mi")
+	if err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: requestCPU, corev1.ResourceMemory: requestMemory},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: limitCPU, corev1.ResourceMemory: limitMemory},
+	}, nil
+}
+
+// buildLimitRange creates the plugin namespace LimitRange, so pods without explicit resources
+// still fit the quota
+func buildLimitRange(defaults corev1.ResourceRequirements) *corev1.LimitRange {
+	return &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-limits", PluginName),
+			Namespace: PluginNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       PluginName,
+				"app.kubernetes.io/part-of":    "ocp-secrets-management",
+				"app.kubernetes.io/managed-by": "secrets-management-operator",
+			},
+		},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type:           corev1.LimitTypeContainer,
+					DefaultRequest: defaults.Requests,
+					Default:        defaults.Limits,
+				},
+			},
+		},
+	}
+}
+
+// cleanupNamespaceQuota removes the plugin namespace ResourceQuota and LimitRange
+func (r *SecretsManagementConfigReconciler) cleanupNamespaceQuota(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-quota", PluginName),
+			Namespace: PluginNamespace,
+		},
+	}
+	if err := r.Delete(ctx, quota); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-limits", PluginName),
+			Namespace: PluginNamespace,
+		},
+	}
+	if err := r.Delete(ctx, limitRange); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// parseQuantityOrDefault parses val as a resource quantity, using def when val is empty
+func parseQuantityOrDefault(fieldName, val, def string) (resource.Quantity, error) {
+	if val == "" {
+		val = def
+	}
+	q, err := resource.ParseQuantity(val)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("%s: invalid quantity %q: %w", fieldName, val, err)
+	}
+	return q, nil
+}
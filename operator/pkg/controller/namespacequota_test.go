@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestReconcileNamespaceQuota(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.NamespaceQuota = smv1alpha1.NamespaceQuotaConfig{
+		Enabled: true,
+		Pods:    4,
+		Limits:  smv1alpha1.ResourceRequirements{Memory: "1Gi"},
+	}
+	r := newTestReconciler()
+
+	// Create namespace first
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: PluginNamespace},
+	}
+	err := r.Create(ctx, ns)
+	require.NoError(t, err)
+
+	err = r.reconcileNamespaceQuota(ctx, config)
+	require.NoError(t, err)
+
+	// Verify quota uses configured values and defaults
+	quota := &corev1.ResourceQuota{}
+	err = r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-quota", Namespace: PluginNamespace}, quota)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), quota.Spec.Hard.Pods().Value())
+	assert.True(t, resource.MustParse("1Gi").Equal(quota.Spec.Hard[corev1.ResourceLimitsMemory]))
+	assert.True(t, resource.MustParse("550m").Equal(quota.Spec.Hard[corev1.ResourceRequestsCPU]))
+
+	// Verify limit range defaults match the plugin container defaults
+	limitRange := &corev1.LimitRange{}
+	err = r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-limits", Namespace: PluginNamespace}, limitRange)
+	require.NoError(t, err)
+	require.Len(t, limitRange.Spec.Limits, 1)
+	assert.True(t, resource.MustParse("10m").Equal(limitRange.Spec.Limits[0].DefaultRequest[corev1.ResourceCPU]))
+	assert.True(t, resource.MustParse("128Mi").Equal(limitRange.Spec.Limits[0].Default[corev1.ResourceMemory]))
+
+	// Update
+	config.Spec.Plugin.NamespaceQuota.Pods = 6
+	err = r.reconcileNamespaceQuota(ctx, config)
+	require.NoError(t, err)
+	err = r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-quota", Namespace: PluginNamespace}, quota)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), quota.Spec.Hard.Pods().Value())
+
+	// A canary config makes room for its plugin instance
+	require.NoError(t, r.Create(ctx, newTestConfig(CanaryConfigName)))
+	err = r.reconcileNamespaceQuota(ctx, config)
+	require.NoError(t, err)
+	err = r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-quota", Namespace: PluginNamespace}, quota)
+	require.NoError(t, err)
+	assert.True(t, resource.MustParse("580m").Equal(quota.Spec.Hard[corev1.ResourceRequestsCPU]))
+
+	// Disabling removes both objects
+	config.Spec.Plugin.NamespaceQuota.Enabled = false
+	err = r.reconcileNamespaceQuota(ctx, config)
+	require.NoError(t, err)
+	err = r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-quota", Namespace: PluginNamespace}, quota)
+	assert.True(t, apierrors.IsNotFound(err))
+	err = r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-limits", Namespace: PluginNamespace}, limitRange)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestBuildResourceQuota_Defaults(t *testing.T) {
+	defaults, err := containerDefaults(smv1alpha1.NamespaceQuotaConfig{})
+	require.NoError(t, err)
+	hard := func(config *smv1alpha1.SecretsManagementConfig) corev1.ResourceList {
+		t.Helper()
+		quota, err := buildResourceQuota(config, nil, defaults)
+		require.NoError(t, err)
+		return quota.Spec.Hard
+	}
+
+	// 3 plugin pods while they surge, 3 operator pods, 2 smcctl download pods and one pod per
+	// CronJob, whose containers leave the CPU limit to the LimitRange
+	quota := hard(newTestConfig(SingletonConfigName))
+	assert.Equal(t, int64(10), quota.Pods().Value())
+	for name, want := range map[corev1.ResourceName]string{
+		corev1.ResourceRequestsCPU:    "550m",
+		corev1.ResourceRequestsMemory: "854Mi",
+		corev1.ResourceLimitsCPU:      "1300m",
+		corev1.ResourceLimitsMemory:   "2432Mi",
+	} {
+		assert.True(t, resource.MustParse(want).Equal(quota[name]), "%s: want %s, got %s", name, want, quota.Name(name, resource.DecimalSI))
+	}
+
+	// The plugin share follows its replicas, strategy and resources
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Plugin.Replicas = 4
+	config.Spec.Plugin.Strategy.Type = "Recreate"
+	config.Spec.Plugin.Resources.Requests.Memory = "100Mi"
+	quota = hard(config)
+	assert.Equal(t, int64(11), quota.Pods().Value())
+	assert.True(t, resource.MustParse("1104Mi").Equal(quota[corev1.ResourceRequestsMemory]), quota.Name(corev1.ResourceRequestsMemory, resource.BinarySI).String())
+
+	// A canary adds its own instance, sized by its own config
+	canary := newTestConfig(CanaryConfigName)
+	canary.Spec.Plugin.Replicas = 1
+	canary.Spec.Plugin.Strategy.Type = "Recreate"
+	withCanary, err := buildResourceQuota(newTestConfig(SingletonConfigName), canary, defaults)
+	require.NoError(t, err)
+	quota = withCanary.Spec.Hard
+	assert.Equal(t, int64(11), quota.Pods().Value())
+	assert.True(t, resource.MustParse("904Mi").Equal(quota[corev1.ResourceRequestsMemory]), quota.Name(corev1.ResourceRequestsMemory, resource.BinarySI).String())
+}
+
+// The quota counts the operator at operatorReplicas and operatorPodResources, so the manifests
+// that deploy it must agree
+func TestOperatorManifestsMatchQuota(t *testing.T) {
+	manager, err := os.ReadFile("../../config/manager/manager.yaml")
+	require.NoError(t, err)
+	var deployments []appsv1.DeploymentSpec
+	for _, doc := range strings.Split(string(manager), "\n---\n") {
+		deployment := &appsv1.Deployment{}
+		require.NoError(t, yaml.Unmarshal([]byte(doc), deployment))
+		if deployment.Kind == "Deployment" {
+			deployments = append(deployments, deployment.Spec)
+		}
+	}
+	require.Len(t, deployments, 1, "config/manager/manager.yaml")
+
+	bundle, err := os.ReadFile("../../bundle/manifests/secrets-management-operator.clusterserviceversion.yaml")
+	require.NoError(t, err)
+	var csv struct {
+		Spec struct {
+			Install struct {
+				Spec struct {
+					Deployments []struct {
+						Spec appsv1.DeploymentSpec `json:"spec"`
+					} `json:"deployments"`
+				} `json:"spec"`
+			} `json:"install"`
+		} `json:"spec"`
+	}
+	require.NoError(t, yaml.Unmarshal(bundle, &csv))
+	require.Len(t, csv.Spec.Install.Spec.Deployments, 1, "bundle CSV")
+	deployments = append(deployments, csv.Spec.Install.Spec.Deployments[0].Spec)
+
+	for _, spec := range deployments {
+		require.NotNil(t, spec.Replicas)
+		assert.Equal(t, int32(operatorReplicas), *spec.Replicas)
+		assert.Equal(t, appsv1.DeploymentStrategy{}, spec.Strategy, "the quota assumes the default rolling update")
+		require.Len(t, spec.Template.Spec.Containers, 1)
+		resources := spec.Template.Spec.Containers[0].Resources
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			assert.True(t, operatorPodResources.Requests[name].Equal(resources.Requests[name]), "%s request: %s", name, resources.Requests.Name(name, resource.DecimalSI))
+			assert.True(t, operatorPodResources.Limits[name].Equal(resources.Limits[name]), "%s limit: %s", name, resources.Limits.Name(name, resource.DecimalSI))
+		}
+	}
+}
+
+func TestReconcileNamespaceQuota_InvalidQuantity(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.NamespaceQuota = smv1alpha1.NamespaceQuotaConfig{
+		Enabled:  true,
+		Requests: smv1alpha1.ResourceRequirements{CPU: "lots"},
+	}
+	r := newTestReconciler()
+
+	err := r.reconcileNamespaceQuota(ctx, config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.plugin.namespaceQuota.requests.cpu")
+}
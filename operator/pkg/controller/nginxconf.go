@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+
+	corev1 "k8s.io/api/core/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// nginxConfTemplate renders the plugin's nginx.conf from nginxConfData
+var nginxConfTemplate = template.Must(template.New("nginx.conf").Parse(`
+{{.ErrorLog}}events {}
+http {
+{{.AccessLog}}  include /etc/nginx/mime.types;
+  default_type application/octet-stream;
+  server {
+{{.Listen}}    ssl_certificate {{.CertFile}};
+    ssl_certificate_key {{.KeyFile}};
+    root /usr/share/nginx/html;
+{{- range .SecurityHeaders}}
+    {{.}}
+{{- end}}
+
+    # Serve plugin manifest at / so the console gets a valid manifest when fetching basePath
+    location = / {
+      add_header Content-Type application/json;
+{{- range .SecurityHeaders}}
+      {{.}}
+{{- end}}
+      alias /usr/share/nginx/html/plugin-manifest.json;
+    }
+    location = /plugin-manifest.json {
+      add_header Content-Type application/json;
+{{- range .SecurityHeaders}}
+      {{.}}
+{{- end}}
+    }
+
+    location /health {
+      return 200 'OK';
+      add_header Content-Type text/plain;
+{{- range .SecurityHeaders}}
+      {{.}}
+{{- end}}
+    }
+{{- with .ServerConfigOverride}}
+
+    # spec.plugin.serverConfigOverride
+{{.}}
+{{- end}}
+  }
+{{- with .MetricsListen}}
+  server {
+{{.}}
+    location = /metrics {
+      stub_status;
+    }
+    location /health {
+      return 200 'OK';
+      add_header Content-Type text/plain;
+    }
+  }
+{{- end}}
+}
+`))
+
+// nginxConfData holds the values nginxConfTemplate is rendered with
+type nginxConfData struct {
+	ErrorLog  string
+	AccessLog string
+	// Listen and MetricsListen are listen directives; no metrics server is rendered without the latter
+	Listen        string
+	MetricsListen string
+	CertFile      string
+	KeyFile       string
+	// ServerConfigOverride is appended to the plugin server block, indented
+	ServerConfigOverride string
+	// SecurityHeaders are add_header directives. nginx only inherits add_header into locations
+	// that set none themselves, so they are repeated in each of those.
+	SecurityHeaders []string
+}
+
+// renderNginxConfig returns the plugin's nginx.conf for config, listening on families
+func renderNginxConfig(config *smv1alpha1.SecretsManagementConfig, families []corev1.IPFamily) (string, error) {
+	plugin := config.Spec.Plugin
+	override, err := nginxServerConfigOverride(plugin.ServerConfigOverride)
+	if err != nil {
+		return "", err
+	}
+	data := nginxConfData{
+		ErrorLog:             nginxErrorLog(plugin.Logging),
+		AccessLog:            nginxAccessLog(plugin.Logging),
+		Listen:               nginxListen(pluginPort(config), "ssl", families),
+		CertFile:             "/var/cert/" + corev1.TLSCertKey,
+		KeyFile:              "/var/cert/" + corev1.TLSPrivateKeyKey,
+		ServerConfigOverride: override,
+	}
+	data.SecurityHeaders, err = nginxSecurityHeaders(plugin.SecurityHeaders)
+	if err != nil {
+		return "", err
+	}
+	if plugin.MetricsPort != 0 {
+		data.MetricsListen = nginxListen(plugin.MetricsPort, "", families)
+	}
+	var b strings.Builder
+	if err := nginxConfTemplate.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// nginxServerConfigOverride returns spec.plugin.serverConfigOverride indented for the server
+// block. Unbalanced braces would close the server block early or swallow the rest of the file, so
+// they are rejected rather than breaking every plugin pod.
+func nginxServerConfigOverride(override string) (string, error) {
+	override = strings.TrimSpace(override)
+	if override == "" {
+		return "", nil
+	}
+	depth := 0
+	for _, c := range override {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+		if depth < 0 {
+			break
+		}
+	}
+	if depth != 0 {
+		return "", fmt.Errorf("spec.plugin.serverConfigOverride: unbalanced braces")
+	}
+	lines := strings.Split(override, "\n")
+	for i, line := range lines {
+		if line = strings.TrimRight(line, " \t"); line != "" {
+			lines[i] = "    " + line
+		} else {
+			lines[i] = ""
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// nginxSecurityHeaders returns the add_header directives for spec.plugin.securityHeaders. always
+// sends them with error responses too, which security scanners also check.
+func nginxSecurityHeaders(headers smv1alpha1.PluginSecurityHeadersConfig) ([]string, error) {
+	var directives []string
+	for _, header := range []struct{ name, field, value string }{
+		{"Content-Security-Policy", "contentSecurityPolicy", headers.ContentSecurityPolicy},
+		{"X-Frame-Options", "frameOptions", headers.FrameOptions},
+		{"Strict-Transport-Security", "strictTransportSecurity", headers.StrictTransportSecurity},
+	} {
+		if header.value == "" {
+			continue
+		}
+		if strings.ContainsFunc(header.value, unicode.IsControl) {
+			return nil, fmt.Errorf("spec.plugin.securityHeaders.%s: control characters are not allowed", header.field)
+		}
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(header.value)
+		directives = append(directives, fmt.Sprintf(`add_header %s "%s" always;`, header.name, value))
+	}
+	return directives, nil
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestRenderNginxConfig(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	conf, err := renderNginxConfig(config, nil)
+	require.NoError(t, err)
+	assert.Contains(t, conf, "    listen 9443 ssl;\n    ssl_certificate /var/cert/tls.crt;\n    ssl_certificate_key /var/cert/tls.key;\n")
+	assert.Contains(t, conf, "error_log /dev/stdout info;")
+	assert.NotContains(t, conf, "stub_status")
+	assert.NotContains(t, conf, "serverConfigOverride")
+
+	config.Spec.Plugin.MetricsPort = 9090
+	conf, err = renderNginxConfig(config, nil)
+	require.NoError(t, err)
+	assert.Contains(t, conf, "    listen 9090;\n")
+	assert.Contains(t, conf, "stub_status;")
+}
+
+func TestRenderNginxConfig_ServerConfigOverride(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Plugin.ServerConfigOverride = "location /branding/ {\n  alias /opt/branding/;\n}\n"
+	conf, err := renderNginxConfig(config, nil)
+	require.NoError(t, err)
+	// The locations land inside the plugin server block, before it closes
+	assert.Contains(t, conf, "    # spec.plugin.serverConfigOverride\n    location /branding/ {\n      alias /opt/branding/;\n    }\n  }\n}\n")
+
+	for _, override := range []string{"location /x {", "}", "} location /x {"} {
+		config.Spec.Plugin.ServerConfigOverride = override
+		_, err := renderNginxConfig(config, nil)
+		assert.ErrorContains(t, err, "unbalanced braces", override)
+	}
+}
+
+func TestReconcileNginxConfig_RestoresManagedConfig(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler(config)
+	hash, err := r.reconcileNginxConfig(ctx, config)
+	require.NoError(t, err)
+
+	// Hand edits are replaced on the next loop
+	key := types.NamespacedName{Name: "ocp-secrets-management-nginx-conf", Namespace: PluginNamespace}
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(ctx, key, cm))
+	cm.Data["nginx.conf"] = "events {}"
+	require.NoError(t, r.Update(ctx, cm))
+	restored, err := r.reconcileNginxConfig(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, hash, restored)
+	require.NoError(t, r.Get(ctx, key, cm))
+	assert.Equal(t, hash, nginxConfigHash(cm.Data["nginx.conf"]))
+
+	// The override changes the hash, rolling out the plugin pods
+	config.Spec.Plugin.ServerConfigOverride = "location /x { return 204; }"
+	changed, err := r.reconcileNginxConfig(ctx, config)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+}
+
+func TestRenderNginxConfig_SecurityHeaders(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Plugin.SecurityHeaders = smv1alpha1.PluginSecurityHeadersConfig{
+		ContentSecurityPolicy:   `default-src 'self'; report-uri "https://csp.example.com"`,
+		FrameOptions:            "DENY",
+		StrictTransportSecurity: "max-age=31536000; includeSubDomains",
+	}
+	conf, err := renderNginxConfig(config, nil)
+	require.NoError(t, err)
+	csp := `add_header Content-Security-Policy "default-src 'self'; report-uri \"https://csp.example.com\"" always;`
+	assert.Contains(t, conf, "    root /usr/share/nginx/html;\n    "+csp+"\n    add_header X-Frame-Options \"DENY\" always;\n")
+	// Repeated in every location that sets headers of its own, which would not inherit them
+	assert.Equal(t, 4, strings.Count(conf, csp))
+	assert.Equal(t, 4, strings.Count(conf, `add_header Strict-Transport-Security "max-age=31536000; includeSubDomains" always;`))
+
+	config.Spec.Plugin.SecurityHeaders = smv1alpha1.PluginSecurityHeadersConfig{FrameOptions: "SAMEORIGIN"}
+	conf, err = renderNginxConfig(config, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, conf, "Content-Security-Policy")
+
+	config.Spec.Plugin.SecurityHeaders = smv1alpha1.PluginSecurityHeadersConfig{ContentSecurityPolicy: "default-src 'self';\n  return 200"}
+	_, err = renderNginxConfig(config, nil)
+	assert.ErrorContains(t, err, "spec.plugin.securityHeaders.contentSecurityPolicy")
+}
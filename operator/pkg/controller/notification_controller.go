@@ -0,0 +1,553 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// NotificationURLKey is the key of a receiver's URL Secret holding the webhook URL
+	NotificationURLKey = "url"
+
+	// DefaultNotificationInterval is how often Certificates and ExternalSecrets are checked
+	DefaultNotificationInterval = 2 * time.Minute
+
+	// DefaultExternalSecretFailureDuration is how long an ExternalSecret stays not Ready before
+	// receivers are notified, unless spec.notifications.externalSecretFailureDuration is set
+	DefaultExternalSecretFailureDuration = 15 * time.Minute
+
+	// DefaultNotificationsPerHour is a receiver's rate limit when maxPerHour is unset
+	DefaultNotificationsPerHour = 20
+
+	// notificationTimeout bounds a single request to a receiver
+	notificationTimeout = 10 * time.Second
+)
+
+// DefaultCertificateExpiryThresholds are used when spec.notifications.certificateExpiryThresholds is empty
+var DefaultCertificateExpiryThresholds = []time.Duration{30 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour}
+
+var (
+	certificateListGVK = schema.GroupVersionKind{
+		Group:   "cert-manager.io",
+		Version: "v1",
+		Kind:    "CertificateList",
+	}
+	externalSecretListGVK = schema.GroupVersionKind{
+		Group:   "external-secrets.io",
+		Version: "v1beta1",
+		Kind:    "ExternalSecretList",
+	}
+)
+
+// notification is one situation receivers are told about
+type notification struct {
+	// key identifies the situation so it is sent to each receiver once while it lasts
+	key       string
+	event     smv1alpha1.NotificationEvent
+	namespace string
+	name      string
+	title     string
+	message   string
+}
+
+// receiverState tracks what was sent to one receiver since the operator started
+type receiverState struct {
+	// sentTimes are the send times within the last hour, oldest first
+	sentTimes []time.Time
+	// handled holds the notification keys sent or suppressed, so they are not sent again
+	handled map[string]bool
+
+	sent       int64
+	suppressed int64
+	lastSent   time.Time
+	lastError  string
+}
+
+// NotificationReconciler notifies the receivers in spec.notifications when Certificates cross
+// expiry thresholds, ExternalSecrets stay not Ready, or the config becomes Degraded. Each
+// situation is sent to a receiver once while it lasts, and receivers are rate limited per hour.
+type NotificationReconciler struct {
+	client.Client
+	Log logr.Logger
+
+	// APIReader reads Certificates and ExternalSecrets in workload namespaces and the receivers'
+	// URL Secrets, none of which are cached
+	APIReader client.Reader
+
+	// HTTPClient sends notifications; a client with a 10s timeout is used when nil
+	HTTPClient *http.Client
+
+	// Interval is how often Certificates and ExternalSecrets are checked
+	Interval time.Duration
+
+	// Restricted skips Certificates and ExternalSecrets, which cannot be listed cluster-wide
+	Restricted bool
+
+	mu        sync.Mutex
+	receivers map[string]*receiverState
+}
+
+// Reconcile sends pending notifications for the primary config
+func (r *NotificationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if req.Name != SingletonConfigName {
+		return ctrl.Result{}, nil
+	}
+
+	config := &smv1alpha1.SecretsManagementConfig{}
+	if err := r.Get(ctx, req.NamespacedName, config); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !config.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	original := config.DeepCopy()
+
+	receivers := config.Spec.Notifications.Receivers
+	if len(receivers) == 0 {
+		r.mu.Lock()
+		r.receivers = nil
+		r.mu.Unlock()
+		config.Status.NotificationReceivers = nil
+		return ctrl.Result{}, r.patchStatus(ctx, original, config)
+	}
+
+	notifications, err := r.collect(ctx, config)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.receivers == nil {
+		r.receivers = map[string]*receiverState{}
+	}
+
+	statuses := make([]smv1alpha1.NotificationReceiverStatus, 0, len(receivers))
+	configured := make(map[string]bool, len(receivers))
+	for i := range receivers {
+		receiver := &receivers[i]
+		configured[receiver.Name] = true
+		state, ok := r.receivers[receiver.Name]
+		if !ok {
+			state = &receiverState{handled: map[string]bool{}}
+			r.receivers[receiver.Name] = state
+		}
+		if err := r.notifyReceiver(ctx, receiver, state, notifications); err != nil {
+			return ctrl.Result{}, err
+		}
+		statuses = append(statuses, state.status(receiver.Name))
+	}
+	for name := range r.receivers {
+		if !configured[name] {
+			delete(r.receivers, name)
+		}
+	}
+	config.Status.NotificationReceivers = statuses
+
+	if err := r.patchStatus(ctx, original, config); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.interval()}, nil
+}
+
+// notifyReceiver sends the notifications the receiver accepts and has not been sent yet. A
+// missing URL Secret or failed send is reported in status and retried on the next check.
+func (r *NotificationReconciler) notifyReceiver(ctx context.Context, receiver *smv1alpha1.NotificationReceiver, state *receiverState, notifications []notification) error {
+	current := make(map[string]bool, len(notifications))
+	for _, n := range notifications {
+		current[n.key] = true
+	}
+	// Forget situations that have cleared so they are sent again if they recur
+	for key := range state.handled {
+		if !current[key] {
+			delete(state.handled, key)
+		}
+	}
+
+	var pending []notification
+	for _, n := range notifications {
+		if !state.handled[n.key] && receiverAccepts(receiver, n) {
+			pending = append(pending, n)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	url, err := r.readReceiverURL(ctx, receiver.URLSecret)
+	if err != nil {
+		return err
+	}
+	if url == "" {
+		state.lastError = fmt.Sprintf("Secret %s/%s has no %q key", PluginNamespace, receiver.URLSecret, NotificationURLKey)
+		return nil
+	}
+
+	limit := int(receiver.MaxPerHour)
+	if limit <= 0 {
+		limit = DefaultNotificationsPerHour
+	}
+	for _, n := range pending {
+		now := time.Now()
+		state.pruneSentTimes(now)
+		if len(state.sentTimes) >= limit {
+			state.suppressed++
+			state.handled[n.key] = true
+			continue
+		}
+		if err := r.send(ctx, receiver.Type, url, n); err != nil {
+			state.lastError = err.Error()
+			r.Log.Error(err, "Failed to send notification", "receiver", receiver.Name, "event", n.event)
+			// Remaining notifications are retried on the next check
+			return nil
+		}
+		state.sentTimes = append(state.sentTimes, now)
+		state.handled[n.key] = true
+		state.sent++
+		state.lastSent = now
+		state.lastError = ""
+	}
+	return nil
+}
+
+// receiverAccepts applies the receiver's event and namespace filters
+func receiverAccepts(receiver *smv1alpha1.NotificationReceiver, n notification) bool {
+	if len(receiver.Events) > 0 {
+		found := false
+		for _, e := range receiver.Events {
+			if e == n.event {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(receiver.Namespaces) > 0 && n.namespace != "" {
+		for _, ns := range receiver.Namespaces {
+			if ns == n.namespace {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// pruneSentTimes drops send times older than an hour
+func (s *receiverState) pruneSentTimes(now time.Time) {
+	cutoff := now.Add(-time.Hour)
+	i := 0
+	for i < len(s.sentTimes) && !s.sentTimes[i].After(cutoff) {
+		i++
+	}
+	s.sentTimes = s.sentTimes[i:]
+}
+
+func (s *receiverState) status(name string) smv1alpha1.NotificationReceiverStatus {
+	status := smv1alpha1.NotificationReceiverStatus{
+		Name:       name,
+		Sent:       s.sent,
+		Suppressed: s.suppressed,
+		LastError:  s.lastError,
+	}
+	if !s.lastSent.IsZero() {
+		t := metav1.NewTime(s.lastSent)
+		status.LastSentTime = &t
+	}
+	return status
+}
+
+// collect returns the current situations receivers should know about, in a stable order
+func (r *NotificationReconciler) collect(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) ([]notification, error) {
+	var notifications []notification
+
+	if config.Status.Phase == smv1alpha1.PhaseDegraded {
+		message := config.Status.LastError
+		if message == "" {
+			message = "The secrets management plugin is Degraded"
+		}
+		notifications = append(notifications, notification{
+			key:     "degraded",
+			event:   smv1alpha1.NotificationPluginDegraded,
+			name:    config.Name,
+			title:   "Secrets management plugin is Degraded",
+			message: message,
+		})
+	}
+
+	if r.Restricted {
+		return notifications, nil
+	}
+
+	now := time.Now()
+	if config.Spec.Operators.CertManager.Enabled {
+		certs, err := r.certificateNotifications(ctx, config, now)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, certs...)
+	}
+	if config.Spec.Operators.ExternalSecrets.Enabled {
+		failing, err := r.externalSecretNotifications(ctx, config, now)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, failing...)
+	}
+	return notifications, nil
+}
+
+// certificateNotifications reports Certificates whose remaining validity is below a threshold.
+// Only the smallest threshold crossed is reported, keyed by notAfter so a renewed certificate
+// starts over.
+func (r *NotificationReconciler) certificateNotifications(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, now time.Time) ([]notification, error) {
+	thresholds := make([]time.Duration, 0, len(config.Spec.Notifications.CertificateExpiryThresholds))
+	for _, t := range config.Spec.Notifications.CertificateExpiryThresholds {
+		if t.Duration > 0 {
+			thresholds = append(thresholds, t.Duration)
+		}
+	}
+	if len(thresholds) == 0 {
+		thresholds = DefaultCertificateExpiryThresholds
+	}
+
+	certs := &unstructured.UnstructuredList{}
+	certs.SetGroupVersionKind(certificateListGVK)
+	if err := r.apiReader().List(ctx, certs); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var notifications []notification
+	for _, cert := range certs.Items {
+		value, _, _ := unstructured.NestedString(cert.Object, "status", "notAfter")
+		notAfter, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			continue
+		}
+		remaining := notAfter.Sub(now)
+
+		var crossed time.Duration
+		for _, t := range thresholds {
+			if remaining < t && (crossed == 0 || t < crossed) {
+				crossed = t
+			}
+		}
+		if crossed == 0 {
+			continue
+		}
+
+		message := fmt.Sprintf("Certificate %s/%s expires at %s (in %s)", cert.GetNamespace(), cert.GetName(), value, remaining.Round(time.Minute))
+		if remaining <= 0 {
+			message = fmt.Sprintf("Certificate %s/%s expired at %s", cert.GetNamespace(), cert.GetName(), value)
+		}
+		notifications = append(notifications, notification{
+			key:       fmt.Sprintf("certificate/%s/%s/%s/%s", cert.GetNamespace(), cert.GetName(), value, crossed),
+			event:     smv1alpha1.NotificationCertificateExpiring,
+			namespace: cert.GetNamespace(),
+			name:      cert.GetName(),
+			title:     fmt.Sprintf("Certificate %s/%s expires within %s", cert.GetNamespace(), cert.GetName(), crossed),
+			message:   message,
+		})
+	}
+	sortNotifications(notifications)
+	return notifications, nil
+}
+
+// externalSecretNotifications reports ExternalSecrets that have not been Ready for longer than
+// the configured duration, keyed by the condition's transition time so a new failure is reported again
+func (r *NotificationReconciler) externalSecretNotifications(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, now time.Time) ([]notification, error) {
+	failureDuration := DefaultExternalSecretFailureDuration
+	if d := config.Spec.Notifications.ExternalSecretFailureDuration; d != nil && d.Duration > 0 {
+		failureDuration = d.Duration
+	}
+
+	externalSecrets := &unstructured.UnstructuredList{}
+	externalSecrets.SetGroupVersionKind(externalSecretListGVK)
+	if err := r.apiReader().List(ctx, externalSecrets); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var notifications []notification
+	for _, es := range externalSecrets.Items {
+		conditions, _, _ := unstructured.NestedSlice(es.Object, "status", "conditions")
+		for _, c := range conditions {
+			cond, ok := c.(map[string]interface{})
+			if !ok || cond["type"] != "Ready" || cond["status"] != string(corev1.ConditionFalse) {
+				continue
+			}
+			since, _ := cond["lastTransitionTime"].(string)
+			transition, err := time.Parse(time.RFC3339, since)
+			if err != nil || now.Sub(transition) < failureDuration {
+				continue
+			}
+			reason, _ := cond["reason"].(string)
+			detail, _ := cond["message"].(string)
+			notifications = append(notifications, notification{
+				key:       fmt.Sprintf("externalsecret/%s/%s/%s", es.GetNamespace(), es.GetName(), since),
+				event:     smv1alpha1.NotificationExternalSecretFailing,
+				namespace: es.GetNamespace(),
+				name:      es.GetName(),
+				title:     fmt.Sprintf("ExternalSecret %s/%s is failing to sync", es.GetNamespace(), es.GetName()),
+				message:   fmt.Sprintf("ExternalSecret %s/%s has not been Ready since %s: %s %s", es.GetNamespace(), es.GetName(), since, reason, detail),
+			})
+		}
+	}
+	sortNotifications(notifications)
+	return notifications, nil
+}
+
+func sortNotifications(notifications []notification) {
+	sort.Slice(notifications, func(i, j int) bool { return notifications[i].key < notifications[j].key })
+}
+
+// readReceiverURL returns the URL in a Secret in the plugin namespace, or "" when the Secret or
+// key is missing
+func (r *NotificationReconciler) readReceiverURL(ctx context.Context, name string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.apiReader().Get(ctx, types.NamespacedName{Namespace: PluginNamespace, Name: name}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(secret.Data[NotificationURLKey])), nil
+}
+
+// send posts n to the receiver in its message format
+func (r *NotificationReconciler) send(ctx context.Context, receiverType smv1alpha1.NotificationReceiverType, url string, n notification) error {
+	body, err := json.Marshal(notificationPayload(receiverType, n))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: notificationTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// notificationPayload formats n for Slack or Teams incoming webhooks, or as plain JSON
+func notificationPayload(receiverType smv1alpha1.NotificationReceiverType, n notification) interface{} {
+	switch receiverType {
+	case smv1alpha1.NotificationReceiverSlack:
+		return map[string]interface{}{
+			"text": fmt.Sprintf("*%s*\n%s", n.title, n.message),
+		}
+	case smv1alpha1.NotificationReceiverTeams:
+		return map[string]interface{}{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    n.title,
+			"themeColor": "D83B01",
+			"title":      n.title,
+			"text":       n.message,
+		}
+	default:
+		return map[string]interface{}{
+			"event":     n.event,
+			"namespace": n.namespace,
+			"name":      n.name,
+			"title":     n.title,
+			"message":   n.message,
+			"time":      time.Now().UTC().Format(time.RFC3339),
+		}
+	}
+}
+
+// patchStatus writes the receiver statuses with a merge patch, leaving the rest of the status
+// to the SecretsManagementConfig controller
+func (r *NotificationReconciler) patchStatus(ctx context.Context, original, config *smv1alpha1.SecretsManagementConfig) error {
+	if equality.Semantic.DeepEqual(original.Status.NotificationReceivers, config.Status.NotificationReceivers) {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Status().Patch(ctx, config, client.MergeFrom(original))
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+func (r *NotificationReconciler) interval() time.Duration {
+	if r.Interval > 0 {
+		return r.Interval
+	}
+	return DefaultNotificationInterval
+}
+
+// apiReader returns the uncached reader, falling back to the cached client
+func (r *NotificationReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// SetupWithManager sets up the controller with the Manager. Besides spec changes, the primary
+// config is reconciled when its phase changes so Degraded is reported promptly.
+func (r *NotificationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("notifications").
+		For(&smv1alpha1.SecretsManagementConfig{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldConfig, ok := e.ObjectOld.(*smv1alpha1.SecretsManagementConfig)
+				newConfig, ok2 := e.ObjectNew.(*smv1alpha1.SecretsManagementConfig)
+				if !ok || !ok2 {
+					return false
+				}
+				return oldConfig.Generation != newConfig.Generation || oldConfig.Status.Phase != newConfig.Status.Phase
+			},
+		})).
+		Complete(r)
+}
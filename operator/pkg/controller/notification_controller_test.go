@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// receiverServer records the JSON bodies posted to it
+type receiverServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	bodies []map[string]interface{}
+}
+
+func newReceiverServer(t *testing.T) *receiverServer {
+	s := &receiverServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		s.mu.Lock()
+		s.bodies = append(s.bodies, body)
+		s.mu.Unlock()
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *receiverServer) received() []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]map[string]interface{}(nil), s.bodies...)
+}
+
+func newTestNotificationReconciler(objs ...client.Object) *NotificationReconciler {
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithObjects(objs...).
+		WithStatusSubresource(&smv1alpha1.SecretsManagementConfig{}).
+		Build()
+
+	return &NotificationReconciler{
+		Client: fakeClient,
+		Log:    logr.Discard(),
+	}
+}
+
+func newTestURLSecret(name, url string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: PluginNamespace},
+		Data:       map[string][]byte{NotificationURLKey: []byte(url)},
+	}
+}
+
+func newTestCertificate(namespace, name string, notAfter time.Time) *unstructured.Unstructured {
+	cert := &unstructured.Unstructured{}
+	cert.SetAPIVersion("cert-manager.io/v1")
+	cert.SetKind("Certificate")
+	cert.SetNamespace(namespace)
+	cert.SetName(name)
+	_ = unstructured.SetNestedField(cert.Object, notAfter.UTC().Format(time.RFC3339), "status", "notAfter")
+	return cert
+}
+
+func newTestExternalSecret(namespace, name string, notReadySince time.Time) *unstructured.Unstructured {
+	es := &unstructured.Unstructured{}
+	es.SetAPIVersion("external-secrets.io/v1beta1")
+	es.SetKind("ExternalSecret")
+	es.SetNamespace(namespace)
+	es.SetName(name)
+	_ = unstructured.SetNestedSlice(es.Object, []interface{}{
+		map[string]interface{}{
+			"type":               "Ready",
+			"status":             "False",
+			"reason":             "SecretSyncedError",
+			"message":            "could not get secret data from provider",
+			"lastTransitionTime": notReadySince.UTC().Format(time.RFC3339),
+		},
+	}, "status", "conditions")
+	return es
+}
+
+func reconcileNotifications(t *testing.T, r *NotificationReconciler) *smv1alpha1.SecretsManagementConfig {
+	t.Helper()
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: SingletonConfigName}})
+	require.NoError(t, err)
+
+	config := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: SingletonConfigName}, config))
+	return config
+}
+
+func TestNotifications_CertificateExpiry(t *testing.T) {
+	server := newReceiverServer(t)
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Notifications.Receivers = []smv1alpha1.NotificationReceiver{
+		{Name: "slack", Type: smv1alpha1.NotificationReceiverSlack, URLSecret: "slack-url"},
+	}
+	r := newTestNotificationReconciler(
+		config,
+		newTestURLSecret("slack-url", server.URL),
+		newTestCertificate("app", "expiring", time.Now().Add(12*time.Hour)),
+		newTestCertificate("app", "healthy", time.Now().Add(90*24*time.Hour)),
+	)
+
+	config = reconcileNotifications(t, r)
+	bodies := server.received()
+	require.Len(t, bodies, 1)
+	assert.Contains(t, bodies[0]["text"], "app/expiring")
+	assert.Contains(t, bodies[0]["text"], "24h0m0s")
+
+	require.Len(t, config.Status.NotificationReceivers, 1)
+	assert.Equal(t, int64(1), config.Status.NotificationReceivers[0].Sent)
+	assert.NotNil(t, config.Status.NotificationReceivers[0].LastSentTime)
+
+	// Already notified while the situation lasts
+	reconcileNotifications(t, r)
+	assert.Len(t, server.received(), 1)
+}
+
+func TestNotifications_ExternalSecretFailing(t *testing.T) {
+	server := newReceiverServer(t)
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Notifications.ExternalSecretFailureDuration = &metav1.Duration{Duration: 30 * time.Minute}
+	config.Spec.Notifications.Receivers = []smv1alpha1.NotificationReceiver{
+		{Name: "hook", Type: smv1alpha1.NotificationReceiverWebhook, URLSecret: "hook-url"},
+	}
+	r := newTestNotificationReconciler(
+		config,
+		newTestURLSecret("hook-url", server.URL),
+		newTestExternalSecret("app", "db", time.Now().Add(-time.Hour)),
+		newTestExternalSecret("app", "recent", time.Now().Add(-10*time.Minute)),
+	)
+
+	reconcileNotifications(t, r)
+	bodies := server.received()
+	require.Len(t, bodies, 1)
+	assert.Equal(t, string(smv1alpha1.NotificationExternalSecretFailing), bodies[0]["event"])
+	assert.Equal(t, "db", bodies[0]["name"])
+	assert.Contains(t, bodies[0]["message"], "could not get secret data from provider")
+}
+
+func TestNotifications_Filtering(t *testing.T) {
+	server := newReceiverServer(t)
+	config := newTestConfig(SingletonConfigName)
+	config.Status.Phase = smv1alpha1.PhaseDegraded
+	config.Spec.Notifications.Receivers = []smv1alpha1.NotificationReceiver{
+		{
+			Name:       "team-a",
+			Type:       smv1alpha1.NotificationReceiverTeams,
+			URLSecret:  "teams-url",
+			Events:     []smv1alpha1.NotificationEvent{smv1alpha1.NotificationCertificateExpiring},
+			Namespaces: []string{"team-a"},
+		},
+	}
+	r := newTestNotificationReconciler(
+		config,
+		newTestURLSecret("teams-url", server.URL),
+		newTestCertificate("team-a", "api", time.Now().Add(time.Hour)),
+		newTestCertificate("team-b", "api", time.Now().Add(time.Hour)),
+		newTestExternalSecret("team-a", "db", time.Now().Add(-time.Hour)),
+	)
+
+	reconcileNotifications(t, r)
+	bodies := server.received()
+	require.Len(t, bodies, 1)
+	assert.Equal(t, "MessageCard", bodies[0]["@type"])
+	assert.Contains(t, bodies[0]["title"], "team-a/api")
+}
+
+func TestNotifications_RateLimited(t *testing.T) {
+	server := newReceiverServer(t)
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Notifications.Receivers = []smv1alpha1.NotificationReceiver{
+		{Name: "slack", Type: smv1alpha1.NotificationReceiverSlack, URLSecret: "slack-url", MaxPerHour: 2},
+	}
+	r := newTestNotificationReconciler(
+		config,
+		newTestURLSecret("slack-url", server.URL),
+		newTestCertificate("app", "a", time.Now().Add(time.Hour)),
+		newTestCertificate("app", "b", time.Now().Add(time.Hour)),
+		newTestCertificate("app", "c", time.Now().Add(time.Hour)),
+	)
+
+	config = reconcileNotifications(t, r)
+	assert.Len(t, server.received(), 2)
+	require.Len(t, config.Status.NotificationReceivers, 1)
+	assert.Equal(t, int64(2), config.Status.NotificationReceivers[0].Sent)
+	assert.Equal(t, int64(1), config.Status.NotificationReceivers[0].Suppressed)
+
+	// Suppressed notifications are not retried
+	config = reconcileNotifications(t, r)
+	assert.Len(t, server.received(), 2)
+	assert.Equal(t, int64(1), config.Status.NotificationReceivers[0].Suppressed)
+}
+
+func TestNotifications_PluginDegradedRecurs(t *testing.T) {
+	server := newReceiverServer(t)
+	config := newTestConfig(SingletonConfigName)
+	config.Status.Phase = smv1alpha1.PhaseDegraded
+	config.Status.LastError = "plugin deployment unavailable"
+	config.Spec.Notifications.Receivers = []smv1alpha1.NotificationReceiver{
+		{Name: "hook", Type: smv1alpha1.NotificationReceiverWebhook, URLSecret: "hook-url"},
+	}
+	r := newTestNotificationReconciler(config, newTestURLSecret("hook-url", server.URL))
+
+	config = reconcileNotifications(t, r)
+	require.Len(t, server.received(), 1)
+	assert.Equal(t, "plugin deployment unavailable", server.received()[0]["message"])
+
+	config.Status.Phase = smv1alpha1.PhaseReady
+	require.NoError(t, r.Status().Update(context.Background(), config))
+	config = reconcileNotifications(t, r)
+
+	config.Status.Phase = smv1alpha1.PhaseDegraded
+	require.NoError(t, r.Status().Update(context.Background(), config))
+	reconcileNotifications(t, r)
+	assert.Len(t, server.received(), 2)
+}
+
+func TestNotifications_MissingURLSecret(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.Phase = smv1alpha1.PhaseDegraded
+	config.Spec.Notifications.Receivers = []smv1alpha1.NotificationReceiver{
+		{Name: "slack", Type: smv1alpha1.NotificationReceiverSlack, URLSecret: "missing"},
+	}
+	r := newTestNotificationReconciler(config)
+
+	config = reconcileNotifications(t, r)
+	require.Len(t, config.Status.NotificationReceivers, 1)
+	assert.Contains(t, config.Status.NotificationReceivers[0].LastError, "missing")
+	assert.Zero(t, config.Status.NotificationReceivers[0].Sent)
+}
@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// OperatorConditionNameEnv is set by OLM on the operator Deployment to the name of the
+	// operator's OperatorCondition
+	OperatorConditionNameEnv = "OPERATOR_CONDITION_NAME"
+
+	// OperatorConditionUpgradeable is the OperatorCondition condition OLM checks before upgrading
+	OperatorConditionUpgradeable = "Upgradeable"
+
+	// ReasonUpgradeable indicates no plugin rollout is in progress
+	ReasonUpgradeable = "NoRolloutInProgress"
+
+	// ReasonPluginRolloutInProgress indicates a plugin Deployment is rolling out
+	ReasonPluginRolloutInProgress = "PluginRolloutInProgress"
+)
+
+var operatorConditionGVK = schema.GroupVersionKind{
+	Group:   "operators.coreos.com",
+	Version: "v2",
+	Kind:    "OperatorCondition",
+}
+
+// reconcileOperatorCondition sets the Upgradeable condition of the OperatorCondition OLM created
+// for the operator: False while a plugin Deployment of the primary or canary config rolls out,
+// so OLM does not replace the operator mid-rollout, and True otherwise. It does nothing when the
+// operator was not installed by OLM. OLM grants the operator access to its OperatorCondition.
+func (r *SecretsManagementConfigReconciler) reconcileOperatorCondition(ctx context.Context) error {
+	if r.OperatorConditionName == "" {
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:    OperatorConditionUpgradeable,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonUpgradeable,
+		Message: "No plugin rollout is in progress",
+	}
+	var rolling []string
+	for _, name := range []string{PluginName, fmt.Sprintf("%s-%s", PluginName, CanaryConfigName)} {
+		deployment := &appsv1.Deployment{}
+		err := r.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("%s-plugin", name), Namespace: PluginNamespace}, deployment)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if deploymentRollingOut(deployment) {
+			rolling = append(rolling, deployment.Name)
+		}
+	}
+	if len(rolling) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonPluginRolloutInProgress
+		condition.Message = fmt.Sprintf("Plugin Deployments are rolling out: %s", strings.Join(rolling, ", "))
+	}
+
+	// The OperatorCondition is in the operator namespace, outside the cache
+	operatorCondition := &unstructured.Unstructured{}
+	operatorCondition.SetGroupVersionKind(operatorConditionGVK)
+	err := r.apiReader().Get(ctx, types.NamespacedName{Name: r.OperatorConditionName, Namespace: r.operatorNamespace()}, operatorCondition)
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var conditions []metav1.Condition
+	raw, _, _ := unstructured.NestedSlice(operatorCondition.Object, "spec", "conditions")
+	for _, item := range raw {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		c := metav1.Condition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &c); err != nil {
+			return err
+		}
+		conditions = append(conditions, c)
+	}
+	if !meta.SetStatusCondition(&conditions, condition) {
+		return nil
+	}
+
+	items := make([]interface{}, 0, len(conditions))
+	for i := range conditions {
+		item, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&conditions[i])
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+	}
+	if err := unstructured.SetNestedSlice(operatorCondition.Object, items, "spec", "conditions"); err != nil {
+		return err
+	}
+	return r.Update(ctx, operatorCondition)
+}
+
+// deploymentRollingOut reports whether a Deployment has not finished rolling out its current
+// template, the same check as kubectl rollout status. A rollout that exceeded its progress
+// deadline has failed and no longer counts, so that an upgrade can still fix it.
+func deploymentRollingOut(deployment *appsv1.Deployment) bool {
+	_, done := rolloutProgress(deployment)
+	return !done && deploymentStalled(deployment) == nil
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestOperatorCondition() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(operatorConditionGVK)
+	u.SetName("secrets-management-operator.v0.1.0")
+	u.SetNamespace("operators")
+	return u
+}
+
+func getUpgradeable(t *testing.T, r *SecretsManagementConfigReconciler) map[string]interface{} {
+	t.Helper()
+	u := newTestOperatorCondition()
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: u.GetName(), Namespace: u.GetNamespace()}, u))
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "spec", "conditions")
+	for _, c := range conditions {
+		if m := c.(map[string]interface{}); m["type"] == OperatorConditionUpgradeable {
+			return m
+		}
+	}
+	return nil
+}
+
+func TestOperatorCondition_NotUpgradeableDuringRollout(t *testing.T) {
+	r := newTestReconciler(newTestConfig(SingletonConfigName), newTestOperatorCondition())
+	r.OperatorNamespace = "operators"
+	r.OperatorConditionName = "secrets-management-operator.v0.1.0"
+	reconcileTestConfig(t, r)
+
+	// The new Deployment has no updated replicas yet
+	upgradeable := getUpgradeable(t, r)
+	require.NotNil(t, upgradeable)
+	assert.Equal(t, "False", upgradeable["status"])
+	assert.Equal(t, ReasonPluginRolloutInProgress, upgradeable["reason"])
+	assert.Contains(t, upgradeable["message"], "ocp-secrets-management-plugin")
+
+	ctx := context.Background()
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, deployment))
+	deployment.Status = appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2, ReadyReplicas: 2}
+	require.NoError(t, r.Status().Update(ctx, deployment))
+	reconcileTestConfig(t, r)
+
+	upgradeable = getUpgradeable(t, r)
+	assert.Equal(t, "True", upgradeable["status"])
+	assert.Equal(t, ReasonUpgradeable, upgradeable["reason"])
+}
+
+func TestOperatorCondition_SkippedWithoutOLM(t *testing.T) {
+	r := newTestReconciler(newTestConfig(SingletonConfigName), newTestOperatorCondition())
+	r.OperatorNamespace = "operators"
+	reconcileTestConfig(t, r)
+	assert.Nil(t, getUpgradeable(t, r))
+
+	// OLM has not created the OperatorCondition yet
+	r = newTestReconciler(newTestConfig(SingletonConfigName))
+	r.OperatorConditionName = "secrets-management-operator.v0.1.0"
+	reconcileTestConfig(t, r)
+}
+
+func TestDeploymentRollingOut(t *testing.T) {
+	done := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+	}
+	assert.False(t, deploymentRollingOut(done))
+
+	unobserved := done.DeepCopy()
+	unobserved.Generation = 3
+	assert.True(t, deploymentRollingOut(unobserved))
+
+	oldPodsLeft := done.DeepCopy()
+	oldPodsLeft.Status.Replicas = 3
+	assert.True(t, deploymentRollingOut(oldPodsLeft))
+
+	unavailable := done.DeepCopy()
+	unavailable.Status.AvailableReplicas = 1
+	assert.True(t, deploymentRollingOut(unavailable))
+
+	failed := unavailable.DeepCopy()
+	failed.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"}}
+	assert.False(t, deploymentRollingOut(failed))
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// NginxConfigHashAnnotation records the hash of nginx.conf on the plugin pod template. The file is
+// mounted with subPath, which never sees ConfigMap updates, so changes roll out new pods instead.
+const NginxConfigHashAnnotation = "secrets-management.openshift.io/nginx-config-hash"
+
+// defaultPluginLogLevel is the error log level nginx runs with unless spec.plugin.logging.level is set
+const defaultPluginLogLevel = "info"
+
+// nginxJSONLogFormat writes one JSON object per request. escape=json keeps request-controlled
+// values such as the URI and user agent from breaking the object.
+const nginxJSONLogFormat = `  log_format json escape=json '{"time":"$time_iso8601","remote_addr":"$remote_addr",'
+    '"method":"$request_method","uri":"$request_uri","status":$status,'
+    '"bytes_sent":$body_bytes_sent,"duration":$request_time,"user_agent":"$http_user_agent"}';
+`
+
+// nginxErrorLog returns the error_log directive for spec.plugin.logging
+func nginxErrorLog(logging smv1alpha1.PluginLoggingConfig) string {
+	return fmt.Sprintf("error_log /dev/stdout %s;\n", valueOr(logging.Level, defaultPluginLogLevel))
+}
+
+// nginxAccessLog returns the http block directives for the access log of spec.plugin.logging
+func nginxAccessLog(logging smv1alpha1.PluginLoggingConfig) string {
+	switch {
+	case logging.DisableAccessLog:
+		return "  access_log off;\n"
+	case logging.Format == smv1alpha1.PluginLogFormatJSON:
+		return nginxJSONLogFormat + "  access_log /dev/stdout json;\n"
+	default:
+		return "  access_log /dev/stdout;\n"
+	}
+}
+
+// nginxConfigHash returns a short hash identifying an nginx.conf
+func nginxConfigHash(conf string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(conf)))[:16]
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestNginxLogDirectives(t *testing.T) {
+	tests := []struct {
+		name      string
+		logging   smv1alpha1.PluginLoggingConfig
+		errorLog  string
+		accessLog string
+	}{
+		{"defaults", smv1alpha1.PluginLoggingConfig{}, "error_log /dev/stdout info;", "access_log /dev/stdout;"},
+		{"quiet", smv1alpha1.PluginLoggingConfig{Level: "warn", DisableAccessLog: true}, "error_log /dev/stdout warn;", "access_log off;"},
+		{"json", smv1alpha1.PluginLoggingConfig{Format: smv1alpha1.PluginLogFormatJSON}, "error_log /dev/stdout info;", "access_log /dev/stdout json;"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Contains(t, nginxErrorLog(tt.logging), tt.errorLog)
+			assert.Contains(t, nginxAccessLog(tt.logging), tt.accessLog)
+		})
+	}
+	assert.Contains(t, nginxAccessLog(smv1alpha1.PluginLoggingConfig{Format: smv1alpha1.PluginLogFormatJSON}), "log_format json escape=json")
+}
+
+func TestPluginLoggingRollsOutPlugin(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler(config)
+	reconcileTestConfig(t, r)
+
+	key := types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, key, deployment))
+	firstHash := deployment.Spec.Template.Annotations[NginxConfigHashAnnotation]
+	require.NotEmpty(t, firstHash)
+
+	current := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, current))
+	current.Spec.Plugin.Logging = smv1alpha1.PluginLoggingConfig{Level: "error", DisableAccessLog: true}
+	require.NoError(t, r.Update(ctx, current))
+	reconcileTestConfig(t, r)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-nginx-conf", Namespace: PluginNamespace}, cm))
+	assert.Contains(t, cm.Data["nginx.conf"], "error_log /dev/stdout error;")
+	assert.Contains(t, cm.Data["nginx.conf"], "access_log off;")
+
+	require.NoError(t, r.Get(ctx, key, deployment))
+	assert.NotEqual(t, firstHash, deployment.Spec.Template.Annotations[NginxConfigHashAnnotation])
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// setServiceConfig applies spec.plugin.service to svc. The configured annotations never replace
+// the ones the operator sets. Unset IP families are left to the cluster, which fills them in on
+// creation; the traffic policy and session affinity fall back to the Kubernetes defaults so that
+// clearing them reverts the Service.
+func setServiceConfig(config *smv1alpha1.SecretsManagementConfig, svc *corev1.Service) {
+	service := config.Spec.Plugin.Service
+	if len(service.IPFamilies) > 0 {
+		svc.Spec.IPFamilies = append([]corev1.IPFamily(nil), service.IPFamilies...)
+	}
+	if service.IPFamilyPolicy != "" {
+		policy := service.IPFamilyPolicy
+		svc.Spec.IPFamilyPolicy = &policy
+	}
+	if len(service.Annotations) > 0 {
+		annotations := make(map[string]string, len(service.Annotations)+len(svc.Annotations))
+		for k, v := range service.Annotations {
+			annotations[k] = v
+		}
+		for k, v := range svc.Annotations {
+			annotations[k] = v
+		}
+		svc.Annotations = annotations
+	}
+	trafficPolicy := service.InternalTrafficPolicy
+	if trafficPolicy == "" {
+		trafficPolicy = corev1.ServiceInternalTrafficPolicyCluster
+	}
+	svc.Spec.InternalTrafficPolicy = &trafficPolicy
+	svc.Spec.SessionAffinity = service.SessionAffinity
+	if svc.Spec.SessionAffinity == "" {
+		svc.Spec.SessionAffinity = corev1.ServiceAffinityNone
+	}
+}
+
+// pluginIPFamilies returns the IP families the plugin serves on: those the cluster assigned to
+// the plugin Service, which follow spec.plugin.service, or the configured ones before the Service
+// reports any
+func (r *SecretsManagementConfigReconciler) pluginIPFamilies(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) ([]corev1.IPFamily, error) {
+	svc := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("%s-plugin", instanceName(config)), Namespace: PluginNamespace}, svc)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if len(svc.Spec.IPFamilies) > 0 {
+		return svc.Spec.IPFamilies, nil
+	}
+	return config.Spec.Plugin.Service.IPFamilies, nil
+}
+
+// nginxListen returns the listen directives for port on each of families. Without families nginx
+// listens on IPv4 only, as IPv6 may be disabled in the pod.
+func nginxListen(port int32, params string, families []corev1.IPFamily) string {
+	if params != "" {
+		params = " " + params
+	}
+	var b strings.Builder
+	ipv4 := len(families) == 0
+	ipv6 := false
+	for _, family := range families {
+		switch family {
+		case corev1.IPv4Protocol:
+			ipv4 = true
+		case corev1.IPv6Protocol:
+			ipv6 = true
+		}
+	}
+	if ipv4 {
+		fmt.Fprintf(&b, "    listen %d%s;\n", port, params)
+	}
+	if ipv6 {
+		fmt.Fprintf(&b, "    listen [::]:%d%s;\n", port, params)
+	}
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestNginxListen(t *testing.T) {
+	assert.Equal(t, "    listen 9443 ssl;\n", nginxListen(9443, "ssl", nil))
+	assert.Equal(t, "    listen [::]:9443 ssl;\n", nginxListen(9443, "ssl", []corev1.IPFamily{corev1.IPv6Protocol}))
+	assert.Equal(t, "    listen 9090;\n    listen [::]:9090;\n", nginxListen(9090, "", []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}))
+}
+
+func TestReconcileService_IPFamilies(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Plugin.MetricsPort = 9090
+	config.Spec.Plugin.Service.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}
+	config.Spec.Plugin.Service.IPFamilyPolicy = corev1.IPFamilyPolicyPreferDualStack
+	r := newTestReconciler(config)
+
+	require.NoError(t, r.reconcileService(ctx, config))
+	svc := &corev1.Service{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, svc))
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}, svc.Spec.IPFamilies)
+	require.NotNil(t, svc.Spec.IPFamilyPolicy)
+	assert.Equal(t, corev1.IPFamilyPolicyPreferDualStack, *svc.Spec.IPFamilyPolicy)
+
+	_, err := r.reconcileNginxConfig(ctx, config)
+	require.NoError(t, err)
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-nginx-conf", Namespace: PluginNamespace}, cm))
+	assert.Contains(t, cm.Data["nginx.conf"], "listen 9443 ssl;")
+	assert.Contains(t, cm.Data["nginx.conf"], "listen [::]:9443 ssl;")
+	assert.Contains(t, cm.Data["nginx.conf"], "listen [::]:9090;")
+}
+
+func TestReconcileNginxConfig_AssignedIPFamilies(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	// An IPv6-only cluster assigns IPv6 without spec.plugin.service
+	svc := &corev1.Service{}
+	svc.Name, svc.Namespace = "ocp-secrets-management-plugin", PluginNamespace
+	svc.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+	r := newTestReconciler(config, svc)
+
+	require.NoError(t, r.reconcileService(ctx, config))
+	_, err := r.reconcileNginxConfig(ctx, config)
+	require.NoError(t, err)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-nginx-conf", Namespace: PluginNamespace}, cm))
+	assert.Contains(t, cm.Data["nginx.conf"], "listen [::]:9443 ssl;")
+	assert.NotContains(t, cm.Data["nginx.conf"], "    listen 9443 ssl;")
+}
+
+func TestReconcileService_Settings(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Plugin.Service.Annotations = map[string]string{
+		"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+		"service.alpha.openshift.io/serving-cert-secret-name":   "other-cert",
+	}
+	config.Spec.Plugin.Service.InternalTrafficPolicy = corev1.ServiceInternalTrafficPolicyLocal
+	config.Spec.Plugin.Service.SessionAffinity = corev1.ServiceAffinityClientIP
+	// Annotations added by a mesh are kept
+	existing := &corev1.Service{}
+	existing.Name, existing.Namespace = "ocp-secrets-management-plugin", PluginNamespace
+	existing.Annotations = map[string]string{"sidecar.istio.io/inject": "false"}
+	r := newTestReconciler(config, existing)
+
+	require.NoError(t, r.reconcileService(ctx, config))
+	svc := &corev1.Service{}
+	key := types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}
+	require.NoError(t, r.Get(ctx, key, svc))
+	assert.Equal(t, "true", svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"])
+	assert.Equal(t, "false", svc.Annotations["sidecar.istio.io/inject"])
+	assert.Equal(t, "ocp-secrets-management-plugin-cert", svc.Annotations["service.alpha.openshift.io/serving-cert-secret-name"])
+	require.NotNil(t, svc.Spec.InternalTrafficPolicy)
+	assert.Equal(t, corev1.ServiceInternalTrafficPolicyLocal, *svc.Spec.InternalTrafficPolicy)
+	assert.Equal(t, corev1.ServiceAffinityClientIP, svc.Spec.SessionAffinity)
+
+	// Clearing the settings reverts to the Kubernetes defaults
+	timeout := int32(10800)
+	svc.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeout}}
+	require.NoError(t, r.Update(ctx, svc))
+	config.Spec.Plugin.Service = smv1alpha1.PluginServiceConfig{}
+	require.NoError(t, r.reconcileService(ctx, config))
+	require.NoError(t, r.Get(ctx, key, svc))
+	assert.Equal(t, corev1.ServiceInternalTrafficPolicyCluster, *svc.Spec.InternalTrafficPolicy)
+	assert.Equal(t, corev1.ServiceAffinityNone, svc.Spec.SessionAffinity)
+	assert.Nil(t, svc.Spec.SessionAffinityConfig)
+}
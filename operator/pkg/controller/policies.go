@@ -0,0 +1,419 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// PolicyBundleLabel marks the policies rendered from spec.policies, so they are told apart from
+// the operator's own protection policies when pruned
+const PolicyBundleLabel = "secrets-management.openshift.io/policy-bundle"
+
+// DefaultProductionNamespaceSelector selects the namespaces the rules apply to unless
+// spec.policies.productionNamespaceSelector is set
+var DefaultProductionNamespaceSelector = metav1.LabelSelector{
+	MatchLabels: map[string]string{"environment": "production"},
+}
+
+// Policy engine GroupVersionKinds
+var (
+	constraintTemplateGVK = schema.GroupVersionKind{
+		Group:   "templates.gatekeeper.sh",
+		Version: "v1",
+		Kind:    "ConstraintTemplate",
+	}
+	kyvernoClusterPolicyGVK = schema.GroupVersionKind{
+		Group:   "kyverno.io",
+		Version: "v1",
+		Kind:    "ClusterPolicy",
+	}
+	validatingAdmissionPolicyGVK        = admissionregistrationv1beta1.SchemeGroupVersion.WithKind("ValidatingAdmissionPolicy")
+	validatingAdmissionPolicyBindingGVK = admissionregistrationv1beta1.SchemeGroupVersion.WithKind("ValidatingAdmissionPolicyBinding")
+)
+
+// secretPolicyRule is one organization rule about Secrets, written for each policy engine
+type secretPolicyRule struct {
+	// name is used for the rendered objects, prefixed with secrets-management-
+	name string
+	// kind is the Gatekeeper constraint kind
+	kind string
+	// message is returned to clients whose request violates the rule
+	message string
+	// cel holds for compliant Secrets in a ValidatingAdmissionPolicy
+	cel string
+	// rego defines violation[] for a Gatekeeper ConstraintTemplate
+	rego string
+	// kyvernoDeny are the conditions under which Kyverno denies a Secret
+	kyvernoDeny []interface{}
+}
+
+var (
+	requireExternalSecretsRule = secretPolicyRule{
+		name:    "require-external-secrets",
+		kind:    "SecretsManagementRequireExternalSecrets",
+		message: "Opaque Secrets in production namespaces must be created by an ExternalSecret",
+		cel: "!has(object.type) || object.type != 'Opaque' || " +
+			"(has(object.metadata.ownerReferences) && object.metadata.ownerReferences.exists(o, o.kind == 'ExternalSecret'))",
+		rego: `package secretsmanagementrequireexternalsecrets
+
+violation[{"msg": msg}] {
+  input.review.object.type == "Opaque"
+  not owned_by_external_secret
+  msg := "Opaque Secrets in production namespaces must be created by an ExternalSecret"
+}
+
+owned_by_external_secret {
+  input.review.object.metadata.ownerReferences[_].kind == "ExternalSecret"
+}
+`,
+		kyvernoDeny: []interface{}{
+			map[string]interface{}{"key": "{{ request.object.type }}", "operator": "Equals", "value": "Opaque"},
+			map[string]interface{}{"key": "ExternalSecret", "operator": "AnyNotIn", "value": "{{ request.object.metadata.ownerReferences[].kind || `[]` }}"},
+		},
+	}
+	forbidServiceAccountTokensRule = secretPolicyRule{
+		name:    "forbid-sa-token-secrets",
+		kind:    "SecretsManagementForbidSATokenSecrets",
+		message: "Long-lived service account token Secrets are not allowed in production namespaces; use projected tokens",
+		cel:     "!has(object.type) || object.type != 'kubernetes.io/service-account-token'",
+		rego: `package secretsmanagementforbidsatokensecrets
+
+violation[{"msg": msg}] {
+  input.review.object.type == "kubernetes.io/service-account-token"
+  msg := "Long-lived service account token Secrets are not allowed in production namespaces; use projected tokens"
+}
+`,
+		kyvernoDeny: []interface{}{
+			map[string]interface{}{"key": "{{ request.object.type }}", "operator": "Equals", "value": "kubernetes.io/service-account-token"},
+		},
+	}
+
+	// secretPolicyRules are every rule the bundle can hold, for pruning
+	secretPolicyRules = []secretPolicyRule{requireExternalSecretsRule, forbidServiceAccountTokensRule}
+)
+
+// +kubebuilder:rbac:groups=templates.gatekeeper.sh,resources=constrainttemplates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=constraints.gatekeeper.sh,resources=*,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kyverno.io,resources=clusterpolicies,verbs=get;list;watch;create;update;patch;delete
+
+// reconcilePolicies renders the rules enabled in spec.policies for the configured engine and
+// removes policies for rules that were turned off or for another engine. Only the primary config
+// owns them.
+func (r *SecretsManagementConfigReconciler) reconcilePolicies(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	policies := &config.Spec.Policies
+	var rules []secretPolicyRule
+	if policies.RequireExternalSecrets {
+		rules = append(rules, requireExternalSecretsRule)
+	}
+	if policies.ForbidServiceAccountTokenSecrets {
+		rules = append(rules, forbidServiceAccountTokensRule)
+	}
+	if policies.Engine == "" || len(rules) == 0 {
+		if err := r.prunePolicies(ctx, nil); err != nil {
+			return err
+		}
+		r.setCondition(config, smv1alpha1.ConditionPoliciesConfigured, "False", smv1alpha1.ReasonPoliciesDisabled, "No policy engine or rule is configured in spec.policies")
+		return nil
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, rule := range rules {
+		rendered, err := renderSecretPolicy(policies, rule)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, rendered...)
+	}
+
+	desired := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		desired[policyObjectKey(obj.GroupVersionKind(), obj.GetName())] = true
+	}
+	if err := r.prunePolicies(ctx, desired); err != nil {
+		return err
+	}
+
+	for _, obj := range objects {
+		applyCommonMetadata(config, obj)
+		if err := r.applyPolicyObject(ctx, obj); err != nil {
+			if !meta.IsNoMatchError(err) {
+				return err
+			}
+			// Gatekeeper creates each constraint kind once its template is accepted
+			if policies.Engine == smv1alpha1.PolicyEngineGatekeeper && obj.GroupVersionKind().Group == "constraints.gatekeeper.sh" {
+				r.setCondition(config, smv1alpha1.ConditionPoliciesConfigured, "False", smv1alpha1.ReasonPoliciesPending, "Waiting for Gatekeeper to create the constraint kinds")
+				return nil
+			}
+			r.setCondition(config, smv1alpha1.ConditionPoliciesConfigured, "False", smv1alpha1.ReasonPolicyEngineNotInstalled, fmt.Sprintf("%s is not installed", policies.Engine))
+			return nil
+		}
+	}
+
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		names = append(names, rule.name)
+	}
+	r.setCondition(config, smv1alpha1.ConditionPoliciesConfigured, "True", smv1alpha1.ReasonPoliciesApplied,
+		fmt.Sprintf("%s enforces %s", policies.Engine, strings.Join(names, ", ")))
+	return nil
+}
+
+// renderSecretPolicy returns the objects enforcing rule with the configured engine
+func renderSecretPolicy(policies *smv1alpha1.PoliciesConfig, rule secretPolicyRule) ([]*unstructured.Unstructured, error) {
+	selector := DefaultProductionNamespaceSelector
+	if policies.ProductionNamespaceSelector != nil {
+		selector = *policies.ProductionNamespaceSelector
+	}
+	warn := policies.Action == smv1alpha1.PolicyActionWarn
+	name := "secrets-management-" + rule.name
+
+	switch policies.Engine {
+	case smv1alpha1.PolicyEngineValidatingAdmissionPolicy:
+		return renderValidatingAdmissionPolicy(name, rule, selector, warn)
+	case smv1alpha1.PolicyEngineGatekeeper:
+		return renderGatekeeperPolicy(rule, selector, warn)
+	case smv1alpha1.PolicyEngineKyverno:
+		return renderKyvernoPolicy(name, rule, selector, warn)
+	default:
+		return nil, fmt.Errorf("unsupported policy engine %q", policies.Engine)
+	}
+}
+
+// renderValidatingAdmissionPolicy returns a ValidatingAdmissionPolicy checking Secrets on create
+// and update, bound to the production namespaces
+func renderValidatingAdmissionPolicy(name string, rule secretPolicyRule, selector metav1.LabelSelector, warn bool) ([]*unstructured.Unstructured, error) {
+	failurePolicy := admissionregistrationv1beta1.Fail
+	createOrUpdate := []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+	policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicySpec{
+			FailurePolicy: &failurePolicy,
+			MatchConstraints: &admissionregistrationv1beta1.MatchResources{
+				ResourceRules: []admissionregistrationv1beta1.NamedRuleWithOperations{
+					admissionRule(createOrUpdate, "", "secrets"),
+				},
+			},
+			Validations: []admissionregistrationv1beta1.Validation{
+				{
+					Expression: rule.cel,
+					Message:    rule.message,
+					Reason:     reasonPtr(metav1.StatusReasonForbidden),
+				},
+			},
+		},
+	}
+
+	actions := []admissionregistrationv1beta1.ValidationAction{admissionregistrationv1beta1.Deny}
+	if warn {
+		actions = []admissionregistrationv1beta1.ValidationAction{admissionregistrationv1beta1.Warn, admissionregistrationv1beta1.Audit}
+	}
+	binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicyBindingSpec{
+			PolicyName:        name,
+			MatchResources:    &admissionregistrationv1beta1.MatchResources{NamespaceSelector: selector.DeepCopy()},
+			ValidationActions: actions,
+		},
+	}
+
+	policyObj, err := toPolicyUnstructured(policy, validatingAdmissionPolicyGVK)
+	if err != nil {
+		return nil, err
+	}
+	bindingObj, err := toPolicyUnstructured(binding, validatingAdmissionPolicyBindingGVK)
+	if err != nil {
+		return nil, err
+	}
+	return []*unstructured.Unstructured{policyObj, bindingObj}, nil
+}
+
+// renderGatekeeperPolicy returns a ConstraintTemplate holding the rule's Rego and the constraint
+// applying it to Secrets in the production namespaces
+func renderGatekeeperPolicy(rule secretPolicyRule, selector metav1.LabelSelector, warn bool) ([]*unstructured.Unstructured, error) {
+	template := newPolicyObject(constraintTemplateGVK, strings.ToLower(rule.kind))
+	templateSpec := map[string]interface{}{
+		"crd": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"names": map[string]interface{}{"kind": rule.kind},
+			},
+		},
+		"targets": []interface{}{
+			map[string]interface{}{
+				"target": "admission.k8s.gatekeeper.sh",
+				"rego":   rule.rego,
+			},
+		},
+	}
+	if err := unstructured.SetNestedField(template.Object, templateSpec, "spec"); err != nil {
+		return nil, err
+	}
+
+	namespaceSelector, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&selector)
+	if err != nil {
+		return nil, err
+	}
+	enforcementAction := "deny"
+	if warn {
+		enforcementAction = "warn"
+	}
+	constraint := newPolicyObject(schema.GroupVersionKind{Group: "constraints.gatekeeper.sh", Version: "v1beta1", Kind: rule.kind}, "secrets-management-"+rule.name)
+	constraintSpec := map[string]interface{}{
+		"enforcementAction": enforcementAction,
+		"match": map[string]interface{}{
+			"kinds": []interface{}{
+				map[string]interface{}{"apiGroups": []interface{}{""}, "kinds": []interface{}{"Secret"}},
+			},
+			"namespaceSelector": namespaceSelector,
+		},
+	}
+	if err := unstructured.SetNestedField(constraint.Object, constraintSpec, "spec"); err != nil {
+		return nil, err
+	}
+	return []*unstructured.Unstructured{template, constraint}, nil
+}
+
+// renderKyvernoPolicy returns a ClusterPolicy denying Secrets that break the rule in the
+// production namespaces
+func renderKyvernoPolicy(name string, rule secretPolicyRule, selector metav1.LabelSelector, warn bool) ([]*unstructured.Unstructured, error) {
+	namespaceSelector, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&selector)
+	if err != nil {
+		return nil, err
+	}
+	failureAction := "Enforce"
+	if warn {
+		failureAction = "Audit"
+	}
+	policy := newPolicyObject(kyvernoClusterPolicyGVK, name)
+	spec := map[string]interface{}{
+		"validationFailureAction": failureAction,
+		"background":              false,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"name": rule.name,
+				"match": map[string]interface{}{
+					"any": []interface{}{
+						map[string]interface{}{"resources": map[string]interface{}{
+							"kinds":             []interface{}{"Secret"},
+							"operations":        []interface{}{"CREATE", "UPDATE"},
+							"namespaceSelector": namespaceSelector,
+						}},
+					},
+				},
+				"validate": map[string]interface{}{
+					"message": rule.message,
+					"deny": map[string]interface{}{
+						"conditions": map[string]interface{}{"all": rule.kyvernoDeny},
+					},
+				},
+			},
+		},
+	}
+	if err := unstructured.SetNestedField(policy.Object, spec, "spec"); err != nil {
+		return nil, err
+	}
+	return []*unstructured.Unstructured{policy}, nil
+}
+
+// newPolicyObject returns an empty policy object labeled as part of the bundle
+func newPolicyObject(gvk schema.GroupVersionKind, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetName(name)
+	u.SetLabels(map[string]string{
+		"app.kubernetes.io/part-of":    "ocp-secrets-management",
+		"app.kubernetes.io/managed-by": "secrets-management-operator",
+		PolicyBundleLabel:              "true",
+	})
+	return u
+}
+
+// toPolicyUnstructured converts a typed policy object into a labeled bundle object
+func toPolicyUnstructured(obj runtime.Object, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := newPolicyObject(gvk, "")
+	spec, _, _ := unstructured.NestedMap(content, "spec")
+	if err := unstructured.SetNestedField(u.Object, spec, "spec"); err != nil {
+		return nil, err
+	}
+	name, _, _ := unstructured.NestedString(content, "metadata", "name")
+	u.SetName(name)
+	return u, nil
+}
+
+// applyPolicyObject creates obj or updates its spec and metadata when they drifted
+func (r *SecretsManagementConfigReconciler) applyPolicyObject(ctx context.Context, obj *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	err := r.Get(ctx, types.NamespacedName{Name: obj.GetName()}, existing)
+	if errors.IsNotFound(err) {
+		return r.Create(ctx, obj)
+	}
+	if err != nil {
+		return err
+	}
+
+	before := existing.DeepCopy()
+	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+	if err := unstructured.SetNestedField(existing.Object, spec, "spec"); err != nil {
+		return err
+	}
+	mergeMetadata(existing, obj)
+	return updateIfChanged(ctx, r, before, existing)
+}
+
+// prunePolicies deletes bundle policies not in desired, for every engine. Kinds the cluster does
+// not serve are skipped.
+func (r *SecretsManagementConfigReconciler) prunePolicies(ctx context.Context, desired map[string]bool) error {
+	kinds := []schema.GroupVersionKind{
+		validatingAdmissionPolicyBindingGVK,
+		validatingAdmissionPolicyGVK,
+		kyvernoClusterPolicyGVK,
+	}
+	// Constraints go before their templates, whose deletion removes the constraint kind
+	for _, rule := range secretPolicyRules {
+		kinds = append(kinds, schema.GroupVersionKind{Group: "constraints.gatekeeper.sh", Version: "v1beta1", Kind: rule.kind})
+	}
+	kinds = append(kinds, constraintTemplateGVK)
+
+	for _, gvk := range kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := r.List(ctx, list, client.MatchingLabels{PolicyBundleLabel: "true"}); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return err
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if desired[policyObjectKey(gvk, obj.GetName())] {
+				continue
+			}
+			if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func policyObjectKey(gvk schema.GroupVersionKind, name string) string {
+	return gvk.GroupKind().String() + "/" + name
+}
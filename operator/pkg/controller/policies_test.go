@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func getPolicyObject(t *testing.T, r *SecretsManagementConfigReconciler, gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+	t.Helper()
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	err := r.Get(context.Background(), types.NamespacedName{Name: name}, u)
+	return u, err
+}
+
+func TestReconcilePolicies_ValidatingAdmissionPolicy(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Policies = smv1alpha1.PoliciesConfig{
+		Engine:                 smv1alpha1.PolicyEngineValidatingAdmissionPolicy,
+		Action:                 smv1alpha1.PolicyActionWarn,
+		RequireExternalSecrets: true,
+		ProductionNamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"tier": "prod"},
+		},
+	}
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcilePolicies(ctx, config))
+
+	policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-require-external-secrets"}, policy))
+	require.Len(t, policy.Spec.Validations, 1)
+	assert.Contains(t, policy.Spec.Validations[0].Expression, "ExternalSecret")
+	assert.Equal(t, "true", policy.Labels[PolicyBundleLabel])
+
+	binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-require-external-secrets"}, binding))
+	assert.Equal(t, map[string]string{"tier": "prod"}, binding.Spec.MatchResources.NamespaceSelector.MatchLabels)
+	assert.Equal(t, []admissionregistrationv1beta1.ValidationAction{admissionregistrationv1beta1.Warn, admissionregistrationv1beta1.Audit}, binding.Spec.ValidationActions)
+
+	cond := findCondition(config, smv1alpha1.ConditionPoliciesConfigured)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonPoliciesApplied, cond.Reason)
+}
+
+func TestReconcilePolicies_Kyverno(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Policies = smv1alpha1.PoliciesConfig{
+		Engine:                           smv1alpha1.PolicyEngineKyverno,
+		ForbidServiceAccountTokenSecrets: true,
+	}
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcilePolicies(context.Background(), config))
+
+	policy, err := getPolicyObject(t, r, kyvernoClusterPolicyGVK, "secrets-management-forbid-sa-token-secrets")
+	require.NoError(t, err)
+	action, _, _ := unstructured.NestedString(policy.Object, "spec", "validationFailureAction")
+	assert.Equal(t, "Enforce", action)
+	rules, _, _ := unstructured.NestedSlice(policy.Object, "spec", "rules")
+	require.Len(t, rules, 1)
+	match, _, _ := unstructured.NestedSlice(rules[0].(map[string]interface{}), "match", "any")
+	require.Len(t, match, 1)
+	selector, _, _ := unstructured.NestedStringMap(match[0].(map[string]interface{}), "resources", "namespaceSelector", "matchLabels")
+	assert.Equal(t, DefaultProductionNamespaceSelector.MatchLabels, selector)
+}
+
+func TestReconcilePolicies_Gatekeeper(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Policies = smv1alpha1.PoliciesConfig{
+		Engine:                 smv1alpha1.PolicyEngineGatekeeper,
+		RequireExternalSecrets: true,
+	}
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcilePolicies(context.Background(), config))
+
+	template, err := getPolicyObject(t, r, constraintTemplateGVK, "secretsmanagementrequireexternalsecrets")
+	require.NoError(t, err)
+	kind, _, _ := unstructured.NestedString(template.Object, "spec", "crd", "spec", "names", "kind")
+	assert.Equal(t, requireExternalSecretsRule.kind, kind)
+
+	constraint, err := getPolicyObject(t, r, schema.GroupVersionKind{Group: "constraints.gatekeeper.sh", Version: "v1beta1", Kind: requireExternalSecretsRule.kind}, "secrets-management-require-external-secrets")
+	require.NoError(t, err)
+	action, _, _ := unstructured.NestedString(constraint.Object, "spec", "enforcementAction")
+	assert.Equal(t, "deny", action)
+}
+
+func TestReconcilePolicies_FollowsToggles(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Policies = smv1alpha1.PoliciesConfig{
+		Engine:                           smv1alpha1.PolicyEngineValidatingAdmissionPolicy,
+		RequireExternalSecrets:           true,
+		ForbidServiceAccountTokenSecrets: true,
+	}
+	r := newTestReconciler()
+	require.NoError(t, r.reconcileProtection(ctx, func() *smv1alpha1.SecretsManagementConfig {
+		c := config.DeepCopy()
+		c.Spec.Protection.Enabled = true
+		return c
+	}()))
+	require.NoError(t, r.reconcilePolicies(ctx, config))
+
+	// Turning a rule off removes its policy
+	config.Spec.Policies.ForbidServiceAccountTokenSecrets = false
+	require.NoError(t, r.reconcilePolicies(ctx, config))
+	_, err := getPolicyObject(t, r, validatingAdmissionPolicyGVK, "secrets-management-forbid-sa-token-secrets")
+	assert.True(t, errors.IsNotFound(err))
+	_, err = getPolicyObject(t, r, validatingAdmissionPolicyBindingGVK, "secrets-management-forbid-sa-token-secrets")
+	assert.True(t, errors.IsNotFound(err))
+
+	// Switching engines moves the remaining rule to the new engine
+	config.Spec.Policies.Engine = smv1alpha1.PolicyEngineKyverno
+	require.NoError(t, r.reconcilePolicies(ctx, config))
+	_, err = getPolicyObject(t, r, validatingAdmissionPolicyGVK, "secrets-management-require-external-secrets")
+	assert.True(t, errors.IsNotFound(err))
+	_, err = getPolicyObject(t, r, kyvernoClusterPolicyGVK, "secrets-management-require-external-secrets")
+	assert.NoError(t, err)
+
+	// Clearing the engine removes everything
+	config.Spec.Policies.Engine = ""
+	require.NoError(t, r.reconcilePolicies(ctx, config))
+	_, err = getPolicyObject(t, r, kyvernoClusterPolicyGVK, "secrets-management-require-external-secrets")
+	assert.True(t, errors.IsNotFound(err))
+	cond := findCondition(config, smv1alpha1.ConditionPoliciesConfigured)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonPoliciesDisabled, cond.Reason)
+
+	// The operator's own protection policy is not part of the bundle
+	_, err = getPolicyObject(t, r, validatingAdmissionPolicyGVK, ProtectionPolicyName)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// PolicyReportName is the name of the ClusterPolicyReport mirroring the compliance report
+const PolicyReportName = "secrets-management"
+
+// policyReportSource identifies the operator as the source of the policy report results
+const policyReportSource = "ocp-secrets-management"
+
+var clusterPolicyReportGVK = schema.GroupVersionKind{
+	Group:   "wgpolicyk8s.io",
+	Version: "v1alpha2",
+	Kind:    "ClusterPolicyReport",
+}
+
+// policyReportResults maps compliance results to PolicyReport results. Controls to check by hand
+// are warnings, so dashboards surface them.
+var policyReportResults = map[smv1alpha1.ComplianceCheckResult]string{
+	smv1alpha1.ComplianceResultPass:          "pass",
+	smv1alpha1.ComplianceResultFail:          "fail",
+	smv1alpha1.ComplianceResultManual:        "warn",
+	smv1alpha1.ComplianceResultError:         "error",
+	smv1alpha1.ComplianceResultNotApplicable: "skip",
+}
+
+// +kubebuilder:rbac:groups=wgpolicyk8s.io,resources=clusterpolicyreports,verbs=get;create;update;delete
+
+// writePolicyReport mirrors the compliance report to the ClusterPolicyReport owned by the config
+// while spec.compliance.policyReport is set. Reconcile deletes it otherwise, since the scan job
+// cannot delete it.
+func (r *ComplianceReconciler) writePolicyReport(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, status smv1alpha1.SecretsComplianceReportStatus) error {
+	if !config.Spec.Compliance.PolicyReport {
+		return nil
+	}
+
+	desired := buildPolicyReport(status)
+	report := &unstructured.Unstructured{}
+	report.SetGroupVersionKind(clusterPolicyReportGVK)
+	err := r.Get(ctx, types.NamespacedName{Name: PolicyReportName}, report)
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+	if errors.IsNotFound(err) {
+		if err := controllerutil.SetControllerReference(config, desired, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, desired)
+	}
+	if err != nil {
+		return err
+	}
+
+	report.Object["summary"] = desired.Object["summary"]
+	report.Object["results"] = desired.Object["results"]
+	return r.Update(ctx, report)
+}
+
+// deletePolicyReport removes the ClusterPolicyReport, if any
+func (r *ComplianceReconciler) deletePolicyReport(ctx context.Context) error {
+	report := &unstructured.Unstructured{}
+	report.SetGroupVersionKind(clusterPolicyReportGVK)
+	report.SetName(PolicyReportName)
+	if err := r.Delete(ctx, report); err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
+
+// buildPolicyReport returns the ClusterPolicyReport listing each control as a rule of the
+// secrets-management policy. Findings and benchmark references go into the result properties.
+func buildPolicyReport(status smv1alpha1.SecretsComplianceReportStatus) *unstructured.Unstructured {
+	summary := map[string]interface{}{"pass": int64(0), "fail": int64(0), "warn": int64(0), "error": int64(0), "skip": int64(0)}
+	results := make([]interface{}, 0, len(status.Controls))
+	for _, control := range status.Controls {
+		result := policyReportResults[control.Result]
+		if result == "" {
+			result = "error"
+		}
+		summary[result] = summary[result].(int64) + 1
+
+		entry := map[string]interface{}{
+			"source":   policyReportSource,
+			"policy":   "secrets-management",
+			"rule":     control.ID,
+			"category": "Secrets Management",
+			"severity": string(control.Severity),
+			"result":   result,
+			"message":  control.Title,
+		}
+		if control.Message != "" {
+			entry["message"] = control.Title + ": " + control.Message
+		}
+		properties := map[string]interface{}{}
+		if len(control.References) > 0 {
+			properties["references"] = strings.Join(control.References, ", ")
+		}
+		if len(control.Findings) > 0 {
+			properties["findings"] = strings.Join(control.Findings, ", ")
+		}
+		if len(properties) > 0 {
+			entry["properties"] = properties
+		}
+		if status.GeneratedAt != nil {
+			entry["timestamp"] = map[string]interface{}{
+				"seconds": status.GeneratedAt.Unix(),
+				"nanos":   int64(0),
+			}
+		}
+		results = append(results, entry)
+	}
+
+	report := &unstructured.Unstructured{Object: map[string]interface{}{
+		"summary": summary,
+		"results": results,
+	}}
+	report.SetGroupVersionKind(clusterPolicyReportGVK)
+	report.SetName(PolicyReportName)
+	report.SetLabels(map[string]string{
+		"app.kubernetes.io/part-of":    "ocp-secrets-management",
+		"app.kubernetes.io/managed-by": "secrets-management-operator",
+	})
+	return report
+}
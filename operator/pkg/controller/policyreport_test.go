@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func getPolicyReport(r *ComplianceReconciler) (*unstructured.Unstructured, error) {
+	report := &unstructured.Unstructured{}
+	report.SetGroupVersionKind(clusterPolicyReportGVK)
+	err := r.Get(context.Background(), types.NamespacedName{Name: PolicyReportName}, report)
+	return report, err
+}
+
+func TestBuildPolicyReport(t *testing.T) {
+	generatedAt := metav1.NewTime(time.Unix(1700000000, 0))
+	report := buildPolicyReport(smv1alpha1.SecretsComplianceReportStatus{
+		GeneratedAt: &generatedAt,
+		Controls: []smv1alpha1.ComplianceControlStatus{
+			{ID: "a", Title: "A holds", Severity: smv1alpha1.ComplianceSeverityHigh, Result: smv1alpha1.ComplianceResultPass, References: []string{"CIS 5.4.1", "NIST SC-28"}},
+			{ID: "b", Title: "B holds", Severity: smv1alpha1.ComplianceSeverityLow, Result: smv1alpha1.ComplianceResultFail, Message: "2 violations", Findings: []string{"app/x", "app/y"}},
+			{ID: "c", Title: "C holds", Severity: smv1alpha1.ComplianceSeverityMedium, Result: smv1alpha1.ComplianceResultManual},
+		},
+	})
+
+	assert.Equal(t, PolicyReportName, report.GetName())
+	summary, _, err := unstructured.NestedMap(report.Object, "summary")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"pass": int64(1), "fail": int64(1), "warn": int64(1), "error": int64(0), "skip": int64(0)}, summary)
+
+	results, _, err := unstructured.NestedSlice(report.Object, "results")
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	passed := results[0].(map[string]interface{})
+	assert.Equal(t, "a", passed["rule"])
+	assert.Equal(t, "pass", passed["result"])
+	assert.Equal(t, "high", passed["severity"])
+	assert.Equal(t, "A holds", passed["message"])
+	assert.Equal(t, map[string]interface{}{"references": "CIS 5.4.1, NIST SC-28"}, passed["properties"])
+	assert.Equal(t, int64(1700000000), passed["timestamp"].(map[string]interface{})["seconds"])
+
+	failed := results[1].(map[string]interface{})
+	assert.Equal(t, "fail", failed["result"])
+	assert.Equal(t, "B holds: 2 violations", failed["message"])
+	assert.Equal(t, map[string]interface{}{"findings": "app/x, app/y"}, failed["properties"])
+}
+
+func TestComplianceReconciler_PolicyReport(t *testing.T) {
+	ctx := context.Background()
+	config := newTestComplianceConfig()
+	config.Spec.Compliance.PolicyReport = true
+	r := newTestComplianceReconciler(config)
+
+	reconcileCompliance(t, r)
+	report, err := getPolicyReport(r)
+	require.NoError(t, err)
+	require.Len(t, report.GetOwnerReferences(), 1)
+	assert.Equal(t, SingletonConfigName, report.GetOwnerReferences()[0].Name)
+	results, _, err := unstructured.NestedSlice(report.Object, "results")
+	require.NoError(t, err)
+	assert.Len(t, results, 4)
+
+	// A second scan replaces the results
+	reconcileCompliance(t, r)
+	report, err = getPolicyReport(r)
+	require.NoError(t, err)
+	results, _, err = unstructured.NestedSlice(report.Object, "results")
+	require.NoError(t, err)
+	assert.Len(t, results, 4)
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config))
+	config.Spec.Compliance.PolicyReport = false
+	require.NoError(t, r.Update(ctx, config))
+	reconcileCompliance(t, r)
+	_, err = getPolicyReport(r)
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"reflect"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// configChangedPredicate passes spec changes of a SecretsManagementConfig and changes of its
+// annotations, which request actions such as ForceDeleteAnnotation and RestoredAtAnnotation
+// without bumping the generation. Status-only writes, including our own, are dropped.
+func configChangedPredicate() predicate.Predicate {
+	return predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})
+}
+
+// ownedObjectChangedPredicate drops update events for owned objects whose content did not change,
+// such as resyncs and writes that only touch resourceVersion or managedFields. Deployment
+// availability changes are let through because they feed status.
+func ownedObjectChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return true
+			}
+			if !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) ||
+				!reflect.DeepEqual(e.ObjectOld.GetAnnotations(), e.ObjectNew.GetAnnotations()) ||
+				!reflect.DeepEqual(e.ObjectOld.GetOwnerReferences(), e.ObjectNew.GetOwnerReferences()) ||
+				e.ObjectOld.GetDeletionTimestamp().IsZero() != e.ObjectNew.GetDeletionTimestamp().IsZero() {
+				return true
+			}
+
+			if oldDeployment, ok := e.ObjectOld.(*appsv1.Deployment); ok {
+				newDeployment, ok := e.ObjectNew.(*appsv1.Deployment)
+				// Replicas drops to the updated replicas when a rollout finishes, for the OperatorCondition
+				// and the Progressing condition, which also follows the observed generation and deadline
+				if !ok || oldDeployment.Status.AvailableReplicas != newDeployment.Status.AvailableReplicas ||
+					oldDeployment.Status.ReadyReplicas != newDeployment.Status.ReadyReplicas ||
+					oldDeployment.Status.UpdatedReplicas != newDeployment.Status.UpdatedReplicas ||
+					oldDeployment.Status.Replicas != newDeployment.Status.Replicas ||
+					oldDeployment.Status.ObservedGeneration != newDeployment.Status.ObservedGeneration ||
+					(deploymentStalled(oldDeployment) == nil) != (deploymentStalled(newDeployment) == nil) {
+					return true
+				}
+			}
+
+			oldHash, err := contentHash(e.ObjectOld)
+			if err != nil {
+				return true
+			}
+			newHash, err := contentHash(e.ObjectNew)
+			if err != nil {
+				return true
+			}
+			return oldHash != newHash
+		},
+	}
+}
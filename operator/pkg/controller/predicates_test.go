@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestConfigChangedPredicate(t *testing.T) {
+	p := configChangedPredicate()
+	base := newTestConfig(SingletonConfigName)
+	base.Generation = 1
+
+	status := base.DeepCopy()
+	status.Status.ObservedGeneration = 1
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: base, ObjectNew: status}), "status-only change")
+
+	spec := base.DeepCopy()
+	spec.Generation = 2
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: base, ObjectNew: spec}), "spec change")
+
+	annotated := base.DeepCopy()
+	annotated.Annotations = map[string]string{ForceDeleteAnnotation: "true"}
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: base, ObjectNew: annotated}), "annotation change")
+}
+
+func TestOwnedObjectChangedPredicate(t *testing.T) {
+	p := ownedObjectChangedPredicate()
+	base := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", ResourceVersion: "1", Labels: map[string]string{"a": "b"}},
+		Data:       map[string]string{"k": "v"},
+	}
+
+	resync := base.DeepCopy()
+	resync.ResourceVersion = "2"
+	resync.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "other"}}
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: base, ObjectNew: resync}), "metadata-only change")
+
+	data := base.DeepCopy()
+	data.Data["k"] = "changed"
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: base, ObjectNew: data}), "data change")
+
+	labels := base.DeepCopy()
+	labels.Labels = nil
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: base, ObjectNew: labels}), "label change")
+
+	assert.True(t, p.Create(event.CreateEvent{Object: base}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: base}))
+}
+
+func TestOwnedObjectChangedPredicate_DeploymentAvailability(t *testing.T) {
+	p := ownedObjectChangedPredicate()
+	old := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "d"}}
+
+	collided := old.DeepCopy()
+	collided.Status.CollisionCount = int32Ptr(1)
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: collided}), "irrelevant status change")
+
+	observed := old.DeepCopy()
+	observed.Status.ObservedGeneration = 1
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: observed}), "new generation observed")
+
+	stalled := old.DeepCopy()
+	stalled.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"}}
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: stalled}), "rollout stalled")
+
+	available := old.DeepCopy()
+	available.Status.AvailableReplicas = 1
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: available}), "availability change")
+
+	scaledDown := old.DeepCopy()
+	scaledDown.Status.Replicas = 1
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: scaledDown}), "old replicas removed after a rollout")
+}
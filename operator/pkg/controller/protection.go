@@ -0,0 +1,254 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// ProtectionPolicyName is the name of the ValidatingAdmissionPolicy and its binding
+	ProtectionPolicyName = "secrets-management-protect-managed-resources"
+
+	// ProtectionOverrideAnnotation exempts an object from the protection policy when set to "true"
+	ProtectionOverrideAnnotation = "secrets-management.openshift.io/allow-modification"
+
+	// OperatorServiceAccountName is the ServiceAccount the operator Deployment runs as
+	OperatorServiceAccountName = "secrets-management-operator"
+
+	// OperatorNamespaceEnv is set through the downward API to the namespace the operator runs in
+	OperatorNamespaceEnv = "OPERATOR_NAMESPACE"
+)
+
+// reconcileProtection ensures the admission policy guarding operator-managed resources matches
+// spec.protection, removing it when protection is disabled. Only the primary config owns it.
+func (r *SecretsManagementConfigReconciler) reconcileProtection(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	if !config.Spec.Protection.Enabled {
+		if err := r.cleanupProtection(ctx); err != nil {
+			return err
+		}
+		r.setCondition(config, smv1alpha1.ConditionProtectionConfigured, "False", smv1alpha1.ReasonProtectionDisabled, "Managed resources are not protected by an admission policy")
+		return nil
+	}
+
+	served, err := r.applyAdmissionPolicy(ctx, config, buildProtectionPolicy(r.operatorUsername()), buildPolicyBinding(ProtectionPolicyName, map[string]string{
+		"app.kubernetes.io/managed-by": "secrets-management-operator",
+	}))
+	if err != nil {
+		return err
+	}
+	if !served {
+		r.setCondition(config, smv1alpha1.ConditionProtectionConfigured, "False", smv1alpha1.ReasonAdmissionPolicyAPIUnavailable, "admissionregistration.k8s.io/v1beta1 ValidatingAdmissionPolicy is not served by this cluster")
+		return nil
+	}
+
+	r.setCondition(config, smv1alpha1.ConditionProtectionConfigured, "True", smv1alpha1.ReasonProtectionPolicyApplied, "Managed resources can only be modified by the operator")
+	return nil
+}
+
+// applyAdmissionPolicy creates or updates a ValidatingAdmissionPolicy and its binding. It reports
+// false without error when the cluster does not serve the ValidatingAdmissionPolicy API.
+func (r *SecretsManagementConfigReconciler) applyAdmissionPolicy(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, policy *admissionregistrationv1beta1.ValidatingAdmissionPolicy, binding *admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding) (bool, error) {
+	for _, obj := range []client.Object{policy, binding} {
+		applyCommonMetadata(config, obj)
+		if err := setAppliedSpecHash(obj); err != nil {
+			return false, err
+		}
+	}
+
+	existingPolicy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+	err := r.Get(ctx, types.NamespacedName{Name: policy.Name}, existingPolicy)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+		if err := r.Create(ctx, policy); err != nil {
+			return false, err
+		}
+	} else {
+		before := existingPolicy.DeepCopy()
+		if specChanged(existingPolicy, policy, existingPolicy.Spec, policy.Spec) {
+			existingPolicy.Spec = policy.Spec
+		}
+		mergeMetadata(existingPolicy, policy)
+		if err := updateIfChanged(ctx, r, before, existingPolicy); err != nil {
+			return false, err
+		}
+	}
+
+	existingBinding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+	err = r.Get(ctx, types.NamespacedName{Name: binding.Name}, existingBinding)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+		return true, r.Create(ctx, binding)
+	}
+
+	before := existingBinding.DeepCopy()
+	if specChanged(existingBinding, binding, existingBinding.Spec, binding.Spec) {
+		existingBinding.Spec = binding.Spec
+	}
+	mergeMetadata(existingBinding, binding)
+	return true, updateIfChanged(ctx, r, before, existingBinding)
+}
+
+// operatorUsername returns the username the operator authenticates as
+func (r *SecretsManagementConfigReconciler) operatorUsername() string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", r.operatorNamespace(), OperatorServiceAccountName)
+}
+
+// operatorNamespace returns the namespace the operator runs in
+func (r *SecretsManagementConfigReconciler) operatorNamespace() string {
+	if r.OperatorNamespace == "" {
+		return PluginNamespace
+	}
+	return r.OperatorNamespace
+}
+
+// protectionExemptControllers are the OpenShift controllers writing objects the operator manages:
+// the service CA operator injects serving certificates and CA bundles, and the cluster policy
+// controller allocates the namespace's UID ranges and adds image pull secrets to ServiceAccounts.
+var protectionExemptControllers = []string{
+	"system:serviceaccount:openshift-service-ca:service-ca",
+	"system:serviceaccount:openshift-infra:namespace-security-allocation-controller",
+	"system:serviceaccount:openshift-infra:serviceaccount-pull-secrets-controller",
+}
+
+// buildProtectionPolicy creates the ValidatingAdmissionPolicy rejecting updates and deletes of
+// objects labeled as managed by the operator. Controllers in kube-system and
+// protectionExemptControllers keep write access so serving certificates, CA bundle injection and
+// garbage collection work. Objects already carrying the override annotation are exempt; adding it
+// takes update access to SecretsManagementConfigs, so it cannot be set in the edit it exempts.
+func buildProtectionPolicy(operatorUsername string) *admissionregistrationv1beta1.ValidatingAdmissionPolicy {
+	failurePolicy := admissionregistrationv1beta1.Fail
+	updateOrDelete := []admissionregistrationv1.OperationType{admissionregistrationv1.Update, admissionregistrationv1.Delete}
+	return &admissionregistrationv1beta1.ValidatingAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ProtectionPolicyName,
+			Labels: map[string]string{
+				"app.kubernetes.io/part-of":    "ocp-secrets-management",
+				"app.kubernetes.io/managed-by": "secrets-management-operator",
+			},
+		},
+		Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicySpec{
+			FailurePolicy: &failurePolicy,
+			MatchConstraints: &admissionregistrationv1beta1.MatchResources{
+				ResourceRules: []admissionregistrationv1beta1.NamedRuleWithOperations{
+					admissionRule(updateOrDelete, "", "namespaces", "services", "serviceaccounts", "configmaps", "resourcequotas", "limitranges"),
+					admissionRule(updateOrDelete, "apps", "deployments"),
+					admissionRule(updateOrDelete, "rbac.authorization.k8s.io", "clusterroles"),
+					admissionRule(updateOrDelete, "console.openshift.io", "consoleplugins"),
+					admissionRule(updateOrDelete, "route.openshift.io", "routes"),
+				},
+			},
+			Variables: []admissionregistrationv1beta1.Variable{
+				{
+					// The override is checked on the stored object, so an update cannot exempt itself
+					Name:       "overridden",
+					Expression: annotationTrue("oldObject", ProtectionOverrideAnnotation),
+				},
+				{
+					Name:       "overriding",
+					Expression: "request.operation == 'UPDATE' && " + annotationTrue("object", ProtectionOverrideAnnotation),
+				},
+			},
+			Validations: []admissionregistrationv1beta1.Validation{
+				{
+					Expression: fmt.Sprintf("request.userInfo.username == '%s' || "+
+						"request.userInfo.username.startsWith('system:serviceaccount:kube-system:') || "+
+						"request.userInfo.username in ['%s'] || "+
+						"variables.overridden || "+
+						"(variables.overriding && authorizer.group('%s').resource('secretsmanagementconfigs').check('update').allowed())",
+						operatorUsername, strings.Join(protectionExemptControllers, "', '"), smv1alpha1.GroupVersion.Group),
+					MessageExpression: fmt.Sprintf("request.kind.kind + ' ' + oldObject.metadata.name + ' is managed by the secrets-management operator; "+
+						"change the SecretsManagementConfig instead, or, with update access to it, annotate the object with %s=true to override'", ProtectionOverrideAnnotation),
+					Reason: reasonPtr(metav1.StatusReasonForbidden),
+				},
+			},
+		},
+	}
+}
+
+// buildPolicyBinding creates a binding denying requests that fail the named policy, limited to objects carrying matchLabels
+func buildPolicyBinding(policyName string, matchLabels map[string]string) *admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding {
+	return &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+			Labels: map[string]string{
+				"app.kubernetes.io/part-of":    "ocp-secrets-management",
+				"app.kubernetes.io/managed-by": "secrets-management-operator",
+			},
+		},
+		Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicyBindingSpec{
+			PolicyName: policyName,
+			MatchResources: &admissionregistrationv1beta1.MatchResources{
+				ObjectSelector: &metav1.LabelSelector{
+					MatchLabels: matchLabels,
+				},
+			},
+			ValidationActions: []admissionregistrationv1beta1.ValidationAction{admissionregistrationv1beta1.Deny},
+		},
+	}
+}
+
+// annotationTrue returns a CEL expression testing that the object named obj carries annotation set to "true"
+func annotationTrue(obj, annotation string) string {
+	return fmt.Sprintf("has(%[1]s.metadata.annotations) && '%[2]s' in %[1]s.metadata.annotations && "+
+		"%[1]s.metadata.annotations['%[2]s'] == 'true'", obj, annotation)
+}
+
+// admissionRule matches the given operations on resources in an API group
+func admissionRule(operations []admissionregistrationv1.OperationType, group string, resources ...string) admissionregistrationv1beta1.NamedRuleWithOperations {
+	return admissionregistrationv1beta1.NamedRuleWithOperations{
+		RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+			Operations: operations,
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{group},
+				APIVersions: []string{"*"},
+				Resources:   resources,
+			},
+		},
+	}
+}
+
+// cleanupProtection removes the protection policy and its binding
+func (r *SecretsManagementConfigReconciler) cleanupProtection(ctx context.Context) error {
+	return r.cleanupAdmissionPolicy(ctx, ProtectionPolicyName)
+}
+
+// cleanupAdmissionPolicy removes a ValidatingAdmissionPolicy and the binding of the same name
+func (r *SecretsManagementConfigReconciler) cleanupAdmissionPolicy(ctx context.Context, name string) error {
+	binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	if err := r.Delete(ctx, binding); err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+
+	policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	if err := r.Delete(ctx, policy); err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+
+	return nil
+}
+
+func reasonPtr(reason metav1.StatusReason) *metav1.StatusReason {
+	return &reason
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestReconcileProtection(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Protection.Enabled = true
+	r := newTestReconciler()
+	r.OperatorNamespace = "secrets-operator"
+
+	require.NoError(t, r.reconcileProtection(ctx, config))
+
+	policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: ProtectionPolicyName}, policy))
+	require.Len(t, policy.Spec.Validations, 1)
+	assert.Contains(t, policy.Spec.Validations[0].Expression, "'system:serviceaccount:secrets-operator:secrets-management-operator'")
+	assert.NotContains(t, policy.Spec.Validations[0].Expression, "startsWith('system:serviceaccount:openshift-')")
+	assert.Contains(t, policy.Spec.Validations[0].Expression, "'system:serviceaccount:openshift-service-ca:service-ca'")
+	// An update is exempt by the annotation it replaces, and only config editors may add it
+	require.Len(t, policy.Spec.Variables, 2)
+	assert.Equal(t, "overridden", policy.Spec.Variables[0].Name)
+	assert.Contains(t, policy.Spec.Variables[0].Expression, "oldObject.metadata.annotations['"+ProtectionOverrideAnnotation+"'] == 'true'")
+	assert.Contains(t, policy.Spec.Validations[0].Expression,
+		"variables.overriding && authorizer.group('secrets-management.openshift.io').resource('secretsmanagementconfigs').check('update').allowed()")
+	require.NotNil(t, policy.Spec.FailurePolicy)
+	assert.Equal(t, admissionregistrationv1beta1.Fail, *policy.Spec.FailurePolicy)
+
+	binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: ProtectionPolicyName}, binding))
+	assert.Equal(t, ProtectionPolicyName, binding.Spec.PolicyName)
+	assert.Equal(t, []admissionregistrationv1beta1.ValidationAction{admissionregistrationv1beta1.Deny}, binding.Spec.ValidationActions)
+	assert.Equal(t, "secrets-management-operator", binding.Spec.MatchResources.ObjectSelector.MatchLabels["app.kubernetes.io/managed-by"])
+
+	cond := findCondition(config, smv1alpha1.ConditionProtectionConfigured)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonProtectionPolicyApplied, cond.Reason)
+
+	// Disabling protection removes the policy and binding
+	config.Spec.Protection.Enabled = false
+	require.NoError(t, r.reconcileProtection(ctx, config))
+
+	err := r.Get(ctx, types.NamespacedName{Name: ProtectionPolicyName}, &admissionregistrationv1beta1.ValidatingAdmissionPolicy{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = r.Get(ctx, types.NamespacedName{Name: ProtectionPolicyName}, &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{})
+	assert.True(t, apierrors.IsNotFound(err))
+	cond = findCondition(config, smv1alpha1.ConditionProtectionConfigured)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonProtectionDisabled, cond.Reason)
+}
+
+func TestOperatorUsername_DefaultsToPluginNamespace(t *testing.T) {
+	r := newTestReconciler()
+	assert.Equal(t, "system:serviceaccount:"+PluginNamespace+":secrets-management-operator", r.operatorUsername())
+}
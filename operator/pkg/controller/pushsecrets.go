@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// MaxPushSecretFailures bounds the failing PushSecrets listed in status
+const MaxPushSecretFailures = 50
+
+// PushSecret GroupVersionKind for the External Secrets Operator
+var pushSecretGVK = schema.GroupVersionKind{
+	Group:   "external-secrets.io",
+	Version: "v1alpha1",
+	Kind:    "PushSecret",
+}
+
+// reconcilePushSecrets reports the PushSecrets whose Ready condition is False, since a PushSecret
+// that stops pushing leaves the external store stale without anything failing in the cluster.
+// The failing count is exported for the SecretsManagementPushSecretFailing alert. PushSecrets live
+// in workload namespaces, so they are not reported in restricted mode.
+func (r *SecretsManagementConfigReconciler) reconcilePushSecrets(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	if r.Restricted || !config.Status.DetectedOperators.ExternalSecrets.Installed {
+		config.Status.PushSecrets = smv1alpha1.PushSecretsStatus{}
+		pushSecretsFailing.Set(0)
+		return nil
+	}
+
+	status := smv1alpha1.PushSecretsStatus{}
+	err := r.forEachResource(ctx, pushSecretGVK, func(pushSecret *unstructured.Unstructured) {
+		status.Total++
+		state, reason, message, since := findConditionOf(pushSecret, "Ready")
+		if state != string(corev1.ConditionFalse) {
+			return
+		}
+		status.Failing++
+		failure := smv1alpha1.PushSecretFailure{
+			Namespace: pushSecret.GetNamespace(),
+			Name:      pushSecret.GetName(),
+			Reason:    reason,
+			Message:   message,
+		}
+		if !since.IsZero() {
+			failure.Since = &metav1.Time{Time: since}
+		}
+		status.Failures = append(status.Failures, failure)
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(status.Failures, func(i, j int) bool {
+		if ti, tj := pushSecretFailureSince(status.Failures[i]), pushSecretFailureSince(status.Failures[j]); !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		if status.Failures[i].Namespace != status.Failures[j].Namespace {
+			return status.Failures[i].Namespace < status.Failures[j].Namespace
+		}
+		return status.Failures[i].Name < status.Failures[j].Name
+	})
+	if len(status.Failures) > MaxPushSecretFailures {
+		status.Failures = status.Failures[:MaxPushSecretFailures]
+	}
+	config.Status.PushSecrets = status
+	pushSecretsFailing.Set(float64(status.Failing))
+
+	if status.Failing > 0 {
+		first := status.Failures[0]
+		r.setCondition(config, smv1alpha1.ConditionPushSecretsSynced, "False", smv1alpha1.ReasonPushSecretSyncFailed,
+			fmt.Sprintf("%d of %d PushSecret(s) are failing to push, including %s/%s: %s",
+				status.Failing, status.Total, first.Namespace, first.Name, valueOr(first.Message, first.Reason)))
+		return nil
+	}
+	r.setCondition(config, smv1alpha1.ConditionPushSecretsSynced, "True", smv1alpha1.ReasonPushSecretsSynced,
+		fmt.Sprintf("%d PushSecret(s) are pushing to their stores", status.Total))
+	return nil
+}
+
+// pushSecretFailureSince returns when a PushSecret started failing, zero when unknown
+func pushSecretFailureSince(failure smv1alpha1.PushSecretFailure) time.Time {
+	if failure.Since == nil {
+		return time.Time{}
+	}
+	return failure.Since.Time
+}
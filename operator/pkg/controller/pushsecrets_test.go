@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestPushSecret(namespace, name string, ready map[string]interface{}) *unstructured.Unstructured {
+	pushSecret := &unstructured.Unstructured{}
+	pushSecret.SetGroupVersionKind(pushSecretGVK)
+	pushSecret.SetNamespace(namespace)
+	pushSecret.SetName(name)
+	if ready != nil {
+		ready["type"] = "Ready"
+		_ = unstructured.SetNestedSlice(pushSecret.Object, []interface{}{ready}, "status", "conditions")
+	}
+	return pushSecret
+}
+
+func TestReconcilePushSecrets(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	r := newTestReconciler(
+		newTestPushSecret("app", "synced", map[string]interface{}{"status": "True", "reason": "Synced"}),
+		newTestPushSecret("app", "new", nil),
+		newTestPushSecret("billing", "api-key", map[string]interface{}{
+			"status": "False", "reason": "Errored", "message": "set secret failed: AccessDenied", "lastTransitionTime": "2026-01-02T00:00:00Z",
+		}),
+		newTestPushSecret("app", "db", map[string]interface{}{
+			"status": "False", "reason": "Errored", "message": "store is not ready", "lastTransitionTime": "2026-01-01T00:00:00Z",
+		}),
+	)
+
+	require.NoError(t, r.reconcilePushSecrets(ctx, config))
+
+	status := config.Status.PushSecrets
+	assert.Equal(t, int32(4), status.Total)
+	assert.Equal(t, int32(2), status.Failing)
+	require.Len(t, status.Failures, 2)
+	assert.Equal(t, "db", status.Failures[0].Name, "the longest failing PushSecret is listed first")
+	assert.Equal(t, "Errored", status.Failures[0].Reason)
+	require.NotNil(t, status.Failures[0].Since)
+	assert.Equal(t, "api-key", status.Failures[1].Name)
+	assert.Equal(t, float64(2), testutil.ToFloat64(pushSecretsFailing))
+	cond := findCondition(config, smv1alpha1.ConditionPushSecretsSynced)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonPushSecretSyncFailed, cond.Reason)
+	assert.Contains(t, cond.Message, "2 of 4 PushSecret(s)")
+	assert.Contains(t, cond.Message, "app/db: store is not ready")
+
+	// Once the failing PushSecrets are gone, the failures and the metric clear
+	require.NoError(t, r.Delete(ctx, newTestPushSecret("app", "db", nil)))
+	require.NoError(t, r.Delete(ctx, newTestPushSecret("billing", "api-key", nil)))
+	require.NoError(t, r.reconcilePushSecrets(ctx, config))
+	assert.Equal(t, smv1alpha1.PushSecretsStatus{Total: 2}, config.Status.PushSecrets)
+	assert.Equal(t, float64(0), testutil.ToFloat64(pushSecretsFailing))
+	cond = findCondition(config, smv1alpha1.ConditionPushSecretsSynced)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+}
+
+func TestReconcilePushSecrets_ExternalSecretsNotInstalled(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.PushSecrets = smv1alpha1.PushSecretsStatus{Total: 1, Failing: 1}
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcilePushSecrets(context.Background(), config))
+	assert.Empty(t, config.Status.PushSecrets)
+	assert.Nil(t, findCondition(config, smv1alpha1.ConditionPushSecretsSynced))
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// maxBindingSubjects caps how many subjects status.rbac.bindings lists per ClusterRole
+const maxBindingSubjects = 50
+
+// summarizeRoleBindings returns who is bound to each of the named ClusterRoles, in the order
+// given. Bindings may live in any namespace and are not cached, so they are read from the API
+// server; in restricted mode only RoleBindings in the plugin namespace are read.
+func (r *SecretsManagementConfigReconciler) summarizeRoleBindings(ctx context.Context, names []string) ([]smv1alpha1.RoleBindingsStatus, error) {
+	summaries := make(map[string]*roleBindingsSummary, len(names))
+	for _, name := range names {
+		summaries[name] = &roleBindingsSummary{subjects: map[rbacv1.Subject]*smv1alpha1.BindingSubjectStatus{}}
+	}
+
+	clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+	if err := r.apiReader().List(ctx, clusterRoleBindings); err != nil {
+		return nil, err
+	}
+	for _, binding := range clusterRoleBindings.Items {
+		if summary := summaries[boundClusterRole(binding.RoleRef)]; summary != nil {
+			summary.status.ClusterRoleBindings++
+			summary.add(binding.Subjects, true)
+		}
+	}
+
+	var opts []client.ListOption
+	if r.Restricted {
+		opts = append(opts, client.InNamespace(PluginNamespace))
+	}
+	roleBindings := &rbacv1.RoleBindingList{}
+	if err := r.apiReader().List(ctx, roleBindings, opts...); err != nil {
+		return nil, err
+	}
+	for _, binding := range roleBindings.Items {
+		if summary := summaries[boundClusterRole(binding.RoleRef)]; summary != nil {
+			summary.status.RoleBindings++
+			summary.add(binding.Subjects, false)
+		}
+	}
+
+	statuses := make([]smv1alpha1.RoleBindingsStatus, 0, len(names))
+	for _, name := range names {
+		status := summaries[name].result()
+		status.ClusterRole = name
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// boundClusterRole returns the name of the ClusterRole ref points to, or "" for a Role
+func boundClusterRole(ref rbacv1.RoleRef) string {
+	if ref.Kind != "ClusterRole" {
+		return ""
+	}
+	return ref.Name
+}
+
+// roleBindingsSummary accumulates the bindings of one ClusterRole
+type roleBindingsSummary struct {
+	status   smv1alpha1.RoleBindingsStatus
+	subjects map[rbacv1.Subject]*smv1alpha1.BindingSubjectStatus
+}
+
+// add counts a binding granting the role to subjects
+func (s *roleBindingsSummary) add(subjects []rbacv1.Subject, clusterWide bool) {
+	for _, subject := range subjects {
+		// The API group differs between subject kinds and does not identify a subject
+		key := rbacv1.Subject{Kind: subject.Kind, Name: subject.Name, Namespace: subject.Namespace}
+		entry := s.subjects[key]
+		if entry == nil {
+			entry = &smv1alpha1.BindingSubjectStatus{Kind: subject.Kind, Name: subject.Name, Namespace: subject.Namespace}
+			s.subjects[key] = entry
+		}
+		entry.Bindings++
+		entry.ClusterWide = entry.ClusterWide || clusterWide
+	}
+}
+
+// result returns the summary with its subjects sorted by kind, namespace and name
+func (s *roleBindingsSummary) result() smv1alpha1.RoleBindingsStatus {
+	status := s.status
+	subjects := make([]smv1alpha1.BindingSubjectStatus, 0, len(s.subjects))
+	for _, subject := range s.subjects {
+		subjects = append(subjects, *subject)
+	}
+	sort.Slice(subjects, func(i, j int) bool {
+		if subjects[i].Kind != subjects[j].Kind {
+			return subjects[i].Kind < subjects[j].Kind
+		}
+		if subjects[i].Namespace != subjects[j].Namespace {
+			return subjects[i].Namespace < subjects[j].Namespace
+		}
+		return subjects[i].Name < subjects[j].Name
+	})
+	status.TotalSubjects = int32(len(subjects))
+	if len(subjects) > maxBindingSubjects {
+		subjects = subjects[:maxBindingSubjects]
+	}
+	if len(subjects) > 0 {
+		status.Subjects = subjects
+	}
+	return status
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestReconcileRBAC_Bindings(t *testing.T) {
+	ctx := context.Background()
+	alice := rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "alice"}
+	auditors := rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "auditors"}
+	robot := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "robot", Namespace: "ci"}
+	r := newTestReconciler(
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "auditors-view"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "secrets-management-view"},
+			Subjects:   []rbacv1.Subject{auditors, alice},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice-view", Namespace: "team-a"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "secrets-management-view"},
+			Subjects:   []rbacv1.Subject{alice},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "robot-delete", Namespace: "ci"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "secrets-management-delete"},
+			Subjects:   []rbacv1.Subject{robot},
+		},
+		// A Role of the same name is not a managed ClusterRole
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "team-a"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "secrets-management-admin"},
+			Subjects:   []rbacv1.Subject{alice},
+		},
+	)
+	config := newTestConfig(SingletonConfigName)
+
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	assert.Equal(t, []smv1alpha1.RoleBindingsStatus{
+		{
+			ClusterRole:         "secrets-management-view",
+			ClusterRoleBindings: 1,
+			RoleBindings:        1,
+			TotalSubjects:       2,
+			Subjects: []smv1alpha1.BindingSubjectStatus{
+				{Kind: "Group", Name: "auditors", Bindings: 1, ClusterWide: true},
+				{Kind: "User", Name: "alice", Bindings: 2, ClusterWide: true},
+			},
+		},
+		{
+			ClusterRole:   "secrets-management-delete",
+			RoleBindings:  1,
+			TotalSubjects: 1,
+			Subjects: []smv1alpha1.BindingSubjectStatus{
+				{Kind: "ServiceAccount", Name: "robot", Namespace: "ci", Bindings: 1},
+			},
+		},
+		{ClusterRole: "secrets-management-admin"},
+	}, config.Status.RBAC.Bindings)
+
+	// Restricted mode only counts RoleBindings in the plugin namespace
+	r.Restricted = true
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	assert.Equal(t, int32(0), config.Status.RBAC.Bindings[0].RoleBindings)
+	assert.Equal(t, int32(1), config.Status.RBAC.Bindings[0].ClusterRoleBindings)
+	assert.Equal(t, int32(0), config.Status.RBAC.Bindings[1].RoleBindings)
+}
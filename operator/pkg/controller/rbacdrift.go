@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// maxRBACDriftLines caps how many diff lines per ClusterRole the RBACDrifted condition shows
+const maxRBACDriftLines = 5
+
+// clusterRoleDrift returns how the rules of existing differ from those of desired when existing
+// was changed on the cluster since the operator last wrote it, which its applied-spec-hash
+// annotation records. Roles the operator changes because the spec changed are not drift.
+func clusterRoleDrift(existing, desired *rbacv1.ClusterRole) ([]string, error) {
+	applied := existing.GetAnnotations()[AppliedSpecHashAnnotation]
+	if applied == "" {
+		return nil, nil
+	}
+	live, err := contentHash(existing.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+	if live == applied {
+		return nil, nil
+	}
+	return objectDiff(
+		&rbacv1.ClusterRole{Rules: desired.Rules, AggregationRule: desired.AggregationRule},
+		&rbacv1.ClusterRole{Rules: existing.Rules, AggregationRule: existing.AggregationRule},
+	)
+}
+
+// reportRBACDrift sets the RBACDrifted condition from the diffs of the generated ClusterRoles
+// changed on the cluster, by name, and emits a Warning event on config when there are any
+func (r *SecretsManagementConfigReconciler) reportRBACDrift(config *smv1alpha1.SecretsManagementConfig, drifted map[string][]string) {
+	if len(drifted) == 0 {
+		r.setCondition(config, smv1alpha1.ConditionRBACDrifted, "False", smv1alpha1.ReasonNoRBACDrift, "The generated ClusterRoles match the desired rules")
+		return
+	}
+
+	names := make([]string, 0, len(drifted))
+	for name := range drifted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	summaries := make([]string, 0, len(names))
+	for _, name := range names {
+		lines := drifted[name]
+		if len(lines) > maxRBACDriftLines {
+			lines = append(lines[:maxRBACDriftLines:maxRBACDriftLines], fmt.Sprintf("%d more", len(drifted[name])-maxRBACDriftLines))
+		}
+		summaries = append(summaries, fmt.Sprintf("%s (%s)", name, strings.Join(lines, "; ")))
+	}
+	message := fmt.Sprintf("ClusterRole(s) changed on the cluster and reverted: %s", strings.Join(summaries, ", "))
+	r.setCondition(config, smv1alpha1.ConditionRBACDrifted, "True", smv1alpha1.ReasonRBACDriftDetected, message)
+	r.Log.Info("Reverted changes to generated ClusterRoles", "config", config.Name, "clusterRoles", names)
+	if r.Recorder != nil {
+		r.Recorder.Event(config, corev1.EventTypeWarning, "RBACDrifted", message)
+	}
+}
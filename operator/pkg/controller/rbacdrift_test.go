@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestReconcileRBAC_Drift(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler()
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	cond := findCondition(config, smv1alpha1.ConditionRBACDrifted)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+
+	// A spec change rewrites the roles without being reported as drift
+	config.Spec.RBAC.IncludeCoreSecrets = true
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	cond = findCondition(config, smv1alpha1.ConditionRBACDrifted)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonNoRBACDrift, cond.Reason)
+	assert.Empty(t, recorder.Events)
+
+	// A rule added on the cluster is reported and reverted
+	view := &rbacv1.ClusterRole{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-view"}, view))
+	desired := view.DeepCopy().Rules
+	view.Rules = append(view.Rules, rbacv1.PolicyRule{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"clusterrolebindings"}, Verbs: []string{"create"}})
+	require.NoError(t, r.Update(ctx, view))
+
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	cond = findCondition(config, smv1alpha1.ConditionRBACDrifted)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonRBACDriftDetected, cond.Reason)
+	assert.Contains(t, cond.Message, "secrets-management-view")
+	assert.Contains(t, cond.Message, "clusterrolebindings")
+	assert.NotContains(t, cond.Message, "secrets-management-admin")
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Warning RBACDrifted")
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-view"}, view))
+	assert.Equal(t, desired, view.Rules)
+
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	cond = findCondition(config, smv1alpha1.ConditionRBACDrifted)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// rulesOverrideRoles are the ConfigMap keys patching the generated ClusterRoles, by role suffix
+var rulesOverrideRoles = []string{"view", "delete", "admin"}
+
+// rulesOverrideForbiddenVerbs let holders of a role grant themselves more; they cannot be added
+var rulesOverrideForbiddenVerbs = map[string]bool{"*": true, "escalate": true, "bind": true, "impersonate": true}
+
+// rulesPatch is the patch of one generated ClusterRole held by the rules override ConfigMap
+type rulesPatch struct {
+	// Remove drops their resources from the role's rules of the same API groups
+	Remove []rbacv1.PolicyRule `json:"remove,omitempty"`
+
+	// Add appends rules to the role
+	Add []rbacv1.PolicyRule `json:"add,omitempty"`
+}
+
+// loadRulesOverride returns the patches of spec.rbac.rulesOverrideConfigMap by role suffix and
+// reports them in the RulesOverridden condition. It returns no patches while the ConfigMap is
+// unset, missing or invalid, so the built-in rules are kept.
+func (r *SecretsManagementConfigReconciler) loadRulesOverride(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) (map[string]rulesPatch, error) {
+	name := config.Spec.RBAC.RulesOverrideConfigMap
+	if name == "" {
+		r.setCondition(config, smv1alpha1.ConditionRulesOverridden, "False", smv1alpha1.ReasonRulesOverrideNotConfigured, "The generated ClusterRoles have the built-in rules")
+		return nil, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: PluginNamespace, Name: name}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			r.setCondition(config, smv1alpha1.ConditionRulesOverridden, "False", smv1alpha1.ReasonRulesOverrideConfigMapNotFound, fmt.Sprintf("ConfigMap %s/%s not found; keeping the built-in rules", PluginNamespace, name))
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	patches, err := parseRulesOverride(cm.Data)
+	if err != nil {
+		r.setCondition(config, smv1alpha1.ConditionRulesOverridden, "False", smv1alpha1.ReasonRulesOverrideInvalid, fmt.Sprintf("ConfigMap %s/%s: %v; keeping the built-in rules", PluginNamespace, name, err))
+		return nil, nil
+	}
+	roles := make([]string, 0, len(patches))
+	for role := range patches {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	r.setCondition(config, smv1alpha1.ConditionRulesOverridden, "True", smv1alpha1.ReasonRulesOverrideApplied, fmt.Sprintf("Rules patched from ConfigMap %s/%s for the %s role(s)", PluginNamespace, name, strings.Join(roles, ", ")))
+	return patches, nil
+}
+
+// parseRulesOverride parses and validates the patches in the data of the rules override ConfigMap
+func parseRulesOverride(data map[string]string) (map[string]rulesPatch, error) {
+	known := make(map[string]bool, len(rulesOverrideRoles))
+	for _, role := range rulesOverrideRoles {
+		known[role] = true
+	}
+
+	patches := make(map[string]rulesPatch, len(data))
+	for key, value := range data {
+		if !known[key] {
+			return nil, fmt.Errorf("unknown key %q, expected one of %s", key, strings.Join(rulesOverrideRoles, ", "))
+		}
+		var patch rulesPatch
+		if err := yaml.UnmarshalStrict([]byte(value), &patch); err != nil {
+			return nil, fmt.Errorf("%s: %v", key, err)
+		}
+		for i, rule := range patch.Remove {
+			if len(rule.APIGroups) == 0 || len(rule.Resources) == 0 {
+				return nil, fmt.Errorf("%s: remove[%d] must set apiGroups and resources", key, i)
+			}
+			if len(rule.Verbs) > 0 || len(rule.ResourceNames) > 0 || len(rule.NonResourceURLs) > 0 {
+				return nil, fmt.Errorf("%s: remove[%d] selects resources by apiGroups and resources only", key, i)
+			}
+		}
+		for i, rule := range patch.Add {
+			if msg := validateAddedRule(rule); msg != "" {
+				return nil, fmt.Errorf("%s: add[%d] %s", key, i, msg)
+			}
+		}
+		patches[key] = patch
+	}
+	return patches, nil
+}
+
+// validateAddedRule returns why rule cannot be added to a generated role, or "" when it can
+func validateAddedRule(rule rbacv1.PolicyRule) string {
+	if len(rule.APIGroups) == 0 || len(rule.Resources) == 0 || len(rule.Verbs) == 0 {
+		return "must set apiGroups, resources and verbs"
+	}
+	if len(rule.NonResourceURLs) > 0 {
+		return "cannot grant nonResourceURLs"
+	}
+	for _, value := range append(append([]string{}, rule.APIGroups...), rule.Resources...) {
+		if value == "*" {
+			return "cannot use wildcard API groups or resources"
+		}
+	}
+	for _, verb := range rule.Verbs {
+		if rulesOverrideForbiddenVerbs[verb] {
+			return fmt.Sprintf("cannot grant the %q verb", verb)
+		}
+	}
+	return ""
+}
+
+// applyRulesPatch returns rules without the resources patch removes and with the rules it adds.
+// Rules left without resources are dropped.
+func applyRulesPatch(rules []rbacv1.PolicyRule, patch rulesPatch) []rbacv1.PolicyRule {
+	patched := make([]rbacv1.PolicyRule, 0, len(rules)+len(patch.Add))
+	for _, rule := range rules {
+		rule = *rule.DeepCopy()
+		for _, remove := range patch.Remove {
+			if !containsAny(rule.APIGroups, remove.APIGroups...) {
+				continue
+			}
+			resources := rule.Resources[:0]
+			for _, resource := range rule.Resources {
+				if !containsAny(remove.Resources, resource) {
+					resources = append(resources, resource)
+				}
+			}
+			rule.Resources = resources
+		}
+		if len(rule.Resources) > 0 {
+			patched = append(patched, rule)
+		}
+	}
+	for _, rule := range patch.Add {
+		patched = append(patched, *rule.DeepCopy())
+	}
+	return patched
+}
+
+// configsForRulesOverride requeues the configs whose spec.rbac.rulesOverrideConfigMap names cm
+func (r *SecretsManagementConfigReconciler) configsForRulesOverride(ctx context.Context, cm client.Object) []reconcile.Request {
+	if cm.GetNamespace() != PluginNamespace {
+		return nil
+	}
+	configs := &smv1alpha1.SecretsManagementConfigList{}
+	if err := r.List(ctx, configs); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, config := range configs.Items {
+		if config.Spec.RBAC.RulesOverrideConfigMap == cm.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: config.Name}})
+		}
+	}
+	return requests
+}
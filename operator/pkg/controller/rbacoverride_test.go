@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestParseRulesOverride(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			data: map[string]string{
+				"view":  "remove:\n- apiGroups: [external-secrets.io]\n  resources: [pushsecrets]\n",
+				"admin": "add:\n- apiGroups: [route.openshift.io]\n  resources: [routes]\n  verbs: [get, list]\n",
+			},
+		},
+		{name: "unknown key", data: map[string]string{"edit": "add: []"}, wantErr: `unknown key "edit"`},
+		{name: "unknown field", data: map[string]string{"view": "drop: []"}, wantErr: "view:"},
+		{name: "remove with verbs", data: map[string]string{"view": "remove:\n- apiGroups: [cert-manager.io]\n  resources: [issuers]\n  verbs: [get]\n"}, wantErr: "remove[0] selects resources"},
+		{name: "add without verbs", data: map[string]string{"admin": "add:\n- apiGroups: [route.openshift.io]\n  resources: [routes]\n"}, wantErr: "add[0] must set"},
+		{name: "add wildcard", data: map[string]string{"admin": "add:\n- apiGroups: ['*']\n  resources: [routes]\n  verbs: [get]\n"}, wantErr: "wildcard"},
+		{name: "add escalate", data: map[string]string{"admin": "add:\n- apiGroups: [rbac.authorization.k8s.io]\n  resources: [clusterroles]\n  verbs: [escalate]\n"}, wantErr: `"escalate"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patches, err := parseRulesOverride(tt.data)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, patches, 2)
+		})
+	}
+}
+
+func TestApplyRulesPatch(t *testing.T) {
+	rules := []rbacv1.PolicyRule{
+		{APIGroups: []string{"external-secrets.io"}, Resources: []string{"externalsecrets", "pushsecrets"}, Verbs: []string{"get"}},
+		{APIGroups: []string{"secrets-store.csi.x-k8s.io"}, Resources: []string{"secretproviderclasses"}, Verbs: []string{"get"}},
+	}
+	patched := applyRulesPatch(rules, rulesPatch{
+		Remove: []rbacv1.PolicyRule{
+			{APIGroups: []string{"external-secrets.io"}, Resources: []string{"pushsecrets"}},
+			{APIGroups: []string{"secrets-store.csi.x-k8s.io"}, Resources: []string{"secretproviderclasses"}},
+		},
+		Add: []rbacv1.PolicyRule{
+			{APIGroups: []string{"route.openshift.io"}, Resources: []string{"routes"}, Verbs: []string{"get"}},
+		},
+	})
+	assert.Equal(t, []rbacv1.PolicyRule{
+		{APIGroups: []string{"external-secrets.io"}, Resources: []string{"externalsecrets"}, Verbs: []string{"get"}},
+		{APIGroups: []string{"route.openshift.io"}, Resources: []string{"routes"}, Verbs: []string{"get"}},
+	}, patched)
+	assert.Equal(t, []string{"externalsecrets", "pushsecrets"}, rules[0].Resources, "the built-in rules are not modified")
+}
+
+func TestReconcileRBAC_RulesOverride(t *testing.T) {
+	ctx := context.Background()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "role-overrides", Namespace: PluginNamespace},
+		Data: map[string]string{
+			"view":  "remove:\n- apiGroups: [external-secrets.io]\n  resources: [pushsecrets]\n",
+			"admin": "add:\n- apiGroups: [route.openshift.io]\n  resources: [routes]\n  verbs: [get, list, watch]\n",
+		},
+	}
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.RBAC.RulesOverrideConfigMap = cm.Name
+	r := newTestReconciler(cm)
+
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	view := &rbacv1.ClusterRole{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-view"}, view))
+	for _, rule := range view.Rules {
+		assert.NotContains(t, rule.Resources, "pushsecrets")
+	}
+	admin := &rbacv1.ClusterRole{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-admin"}, admin))
+	assert.Equal(t, []string{"routes"}, admin.Rules[len(admin.Rules)-1].Resources)
+	cond := findCondition(config, smv1alpha1.ConditionRulesOverridden)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+	assert.Contains(t, cond.Message, "admin, view")
+
+	// An invalid patch restores the built-in rules
+	cm.Data["view"] = "remove: [oops"
+	require.NoError(t, r.Update(ctx, cm))
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-view"}, view))
+	assert.Contains(t, view.Rules[1].Resources, "pushsecrets")
+	cond = findCondition(config, smv1alpha1.ConditionRulesOverridden)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonRulesOverrideInvalid, cond.Reason)
+
+	require.NoError(t, r.Delete(ctx, cm))
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	cond = findCondition(config, smv1alpha1.ConditionRulesOverridden)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonRulesOverrideConfigMapNotFound, cond.Reason)
+
+	// The watch requeues configs naming the ConfigMap
+	r = newTestReconciler(config, cm)
+	assert.Len(t, r.configsForRulesOverride(ctx, cm), 1)
+	other := cm.DeepCopy()
+	other.Name = "other"
+	assert.Empty(t, r.configsForRulesOverride(ctx, other))
+}
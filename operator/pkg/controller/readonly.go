@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// ReadOnlyPolicyName is the name of the ValidatingAdmissionPolicy rejecting writes in read-only mode and its binding
+const ReadOnlyPolicyName = "secrets-management-read-only"
+
+// readOnlyRules returns rules granting only get, list and watch on the resources of rules
+func readOnlyRules(rules []rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	readOnly := make([]rbacv1.PolicyRule, 0, len(rules))
+	for _, rule := range rules {
+		rule = *rule.DeepCopy()
+		rule.Verbs = []string{"get", "list", "watch"}
+		readOnly = append(readOnly, rule)
+	}
+	return readOnly
+}
+
+// reconcileReadOnlyPolicy ensures the admission policy rejecting user writes matches
+// spec.features.readOnly, removing it when disabled. Only the primary config owns it.
+func (r *SecretsManagementConfigReconciler) reconcileReadOnlyPolicy(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	readOnly := config.Spec.Features.ReadOnly
+	if !readOnly.Enabled || !readOnly.AdmissionPolicy {
+		if err := r.cleanupAdmissionPolicy(ctx, ReadOnlyPolicyName); err != nil {
+			return err
+		}
+		r.setCondition(config, smv1alpha1.ConditionReadOnlyEnforced, "False", smv1alpha1.ReasonProtectionDisabled, "Users can modify secrets-management resources")
+		return nil
+	}
+
+	// An empty object selector binds the policy to every object its match constraints select
+	served, err := r.applyAdmissionPolicy(ctx, config, buildReadOnlyPolicy(), buildPolicyBinding(ReadOnlyPolicyName, nil))
+	if err != nil {
+		return err
+	}
+	if !served {
+		r.setCondition(config, smv1alpha1.ConditionReadOnlyEnforced, "False", smv1alpha1.ReasonAdmissionPolicyAPIUnavailable, "admissionregistration.k8s.io/v1beta1 ValidatingAdmissionPolicy is not served by this cluster")
+		return nil
+	}
+
+	r.setCondition(config, smv1alpha1.ConditionReadOnlyEnforced, "True", smv1alpha1.ReasonProtectionPolicyApplied, "Only service accounts and system components can modify secrets-management resources")
+	return nil
+}
+
+// buildReadOnlyPolicy creates the ValidatingAdmissionPolicy rejecting creates, updates and deletes of
+// the resources the plugin manages by users. Admission requests do not carry the client's user agent,
+// so the console's writes are told apart by their author: users rather than service accounts or other
+// system: identities, which keeps controllers reconciling.
+func buildReadOnlyPolicy() *admissionregistrationv1beta1.ValidatingAdmissionPolicy {
+	failurePolicy := admissionregistrationv1beta1.Fail
+	writes := []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete}
+	return &admissionregistrationv1beta1.ValidatingAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ReadOnlyPolicyName,
+			Labels: map[string]string{
+				"app.kubernetes.io/part-of":    "ocp-secrets-management",
+				"app.kubernetes.io/managed-by": "secrets-management-operator",
+			},
+		},
+		Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicySpec{
+			FailurePolicy: &failurePolicy,
+			MatchConstraints: &admissionregistrationv1beta1.MatchResources{
+				ResourceRules: []admissionregistrationv1beta1.NamedRuleWithOperations{
+					admissionRule(writes, "cert-manager.io", "certificates", "issuers", "clusterissuers"),
+					admissionRule(writes, "external-secrets.io", "externalsecrets", "clusterexternalsecrets", "secretstores", "clustersecretstores", "pushsecrets"),
+					admissionRule(writes, "secrets-store.csi.x-k8s.io", "secretproviderclasses"),
+				},
+			},
+			Validations: []admissionregistrationv1beta1.Validation{
+				{
+					Expression:        "request.userInfo.username.startsWith('system:')",
+					MessageExpression: "request.kind.kind + ' ' + request.name + ' cannot be changed: secrets management is in read-only mode'",
+					Reason:            reasonPtr(metav1.StatusReasonForbidden),
+				},
+			},
+		},
+	}
+}
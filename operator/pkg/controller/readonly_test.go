@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestReadOnly_AdminRoleAndRuntimeConfig(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Features.ReadOnly.Enabled = true
+	r := newTestReconciler(config)
+	reconcileTestConfig(t, r)
+
+	role := &rbacv1.ClusterRole{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-admin"}, role))
+	require.NotEmpty(t, role.Rules)
+	for _, rule := range role.Rules {
+		assert.Equal(t, []string{"get", "list", "watch"}, rule.Verbs)
+	}
+
+	current := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, current))
+	for _, s := range current.Status.RBAC.ClusterRoles {
+		if s.Name == role.Name {
+			assert.Equal(t, []string{"view"}, s.Operations)
+		}
+	}
+	assert.True(t, readRuntimeConfig(t, r).ReadOnly)
+
+	// Leaving read-only mode restores the admin role
+	current.Spec.Features.ReadOnly.Enabled = false
+	require.NoError(t, r.Update(ctx, current))
+	reconcileTestConfig(t, r)
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-admin"}, role))
+	for _, rule := range role.Rules {
+		assert.Equal(t, []string{"*"}, rule.Verbs)
+	}
+	assert.False(t, readRuntimeConfig(t, r).ReadOnly)
+}
+
+func TestReconcileReadOnlyPolicy(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Features.ReadOnly.AdmissionPolicy = true
+	r := newTestReconciler()
+
+	// The policy needs read-only mode
+	require.NoError(t, r.reconcileReadOnlyPolicy(ctx, config))
+	err := r.Get(ctx, types.NamespacedName{Name: ReadOnlyPolicyName}, &admissionregistrationv1beta1.ValidatingAdmissionPolicy{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	config.Spec.Features.ReadOnly.Enabled = true
+	require.NoError(t, r.reconcileReadOnlyPolicy(ctx, config))
+
+	policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: ReadOnlyPolicyName}, policy))
+	require.Len(t, policy.Spec.Validations, 1)
+	assert.Equal(t, "request.userInfo.username.startsWith('system:')", policy.Spec.Validations[0].Expression)
+	assert.Len(t, policy.Spec.MatchConstraints.ResourceRules, 3)
+
+	binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: ReadOnlyPolicyName}, binding))
+	assert.Equal(t, ReadOnlyPolicyName, binding.Spec.PolicyName)
+	assert.Empty(t, binding.Spec.MatchResources.ObjectSelector.MatchLabels)
+
+	cond := findCondition(config, smv1alpha1.ConditionReadOnlyEnforced)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+
+	config.Spec.Features.ReadOnly.AdmissionPolicy = false
+	require.NoError(t, r.reconcileReadOnlyPolicy(ctx, config))
+	err = r.Get(ctx, types.NamespacedName{Name: ReadOnlyPolicyName}, &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{})
+	assert.True(t, apierrors.IsNotFound(err))
+	cond = findCondition(config, smv1alpha1.ConditionReadOnlyEnforced)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonProtectionDisabled, cond.Reason)
+}
@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/yaml"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// RenderOptions are the operator settings that change the rendered manifests
+type RenderOptions struct {
+	// Restricted renders what the operator creates in restricted mode
+	Restricted bool
+
+	// OperatorNamespace is the namespace the operator runs in, exempted by the protection policy
+	OperatorNamespace string
+}
+
+// renderKindOrder is the order kinds are written in, so that namespaces, identities and
+// permissions come before the workloads that use them. Other kinds follow, ordered by group and kind.
+var renderKindOrder = []string{
+	"Namespace",
+	"ResourceQuota",
+	"LimitRange",
+	"ServiceAccount",
+	"Secret",
+	"ConfigMap",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Service",
+	"Deployment",
+	"CronJob",
+}
+
+// renderedObject identifies an object written during a rendering reconcile
+type renderedObject struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+}
+
+// Render runs a reconcile of config against an empty in-memory cluster and returns every object
+// the operator would create, as a multi-document YAML bundle. The output is deterministic, so it
+// can be committed for a GitOps tool to apply in place of the operator. Status, owner references
+// and server-set metadata are left out, and the config itself is not included.
+func Render(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, opts RenderOptions) ([]byte, error) {
+	if config.Name != SingletonConfigName && config.Name != CanaryConfigName {
+		return nil, fmt.Errorf("only the SecretsManagementConfigs named %q and %q manage resources, not %q", SingletonConfigName, CanaryConfigName, config.Name)
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(smv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
+
+	config = config.DeepCopy()
+	config.ResourceVersion = ""
+	config.Status = smv1alpha1.SecretsManagementConfigStatus{}
+
+	// The reconcile step groups call the client concurrently. The fake client is not safe for that
+	// when listing unstructured objects, and written is not either, so calls are serialized.
+	var mu sync.Mutex
+	written := map[renderedObject]bool{}
+	record := func(obj client.Object, present bool) {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil || gvk.Group == smv1alpha1.GroupVersion.Group {
+			return
+		}
+		// The empty cluster has no operators installed; the banner reports cluster state, not spec
+		if obj.GetName() == MissingOperatorsNotificationName {
+			return
+		}
+		written[renderedObject{gvk: gvk, namespace: obj.GetNamespace(), name: obj.GetName()}] = present
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(config).
+		WithStatusSubresource(config).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				mu.Lock()
+				defer mu.Unlock()
+				return c.Get(ctx, key, obj, opts...)
+			},
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				mu.Lock()
+				defer mu.Unlock()
+				return c.List(ctx, list, opts...)
+			},
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				mu.Lock()
+				defer mu.Unlock()
+				record(obj, true)
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				mu.Lock()
+				defer mu.Unlock()
+				record(obj, true)
+				return c.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				mu.Lock()
+				defer mu.Unlock()
+				record(obj, true)
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				mu.Lock()
+				defer mu.Unlock()
+				record(obj, false)
+				return c.Delete(ctx, obj, opts...)
+			},
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				mu.Lock()
+				defer mu.Unlock()
+				return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	reconciler := &SecretsManagementConfigReconciler{
+		Client:            c,
+		Log:               logr.Discard(),
+		Scheme:            scheme,
+		Restricted:        opts.Restricted,
+		OperatorNamespace: opts.OperatorNamespace,
+	}
+	if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: config.Name}}); err != nil {
+		return nil, err
+	}
+	if isPrimaryConfig(config) && config.Spec.Compliance.Enabled && config.Spec.Scan.Schedule != "" {
+		compliance := &ComplianceReconciler{Client: c, Log: logr.Discard(), Scheme: scheme, Restricted: opts.Restricted}
+		if err := compliance.reconcileScanJob(ctx, config); err != nil {
+			return nil, err
+		}
+	}
+
+	objects := make([]renderedObject, 0, len(written))
+	for o, present := range written {
+		if present {
+			objects = append(objects, o)
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return renderLess(objects[i], objects[j]) })
+
+	var out bytes.Buffer
+	for _, o := range objects {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(o.gvk)
+		if err := c.Get(ctx, types.NamespacedName{Namespace: o.namespace, Name: o.name}, u); err != nil {
+			return nil, err
+		}
+		data, err := yaml.Marshal(renderable(u))
+		if err != nil {
+			return nil, err
+		}
+		out.WriteString("---\n")
+		out.Write(data)
+	}
+	return out.Bytes(), nil
+}
+
+// renderable drops what the API server sets, the status and the owner references, which name
+// the config by a UID that only exists once the operator has created it
+func renderable(u *unstructured.Unstructured) map[string]interface{} {
+	obj := u.DeepCopy().Object
+	delete(obj, "status")
+	for _, field := range []string{"resourceVersion", "uid", "generation", "creationTimestamp", "managedFields", "ownerReferences", "finalizers"} {
+		unstructured.RemoveNestedField(obj, "metadata", field)
+	}
+	return obj
+}
+
+// renderLess orders objects by renderKindOrder, then by group and kind, namespace and name
+func renderLess(a, b renderedObject) bool {
+	ra, rb := renderKindRank(a.gvk), renderKindRank(b.gvk)
+	if ra != rb {
+		return ra < rb
+	}
+	if a.gvk.Group != b.gvk.Group {
+		return a.gvk.Group < b.gvk.Group
+	}
+	if a.gvk.Kind != b.gvk.Kind {
+		return a.gvk.Kind < b.gvk.Kind
+	}
+	if a.namespace != b.namespace {
+		return a.namespace < b.namespace
+	}
+	return a.name < b.name
+}
+
+func renderKindRank(gvk schema.GroupVersionKind) int {
+	for i, kind := range renderKindOrder {
+		if gvk.Kind == kind && (gvk.Group == "" || gvk.Group == "apps" || gvk.Group == "batch" || gvk.Group == "rbac.authorization.k8s.io") {
+			return i
+		}
+	}
+	return len(renderKindOrder)
+}
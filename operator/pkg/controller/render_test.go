@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func renderedKinds(t *testing.T, manifests []byte) []string {
+	t.Helper()
+	var kinds []string
+	for _, doc := range strings.Split(string(manifests), "---\n") {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		obj := map[string]interface{}{}
+		require.NoError(t, yaml.Unmarshal([]byte(doc), &obj))
+		metadata := obj["metadata"].(map[string]interface{})
+		assert.NotContains(t, metadata, "resourceVersion")
+		assert.NotContains(t, metadata, "ownerReferences")
+		assert.NotContains(t, obj, "status")
+		kinds = append(kinds, obj["kind"].(string)+"/"+metadata["name"].(string))
+	}
+	return kinds
+}
+
+func TestRender(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Protection.Enabled = true
+	config.Spec.Compliance.Enabled = true
+	config.Spec.Scan.Schedule = "@daily"
+
+	manifests, err := Render(context.Background(), config, RenderOptions{OperatorNamespace: "operators"})
+	require.NoError(t, err)
+	kinds := renderedKinds(t, manifests)
+
+	require.NotEmpty(t, kinds)
+	assert.Equal(t, "Namespace/"+PluginNamespace, kinds[0])
+	assert.Contains(t, kinds, "Deployment/ocp-secrets-management-plugin")
+	assert.Contains(t, kinds, "ConsolePlugin/ocp-secrets-management")
+	assert.Contains(t, kinds, "ValidatingAdmissionPolicy/"+ProtectionPolicyName)
+	assert.Contains(t, kinds, "CronJob/"+ComplianceScanName)
+	assert.NotContains(t, kinds, "ConsoleNotification/"+MissingOperatorsNotificationName)
+	assert.NotContains(t, kinds, "SecretsManagementConfig/"+SingletonConfigName)
+	assert.Less(t, indexOf(kinds, "ServiceAccount/ocp-secrets-management-plugin"), indexOf(kinds, "Deployment/ocp-secrets-management-plugin"))
+
+	// The input is not modified and the output is stable
+	assert.Empty(t, config.Status.Phase)
+	again, err := Render(context.Background(), config, RenderOptions{OperatorNamespace: "operators"})
+	require.NoError(t, err)
+	assert.Equal(t, string(manifests), string(again))
+}
+
+func TestRender_RejectsOtherConfigs(t *testing.T) {
+	_, err := Render(context.Background(), newTestConfig("other"), RenderOptions{})
+	assert.Error(t, err)
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
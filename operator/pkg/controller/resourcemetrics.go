@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/resourcemetrics"
+)
+
+// secretProviderClassPodStatusGVK is the Secrets Store CSI driver's record of a mount in a pod
+var secretProviderClassPodStatusGVK = schema.GroupVersionKind{
+	Group:   "secrets-store.csi.x-k8s.io",
+	Version: "v1",
+	Kind:    "SecretProviderClassPodStatus",
+}
+
+// reconcileResourceMetrics exports the state of each Certificate, ExternalSecret and
+// SecretProviderClassPodStatus when spec.resourceMetrics is enabled and stops exporting it
+// otherwise. Only the kinds of detected operators are listed, and resources are only listed
+// cluster-wide, so nothing is exported in restricted mode.
+func (r *SecretsManagementConfigReconciler) reconcileResourceMetrics(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	if r.ResourceMetrics == nil {
+		return nil
+	}
+	if !config.Spec.ResourceMetrics.Enabled || r.Restricted {
+		r.ResourceMetrics.Report(nil)
+		return nil
+	}
+
+	detected := &config.Status.DetectedOperators
+	snapshot := &resourcemetrics.Snapshot{}
+	if detected.CertManager.Installed {
+		err := r.forEachResource(ctx, certificateGVK, func(cert *unstructured.Unstructured) {
+			value, _, _ := unstructured.NestedString(cert.Object, "status", "notAfter")
+			notAfter, _ := time.Parse(time.RFC3339, value)
+			snapshot.Certificates = append(snapshot.Certificates, resourcemetrics.Certificate{
+				Namespace: cert.GetNamespace(),
+				Name:      cert.GetName(),
+				NotAfter:  notAfter,
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if detected.ExternalSecrets.Installed {
+		err := r.forEachResource(ctx, externalSecretGVK, func(es *unstructured.Unstructured) {
+			snapshot.ExternalSecrets = append(snapshot.ExternalSecrets, resourcemetrics.ExternalSecret{
+				Namespace: es.GetNamespace(),
+				Name:      es.GetName(),
+				Ready:     readyConditionStatus(es),
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if detected.SecretsStoreCSI.Installed {
+		err := r.forEachResource(ctx, secretProviderClassPodStatusGVK, func(status *unstructured.Unstructured) {
+			pod, _, _ := unstructured.NestedString(status.Object, "status", "podName")
+			class, _, _ := unstructured.NestedString(status.Object, "status", "secretProviderClassName")
+			mounted, _, _ := unstructured.NestedBool(status.Object, "status", "mounted")
+			snapshot.PodStatuses = append(snapshot.PodStatuses, resourcemetrics.PodStatus{
+				Namespace:           status.GetNamespace(),
+				Name:                status.GetName(),
+				Pod:                 pod,
+				SecretProviderClass: class,
+				Mounted:             mounted,
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	r.ResourceMetrics.Report(snapshot)
+	return nil
+}
+
+// readyConditionStatus returns the status of obj's Ready condition, Unknown when it has none
+func readyConditionStatus(obj *unstructured.Unstructured) string {
+	status, _ := readyCondition(obj)
+	return status
+}
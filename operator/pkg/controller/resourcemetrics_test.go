@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/resourcemetrics"
+)
+
+func TestReconcileResourceMetrics(t *testing.T) {
+	ctx := context.Background()
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	certificate.SetNamespace("app")
+	certificate.SetName("serving")
+	require.NoError(t, unstructured.SetNestedField(certificate.Object, "2026-03-01T00:00:00Z", "status", "notAfter"))
+
+	externalSecret := &unstructured.Unstructured{}
+	externalSecret.SetGroupVersionKind(externalSecretGVK)
+	externalSecret.SetNamespace("app")
+	externalSecret.SetName("db")
+	require.NoError(t, unstructured.SetNestedSlice(externalSecret.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "False", "reason": "SecretSyncedError"},
+	}, "status", "conditions"))
+
+	podStatus := &unstructured.Unstructured{}
+	podStatus.SetGroupVersionKind(secretProviderClassPodStatusGVK)
+	podStatus.SetNamespace("app")
+	podStatus.SetName("web-0-app-vault")
+	require.NoError(t, unstructured.SetNestedMap(podStatus.Object, map[string]interface{}{
+		"podName":                 "web-0",
+		"secretProviderClassName": "vault",
+		"mounted":                 true,
+	}, "status"))
+
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.CertManager.Installed = true
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	r := newTestReconciler(certificate, externalSecret, podStatus)
+	r.ResourceMetrics = resourcemetrics.NewExporter()
+
+	// Nothing is exported until the config opts in
+	require.NoError(t, r.reconcileResourceMetrics(ctx, config))
+	assert.Nil(t, r.ResourceMetrics.Snapshot())
+
+	config.Spec.ResourceMetrics.Enabled = true
+	require.NoError(t, r.reconcileResourceMetrics(ctx, config))
+	assert.Equal(t, &resourcemetrics.Snapshot{
+		Certificates:    []resourcemetrics.Certificate{{Namespace: "app", Name: "serving", NotAfter: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}},
+		ExternalSecrets: []resourcemetrics.ExternalSecret{{Namespace: "app", Name: "db", Ready: "False"}},
+	}, r.ResourceMetrics.Snapshot(), "resources of operators that are not installed are not listed")
+
+	config.Status.DetectedOperators.SecretsStoreCSI.Installed = true
+	require.NoError(t, r.reconcileResourceMetrics(ctx, config))
+	assert.Equal(t, []resourcemetrics.PodStatus{
+		{Namespace: "app", Name: "web-0-app-vault", Pod: "web-0", SecretProviderClass: "vault", Mounted: true},
+	}, r.ResourceMetrics.Snapshot().PodStatuses)
+
+	// Restricted mode cannot list resources cluster-wide
+	r.Restricted = true
+	require.NoError(t, r.reconcileResourceMetrics(ctx, config))
+	assert.Nil(t, r.ResourceMetrics.Snapshot())
+	r.Restricted = false
+
+	config.Spec.ResourceMetrics.Enabled = false
+	require.NoError(t, r.reconcileResourceMetrics(ctx, config))
+	assert.Nil(t, r.ResourceMetrics.Snapshot())
+}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// RestoredAtAnnotation is set on a SecretsManagementConfig by smcctl restore, to the time it
+	// ran, so that the operator verifies the stores and issuers reconnect
+	RestoredAtAnnotation = "secrets-management.openshift.io/restored-at"
+
+	// VerifyRequestedAnnotation is set on the stores and issuers after a restore; the change makes
+	// the External Secrets Operator and cert-manager validate them again
+	VerifyRequestedAnnotation = "secrets-management.openshift.io/verify-requested-at"
+
+	// veleroRestoreNameLabel is set by Velero on the objects it restores
+	veleroRestoreNameLabel = "velero.io/restore-name"
+
+	// restoreVerificationGrace is how long the controllers get to validate again before the
+	// result is read
+	restoreVerificationGrace = time.Minute
+
+	// restoreVerificationTimeout is how long a store or issuer may go without a Ready condition
+	// before it counts as failed
+	restoreVerificationTimeout = 10 * time.Minute
+
+	// restoreVerificationRequeue is how often a verification in progress is checked
+	restoreVerificationRequeue = 15 * time.Second
+
+	// maxRestoreVerificationFailures bounds status.restoreVerification.failures
+	maxRestoreVerificationFailures = 20
+)
+
+// restoreMarker identifies the restore config came from: the time smcctl restore ran, or the name
+// of the Velero Restore that recreated it. It is empty for configs that were never restored.
+func restoreMarker(config *smv1alpha1.SecretsManagementConfig) string {
+	if at := config.Annotations[RestoredAtAnnotation]; at != "" {
+		return at
+	}
+	return config.Labels[veleroRestoreNameLabel]
+}
+
+// reconcileRestoreVerification checks that every ClusterSecretStore and ClusterIssuer reconnects
+// once the config was restored, since credentials that went stale between the backup and the
+// restore otherwise fail silently. The stores and issuers are annotated so their controllers
+// validate them again; their Ready conditions are read after restoreVerificationGrace and the
+// result is reported in status.restoreVerification and the RestoreVerified condition. Each
+// restore is verified once. It reports whether verification is still in progress.
+func (r *SecretsManagementConfigReconciler) reconcileRestoreVerification(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, now time.Time) (bool, error) {
+	marker := restoreMarker(config)
+	if marker == "" {
+		return false, nil
+	}
+	verification := config.Status.RestoreVerification
+
+	if verification == nil || verification.Restore != marker {
+		targets, err := r.restoreVerificationTargets(ctx)
+		if err != nil {
+			return false, err
+		}
+		requested := now.UTC().Format(time.RFC3339)
+		for i := range targets {
+			target := &targets[i]
+			before := target.DeepCopy()
+			annotations := target.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[VerifyRequestedAnnotation] = requested
+			target.SetAnnotations(annotations)
+			if err := r.Patch(ctx, target, client.MergeFrom(before)); client.IgnoreNotFound(err) != nil {
+				return false, err
+			}
+		}
+		config.Status.RestoreVerification = &smv1alpha1.RestoreVerificationStatus{Restore: marker, StartTime: metav1.NewTime(now)}
+		r.setCondition(config, smv1alpha1.ConditionRestoreVerified, "Unknown", smv1alpha1.ReasonRestoreVerificationPending,
+			fmt.Sprintf("Asked %d stores and issuers to validate again after restore %s", len(targets), marker))
+		return true, nil
+	}
+	if verification.CompletionTime != nil {
+		return false, nil
+	}
+	elapsed := now.Sub(verification.StartTime.Time)
+	if elapsed < restoreVerificationGrace {
+		return true, nil
+	}
+
+	targets, err := r.restoreVerificationTargets(ctx)
+	if err != nil {
+		return false, err
+	}
+	var verified, pending int32
+	var failures []smv1alpha1.RestoreVerificationFailure
+	for i := range targets {
+		target := &targets[i]
+		status, message := readyCondition(target)
+		switch {
+		case status == "True":
+			verified++
+			continue
+		case status == "Unknown" && elapsed < restoreVerificationTimeout:
+			pending++
+			continue
+		case status == "Unknown":
+			message = fmt.Sprintf("no Ready condition within %s", restoreVerificationTimeout)
+		}
+		failures = append(failures, smv1alpha1.RestoreVerificationFailure{Kind: target.GetKind(), Name: target.GetName(), Message: message})
+	}
+	if pending > 0 {
+		r.setCondition(config, smv1alpha1.ConditionRestoreVerified, "Unknown", smv1alpha1.ReasonRestoreVerificationPending,
+			fmt.Sprintf("Waiting for %d of %d stores and issuers to validate after restore %s", pending, len(targets), marker))
+		return true, nil
+	}
+
+	completed := metav1.NewTime(now)
+	verification.CompletionTime = &completed
+	verification.Verified = verified
+	verification.Failures = failures
+	if len(verification.Failures) > maxRestoreVerificationFailures {
+		verification.Failures = verification.Failures[:maxRestoreVerificationFailures]
+	}
+	if len(failures) == 0 {
+		r.setCondition(config, smv1alpha1.ConditionRestoreVerified, "True", smv1alpha1.ReasonRestoreVerified,
+			fmt.Sprintf("All %d stores and issuers are Ready after restore %s", verified, marker))
+		return false, nil
+	}
+	names := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		names = append(names, failure.Kind+" "+failure.Name)
+	}
+	message := fmt.Sprintf("%d of %d stores and issuers are not Ready after restore %s, check their credentials: %s",
+		len(failures), len(targets), marker, strings.Join(names, ", "))
+	r.setCondition(config, smv1alpha1.ConditionRestoreVerified, "False", smv1alpha1.ReasonRestoreVerificationFailed, message)
+	if r.Recorder != nil {
+		r.Recorder.Event(config, corev1.EventTypeWarning, smv1alpha1.ReasonRestoreVerificationFailed, message)
+	}
+	return false, nil
+}
+
+// restoreVerificationTargets lists the ClusterSecretStores and ClusterIssuers, sorted by kind and
+// name. Kinds the cluster does not serve are left out.
+func (r *SecretsManagementConfigReconciler) restoreVerificationTargets(ctx context.Context) ([]unstructured.Unstructured, error) {
+	var targets []unstructured.Unstructured
+	for _, gvk := range []schema.GroupVersionKind{clusterIssuerGVK, clusterSecretStoreGVK} {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := r.apiReader().List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, err
+		}
+		sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].GetName() < list.Items[j].GetName() })
+		for i := range list.Items {
+			list.Items[i].SetGroupVersionKind(gvk)
+			targets = append(targets, list.Items[i])
+		}
+	}
+	return targets, nil
+}
+
+// readyCondition returns the status of the Ready condition of a cert-manager or External Secrets
+// Operator object, Unknown when it has none, and its message
+func readyCondition(obj *unstructured.Unstructured) (string, string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != "Ready" {
+			continue
+		}
+		message, _ := cond["message"].(string)
+		if status, _ := cond["status"].(string); status == "True" || status == "False" {
+			return status, message
+		}
+		return "Unknown", message
+	}
+	return "Unknown", "no Ready condition"
+}
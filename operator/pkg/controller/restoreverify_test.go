@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestReadyObject(gvk schema.GroupVersionKind, name, status, message string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+	u.SetGroupVersionKind(gvk)
+	u.SetName(name)
+	if status != "" {
+		u.Object["status"] = map[string]interface{}{"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": status, "message": message},
+		}}
+	}
+	return u
+}
+
+func TestReconcileRestoreVerification(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Annotations = map[string]string{RestoredAtAnnotation: "2026-10-16T02:00:00Z"}
+	vault := newTestReadyObject(clusterSecretStoreGVK, "vault", "False", "could not get provider client: token expired")
+	aws := newTestReadyObject(clusterSecretStoreGVK, "aws", "True", "store validated")
+	issuer := newTestReadyObject(clusterIssuerGVK, "letsencrypt", "", "")
+	r := newTestReconciler(config, vault, aws, issuer)
+
+	start := time.Date(2026, 10, 16, 2, 1, 0, 0, time.UTC)
+	verifying, err := r.reconcileRestoreVerification(ctx, config, start)
+	require.NoError(t, err)
+	assert.True(t, verifying)
+	require.NotNil(t, config.Status.RestoreVerification)
+	assert.Equal(t, "2026-10-16T02:00:00Z", config.Status.RestoreVerification.Restore)
+	cond := findCondition(config, smv1alpha1.ConditionRestoreVerified)
+	require.NotNil(t, cond)
+	assert.Equal(t, "Unknown", string(cond.Status))
+	assert.Contains(t, cond.Message, "Asked 3 stores and issuers")
+
+	// The stores and issuers are nudged to validate again
+	store := &unstructured.Unstructured{}
+	store.SetGroupVersionKind(clusterSecretStoreGVK)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "vault"}, store))
+	assert.Equal(t, "2026-10-16T02:01:00Z", store.GetAnnotations()[VerifyRequestedAnnotation])
+
+	// The issuer has not reported yet after the grace period
+	verifying, err = r.reconcileRestoreVerification(ctx, config, start.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, verifying)
+	cond = findCondition(config, smv1alpha1.ConditionRestoreVerified)
+	assert.Contains(t, cond.Message, "Waiting for 1 of 3")
+
+	// It counts as failed once the timeout passes
+	verifying, err = r.reconcileRestoreVerification(ctx, config, start.Add(restoreVerificationTimeout))
+	require.NoError(t, err)
+	assert.False(t, verifying)
+	verification := config.Status.RestoreVerification
+	require.NotNil(t, verification.CompletionTime)
+	assert.Equal(t, int32(1), verification.Verified)
+	require.Len(t, verification.Failures, 2)
+	assert.Equal(t, smv1alpha1.RestoreVerificationFailure{Kind: "ClusterIssuer", Name: "letsencrypt", Message: "no Ready condition within 10m0s"}, verification.Failures[0])
+	assert.Equal(t, "could not get provider client: token expired", verification.Failures[1].Message)
+	cond = findCondition(config, smv1alpha1.ConditionRestoreVerified)
+	assert.Equal(t, "False", string(cond.Status))
+	assert.Equal(t, smv1alpha1.ReasonRestoreVerificationFailed, cond.Reason)
+
+	// A restore is verified once
+	verifying, err = r.reconcileRestoreVerification(ctx, config, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, verifying)
+	assert.Equal(t, verification, config.Status.RestoreVerification)
+}
+
+func TestReconcileRestoreVerification_VeleroRestore(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Labels = map[string]string{veleroRestoreNameLabel: "dr-20261016"}
+	r := newTestReconciler(config, newTestReadyObject(clusterSecretStoreGVK, "aws", "True", ""))
+
+	start := time.Date(2026, 10, 16, 2, 1, 0, 0, time.UTC)
+	_, err := r.reconcileRestoreVerification(ctx, config, start)
+	require.NoError(t, err)
+	verifying, err := r.reconcileRestoreVerification(ctx, config, start.Add(restoreVerificationGrace))
+	require.NoError(t, err)
+	assert.False(t, verifying)
+
+	cond := findCondition(config, smv1alpha1.ConditionRestoreVerified)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonRestoreVerified, cond.Reason)
+	assert.Contains(t, cond.Message, "dr-20261016")
+}
+
+func TestReconcileRestoreVerification_NotRestored(t *testing.T) {
+	config := newTestConfig("cluster")
+	r := newTestReconciler(config)
+
+	verifying, err := r.reconcileRestoreVerification(context.Background(), config, time.Now())
+	require.NoError(t, err)
+	assert.False(t, verifying)
+	assert.Nil(t, config.Status.RestoreVerification)
+	assert.Nil(t, findCondition(config, smv1alpha1.ConditionRestoreVerified))
+}
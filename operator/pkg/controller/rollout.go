@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// setRolloutCondition sets the Progressing condition from the rollout of the plugin Deployment's
+// current generation: True while its new ReplicaSet replaces the old pods, False once every replica
+// is updated and available, or when the Deployment controller gave up on the rollout
+func (r *SecretsManagementConfigReconciler) setRolloutCondition(config *smv1alpha1.SecretsManagementConfig, deployment *appsv1.Deployment) {
+	if stalled := deploymentStalled(deployment); stalled != nil {
+		r.setCondition(config, smv1alpha1.ConditionProgressing, "False", smv1alpha1.ReasonProgressDeadlineExceeded,
+			fmt.Sprintf("Plugin rollout stalled: %s", stalled.Message))
+		return
+	}
+	if message, done := rolloutProgress(deployment); !done {
+		r.setCondition(config, smv1alpha1.ConditionProgressing, "True", smv1alpha1.ReasonReplicaSetUpdating, message)
+		return
+	}
+	r.setCondition(config, smv1alpha1.ConditionProgressing, "False", smv1alpha1.ReasonNewReplicaSetAvailable,
+		"Plugin Deployment has rolled out its current generation")
+}
+
+// rolloutProgress reports whether every replica of deployment runs its current generation and is
+// available, with a message saying what the rollout is waiting for when not, as kubectl rollout status does
+func rolloutProgress(deployment *appsv1.Deployment) (string, bool) {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	if status.ObservedGeneration < deployment.Generation {
+		return "Waiting for the Deployment controller to observe the new generation", false
+	}
+	// Pods lost after the new ReplicaSet became available are an availability problem, not a rollout
+	for _, c := range status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionTrue && c.Reason == smv1alpha1.ReasonNewReplicaSetAvailable {
+			return "", true
+		}
+	}
+	switch {
+	case status.UpdatedReplicas < replicas:
+		return fmt.Sprintf("Waiting for rollout to finish: %d of %d new replicas have been updated", status.UpdatedReplicas, replicas), false
+	case status.Replicas > status.UpdatedReplicas:
+		return fmt.Sprintf("Waiting for rollout to finish: %d old replicas are pending termination", status.Replicas-status.UpdatedReplicas), false
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return fmt.Sprintf("Waiting for rollout to finish: %d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas), false
+	}
+	return "", true
+}
+
+// deploymentStalled returns the Progressing condition of deployment when the Deployment
+// controller stopped the rollout after progressDeadlineSeconds, and nil otherwise
+func deploymentStalled(deployment *appsv1.Deployment) *appsv1.DeploymentCondition {
+	for i, c := range deployment.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse && c.Reason == smv1alpha1.ReasonProgressDeadlineExceeded {
+			return &deployment.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// statusCondition returns the condition of condType in the status of config, or nil
+func statusCondition(config *smv1alpha1.SecretsManagementConfig, condType smv1alpha1.ConditionType) *smv1alpha1.Condition {
+	for i := range config.Status.Conditions {
+		if config.Status.Conditions[i].Type == condType {
+			return &config.Status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// Route GroupVersionKind for OpenShift
+var routeGVK = schema.GroupVersionKind{
+	Group:   "route.openshift.io",
+	Version: "v1",
+	Kind:    "Route",
+}
+
+// reconcileRoute ensures the plugin Route exists when enabled and removes it otherwise
+func (r *SecretsManagementConfigReconciler) reconcileRoute(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	if !config.Spec.Plugin.Route.Enabled {
+		config.Status.Plugin.RouteHost = ""
+		return r.cleanupRoute(ctx, config)
+	}
+
+	spec := buildRouteSpec(config)
+	labels := map[string]string{
+		"app.kubernetes.io/name":       instanceName(config),
+		"app.kubernetes.io/part-of":    "ocp-secrets-management",
+		"app.kubernetes.io/managed-by": "secrets-management-operator",
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(routeGVK)
+	u.SetName(fmt.Sprintf("%s-plugin", instanceName(config)))
+	u.SetNamespace(PluginNamespace)
+	u.SetLabels(labels)
+	if err := unstructured.SetNestedField(u.Object, spec, "spec"); err != nil {
+		return err
+	}
+	applyCommonMetadata(config, u)
+	if err := setAppliedSpecHash(u); err != nil {
+		return err
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(routeGVK)
+	err := r.Get(ctx, types.NamespacedName{Name: u.GetName(), Namespace: PluginNamespace}, existing)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return r.Create(ctx, u)
+		}
+		return err
+	}
+
+	before := existing.DeepCopy()
+	if specChanged(existing, u, existing.Object["spec"], u.Object["spec"]) {
+		// Keep a router-generated host stable across updates
+		if _, ok := spec["host"]; !ok {
+			if host, found, _ := unstructured.NestedString(existing.Object, "spec", "host"); found {
+				spec["host"] = host
+			}
+		}
+		if err := unstructured.SetNestedField(existing.Object, spec, "spec"); err != nil {
+			return err
+		}
+	}
+	mergeMetadata(existing, u)
+	if err := updateIfChanged(ctx, r, before, existing); err != nil {
+		return err
+	}
+
+	config.Status.Plugin.RouteHost = admittedRouteHost(existing)
+	return nil
+}
+
+// buildRouteSpec builds the reencrypt Route spec targeting the plugin Service
+func buildRouteSpec(config *smv1alpha1.SecretsManagementConfig) map[string]interface{} {
+	routeConfig := config.Spec.Plugin.Route
+
+	insecurePolicy := routeConfig.InsecureEdgeTerminationPolicy
+	if insecurePolicy == "" {
+		insecurePolicy = "Redirect"
+	}
+	tls := map[string]interface{}{
+		"termination":                   "reencrypt",
+		"insecureEdgeTerminationPolicy": insecurePolicy,
+	}
+	if routeConfig.ExternalCertificateSecretName != "" {
+		tls["externalCertificate"] = map[string]interface{}{
+			"name": routeConfig.ExternalCertificateSecretName,
+		}
+	}
+
+	spec := map[string]interface{}{
+		"to": map[string]interface{}{
+			"kind":   "Service",
+			"name":   fmt.Sprintf("%s-plugin", instanceName(config)),
+			"weight": int64(100),
+		},
+		"port": map[string]interface{}{
+			"targetPort": "https",
+		},
+		"tls":            tls,
+		"wildcardPolicy": "None",
+	}
+	if routeConfig.Host != "" {
+		spec["host"] = routeConfig.Host
+	}
+	return spec
+}
+
+// admittedRouteHost returns the host of the first ingress that admitted the Route
+func admittedRouteHost(route *unstructured.Unstructured) string {
+	ingresses, _, _ := unstructured.NestedSlice(route.Object, "status", "ingress")
+	for _, ingress := range ingresses {
+		ingressMap, ok := ingress.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditions, _, _ := unstructured.NestedSlice(ingressMap, "conditions")
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if ok && condition["type"] == "Admitted" && condition["status"] == "True" {
+				host, _, _ := unstructured.NestedString(ingressMap, "host")
+				return host
+			}
+		}
+	}
+	return ""
+}
+
+// cleanupRoute removes the plugin Route
+func (r *SecretsManagementConfigReconciler) cleanupRoute(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(routeGVK)
+	u.SetName(fmt.Sprintf("%s-plugin", instanceName(config)))
+	u.SetNamespace(PluginNamespace)
+
+	if err := r.Delete(ctx, u); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func getTestRoute(ctx context.Context, r *SecretsManagementConfigReconciler) (*unstructured.Unstructured, error) {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(routeGVK)
+	err := r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, route)
+	return route, err
+}
+
+func TestReconcileRoute(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.Route.Enabled = true
+	config.Spec.Plugin.Route.Host = "plugin.apps.example.com"
+	r := newTestReconciler()
+
+	err := r.reconcileRoute(ctx, config)
+	require.NoError(t, err)
+
+	// Verify reencrypt Route to the plugin Service
+	route, err := getTestRoute(ctx, r)
+	require.NoError(t, err)
+	termination, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "termination")
+	assert.Equal(t, "reencrypt", termination)
+	policy, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "insecureEdgeTerminationPolicy")
+	assert.Equal(t, "Redirect", policy)
+	service, _, _ := unstructured.NestedString(route.Object, "spec", "to", "name")
+	assert.Equal(t, "ocp-secrets-management-plugin", service)
+	host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+	assert.Equal(t, "plugin.apps.example.com", host)
+
+	// Simulate admission by the router and reconcile again
+	err = unstructured.SetNestedSlice(route.Object, []interface{}{
+		map[string]interface{}{
+			"host": "plugin.apps.example.com",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Admitted", "status": "True"},
+			},
+		},
+	}, "status", "ingress")
+	require.NoError(t, err)
+	err = r.Update(ctx, route)
+	require.NoError(t, err)
+
+	config.Spec.Plugin.Route.ExternalCertificateSecretName = "plugin-route-tls"
+	err = r.reconcileRoute(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, "plugin.apps.example.com", config.Status.Plugin.RouteHost)
+
+	route, err = getTestRoute(ctx, r)
+	require.NoError(t, err)
+	secretName, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "externalCertificate", "name")
+	assert.Equal(t, "plugin-route-tls", secretName)
+
+	// Disabling removes the Route
+	config.Spec.Plugin.Route.Enabled = false
+	err = r.reconcileRoute(ctx, config)
+	require.NoError(t, err)
+	_, err = getTestRoute(ctx, r)
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Empty(t, config.Status.Plugin.RouteHost)
+}
+
+func TestReconcileRoute_KeepsGeneratedHost(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.Route.Enabled = true
+	r := newTestReconciler()
+
+	err := r.reconcileRoute(ctx, config)
+	require.NoError(t, err)
+
+	// Router fills in a host when none is requested
+	route, err := getTestRoute(ctx, r)
+	require.NoError(t, err)
+	err = unstructured.SetNestedField(route.Object, "generated.apps.example.com", "spec", "host")
+	require.NoError(t, err)
+	err = r.Update(ctx, route)
+	require.NoError(t, err)
+
+	err = r.reconcileRoute(ctx, config)
+	require.NoError(t, err)
+
+	route, err = getTestRoute(ctx, r)
+	require.NoError(t, err)
+	host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+	assert.Equal(t, "generated.apps.example.com", host)
+}
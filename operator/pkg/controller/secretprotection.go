@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// SecretProtectionPolicyName is the name of the ValidatingAdmissionPolicy guarding protected secrets and its binding
+	SecretProtectionPolicyName = "secrets-management-protect-secrets"
+
+	// ProtectedLabel marks a Secret, ExternalSecret or Certificate as protected from deletion
+	ProtectedLabel = "secrets-management.openshift.io/protected"
+
+	// DeletionApprovedAnnotation allows deletion of a protected object when set to "true"
+	DeletionApprovedAnnotation = "secrets-management.openshift.io/deletion-approved"
+)
+
+// reconcileSecretProtection ensures the admission policy blocking deletion of protected secrets matches
+// spec.features.delete.blockProtected, removing it when disabled. Only the primary config owns it.
+func (r *SecretsManagementConfigReconciler) reconcileSecretProtection(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	if !config.Spec.Features.Delete.BlockProtected {
+		if err := r.cleanupAdmissionPolicy(ctx, SecretProtectionPolicyName); err != nil {
+			return err
+		}
+		r.setCondition(config, smv1alpha1.ConditionDeletionProtectionConfigured, "False", smv1alpha1.ReasonProtectionDisabled, "Protected secrets can be deleted without approval")
+		return nil
+	}
+
+	served, err := r.applyAdmissionPolicy(ctx, config, buildSecretProtectionPolicy(), buildPolicyBinding(SecretProtectionPolicyName, map[string]string{
+		ProtectedLabel: "true",
+	}))
+	if err != nil {
+		return err
+	}
+	if !served {
+		r.setCondition(config, smv1alpha1.ConditionDeletionProtectionConfigured, "False", smv1alpha1.ReasonAdmissionPolicyAPIUnavailable, "admissionregistration.k8s.io/v1beta1 ValidatingAdmissionPolicy is not served by this cluster")
+		return nil
+	}
+
+	r.setCondition(config, smv1alpha1.ConditionDeletionProtectionConfigured, "True", smv1alpha1.ReasonProtectionPolicyApplied,
+		fmt.Sprintf("Objects labeled %s=true require the %s annotation to be deleted", ProtectedLabel, DeletionApprovedAnnotation))
+	return nil
+}
+
+// buildSecretProtectionPolicy creates the ValidatingAdmissionPolicy rejecting deletion of protected
+// Secrets, ExternalSecrets and Certificates that have not been approved for deletion. The namespace
+// controller and garbage collector are exempt so deleting a namespace or owner still completes.
+func buildSecretProtectionPolicy() *admissionregistrationv1beta1.ValidatingAdmissionPolicy {
+	failurePolicy := admissionregistrationv1beta1.Fail
+	deleteOnly := []admissionregistrationv1.OperationType{admissionregistrationv1.Delete}
+	return &admissionregistrationv1beta1.ValidatingAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: SecretProtectionPolicyName,
+			Labels: map[string]string{
+				"app.kubernetes.io/part-of":    "ocp-secrets-management",
+				"app.kubernetes.io/managed-by": "secrets-management-operator",
+			},
+		},
+		Spec: admissionregistrationv1beta1.ValidatingAdmissionPolicySpec{
+			FailurePolicy: &failurePolicy,
+			MatchConstraints: &admissionregistrationv1beta1.MatchResources{
+				ResourceRules: []admissionregistrationv1beta1.NamedRuleWithOperations{
+					admissionRule(deleteOnly, "", "secrets"),
+					admissionRule(deleteOnly, "external-secrets.io", "externalsecrets"),
+					admissionRule(deleteOnly, "cert-manager.io", "certificates"),
+				},
+			},
+			Validations: []admissionregistrationv1beta1.Validation{
+				{
+					Expression: fmt.Sprintf("request.userInfo.username == 'system:serviceaccount:kube-system:namespace-controller' || "+
+						"request.userInfo.username == 'system:serviceaccount:kube-system:generic-garbage-collector' || "+
+						"(has(oldObject.metadata.annotations) && '%s' in oldObject.metadata.annotations && "+
+						"oldObject.metadata.annotations['%s'] == 'true')",
+						DeletionApprovedAnnotation, DeletionApprovedAnnotation),
+					MessageExpression: fmt.Sprintf("request.kind.kind + ' ' + oldObject.metadata.name + ' is protected; "+
+						"annotate it with %s=true before deleting it'", DeletionApprovedAnnotation),
+					Reason: reasonPtr(metav1.StatusReasonForbidden),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestReconcileSecretProtection(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Features.Delete.BlockProtected = true
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcileSecretProtection(ctx, config))
+
+	policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SecretProtectionPolicyName}, policy))
+	resources := []string{}
+	for _, rule := range policy.Spec.MatchConstraints.ResourceRules {
+		assert.Equal(t, []admissionregistrationv1.OperationType{admissionregistrationv1.Delete}, rule.Operations)
+		resources = append(resources, rule.Resources...)
+	}
+	assert.ElementsMatch(t, []string{"secrets", "externalsecrets", "certificates"}, resources)
+	require.Len(t, policy.Spec.Validations, 1)
+	assert.Contains(t, policy.Spec.Validations[0].Expression, "oldObject.metadata.annotations['"+DeletionApprovedAnnotation+"'] == 'true'")
+
+	binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SecretProtectionPolicyName}, binding))
+	assert.Equal(t, map[string]string{ProtectedLabel: "true"}, binding.Spec.MatchResources.ObjectSelector.MatchLabels)
+
+	cond := findCondition(config, smv1alpha1.ConditionDeletionProtectionConfigured)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+
+	// The managed-resource protection policy is independent and stays absent
+	err := r.Get(ctx, types.NamespacedName{Name: ProtectionPolicyName}, &admissionregistrationv1beta1.ValidatingAdmissionPolicy{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	config.Spec.Features.Delete.BlockProtected = false
+	require.NoError(t, r.reconcileSecretProtection(ctx, config))
+	err = r.Get(ctx, types.NamespacedName{Name: SecretProtectionPolicyName}, &admissionregistrationv1beta1.ValidatingAdmissionPolicy{})
+	assert.True(t, apierrors.IsNotFound(err))
+	cond = findCondition(config, smv1alpha1.ConditionDeletionProtectionConfigured)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonProtectionDisabled, cond.Reason)
+}
@@ -0,0 +1,299 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// SecretsStoreCSIDriverName is the CSI driver name pods use to mount SecretProviderClasses
+	SecretsStoreCSIDriverName = "secrets-store.csi.k8s.io"
+
+	// MaxSecretProviderClassReferences bounds the SecretProviderClass lists kept in status
+	MaxSecretProviderClassReferences = 50
+
+	// podListPageSize is the page size used when listing pods across the cluster
+	podListPageSize = 500
+
+	// MaxMountErrors bounds the mount error messages kept in status
+	MaxMountErrors = 5
+
+	// mountFailureGracePeriod is how long a pending pod may wait for its SecretProviderClasses to be
+	// mounted before it counts as a mount failure
+	mountFailureGracePeriod = 2 * time.Minute
+
+	// maxMountErrorLength truncates mount error messages kept in status
+	maxMountErrorLength = 256
+)
+
+// SecretProviderClass GroupVersionKind for the Secrets Store CSI driver
+var secretProviderClassGVK = schema.GroupVersionKind{
+	Group:   "secrets-store.csi.x-k8s.io",
+	Version: "v1",
+	Kind:    "SecretProviderClass",
+}
+
+// reconcileSecretProviderClassUsage counts the pods mounting each SecretProviderClass and reports
+// pods whose SecretProviderClass was deleted, since they fail to start on their next restart, and
+// pods stuck pending because the CSI driver failed to mount their SecretProviderClasses.
+// Restricted mode cannot list pods outside the plugin namespace, so usage is not reported there.
+func (r *SecretsManagementConfigReconciler) reconcileSecretProviderClassUsage(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	csiMountFailures.Reset()
+	if r.Restricted || !config.Spec.Operators.SecretsStoreCSI.Enabled {
+		config.Status.SecretProviderClasses = smv1alpha1.SecretProviderClassUsageStatus{}
+		return nil
+	}
+	if !config.Status.DetectedOperators.SecretsStoreCSI.Installed {
+		config.Status.SecretProviderClasses = smv1alpha1.SecretProviderClassUsageStatus{}
+		r.setCondition(config, smv1alpha1.ConditionSecretProviderClassesAvailable, "Unknown", smv1alpha1.ReasonSecretsStoreCSINotInstalled, "The Secrets Store CSI driver is not installed")
+		return nil
+	}
+
+	// SecretProviderClasses and pods live in workload namespaces the cache does not cover
+	reader := r.apiReader()
+
+	spcs := &unstructured.UnstructuredList{}
+	spcs.SetGroupVersionKind(secretProviderClassGVK.GroupVersion().WithKind("SecretProviderClassList"))
+	if err := reader.List(ctx, spcs); err != nil {
+		if meta.IsNoMatchError(err) {
+			config.Status.SecretProviderClasses = smv1alpha1.SecretProviderClassUsageStatus{}
+			r.setCondition(config, smv1alpha1.ConditionSecretProviderClassesAvailable, "Unknown", smv1alpha1.ReasonSecretsStoreCSINotInstalled, "The Secrets Store CSI driver is not installed")
+			return nil
+		}
+		return err
+	}
+	existing := make(map[types.NamespacedName]bool, len(spcs.Items))
+	for _, spc := range spcs.Items {
+		existing[types.NamespacedName{Namespace: spc.GetNamespace(), Name: spc.GetName()}] = true
+	}
+
+	podCounts := make(map[types.NamespacedName]int32)
+	var pending []corev1.Pod
+	pods := &corev1.PodList{}
+	opts := []client.ListOption{client.Limit(podListPageSize)}
+	for {
+		if err := reader.List(ctx, pods, opts...); err != nil {
+			return err
+		}
+		for i := range pods.Items {
+			refs := podSecretProviderClasses(&pods.Items[i])
+			for _, ref := range refs {
+				podCounts[ref]++
+			}
+			if len(refs) > 0 && pods.Items[i].Status.Phase == corev1.PodPending && time.Since(pods.Items[i].CreationTimestamp.Time) > mountFailureGracePeriod {
+				pending = append(pending, pods.Items[i])
+			}
+		}
+		if pods.Continue == "" {
+			break
+		}
+		opts = []client.ListOption{client.Limit(podListPageSize), client.Continue(pods.Continue)}
+	}
+
+	usage := smv1alpha1.SecretProviderClassUsageStatus{Total: int32(len(spcs.Items))}
+	for ref, count := range podCounts {
+		entry := smv1alpha1.SecretProviderClassReference{Namespace: ref.Namespace, Name: ref.Name, Pods: count}
+		if existing[ref] {
+			usage.InUse++
+			usage.InUseBy = append(usage.InUseBy, entry)
+		} else {
+			usage.Missing = append(usage.Missing, entry)
+		}
+	}
+	usage.InUseBy = sortAndTruncateReferences(usage.InUseBy)
+	usage.Missing = sortAndTruncateReferences(usage.Missing)
+	failures, err := r.secretProviderClassMountFailures(ctx, pending)
+	if err != nil {
+		return err
+	}
+	usage.MountFailures = failures
+	config.Status.SecretProviderClasses = usage
+
+	if len(usage.Missing) > 0 {
+		first := usage.Missing[0]
+		r.setCondition(config, smv1alpha1.ConditionSecretProviderClassesAvailable, "False", smv1alpha1.ReasonSecretProviderClassMissing,
+			fmt.Sprintf("%d SecretProviderClass(es) mounted by pods no longer exist, including %s/%s used by %d pod(s); the pods will fail to start on restart",
+				len(usage.Missing), first.Namespace, first.Name, first.Pods))
+		return nil
+	}
+	if failures.Pods > 0 {
+		message := fmt.Sprintf("%d pod(s) in %s are pending because the CSI driver has not mounted their SecretProviderClasses",
+			failures.Pods, strings.Join(failures.Namespaces, ", "))
+		if len(failures.TopErrors) > 0 {
+			message += ": " + failures.TopErrors[0].Message
+		}
+		r.setCondition(config, smv1alpha1.ConditionSecretProviderClassesAvailable, "False", smv1alpha1.ReasonSecretProviderClassMountFailed, message)
+		return nil
+	}
+	r.setCondition(config, smv1alpha1.ConditionSecretProviderClassesAvailable, "True", smv1alpha1.ReasonSecretProviderClassesResolved,
+		fmt.Sprintf("%d of %d SecretProviderClasses are in use by pods", usage.InUse, usage.Total))
+	return nil
+}
+
+// secretProviderClassMountFailures aggregates the pending pods missing a mounted
+// SecretProviderClassPodStatus for one of their SecretProviderClasses, with the FailedMount
+// Warning Events the kubelet reported for their CSI volumes, and exports the count per namespace.
+func (r *SecretsManagementConfigReconciler) secretProviderClassMountFailures(ctx context.Context, pending []corev1.Pod) (smv1alpha1.SecretProviderClassMountFailures, error) {
+	failures := smv1alpha1.SecretProviderClassMountFailures{}
+	if len(pending) == 0 {
+		return failures, nil
+	}
+
+	// The driver creates a SecretProviderClassPodStatus once it mounted a SecretProviderClass in a pod
+	type mount struct {
+		pod   types.NamespacedName
+		class string
+	}
+	mounted := make(map[mount]bool)
+	err := r.forEachResource(ctx, secretProviderClassPodStatusGVK, func(status *unstructured.Unstructured) {
+		pod, _, _ := unstructured.NestedString(status.Object, "status", "podName")
+		class, _, _ := unstructured.NestedString(status.Object, "status", "secretProviderClassName")
+		ok, _, _ := unstructured.NestedBool(status.Object, "status", "mounted")
+		if ok {
+			mounted[mount{pod: types.NamespacedName{Namespace: status.GetNamespace(), Name: pod}, class: class}] = true
+		}
+	})
+	if err != nil {
+		return failures, err
+	}
+
+	// failing maps each failing pod to its Secrets Store CSI volume names
+	failing := make(map[types.NamespacedName][]string)
+	perNamespace := make(map[string]int32)
+	for i := range pending {
+		pod := &pending[i]
+		key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+		unmounted := false
+		for _, ref := range podSecretProviderClasses(pod) {
+			if !mounted[mount{pod: key, class: ref.Name}] {
+				unmounted = true
+			}
+		}
+		if !unmounted {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.CSI != nil && volume.CSI.Driver == SecretsStoreCSIDriverName {
+				failing[key] = append(failing[key], volume.Name)
+			}
+		}
+		perNamespace[pod.Namespace]++
+	}
+	if len(failing) == 0 {
+		return failures, nil
+	}
+	for namespace, count := range perNamespace {
+		failures.Pods += count
+		failures.Namespaces = append(failures.Namespaces, namespace)
+		csiMountFailures.WithLabelValues(namespace).Set(float64(count))
+	}
+	sort.Strings(failures.Namespaces)
+	if len(failures.Namespaces) > MaxSecretProviderClassReferences {
+		failures.Namespaces = failures.Namespaces[:MaxSecretProviderClassReferences]
+	}
+
+	// Warning Events are cached cluster-wide outside restricted mode
+	events := &corev1.EventList{}
+	if err := r.List(ctx, events); err != nil {
+		return failures, err
+	}
+	podsByMessage := make(map[string]map[types.NamespacedName]bool)
+	for _, event := range events.Items {
+		if event.Reason != "FailedMount" || event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+		key := types.NamespacedName{Namespace: event.InvolvedObject.Namespace, Name: event.InvolvedObject.Name}
+		volumes, ok := failing[key]
+		if !ok || !mentionsVolume(event.Message, volumes) {
+			continue
+		}
+		message := normalizeMountError(event.Message, key)
+		if podsByMessage[message] == nil {
+			podsByMessage[message] = make(map[types.NamespacedName]bool)
+		}
+		podsByMessage[message][key] = true
+	}
+	for message, pods := range podsByMessage {
+		failures.TopErrors = append(failures.TopErrors, smv1alpha1.MountErrorCount{Message: message, Pods: int32(len(pods))})
+	}
+	sort.Slice(failures.TopErrors, func(i, j int) bool {
+		if failures.TopErrors[i].Pods != failures.TopErrors[j].Pods {
+			return failures.TopErrors[i].Pods > failures.TopErrors[j].Pods
+		}
+		return failures.TopErrors[i].Message < failures.TopErrors[j].Message
+	})
+	if len(failures.TopErrors) > MaxMountErrors {
+		failures.TopErrors = failures.TopErrors[:MaxMountErrors]
+	}
+	return failures, nil
+}
+
+// mentionsVolume reports whether a FailedMount message is about one of the named volumes
+func mentionsVolume(message string, volumes []string) bool {
+	for _, volume := range volumes {
+		if strings.Contains(message, fmt.Sprintf("volume %q", volume)) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeMountError strips the kubelet's volume prefix and the pod name from a FailedMount
+// message, so pods failing for the same reason are counted together
+func normalizeMountError(message string, pod types.NamespacedName) string {
+	if _, rest, ok := strings.Cut(message, " : "); ok {
+		message = rest
+	}
+	message = strings.ReplaceAll(message, pod.Namespace+"/"+pod.Name, "<pod>")
+	message = strings.TrimSpace(message)
+	if len(message) > maxMountErrorLength {
+		message = message[:maxMountErrorLength]
+	}
+	return message
+}
+
+// podSecretProviderClasses returns the SecretProviderClasses mounted by a pod that has not terminated
+func podSecretProviderClasses(pod *corev1.Pod) []types.NamespacedName {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return nil
+	}
+	var refs []types.NamespacedName
+	for _, volume := range pod.Spec.Volumes {
+		if volume.CSI == nil || volume.CSI.Driver != SecretsStoreCSIDriverName {
+			continue
+		}
+		if name := volume.CSI.VolumeAttributes["secretProviderClass"]; name != "" {
+			refs = append(refs, types.NamespacedName{Namespace: pod.Namespace, Name: name})
+		}
+	}
+	return refs
+}
+
+// sortAndTruncateReferences orders references by pod count, then by namespace and name, keeping the first MaxSecretProviderClassReferences
+func sortAndTruncateReferences(refs []smv1alpha1.SecretProviderClassReference) []smv1alpha1.SecretProviderClassReference {
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Pods != refs[j].Pods {
+			return refs[i].Pods > refs[j].Pods
+		}
+		if refs[i].Namespace != refs[j].Namespace {
+			return refs[i].Namespace < refs[j].Namespace
+		}
+		return refs[i].Name < refs[j].Name
+	})
+	if len(refs) > MaxSecretProviderClassReferences {
+		refs = refs[:MaxSecretProviderClassReferences]
+	}
+	return refs
+}
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestSecretProviderClass(namespace, name string) *unstructured.Unstructured {
+	spc := &unstructured.Unstructured{}
+	spc.SetGroupVersionKind(secretProviderClassGVK)
+	spc.SetNamespace(namespace)
+	spc.SetName(name)
+	return spc
+}
+
+func newTestCSIPod(namespace, name, spc string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "secrets",
+					VolumeSource: corev1.VolumeSource{
+						CSI: &corev1.CSIVolumeSource{
+							Driver:           SecretsStoreCSIDriverName,
+							VolumeAttributes: map[string]string{"secretProviderClass": spc},
+						},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestReconcileSecretProviderClassUsage(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Status.DetectedOperators.SecretsStoreCSI.Installed = true
+	r := newTestReconciler(
+		newTestSecretProviderClass("app", "vault-db"),
+		newTestSecretProviderClass("app", "unused"),
+		newTestCSIPod("app", "db-0", "vault-db", corev1.PodRunning),
+		newTestCSIPod("app", "db-1", "vault-db", corev1.PodRunning),
+		newTestCSIPod("app", "job-1", "vault-db", corev1.PodSucceeded),
+	)
+
+	require.NoError(t, r.reconcileSecretProviderClassUsage(ctx, config))
+
+	usage := config.Status.SecretProviderClasses
+	assert.Equal(t, int32(2), usage.Total)
+	assert.Equal(t, int32(1), usage.InUse)
+	assert.Equal(t, []smv1alpha1.SecretProviderClassReference{{Namespace: "app", Name: "vault-db", Pods: 2}}, usage.InUseBy)
+	assert.Empty(t, usage.Missing)
+	cond := findCondition(config, smv1alpha1.ConditionSecretProviderClassesAvailable)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+
+	// Deleting an in-use SecretProviderClass is reported against the pods still mounting it
+	require.NoError(t, r.Delete(ctx, newTestSecretProviderClass("app", "vault-db")))
+	require.NoError(t, r.reconcileSecretProviderClassUsage(ctx, config))
+
+	usage = config.Status.SecretProviderClasses
+	assert.Equal(t, int32(0), usage.InUse)
+	assert.Equal(t, []smv1alpha1.SecretProviderClassReference{{Namespace: "app", Name: "vault-db", Pods: 2}}, usage.Missing)
+	cond = findCondition(config, smv1alpha1.ConditionSecretProviderClassesAvailable)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonSecretProviderClassMissing, cond.Reason)
+}
+
+func newTestMountedPodStatus(namespace, pod, spc string) *unstructured.Unstructured {
+	status := &unstructured.Unstructured{}
+	status.SetGroupVersionKind(secretProviderClassPodStatusGVK)
+	status.SetNamespace(namespace)
+	status.SetName(pod + "-" + namespace + "-" + spc)
+	_ = unstructured.SetNestedMap(status.Object, map[string]interface{}{
+		"podName":                 pod,
+		"secretProviderClassName": spc,
+		"mounted":                 true,
+	}, "status")
+	return status
+}
+
+func newTestFailedMountEvent(namespace, pod, message string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: pod + ".failedmount", Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: pod},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "FailedMount",
+		Message:        message,
+	}
+}
+
+func TestReconcileSecretProviderClassUsage_MountFailures(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Status.DetectedOperators.SecretsStoreCSI.Installed = true
+	stuck := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	pendingPod := func(namespace, name string, created metav1.Time) *corev1.Pod {
+		pod := newTestCSIPod(namespace, name, "vault-db", corev1.PodPending)
+		pod.CreationTimestamp = created
+		return pod
+	}
+	pvcEvent := newTestFailedMountEvent("app", "db-0", `MountVolume.SetUp failed for volume "data" : not found`)
+	pvcEvent.Name = "db-0.pvc"
+	denied := "rpc error: code = Unknown desc = failed to mount secrets store objects for pod %s, err: permission denied"
+	r := newTestReconciler(
+		newTestSecretProviderClass("app", "vault-db"),
+		newTestSecretProviderClass("billing", "vault-db"),
+		pendingPod("app", "db-0", stuck),
+		pendingPod("billing", "api-0", stuck),
+		// Mounted, but pending on something else
+		pendingPod("app", "db-1", stuck),
+		newTestMountedPodStatus("app", "db-1", "vault-db"),
+		// Still within the grace period
+		pendingPod("app", "db-2", metav1.Now()),
+		newTestFailedMountEvent("app", "db-0", `MountVolume.SetUp failed for volume "secrets" : `+fmt.Sprintf(denied, "app/db-0")),
+		newTestFailedMountEvent("billing", "api-0", `MountVolume.SetUp failed for volume "secrets" : `+fmt.Sprintf(denied, "billing/api-0")),
+		// About another volume of the pod
+		pvcEvent,
+	)
+
+	require.NoError(t, r.reconcileSecretProviderClassUsage(ctx, config))
+
+	failures := config.Status.SecretProviderClasses.MountFailures
+	assert.Equal(t, int32(2), failures.Pods)
+	assert.Equal(t, []string{"app", "billing"}, failures.Namespaces)
+	assert.Equal(t, []smv1alpha1.MountErrorCount{{Message: fmt.Sprintf(denied, "<pod>"), Pods: 2}}, failures.TopErrors)
+	assert.Equal(t, float64(1), testutil.ToFloat64(csiMountFailures.WithLabelValues("billing")))
+	cond := findCondition(config, smv1alpha1.ConditionSecretProviderClassesAvailable)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonSecretProviderClassMountFailed, cond.Reason)
+	assert.Contains(t, cond.Message, "permission denied")
+
+	// Once the driver mounts the SecretProviderClasses the failures clear
+	require.NoError(t, r.Create(ctx, newTestMountedPodStatus("app", "db-0", "vault-db")))
+	require.NoError(t, r.Create(ctx, newTestMountedPodStatus("billing", "api-0", "vault-db")))
+	require.NoError(t, r.reconcileSecretProviderClassUsage(ctx, config))
+
+	assert.Empty(t, config.Status.SecretProviderClasses.MountFailures)
+	assert.Equal(t, 0, testutil.CollectAndCount(csiMountFailures))
+	cond = findCondition(config, smv1alpha1.ConditionSecretProviderClassesAvailable)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+}
+
+func TestReconcileSecretProviderClassUsage_NotInstalled(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcileSecretProviderClassUsage(ctx, config))
+
+	cond := findCondition(config, smv1alpha1.ConditionSecretProviderClassesAvailable)
+	require.NotNil(t, cond)
+	assert.Equal(t, "Unknown", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonSecretsStoreCSINotInstalled, cond.Reason)
+}
+
+func TestPodSecretProviderClasses_IgnoresOtherDrivers(t *testing.T) {
+	pod := newTestCSIPod("app", "db-0", "vault-db", corev1.PodRunning)
+	pod.Spec.Volumes[0].CSI.Driver = "ebs.csi.aws.com"
+	assert.Empty(t, podSecretProviderClasses(pod))
+}
@@ -0,0 +1,457 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// RotatedAtAnnotation records when a Secret that is not managed by the External Secrets
+	// Operator or cert-manager was last rotated, as an RFC 3339 time. Its creation time is used
+	// when unset.
+	RotatedAtAnnotation = "secrets-management.openshift.io/rotated-at"
+
+	// RotationDueAnnotation is set by the Annotate action on Secrets older than the policy's
+	// maxAge, to the time they became overdue, and removed once they are rotated
+	RotationDueAnnotation = "secrets-management.openshift.io/rotation-due"
+
+	// externalSecretForceSyncAnnotation makes the External Secrets Operator refresh an
+	// ExternalSecret when its value changes
+	externalSecretForceSyncAnnotation = "force-sync"
+
+	// certificateNameAnnotation is set by cert-manager on the Secrets and CertificateRequests it
+	// creates for a Certificate
+	certificateNameAnnotation = "cert-manager.io/certificate-name"
+
+	// DefaultRotationCheckInterval is how often each policy re-checks its Secrets
+	DefaultRotationCheckInterval = 10 * time.Minute
+
+	// maxRotationNamespaces is the number of namespaces reported in a policy's status
+	maxRotationNamespaces = 100
+
+	// maxOverdueSecrets is the number of overdue Secrets named per namespace
+	maxOverdueSecrets = 10
+)
+
+var (
+	externalSecretGVK = schema.GroupVersionKind{
+		Group:   "external-secrets.io",
+		Version: "v1beta1",
+		Kind:    "ExternalSecret",
+	}
+	certificateGVK = schema.GroupVersionKind{
+		Group:   "cert-manager.io",
+		Version: "v1",
+		Kind:    "Certificate",
+	}
+)
+
+// rotationSource is what a Secret's last rotation time was read from
+type rotationSource struct {
+	rotatedAt time.Time
+	// externalSecret owns the Secret, when it is synced by the External Secrets Operator
+	externalSecret *unstructured.Unstructured
+	// certificate issued the Secret, when it is managed by cert-manager
+	certificate *unstructured.Unstructured
+}
+
+// SecretRotationPolicyReconciler keeps the Secrets selected by each SecretRotationPolicy younger
+// than its maxAge and reports rotation compliance per namespace
+type SecretRotationPolicyReconciler struct {
+	client.Client
+	Log logr.Logger
+
+	// APIReader lists Secrets and Namespaces and reads ExternalSecrets and Certificates across the
+	// cluster, none of which are cached
+	APIReader client.Reader
+
+	// Restricted limits every policy to the plugin namespace, since Secrets cannot be listed
+	// cluster-wide
+	Restricted bool
+}
+
+// +kubebuilder:rbac:groups=secrets-management.openshift.io,resources=secretrotationpolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=secrets-management.openshift.io,resources=secretrotationpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=patch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates/status,verbs=update;patch
+
+// Reconcile checks the age of the policy's Secrets and rotates the overdue ones
+func (r *SecretRotationPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reterr error) {
+	log := r.Log.WithValues("secretrotationpolicy", req.NamespacedName)
+
+	policy := &smv1alpha1.SecretRotationPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !policy.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	original := policy.DeepCopy()
+	defer func() {
+		if err := r.patchStatus(ctx, original, policy); err != nil {
+			log.Error(err, "Failed to patch status")
+			if reterr == nil {
+				reterr = err
+			}
+		}
+	}()
+	policy.Status.ObservedGeneration = policy.Generation
+
+	if msg := validateRotationPolicy(policy); msg != "" {
+		policy.Status.Message = msg
+		return ctrl.Result{}, nil
+	}
+	policy.Status.Message = ""
+
+	secrets, err := r.coveredSecrets(ctx, policy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	maxAge := policy.Spec.MaxAge.Duration
+	namespaces := map[string]*smv1alpha1.NamespaceRotationStatus{}
+	var total, compliant, triggered int32
+	for i := range secrets {
+		secret := &secrets[i]
+		ns, ok := namespaces[secret.Namespace]
+		if !ok {
+			ns = &smv1alpha1.NamespaceRotationStatus{Namespace: secret.Namespace}
+			namespaces[secret.Namespace] = ns
+		}
+		ns.Secrets++
+		total++
+
+		source, err := r.lastRotation(ctx, secret)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if now.Sub(source.rotatedAt) <= maxAge {
+			ns.Compliant++
+			compliant++
+			if err := r.clearRotationDue(ctx, secret); err != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+
+		ns.Overdue = append(ns.Overdue, secret.Name)
+		started, err := r.rotate(ctx, policy.Spec.Action, secret, source, now)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if started {
+			log.Info("Triggered rotation", "secret", secret.Namespace+"/"+secret.Name, "action", policy.Spec.Action)
+			triggered++
+		}
+	}
+
+	policy.Status.LastEvaluated = &metav1.Time{Time: now}
+	policy.Status.Secrets = total
+	policy.Status.Compliant = compliant
+	policy.Status.RotationsTriggered = triggered
+	policy.Status.Namespaces = namespaceRotationStatuses(namespaces)
+	return ctrl.Result{RequeueAfter: DefaultRotationCheckInterval}, nil
+}
+
+// validateRotationPolicy returns why a policy cannot be applied, or "" when it is valid
+func validateRotationPolicy(policy *smv1alpha1.SecretRotationPolicy) string {
+	if policy.Spec.MaxAge.Duration <= 0 {
+		return "maxAge must be positive"
+	}
+	switch policy.Spec.Action {
+	case smv1alpha1.RotationActionAnnotate, smv1alpha1.RotationActionRefreshExternalSecret, smv1alpha1.RotationActionRenewCertificate:
+	default:
+		return fmt.Sprintf("Unknown action %q", policy.Spec.Action)
+	}
+	if _, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector); err != nil {
+		return fmt.Sprintf("Invalid selector: %v", err)
+	}
+	if _, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector); err != nil {
+		return fmt.Sprintf("Invalid namespaceSelector: %v", err)
+	}
+	return ""
+}
+
+// coveredSecrets returns the metadata of the Secrets the policy selects. Only metadata is
+// needed to judge a Secret's age, so Secret data is never read.
+func (r *SecretRotationPolicyReconciler) coveredSecrets(ctx context.Context, policy *smv1alpha1.SecretRotationPolicy) ([]metav1.PartialObjectMetadata, error) {
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	// A nil selector converts to one matching nothing
+	if policy.Spec.Selector == nil {
+		selector = labels.Everything()
+	}
+
+	if r.Restricted {
+		return r.listSecretMetadata(ctx, selector, PluginNamespace)
+	}
+
+	if policy.Spec.NamespaceSelector == nil {
+		all, err := r.listSecretMetadata(ctx, selector, "")
+		if err != nil {
+			return nil, err
+		}
+		secrets := all[:0]
+		for _, s := range all {
+			if !isPlatformNamespace(s.Namespace) {
+				secrets = append(secrets, s)
+			}
+		}
+		return secrets, nil
+	}
+
+	nsSelector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := &metav1.PartialObjectMetadataList{}
+	namespaces.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NamespaceList"))
+	if err := r.apiReader().List(ctx, namespaces, client.MatchingLabelsSelector{Selector: nsSelector}); err != nil {
+		return nil, err
+	}
+	var secrets []metav1.PartialObjectMetadata
+	for _, ns := range namespaces.Items {
+		inNamespace, err := r.listSecretMetadata(ctx, selector, ns.Name)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, inNamespace...)
+	}
+	return secrets, nil
+}
+
+// listSecretMetadata lists the metadata of Secrets matching selector in namespace, or in every
+// namespace when it is empty, a page at a time
+func (r *SecretRotationPolicyReconciler) listSecretMetadata(ctx context.Context, selector labels.Selector, namespace string) ([]metav1.PartialObjectMetadata, error) {
+	var secrets []metav1.PartialObjectMetadata
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	opts := []client.ListOption{client.MatchingLabelsSelector{Selector: selector}, client.InNamespace(namespace), client.Limit(podListPageSize)}
+	for {
+		if err := r.apiReader().List(ctx, list, opts...); err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			// List items carry no kind, which patching them needs
+			item.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+			secrets = append(secrets, item)
+		}
+		if list.Continue == "" {
+			return secrets, nil
+		}
+		opts = []client.ListOption{client.MatchingLabelsSelector{Selector: selector}, client.InNamespace(namespace), client.Limit(podListPageSize), client.Continue(list.Continue)}
+	}
+}
+
+// lastRotation returns when a Secret was last rotated: the last refresh of the ExternalSecret
+// owning it, the issuance of the Certificate it holds, its rotated-at annotation, or its creation
+func (r *SecretRotationPolicyReconciler) lastRotation(ctx context.Context, secret *metav1.PartialObjectMetadata) (rotationSource, error) {
+	source := rotationSource{rotatedAt: secret.CreationTimestamp.Time}
+
+	for _, ref := range secret.OwnerReferences {
+		if ref.Kind != externalSecretGVK.Kind {
+			continue
+		}
+		es, err := r.getOptional(ctx, externalSecretGVK, secret.Namespace, ref.Name)
+		if err != nil || es == nil {
+			return source, err
+		}
+		source.externalSecret = es
+		if t, ok := nestedTime(es, "status", "refreshTime"); ok {
+			source.rotatedAt = t
+		}
+		return source, nil
+	}
+
+	if name := secret.Annotations[certificateNameAnnotation]; name != "" {
+		cert, err := r.getOptional(ctx, certificateGVK, secret.Namespace, name)
+		if err != nil || cert == nil {
+			return source, err
+		}
+		source.certificate = cert
+		if t, ok := nestedTime(cert, "status", "notBefore"); ok {
+			source.rotatedAt = t
+		}
+		return source, nil
+	}
+
+	if value := secret.Annotations[RotatedAtAnnotation]; value != "" {
+		if t, err := time.Parse(time.RFC3339, value); err == nil && t.After(source.rotatedAt) {
+			source.rotatedAt = t
+		}
+	}
+	return source, nil
+}
+
+// rotate starts the policy's action for an overdue Secret and reports whether it started a new
+// rotation. Rotations already in progress are not started again, and Secrets the action cannot
+// rotate, such as a Secret without an ExternalSecret for RefreshExternalSecret, are only reported.
+func (r *SecretRotationPolicyReconciler) rotate(ctx context.Context, action smv1alpha1.RotationAction, secret *metav1.PartialObjectMetadata, source rotationSource, now time.Time) (bool, error) {
+	switch action {
+	case smv1alpha1.RotationActionAnnotate:
+		if _, ok := secret.Annotations[RotationDueAnnotation]; ok {
+			return false, nil
+		}
+		patch := client.MergeFrom(secret.DeepCopy())
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[RotationDueAnnotation] = now.UTC().Format(time.RFC3339)
+		return true, client.IgnoreNotFound(r.Patch(ctx, secret, patch))
+
+	case smv1alpha1.RotationActionRefreshExternalSecret:
+		es := source.externalSecret
+		if es == nil {
+			return false, nil
+		}
+		// A force-sync newer than the last refresh is still being processed
+		if requested, err := strconv.ParseInt(es.GetAnnotations()[externalSecretForceSyncAnnotation], 10, 64); err == nil && requested > source.rotatedAt.Unix() {
+			return false, nil
+		}
+		annotations := es.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[externalSecretForceSyncAnnotation] = strconv.FormatInt(now.Unix(), 10)
+		es.SetAnnotations(annotations)
+		return true, client.IgnoreNotFound(r.Update(ctx, es))
+
+	case smv1alpha1.RotationActionRenewCertificate:
+		cert := source.certificate
+		if cert == nil {
+			return false, nil
+		}
+		conditions, _, _ := unstructured.NestedSlice(cert.Object, "status", "conditions")
+		for _, c := range conditions {
+			if cond, ok := c.(map[string]interface{}); ok && cond["type"] == "Issuing" && cond["status"] == string(corev1.ConditionTrue) {
+				return false, nil
+			}
+		}
+		// Setting Issuing=True is how cert-manager's own renew command triggers a reissue
+		conditions = append(conditions, map[string]interface{}{
+			"type":               "Issuing",
+			"status":             string(corev1.ConditionTrue),
+			"reason":             "ManuallyTriggered",
+			"message":            "Renewal requested by a SecretRotationPolicy because the Secret exceeded its maximum age",
+			"lastTransitionTime": now.UTC().Format(time.RFC3339),
+		})
+		if err := unstructured.SetNestedSlice(cert.Object, conditions, "status", "conditions"); err != nil {
+			return false, err
+		}
+		return true, client.IgnoreNotFound(r.Status().Update(ctx, cert))
+	}
+	return false, nil
+}
+
+// clearRotationDue removes the rotation-due annotation from a Secret that was rotated
+func (r *SecretRotationPolicyReconciler) clearRotationDue(ctx context.Context, secret *metav1.PartialObjectMetadata) error {
+	if _, ok := secret.Annotations[RotationDueAnnotation]; !ok {
+		return nil
+	}
+	patch := client.MergeFrom(secret.DeepCopy())
+	delete(secret.Annotations, RotationDueAnnotation)
+	return client.IgnoreNotFound(r.Patch(ctx, secret, patch))
+}
+
+// getOptional reads an object that may be missing or whose API may not be installed, returning nil then
+func (r *SecretRotationPolicyReconciler) getOptional(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	if err := r.apiReader().Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, u); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return u, nil
+}
+
+// nestedTime parses an RFC 3339 time field of an unstructured object
+func nestedTime(u *unstructured.Unstructured, fields ...string) (time.Time, bool) {
+	value, _, _ := unstructured.NestedString(u.Object, fields...)
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, err == nil
+}
+
+// namespaceRotationStatuses orders namespaces with the most overdue Secrets first and keeps the
+// first maxRotationNamespaces
+func namespaceRotationStatuses(namespaces map[string]*smv1alpha1.NamespaceRotationStatus) []smv1alpha1.NamespaceRotationStatus {
+	statuses := make([]smv1alpha1.NamespaceRotationStatus, 0, len(namespaces))
+	for _, ns := range namespaces {
+		sort.Strings(ns.Overdue)
+		if len(ns.Overdue) > maxOverdueSecrets {
+			ns.Overdue = ns.Overdue[:maxOverdueSecrets]
+		}
+		statuses = append(statuses, *ns)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		oi, oj := statuses[i].Secrets-statuses[i].Compliant, statuses[j].Secrets-statuses[j].Compliant
+		if oi != oj {
+			return oi > oj
+		}
+		return statuses[i].Namespace < statuses[j].Namespace
+	})
+	if len(statuses) > maxRotationNamespaces {
+		statuses = statuses[:maxRotationNamespaces]
+	}
+	return statuses
+}
+
+// patchStatus writes the policy status with a merge patch
+func (r *SecretRotationPolicyReconciler) patchStatus(ctx context.Context, original, policy *smv1alpha1.SecretRotationPolicy) error {
+	if equality.Semantic.DeepEqual(original.Status, policy.Status) {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Status().Patch(ctx, policy, client.MergeFrom(original))
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+// apiReader returns the uncached reader, falling back to the cached client
+func (r *SecretRotationPolicyReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *SecretRotationPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Secrets, ExternalSecrets and Certificates may live in any namespace and are not watched;
+	// ages are re-checked every DefaultRotationCheckInterval
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&smv1alpha1.SecretRotationPolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
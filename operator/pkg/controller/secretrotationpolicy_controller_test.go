@@ -0,0 +1,211 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestRotationReconciler(objs ...client.Object) *SecretRotationPolicyReconciler {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithObjects(objs...).
+		WithStatusSubresource(&smv1alpha1.SecretRotationPolicy{}, certificate).
+		Build()
+
+	return &SecretRotationPolicyReconciler{
+		Client: fakeClient,
+		Log:    logr.Discard(),
+	}
+}
+
+func newTestRotationPolicy(action smv1alpha1.RotationAction) *smv1alpha1.SecretRotationPolicy {
+	return &smv1alpha1.SecretRotationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "rotate"},
+		Spec: smv1alpha1.SecretRotationPolicySpec{
+			MaxAge: metav1.Duration{Duration: 24 * time.Hour},
+			Action: action,
+		},
+	}
+}
+
+func newTestAgedSecret(namespace, name string, age time.Duration) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.Time{Time: time.Now().Add(-age)},
+		},
+	}
+}
+
+func reconcileRotationPolicy(t *testing.T, r *SecretRotationPolicyReconciler) *smv1alpha1.SecretRotationPolicy {
+	t.Helper()
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "rotate"}})
+	require.NoError(t, err)
+	policy := &smv1alpha1.SecretRotationPolicy{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: "rotate"}, policy))
+	return policy
+}
+
+func getTestSecret(t *testing.T, r *SecretRotationPolicyReconciler, namespace, name string) *corev1.Secret {
+	t.Helper()
+	secret := &corev1.Secret{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, secret))
+	return secret
+}
+
+func TestSecretRotationPolicy_AnnotatesOverdueSecrets(t *testing.T) {
+	rotated := newTestAgedSecret("app", "rotated", 48*time.Hour)
+	rotated.Annotations = map[string]string{
+		RotatedAtAnnotation:   time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+		RotationDueAnnotation: "2026-01-01T00:00:00Z",
+	}
+	r := newTestRotationReconciler(
+		newTestRotationPolicy(smv1alpha1.RotationActionAnnotate),
+		newTestAgedSecret("app", "fresh", time.Hour),
+		newTestAgedSecret("app", "stale", 48*time.Hour),
+		rotated,
+		newTestAgedSecret("other", "stale", 72*time.Hour),
+		// Platform namespaces are not covered without a namespaceSelector
+		newTestAgedSecret("openshift-config", "stale", 72*time.Hour),
+	)
+
+	policy := reconcileRotationPolicy(t, r)
+	assert.Empty(t, policy.Status.Message)
+	require.NotNil(t, policy.Status.LastEvaluated)
+	assert.Equal(t, int32(4), policy.Status.Secrets)
+	assert.Equal(t, int32(2), policy.Status.Compliant)
+	assert.Equal(t, int32(2), policy.Status.RotationsTriggered)
+	assert.Equal(t, []smv1alpha1.NamespaceRotationStatus{
+		{Namespace: "app", Secrets: 3, Compliant: 2, Overdue: []string{"stale"}},
+		{Namespace: "other", Secrets: 1, Compliant: 0, Overdue: []string{"stale"}},
+	}, policy.Status.Namespaces)
+
+	assert.Contains(t, getTestSecret(t, r, "app", "stale").Annotations, RotationDueAnnotation)
+	assert.NotContains(t, getTestSecret(t, r, "app", "fresh").Annotations, RotationDueAnnotation)
+	assert.NotContains(t, getTestSecret(t, r, "app", "rotated").Annotations, RotationDueAnnotation)
+	assert.NotContains(t, getTestSecret(t, r, "openshift-config", "stale").Annotations, RotationDueAnnotation)
+
+	// Secrets already marked are not counted again
+	policy = reconcileRotationPolicy(t, r)
+	assert.Equal(t, int32(0), policy.Status.RotationsTriggered)
+}
+
+func TestSecretRotationPolicy_Selectors(t *testing.T) {
+	policy := newTestRotationPolicy(smv1alpha1.RotationActionAnnotate)
+	policy.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"rotate": "true"}}
+	policy.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "db"}}
+	selected := newTestAgedSecret("app", "db", 48*time.Hour)
+	selected.Labels = map[string]string{"tier": "db"}
+	unselected := newTestAgedSecret("app", "web", 48*time.Hour)
+	elsewhere := newTestAgedSecret("other", "db", 48*time.Hour)
+	elsewhere.Labels = map[string]string{"tier": "db"}
+	r := newTestRotationReconciler(
+		policy, selected, unselected, elsewhere,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"rotate": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	)
+
+	policy = reconcileRotationPolicy(t, r)
+	assert.Equal(t, int32(1), policy.Status.Secrets)
+	require.Len(t, policy.Status.Namespaces, 1)
+	assert.Equal(t, []string{"db"}, policy.Status.Namespaces[0].Overdue)
+}
+
+func TestSecretRotationPolicy_RefreshesExternalSecret(t *testing.T) {
+	es := &unstructured.Unstructured{}
+	es.SetGroupVersionKind(externalSecretGVK)
+	es.SetNamespace("app")
+	es.SetName("db-credentials")
+	require.NoError(t, unstructured.SetNestedField(es.Object, time.Now().Add(-48*time.Hour).UTC().Format(time.RFC3339), "status", "refreshTime"))
+	// Recently created, but last refreshed from the store two days ago
+	secret := newTestAgedSecret("app", "db-credentials", time.Hour)
+	secret.OwnerReferences = []metav1.OwnerReference{{APIVersion: "external-secrets.io/v1beta1", Kind: "ExternalSecret", Name: "db-credentials", UID: "es-uid"}}
+	r := newTestRotationReconciler(newTestRotationPolicy(smv1alpha1.RotationActionRefreshExternalSecret), es, secret, newTestAgedSecret("app", "plain", 48*time.Hour))
+
+	policy := reconcileRotationPolicy(t, r)
+	// The plain Secret is overdue but has no ExternalSecret to refresh
+	assert.Equal(t, int32(0), policy.Status.Compliant)
+	assert.Equal(t, int32(1), policy.Status.RotationsTriggered)
+
+	got, err := r.getOptional(context.Background(), externalSecretGVK, "app", "db-credentials")
+	require.NoError(t, err)
+	requested, err := strconv.ParseInt(got.GetAnnotations()[externalSecretForceSyncAnnotation], 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Now().Unix(), requested, 60)
+
+	// The pending refresh is not requested again
+	policy = reconcileRotationPolicy(t, r)
+	assert.Equal(t, int32(0), policy.Status.RotationsTriggered)
+}
+
+func TestSecretRotationPolicy_RenewsCertificate(t *testing.T) {
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(certificateGVK)
+	cert.SetNamespace("app")
+	cert.SetName("serving")
+	require.NoError(t, unstructured.SetNestedField(cert.Object, time.Now().Add(-48*time.Hour).UTC().Format(time.RFC3339), "status", "notBefore"))
+	secret := newTestAgedSecret("app", "serving-tls", time.Hour)
+	secret.Annotations = map[string]string{certificateNameAnnotation: "serving"}
+	r := newTestRotationReconciler(newTestRotationPolicy(smv1alpha1.RotationActionRenewCertificate), cert, secret)
+
+	policy := reconcileRotationPolicy(t, r)
+	assert.Equal(t, int32(1), policy.Status.RotationsTriggered)
+
+	got, err := r.getOptional(context.Background(), certificateGVK, "app", "serving")
+	require.NoError(t, err)
+	conditions, _, _ := unstructured.NestedSlice(got.Object, "status", "conditions")
+	require.Len(t, conditions, 1)
+	issuing := conditions[0].(map[string]interface{})
+	assert.Equal(t, "Issuing", issuing["type"])
+	assert.Equal(t, "True", issuing["status"])
+
+	// cert-manager is already reissuing
+	policy = reconcileRotationPolicy(t, r)
+	assert.Equal(t, int32(0), policy.Status.RotationsTriggered)
+}
+
+func TestSecretRotationPolicy_RestrictedMode(t *testing.T) {
+	r := newTestRotationReconciler(
+		newTestRotationPolicy(smv1alpha1.RotationActionAnnotate),
+		newTestAgedSecret(PluginNamespace, "plugin-config", 48*time.Hour),
+		newTestAgedSecret("app", "stale", 48*time.Hour),
+	)
+	r.Restricted = true
+
+	policy := reconcileRotationPolicy(t, r)
+	assert.Equal(t, int32(1), policy.Status.Secrets)
+	require.Len(t, policy.Status.Namespaces, 1)
+	assert.Equal(t, PluginNamespace, policy.Status.Namespaces[0].Namespace)
+	assert.NotContains(t, getTestSecret(t, r, "app", "stale").Annotations, RotationDueAnnotation)
+}
+
+func TestSecretRotationPolicy_InvalidPolicy(t *testing.T) {
+	policy := newTestRotationPolicy(smv1alpha1.RotationActionAnnotate)
+	policy.Spec.Selector = &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}},
+	}
+	r := newTestRotationReconciler(policy, newTestAgedSecret("app", "stale", 48*time.Hour))
+
+	policy = reconcileRotationPolicy(t, r)
+	assert.Contains(t, policy.Status.Message, "Invalid selector")
+	assert.Nil(t, policy.Status.LastEvaluated)
+	assert.NotContains(t, getTestSecret(t, r, "app", "stale").Annotations, RotationDueAnnotation)
+}
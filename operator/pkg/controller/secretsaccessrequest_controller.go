@@ -0,0 +1,366 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// MaxAccessRequestDuration is the longest access a SecretsAccessRequest can grant
+	MaxAccessRequestDuration = 24 * time.Hour
+
+	// MaxAccessRequestAuditEntries is the number of entries kept in status.auditTrail
+	MaxAccessRequestAuditEntries = 20
+
+	// AccessRequestLabel is set on bindings to the name of the SecretsAccessRequest that created them
+	AccessRequestLabel = "secrets-management.openshift.io/access-request"
+)
+
+// SecretsAccessRequestReconciler grants the access described by approved SecretsAccessRequests
+// and revokes it when the request expires or its approval is withdrawn
+type SecretsAccessRequestReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// APIReader reads bindings outside the cached plugin namespace; the cached client is used when nil
+	APIReader client.Reader
+}
+
+// +kubebuilder:rbac:groups=secrets-management.openshift.io,resources=secretsaccessrequests,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=secrets-management.openshift.io,resources=secretsaccessrequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingadmissionpolicies;validatingadmissionpolicybindings,verbs=get;list;watch
+
+// Reconcile moves a SecretsAccessRequest through its lifecycle
+func (r *SecretsAccessRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reterr error) {
+	log := r.Log.WithValues("secretsaccessrequest", req.NamespacedName)
+
+	request := &smv1alpha1.SecretsAccessRequest{}
+	if err := r.Get(ctx, req.NamespacedName, request); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Bindings are owned by the request and garbage collected with it
+	if !request.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	original := request.DeepCopy()
+	defer func() {
+		if err := r.patchStatus(ctx, original, request); err != nil {
+			log.Error(err, "Failed to patch status")
+			if reterr == nil {
+				reterr = err
+			}
+		}
+	}()
+
+	switch request.Status.Phase {
+	case smv1alpha1.AccessRequestExpired, smv1alpha1.AccessRequestRevoked, smv1alpha1.AccessRequestRejected:
+		// Terminal; make sure nothing is left behind
+		return ctrl.Result{}, r.deleteBinding(ctx, request)
+	}
+
+	config, err := r.primaryConfig(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if msg := validateAccessRequest(request, config); msg != "" {
+		// The config may have stopped allowing what an active request was granted
+		if err := r.deleteBinding(ctx, request); err != nil {
+			return ctrl.Result{}, err
+		}
+		setAccessRequestPhase(request, smv1alpha1.AccessRequestRejected, msg)
+		return ctrl.Result{}, nil
+	}
+
+	if !request.Spec.Approved {
+		if request.Status.Phase == smv1alpha1.AccessRequestActive {
+			if err := r.deleteBinding(ctx, request); err != nil {
+				return ctrl.Result{}, err
+			}
+			setAccessRequestPhase(request, smv1alpha1.AccessRequestRevoked, "Approval withdrawn; access revoked")
+			return ctrl.Result{}, nil
+		}
+		setAccessRequestPhase(request, smv1alpha1.AccessRequestPending,
+			fmt.Sprintf("%s requested %s access for %s: %s", request.Spec.User, request.Spec.AccessLevel, request.Spec.Duration.Duration, request.Spec.Justification))
+		return ctrl.Result{}, nil
+	}
+
+	// The admission policy checked the approver could approve and set approvedBy to their name
+	if request.Spec.ApprovedBy == "" {
+		setAccessRequestPhase(request, smv1alpha1.AccessRequestPending, "Approved without spec.approvedBy naming the approver; access is not granted")
+		return ctrl.Result{}, nil
+	}
+	if request.Spec.ApprovedBy == request.Spec.User {
+		if err := r.deleteBinding(ctx, request); err != nil {
+			return ctrl.Result{}, err
+		}
+		setAccessRequestPhase(request, smv1alpha1.AccessRequestRejected, fmt.Sprintf("%s cannot approve their own access", request.Spec.User))
+		return ctrl.Result{}, nil
+	}
+
+	now := metav1.Now()
+	if request.Status.ApprovedAt == nil {
+		enforced, err := r.approvalEnforced(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !enforced {
+			// Without the policy anyone able to edit the request could have approved it
+			setAccessRequestPhase(request, smv1alpha1.AccessRequestPending,
+				fmt.Sprintf("The %s ValidatingAdmissionPolicy is not in place, so the approval cannot be trusted; access is not granted", AccessRequestPolicyName))
+			return ctrl.Result{}, nil
+		}
+		expiresAt := metav1.NewTime(now.Add(request.Spec.Duration.Duration))
+		request.Status.ApprovedAt = &now
+		request.Status.ExpiresAt = &expiresAt
+		request.Status.ApprovedBy = request.Spec.ApprovedBy
+	}
+
+	if !now.Before(request.Status.ExpiresAt) {
+		if err := r.deleteBinding(ctx, request); err != nil {
+			return ctrl.Result{}, err
+		}
+		setAccessRequestPhase(request, smv1alpha1.AccessRequestExpired, "Access expired and was revoked")
+		return ctrl.Result{}, nil
+	}
+
+	roleName := fmt.Sprintf("%s-%s", rolePrefix(config), request.Spec.AccessLevel)
+	msg, err := r.ensureBinding(ctx, request, roleName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if msg != "" {
+		setAccessRequestPhase(request, smv1alpha1.AccessRequestRejected, msg)
+		return ctrl.Result{}, nil
+	}
+	setAccessRequestPhase(request, smv1alpha1.AccessRequestActive,
+		fmt.Sprintf("Bound %s to ClusterRole %s until %s, approved by %s", request.Spec.User, roleName,
+			request.Status.ExpiresAt.UTC().Format(time.RFC3339), request.Status.ApprovedBy))
+
+	// Come back when the access expires
+	return ctrl.Result{RequeueAfter: request.Status.ExpiresAt.Sub(now.Time)}, nil
+}
+
+// validateAccessRequest returns why a request cannot be granted under config, or "" when it is valid
+func validateAccessRequest(request *smv1alpha1.SecretsAccessRequest, config *smv1alpha1.SecretsManagementConfig) string {
+	switch request.Spec.AccessLevel {
+	case smv1alpha1.AccessLevelView, smv1alpha1.AccessLevelDelete, smv1alpha1.AccessLevelAdmin:
+	default:
+		return fmt.Sprintf("Unknown access level %q", request.Spec.AccessLevel)
+	}
+	if d := request.Spec.Duration.Duration; d <= 0 || d > MaxAccessRequestDuration {
+		return fmt.Sprintf("Duration %s must be positive and at most %s", d, MaxAccessRequestDuration)
+	}
+	if request.Spec.Namespace == "" && !config.Spec.RBAC.AllowClusterWideAccessRequests {
+		return "Cluster-wide access is not allowed: set spec.namespace, or spec.rbac.allowClusterWideAccessRequests on the SecretsManagementConfig"
+	}
+	return ""
+}
+
+// primaryConfig returns the primary SecretsManagementConfig, whose role prefix names the granted
+// ClusterRoles, or an empty one with its name when it does not exist
+func (r *SecretsAccessRequestReconciler) primaryConfig(ctx context.Context) (*smv1alpha1.SecretsManagementConfig, error) {
+	config := &smv1alpha1.SecretsManagementConfig{}
+	err := r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config)
+	if errors.IsNotFound(err) {
+		config.Name = SingletonConfigName
+		return config, nil
+	}
+	return config, err
+}
+
+// approvalEnforced reports whether the admission policy guarding approvals and its binding exist
+func (r *SecretsAccessRequestReconciler) approvalEnforced(ctx context.Context) (bool, error) {
+	for _, obj := range []client.Object{
+		&admissionregistrationv1beta1.ValidatingAdmissionPolicy{},
+		&admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{},
+	} {
+		err := r.Get(ctx, types.NamespacedName{Name: AccessRequestPolicyName}, obj)
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ensureBinding creates the binding granting the request's access, or brings one the request
+// already controls back to roleName and the requesting user. It returns why access cannot be
+// granted when a binding of the same name belongs to someone else.
+func (r *SecretsAccessRequestReconciler) ensureBinding(ctx context.Context, request *smv1alpha1.SecretsAccessRequest, roleName string) (string, error) {
+	name := accessBindingName(request)
+	meta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: request.Spec.Namespace,
+		Labels: map[string]string{
+			"app.kubernetes.io/part-of":    "ocp-secrets-management",
+			"app.kubernetes.io/managed-by": "secrets-management-operator",
+			AccessRequestLabel:             request.Name,
+		},
+		// Lets the binding expiry controller remove the binding even if this request is never reconciled again
+		Annotations: map[string]string{
+			BindingExpiresAtAnnotation: request.Status.ExpiresAt.UTC().Format(time.RFC3339),
+		},
+	}
+	roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: roleName}
+	subjects := []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: request.Spec.User}}
+
+	var binding client.Object
+	if request.Spec.Namespace == "" {
+		binding = &rbacv1.ClusterRoleBinding{ObjectMeta: meta, RoleRef: roleRef, Subjects: subjects}
+	} else {
+		binding = &rbacv1.RoleBinding{ObjectMeta: meta, RoleRef: roleRef, Subjects: subjects}
+	}
+	if err := controllerutil.SetControllerReference(request, binding, r.Scheme); err != nil {
+		return "", err
+	}
+
+	existing := binding.DeepCopyObject().(client.Object)
+	err := r.apiReader().Get(ctx, client.ObjectKeyFromObject(binding), existing)
+	if errors.IsNotFound(err) {
+		request.Status.BindingName = name
+		return "", r.Create(ctx, binding)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !metav1.IsControlledBy(existing, request) {
+		return fmt.Sprintf("%s %s already exists and was not created for this request; access is not granted",
+			bindingKind(request), name), nil
+	}
+	request.Status.BindingName = name
+
+	var existingRoleRef *rbacv1.RoleRef
+	var existingSubjects *[]rbacv1.Subject
+	switch b := existing.(type) {
+	case *rbacv1.ClusterRoleBinding:
+		existingRoleRef, existingSubjects = &b.RoleRef, &b.Subjects
+	case *rbacv1.RoleBinding:
+		existingRoleRef, existingSubjects = &b.RoleRef, &b.Subjects
+	}
+	if *existingRoleRef != roleRef {
+		// The role of a binding cannot be changed, so the binding is replaced
+		uid := existing.GetUID()
+		if err := r.Delete(ctx, existing, client.Preconditions{UID: &uid}); err != nil && !errors.IsNotFound(err) {
+			return "", err
+		}
+		return "", r.Create(ctx, binding)
+	}
+	if !equality.Semantic.DeepEqual(*existingSubjects, subjects) {
+		*existingSubjects = subjects
+		return "", r.Update(ctx, existing)
+	}
+	return "", nil
+}
+
+// deleteBinding removes the binding granting the request's access, if any. A binding of the same
+// name the request does not control is left alone.
+func (r *SecretsAccessRequestReconciler) deleteBinding(ctx context.Context, request *smv1alpha1.SecretsAccessRequest) error {
+	meta := metav1.ObjectMeta{Name: accessBindingName(request), Namespace: request.Spec.Namespace}
+	var binding client.Object = &rbacv1.RoleBinding{ObjectMeta: meta}
+	if request.Spec.Namespace == "" {
+		binding = &rbacv1.ClusterRoleBinding{ObjectMeta: meta}
+	}
+	if err := r.apiReader().Get(ctx, client.ObjectKeyFromObject(binding), binding); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(binding, request) {
+		return nil
+	}
+	uid := binding.GetUID()
+	if err := r.Delete(ctx, binding, client.Preconditions{UID: &uid}); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// bindingKind returns the kind of the binding created for a request
+func bindingKind(request *smv1alpha1.SecretsAccessRequest) string {
+	if request.Spec.Namespace == "" {
+		return "ClusterRoleBinding"
+	}
+	return "RoleBinding"
+}
+
+// accessBindingName returns the name of the binding created for a request
+func accessBindingName(request *smv1alpha1.SecretsAccessRequest) string {
+	return fmt.Sprintf("secrets-access-%s", request.Name)
+}
+
+// setAccessRequestPhase updates the phase, recording the transition, or a new message in the same
+// phase, in the bounded audit trail
+func setAccessRequestPhase(request *smv1alpha1.SecretsAccessRequest, phase smv1alpha1.AccessRequestPhase, message string) {
+	if n := len(request.Status.AuditTrail); request.Status.Phase == phase && n > 0 && request.Status.AuditTrail[n-1].Message == message {
+		return
+	}
+	request.Status.Phase = phase
+	request.Status.AuditTrail = append(request.Status.AuditTrail, smv1alpha1.AccessRequestAuditEntry{
+		Time:    metav1.Now(),
+		Phase:   phase,
+		Message: message,
+	})
+	if n := len(request.Status.AuditTrail); n > MaxAccessRequestAuditEntries {
+		request.Status.AuditTrail = request.Status.AuditTrail[n-MaxAccessRequestAuditEntries:]
+	}
+}
+
+// patchStatus writes the status changes between original and request as a merge patch, retrying on conflict
+func (r *SecretsAccessRequestReconciler) patchStatus(ctx context.Context, original, request *smv1alpha1.SecretsAccessRequest) error {
+	if equality.Semantic.DeepEqual(original.Status, request.Status) {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Status().Patch(ctx, request, client.MergeFrom(original))
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+// apiReader returns the uncached reader, falling back to the cached client
+func (r *SecretsAccessRequestReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *SecretsAccessRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Bindings may live in any namespace and are not watched; expiry is driven by RequeueAfter
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&smv1alpha1.SecretsAccessRequest{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
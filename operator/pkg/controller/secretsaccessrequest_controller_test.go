@@ -0,0 +1,314 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// newTestAccessRequestReconciler returns a reconciler on a cluster holding objs and the admission
+// policy guarding approvals
+func newTestAccessRequestReconciler(objs ...client.Object) *SecretsAccessRequestReconciler {
+	scheme := newTestScheme()
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithObjects(buildAccessRequestPolicy(), buildPolicyBinding(AccessRequestPolicyName, nil)).
+		WithStatusSubresource(&smv1alpha1.SecretsAccessRequest{}).
+		Build()
+
+	return &SecretsAccessRequestReconciler{
+		Client: fakeClient,
+		Log:    logr.Discard(),
+		Scheme: scheme,
+	}
+}
+
+// newTestAccessRequest returns alice's request, approved by bob when approved is set
+func newTestAccessRequest(name, namespace string, approved bool) *smv1alpha1.SecretsAccessRequest {
+	request := &smv1alpha1.SecretsAccessRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name + "-uid")},
+		Spec: smv1alpha1.SecretsAccessRequestSpec{
+			User:          "alice",
+			AccessLevel:   smv1alpha1.AccessLevelDelete,
+			Namespace:     namespace,
+			Duration:      metav1.Duration{Duration: time.Hour},
+			Justification: "rotate leaked credentials",
+			Approved:      approved,
+		},
+	}
+	if approved {
+		request.Spec.ApprovedBy = "bob"
+	}
+	return request
+}
+
+func reconcileAccessRequest(t *testing.T, r *SecretsAccessRequestReconciler, name string) (ctrl.Result, *smv1alpha1.SecretsAccessRequest) {
+	t.Helper()
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+	require.NoError(t, err)
+
+	request := &smv1alpha1.SecretsAccessRequest{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: name}, request))
+	return result, request
+}
+
+func TestAccessRequest_PendingUntilApproved(t *testing.T) {
+	r := newTestAccessRequestReconciler(newTestAccessRequest("req", "team-a", false))
+
+	_, request := reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestPending, request.Status.Phase)
+	require.Len(t, request.Status.AuditTrail, 1)
+	assert.Contains(t, request.Status.AuditTrail[0].Message, "rotate leaked credentials")
+
+	err := r.Get(context.Background(), types.NamespacedName{Namespace: "team-a", Name: "secrets-access-req"}, &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestAccessRequest_ApprovedCreatesRoleBinding(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.RBAC.RolePrefix = "custom"
+	r := newTestAccessRequestReconciler(config, newTestAccessRequest("req", "team-a", true))
+
+	result, request := reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestActive, request.Status.Phase)
+	require.NotNil(t, request.Status.ExpiresAt)
+	assert.Equal(t, time.Hour, request.Status.ExpiresAt.Sub(request.Status.ApprovedAt.Time))
+	assert.Greater(t, result.RequeueAfter, 59*time.Minute)
+	assert.Equal(t, "secrets-access-req", request.Status.BindingName)
+	assert.Equal(t, "bob", request.Status.ApprovedBy)
+	assert.Contains(t, request.Status.AuditTrail[len(request.Status.AuditTrail)-1].Message, "approved by bob")
+
+	binding := &rbacv1.RoleBinding{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Namespace: "team-a", Name: "secrets-access-req"}, binding))
+	assert.Equal(t, "custom-delete", binding.RoleRef.Name)
+	require.Len(t, binding.Subjects, 1)
+	assert.Equal(t, "alice", binding.Subjects[0].Name)
+	assert.Equal(t, "req", binding.Labels[AccessRequestLabel])
+	assert.Equal(t, request.Status.ExpiresAt.UTC().Format(time.RFC3339), binding.Annotations[BindingExpiresAtAnnotation])
+	require.Len(t, binding.OwnerReferences, 1)
+	assert.Equal(t, "SecretsAccessRequest", binding.OwnerReferences[0].Kind)
+}
+
+func TestAccessRequest_ClusterWideCreatesClusterRoleBinding(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.RBAC.AllowClusterWideAccessRequests = true
+	r := newTestAccessRequestReconciler(config, newTestAccessRequest("req", "", true))
+
+	_, request := reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestActive, request.Status.Phase)
+
+	binding := &rbacv1.ClusterRoleBinding{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Name: "secrets-access-req"}, binding))
+	assert.Equal(t, "secrets-management-delete", binding.RoleRef.Name)
+}
+
+func TestAccessRequest_ExpiryRevokesBinding(t *testing.T) {
+	request := newTestAccessRequest("req", "team-a", true)
+	approvedAt := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	expiresAt := metav1.NewTime(time.Now().Add(-time.Hour))
+	request.Status = smv1alpha1.SecretsAccessRequestStatus{
+		Phase:      smv1alpha1.AccessRequestActive,
+		ApprovedAt: &approvedAt,
+		ExpiresAt:  &expiresAt,
+	}
+	binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "secrets-access-req"}}
+	require.NoError(t, controllerutil.SetControllerReference(request, binding, newTestScheme()))
+	r := newTestAccessRequestReconciler(request, binding)
+
+	result, request := reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestExpired, request.Status.Phase)
+	assert.Zero(t, result.RequeueAfter)
+	require.NotEmpty(t, request.Status.AuditTrail)
+	assert.Equal(t, smv1alpha1.AccessRequestExpired, request.Status.AuditTrail[len(request.Status.AuditTrail)-1].Phase)
+
+	err := r.Get(context.Background(), client.ObjectKeyFromObject(binding), &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestAccessRequest_ForeignBindingRejected(t *testing.T) {
+	// Someone else already created a binding of the name the request would use
+	foreign := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "secrets-access-req"},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "mallory"}},
+	}
+	r := newTestAccessRequestReconciler(newTestAccessRequest("req", "team-a", true), foreign)
+
+	_, request := reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestRejected, request.Status.Phase)
+	assert.Contains(t, request.Status.AuditTrail[len(request.Status.AuditTrail)-1].Message, "RoleBinding secrets-access-req already exists")
+	assert.Empty(t, request.Status.BindingName)
+
+	// The rejected request leaves the binding it does not control alone
+	reconcileAccessRequest(t, r, "req")
+	binding := &rbacv1.RoleBinding{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(foreign), binding))
+	assert.Equal(t, "cluster-admin", binding.RoleRef.Name)
+	assert.Equal(t, "mallory", binding.Subjects[0].Name)
+}
+
+func TestAccessRequest_DriftedBindingRestored(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	r := newTestAccessRequestReconciler(config, newTestAccessRequest("req", "team-a", true))
+	_, request := reconcileAccessRequest(t, r, "req")
+	require.Equal(t, smv1alpha1.AccessRequestActive, request.Status.Phase)
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "team-a", Name: "secrets-access-req"}
+
+	// A subject added to the binding is removed
+	binding := &rbacv1.RoleBinding{}
+	require.NoError(t, r.Get(ctx, key, binding))
+	binding.Subjects = append(binding.Subjects, rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "mallory"})
+	require.NoError(t, r.Update(ctx, binding))
+	reconcileAccessRequest(t, r, "req")
+	require.NoError(t, r.Get(ctx, key, binding))
+	require.Len(t, binding.Subjects, 1)
+	assert.Equal(t, "alice", binding.Subjects[0].Name)
+
+	// A binding to another role is replaced, since its roleRef cannot be changed
+	config.Spec.RBAC.RolePrefix = "custom"
+	require.NoError(t, r.Update(ctx, config))
+	_, request = reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestActive, request.Status.Phase)
+	require.NoError(t, r.Get(ctx, key, binding))
+	assert.Equal(t, "custom-delete", binding.RoleRef.Name)
+	assert.True(t, metav1.IsControlledBy(binding, request))
+}
+
+func TestAccessRequest_WithdrawnApprovalRevokes(t *testing.T) {
+	r := newTestAccessRequestReconciler(newTestAccessRequest("req", "team-a", true))
+	_, request := reconcileAccessRequest(t, r, "req")
+	require.Equal(t, smv1alpha1.AccessRequestActive, request.Status.Phase)
+
+	request.Spec.Approved = false
+	require.NoError(t, r.Update(context.Background(), request))
+
+	_, request = reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestRevoked, request.Status.Phase)
+
+	err := r.Get(context.Background(), types.NamespacedName{Namespace: "team-a", Name: "secrets-access-req"}, &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+
+	// Approving again does not grant access once revoked
+	request.Spec.Approved = true
+	require.NoError(t, r.Update(context.Background(), request))
+	_, request = reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestRevoked, request.Status.Phase)
+}
+
+func TestAccessRequest_DurationOverLimitRejected(t *testing.T) {
+	request := newTestAccessRequest("req", "team-a", true)
+	request.Spec.Duration = metav1.Duration{Duration: 48 * time.Hour}
+	r := newTestAccessRequestReconciler(request)
+
+	_, request = reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestRejected, request.Status.Phase)
+
+	err := r.Get(context.Background(), types.NamespacedName{Namespace: "team-a", Name: "secrets-access-req"}, &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestAccessRequest_ClusterWideRejectedUnlessAllowed(t *testing.T) {
+	r := newTestAccessRequestReconciler(newTestConfig(SingletonConfigName), newTestAccessRequest("req", "", true))
+
+	_, request := reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestRejected, request.Status.Phase)
+	assert.Contains(t, request.Status.AuditTrail[len(request.Status.AuditTrail)-1].Message, "allowClusterWideAccessRequests")
+
+	err := r.Get(context.Background(), types.NamespacedName{Name: "secrets-access-req"}, &rbacv1.ClusterRoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestAccessRequest_ApprovalNeedsApprover(t *testing.T) {
+	request := newTestAccessRequest("req", "team-a", true)
+	request.Spec.ApprovedBy = ""
+	r := newTestAccessRequestReconciler(request)
+
+	_, request = reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestPending, request.Status.Phase)
+	assert.Nil(t, request.Status.ApprovedAt)
+	assert.Contains(t, request.Status.AuditTrail[len(request.Status.AuditTrail)-1].Message, "spec.approvedBy")
+
+	err := r.Get(context.Background(), types.NamespacedName{Namespace: "team-a", Name: "secrets-access-req"}, &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestAccessRequest_SelfApprovalRejected(t *testing.T) {
+	request := newTestAccessRequest("req", "team-a", true)
+	request.Spec.ApprovedBy = request.Spec.User
+	r := newTestAccessRequestReconciler(request)
+
+	_, request = reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestRejected, request.Status.Phase)
+
+	err := r.Get(context.Background(), types.NamespacedName{Namespace: "team-a", Name: "secrets-access-req"}, &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestAccessRequest_NotGrantedWithoutApprovalPolicy(t *testing.T) {
+	ctx := context.Background()
+	r := newTestAccessRequestReconciler(newTestAccessRequest("req", "team-a", true))
+	require.NoError(t, r.Delete(ctx, buildPolicyBinding(AccessRequestPolicyName, nil)))
+
+	_, request := reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestPending, request.Status.Phase)
+	assert.Contains(t, request.Status.AuditTrail[len(request.Status.AuditTrail)-1].Message, AccessRequestPolicyName)
+	err := r.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "secrets-access-req"}, &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err))
+
+	// Granted once the operator puts the policy back
+	require.NoError(t, r.Create(ctx, buildPolicyBinding(AccessRequestPolicyName, nil)))
+	_, request = reconcileAccessRequest(t, r, "req")
+	assert.Equal(t, smv1alpha1.AccessRequestActive, request.Status.Phase)
+}
+
+func TestReconcileAccessRequestPolicy(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcileAccessRequestPolicy(ctx, config))
+
+	policy := &admissionregistrationv1beta1.ValidatingAdmissionPolicy{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: AccessRequestPolicyName}, policy))
+	require.Len(t, policy.Spec.MatchConstraints.ResourceRules, 1)
+	rule := policy.Spec.MatchConstraints.ResourceRules[0]
+	assert.Equal(t, []string{"secretsaccessrequests"}, rule.Resources, "status writes are not matched")
+	assert.Equal(t, []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}, rule.Operations)
+	var expressions []string
+	for _, v := range policy.Spec.Validations {
+		expressions = append(expressions, v.Expression)
+	}
+	assert.Equal(t, []string{
+		"request.operation != 'CREATE' || object.spec.user == request.userInfo.username || variables.approver",
+		"!variables.approvalChanged || variables.approver",
+		"!variables.approvalChanged || !variables.approved || object.spec.user != request.userInfo.username",
+		"!variables.approvalChanged || !variables.approved || variables.approvedBy == request.userInfo.username",
+	}, expressions)
+	assert.Contains(t, policy.Spec.Variables[3].Expression, "check('approve')")
+
+	binding := &admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: AccessRequestPolicyName}, binding))
+	assert.Empty(t, binding.Spec.MatchResources.ObjectSelector.MatchLabels)
+
+	cond := findCondition(config, smv1alpha1.ConditionAccessRequestApprovalEnforced)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+}
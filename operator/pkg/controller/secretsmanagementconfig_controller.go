@@ -2,27 +2,42 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/audit"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/cloudcredentials"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/resourcemetrics"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/telemetry"
 )
 
 const (
@@ -38,8 +53,41 @@ const (
 	// Default image for the plugin
 	DefaultPluginImage = "openshift.io/ocp-secrets-management:latest"
 
-	// Plugin port
+	// RelatedImagePluginEnv is set by OLM on the operator Deployment to the (possibly mirrored) plugin image
+	RelatedImagePluginEnv = "RELATED_IMAGE_PLUGIN"
+
+	// Default plugin port
 	PluginPort = 9443
+
+	// TrustedCABundleInjectLabel asks the cluster network operator to inject the merged trusted CA bundle
+	TrustedCABundleInjectLabel = "config.openshift.io/inject-trusted-cabundle"
+
+	// TrustedCABundleKey is the ConfigMap key the injected bundle is written to
+	TrustedCABundleKey = "ca-bundle.crt"
+
+	// TrustedCABundleMountPath is where the injected bundle is mounted in the plugin container
+	TrustedCABundleMountPath = "/etc/pki/ca-trust/extracted/pem"
+
+	// TrustedCABundleHashAnnotation records the injected bundle hash on the pod template so rotation triggers a rollout
+	TrustedCABundleHashAnnotation = "secrets-management.openshift.io/trusted-ca-bundle-hash"
+
+	// DefaultReconcileInterval is the periodic requeue interval when neither the flag nor the spec set one
+	DefaultReconcileInterval = 5 * time.Minute
+
+	// ReconcileJitterFactor is the maximum fraction added to the requeue interval so fleets of clusters don't poll in lockstep
+	ReconcileJitterFactor = 0.1
+
+	// SingletonConfigName is the name of the primary SecretsManagementConfig
+	SingletonConfigName = "cluster"
+
+	// CanaryConfigName is the name of the optional config that deploys a parallel canary plugin instance
+	CanaryConfigName = "canary"
+
+	// WatchNamespaceEnv restricts the operator to the plugin namespace when set
+	WatchNamespaceEnv = "WATCH_NAMESPACE"
+
+	// MaxPhaseHistory is the number of phase transitions kept in status.history
+	MaxPhaseHistory = 10
 )
 
 // ConsolePlugin GroupVersionKind for OpenShift
@@ -61,6 +109,57 @@ type SecretsManagementConfigReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+
+	// ReconcileInterval is the default periodic requeue interval; DefaultReconcileInterval when zero
+	ReconcileInterval time.Duration
+
+	// Restricted is set when the operator runs with WATCH_NAMESPACE. It then never reads
+	// cluster-wide core kinds: the plugin namespace must already exist and Nodes are not listed.
+	Restricted bool
+
+	// OperatorNamespace is the namespace the operator runs in; PluginNamespace when empty
+	OperatorNamespace string
+
+	// OperatorConditionName names the OperatorCondition OLM created for the operator. Upgradeable
+	// is not reported when empty, as when the operator was not installed by OLM.
+	OperatorConditionName string
+
+	// APIReader reads directly from the API server, for objects outside the cached plugin namespace.
+	// The cached client is used when nil.
+	APIReader client.Reader
+
+	// AuditForwarder delivers audit records to spec.audit.sinks; sinks are not configured when nil
+	AuditForwarder *audit.Forwarder
+
+	// Telemetry exports the anonymized usage report of spec.telemetry; usage is not reported when nil
+	Telemetry *telemetry.Reporter
+
+	// ResourceMetrics exports the per-resource metrics of spec.resourceMetrics; they are not
+	// exported when nil
+	ResourceMetrics *resourcemetrics.Exporter
+
+	// CredentialChecker checks the credentials of cloud stores in spec.stores and the workload
+	// identity federation of spec.cloudAuth against their provider; they are not checked when nil
+	CredentialChecker *cloudcredentials.Checker
+
+	// Recorder emits Events on managed objects; events are not emitted when nil
+	Recorder record.EventRecorder
+
+	// UpdateEvents emits an Event on each managed object the operator updates, listing the fields
+	// that changed. The changes are logged either way.
+	UpdateEvents bool
+
+	// ConsoleDisabled is set when the cluster's Console capability is disabled. The plugin
+	// Deployment, Service, Route and ConsolePlugin are then not created, as nothing would load them.
+	ConsoleDisabled bool
+
+	// DeletionRetryBudget is how long after a config is deleted its cleanup is retried before the
+	// finalizer is removed regardless; DefaultDeletionRetryBudget when zero
+	DeletionRetryBudget time.Duration
+
+	// DryRun sends every write as a server-side dry run, so Reconcile computes the objects it
+	// would write without persisting them. Audit sinks are not configured and no Events are emitted.
+	DryRun bool
 }
 
 // +kubebuilder:rbac:groups=secrets-management.openshift.io,resources=secretsmanagementconfigs,verbs=get;list;watch;create;update;patch;delete
@@ -68,19 +167,43 @@ type SecretsManagementConfigReconciler struct {
 // +kubebuilder:rbac:groups=secrets-management.openshift.io,resources=secretsmanagementconfigs/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services;serviceaccounts;configmaps;namespaces,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=resourcequotas;limitranges,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=console.openshift.io,resources=consoleplugins,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingadmissionpolicies;validatingadmissionpolicybindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=console.openshift.io,resources=consoleplugins;consolenotifications,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=create;update;delete
+// +kubebuilder:rbac:groups=config.openshift.io,resources=imagedigestmirrorsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=imagecontentsourcepolicies,verbs=get;list;watch
 // Permissions for cert-manager / external-secrets / secrets-store-csi so the operator can create ClusterRoles that grant these to the plugin (RBAC escalation rule; use * so we can grant * to admin role)
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates;issuers;clusterissuers,verbs=*
 // +kubebuilder:rbac:groups=external-secrets.io,resources=externalsecrets;secretstores;clustersecretstores;clusterexternalsecrets;pushsecrets,verbs=*
 // +kubebuilder:rbac:groups=secrets-store.csi.x-k8s.io,resources=secretproviderclasses;secretproviderclasspodstatuses,verbs=*
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificaterequests,verbs=get;list
+// +kubebuilder:rbac:groups=acme.cert-manager.io,resources=orders;challenges,verbs=get;list
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile handles the reconciliation loop for SecretsManagementConfig
-func (r *SecretsManagementConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *SecretsManagementConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reterr error) {
+	if r.DryRun {
+		dry := *r
+		dry.DryRun = false
+		dry.Client = client.NewDryRunClient(r.Client)
+		dry.AuditForwarder = nil
+		dry.UpdateEvents = false
+		return dry.Reconcile(ctx, req)
+	}
+
 	log := r.Log.WithValues("secretsmanagementconfig", req.NamespacedName)
+	start := time.Now()
+	ctx, span := startSpan(ctx, "Reconcile", attribute.String("secretsmanagementconfig", req.Name))
+	defer func() { endSpan(span, reterr) }()
 
 	// Fetch the SecretsManagementConfig instance
 	config := &smv1alpha1.SecretsManagementConfig{}
@@ -94,6 +217,11 @@ func (r *SecretsManagementConfigReconciler) Reconcile(ctx context.Context, req c
 		return ctrl.Result{}, err
 	}
 
+	// Only the primary and canary configs manage resources; others would fight over the same names
+	if config.Name != SingletonConfigName && config.Name != CanaryConfigName {
+		return r.reconcileDuplicate(ctx, config)
+	}
+
 	// Add finalizer if not present
 	if !controllerutil.ContainsFinalizer(config, FinalizerName) {
 		controllerutil.AddFinalizer(config, FinalizerName)
@@ -107,92 +235,248 @@ func (r *SecretsManagementConfigReconciler) Reconcile(ctx context.Context, req c
 		return r.reconcileDelete(ctx, config)
 	}
 
+	// Status changes made below are written once, as a single patch, when the loop returns
+	original := config.DeepCopy()
+	defer func() {
+		if err := r.patchStatus(ctx, original, config); err != nil {
+			log.Error(err, "Failed to patch status")
+			if reterr == nil {
+				reterr = err
+			}
+		}
+	}()
+
+	// Stay out of Velero's way while it backs up or restores the plugin namespace
+	paused, err := r.reconcileVeleroPause(ctx, config)
+	if err != nil {
+		log.Error(err, "Failed to check for Velero operations")
+		return r.updateStatusError(config, start, err)
+	}
+	if paused {
+		return ctrl.Result{RequeueAfter: veleroPauseRequeue}, nil
+	}
+
 	// Update phase to Deploying
 	if config.Status.Phase == "" || config.Status.Phase == smv1alpha1.PhasePending {
-		config.Status.Phase = smv1alpha1.PhaseDeploying
-		if err := r.Status().Update(ctx, config); err != nil {
-			return ctrl.Result{}, err
-		}
+		setPhase(config, smv1alpha1.PhaseDeploying, "Deploying managed resources")
 	}
 
 	// Reconcile Namespace
 	if err := r.reconcileNamespace(ctx, config); err != nil {
 		log.Error(err, "Failed to reconcile namespace")
-		return r.updateStatusError(ctx, config, err)
+		return r.updateStatusError(config, start, err)
 	}
 
-	// Reconcile RBAC
-	if err := r.reconcileRBAC(ctx, config); err != nil {
-		log.Error(err, "Failed to reconcile RBAC")
-		return r.updateStatusError(ctx, config, err)
+	// Reconcile namespace ResourceQuota and LimitRange; shared, so owned by the primary config
+	if isPrimaryConfig(config) {
+		if err := r.reconcileNamespaceQuota(ctx, config); err != nil {
+			log.Error(err, "Failed to reconcile namespace quota")
+			return r.updateStatusError(config, start, err)
+		}
 	}
 
-	// Reconcile plugin deployment
-	if err := r.reconcilePluginDeployment(ctx, config); err != nil {
-		log.Error(err, "Failed to reconcile plugin deployment")
-		return r.updateStatusError(ctx, config, err)
+	// Independent sub-systems are reconciled concurrently, each reporting its own condition, so
+	// one failing does not keep the others from converging
+	verifyingRestore := false
+	groups := []reconcileGroup{
+		{condition: smv1alpha1.ConditionAccessReconciled, steps: []reconcileStep{
+			{name: "reconcile RBAC", run: r.reconcileRBAC},
+			// Limit the namespaces the plugin shows to spec.visibility
+			{name: "reconcile namespace visibility", run: r.reconcileVisibility},
+		}},
+		{condition: smv1alpha1.ConditionOperatorsDetected, steps: []reconcileStep{
+			// Detection errors are reported but do not fail the loop; the next one detects again
+			{name: "detect operators", run: r.detectOperators, optional: true},
+		}},
+	}
+	// The plugin and everything that exposes it only matter on clusters with a console
+	if !r.ConsoleDisabled {
+		pluginSteps := []reconcileStep{
+			{name: "reconcile plugin deployment", run: r.reconcilePluginDeployment},
+			// Hold off OLM upgrades while a plugin rollout is in progress
+			{name: "report Upgradeable on the OperatorCondition", run: func(ctx context.Context, _ *smv1alpha1.SecretsManagementConfig) error {
+				return r.reconcileOperatorCondition(ctx)
+			}},
+			{name: "reconcile plugin Route", run: r.reconcileRoute},
+			{name: "reconcile ConsolePlugin", run: r.reconcileConsolePlugin},
+		}
+		// The Command Line Tools page lists smcctl once for the cluster
+		if isPrimaryConfig(config) {
+			pluginSteps = append(pluginSteps, reconcileStep{name: "reconcile smcctl downloads", run: r.reconcileCLIDownloads})
+		}
+		groups = append(groups, reconcileGroup{condition: smv1alpha1.ConditionPluginReconciled, steps: pluginSteps})
+	}
+	// The cluster-wide admission policies cover every instance, so the primary config owns them
+	if isPrimaryConfig(config) {
+		groups = append(groups, reconcileGroup{condition: smv1alpha1.ConditionPoliciesReconciled, steps: []reconcileStep{
+			{name: "reconcile protection policy", run: r.reconcileProtection},
+			{name: "reconcile secret deletion protection policy", run: r.reconcileSecretProtection},
+			{name: "reconcile read-only policy", run: r.reconcileReadOnlyPolicy},
+			{name: "reconcile access request approval policy", run: r.reconcileAccessRequestPolicy},
+			{name: "reconcile policy bundle", run: r.reconcilePolicies},
+			{name: "reconcile alert routing", run: r.reconcileAlertRouting},
+			{name: "reconcile backup schedule", run: r.reconcileBackup},
+			{name: "verify restored stores and issuers", run: func(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+				var err error
+				verifyingRestore, err = r.reconcileRestoreVerification(ctx, config, start)
+				return err
+			}},
+		}})
+	}
+	stepsErr := r.runGroupsConcurrently(ctx, config, groups)
+
+	// Report anonymized usage when opted in; the report covers the cluster, so the primary config owns it
+	if isPrimaryConfig(config) {
+		if err := r.reconcileTelemetry(ctx, config); err != nil {
+			log.Error(err, "Failed to report usage")
+			// Don't fail on telemetry errors, the next reconcile reports again
+		}
+		if err := r.reconcileResourceMetrics(ctx, config); err != nil {
+			log.Error(err, "Failed to export resource metrics")
+			// Don't fail on metrics errors, the next reconcile exports again
+		}
 	}
 
-	// Reconcile ConsolePlugin
-	if err := r.reconcileConsolePlugin(ctx, config); err != nil {
-		log.Error(err, "Failed to reconcile ConsolePlugin")
-		return r.updateStatusError(ctx, config, err)
+	// The integrations read the detected operators, so they run once detection is done. They
+	// are cluster-scoped, like the stores and issuers whose health the managed clusters report,
+	// so the primary config owns them.
+	integrations := reconcileGroup{condition: smv1alpha1.ConditionIntegrationsReconciled}
+	if isPrimaryConfig(config) {
+		integrations.steps = []reconcileStep{
+			// Warn console users while no supported operator is installed; the banner is cluster-wide
+			{name: "reconcile missing operators ConsoleNotification", run: r.reconcileMissingOperatorsNotification},
+			// Report SecretProviderClass usage by pods across the cluster
+			{name: "report SecretProviderClass usage", run: r.reconcileSecretProviderClassUsage},
+			// Forward audit records to external sinks; the audit trail belongs to the primary config
+			{name: "configure audit sinks", run: r.reconcileAuditSinks},
+			{name: "reconcile secret stores", run: r.reconcileSecretStores},
+			// Annotate the ESO and CSI provider service accounts for cloud workload identity
+			{name: "reconcile cloud auth", run: r.reconcileCloudAuth},
+			// Report PushSecrets failing to push to their stores
+			{name: "report PushSecret sync", run: r.reconcilePushSecrets},
+			// Summarize the namespaces ClusterExternalSecrets fan out to
+			{name: "summarize ClusterExternalSecret provisioning", run: r.reconcileClusterExternalSecrets},
+			// Probe the Vault servers behind ClusterSecretStores and SecretProviderClasses
+			{name: "probe Vault servers", run: r.reconcileVaultHealth},
+			{name: "reconcile issuers", run: r.reconcileIssuers},
+			// Summarize failing certificate issuance per issuer across the cluster
+			{name: "summarize issuance failures", run: r.reconcileIssuanceFailures},
+			// Report whether Secrets are encrypted at rest
+			{name: "report security posture", run: r.reconcileSecurityPosture},
+			// Distribute the config to ACM managed clusters and aggregate what they report
+			{name: "reconcile managed clusters", run: func(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+				setHealthStatus(config)
+				return r.reconcileHub(ctx, config)
+			}},
+		}
 	}
-
-	// Detect installed operators
-	if err := r.detectOperators(ctx, config); err != nil {
-		log.Error(err, "Failed to detect operators")
-		// Don't fail on detection errors, just log
+	// Record the inventory of managed resources
+	integrations.steps = append(integrations.steps, reconcileStep{name: "record managed resources", run: r.reconcileInventory})
+	stepsErr = utilerrors.NewAggregate([]error{stepsErr, r.runGroup(ctx, config, integrations)})
+	if stepsErr != nil {
+		return r.updateStatusError(config, start, stepsErr)
+	}
+
+	if r.ConsoleDisabled {
+		r.setConsoleDisabledCondition(config)
+		// Only the RBAC settings apply without the plugin
+		config.Status.ResolvedConfiguration = smv1alpha1.ResolvedConfigurationStatus{RolePrefix: rolePrefix(config)}
+	}
+
+	// Ready only once the plugin Deployment has rolled out the current generation and has replicas
+	// available, and every detected operator is supported; Deployment status changes requeue the
+	// config, so the phase follows them
+	progressing := statusCondition(config, smv1alpha1.ConditionProgressing)
+	available := statusCondition(config, smv1alpha1.ConditionDeploymentAvailable)
+	degraded := statusCondition(config, smv1alpha1.ConditionDegraded)
+	switch {
+	case r.ConsoleDisabled:
+		setPhase(config, smv1alpha1.PhaseDegraded, degraded.Message)
+		config.Status.Reason = degraded.Reason
+	case progressing != nil && progressing.Reason == smv1alpha1.ReasonProgressDeadlineExceeded:
+		setPhase(config, smv1alpha1.PhaseDegraded, progressing.Message)
+		config.Status.Reason = progressing.Reason
+	case progressing != nil && progressing.Status == "True":
+		setPhase(config, smv1alpha1.PhaseDeploying, progressing.Message)
+		config.Status.Reason = progressing.Reason
+	case available != nil && available.Status == "False":
+		setPhase(config, smv1alpha1.PhaseDegraded, "No plugin replicas are available")
+		config.Status.Reason = available.Reason
+	case degraded != nil && degraded.Status == "True":
+		setPhase(config, smv1alpha1.PhaseDegraded, degraded.Message)
+		config.Status.Reason = degraded.Reason
+	default:
+		setPhase(config, smv1alpha1.PhaseReady, "All managed resources reconciled")
+		config.Status.Reason = ""
 	}
-
-	// Update status to Ready
-	config.Status.Phase = smv1alpha1.PhaseReady
 	config.Status.ObservedGeneration = config.Generation
-	if err := r.Status().Update(ctx, config); err != nil {
-		return ctrl.Result{}, err
-	}
+	recordReconcile(config, start, nil)
 
-	// Requeue after 5 minutes to refresh operator detection
-	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+	// Requeue periodically to refresh operator detection, sooner while checking a restore
+	requeue := r.requeueInterval(config)
+	if verifyingRestore && requeue > restoreVerificationRequeue {
+		requeue = restoreVerificationRequeue
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
 }
 
-// reconcileDelete handles the deletion of the SecretsManagementConfig
-func (r *SecretsManagementConfigReconciler) reconcileDelete(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) (ctrl.Result, error) {
-	log := r.Log.WithValues("secretsmanagementconfig", config.Name)
-	log.Info("Reconciling deletion")
-
-	// Clean up resources; log errors but do not block finalizer removal so the CR can be deleted
-	if err := r.cleanupConsolePlugin(ctx, config); err != nil {
-		log.Error(err, "Failed to cleanup ConsolePlugin (continuing to remove finalizer)")
+// instanceName returns the base name of the plugin resources managed for config. The primary
+// config uses PluginName; a canary config gets its own suffixed Deployment, Service and ConsolePlugin.
+func instanceName(config *smv1alpha1.SecretsManagementConfig) string {
+	if isPrimaryConfig(config) {
+		return PluginName
 	}
-	if err := r.cleanupPluginDeployment(ctx, config); err != nil {
-		log.Error(err, "Failed to cleanup plugin deployment (continuing to remove finalizer)")
+	return fmt.Sprintf("%s-%s", PluginName, config.Name)
+}
+
+// rolePrefix returns the ClusterRole name prefix for config, suffixed for canary configs
+func rolePrefix(config *smv1alpha1.SecretsManagementConfig) string {
+	prefix := config.Spec.RBAC.RolePrefix
+	if prefix == "" {
+		prefix = "secrets-management"
 	}
-	if err := r.cleanupRBAC(ctx, config); err != nil {
-		log.Error(err, "Failed to cleanup RBAC (continuing to remove finalizer)")
+	if !isPrimaryConfig(config) {
+		prefix = fmt.Sprintf("%s-%s", prefix, config.Name)
 	}
+	return prefix
+}
 
-	// Re-fetch to get latest resourceVersion and avoid update conflicts
-	if err := r.Get(ctx, types.NamespacedName{Name: config.Name}, config); err != nil {
-		if errors.IsNotFound(err) {
-			return ctrl.Result{}, nil
-		}
-		return ctrl.Result{}, err
-	}
+// isPrimaryConfig reports whether config is the singleton that also owns shared namespace resources
+func isPrimaryConfig(config *smv1alpha1.SecretsManagementConfig) bool {
+	return config.Name == SingletonConfigName
+}
 
-	// Remove finalizer so the API server can complete deletion
+// reconcileDuplicate marks a non-singleton config as ignored without touching any managed resources
+func (r *SecretsManagementConfigReconciler) reconcileDuplicate(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) (ctrl.Result, error) {
+	// Never run cleanup for a duplicate; it would delete the singleton's resources
 	if controllerutil.ContainsFinalizer(config, FinalizerName) {
 		controllerutil.RemoveFinalizer(config, FinalizerName)
 		if err := r.Update(ctx, config); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
+	if !config.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
 
-	return ctrl.Result{}, nil
+	original := config.DeepCopy()
+	config.Status.Reason = smv1alpha1.ReasonNotSingleton
+	setPhase(config, smv1alpha1.PhaseIgnored, fmt.Sprintf("Only the SecretsManagementConfigs named %q and %q are reconciled", SingletonConfigName, CanaryConfigName))
+	r.setCondition(config, smv1alpha1.ConditionDuplicateConfig, "True", smv1alpha1.ReasonNotSingleton,
+		fmt.Sprintf("Only the SecretsManagementConfigs named %q and %q are reconciled; delete this resource", SingletonConfigName, CanaryConfigName))
+	config.Status.ObservedGeneration = config.Generation
+
+	return ctrl.Result{}, r.patchStatus(ctx, original, config)
 }
 
 // reconcileNamespace ensures the plugin namespace exists
 func (r *SecretsManagementConfigReconciler) reconcileNamespace(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	if r.Restricted {
+		// The namespace is provisioned by the cluster admin and cannot be read without cluster-wide access
+		r.setCondition(config, smv1alpha1.ConditionNamespaceReady, "True", smv1alpha1.ReasonNamespaceUnmanaged, "Namespace is managed outside the operator in restricted mode")
+		return nil
+	}
+
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: PluginNamespace,
@@ -203,17 +487,31 @@ func (r *SecretsManagementConfigReconciler) reconcileNamespace(ctx context.Conte
 			},
 		},
 	}
+	applyCommonMetadata(config, ns)
 
 	existing := &corev1.Namespace{}
 	err := r.Get(ctx, types.NamespacedName{Name: PluginNamespace}, existing)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return r.Create(ctx, ns)
+			if err := r.Create(ctx, ns); err != nil {
+				return err
+			}
+			r.setCondition(config, smv1alpha1.ConditionNamespaceReady, "True", smv1alpha1.ReasonNamespaceActive, "Namespace created")
+			return nil
 		}
 		return err
 	}
 
-	return nil
+	if existing.Status.Phase == corev1.NamespaceTerminating {
+		r.setCondition(config, smv1alpha1.ConditionNamespaceReady, "False", smv1alpha1.ReasonNamespaceTerminating, "Namespace is terminating")
+		return nil
+	}
+	r.setCondition(config, smv1alpha1.ConditionNamespaceReady, "True", smv1alpha1.ReasonNamespaceActive, "Namespace is active")
+
+	// The namespace may have been created by the installer, so only the common metadata is added
+	before := existing.DeepCopy()
+	mergeMetadata(existing, commonMetadata(config))
+	return updateIfChanged(ctx, r, before, existing)
 }
 
 // reconcileRBAC ensures the RBAC resources exist
@@ -222,29 +520,42 @@ func (r *SecretsManagementConfigReconciler) reconcileRBAC(ctx context.Context, c
 		return nil
 	}
 
-	prefix := config.Spec.RBAC.RolePrefix
-	if prefix == "" {
-		prefix = "secrets-management"
+	prefix := rolePrefix(config)
+	patches, err := r.loadRulesOverride(ctx, config)
+	if err != nil {
+		return err
 	}
 
-	// Create view role
+	// View role
 	viewRole := r.buildViewClusterRole(prefix)
-	if err := r.createOrUpdateClusterRole(ctx, viewRole); err != nil {
-		return err
-	}
+	viewRole.Rules = applyRulesPatch(withCoreSecrets(config, viewRole.Rules, coreSecretsViewVerbs...), patches["view"])
 
-	// Create delete role
+	// Delete role
 	deleteRole := r.buildDeleteClusterRole(prefix)
-	if err := r.createOrUpdateClusterRole(ctx, deleteRole); err != nil {
-		return err
-	}
+	deleteRole.Rules = applyRulesPatch(withCoreSecrets(config, deleteRole.Rules, "delete"), patches["delete"])
 
-	// Create admin role
+	// Admin role
 	adminRole := r.buildAdminClusterRole(prefix)
-	if err := r.createOrUpdateClusterRole(ctx, adminRole); err != nil {
-		return err
+	adminRole.Rules = applyRulesPatch(withCoreSecrets(config, adminRole.Rules, coreSecretsAdminVerbs...), patches["admin"])
+	adminOperations := []string{"view", "delete", "create", "edit"}
+	if config.Spec.Features.ReadOnly.Enabled {
+		adminRole.Rules = readOnlyRules(adminRole.Rules)
+		adminOperations = []string{"view"}
 	}
 
+	drifted := map[string][]string{}
+	for _, role := range []*rbacv1.ClusterRole{viewRole, deleteRole, adminRole} {
+		applyCommonMetadata(config, role)
+		drift, err := r.createOrUpdateClusterRole(ctx, role)
+		if err != nil {
+			return err
+		}
+		if len(drift) > 0 {
+			drifted[role.Name] = drift
+		}
+	}
+	r.reportRBACDrift(config, drifted)
+
 	// Update status with created roles, preserving existing Created timestamps
 	existingByRole := make(map[string]metav1.Time)
 	for _, s := range config.Status.RBAC.ClusterRoles {
@@ -263,9 +574,15 @@ func (r *SecretsManagementConfigReconciler) reconcileRBAC(ctx context.Context, c
 	config.Status.RBAC.ClusterRoles = []smv1alpha1.ClusterRoleStatus{
 		{Name: viewRole.Name, Operations: []string{"view"}, Created: createdAt(viewRole.Name)},
 		{Name: deleteRole.Name, Operations: []string{"delete"}, Created: createdAt(deleteRole.Name)},
-		{Name: adminRole.Name, Operations: []string{"view", "delete", "create", "edit"}, Created: createdAt(adminRole.Name)},
+		{Name: adminRole.Name, Operations: adminOperations, Created: createdAt(adminRole.Name)},
 	}
 
+	bindings, err := r.summarizeRoleBindings(ctx, []string{viewRole.Name, deleteRole.Name, adminRole.Name})
+	if err != nil {
+		return err
+	}
+	config.Status.RBAC.Bindings = bindings
+
 	r.setCondition(config, smv1alpha1.ConditionRBACConfigured, "True", "RolesCreated", "Created 3 ClusterRoles")
 
 	return nil
@@ -361,20 +678,51 @@ func (r *SecretsManagementConfigReconciler) buildAdminClusterRole(prefix string)
 	}
 }
 
-// createOrUpdateClusterRole creates or updates a ClusterRole
-func (r *SecretsManagementConfigReconciler) createOrUpdateClusterRole(ctx context.Context, role *rbacv1.ClusterRole) error {
+// coreSecretsViewVerbs and coreSecretsAdminVerbs are granted on core Secrets by the view and admin
+// roles with spec.rbac.includeCoreSecrets. The admin verbs are listed rather than "*", which the
+// operator could only grant by holding every verb on Secrets itself.
+var (
+	coreSecretsViewVerbs  = []string{"get", "list", "watch"}
+	coreSecretsAdminVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+)
+
+// withCoreSecrets returns rules with a rule granting verbs on core Secrets appended when
+// spec.rbac.includeCoreSecrets is set
+func withCoreSecrets(config *smv1alpha1.SecretsManagementConfig, rules []rbacv1.PolicyRule, verbs ...string) []rbacv1.PolicyRule {
+	if !config.Spec.RBAC.IncludeCoreSecrets {
+		return rules
+	}
+	return append(rules, rbacv1.PolicyRule{
+		APIGroups: []string{""},
+		Resources: []string{"secrets"},
+		Verbs:     verbs,
+	})
+}
+
+// createOrUpdateClusterRole creates or updates a ClusterRole, recording a hash of its rules. It
+// returns how the live rules differ from the desired ones when they were changed on the cluster
+// since the operator last wrote them; the update reverts that change.
+func (r *SecretsManagementConfigReconciler) createOrUpdateClusterRole(ctx context.Context, role *rbacv1.ClusterRole) ([]string, error) {
+	if err := setAppliedSpecHash(role); err != nil {
+		return nil, err
+	}
 	existing := &rbacv1.ClusterRole{}
 	err := r.Get(ctx, types.NamespacedName{Name: role.Name}, existing)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return r.Create(ctx, role)
+			return nil, r.Create(ctx, role)
 		}
-		return err
+		return nil, err
 	}
 
+	drift, err := clusterRoleDrift(existing, role)
+	if err != nil {
+		return nil, err
+	}
+	before := existing.DeepCopy()
 	existing.Rules = role.Rules
-	existing.Labels = role.Labels
-	return r.Update(ctx, existing)
+	mergeMetadata(existing, role)
+	return drift, updateIfChanged(ctx, r, before, existing)
 }
 
 // reconcilePluginDeployment ensures the plugin deployment exists
@@ -389,6 +737,11 @@ func (r *SecretsManagementConfigReconciler) reconcilePluginDeployment(ctx contex
 		return err
 	}
 
+	// Report serving certificate presence
+	if err := r.reconcileCertSecretCondition(ctx, config); err != nil {
+		return err
+	}
+
 	// Create Deployment
 	if err := r.reconcileDeployment(ctx, config); err != nil {
 		return err
@@ -399,17 +752,24 @@ func (r *SecretsManagementConfigReconciler) reconcilePluginDeployment(ctx contex
 
 // reconcileServiceAccount ensures the plugin ServiceAccount exists
 func (r *SecretsManagementConfigReconciler) reconcileServiceAccount(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	saConfig := config.Spec.Plugin.ServiceAccount
 	sa := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-plugin", PluginName),
+			Name:      fmt.Sprintf("%s-plugin", instanceName(config)),
 			Namespace: PluginNamespace,
 			Labels: map[string]string{
-				"app.kubernetes.io/name":       PluginName,
+				"app.kubernetes.io/name":       instanceName(config),
 				"app.kubernetes.io/part-of":    "ocp-secrets-management",
 				"app.kubernetes.io/managed-by": "secrets-management-operator",
 			},
+			Annotations: saConfig.Annotations,
 		},
+		AutomountServiceAccountToken: boolPtr(saConfig.AutomountServiceAccountToken),
 	}
+	if err := controllerutil.SetControllerReference(config, sa, r.Scheme); err != nil {
+		return err
+	}
+	applyCommonMetadata(config, sa)
 
 	existing := &corev1.ServiceAccount{}
 	err := r.Get(ctx, types.NamespacedName{Name: sa.Name, Namespace: sa.Namespace}, existing)
@@ -420,70 +780,124 @@ func (r *SecretsManagementConfigReconciler) reconcileServiceAccount(ctx context.
 		return err
 	}
 
-	return nil
+	// Merge configured annotations so annotations added by other controllers
+	// (e.g. OpenShift image pull secrets) are preserved
+	before := existing.DeepCopy()
+	mergeMetadata(existing, sa)
+	existing.AutomountServiceAccountToken = sa.AutomountServiceAccountToken
+	// Adopt ServiceAccounts created before the operator set owner references
+	if err := controllerutil.SetControllerReference(config, existing, r.Scheme); err != nil {
+		return err
+	}
+	return updateIfChanged(ctx, r, before, existing)
 }
 
 // reconcileService ensures the plugin Service exists
 func (r *SecretsManagementConfigReconciler) reconcileService(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-plugin", PluginName),
+			Name:      fmt.Sprintf("%s-plugin", instanceName(config)),
 			Namespace: PluginNamespace,
 			Labels: map[string]string{
-				"app.kubernetes.io/name":       PluginName,
+				"app.kubernetes.io/name":       instanceName(config),
 				"app.kubernetes.io/part-of":    "ocp-secrets-management",
 				"app.kubernetes.io/managed-by": "secrets-management-operator",
 			},
 			Annotations: map[string]string{
-				"service.alpha.openshift.io/serving-cert-secret-name": fmt.Sprintf("%s-plugin-cert", PluginName),
+				"service.alpha.openshift.io/serving-cert-secret-name": fmt.Sprintf("%s-plugin-cert", instanceName(config)),
 			},
 		},
 		Spec: corev1.ServiceSpec{
 			Selector: map[string]string{
-				"app.kubernetes.io/name": PluginName,
+				"app.kubernetes.io/name": instanceName(config),
 			},
 			Ports: []corev1.ServicePort{
 				{
 					Name:       "https",
-					Port:       PluginPort,
-					TargetPort: intstr.FromInt(PluginPort),
+					Port:       pluginPort(config),
+					TargetPort: intstr.FromInt32(pluginPort(config)),
 					Protocol:   corev1.ProtocolTCP,
 				},
 			},
 		},
 	}
+	if metricsPort := config.Spec.Plugin.MetricsPort; metricsPort != 0 {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       metricsPort,
+			TargetPort: intstr.FromInt32(metricsPort),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+	setServiceConfig(config, svc)
+	setServiceAppProtocols(config, svc)
+
+	// The controller reference lets the Service watch enqueue this config
+	if err := controllerutil.SetControllerReference(config, svc, r.Scheme); err != nil {
+		return err
+	}
+	applyCommonMetadata(config, svc)
+	if err := setAppliedSpecHash(svc); err != nil {
+		return err
+	}
 
 	existing := &corev1.Service{}
 	err := r.Get(ctx, types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, existing)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			return r.Create(ctx, svc)
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if err := r.Create(ctx, svc); err != nil {
+			return err
+		}
+	} else {
+		// Update service spec and metadata (labels/annotations e.g. for serving-cert). The spec is
+		// only written when it differs from the one last applied, since the API server fills in
+		// ports and cluster IPs the desired spec leaves unset.
+		before := existing.DeepCopy()
+		if specChanged(existing, svc, existing.Spec, svc.Spec) {
+			existing.Spec.Ports = svc.Spec.Ports
+			existing.Spec.Selector = svc.Spec.Selector
+			if svc.Spec.IPFamilies != nil {
+				existing.Spec.IPFamilies = svc.Spec.IPFamilies
+			}
+			if svc.Spec.IPFamilyPolicy != nil {
+				existing.Spec.IPFamilyPolicy = svc.Spec.IPFamilyPolicy
+			}
+			existing.Spec.InternalTrafficPolicy = svc.Spec.InternalTrafficPolicy
+			if existing.Spec.SessionAffinity != svc.Spec.SessionAffinity {
+				// The API server defaults the ClientIP timeout and rejects it with None
+				existing.Spec.SessionAffinity = svc.Spec.SessionAffinity
+				existing.Spec.SessionAffinityConfig = nil
+			}
+		}
+		mergeMetadata(existing, svc)
+		if err := controllerutil.SetControllerReference(config, existing, r.Scheme); err != nil {
+			return err
+		}
+		if err := updateIfChanged(ctx, r, before, existing); err != nil {
+			return err
 		}
-		return err
 	}
 
-	// Update service spec and metadata (labels/annotations e.g. for serving-cert)
-	existing.Labels = svc.Labels
-	existing.Annotations = svc.Annotations
-	existing.Spec.Ports = svc.Spec.Ports
-	existing.Spec.Selector = svc.Spec.Selector
-	return r.Update(ctx, existing)
+	r.setCondition(config, smv1alpha1.ConditionServiceReady, "True", smv1alpha1.ReasonServiceConfigured, "Service is configured")
+	return nil
 }
 
 // reconcileDeployment ensures the plugin Deployment exists
 func (r *SecretsManagementConfigReconciler) reconcileDeployment(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
 	// Get image from config or use default
-	image := config.Spec.Plugin.Image
-	if image == "" {
-		image = DefaultPluginImage
+	image, arch, err := r.resolvePluginImage(ctx, config)
+	if err != nil {
+		return err
 	}
-
-	// Get replicas from config or use default
-	replicas := config.Spec.Plugin.Replicas
-	if replicas == 0 {
-		replicas = 2
+	resolvedImage, err := r.resolveMirroredImage(ctx, image)
+	if err != nil {
+		return err
 	}
 
+	replicas := pluginReplicas(config)
+
 	// Get image pull policy
 	imagePullPolicy := corev1.PullIfNotPresent
 	if config.Spec.Plugin.ImagePullPolicy == "Always" {
@@ -492,76 +906,63 @@ func (r *SecretsManagementConfigReconciler) reconcileDeployment(ctx context.Cont
 		imagePullPolicy = corev1.PullNever
 	}
 
-	// Build resource requirements (defaults)
-	resources := corev1.ResourceRequirements{
-		Requests: corev1.ResourceList{
-			corev1.ResourceCPU:    resource.MustParse("10m"),
-			corev1.ResourceMemory: resource.MustParse("50Mi"),
-		},
-		Limits: corev1.ResourceList{
-			corev1.ResourceCPU:    resource.MustParse("100m"),
-			corev1.ResourceMemory: resource.MustParse("128Mi"),
-		},
-	}
-
-	parseAndSet := func(fieldName string, val string, setter func(resource.Quantity)) error {
-		if val == "" {
-			return nil
-		}
-		q, err := resource.ParseQuantity(val)
-		if err != nil {
-			return fmt.Errorf("%s: invalid quantity %q: %w", fieldName, val, err)
-		}
-		setter(q)
-		return nil
-	}
-	if err := parseAndSet("spec.plugin.resources.requests.cpu", config.Spec.Plugin.Resources.Requests.CPU, func(q resource.Quantity) {
-		resources.Requests[corev1.ResourceCPU] = q
-	}); err != nil {
+	strategy, err := buildDeploymentStrategy(config.Spec.Plugin.Strategy, replicas)
+	if err != nil {
 		return err
 	}
-	if err := parseAndSet("spec.plugin.resources.requests.memory", config.Spec.Plugin.Resources.Requests.Memory, func(q resource.Quantity) {
-		resources.Requests[corev1.ResourceMemory] = q
-	}); err != nil {
+
+	// Ensure the trusted CA bundle ConfigMap exists when injection is enabled
+	caBundleHash, err := r.reconcileTrustedCABundle(ctx, config)
+	if err != nil {
 		return err
 	}
-	if err := parseAndSet("spec.plugin.resources.limits.cpu", config.Spec.Plugin.Resources.Limits.CPU, func(q resource.Quantity) {
-		resources.Limits[corev1.ResourceCPU] = q
-	}); err != nil {
+
+	resources, err := pluginResources(config)
+	if err != nil {
 		return err
 	}
-	if err := parseAndSet("spec.plugin.resources.limits.memory", config.Spec.Plugin.Resources.Limits.Memory, func(q resource.Quantity) {
-		resources.Limits[corev1.ResourceMemory] = q
-	}); err != nil {
-		return err
+	requests, limits := resources.Requests, resources.Limits
+	config.Status.ResolvedConfiguration = smv1alpha1.ResolvedConfigurationStatus{
+		Image:           image,
+		ImagePullPolicy: string(imagePullPolicy),
+		Replicas:        replicas,
+		Port:            pluginPort(config),
+		Resources: smv1alpha1.ResourceConfig{
+			Requests: smv1alpha1.ResourceRequirements{CPU: requests.Cpu().String(), Memory: requests.Memory().String()},
+			Limits:   smv1alpha1.ResourceRequirements{CPU: limits.Cpu().String(), Memory: limits.Memory().String()},
+		},
+		Strategy:   string(strategy.Type),
+		RolePrefix: rolePrefix(config),
 	}
 
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-plugin", PluginName),
+			Name:      fmt.Sprintf("%s-plugin", instanceName(config)),
 			Namespace: PluginNamespace,
 			Labels: map[string]string{
-				"app.kubernetes.io/name":       PluginName,
+				"app.kubernetes.io/name":       instanceName(config),
 				"app.kubernetes.io/part-of":    "ocp-secrets-management",
 				"app.kubernetes.io/managed-by": "secrets-management-operator",
 			},
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
+			Strategy: strategy,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
-					"app.kubernetes.io/name": PluginName,
+					"app.kubernetes.io/name": instanceName(config),
 				},
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"app.kubernetes.io/name":    PluginName,
+						"app.kubernetes.io/name":    instanceName(config),
 						"app.kubernetes.io/part-of": "ocp-secrets-management",
 					},
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: fmt.Sprintf("%s-plugin", PluginName),
+					ServiceAccountName:           fmt.Sprintf("%s-plugin", instanceName(config)),
+					AutomountServiceAccountToken: boolPtr(config.Spec.Plugin.ServiceAccount.AutomountServiceAccountToken),
 					SecurityContext: &corev1.PodSecurityContext{
 						RunAsNonRoot: boolPtr(true),
 						SeccompProfile: &corev1.SeccompProfile{
@@ -575,7 +976,8 @@ func (r *SecretsManagementConfigReconciler) reconcileDeployment(ctx context.Cont
 							ImagePullPolicy: imagePullPolicy,
 							Ports: []corev1.ContainerPort{
 								{
-									ContainerPort: PluginPort,
+									Name:          "https",
+									ContainerPort: pluginPort(config),
 									Protocol:      corev1.ProtocolTCP,
 								},
 							},
@@ -598,6 +1000,11 @@ func (r *SecretsManagementConfigReconciler) reconcileDeployment(ctx context.Cont
 									SubPath:   "nginx.conf",
 									ReadOnly:  true,
 								},
+								{
+									Name:      "runtime-config",
+									MountPath: RuntimeConfigMountPath,
+									ReadOnly:  true,
+								},
 							},
 						},
 					},
@@ -606,7 +1013,7 @@ func (r *SecretsManagementConfigReconciler) reconcileDeployment(ctx context.Cont
 							Name: "plugin-cert",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName:  fmt.Sprintf("%s-plugin-cert", PluginName),
+									SecretName:  fmt.Sprintf("%s-plugin-cert", instanceName(config)),
 									DefaultMode: int32Ptr(420),
 								},
 							},
@@ -616,90 +1023,309 @@ func (r *SecretsManagementConfigReconciler) reconcileDeployment(ctx context.Cont
 							VolumeSource: corev1.VolumeSource{
 								ConfigMap: &corev1.ConfigMapVolumeSource{
 									LocalObjectReference: corev1.LocalObjectReference{
-										Name: fmt.Sprintf("%s-nginx-conf", PluginName),
+										Name: fmt.Sprintf("%s-nginx-conf", instanceName(config)),
 									},
 									DefaultMode: int32Ptr(420),
 								},
 							},
 						},
+						{
+							Name: "runtime-config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: fmt.Sprintf("%s-runtime-config", instanceName(config)),
+									},
+									DefaultMode: int32Ptr(420),
+									Optional:    boolPtr(true),
+								},
+							},
+						},
 					},
 				},
 			},
 		},
 	}
 
-	// Ensure nginx config exists
-	if err := r.reconcileNginxConfig(ctx, config); err != nil {
+	if config.Spec.Plugin.InjectTrustedCABundle {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: "trusted-ca-bundle",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: fmt.Sprintf("%s-trusted-ca-bundle", instanceName(config)),
+					},
+					Items: []corev1.KeyToPath{
+						{Key: TrustedCABundleKey, Path: "tls-ca-bundle.pem"},
+					},
+					DefaultMode: int32Ptr(420),
+					Optional:    boolPtr(true),
+				},
+			},
+		})
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "trusted-ca-bundle",
+			MountPath: TrustedCABundleMountPath,
+			ReadOnly:  true,
+		})
+		if caBundleHash != "" {
+			deployment.Spec.Template.Annotations = map[string]string{
+				TrustedCABundleHashAnnotation: caBundleHash,
+			}
+		}
+	}
+
+	// Per-architecture images only run on matching nodes
+	if arch != "" {
+		deployment.Spec.Template.Spec.NodeSelector = map[string]string{
+			corev1.LabelArchStable: arch,
+		}
+	}
+
+	if metricsPort := config.Spec.Plugin.MetricsPort; metricsPort != 0 {
+		container := &deployment.Spec.Template.Spec.Containers[0]
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			Name:          "metrics",
+			ContainerPort: metricsPort,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+
+	// Project bound tokens for each configured audience
+	if audiences := config.Spec.Plugin.ServiceAccount.TokenAudiences; len(audiences) > 0 {
+		sources := make([]corev1.VolumeProjection, 0, len(audiences))
+		for _, audience := range audiences {
+			sources = append(sources, corev1.VolumeProjection{
+				ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+					Audience:          audience,
+					ExpirationSeconds: int64Ptr(3600),
+					Path:              tokenPathForAudience(audience),
+				},
+			})
+		}
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: "bound-sa-token",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources:     sources,
+					DefaultMode: int32Ptr(420),
+				},
+			},
+		})
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "bound-sa-token",
+			MountPath: "/var/run/secrets/tokens",
+			ReadOnly:  true,
+		})
+	}
+
+	// Append user-supplied volumes; names must not collide with the managed ones
+	podSpec := &deployment.Spec.Template.Spec
+	managedVolumes := make(map[string]bool, len(podSpec.Volumes))
+	for _, v := range podSpec.Volumes {
+		managedVolumes[v.Name] = true
+	}
+	for _, v := range config.Spec.Plugin.ExtraVolumes {
+		if managedVolumes[v.Name] {
+			return fmt.Errorf("spec.plugin.extraVolumes: volume name %q is reserved by the operator", v.Name)
+		}
+		podSpec.Volumes = append(podSpec.Volumes, *v.DeepCopy())
+	}
+	for _, m := range config.Spec.Plugin.ExtraVolumeMounts {
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, *m.DeepCopy())
+	}
+
+	// Ensure nginx config exists; changes to it restart the plugin pods
+	nginxHash, err := r.reconcileNginxConfig(ctx, config)
+	if err != nil {
+		return err
+	}
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations[NginxConfigHashAnnotation] = nginxHash
+	setServiceMeshAnnotations(config, deployment)
+
+	// Restart the plugin pods when a feature is toggled
+	if err := setFeaturesHash(config, deployment); err != nil {
+		return err
+	}
+
+	// The controller reference lets the Deployment watch enqueue this config on rollout progress
+	if err := controllerutil.SetControllerReference(config, deployment, r.Scheme); err != nil {
+		return err
+	}
+	applyCommonMetadata(config, deployment)
+	// Pods carry the common labels too, for tools that attribute cost or select by pod
+	deployment.Spec.Template.Labels, _ = withDefaults(deployment.Spec.Template.Labels, commonLabels(config))
+	if err := setAppliedSpecHash(deployment); err != nil {
 		return err
 	}
 
 	existing := &appsv1.Deployment{}
-	err := r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, existing)
+	err = r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, existing)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return r.Create(ctx, deployment)
+			if err := r.Create(ctx, deployment); err != nil {
+				return err
+			}
+			r.setPluginStatus(config, deployment, resolvedImage)
+			return nil
 		}
 		return err
 	}
 
-	// Update deployment spec
-	existing.Spec = deployment.Spec
-	if err := r.Update(ctx, existing); err != nil {
+	// Update deployment spec, unless only fields the API server defaults differ
+	before := existing.DeepCopy()
+	if specChanged(existing, deployment, existing.Spec, deployment.Spec) {
+		existing.Spec = deployment.Spec
+	}
+	mergeMetadata(existing, deployment)
+	if err := controllerutil.SetControllerReference(config, existing, r.Scheme); err != nil {
+		return err
+	}
+	if err := updateIfChanged(ctx, r, before, existing); err != nil {
 		return err
 	}
 
-	// Update status with deployment info
+	// existing now holds the API server's response to the update, or the latest watched state when
+	// nothing changed; Deployment status events requeue the config, so this refreshes on each of them
+	r.setPluginStatus(config, existing, resolvedImage)
+
+	return nil
+}
+
+// setPluginStatus reports the plugin Deployment's replicas and rollout in the status of config
+func (r *SecretsManagementConfigReconciler) setPluginStatus(config *smv1alpha1.SecretsManagementConfig, deployment *appsv1.Deployment, resolvedImage string) {
 	config.Status.Plugin = smv1alpha1.PluginStatus{
 		DeploymentName:    deployment.Name,
-		ServiceName:       fmt.Sprintf("%s-plugin", PluginName),
-		ConsolePluginName: PluginName,
-		AvailableReplicas: existing.Status.AvailableReplicas,
-		Ready:             existing.Status.AvailableReplicas > 0,
+		ServiceName:       fmt.Sprintf("%s-plugin", instanceName(config)),
+		ConsolePluginName: instanceName(config),
+		AvailableReplicas: deployment.Status.AvailableReplicas,
+		Ready:             deployment.Status.AvailableReplicas > 0,
+		ResolvedImage:     resolvedImage,
 	}
 
 	r.setCondition(config, smv1alpha1.ConditionPluginDeployed, "True", "DeploymentReady", "Plugin deployment is ready")
+	if deployment.Status.AvailableReplicas > 0 {
+		r.setCondition(config, smv1alpha1.ConditionDeploymentAvailable, "True", smv1alpha1.ReasonMinimumReplicasAvailable,
+			fmt.Sprintf("%d replicas available", deployment.Status.AvailableReplicas))
+	} else {
+		r.setCondition(config, smv1alpha1.ConditionDeploymentAvailable, "False", smv1alpha1.ReasonMinimumReplicasUnavailable, "No plugin replicas are available yet")
+	}
+	r.setRolloutCondition(config, deployment)
+	setFeaturesStatus(config, deployment)
+}
 
-	return nil
+// tokenPathForAudience returns the file name a bound token for audience is projected to,
+// replacing characters that are not valid in a path element
+func tokenPathForAudience(audience string) string {
+	path := make([]rune, 0, len(audience))
+	for _, c := range audience {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '-', c == '_':
+			path = append(path, c)
+		default:
+			path = append(path, '-')
+		}
+	}
+	return strings.TrimLeft(string(path), ".") + "-token"
+}
+
+// pluginReplicas returns the configured plugin replicas or the default
+func pluginReplicas(config *smv1alpha1.SecretsManagementConfig) int32 {
+	if config.Spec.Plugin.Replicas != 0 {
+		return config.Spec.Plugin.Replicas
+	}
+	return 2
+}
+
+// pluginResources returns the requests and limits of the plugin container, with defaults for
+// those spec.plugin.resources leaves unset
+func pluginResources(config *smv1alpha1.SecretsManagementConfig) (corev1.ResourceRequirements, error) {
+	cfg := config.Spec.Plugin.Resources
+	requestCPU, err := parseQuantityOrDefault("spec.plugin.resources.requests.cpu", cfg.Requests.CPU, "10m")
+	if err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+	requestMemory, err := parseQuantityOrDefault("spec.plugin.resources.requests.memory", cfg.Requests.Memory, "50Mi")
+	if err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+	limitCPU, err := parseQuantityOrDefault("spec.plugin.resources.limits.cpu", cfg.Limits.CPU, "100m")
+	if err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+	limitMemory, err := parseQuantityOrDefault("spec.plugin.resources.limits.memory", cfg.Limits.Memory, "128Mi")
+	if err != nil {
+		return corev1.ResourceRequirements{}, err
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: requestCPU, corev1.ResourceMemory: requestMemory},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: limitCPU, corev1.ResourceMemory: limitMemory},
+	}
+
+	// The API server rejects pods requesting more than their limit; name the fields instead. The
+	// CRD cannot check this, since the CEL quantity functions need a newer API server than supported.
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		request, limit := resources.Requests[name], resources.Limits[name]
+		if request.Cmp(limit) > 0 {
+			return corev1.ResourceRequirements{}, fmt.Errorf("spec.plugin.resources: %s request %s exceeds the limit %s", name, request.String(), limit.String())
+		}
+	}
+	return resources, nil
 }
 
-// reconcileNginxConfig ensures the nginx ConfigMap exists
-func (r *SecretsManagementConfigReconciler) reconcileNginxConfig(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
-	nginxConf := `
-error_log /dev/stdout info;
-events {}
-http {
-  access_log /dev/stdout;
-  include /etc/nginx/mime.types;
-  default_type application/octet-stream;
-  server {
-    listen 9443 ssl;
-    ssl_certificate /var/cert/tls.crt;
-    ssl_certificate_key /var/cert/tls.key;
-    root /usr/share/nginx/html;
-
-    # Serve plugin manifest at / so the console gets a valid manifest when fetching basePath
-    location = / {
-      add_header Content-Type application/json;
-      alias /usr/share/nginx/html/plugin-manifest.json;
-    }
-    location = /plugin-manifest.json {
-      add_header Content-Type application/json;
-    }
-
-    location /health {
-      return 200 'OK';
-      add_header Content-Type text/plain;
-    }
-  }
-}
-`
+// buildDeploymentStrategy converts the configured strategy into a Deployment strategy.
+// Without explicit settings a single-replica plugin surges a new pod before the old one
+// is removed so updates never drop all capacity.
+func buildDeploymentStrategy(cfg smv1alpha1.DeploymentStrategyConfig, replicas int32) (appsv1.DeploymentStrategy, error) {
+	if cfg.Type == string(appsv1.RecreateDeploymentStrategyType) {
+		if cfg.MaxSurge != nil || cfg.MaxUnavailable != nil {
+			return appsv1.DeploymentStrategy{}, fmt.Errorf("spec.plugin.strategy: maxSurge and maxUnavailable are only valid for RollingUpdate")
+		}
+		return appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}, nil
+	}
+
+	rollingUpdate := &appsv1.RollingUpdateDeployment{
+		MaxSurge:       cfg.MaxSurge,
+		MaxUnavailable: cfg.MaxUnavailable,
+	}
+	if rollingUpdate.MaxUnavailable == nil && replicas == 1 {
+		maxUnavailable := intstr.FromInt(0)
+		rollingUpdate.MaxUnavailable = &maxUnavailable
+	}
+	if rollingUpdate.MaxSurge == nil && replicas == 1 {
+		maxSurge := intstr.FromInt(1)
+		rollingUpdate.MaxSurge = &maxSurge
+	}
+
+	return appsv1.DeploymentStrategy{
+		Type:          appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: rollingUpdate,
+	}, nil
+}
+
+// reconcileNginxConfig ensures the nginx ConfigMap exists and returns the hash of nginx.conf
+func (r *SecretsManagementConfigReconciler) reconcileNginxConfig(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) (string, error) {
+	families, err := r.pluginIPFamilies(ctx, config)
+	if err != nil {
+		return "", err
+	}
+	nginxConf, err := renderNginxConfig(config, families)
+	if err != nil {
+		return "", err
+	}
+	hash := nginxConfigHash(nginxConf)
 
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-nginx-conf", PluginName),
+			Name:      fmt.Sprintf("%s-nginx-conf", instanceName(config)),
 			Namespace: PluginNamespace,
 			Labels: map[string]string{
-				"app.kubernetes.io/name":       PluginName,
+				"app.kubernetes.io/name":       instanceName(config),
 				"app.kubernetes.io/part-of":    "ocp-secrets-management",
 				"app.kubernetes.io/managed-by": "secrets-management-operator",
 			},
@@ -708,136 +1334,217 @@ http {
 			"nginx.conf": nginxConf,
 		},
 	}
+	if err := controllerutil.SetControllerReference(config, cm, r.Scheme); err != nil {
+		return "", err
+	}
+	applyCommonMetadata(config, cm)
+	if err := setAppliedSpecHash(cm); err != nil {
+		return "", err
+	}
 
 	existing := &corev1.ConfigMap{}
-	err := r.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, existing)
+	err = r.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, existing)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return r.Create(ctx, cm)
+			return hash, r.Create(ctx, cm)
 		}
-		return err
+		return "", err
 	}
 
-	existing.Data = cm.Data
-	return r.Update(ctx, existing)
+	before := existing.DeepCopy()
+	if specChanged(existing, cm, existing.Data, cm.Data) {
+		existing.Data = cm.Data
+	}
+	mergeMetadata(existing, cm)
+	if err := controllerutil.SetControllerReference(config, existing, r.Scheme); err != nil {
+		return "", err
+	}
+	return hash, updateIfChanged(ctx, r, before, existing)
+}
+
+// reconcileTrustedCABundle ensures the CA bundle ConfigMap exists when injection is enabled (and removes it otherwise).
+// It returns a hash of the injected bundle, or "" if nothing has been injected yet.
+func (r *SecretsManagementConfigReconciler) reconcileTrustedCABundle(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) (string, error) {
+	name := fmt.Sprintf("%s-trusted-ca-bundle", instanceName(config))
+
+	if !config.Spec.Plugin.InjectTrustedCABundle {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: PluginNamespace},
+		}
+		if err := r.Delete(ctx, cm); err != nil && !errors.IsNotFound(err) {
+			return "", err
+		}
+		return "", nil
+	}
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":       instanceName(config),
+		"app.kubernetes.io/part-of":    "ocp-secrets-management",
+		"app.kubernetes.io/managed-by": "secrets-management-operator",
+		TrustedCABundleInjectLabel:     "true",
+	}
+	// Data is left empty; the cluster network operator injects the merged bundle
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: PluginNamespace,
+			Labels:    labels,
+		},
+	}
+	applyCommonMetadata(config, cm)
+
+	existing := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: PluginNamespace}, existing)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return "", err
+		}
+		// Owner reference lets the ConfigMap watch enqueue this config when the bundle rotates
+		if err := controllerutil.SetControllerReference(config, cm, r.Scheme); err != nil {
+			return "", err
+		}
+		return "", r.Create(ctx, cm)
+	}
+
+	// Only manage metadata and ownership; never overwrite the injected data
+	before := existing.DeepCopy()
+	mergeMetadata(existing, cm)
+	if err := controllerutil.SetControllerReference(config, existing, r.Scheme); err != nil {
+		return "", err
+	}
+	if err := updateIfChanged(ctx, r, before, existing); err != nil {
+		return "", err
+	}
+
+	bundle := existing.Data[TrustedCABundleKey]
+	if bundle == "" {
+		return "", nil
+	}
+	sum := sha256.Sum256([]byte(bundle))
+	return hex.EncodeToString(sum[:]), nil
 }
 
 // reconcileConsolePlugin ensures the ConsolePlugin CR exists
 func (r *SecretsManagementConfigReconciler) reconcileConsolePlugin(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	consolePlugin := &unstructured.Unstructured{}
+	consolePlugin.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": "console.openshift.io/v1",
+		"kind":       "ConsolePlugin",
+		"metadata": map[string]interface{}{
+			"name": instanceName(config),
+			"labels": map[string]interface{}{
+				"app.kubernetes.io/name":       instanceName(config),
+				"app.kubernetes.io/part-of":    "ocp-secrets-management",
+				"app.kubernetes.io/managed-by": "secrets-management-operator",
+			},
+		},
+		"spec": r.consolePluginSpec(config),
+	})
+	consolePlugin.SetGroupVersionKind(consolePluginGVK)
+	applyCommonMetadata(config, consolePlugin)
+	if err := setAppliedSpecHash(consolePlugin); err != nil {
+		return err
+	}
+
 	existing := &unstructured.Unstructured{}
 	existing.SetGroupVersionKind(consolePluginGVK)
-	err := r.Get(ctx, types.NamespacedName{Name: PluginName}, existing)
+	err := r.Get(ctx, types.NamespacedName{Name: instanceName(config)}, existing)
 	if err != nil {
+		if meta.IsNoMatchError(err) {
+			r.setCondition(config, smv1alpha1.ConditionConsolePluginRegistered, "False", smv1alpha1.ReasonConsolePluginAPIUnavailable, "console.openshift.io/v1 ConsolePlugin is not served by this cluster")
+			return err
+		}
 		if errors.IsNotFound(err) {
 			// Create new ConsolePlugin
-			consolePlugin := map[string]interface{}{
-				"apiVersion": "console.openshift.io/v1",
-				"kind":       "ConsolePlugin",
-				"metadata": map[string]interface{}{
-					"name": PluginName,
-					"labels": map[string]interface{}{
-						"app.kubernetes.io/name":       PluginName,
-						"app.kubernetes.io/part-of":    "ocp-secrets-management",
-						"app.kubernetes.io/managed-by": "secrets-management-operator",
-					},
-				},
-				"spec": map[string]interface{}{
-					"displayName": "OCP Secrets Management",
-					"backend": map[string]interface{}{
-						"type": "Service",
-						"service": map[string]interface{}{
-							"name":      fmt.Sprintf("%s-plugin", PluginName),
-							"namespace": PluginNamespace,
-							"port":      int64(PluginPort), // Must be int64 for unstructured
-							"basePath":  "/",
-						},
-					},
-				},
+			if err := r.Create(ctx, consolePlugin); err != nil {
+				return err
 			}
-
-			u := &unstructured.Unstructured{}
-			u.SetUnstructuredContent(consolePlugin)
-			u.SetGroupVersionKind(consolePluginGVK)
-			return r.Create(ctx, u)
+			r.setCondition(config, smv1alpha1.ConditionConsolePluginRegistered, "True", smv1alpha1.ReasonConsolePluginRegistered, "ConsolePlugin created")
+			return nil
 		}
 		return err
 	}
 
 	// Update existing - preserve resourceVersion and other metadata
+	// Only update spec, merging in the managed labels and annotations
+	before := existing.DeepCopy()
+	if specChanged(existing, consolePlugin, existing.Object["spec"], consolePlugin.Object["spec"]) {
+		existing.Object["spec"] = consolePlugin.Object["spec"]
+	}
+	mergeMetadata(existing, consolePlugin)
+
+	if err := updateIfChanged(ctx, r, before, existing); err != nil {
+		return err
+	}
+	r.setCondition(config, smv1alpha1.ConditionConsolePluginRegistered, "True", smv1alpha1.ReasonConsolePluginRegistered, "ConsolePlugin is registered")
+	return nil
+}
+
+// consolePluginSpec returns the ConsolePlugin spec. The audit endpoint is proxied for the primary
+// config when spec.audit is enabled.
+func (r *SecretsManagementConfigReconciler) consolePluginSpec(config *smv1alpha1.SecretsManagementConfig) map[string]interface{} {
 	spec := map[string]interface{}{
 		"displayName": "OCP Secrets Management",
 		"backend": map[string]interface{}{
 			"type": "Service",
 			"service": map[string]interface{}{
-				"name":      fmt.Sprintf("%s-plugin", PluginName),
+				"name":      fmt.Sprintf("%s-plugin", instanceName(config)),
 				"namespace": PluginNamespace,
-				"port":      int64(PluginPort),
+				"port":      int64(pluginPort(config)), // Must be int64 for unstructured
 				"basePath":  "/",
 			},
 		},
 	}
-
-	// Only update spec, preserve existing metadata
-	if err := unstructured.SetNestedField(existing.Object, spec, "spec"); err != nil {
-		return err
-	}
-
-	// Update labels
-	labels := map[string]string{
-		"app.kubernetes.io/name":       PluginName,
-		"app.kubernetes.io/part-of":    "ocp-secrets-management",
-		"app.kubernetes.io/managed-by": "secrets-management-operator",
+	proxies := []interface{}{r.operatorProxy(AccessReviewProxyAlias)}
+	if isPrimaryConfig(config) && config.Spec.Audit.Enabled {
+		proxies = append(proxies, r.operatorProxy(AuditProxyAlias))
 	}
-	existing.SetLabels(labels)
-
-	return r.Update(ctx, existing)
+	spec["proxy"] = proxies
+	return spec
 }
 
-// detectOperators checks for installed operator CRDs
+// detectOperators checks for installed operator CRDs and whether the console supports them
 func (r *SecretsManagementConfigReconciler) detectOperators(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	var unsupported []string
 	for operatorKey, crdName := range operatorCRDs {
 		crd := &apiextensionsv1.CustomResourceDefinition{}
 		err := r.Get(ctx, types.NamespacedName{Name: crdName}, crd)
 
-		installed := err == nil
-		version := ""
-		if installed && len(crd.Spec.Versions) > 0 {
+		detected := smv1alpha1.DetectedOperator{Installed: err == nil}
+		if detected.Installed && len(crd.Spec.Versions) > 0 {
 			for _, v := range crd.Spec.Versions {
 				if v.Served {
-					version = v.Name
+					detected.Version = v.Name
 					break
 				}
 			}
 		}
+		if detected.Installed {
+			var problem string
+			detected.ReleaseVersion = crd.Labels[releaseVersionLabel]
+			detected.SupportLevel, problem = checkCompatibility(operatorCompatibility[operatorKey], crd)
+			if problem != "" {
+				unsupported = append(unsupported, problem)
+			}
+		}
 
 		switch operatorKey {
 		case "certManager":
-			config.Status.DetectedOperators.CertManager = smv1alpha1.DetectedOperator{
-				Installed: installed,
-				Version:   version,
-			}
+			config.Status.DetectedOperators.CertManager = detected
 		case "externalSecrets":
-			config.Status.DetectedOperators.ExternalSecrets = smv1alpha1.DetectedOperator{
-				Installed: installed,
-				Version:   version,
-			}
+			config.Status.DetectedOperators.ExternalSecrets = detected
 		case "secretsStoreCSI":
-			config.Status.DetectedOperators.SecretsStoreCSI = smv1alpha1.DetectedOperator{
-				Installed: installed,
-				Version:   version,
-			}
+			config.Status.DetectedOperators.SecretsStoreCSI.DetectedOperator = detected
 		}
 	}
+	r.setCompatibilityCondition(config, unsupported)
 
-	return nil
+	return r.detectCSIDaemonSets(ctx, config)
 }
 
 // cleanupRBAC removes RBAC resources
 func (r *SecretsManagementConfigReconciler) cleanupRBAC(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
-	prefix := config.Spec.RBAC.RolePrefix
-	if prefix == "" {
-		prefix = "secrets-management"
-	}
+	prefix := rolePrefix(config)
 
 	roleNames := []string{
 		fmt.Sprintf("%s-view", prefix),
@@ -854,7 +1561,8 @@ func (r *SecretsManagementConfigReconciler) cleanupRBAC(ctx context.Context, con
 		}
 	}
 
-	return nil
+	// Remove the view Roles generated for spec.visibility
+	return r.reconcileVisibilityRoles(ctx, config, nil)
 }
 
 // cleanupPluginDeployment removes plugin deployment resources
@@ -862,7 +1570,7 @@ func (r *SecretsManagementConfigReconciler) cleanupPluginDeployment(ctx context.
 	// Delete Deployment
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-plugin", PluginName),
+			Name:      fmt.Sprintf("%s-plugin", instanceName(config)),
 			Namespace: PluginNamespace,
 		},
 	}
@@ -873,7 +1581,7 @@ func (r *SecretsManagementConfigReconciler) cleanupPluginDeployment(ctx context.
 	// Delete Service
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-plugin", PluginName),
+			Name:      fmt.Sprintf("%s-plugin", instanceName(config)),
 			Namespace: PluginNamespace,
 		},
 	}
@@ -884,7 +1592,7 @@ func (r *SecretsManagementConfigReconciler) cleanupPluginDeployment(ctx context.
 	// Delete ServiceAccount
 	sa := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-plugin", PluginName),
+			Name:      fmt.Sprintf("%s-plugin", instanceName(config)),
 			Namespace: PluginNamespace,
 		},
 	}
@@ -895,7 +1603,7 @@ func (r *SecretsManagementConfigReconciler) cleanupPluginDeployment(ctx context.
 	// Delete ConfigMap
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-nginx-conf", PluginName),
+			Name:      fmt.Sprintf("%s-nginx-conf", instanceName(config)),
 			Namespace: PluginNamespace,
 		},
 	}
@@ -903,6 +1611,28 @@ func (r *SecretsManagementConfigReconciler) cleanupPluginDeployment(ctx context.
 		return err
 	}
 
+	// Delete runtime config ConfigMap
+	runtimeConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-runtime-config", instanceName(config)),
+			Namespace: PluginNamespace,
+		},
+	}
+	if err := r.Delete(ctx, runtimeConfig); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	// Delete trusted CA bundle ConfigMap
+	caBundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-trusted-ca-bundle", instanceName(config)),
+			Namespace: PluginNamespace,
+		},
+	}
+	if err := r.Delete(ctx, caBundle); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
 	return nil
 }
 
@@ -910,7 +1640,7 @@ func (r *SecretsManagementConfigReconciler) cleanupPluginDeployment(ctx context.
 func (r *SecretsManagementConfigReconciler) cleanupConsolePlugin(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(consolePluginGVK)
-	u.SetName(PluginName)
+	u.SetName(instanceName(config))
 
 	if err := r.Delete(ctx, u); err != nil && !errors.IsNotFound(err) {
 		return err
@@ -934,9 +1664,11 @@ func (r *SecretsManagementConfigReconciler) setCondition(config *smv1alpha1.Secr
 	found := false
 	for i, c := range config.Status.Conditions {
 		if c.Type == condType {
-			if c.Status != status {
-				config.Status.Conditions[i] = condition
+			// Keep the transition time when only the reason or message changes
+			if c.Status == status {
+				condition.LastTransitionTime = c.LastTransitionTime
 			}
+			config.Status.Conditions[i] = condition
 			found = true
 			break
 		}
@@ -946,26 +1678,112 @@ func (r *SecretsManagementConfigReconciler) setCondition(config *smv1alpha1.Secr
 	}
 }
 
-// updateStatusError updates the status with an error
-func (r *SecretsManagementConfigReconciler) updateStatusError(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, err error) (ctrl.Result, error) {
-	config.Status.Phase = smv1alpha1.PhaseError
-	if updateErr := r.Status().Update(ctx, config); updateErr != nil {
-		return ctrl.Result{}, updateErr
+// removeCondition drops the condition of condType, for checks that no longer apply
+func (r *SecretsManagementConfigReconciler) removeCondition(config *smv1alpha1.SecretsManagementConfig, condType smv1alpha1.ConditionType) {
+	conditions := config.Status.Conditions[:0]
+	for _, c := range config.Status.Conditions {
+		if c.Type != condType {
+			conditions = append(conditions, c)
+		}
 	}
+	config.Status.Conditions = conditions
+}
+
+// updateStatusError records err in the status; the deferred patch in Reconcile persists it
+func (r *SecretsManagementConfigReconciler) updateStatusError(config *smv1alpha1.SecretsManagementConfig, start time.Time, err error) (ctrl.Result, error) {
+	setPhase(config, smv1alpha1.PhaseError, err.Error())
+	config.Status.Reason = smv1alpha1.ReasonReconcileError
+	recordReconcile(config, start, err)
 	return ctrl.Result{}, err
 }
 
+// patchStatus writes the status changes between original and config as a merge patch,
+// retrying on conflict so concurrent writers don't surface as reconcile errors
+func (r *SecretsManagementConfigReconciler) patchStatus(ctx context.Context, original, config *smv1alpha1.SecretsManagementConfig) error {
+	if equality.Semantic.DeepEqual(original.Status, config.Status) {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Status().Patch(ctx, config, client.MergeFrom(original))
+		if errors.IsNotFound(err) {
+			// Deleted while reconciling; nothing left to report on
+			return nil
+		}
+		return err
+	})
+}
+
+// requeueInterval returns the jittered periodic requeue interval, preferring spec.reconcileInterval
+func (r *SecretsManagementConfigReconciler) requeueInterval(config *smv1alpha1.SecretsManagementConfig) time.Duration {
+	interval := r.ReconcileInterval
+	if config.Spec.ReconcileInterval != nil && config.Spec.ReconcileInterval.Duration > 0 {
+		interval = config.Spec.ReconcileInterval.Duration
+	}
+	if interval <= 0 {
+		interval = DefaultReconcileInterval
+	}
+	return wait.Jitter(interval, ReconcileJitterFactor)
+}
+
+// setPhase updates the overall phase, recording the transition in the bounded status history
+func setPhase(config *smv1alpha1.SecretsManagementConfig, phase smv1alpha1.ConfigPhase, message string) {
+	if config.Status.Phase == phase {
+		return
+	}
+	config.Status.Phase = phase
+	config.Status.History = append(config.Status.History, smv1alpha1.PhaseTransition{
+		Phase:   phase,
+		Time:    metav1.Now(),
+		Message: message,
+	})
+	if n := len(config.Status.History); n > MaxPhaseHistory {
+		config.Status.History = config.Status.History[n-MaxPhaseHistory:]
+	}
+}
+
+// recordReconcile stores the timing and outcome of the current reconcile loop in status
+func recordReconcile(config *smv1alpha1.SecretsManagementConfig, start time.Time, err error) {
+	startTime := metav1.NewTime(start)
+	config.Status.LastReconcileTime = &startTime
+	config.Status.LastReconcileDuration = &metav1.Duration{Duration: time.Since(start)}
+	config.Status.LastError = ""
+	if err != nil {
+		config.Status.LastError = err.Error()
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager
 func (r *SecretsManagementConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	owned := builder.WithPredicates(ownedObjectChangedPredicate())
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&smv1alpha1.SecretsManagementConfig{}).
-		Owns(&appsv1.Deployment{}).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.ServiceAccount{}).
-		Owns(&corev1.ConfigMap{}).
+		For(&smv1alpha1.SecretsManagementConfig{}, builder.WithPredicates(configChangedPredicate())).
+		Owns(&appsv1.Deployment{}, owned).
+		Owns(&corev1.Service{}, owned).
+		Owns(&corev1.ServiceAccount{}, owned).
+		Owns(&corev1.ConfigMap{}, owned).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.configsForRulesOverride)).
+		Watches(&smv1alpha1.SecretsManagementTenant{}, handler.EnqueueRequestsFromMapFunc(primaryConfigForTenant)).
+		Watches(&smv1alpha1.SecretsManagementConfig{}, handler.EnqueueRequestsFromMapFunc(primaryConfigForCanary),
+			builder.WithPredicates(configChangedPredicate())).
 		Complete(r)
 }
 
+// apiReader returns the uncached reader, falling back to the cached client
+func (r *SecretsManagementConfigReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// pluginPort returns the configured plugin HTTPS port or the default
+func pluginPort(config *smv1alpha1.SecretsManagementConfig) int32 {
+	if config.Spec.Plugin.Port != 0 {
+		return config.Spec.Plugin.Port
+	}
+	return PluginPort
+}
+
 // Helper functions
 func boolPtr(b bool) *bool {
 	return &b
@@ -974,3 +1792,11 @@ func boolPtr(b bool) *bool {
 func int32Ptr(i int32) *int32 {
 	return &i
 }
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
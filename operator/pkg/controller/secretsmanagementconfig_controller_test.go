@@ -2,7 +2,9 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,16 +13,22 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	smtesting "github.com/openshift/ocp-secrets-management/operator/pkg/testing"
 )
 
 func init() {
@@ -28,49 +36,19 @@ func init() {
 }
 
 func newTestScheme() *runtime.Scheme {
-	scheme := runtime.NewScheme()
-	_ = clientgoscheme.AddToScheme(scheme)
-	_ = smv1alpha1.AddToScheme(scheme)
-	_ = apiextensionsv1.AddToScheme(scheme)
-	return scheme
+	return smtesting.NewScheme()
 }
 
 func newTestReconciler(objs ...client.Object) *SecretsManagementConfigReconciler {
-	scheme := newTestScheme()
-	fakeClient := fake.NewClientBuilder().
-		WithScheme(scheme).
-		WithObjects(objs...).
-		WithStatusSubresource(&smv1alpha1.SecretsManagementConfig{}).
-		Build()
-
 	return &SecretsManagementConfigReconciler{
-		Client: fakeClient,
+		Client: smtesting.NewClient(objs...),
 		Log:    ctrl.Log.WithName("test"),
-		Scheme: scheme,
+		Scheme: newTestScheme(),
 	}
 }
 
 func newTestConfig(name string) *smv1alpha1.SecretsManagementConfig {
-	return &smv1alpha1.SecretsManagementConfig{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
-		},
-		Spec: smv1alpha1.SecretsManagementConfigSpec{
-			RBAC: smv1alpha1.RBACConfig{
-				CreateDefaultRoles: true,
-				RolePrefix:         "secrets-management",
-			},
-			Plugin: smv1alpha1.PluginConfig{
-				Image:    "openshift.io/ocp-secrets-management:test",
-				Replicas: 2,
-			},
-			Operators: smv1alpha1.OperatorsConfig{
-				CertManager:     smv1alpha1.OperatorConfig{Enabled: true},
-				ExternalSecrets: smv1alpha1.OperatorConfig{Enabled: true},
-				SecretsStoreCSI: smv1alpha1.OperatorConfig{Enabled: true},
-			},
-		},
-	}
+	return smtesting.NewConfig(name)
 }
 
 func TestReconcile_NewConfig(t *testing.T) {
@@ -90,6 +68,125 @@ func TestReconcile_NewConfig(t *testing.T) {
 	err = r.Get(ctx, types.NamespacedName{Name: "cluster"}, updatedConfig)
 	require.NoError(t, err)
 	assert.Contains(t, updatedConfig.Finalizers, FinalizerName)
+	assert.NotNil(t, updatedConfig.Status.LastReconcileTime)
+	assert.NotNil(t, updatedConfig.Status.LastReconcileDuration)
+	assert.Empty(t, updatedConfig.Status.LastError)
+
+	// The plugin Deployment was just created, so the config waits for its rollout
+	assert.Equal(t, smv1alpha1.PhaseDeploying, updatedConfig.Status.Phase)
+	cond := findCondition(updatedConfig, smv1alpha1.ConditionProgressing)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonReplicaSetUpdating, cond.Reason)
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, deployment))
+	deployment.Status = appsv1.DeploymentStatus{ObservedGeneration: deployment.Generation, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+	require.NoError(t, r.Status().Update(ctx, deployment))
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "cluster"}})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "cluster"}, updatedConfig))
+	assert.Equal(t, smv1alpha1.PhaseReady, updatedConfig.Status.Phase)
+	cond = findCondition(updatedConfig, smv1alpha1.ConditionProgressing)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonNewReplicaSetAvailable, cond.Reason)
+	require.Len(t, updatedConfig.Status.History, 2)
+	assert.Equal(t, smv1alpha1.PhaseDeploying, updatedConfig.Status.History[0].Phase)
+	assert.Equal(t, smv1alpha1.PhaseReady, updatedConfig.Status.History[1].Phase)
+}
+
+func TestReconcile_StalledRolloutDegrades(t *testing.T) {
+	ctx := context.Background()
+	r := newTestReconciler(newTestConfig("cluster"))
+	reconcileTestConfig(t, r)
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, deployment))
+	deployment.Status = appsv1.DeploymentStatus{
+		Replicas:        2,
+		UpdatedReplicas: 1,
+		Conditions: []appsv1.DeploymentCondition{{
+			Type:    appsv1.DeploymentProgressing,
+			Status:  corev1.ConditionFalse,
+			Reason:  smv1alpha1.ReasonProgressDeadlineExceeded,
+			Message: `ReplicaSet "ocp-secrets-management-plugin-7d4b9" has timed out progressing.`,
+		}},
+	}
+	require.NoError(t, r.Status().Update(ctx, deployment))
+	reconcileTestConfig(t, r)
+
+	config := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "cluster"}, config))
+	assert.Equal(t, smv1alpha1.PhaseDegraded, config.Status.Phase)
+	assert.Equal(t, smv1alpha1.ReasonProgressDeadlineExceeded, config.Status.Reason)
+	cond := findCondition(config, smv1alpha1.ConditionProgressing)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonProgressDeadlineExceeded, cond.Reason)
+	assert.Contains(t, cond.Message, "has timed out progressing")
+}
+
+func TestReconcile_PluginStatusFollowsDeployment(t *testing.T) {
+	ctx := context.Background()
+	r := newTestReconciler(newTestConfig("cluster"))
+	reconcileTestConfig(t, r)
+
+	config := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "cluster"}, config))
+	assert.Equal(t, "ocp-secrets-management-plugin", config.Status.Plugin.DeploymentName, "reported from the first loop")
+	assert.False(t, config.Status.Plugin.Ready)
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, deployment))
+	rolledOut := []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: smv1alpha1.ReasonNewReplicaSetAvailable}}
+	deployment.Status = appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2, Conditions: rolledOut}
+	require.NoError(t, r.Status().Update(ctx, deployment))
+	reconcileTestConfig(t, r)
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "cluster"}, config))
+	assert.Equal(t, smv1alpha1.PhaseReady, config.Status.Phase)
+	assert.Empty(t, config.Status.Reason)
+	assert.Equal(t, int32(2), config.Status.Plugin.AvailableReplicas)
+	assert.True(t, config.Status.Plugin.Ready)
+
+	// One pod crashes: still rolled out, fewer replicas
+	deployment.Status.AvailableReplicas = 1
+	require.NoError(t, r.Status().Update(ctx, deployment))
+	reconcileTestConfig(t, r)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "cluster"}, config))
+	assert.Equal(t, smv1alpha1.PhaseReady, config.Status.Phase)
+	assert.Equal(t, int32(1), config.Status.Plugin.AvailableReplicas)
+
+	// Every pod is gone
+	deployment.Status.AvailableReplicas = 0
+	require.NoError(t, r.Status().Update(ctx, deployment))
+	reconcileTestConfig(t, r)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "cluster"}, config))
+	assert.Equal(t, smv1alpha1.PhaseDegraded, config.Status.Phase)
+	assert.Equal(t, smv1alpha1.ReasonMinimumReplicasUnavailable, config.Status.Reason)
+	assert.False(t, config.Status.Plugin.Ready)
+	assert.Equal(t, "False", findCondition(config, smv1alpha1.ConditionDeploymentAvailable).Status)
+}
+
+func TestRolloutProgress(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 1},
+	}
+	message, done := rolloutProgress(deployment)
+	assert.False(t, done)
+	assert.Equal(t, "Waiting for rollout to finish: 1 old replicas are pending termination", message)
+
+	deployment.Status.Replicas = 2
+	message, done = rolloutProgress(deployment)
+	assert.False(t, done)
+	assert.Equal(t, "Waiting for rollout to finish: 1 of 2 updated replicas are available", message)
+
+	deployment.Status.AvailableReplicas = 2
+	_, done = rolloutProgress(deployment)
+	assert.True(t, done)
 }
 
 func TestReconcile_NotFound(t *testing.T) {
@@ -104,6 +201,84 @@ func TestReconcile_NotFound(t *testing.T) {
 	assert.False(t, result.Requeue)
 }
 
+func TestReconcile_DuplicateConfigIgnored(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("second")
+	config.Finalizers = []string{FinalizerName}
+	r := newTestReconciler(config)
+
+	result, err := r.Reconcile(ctx, ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "second"},
+	})
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	updated := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "second"}, updated))
+	assert.Equal(t, smv1alpha1.PhaseIgnored, updated.Status.Phase)
+	assert.Equal(t, smv1alpha1.ReasonNotSingleton, updated.Status.Reason)
+	assert.NotContains(t, updated.Finalizers, FinalizerName)
+	cond := findCondition(updated, smv1alpha1.ConditionDuplicateConfig)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonNotSingleton, cond.Reason)
+
+	// Nothing was deployed for the duplicate
+	ns := &corev1.Namespace{}
+	err = r.Get(ctx, types.NamespacedName{Name: PluginNamespace}, ns)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcile_CanaryConfig(t *testing.T) {
+	ctx := context.Background()
+	primary := newTestConfig(SingletonConfigName)
+	canary := newTestConfig(CanaryConfigName)
+	canary.Spec.Plugin.Image = "quay.io/example/plugin:next"
+	canary.Spec.Plugin.NamespaceQuota.Enabled = true
+	r := newTestReconciler(primary, canary)
+
+	for _, name := range []string{SingletonConfigName, CanaryConfigName} {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+		require.NoError(t, err)
+	}
+
+	// Both instances run side by side with distinct selectors
+	for name, image := range map[string]string{
+		"ocp-secrets-management-plugin":        "openshift.io/ocp-secrets-management:test",
+		"ocp-secrets-management-canary-plugin": "quay.io/example/plugin:next",
+	} {
+		deployment := &appsv1.Deployment{}
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: name, Namespace: PluginNamespace}, deployment))
+		assert.Equal(t, image, deployment.Spec.Template.Spec.Containers[0].Image)
+	}
+	canaryDeployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-canary-plugin", Namespace: PluginNamespace}, canaryDeployment))
+	assert.Equal(t, "ocp-secrets-management-canary", canaryDeployment.Spec.Selector.MatchLabels["app.kubernetes.io/name"])
+
+	role := &rbacv1.ClusterRole{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-canary-view"}, role))
+
+	// Shared namespace quota is only managed by the primary config
+	quota := &corev1.ResourceQuota{}
+	err := r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-quota", Namespace: PluginNamespace}, quota)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	consolePlugin := &unstructured.Unstructured{}
+	consolePlugin.SetGroupVersionKind(consolePluginGVK)
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-canary"}, consolePlugin))
+
+	updated := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: CanaryConfigName}, updated))
+
+	// Removing the canary leaves the primary instance alone
+	require.NoError(t, r.cleanupPluginDeployment(ctx, updated))
+	require.NoError(t, r.cleanupRBAC(ctx, updated))
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, &appsv1.Deployment{}))
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-view"}, &rbacv1.ClusterRole{}))
+	err = r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-canary-plugin", Namespace: PluginNamespace}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
 func TestReconcileRBAC_CreatesRoles(t *testing.T) {
 	ctx := context.Background()
 	config := newTestConfig("cluster")
@@ -151,6 +326,38 @@ func TestReconcileRBAC_CustomPrefix(t *testing.T) {
 	assert.Equal(t, "custom-prefix-view", viewRole.Name)
 }
 
+func TestReconcileRBAC_IncludeCoreSecrets(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	r := newTestReconciler()
+
+	secretVerbs := func(name string) []string {
+		role := &rbacv1.ClusterRole{}
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: name}, role))
+		for _, rule := range role.Rules {
+			if len(rule.APIGroups) == 1 && rule.APIGroups[0] == "" && len(rule.Resources) == 1 && rule.Resources[0] == "secrets" {
+				return rule.Verbs
+			}
+		}
+		return nil
+	}
+
+	// Secrets are left out by default
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	assert.Nil(t, secretVerbs("secrets-management-view"))
+	assert.Nil(t, secretVerbs("secrets-management-admin"))
+
+	config.Spec.RBAC.IncludeCoreSecrets = true
+	require.NoError(t, r.reconcileRBAC(ctx, config))
+	assert.Equal(t, []string{"get", "list", "watch"}, secretVerbs("secrets-management-view"))
+	assert.Equal(t, []string{"delete"}, secretVerbs("secrets-management-delete"))
+	assert.Equal(t, []string{"get", "list", "watch", "create", "update", "patch", "delete"}, secretVerbs("secrets-management-admin"))
+
+	// The view Roles of visible namespaces include them as well
+	role := buildVisibilityRole(config, "team-a")
+	assert.Contains(t, role.Rules, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list", "watch"}})
+}
+
 func TestReconcileRBAC_Disabled(t *testing.T) {
 	ctx := context.Background()
 	config := newTestConfig("cluster")
@@ -182,6 +389,24 @@ func TestReconcileNamespace(t *testing.T) {
 	assert.Equal(t, PluginName, ns.Labels["app.kubernetes.io/name"])
 }
 
+func TestReconcileNamespace_Restricted(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	r := newTestReconciler(config)
+	r.Restricted = true
+
+	require.NoError(t, r.reconcileNamespace(ctx, config))
+
+	// The namespace is left to the cluster admin
+	ns := &corev1.Namespace{}
+	err := r.Get(ctx, types.NamespacedName{Name: PluginNamespace}, ns)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	cond := findCondition(config, smv1alpha1.ConditionNamespaceReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonNamespaceUnmanaged, cond.Reason)
+}
+
 func TestReconcileServiceAccount(t *testing.T) {
 	ctx := context.Background()
 	config := newTestConfig("cluster")
@@ -206,6 +431,38 @@ func TestReconcileServiceAccount(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestReconcileServiceAccount_Settings(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.ServiceAccount = smv1alpha1.ServiceAccountConfig{
+		Annotations: map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/plugin"},
+	}
+
+	// Existing SA with an annotation added by another controller
+	existing := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ocp-secrets-management-plugin",
+			Namespace:   PluginNamespace,
+			Annotations: map[string]string{"openshift.io/internal-registry-pull-secret-ref": "pull-secret"},
+		},
+	}
+	r := newTestReconciler(existing)
+
+	err := r.reconcileServiceAccount(ctx, config)
+	require.NoError(t, err)
+
+	sa := &corev1.ServiceAccount{}
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      "ocp-secrets-management-plugin",
+		Namespace: PluginNamespace,
+	}, sa)
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/plugin", sa.Annotations["eks.amazonaws.com/role-arn"])
+	assert.Equal(t, "pull-secret", sa.Annotations["openshift.io/internal-registry-pull-secret-ref"])
+	require.NotNil(t, sa.AutomountServiceAccountToken)
+	assert.False(t, *sa.AutomountServiceAccountToken)
+}
+
 func TestReconcileService(t *testing.T) {
 	ctx := context.Background()
 	config := newTestConfig("cluster")
@@ -231,6 +488,54 @@ func TestReconcileService(t *testing.T) {
 	assert.Equal(t, int32(PluginPort), svc.Spec.Ports[0].Port)
 }
 
+func TestReconcileService_CustomPorts(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.Port = 8443
+	config.Spec.Plugin.MetricsPort = 9090
+	r := newTestReconciler()
+
+	err := r.reconcileService(ctx, config)
+	require.NoError(t, err)
+
+	svc := &corev1.Service{}
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      "ocp-secrets-management-plugin",
+		Namespace: PluginNamespace,
+	}, svc)
+	require.NoError(t, err)
+	require.Len(t, svc.Spec.Ports, 2)
+	assert.Equal(t, int32(8443), svc.Spec.Ports[0].Port)
+	assert.Equal(t, "metrics", svc.Spec.Ports[1].Name)
+	assert.Equal(t, int32(9090), svc.Spec.Ports[1].Port)
+
+	// Nginx listens on both ports, metrics without TLS
+	_, err = r.reconcileNginxConfig(ctx, config)
+	require.NoError(t, err)
+	cm := &corev1.ConfigMap{}
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      "ocp-secrets-management-nginx-conf",
+		Namespace: PluginNamespace,
+	}, cm)
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data["nginx.conf"], "listen 8443 ssl")
+	assert.Contains(t, cm.Data["nginx.conf"], "listen 9090;")
+
+	// Deployment exposes both container ports
+	err = r.reconcileDeployment(ctx, config)
+	require.NoError(t, err)
+	deployment := &appsv1.Deployment{}
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      "ocp-secrets-management-plugin",
+		Namespace: PluginNamespace,
+	}, deployment)
+	require.NoError(t, err)
+	ports := deployment.Spec.Template.Spec.Containers[0].Ports
+	require.Len(t, ports, 2)
+	assert.Equal(t, int32(8443), ports[0].ContainerPort)
+	assert.Equal(t, int32(9090), ports[1].ContainerPort)
+}
+
 func TestReconcileNginxConfig(t *testing.T) {
 	ctx := context.Background()
 	config := newTestConfig("cluster")
@@ -243,7 +548,7 @@ func TestReconcileNginxConfig(t *testing.T) {
 	err := r.Create(ctx, ns)
 	require.NoError(t, err)
 
-	err = r.reconcileNginxConfig(ctx, config)
+	_, err = r.reconcileNginxConfig(ctx, config)
 	require.NoError(t, err)
 
 	// Verify configmap was created
@@ -254,6 +559,7 @@ func TestReconcileNginxConfig(t *testing.T) {
 	}, cm)
 	require.NoError(t, err)
 	assert.Contains(t, cm.Data["nginx.conf"], "listen 9443 ssl")
+	assert.NotContains(t, cm.Data["nginx.conf"], "stub_status")
 }
 
 func TestReconcileDeployment(t *testing.T) {
@@ -282,6 +588,279 @@ func TestReconcileDeployment(t *testing.T) {
 	assert.Equal(t, "openshift.io/ocp-secrets-management:test", deployment.Spec.Template.Spec.Containers[0].Image)
 }
 
+func TestReconcile_OwnedObjectsEnqueueConfig(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler(config)
+	reconcileTestConfig(t, r)
+
+	// The handler and predicate Owns registers in SetupWithManager
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(smv1alpha1.GroupVersion.WithKind("SecretsManagementConfig"), meta.RESTScopeRoot)
+	enqueue := handler.EnqueueRequestForOwner(r.Scheme, mapper, &smv1alpha1.SecretsManagementConfig{}, handler.OnlyControllerOwner())
+	owned := ownedObjectChangedPredicate()
+
+	name := fmt.Sprintf("%s-plugin", instanceName(config))
+	for _, obj := range []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: PluginNamespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: PluginNamespace}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: PluginNamespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-nginx-conf", instanceName(config)), Namespace: PluginNamespace}},
+	} {
+		t.Run(fmt.Sprintf("%T", obj), func(t *testing.T) {
+			require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(obj), obj))
+			updated := obj.DeepCopyObject().(client.Object)
+			updated.SetLabels(map[string]string{"edited": "true"})
+			e := event.UpdateEvent{ObjectOld: obj, ObjectNew: updated}
+			require.True(t, owned.Update(e))
+
+			q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer q.ShutDown()
+			enqueue.Update(ctx, e, q)
+			require.Equal(t, 1, q.Len())
+			item, _ := q.Get()
+			assert.Equal(t, reconcile.Request{NamespacedName: types.NamespacedName{Name: SingletonConfigName}}, item)
+		})
+	}
+}
+
+func TestReconcileDeployment_AdoptsExisting(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	// Created by an operator version that did not set owner references
+	existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-plugin", instanceName(config)), Namespace: PluginNamespace}}
+	r := newTestReconciler(config, existing)
+
+	require.NoError(t, r.reconcileDeployment(ctx, config))
+
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(existing), existing))
+	owner := metav1.GetControllerOf(existing)
+	require.NotNil(t, owner)
+	assert.Equal(t, "SecretsManagementConfig", owner.Kind)
+	assert.Equal(t, SingletonConfigName, owner.Name)
+}
+
+func TestReconcileDeployment_ResolvedConfiguration(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.Replicas = 0
+	config.Spec.RBAC.RolePrefix = ""
+	config.Spec.Plugin.Resources.Limits.Memory = "256Mi"
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcileDeployment(ctx, config))
+	assert.Equal(t, smv1alpha1.ResolvedConfigurationStatus{
+		Image:           "openshift.io/ocp-secrets-management:test",
+		ImagePullPolicy: "IfNotPresent",
+		Replicas:        2,
+		Port:            9443,
+		Resources: smv1alpha1.ResourceConfig{
+			Requests: smv1alpha1.ResourceRequirements{CPU: "10m", Memory: "50Mi"},
+			Limits:   smv1alpha1.ResourceRequirements{CPU: "100m", Memory: "256Mi"},
+		},
+		Strategy:   "RollingUpdate",
+		RolePrefix: "secrets-management",
+	}, config.Status.ResolvedConfiguration)
+}
+
+func TestReconcileDeployment_RequestsExceedLimits(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	r := newTestReconciler()
+
+	// A request above the default limit is reported against the spec
+	config.Spec.Plugin.Resources.Requests.CPU = "200m"
+	err := r.reconcileDeployment(ctx, config)
+	require.Error(t, err)
+	assert.Equal(t, "spec.plugin.resources: cpu request 200m exceeds the limit 100m", err.Error())
+
+	config.Spec.Plugin.Resources.Limits.CPU = "200m"
+	require.NoError(t, r.reconcileDeployment(ctx, config))
+}
+
+func TestReconcileDeployment_TrustedCABundle(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.InjectTrustedCABundle = true
+	r := newTestReconciler()
+
+	// Create namespace first
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: PluginNamespace},
+	}
+	err := r.Create(ctx, ns)
+	require.NoError(t, err)
+
+	err = r.reconcileDeployment(ctx, config)
+	require.NoError(t, err)
+
+	// Verify the injection ConfigMap was created with the inject label and no data
+	cm := &corev1.ConfigMap{}
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      "ocp-secrets-management-trusted-ca-bundle",
+		Namespace: PluginNamespace,
+	}, cm)
+	require.NoError(t, err)
+	assert.Equal(t, "true", cm.Labels[TrustedCABundleInjectLabel])
+	assert.Empty(t, cm.Data)
+
+	// Verify the bundle is mounted into the plugin container
+	deployment := &appsv1.Deployment{}
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      "ocp-secrets-management-plugin",
+		Namespace: PluginNamespace,
+	}, deployment)
+	require.NoError(t, err)
+	assert.Len(t, deployment.Spec.Template.Spec.Volumes, 4)
+	mounts := deployment.Spec.Template.Spec.Containers[0].VolumeMounts
+	assert.Equal(t, TrustedCABundleMountPath, mounts[len(mounts)-1].MountPath)
+	assert.Empty(t, deployment.Spec.Template.Annotations[TrustedCABundleHashAnnotation])
+
+	// Simulate injection; the next reconcile must keep the data and roll the pods
+	cm.Data = map[string]string{TrustedCABundleKey: "-----BEGIN CERTIFICATE-----"}
+	err = r.Update(ctx, cm)
+	require.NoError(t, err)
+
+	err = r.reconcileDeployment(ctx, config)
+	require.NoError(t, err)
+
+	err = r.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: PluginNamespace}, cm)
+	require.NoError(t, err)
+	assert.Equal(t, "-----BEGIN CERTIFICATE-----", cm.Data[TrustedCABundleKey])
+
+	err = r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: PluginNamespace}, deployment)
+	require.NoError(t, err)
+	assert.NotEmpty(t, deployment.Spec.Template.Annotations[TrustedCABundleHashAnnotation])
+
+	// Disabling injection removes the ConfigMap
+	config.Spec.Plugin.InjectTrustedCABundle = false
+	err = r.reconcileDeployment(ctx, config)
+	require.NoError(t, err)
+	err = r.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: PluginNamespace}, cm)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileDeployment_ExtraVolumes(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.ExtraVolumes = []corev1.Volume{
+		{
+			Name: "branding",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "branding-assets"},
+				},
+			},
+		},
+	}
+	config.Spec.Plugin.ExtraVolumeMounts = []corev1.VolumeMount{
+		{Name: "branding", MountPath: "/usr/share/nginx/html/branding", ReadOnly: true},
+	}
+	r := newTestReconciler()
+
+	// Create namespace first
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: PluginNamespace},
+	}
+	err := r.Create(ctx, ns)
+	require.NoError(t, err)
+
+	// Reconcile twice to make sure the extra volumes survive updates
+	err = r.reconcileDeployment(ctx, config)
+	require.NoError(t, err)
+	err = r.reconcileDeployment(ctx, config)
+	require.NoError(t, err)
+
+	deployment := &appsv1.Deployment{}
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      "ocp-secrets-management-plugin",
+		Namespace: PluginNamespace,
+	}, deployment)
+	require.NoError(t, err)
+	assert.Len(t, deployment.Spec.Template.Spec.Volumes, 4)
+	assert.Equal(t, "branding", deployment.Spec.Template.Spec.Volumes[3].Name)
+	assert.Len(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, 4)
+	assert.Equal(t, "/usr/share/nginx/html/branding", deployment.Spec.Template.Spec.Containers[0].VolumeMounts[3].MountPath)
+
+	// Reserved names are rejected
+	config.Spec.Plugin.ExtraVolumes[0].Name = "plugin-cert"
+	err = r.reconcileDeployment(ctx, config)
+	assert.Error(t, err)
+}
+
+func TestReconcileDeployment_ServiceAccountToken(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	config.Spec.Plugin.ServiceAccount.TokenAudiences = []string{"sts.amazonaws.com"}
+	r := newTestReconciler()
+
+	// Create namespace first
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: PluginNamespace},
+	}
+	err := r.Create(ctx, ns)
+	require.NoError(t, err)
+
+	err = r.reconcileDeployment(ctx, config)
+	require.NoError(t, err)
+
+	deployment := &appsv1.Deployment{}
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      "ocp-secrets-management-plugin",
+		Namespace: PluginNamespace,
+	}, deployment)
+	require.NoError(t, err)
+
+	podSpec := deployment.Spec.Template.Spec
+	require.NotNil(t, podSpec.AutomountServiceAccountToken)
+	assert.False(t, *podSpec.AutomountServiceAccountToken)
+
+	// Verify the bound token is projected for the audience
+	volume := podSpec.Volumes[len(podSpec.Volumes)-1]
+	assert.Equal(t, "bound-sa-token", volume.Name)
+	require.NotNil(t, volume.Projected)
+	token := volume.Projected.Sources[0].ServiceAccountToken
+	assert.Equal(t, "sts.amazonaws.com", token.Audience)
+	assert.Equal(t, "sts.amazonaws.com-token", token.Path)
+}
+
+func TestTokenPathForAudience(t *testing.T) {
+	assert.Equal(t, "sts.amazonaws.com-token", tokenPathForAudience("sts.amazonaws.com"))
+	assert.Equal(t, "api---ExchangeToken-token", tokenPathForAudience("api://ExchangeToken"))
+	assert.Equal(t, "vault-token", tokenPathForAudience("..vault"))
+}
+
+func TestBuildDeploymentStrategy(t *testing.T) {
+	// Single replica without settings never drops capacity
+	strategy, err := buildDeploymentStrategy(smv1alpha1.DeploymentStrategyConfig{}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, appsv1.RollingUpdateDeploymentStrategyType, strategy.Type)
+	assert.Equal(t, intstr.FromInt(0), *strategy.RollingUpdate.MaxUnavailable)
+	assert.Equal(t, intstr.FromInt(1), *strategy.RollingUpdate.MaxSurge)
+
+	// Multiple replicas keep the API defaults unless set
+	strategy, err = buildDeploymentStrategy(smv1alpha1.DeploymentStrategyConfig{}, 2)
+	require.NoError(t, err)
+	assert.Nil(t, strategy.RollingUpdate.MaxUnavailable)
+	assert.Nil(t, strategy.RollingUpdate.MaxSurge)
+
+	// Explicit rolling update settings are passed through
+	maxSurge := intstr.FromString("50%")
+	strategy, err = buildDeploymentStrategy(smv1alpha1.DeploymentStrategyConfig{Type: "RollingUpdate", MaxSurge: &maxSurge}, 4)
+	require.NoError(t, err)
+	assert.Equal(t, maxSurge, *strategy.RollingUpdate.MaxSurge)
+
+	// Recreate
+	strategy, err = buildDeploymentStrategy(smv1alpha1.DeploymentStrategyConfig{Type: "Recreate"}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, strategy.Type)
+	assert.Nil(t, strategy.RollingUpdate)
+
+	// Recreate does not accept rolling update settings
+	_, err = buildDeploymentStrategy(smv1alpha1.DeploymentStrategyConfig{Type: "Recreate", MaxSurge: &maxSurge}, 2)
+	assert.Error(t, err)
+}
+
 func TestDetectOperators_NoneInstalled(t *testing.T) {
 	ctx := context.Background()
 	config := newTestConfig("cluster")
@@ -368,7 +947,7 @@ func TestCleanupPluginDeployment(t *testing.T) {
 	require.NoError(t, err)
 	err = r.reconcileService(ctx, config)
 	require.NoError(t, err)
-	err = r.reconcileNginxConfig(ctx, config)
+	_, err = r.reconcileNginxConfig(ctx, config)
 	require.NoError(t, err)
 	err = r.reconcileDeployment(ctx, config)
 	require.NoError(t, err)
@@ -457,6 +1036,148 @@ func TestSetCondition(t *testing.T) {
 	// Add different condition
 	r.setCondition(config, smv1alpha1.ConditionPluginDeployed, "True", "Ready", "Plugin ready")
 	assert.Len(t, config.Status.Conditions, 2)
+
+	// Same status refreshes reason but keeps the transition time
+	transitioned := metav1.NewTime(time.Now().Add(-time.Hour))
+	config.Status.Conditions[0].LastTransitionTime = transitioned
+	r.setCondition(config, smv1alpha1.ConditionRBACConfigured, "False", "OtherReason", "Still failing")
+	assert.Equal(t, "OtherReason", config.Status.Conditions[0].Reason)
+	assert.Equal(t, transitioned, config.Status.Conditions[0].LastTransitionTime)
+}
+
+func findCondition(config *smv1alpha1.SecretsManagementConfig, condType smv1alpha1.ConditionType) *smv1alpha1.Condition {
+	return smtesting.FindCondition(config, condType)
+}
+
+func TestComponentConditions(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	r := newTestReconciler(config)
+
+	require.NoError(t, r.reconcileNamespace(ctx, config))
+	require.NoError(t, r.reconcilePluginDeployment(ctx, config))
+	require.NoError(t, r.reconcileConsolePlugin(ctx, config))
+
+	expected := map[smv1alpha1.ConditionType][2]string{
+		smv1alpha1.ConditionNamespaceReady:          {"True", smv1alpha1.ReasonNamespaceActive},
+		smv1alpha1.ConditionServiceReady:            {"True", smv1alpha1.ReasonServiceConfigured},
+		smv1alpha1.ConditionCertSecretPresent:       {"False", smv1alpha1.ReasonCertSecretNotFound},
+		smv1alpha1.ConditionWaitingForServingCert:   {"True", smv1alpha1.ReasonCertSecretNotFound},
+		smv1alpha1.ConditionDeploymentAvailable:     {"False", smv1alpha1.ReasonMinimumReplicasUnavailable},
+		smv1alpha1.ConditionConsolePluginRegistered: {"True", smv1alpha1.ReasonConsolePluginRegistered},
+	}
+	for condType, want := range expected {
+		cond := findCondition(config, condType)
+		require.NotNil(t, cond, "condition %s not set", condType)
+		assert.Equal(t, want[0], cond.Status, "condition %s", condType)
+		assert.Equal(t, want[1], cond.Reason, "condition %s", condType)
+	}
+
+	// Service CA issues the certificate
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ocp-secrets-management-plugin-cert", Namespace: PluginNamespace}}
+	require.NoError(t, r.Create(ctx, secret))
+	require.NoError(t, r.reconcileCertSecretCondition(ctx, config))
+	cond := findCondition(config, smv1alpha1.ConditionCertSecretPresent)
+	assert.Equal(t, "True", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonCertSecretFound, cond.Reason)
+}
+
+func TestReconcileNamespace_Terminating(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: PluginNamespace},
+		Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+	}
+	r := newTestReconciler(config, ns)
+
+	require.NoError(t, r.reconcileNamespace(ctx, config))
+	cond := findCondition(config, smv1alpha1.ConditionNamespaceReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonNamespaceTerminating, cond.Reason)
+}
+
+func TestSetPhase_History(t *testing.T) {
+	config := newTestConfig("cluster")
+
+	setPhase(config, smv1alpha1.PhaseDeploying, "deploying")
+	setPhase(config, smv1alpha1.PhaseDeploying, "deploying again")
+	require.Len(t, config.Status.History, 1, "unchanged phase must not add history")
+	assert.Equal(t, smv1alpha1.PhaseDeploying, config.Status.Phase)
+
+	for i := 0; i < MaxPhaseHistory; i++ {
+		setPhase(config, smv1alpha1.PhaseError, fmt.Sprintf("error %d", i))
+		setPhase(config, smv1alpha1.PhaseReady, "ready")
+	}
+	require.Len(t, config.Status.History, MaxPhaseHistory)
+	last := config.Status.History[MaxPhaseHistory-1]
+	assert.Equal(t, smv1alpha1.PhaseReady, last.Phase)
+	assert.Equal(t, fmt.Sprintf("error %d", MaxPhaseHistory-1), config.Status.History[MaxPhaseHistory-2].Message)
+}
+
+func TestRecordReconcile(t *testing.T) {
+	config := newTestConfig("cluster")
+	start := time.Now().Add(-2 * time.Second)
+
+	recordReconcile(config, start, fmt.Errorf("boom"))
+	require.NotNil(t, config.Status.LastReconcileTime)
+	require.NotNil(t, config.Status.LastReconcileDuration)
+	assert.True(t, config.Status.LastReconcileTime.Time.Equal(start))
+	assert.GreaterOrEqual(t, config.Status.LastReconcileDuration.Duration, 2*time.Second)
+	assert.Equal(t, "boom", config.Status.LastError)
+
+	recordReconcile(config, time.Now(), nil)
+	assert.Empty(t, config.Status.LastError)
+}
+
+func TestPatchStatus_PreservesConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	r := newTestReconciler(config)
+
+	stale := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "cluster"}, stale))
+
+	// Another writer updates status after our read
+	other := stale.DeepCopy()
+	other.Status.DetectedOperators.CertManager.Installed = true
+	require.NoError(t, r.Status().Update(ctx, other))
+
+	original := stale.DeepCopy()
+	setPhase(stale, smv1alpha1.PhaseReady, "ready")
+	require.NoError(t, r.patchStatus(ctx, original, stale))
+
+	updated := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "cluster"}, updated))
+	assert.Equal(t, smv1alpha1.PhaseReady, updated.Status.Phase)
+	assert.True(t, updated.Status.DetectedOperators.CertManager.Installed, "concurrent status write must be kept")
+}
+
+func TestPatchStatus_Deleted(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig("cluster")
+	r := newTestReconciler()
+
+	original := config.DeepCopy()
+	setPhase(config, smv1alpha1.PhaseReady, "ready")
+	assert.NoError(t, r.patchStatus(ctx, original, config))
+}
+
+func TestRequeueInterval(t *testing.T) {
+	config := newTestConfig("cluster")
+	inRange := func(d, base time.Duration) bool {
+		return d >= base && d <= base+time.Duration(float64(base)*ReconcileJitterFactor)
+	}
+
+	r := &SecretsManagementConfigReconciler{}
+	assert.True(t, inRange(r.requeueInterval(config), DefaultReconcileInterval))
+
+	r.ReconcileInterval = time.Minute
+	assert.True(t, inRange(r.requeueInterval(config), time.Minute))
+
+	config.Spec.ReconcileInterval = &metav1.Duration{Duration: 30 * time.Minute}
+	assert.True(t, inRange(r.requeueInterval(config), 30*time.Minute))
 }
 
 func TestReconcile_FullCycle(t *testing.T) {
@@ -465,10 +1186,11 @@ func TestReconcile_FullCycle(t *testing.T) {
 	r := newTestReconciler(config)
 
 	// First reconcile - adds finalizer
-	_, err := r.Reconcile(ctx, ctrl.Request{
+	result, err := r.Reconcile(ctx, ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cluster"},
 	})
 	require.NoError(t, err)
+	assert.GreaterOrEqual(t, result.RequeueAfter, DefaultReconcileInterval)
 
 	// Verify finalizer was added and status is updated
 	updatedConfig := &smv1alpha1.SecretsManagementConfig{}
@@ -509,4 +1231,9 @@ func TestHelperFunctions(t *testing.T) {
 	i := int32Ptr(42)
 	assert.NotNil(t, i)
 	assert.Equal(t, int32(42), *i)
+
+	// Test int64Ptr
+	l := int64Ptr(3600)
+	assert.NotNil(t, l)
+	assert.Equal(t, int64(3600), *l)
 }
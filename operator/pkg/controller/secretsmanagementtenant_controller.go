@@ -0,0 +1,474 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// TenantLabel is set by cluster admins on a Namespace to the namespace of the
+	// SecretsManagementTenant allowed to claim it
+	TenantLabel = "secrets-management.openshift.io/tenant"
+
+	// TenantNameLabel and TenantNamespaceLabel are set on the Roles, RoleBindings and
+	// ResourceQuotas created for a tenant to its name and namespace
+	TenantNameLabel      = "secrets-management.openshift.io/tenant-name"
+	TenantNamespaceLabel = "secrets-management.openshift.io/tenant-namespace"
+
+	// TenantFinalizerName lets the operator remove a tenant's Roles, RoleBindings and
+	// ResourceQuotas in other namespaces, which owner references cannot reach
+	TenantFinalizerName = "secrets-management.openshift.io/tenant"
+
+	// tenantResyncInterval is how often tenants are reconciled to pick up Namespace label changes
+	// and quota usage, since Namespaces and ResourceQuotas are not watched
+	tenantResyncInterval = 10 * time.Minute
+)
+
+// externalSecretsQuotaResource and certificatesQuotaResource are the object count quota resources
+// limiting ExternalSecrets and Certificates
+const (
+	externalSecretsQuotaResource corev1.ResourceName = "count/externalsecrets.external-secrets.io"
+	certificatesQuotaResource    corev1.ResourceName = "count/certificates.cert-manager.io"
+)
+
+// tenantRestrictedMessage is reported by every tenant in restricted mode
+const tenantRestrictedMessage = "Tenants are not supported in restricted mode: the operator cannot manage Roles outside its namespace"
+
+// SecretsManagementTenantReconciler creates the Roles, RoleBindings and ResourceQuotas requested
+// by SecretsManagementTenants in their accepted namespaces. The namespaces are added to the plugin
+// visibility by the SecretsManagementConfig controller.
+type SecretsManagementTenantReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// APIReader reads Namespaces and ResourceQuotas, which are not cached outside the plugin
+	// namespace; the cached client is used when nil
+	APIReader client.Reader
+
+	// Restricted is set when the operator only watches its own namespace
+	Restricted bool
+}
+
+// +kubebuilder:rbac:groups=secrets-management.openshift.io,resources=secretsmanagementtenants,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=secrets-management.openshift.io,resources=secretsmanagementtenants/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets-management.openshift.io,resources=secretsmanagementtenants/finalizers,verbs=update
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=bind
+
+// Reconcile keeps a tenant's Roles and RoleBindings in line with its spec
+func (r *SecretsManagementTenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reterr error) {
+	log := r.Log.WithValues("secretsmanagementtenant", req.NamespacedName)
+
+	tenant := &smv1alpha1.SecretsManagementTenant{}
+	if err := r.Get(ctx, req.NamespacedName, tenant); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !tenant.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(tenant, TenantFinalizerName) {
+			return ctrl.Result{}, nil
+		}
+		if err := r.pruneTenantRBAC(ctx, tenant, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.pruneTenantQuotas(ctx, tenant, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+		controllerutil.RemoveFinalizer(tenant, TenantFinalizerName)
+		return ctrl.Result{}, r.Update(ctx, tenant)
+	}
+
+	original := tenant.DeepCopy()
+	defer func() {
+		if err := r.patchStatus(ctx, original, tenant); err != nil {
+			log.Error(err, "Failed to patch status")
+			if reterr == nil {
+				reterr = err
+			}
+		}
+	}()
+	tenant.Status.ObservedGeneration = tenant.Generation
+
+	if r.Restricted {
+		tenant.Status = smv1alpha1.SecretsManagementTenantStatus{ObservedGeneration: tenant.Generation, Message: tenantRestrictedMessage}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(tenant, TenantFinalizerName) {
+		controllerutil.AddFinalizer(tenant, TenantFinalizerName)
+		if err := r.Update(ctx, tenant); err != nil {
+			return ctrl.Result{}, err
+		}
+		original.ObjectMeta = tenant.ObjectMeta
+	}
+
+	accepted, rejected, err := r.tenantNamespaces(ctx, tenant)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	config, err := r.primaryConfig(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	subjects := tenantSubjects(tenant)
+	desired := map[types.NamespacedName]bool{}
+	desiredQuotas := map[types.NamespacedName]bool{}
+	var usage []smv1alpha1.TenantQuotaUsage
+	for _, namespace := range accepted {
+		if quota := buildTenantQuota(config, tenant, namespace); quota != nil {
+			used, err := r.applyTenantQuota(ctx, quota)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			desiredQuotas[client.ObjectKeyFromObject(quota)] = true
+			usage = append(usage, used)
+		}
+		for _, level := range []smv1alpha1.AccessLevel{smv1alpha1.AccessLevelView, smv1alpha1.AccessLevelDelete, smv1alpha1.AccessLevelAdmin} {
+			if len(subjects[level]) == 0 {
+				continue
+			}
+			role := buildTenantRole(config, tenant, namespace, level)
+			if err := r.applyTenantRole(ctx, role); err != nil {
+				return ctrl.Result{}, err
+			}
+			binding := &rbacv1.RoleBinding{
+				ObjectMeta: tenantObjectMeta(config, tenant, namespace, role.Name),
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: role.Name},
+				Subjects:   subjects[level],
+			}
+			if err := r.applyTenantRoleBinding(ctx, binding); err != nil {
+				return ctrl.Result{}, err
+			}
+			desired[client.ObjectKeyFromObject(role)] = true
+		}
+	}
+	if err := r.pruneTenantRBAC(ctx, tenant, desired); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.pruneTenantQuotas(ctx, tenant, desiredQuotas); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	tenant.Status.Namespaces = accepted
+	tenant.Status.RejectedNamespaces = rejected
+	tenant.Status.RoleBindings = int32(len(desired))
+	tenant.Status.QuotaUsage = usage
+	tenant.Status.Message = fmt.Sprintf("Bound %d RoleBinding(s) in %d namespace(s)", len(desired), len(accepted))
+	if len(rejected) > 0 {
+		tenant.Status.Message += fmt.Sprintf("; namespaces not labelled %s=%s: %s", TenantLabel, tenant.Namespace, strings.Join(rejected, ", "))
+	}
+	return ctrl.Result{RequeueAfter: tenantResyncInterval}, nil
+}
+
+// tenantNamespaces returns the sorted namespaces of a tenant that are accepted and those that are
+// rejected. The tenant's own namespace is always accepted; another one only when it exists and
+// carries TenantLabel set to the tenant's namespace.
+func (r *SecretsManagementTenantReconciler) tenantNamespaces(ctx context.Context, tenant *smv1alpha1.SecretsManagementTenant) ([]string, []string, error) {
+	accepted := []string{tenant.Namespace}
+	var rejected []string
+	seen := map[string]bool{tenant.Namespace: true}
+	for _, name := range tenant.Spec.Namespaces {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		ns := &corev1.Namespace{}
+		err := r.apiReader().Get(ctx, types.NamespacedName{Name: name}, ns)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, nil, err
+		}
+		if err == nil && ns.Labels[TenantLabel] == tenant.Namespace {
+			accepted = append(accepted, name)
+		} else {
+			rejected = append(rejected, name)
+		}
+	}
+	sort.Strings(accepted)
+	sort.Strings(rejected)
+	return accepted, rejected, nil
+}
+
+// primaryConfig returns the primary SecretsManagementConfig, or an empty one carrying its name
+// when it does not exist, so the default role prefix applies
+func (r *SecretsManagementTenantReconciler) primaryConfig(ctx context.Context) (*smv1alpha1.SecretsManagementConfig, error) {
+	config := &smv1alpha1.SecretsManagementConfig{}
+	err := r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if errors.IsNotFound(err) {
+		config = &smv1alpha1.SecretsManagementConfig{ObjectMeta: metav1.ObjectMeta{Name: SingletonConfigName}}
+	}
+	return config, nil
+}
+
+// tenantSubjects returns the users and groups of a tenant's role bindings by access level
+func tenantSubjects(tenant *smv1alpha1.SecretsManagementTenant) map[smv1alpha1.AccessLevel][]rbacv1.Subject {
+	subjects := map[smv1alpha1.AccessLevel][]rbacv1.Subject{}
+	for _, binding := range tenant.Spec.RoleBindings {
+		for _, user := range binding.Users {
+			subjects[binding.AccessLevel] = append(subjects[binding.AccessLevel], rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: user})
+		}
+		for _, group := range binding.Groups {
+			subjects[binding.AccessLevel] = append(subjects[binding.AccessLevel], rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: group})
+		}
+	}
+	return subjects
+}
+
+// buildTenantRole returns the Role granting level on the namespaced resources of the operators the
+// tenant selected. Like the default ClusterRoles, it follows spec.rbac.includeCoreSecrets and
+// spec.features.readOnly of the primary config. The tenant's RoleBinding shares its name.
+func buildTenantRole(config *smv1alpha1.SecretsManagementConfig, tenant *smv1alpha1.SecretsManagementTenant, namespace string, level smv1alpha1.AccessLevel) *rbacv1.Role {
+	operators := tenant.Spec.Operators
+	if operators == (smv1alpha1.TenantOperators{}) {
+		operators = smv1alpha1.TenantOperators{CertManager: true, ExternalSecrets: true, SecretsStoreCSI: true}
+	}
+
+	verbs := []string{"get", "list", "watch"}
+	secretVerbs := coreSecretsViewVerbs
+	switch level {
+	case smv1alpha1.AccessLevelDelete:
+		verbs = []string{"delete"}
+		secretVerbs = []string{"delete"}
+	case smv1alpha1.AccessLevelAdmin:
+		verbs = []string{"*"}
+		secretVerbs = coreSecretsAdminVerbs
+	}
+
+	var rules []rbacv1.PolicyRule
+	if operators.CertManager {
+		rules = append(rules, rbacv1.PolicyRule{APIGroups: []string{"cert-manager.io"}, Resources: []string{"certificates", "issuers"}, Verbs: verbs})
+	}
+	if operators.ExternalSecrets {
+		rules = append(rules, rbacv1.PolicyRule{APIGroups: []string{"external-secrets.io"}, Resources: []string{"externalsecrets", "secretstores", "pushsecrets"}, Verbs: verbs})
+	}
+	if operators.SecretsStoreCSI {
+		resources := []string{"secretproviderclasses", "secretproviderclasspodstatuses"}
+		if level == smv1alpha1.AccessLevelDelete {
+			resources = []string{"secretproviderclasses"}
+		}
+		rules = append(rules, rbacv1.PolicyRule{APIGroups: []string{"secrets-store.csi.x-k8s.io"}, Resources: resources, Verbs: verbs})
+	}
+	rules = withCoreSecrets(config, rules, secretVerbs...)
+	if level == smv1alpha1.AccessLevelAdmin && config.Spec.Features.ReadOnly.Enabled {
+		rules = readOnlyRules(rules)
+	}
+	name := fmt.Sprintf("%s-tenant-%s-%s", rolePrefix(config), tenant.Name, level)
+	return &rbacv1.Role{ObjectMeta: tenantObjectMeta(config, tenant, namespace, name), Rules: rules}
+}
+
+// tenantObjectMeta returns the metadata of a Role or RoleBinding created for a tenant
+func tenantObjectMeta(config *smv1alpha1.SecretsManagementConfig, tenant *smv1alpha1.SecretsManagementTenant, namespace, name string) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels: map[string]string{
+			"app.kubernetes.io/managed-by": "secrets-management-operator",
+			"app.kubernetes.io/part-of":    "ocp-secrets-management",
+			TenantNameLabel:                tenant.Name,
+			TenantNamespaceLabel:           tenant.Namespace,
+		},
+	}
+	applyCommonMetadata(config, &meta)
+	return meta
+}
+
+// applyTenantRole creates role or updates its rules
+func (r *SecretsManagementTenantReconciler) applyTenantRole(ctx context.Context, role *rbacv1.Role) error {
+	existing := &rbacv1.Role{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(role), existing)
+	if errors.IsNotFound(err) {
+		return r.Create(ctx, role)
+	}
+	if err != nil {
+		return err
+	}
+	before := existing.DeepCopy()
+	existing.Rules = role.Rules
+	mergeMetadata(existing, role)
+	return updateIfChanged(ctx, r, before, existing)
+}
+
+// applyTenantRoleBinding creates binding or updates its subjects
+func (r *SecretsManagementTenantReconciler) applyTenantRoleBinding(ctx context.Context, binding *rbacv1.RoleBinding) error {
+	existing := &rbacv1.RoleBinding{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(binding), existing)
+	if errors.IsNotFound(err) {
+		return r.Create(ctx, binding)
+	}
+	if err != nil {
+		return err
+	}
+	before := existing.DeepCopy()
+	existing.Subjects = binding.Subjects
+	mergeMetadata(existing, binding)
+	return updateIfChanged(ctx, r, before, existing)
+}
+
+// pruneTenantRBAC deletes the Roles and RoleBindings created for a tenant that are not in desired.
+// Roles share the name of their binding.
+func (r *SecretsManagementTenantReconciler) pruneTenantRBAC(ctx context.Context, tenant *smv1alpha1.SecretsManagementTenant, desired map[types.NamespacedName]bool) error {
+	selector := client.MatchingLabels{TenantNameLabel: tenant.Name, TenantNamespaceLabel: tenant.Namespace}
+	roles := &rbacv1.RoleList{}
+	if err := r.List(ctx, roles, selector); err != nil {
+		return err
+	}
+	bindings := &rbacv1.RoleBindingList{}
+	if err := r.List(ctx, bindings, selector); err != nil {
+		return err
+	}
+	var stale []client.Object
+	for i := range bindings.Items {
+		stale = append(stale, &bindings.Items[i])
+	}
+	for i := range roles.Items {
+		stale = append(stale, &roles.Items[i])
+	}
+	for _, obj := range stale {
+		if desired[client.ObjectKeyFromObject(obj)] {
+			continue
+		}
+		if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildTenantQuota returns the ResourceQuota enforcing spec.quota in namespace, or nil when the
+// tenant sets no limit. Object count quotas are enforced by the API server's quota admission, so
+// creating a resource over the limit is rejected.
+func buildTenantQuota(config *smv1alpha1.SecretsManagementConfig, tenant *smv1alpha1.SecretsManagementTenant, namespace string) *corev1.ResourceQuota {
+	hard := corev1.ResourceList{}
+	if limit := tenant.Spec.Quota.MaxExternalSecrets; limit != nil {
+		hard[externalSecretsQuotaResource] = *resource.NewQuantity(int64(*limit), resource.DecimalSI)
+	}
+	if limit := tenant.Spec.Quota.MaxCertificates; limit != nil {
+		hard[certificatesQuotaResource] = *resource.NewQuantity(int64(*limit), resource.DecimalSI)
+	}
+	if len(hard) == 0 {
+		return nil
+	}
+	name := fmt.Sprintf("%s-tenant-%s", rolePrefix(config), tenant.Name)
+	return &corev1.ResourceQuota{
+		ObjectMeta: tenantObjectMeta(config, tenant, namespace, name),
+		Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+	}
+}
+
+// applyTenantQuota creates quota or updates its limits, and returns the usage the API server last
+// recorded in its status. A new quota reports no usage until the quota controller counts it.
+func (r *SecretsManagementTenantReconciler) applyTenantQuota(ctx context.Context, quota *corev1.ResourceQuota) (smv1alpha1.TenantQuotaUsage, error) {
+	usage := smv1alpha1.TenantQuotaUsage{Namespace: quota.Namespace}
+
+	// ResourceQuotas outside the plugin namespace are not cached
+	existing := &corev1.ResourceQuota{}
+	err := r.apiReader().Get(ctx, client.ObjectKeyFromObject(quota), existing)
+	if errors.IsNotFound(err) {
+		return usage, r.Create(ctx, quota)
+	}
+	if err != nil {
+		return usage, err
+	}
+	before := existing.DeepCopy()
+	existing.Spec = quota.Spec
+	mergeMetadata(existing, quota)
+	if err := updateIfChanged(ctx, r, before, existing); err != nil {
+		return usage, err
+	}
+
+	if used, ok := existing.Status.Used[externalSecretsQuotaResource]; ok {
+		usage.ExternalSecrets = int32(used.Value())
+	}
+	if used, ok := existing.Status.Used[certificatesQuotaResource]; ok {
+		usage.Certificates = int32(used.Value())
+	}
+	return usage, nil
+}
+
+// pruneTenantQuotas deletes the ResourceQuotas created for a tenant that are not in desired
+func (r *SecretsManagementTenantReconciler) pruneTenantQuotas(ctx context.Context, tenant *smv1alpha1.SecretsManagementTenant, desired map[types.NamespacedName]bool) error {
+	quotas := &corev1.ResourceQuotaList{}
+	if err := r.apiReader().List(ctx, quotas, client.MatchingLabels{TenantNameLabel: tenant.Name, TenantNamespaceLabel: tenant.Namespace}); err != nil {
+		return err
+	}
+	for i := range quotas.Items {
+		quota := &quotas.Items[i]
+		if desired[client.ObjectKeyFromObject(quota)] {
+			continue
+		}
+		if err := r.Delete(ctx, quota); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// patchStatus writes the status changes between original and tenant as a merge patch, retrying on conflict
+func (r *SecretsManagementTenantReconciler) patchStatus(ctx context.Context, original, tenant *smv1alpha1.SecretsManagementTenant) error {
+	if equality.Semantic.DeepEqual(original.Status, tenant.Status) {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Status().Patch(ctx, tenant, client.MergeFrom(original))
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+// apiReader returns the uncached reader, falling back to the cached client
+func (r *SecretsManagementTenantReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// tenantForObject maps a Role or RoleBinding created for a tenant back to the tenant, so changes
+// made on the cluster are reverted
+func tenantForObject(_ context.Context, obj client.Object) []reconcile.Request {
+	name, namespace := obj.GetLabels()[TenantNameLabel], obj.GetLabels()[TenantNamespaceLabel]
+	if name == "" || namespace == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *SecretsManagementTenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&smv1alpha1.SecretsManagementTenant{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&rbacv1.Role{}, handler.EnqueueRequestsFromMapFunc(tenantForObject)).
+		Watches(&rbacv1.RoleBinding{}, handler.EnqueueRequestsFromMapFunc(tenantForObject)).
+		Complete(r)
+}
@@ -0,0 +1,195 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestTenantReconciler(objs ...client.Object) *SecretsManagementTenantReconciler {
+	scheme := newTestScheme()
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&smv1alpha1.SecretsManagementTenant{}).
+		Build()
+
+	return &SecretsManagementTenantReconciler{
+		Client: fakeClient,
+		Log:    logr.Discard(),
+		Scheme: scheme,
+	}
+}
+
+func newTestTenant(namespace, name string, namespaces ...string) *smv1alpha1.SecretsManagementTenant {
+	return &smv1alpha1.SecretsManagementTenant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: smv1alpha1.SecretsManagementTenantSpec{
+			Namespaces: namespaces,
+			Operators:  smv1alpha1.TenantOperators{CertManager: true},
+			RoleBindings: []smv1alpha1.TenantRoleBinding{
+				{AccessLevel: smv1alpha1.AccessLevelView, Groups: []string{"payments-devs"}},
+				{AccessLevel: smv1alpha1.AccessLevelAdmin, Users: []string{"alice"}},
+			},
+		},
+	}
+}
+
+func newTestTenantNamespace(name, tenantNamespace string) *corev1.Namespace {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if tenantNamespace != "" {
+		ns.Labels = map[string]string{TenantLabel: tenantNamespace}
+	}
+	return ns
+}
+
+func reconcileTenant(t *testing.T, r *SecretsManagementTenantReconciler, namespace, name string) *smv1alpha1.SecretsManagementTenant {
+	t.Helper()
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}})
+	require.NoError(t, err)
+
+	tenant := &smv1alpha1.SecretsManagementTenant{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, tenant))
+	return tenant
+}
+
+func TestTenantReconcile_BindsAcceptedNamespaces(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Features.ReadOnly.Enabled = true
+	r := newTestTenantReconciler(
+		config,
+		newTestTenant("payments", "team", "payments-prod", "billing", "missing"),
+		newTestTenantNamespace("payments-prod", "payments"),
+		// Labelled for another team
+		newTestTenantNamespace("billing", "billing"),
+	)
+
+	tenant := reconcileTenant(t, r, "payments", "team")
+
+	assert.Contains(t, tenant.Finalizers, TenantFinalizerName)
+	assert.Equal(t, []string{"payments", "payments-prod"}, tenant.Status.Namespaces)
+	assert.Equal(t, []string{"billing", "missing"}, tenant.Status.RejectedNamespaces)
+	assert.Equal(t, int32(4), tenant.Status.RoleBindings)
+	assert.Contains(t, tenant.Status.Message, "not labelled secrets-management.openshift.io/tenant=payments: billing, missing")
+
+	binding := &rbacv1.RoleBinding{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "payments-prod", Name: "secrets-management-tenant-team-view"}, binding))
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "secrets-management-tenant-team-view"}, binding.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "payments-devs"}}, binding.Subjects)
+	assert.Equal(t, "payments", binding.Labels[TenantNamespaceLabel])
+
+	// Only the selected operator's namespaced resources are granted, read-only in read-only mode
+	role := &rbacv1.Role{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "payments", Name: "secrets-management-tenant-team-admin"}, role))
+	require.Len(t, role.Rules, 1)
+	assert.Equal(t, []string{"cert-manager.io"}, role.Rules[0].APIGroups)
+	assert.Equal(t, []string{"certificates", "issuers"}, role.Rules[0].Resources)
+	assert.Equal(t, []string{"get", "list", "watch"}, role.Rules[0].Verbs)
+
+	err := r.Get(ctx, types.NamespacedName{Namespace: "billing", Name: "secrets-management-tenant-team-view"}, &rbacv1.RoleBinding{})
+	assert.True(t, errors.IsNotFound(err), "rejected namespaces get no bindings")
+}
+
+func TestTenantReconcile_PrunesRemovedBindings(t *testing.T) {
+	ctx := context.Background()
+	r := newTestTenantReconciler(
+		newTestTenant("payments", "team", "payments-prod"),
+		newTestTenantNamespace("payments-prod", "payments"),
+	)
+	reconcileTenant(t, r, "payments", "team")
+
+	tenant := &smv1alpha1.SecretsManagementTenant{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "payments", Name: "team"}, tenant))
+	tenant.Spec.Namespaces = nil
+	tenant.Spec.RoleBindings = tenant.Spec.RoleBindings[:1]
+	require.NoError(t, r.Update(ctx, tenant))
+
+	tenant = reconcileTenant(t, r, "payments", "team")
+	assert.Equal(t, int32(1), tenant.Status.RoleBindings)
+
+	bindings := &rbacv1.RoleBindingList{}
+	require.NoError(t, r.List(ctx, bindings, client.MatchingLabels{TenantNameLabel: "team"}))
+	require.Len(t, bindings.Items, 1)
+	assert.Equal(t, "payments", bindings.Items[0].Namespace)
+	roles := &rbacv1.RoleList{}
+	require.NoError(t, r.List(ctx, roles, client.MatchingLabels{TenantNameLabel: "team"}))
+	assert.Len(t, roles.Items, 1)
+}
+
+func TestTenantReconcile_DeletionRemovesBindings(t *testing.T) {
+	ctx := context.Background()
+	r := newTestTenantReconciler(
+		newTestTenant("payments", "team", "payments-prod"),
+		newTestTenantNamespace("payments-prod", "payments"),
+	)
+	reconcileTenant(t, r, "payments", "team")
+
+	require.NoError(t, r.Delete(ctx, &smv1alpha1.SecretsManagementTenant{ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "team"}}))
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "payments", Name: "team"}})
+	require.NoError(t, err)
+
+	bindings := &rbacv1.RoleBindingList{}
+	require.NoError(t, r.List(ctx, bindings, client.MatchingLabels{TenantNameLabel: "team"}))
+	assert.Empty(t, bindings.Items)
+	roles := &rbacv1.RoleList{}
+	require.NoError(t, r.List(ctx, roles, client.MatchingLabels{TenantNameLabel: "team"}))
+	assert.Empty(t, roles.Items)
+	err = r.Get(ctx, types.NamespacedName{Namespace: "payments", Name: "team"}, &smv1alpha1.SecretsManagementTenant{})
+	assert.True(t, errors.IsNotFound(err), "the finalizer is removed")
+}
+
+func TestTenantReconcile_Quota(t *testing.T) {
+	ctx := context.Background()
+	tenant := newTestTenant("payments", "team", "payments-prod")
+	tenant.Spec.Quota.MaxExternalSecrets = int32Ptr(100)
+	r := newTestTenantReconciler(tenant, newTestTenantNamespace("payments-prod", "payments"))
+
+	got := reconcileTenant(t, r, "payments", "team")
+	assert.Equal(t, []smv1alpha1.TenantQuotaUsage{{Namespace: "payments"}, {Namespace: "payments-prod"}}, got.Status.QuotaUsage)
+
+	quota := &corev1.ResourceQuota{}
+	key := types.NamespacedName{Namespace: "payments-prod", Name: "secrets-management-tenant-team"}
+	require.NoError(t, r.Get(ctx, key, quota))
+	assert.Equal(t, corev1.ResourceList{externalSecretsQuotaResource: resource.MustParse("100")}, quota.Spec.Hard)
+
+	// Usage is reported as counted by the API server
+	quota.Status.Used = corev1.ResourceList{externalSecretsQuotaResource: resource.MustParse("42")}
+	require.NoError(t, r.Update(ctx, quota))
+	got = reconcileTenant(t, r, "payments", "team")
+	assert.Equal(t, smv1alpha1.TenantQuotaUsage{Namespace: "payments-prod", ExternalSecrets: 42}, got.Status.QuotaUsage[1])
+
+	// Clearing the limits removes the quotas
+	got.Spec.Quota = smv1alpha1.TenantQuota{}
+	require.NoError(t, r.Update(ctx, got))
+	got = reconcileTenant(t, r, "payments", "team")
+	assert.Empty(t, got.Status.QuotaUsage)
+	err := r.Get(ctx, key, &corev1.ResourceQuota{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestTenantReconcile_Restricted(t *testing.T) {
+	r := newTestTenantReconciler(newTestTenant("payments", "team"))
+	r.Restricted = true
+
+	tenant := reconcileTenant(t, r, "payments", "team")
+	assert.Equal(t, tenantRestrictedMessage, tenant.Status.Message)
+	assert.Empty(t, tenant.Finalizers)
+	bindings := &rbacv1.RoleBindingList{}
+	require.NoError(t, r.List(context.Background(), bindings))
+	assert.Empty(t, bindings.Items)
+}
@@ -0,0 +1,304 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// DefaultVaultPath is the Vault KV v2 mount used when a Vault store sets no path
+const DefaultVaultPath = "secret"
+
+// secretStoreWaitingMessage is reported until the External Secrets Operator validates a store
+const secretStoreWaitingMessage = "Waiting for the External Secrets Operator to validate the store"
+
+// ClusterSecretStore GroupVersionKind for the External Secrets Operator
+var clusterSecretStoreGVK = schema.GroupVersionKind{
+	Group:   "external-secrets.io",
+	Version: "v1beta1",
+	Kind:    "ClusterSecretStore",
+}
+
+// secretStoreAuthKeys are the keys each provider's auth Secret must hold
+var secretStoreAuthKeys = map[smv1alpha1.SecretStoreProvider][]string{
+	smv1alpha1.SecretStoreProviderAWS:              {"access-key-id", "secret-access-key"},
+	smv1alpha1.SecretStoreProviderVault:            {"token"},
+	smv1alpha1.SecretStoreProviderAzureKeyVault:    {"client-id", "client-secret"},
+	smv1alpha1.SecretStoreProviderGCPSecretManager: {"secret-access-credentials"},
+}
+
+// reconcileSecretStores creates the ClusterSecretStores in spec.stores, deletes the ones removed
+// from it, and reports whether the External Secrets Operator validated each store against its
+// provider. Stores with missing fields or credentials are reported and not created. The
+// credentials of cloud stores are also checked against their provider.
+func (r *SecretsManagementConfigReconciler) reconcileSecretStores(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	stores := config.Spec.Stores
+	if len(stores) > 0 && !config.Status.DetectedOperators.ExternalSecrets.Installed {
+		config.Status.Stores = nil
+		r.removeCondition(config, smv1alpha1.ConditionStoreCredentialsValid)
+		r.setCondition(config, smv1alpha1.ConditionSecretStoresReady, "False", smv1alpha1.ReasonExternalSecretsNotInstalled, "The External Secrets Operator is not installed")
+		return nil
+	}
+
+	previous := make(map[string]*smv1alpha1.StoreCredentialsStatus, len(config.Status.Stores))
+	for _, status := range config.Status.Stores {
+		previous[status.Name] = status.Credentials
+	}
+	statuses := make([]smv1alpha1.SecretStoreStatus, 0, len(stores))
+	desired := make(map[string]bool, len(stores))
+	var notReady []string
+	for i := range stores {
+		store := &stores[i]
+		desired[store.Name] = true
+
+		status := smv1alpha1.SecretStoreStatus{Name: store.Name, Provider: store.Provider}
+		message, err := r.applySecretStore(ctx, config, store)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				config.Status.Stores = nil
+				r.removeCondition(config, smv1alpha1.ConditionStoreCredentialsValid)
+				r.setCondition(config, smv1alpha1.ConditionSecretStoresReady, "False", smv1alpha1.ReasonExternalSecretsNotInstalled, "The External Secrets Operator is not installed")
+				return nil
+			}
+			return err
+		}
+		status.Ready = message == ""
+		status.Message = message
+		if !status.Ready {
+			notReady = append(notReady, fmt.Sprintf("%s: %s", store.Name, message))
+		}
+		if status.Credentials, err = r.checkStoreCredentials(ctx, store, previous[store.Name]); err != nil {
+			return err
+		}
+		statuses = append(statuses, status)
+	}
+
+	if err := r.pruneSecretStores(ctx, desired); err != nil {
+		return err
+	}
+
+	if len(stores) == 0 {
+		config.Status.Stores = nil
+		r.removeCondition(config, smv1alpha1.ConditionStoreCredentialsValid)
+		r.setCondition(config, smv1alpha1.ConditionSecretStoresReady, "False", smv1alpha1.ReasonSecretStoresNotConfigured, "No secret stores are configured")
+		return nil
+	}
+	config.Status.Stores = statuses
+	r.setStoreCredentialsCondition(config, statuses)
+
+	if len(notReady) > 0 {
+		sort.Strings(notReady)
+		r.setCondition(config, smv1alpha1.ConditionSecretStoresReady, "False", smv1alpha1.ReasonSecretStoreNotReady, strings.Join(notReady, "; "))
+		return nil
+	}
+	r.setCondition(config, smv1alpha1.ConditionSecretStoresReady, "True", smv1alpha1.ReasonSecretStoresReady, fmt.Sprintf("%d secret store(s) are ready", len(stores)))
+	return nil
+}
+
+// applySecretStore creates or updates the ClusterSecretStore for store and returns why it is not
+// ready, or "" when the External Secrets Operator reports it Ready
+func (r *SecretsManagementConfigReconciler) applySecretStore(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, store *smv1alpha1.SecretStoreConfig) (string, error) {
+	if msg := validateSecretStore(store); msg != "" {
+		return msg, nil
+	}
+	if msg, err := r.checkSecretStoreAuth(ctx, store); msg != "" || err != nil {
+		return msg, err
+	}
+
+	provider := secretStoreProviderSpec(store)
+	labels := map[string]string{
+		"app.kubernetes.io/part-of":    "ocp-secrets-management",
+		"app.kubernetes.io/managed-by": "secrets-management-operator",
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(clusterSecretStoreGVK)
+	err := r.Get(ctx, types.NamespacedName{Name: store.Name}, existing)
+	if errors.IsNotFound(err) {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(clusterSecretStoreGVK)
+		u.SetName(store.Name)
+		u.SetLabels(labels)
+		if err := unstructured.SetNestedField(u.Object, provider, "spec", "provider"); err != nil {
+			return "", err
+		}
+		applyCommonMetadata(config, u)
+		if err := r.Create(ctx, u); err != nil {
+			return "", err
+		}
+		return secretStoreWaitingMessage, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if existing.GetLabels()["app.kubernetes.io/managed-by"] != "secrets-management-operator" {
+		return fmt.Sprintf("ClusterSecretStore %s already exists and is not managed by the operator", store.Name), nil
+	}
+
+	before := existing.DeepCopy()
+	mergeMetadata(existing, commonMetadata(config))
+	current, _, _ := unstructured.NestedMap(existing.Object, "spec", "provider")
+	providerDrifted := !equality.Semantic.DeepEqual(current, provider)
+	if providerDrifted {
+		if err := unstructured.SetNestedField(existing.Object, provider, "spec", "provider"); err != nil {
+			return "", err
+		}
+	}
+	if err := updateIfChanged(ctx, r, before, existing); err != nil {
+		return "", err
+	}
+	if providerDrifted {
+		return secretStoreWaitingMessage, nil
+	}
+
+	return readyConditionMessage(existing, secretStoreWaitingMessage), nil
+}
+
+// validateSecretStore returns why store lacks a field its provider requires, or ""
+func validateSecretStore(store *smv1alpha1.SecretStoreConfig) string {
+	switch store.Provider {
+	case smv1alpha1.SecretStoreProviderAWS:
+		if store.Region == "" {
+			return "region is required for AWS"
+		}
+	case smv1alpha1.SecretStoreProviderVault:
+		if store.Address == "" {
+			return "address is required for Vault"
+		}
+	case smv1alpha1.SecretStoreProviderAzureKeyVault:
+		if store.Address == "" || store.TenantID == "" {
+			return "address and tenantID are required for AzureKeyVault"
+		}
+	case smv1alpha1.SecretStoreProviderGCPSecretManager:
+		if store.ProjectID == "" {
+			return "projectID is required for GCPSecretManager"
+		}
+	default:
+		return fmt.Sprintf("unsupported provider %q", store.Provider)
+	}
+	return ""
+}
+
+// checkSecretStoreAuth returns why the store's auth Secret is unusable, or "". Secrets are cached
+// as metadata only, so the data is read from the API server.
+func (r *SecretsManagementConfigReconciler) checkSecretStoreAuth(ctx context.Context, store *smv1alpha1.SecretStoreConfig) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.apiReader().Get(ctx, types.NamespacedName{Namespace: PluginNamespace, Name: store.AuthSecret}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("auth Secret %s/%s not found", PluginNamespace, store.AuthSecret), nil
+		}
+		return "", err
+	}
+	var missing []string
+	for _, key := range secretStoreAuthKeys[store.Provider] {
+		if len(secret.Data[key]) == 0 {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Sprintf("auth Secret %s/%s is missing %s", PluginNamespace, store.AuthSecret, strings.Join(missing, ", ")), nil
+	}
+	return "", nil
+}
+
+// secretStoreProviderSpec returns the spec.provider of the ClusterSecretStore for store
+func secretStoreProviderSpec(store *smv1alpha1.SecretStoreConfig) map[string]interface{} {
+	ref := func(key string) map[string]interface{} {
+		return map[string]interface{}{"name": store.AuthSecret, "namespace": PluginNamespace, "key": key}
+	}
+
+	switch store.Provider {
+	case smv1alpha1.SecretStoreProviderAWS:
+		return map[string]interface{}{"aws": map[string]interface{}{
+			"service": "SecretsManager",
+			"region":  store.Region,
+			"auth": map[string]interface{}{"secretRef": map[string]interface{}{
+				"accessKeyIDSecretRef":     ref("access-key-id"),
+				"secretAccessKeySecretRef": ref("secret-access-key"),
+			}},
+		}}
+	case smv1alpha1.SecretStoreProviderVault:
+		path := store.Path
+		if path == "" {
+			path = DefaultVaultPath
+		}
+		return map[string]interface{}{"vault": map[string]interface{}{
+			"server":  store.Address,
+			"path":    path,
+			"version": "v2",
+			"auth":    map[string]interface{}{"tokenSecretRef": ref("token")},
+		}}
+	case smv1alpha1.SecretStoreProviderAzureKeyVault:
+		return map[string]interface{}{"azurekv": map[string]interface{}{
+			"vaultUrl": store.Address,
+			"tenantId": store.TenantID,
+			"authType": "ServicePrincipal",
+			"authSecretRef": map[string]interface{}{
+				"clientId":     ref("client-id"),
+				"clientSecret": ref("client-secret"),
+			},
+		}}
+	default:
+		return map[string]interface{}{"gcpsm": map[string]interface{}{
+			"projectID": store.ProjectID,
+			"auth": map[string]interface{}{"secretRef": map[string]interface{}{
+				"secretAccessKeySecretRef": ref("secret-access-credentials"),
+			}},
+		}}
+	}
+}
+
+// readyConditionMessage returns why an object's Ready condition is not True, or "" when it is.
+// waiting is returned while the owning operator has not reported a Ready condition yet.
+func readyConditionMessage(obj *unstructured.Unstructured, waiting string) string {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != "Ready" {
+			continue
+		}
+		if cond["status"] == string(corev1.ConditionTrue) {
+			return ""
+		}
+		if message, _ := cond["message"].(string); message != "" {
+			return message
+		}
+		reason, _ := cond["reason"].(string)
+		return fmt.Sprintf("not ready: %s", reason)
+	}
+	return waiting
+}
+
+// pruneSecretStores deletes operator-managed ClusterSecretStores no longer in spec.stores
+func (r *SecretsManagementConfigReconciler) pruneSecretStores(ctx context.Context, desired map[string]bool) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(clusterSecretStoreGVK.GroupVersion().WithKind("ClusterSecretStoreList"))
+	if err := r.List(ctx, list, client.MatchingLabels{"app.kubernetes.io/managed-by": "secrets-management-operator"}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	for i := range list.Items {
+		store := &list.Items[i]
+		if desired[store.GetName()] {
+			continue
+		}
+		if err := r.Delete(ctx, store); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
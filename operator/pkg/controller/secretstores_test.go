@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestAuthSecret(name string, keys ...string) *corev1.Secret {
+	data := map[string][]byte{}
+	for _, key := range keys {
+		data[key] = []byte("value")
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: PluginNamespace},
+		Data:       data,
+	}
+}
+
+func getClusterSecretStore(t *testing.T, r *SecretsManagementConfigReconciler, name string) (*unstructured.Unstructured, error) {
+	t.Helper()
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(clusterSecretStoreGVK)
+	err := r.Get(context.Background(), types.NamespacedName{Name: name}, u)
+	return u, err
+}
+
+func TestReconcileSecretStores_CreatesStores(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	config.Spec.Stores = []smv1alpha1.SecretStoreConfig{
+		{Name: "aws", Provider: smv1alpha1.SecretStoreProviderAWS, AuthSecret: "aws-creds", Region: "eu-west-1"},
+		{Name: "vault", Provider: smv1alpha1.SecretStoreProviderVault, AuthSecret: "vault-token", Address: "https://vault.example.com:8200"},
+	}
+	r := newTestReconciler(
+		newTestAuthSecret("aws-creds", "access-key-id", "secret-access-key"),
+		newTestAuthSecret("vault-token", "token"),
+	)
+
+	require.NoError(t, r.reconcileSecretStores(ctx, config))
+
+	aws, err := getClusterSecretStore(t, r, "aws")
+	require.NoError(t, err)
+	region, _, _ := unstructured.NestedString(aws.Object, "spec", "provider", "aws", "region")
+	assert.Equal(t, "eu-west-1", region)
+	key, _, _ := unstructured.NestedString(aws.Object, "spec", "provider", "aws", "auth", "secretRef", "accessKeyIDSecretRef", "key")
+	assert.Equal(t, "access-key-id", key)
+	assert.Equal(t, "secrets-management-operator", aws.GetLabels()["app.kubernetes.io/managed-by"])
+
+	vault, err := getClusterSecretStore(t, r, "vault")
+	require.NoError(t, err)
+	path, _, _ := unstructured.NestedString(vault.Object, "spec", "provider", "vault", "path")
+	assert.Equal(t, DefaultVaultPath, path)
+
+	// Not ready until the External Secrets Operator validates the stores
+	require.Len(t, config.Status.Stores, 2)
+	assert.False(t, config.Status.Stores[0].Ready)
+	cond := findCondition(config, smv1alpha1.ConditionSecretStoresReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonSecretStoreNotReady, cond.Reason)
+
+	// The External Secrets Operator marks both stores Ready
+	for _, store := range []*unstructured.Unstructured{aws, vault} {
+		require.NoError(t, unstructured.SetNestedSlice(store.Object, []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "True", "reason": "Valid"},
+		}, "status", "conditions"))
+		require.NoError(t, r.Update(ctx, store))
+	}
+	require.NoError(t, r.reconcileSecretStores(ctx, config))
+	assert.True(t, config.Status.Stores[0].Ready)
+	assert.True(t, config.Status.Stores[1].Ready)
+	cond = findCondition(config, smv1alpha1.ConditionSecretStoresReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+}
+
+func TestReconcileSecretStores_ReportsInvalidStores(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	config.Spec.Stores = []smv1alpha1.SecretStoreConfig{
+		{Name: "azure", Provider: smv1alpha1.SecretStoreProviderAzureKeyVault, AuthSecret: "azure-creds", Address: "https://kv.vault.azure.net"},
+		{Name: "gcp", Provider: smv1alpha1.SecretStoreProviderGCPSecretManager, AuthSecret: "gcp-creds", ProjectID: "my-project"},
+	}
+	r := newTestReconciler(newTestAuthSecret("gcp-creds", "other"))
+
+	require.NoError(t, r.reconcileSecretStores(ctx, config))
+
+	require.Len(t, config.Status.Stores, 2)
+	assert.Contains(t, config.Status.Stores[0].Message, "tenantID")
+	assert.Contains(t, config.Status.Stores[1].Message, "secret-access-credentials")
+
+	_, err := getClusterSecretStore(t, r, "azure")
+	assert.True(t, errors.IsNotFound(err))
+	_, err = getClusterSecretStore(t, r, "gcp")
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileSecretStores_PrunesRemovedStores(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	config.Spec.Stores = []smv1alpha1.SecretStoreConfig{
+		{Name: "vault", Provider: smv1alpha1.SecretStoreProviderVault, AuthSecret: "vault-token", Address: "https://vault.example.com:8200"},
+	}
+	unmanaged := &unstructured.Unstructured{}
+	unmanaged.SetGroupVersionKind(clusterSecretStoreGVK)
+	unmanaged.SetName("hand-written")
+	r := newTestReconciler(newTestAuthSecret("vault-token", "token"), unmanaged)
+
+	require.NoError(t, r.reconcileSecretStores(ctx, config))
+	_, err := getClusterSecretStore(t, r, "vault")
+	require.NoError(t, err)
+
+	config.Spec.Stores = nil
+	require.NoError(t, r.reconcileSecretStores(ctx, config))
+	_, err = getClusterSecretStore(t, r, "vault")
+	assert.True(t, errors.IsNotFound(err))
+	assert.Nil(t, config.Status.Stores)
+
+	// Stores the operator did not create are left alone
+	_, err = getClusterSecretStore(t, r, "hand-written")
+	assert.NoError(t, err)
+}
+
+func TestReconcileSecretStores_ExternalSecretsNotInstalled(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Stores = []smv1alpha1.SecretStoreConfig{
+		{Name: "vault", Provider: smv1alpha1.SecretStoreProviderVault, AuthSecret: "vault-token", Address: "https://vault.example.com:8200"},
+	}
+	r := newTestReconciler()
+
+	require.NoError(t, r.reconcileSecretStores(context.Background(), config))
+	cond := findCondition(config, smv1alpha1.ConditionSecretStoresReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonExternalSecretsNotInstalled, cond.Reason)
+}
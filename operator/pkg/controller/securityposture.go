@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// EtcdEncryptionIdentity is the APIServer encryption type that stores resources unencrypted
+const EtcdEncryptionIdentity = "identity"
+
+// OpenShift cluster APIServer config and kube-apiserver operator GroupVersionKinds
+var (
+	apiServerGVK = schema.GroupVersionKind{
+		Group:   "config.openshift.io",
+		Version: "v1",
+		Kind:    "APIServer",
+	}
+	kubeAPIServerGVK = schema.GroupVersionKind{
+		Group:   "operator.openshift.io",
+		Version: "v1",
+		Kind:    "KubeAPIServer",
+	}
+)
+
+// +kubebuilder:rbac:groups=config.openshift.io,resources=apiservers,verbs=get
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=kubeapiservers,verbs=get
+
+// reconcileSecurityPosture reports whether etcd encrypts Secrets at rest. The encryption type comes
+// from the cluster APIServer config; the kube-apiserver operator's Encrypted condition tells whether
+// existing resources have been rewritten with it yet.
+func (r *SecretsManagementConfigReconciler) reconcileSecurityPosture(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	apiServer := &unstructured.Unstructured{}
+	apiServer.SetGroupVersionKind(apiServerGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: "cluster"}, apiServer); err != nil {
+		if meta.IsNoMatchError(err) || errors.IsNotFound(err) {
+			config.Status.SecurityPosture = smv1alpha1.SecurityPostureStatus{}
+			r.setCondition(config, smv1alpha1.ConditionSecretsEncryptedAtRest, "Unknown", smv1alpha1.ReasonEtcdEncryptionUnknown, "The cluster does not serve the config.openshift.io/v1 APIServer config")
+			return nil
+		}
+		return err
+	}
+
+	encryption, _, _ := unstructured.NestedString(apiServer.Object, "spec", "encryption", "type")
+	if encryption == "" {
+		encryption = EtcdEncryptionIdentity
+	}
+	progress, progressMessage, err := r.etcdEncryptionProgress(ctx)
+	if err != nil {
+		return err
+	}
+	config.Status.SecurityPosture = smv1alpha1.SecurityPostureStatus{
+		EtcdEncryption:         encryption,
+		EtcdEncryptionProgress: progress,
+	}
+
+	switch {
+	case encryption == EtcdEncryptionIdentity:
+		r.setCondition(config, smv1alpha1.ConditionSecretsEncryptedAtRest, "False", smv1alpha1.ReasonEtcdEncryptionDisabled, "Secrets are stored unencrypted in etcd; set spec.encryption.type on the APIServer cluster config to aescbc, aesgcm or KMS")
+	case progress != "" && progress != "EncryptionCompleted":
+		msg := fmt.Sprintf("etcd encryption is set to %s but existing resources are not yet encrypted (%s)", encryption, progress)
+		if progressMessage != "" {
+			msg = fmt.Sprintf("%s: %s", msg, progressMessage)
+		}
+		r.setCondition(config, smv1alpha1.ConditionSecretsEncryptedAtRest, "False", smv1alpha1.ReasonEtcdEncryptionInProgress, msg)
+	default:
+		r.setCondition(config, smv1alpha1.ConditionSecretsEncryptedAtRest, "True", smv1alpha1.ReasonEtcdEncryptionEnabled, fmt.Sprintf("Secrets are encrypted at rest in etcd with %s", encryption))
+	}
+	return nil
+}
+
+// etcdEncryptionProgress returns the reason and message of the kube-apiserver operator's Encrypted
+// condition, or "" when the operator or condition is absent
+func (r *SecretsManagementConfigReconciler) etcdEncryptionProgress(ctx context.Context) (string, string, error) {
+	kubeAPIServer := &unstructured.Unstructured{}
+	kubeAPIServer.SetGroupVersionKind(kubeAPIServerGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: "cluster"}, kubeAPIServer); err != nil {
+		if meta.IsNoMatchError(err) || errors.IsNotFound(err) {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(kubeAPIServer.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != "Encrypted" {
+			continue
+		}
+		reason, _ := cond["reason"].(string)
+		message, _ := cond["message"].(string)
+		return reason, message, nil
+	}
+	return "", "", nil
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestAPIServer(encryption string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(apiServerGVK)
+	u.SetName("cluster")
+	if encryption != "" {
+		_ = unstructured.SetNestedField(u.Object, encryption, "spec", "encryption", "type")
+	}
+	return u
+}
+
+func newTestKubeAPIServer(reason, message string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(kubeAPIServerGVK)
+	u.SetName("cluster")
+	_ = unstructured.SetNestedSlice(u.Object, []interface{}{
+		map[string]interface{}{"type": "Encrypted", "status": "False", "reason": reason, "message": message},
+	}, "status", "conditions")
+	return u
+}
+
+func TestReconcileSecurityPosture(t *testing.T) {
+	tests := []struct {
+		name         string
+		apiServer    *unstructured.Unstructured
+		kubeAPI      *unstructured.Unstructured
+		encryption   string
+		status       string
+		reason       string
+		wantProgress string
+	}{
+		{
+			name:   "not OpenShift",
+			status: "Unknown",
+			reason: smv1alpha1.ReasonEtcdEncryptionUnknown,
+		},
+		{
+			name:       "unset means identity",
+			apiServer:  newTestAPIServer(""),
+			encryption: EtcdEncryptionIdentity,
+			status:     "False",
+			reason:     smv1alpha1.ReasonEtcdEncryptionDisabled,
+		},
+		{
+			name:       "aesgcm without operator status",
+			apiServer:  newTestAPIServer("aesgcm"),
+			encryption: "aesgcm",
+			status:     "True",
+			reason:     smv1alpha1.ReasonEtcdEncryptionEnabled,
+		},
+		{
+			name:         "aescbc migration in progress",
+			apiServer:    newTestAPIServer("aescbc"),
+			kubeAPI:      newTestKubeAPIServer("EncryptionInProgress", "Resource secrets is being encrypted"),
+			encryption:   "aescbc",
+			status:       "False",
+			reason:       smv1alpha1.ReasonEtcdEncryptionInProgress,
+			wantProgress: "EncryptionInProgress",
+		},
+		{
+			name:         "KMS completed",
+			apiServer:    newTestAPIServer("KMS"),
+			kubeAPI:      newTestKubeAPIServer("EncryptionCompleted", "All resources encrypted"),
+			encryption:   "KMS",
+			status:       "True",
+			reason:       smv1alpha1.ReasonEtcdEncryptionEnabled,
+			wantProgress: "EncryptionCompleted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objs []client.Object
+			if tt.apiServer != nil {
+				objs = append(objs, tt.apiServer)
+			}
+			if tt.kubeAPI != nil {
+				objs = append(objs, tt.kubeAPI)
+			}
+			r := newTestReconciler(objs...)
+			config := newTestConfig(SingletonConfigName)
+
+			require.NoError(t, r.reconcileSecurityPosture(context.Background(), config))
+
+			assert.Equal(t, tt.encryption, config.Status.SecurityPosture.EtcdEncryption)
+			assert.Equal(t, tt.wantProgress, config.Status.SecurityPosture.EtcdEncryptionProgress)
+			cond := findCondition(config, smv1alpha1.ConditionSecretsEncryptedAtRest)
+			require.NotNil(t, cond)
+			assert.Equal(t, tt.status, cond.Status)
+			assert.Equal(t, tt.reason, cond.Reason)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// SidecarInjectAnnotation tells Istio whether to inject its proxy into a pod
+	SidecarInjectAnnotation = "sidecar.istio.io/inject"
+
+	// ExcludeInboundPortsAnnotation lists the pod ports the Istio proxy does not intercept
+	ExcludeInboundPortsAnnotation = "traffic.sidecar.istio.io/excludeInboundPorts"
+)
+
+// setServiceMeshAnnotations annotates the plugin pod template for spec.plugin.serviceMesh
+func setServiceMeshAnnotations(config *smv1alpha1.SecretsManagementConfig, deployment *appsv1.Deployment) {
+	mesh := config.Spec.Plugin.ServiceMesh
+	annotations := map[string]string{}
+	switch mesh.SidecarInjection {
+	case smv1alpha1.SidecarInjectionEnabled:
+		annotations[SidecarInjectAnnotation] = "true"
+	case smv1alpha1.SidecarInjectionDisabled:
+		annotations[SidecarInjectAnnotation] = "false"
+	}
+
+	excluded := mesh.ExcludeInboundPorts
+	if len(excluded) == 0 && mesh.SidecarInjection == smv1alpha1.SidecarInjectionEnabled {
+		excluded = []int32{pluginPort(config)}
+	}
+	if len(excluded) > 0 {
+		ports := make([]string, 0, len(excluded))
+		for _, port := range excluded {
+			ports = append(ports, strconv.Itoa(int(port)))
+		}
+		annotations[ExcludeInboundPortsAnnotation] = strings.Join(ports, ",")
+	}
+
+	if len(annotations) == 0 {
+		return
+	}
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	for k, v := range annotations {
+		deployment.Spec.Template.Annotations[k] = v
+	}
+}
+
+// setServiceAppProtocols names the protocol of each plugin Service port when
+// spec.plugin.serviceMesh.appProtocols is set
+func setServiceAppProtocols(config *smv1alpha1.SecretsManagementConfig, svc *corev1.Service) {
+	if !config.Spec.Plugin.ServiceMesh.AppProtocols {
+		return
+	}
+	for i := range svc.Spec.Ports {
+		port := &svc.Spec.Ports[i]
+		switch port.Name {
+		case "https":
+			port.AppProtocol = stringPtr("https")
+		case "metrics":
+			port.AppProtocol = stringPtr("http")
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestSetServiceMeshAnnotations(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	deployment := &appsv1.Deployment{}
+	setServiceMeshAnnotations(config, deployment)
+	assert.Nil(t, deployment.Spec.Template.Annotations)
+
+	// Enabling the sidecar keeps it off the plugin port by default
+	config.Spec.Plugin.ServiceMesh.SidecarInjection = smv1alpha1.SidecarInjectionEnabled
+	setServiceMeshAnnotations(config, deployment)
+	assert.Equal(t, map[string]string{
+		SidecarInjectAnnotation:       "true",
+		ExcludeInboundPortsAnnotation: "9443",
+	}, deployment.Spec.Template.Annotations)
+
+	config.Spec.Plugin.ServiceMesh.ExcludeInboundPorts = []int32{9443, 9090}
+	setServiceMeshAnnotations(config, deployment)
+	assert.Equal(t, "9443,9090", deployment.Spec.Template.Annotations[ExcludeInboundPortsAnnotation])
+
+	deployment = &appsv1.Deployment{}
+	config.Spec.Plugin.ServiceMesh = smv1alpha1.PluginServiceMeshConfig{SidecarInjection: smv1alpha1.SidecarInjectionDisabled}
+	setServiceMeshAnnotations(config, deployment)
+	assert.Equal(t, map[string]string{SidecarInjectAnnotation: "false"}, deployment.Spec.Template.Annotations)
+}
+
+func TestReconcileService_AppProtocols(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Plugin.MetricsPort = 9090
+	config.Spec.Plugin.ServiceMesh.AppProtocols = true
+	r := newTestReconciler(config)
+
+	require.NoError(t, r.reconcileService(ctx, config))
+	svc := &corev1.Service{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, svc))
+	require.Len(t, svc.Spec.Ports, 2)
+	assert.Equal(t, "https", *svc.Spec.Ports[0].AppProtocol)
+	assert.Equal(t, "http", *svc.Spec.Ports[1].AppProtocol)
+
+	config.Spec.Plugin.ServiceMesh.AppProtocols = false
+	require.NoError(t, r.reconcileService(ctx, config))
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, svc))
+	assert.Nil(t, svc.Spec.Ports[0].AppProtocol)
+}
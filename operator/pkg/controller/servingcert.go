@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// SelfSignedCertAnnotation marks a serving certificate Secret the operator generated because
+	// the service CA did not issue one
+	SelfSignedCertAnnotation = "secrets-management.openshift.io/self-signed"
+
+	// DefaultServingCertFallbackAfter is how long the service CA gets to issue the serving
+	// certificate before spec.plugin.servingCert.selfSignedFallback applies
+	DefaultServingCertFallbackAfter = 5 * time.Minute
+
+	// selfSignedCertValidity is the lifetime of a fallback certificate
+	selfSignedCertValidity = 365 * 24 * time.Hour
+)
+
+// reconcileCertSecretCondition reports whether the service CA has issued the plugin serving
+// certificate. Without it the plugin pods cannot mount their certificate and never start; when
+// spec.plugin.servingCert.selfSignedFallback is set, a self-signed certificate is written once
+// the service CA has had FallbackAfter to issue one.
+func (r *SecretsManagementConfigReconciler) reconcileCertSecretCondition(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	name := fmt.Sprintf("%s-plugin-cert", instanceName(config))
+	// Only existence matters; reading metadata keeps certificate data out of the cache
+	secret := &metav1.PartialObjectMetadata{}
+	secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: PluginNamespace}, secret)
+	if err == nil {
+		r.setCondition(config, smv1alpha1.ConditionWaitingForServingCert, "False", smv1alpha1.ReasonCertSecretFound, "Serving certificate Secret exists")
+		if secret.Annotations[SelfSignedCertAnnotation] == "true" {
+			r.setCondition(config, smv1alpha1.ConditionCertSecretPresent, "True", smv1alpha1.ReasonSelfSignedCertIssued,
+				"Using a self-signed serving certificate; delete the Secret to have the service CA issue one")
+			return nil
+		}
+		r.setCondition(config, smv1alpha1.ConditionCertSecretPresent, "True", smv1alpha1.ReasonCertSecretFound, "Serving certificate Secret exists")
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	issued, err := r.reconcileSelfSignedCert(ctx, config, name)
+	if err != nil {
+		return err
+	}
+	if issued {
+		r.setCondition(config, smv1alpha1.ConditionWaitingForServingCert, "False", smv1alpha1.ReasonSelfSignedCertIssued,
+			"The service CA did not issue the serving certificate in time, a self-signed one was written")
+		r.setCondition(config, smv1alpha1.ConditionCertSecretPresent, "True", smv1alpha1.ReasonSelfSignedCertIssued,
+			"Using a self-signed serving certificate; delete the Secret to have the service CA issue one")
+		return nil
+	}
+	message := "Waiting for the service CA to issue the serving certificate"
+	r.setCondition(config, smv1alpha1.ConditionWaitingForServingCert, "True", smv1alpha1.ReasonCertSecretNotFound,
+		message+"; the plugin pods cannot start until Secret "+name+" exists")
+	r.setCondition(config, smv1alpha1.ConditionCertSecretPresent, "False", smv1alpha1.ReasonCertSecretNotFound, message)
+	return nil
+}
+
+// reconcileSelfSignedCert writes a self-signed serving certificate to the Secret name when the
+// fallback is enabled and the plugin Service has waited long enough for the service CA. It
+// returns whether the Secret was written.
+func (r *SecretsManagementConfigReconciler) reconcileSelfSignedCert(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, name string) (bool, error) {
+	servingCert := config.Spec.Plugin.ServingCert
+	if !servingCert.SelfSignedFallback {
+		return false, nil
+	}
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("%s-plugin", instanceName(config)), Namespace: PluginNamespace}, svc); err != nil {
+		return false, err
+	}
+	after := DefaultServingCertFallbackAfter
+	if servingCert.FallbackAfter != nil {
+		after = servingCert.FallbackAfter.Duration
+	}
+	if time.Since(svc.CreationTimestamp.Time) < after {
+		return false, nil
+	}
+
+	certPEM, keyPEM, err := selfSignedServingCert(svc, time.Now())
+	if err != nil {
+		return false, err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: PluginNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       instanceName(config),
+				"app.kubernetes.io/part-of":    "ocp-secrets-management",
+				"app.kubernetes.io/managed-by": "secrets-management-operator",
+			},
+			Annotations: map[string]string{
+				SelfSignedCertAnnotation: "true",
+			},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+	applyCommonMetadata(config, secret)
+	if err := r.Create(ctx, secret); err != nil {
+		if errors.IsAlreadyExists(err) {
+			// The service CA issued it meanwhile
+			return false, nil
+		}
+		return false, err
+	}
+	if r.Recorder != nil {
+		r.Recorder.Eventf(config, corev1.EventTypeWarning, smv1alpha1.ReasonSelfSignedCertIssued,
+			"The service CA did not issue Secret %s/%s within %s, wrote a self-signed certificate", PluginNamespace, name, after)
+	}
+	return true, nil
+}
+
+// selfSignedServingCert returns a PEM encoded self-signed certificate and key for the DNS names
+// of svc
+func selfSignedServingCert(svc *corev1.Service, now time.Time) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	host := fmt.Sprintf("%s.%s.svc", svc.Name, svc.Namespace)
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: host},
+		DNSNames:              []string{host, host + ".cluster.local"},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(selfSignedCertValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
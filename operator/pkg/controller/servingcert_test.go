@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestPluginService(created time.Time) *corev1.Service {
+	return &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:              "ocp-secrets-management-plugin",
+		Namespace:         PluginNamespace,
+		CreationTimestamp: metav1.NewTime(created),
+	}}
+}
+
+func TestReconcileCertSecretCondition_Waiting(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Plugin.ServingCert.SelfSignedFallback = true
+	// The service CA still has time to issue the certificate
+	r := newTestReconciler(config, newTestPluginService(time.Now().Add(-time.Minute)))
+
+	require.NoError(t, r.reconcileCertSecretCondition(ctx, config))
+	waiting := findCondition(config, smv1alpha1.ConditionWaitingForServingCert)
+	require.NotNil(t, waiting)
+	assert.Equal(t, "True", waiting.Status)
+	assert.Contains(t, waiting.Message, "ocp-secrets-management-plugin-cert")
+	err := r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin-cert", Namespace: PluginNamespace}, &corev1.Secret{})
+	assert.Error(t, err)
+}
+
+func TestReconcileCertSecretCondition_SelfSignedFallback(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Plugin.ServingCert.SelfSignedFallback = true
+	config.Spec.Plugin.ServingCert.FallbackAfter = &metav1.Duration{Duration: 2 * time.Minute}
+	r := newTestReconciler(config, newTestPluginService(time.Now().Add(-3*time.Minute)))
+
+	require.NoError(t, r.reconcileCertSecretCondition(ctx, config))
+	waiting := findCondition(config, smv1alpha1.ConditionWaitingForServingCert)
+	require.NotNil(t, waiting)
+	assert.Equal(t, "False", waiting.Status)
+	assert.Equal(t, smv1alpha1.ReasonSelfSignedCertIssued, waiting.Reason)
+
+	secret := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin-cert", Namespace: PluginNamespace}, secret))
+	assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+	assert.Equal(t, "true", secret.Annotations[SelfSignedCertAnnotation])
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	assert.NoError(t, cert.VerifyHostname("ocp-secrets-management-plugin."+PluginNamespace+".svc"))
+
+	// Later loops report the fallback certificate
+	require.NoError(t, r.reconcileCertSecretCondition(ctx, config))
+	present := findCondition(config, smv1alpha1.ConditionCertSecretPresent)
+	assert.Equal(t, "True", present.Status)
+	assert.Equal(t, smv1alpha1.ReasonSelfSignedCertIssued, present.Reason)
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// tracerName names the tracer of the reconcile loop spans
+const tracerName = "github.com/openshift/ocp-secrets-management/operator/pkg/controller"
+
+// reconcileStep is one sub-reconciler of the reconcile loop
+type reconcileStep struct {
+	// name completes "Failed to ..." in logs and conditions
+	name string
+	run  func(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error
+	// optional steps report failures in the group's condition without failing the loop
+	optional bool
+}
+
+// reconcileGroup is a sequence of steps whose outcome is reported by one condition. Steps run in
+// order, and a failing step does not stop the ones after it.
+type reconcileGroup struct {
+	condition smv1alpha1.ConditionType
+	steps     []reconcileStep
+}
+
+// runGroupsConcurrently runs groups at the same time, so a sub-system that fails or hangs until
+// its timeout, such as an unavailable console API, does not hold up the others. Each group works
+// on its own copy of config, so the groups see the status as it was when they started, and the
+// status each group changed is merged back into config in group order once all are done. It
+// returns the errors of every failed step that is not optional.
+func (r *SecretsManagementConfigReconciler) runGroupsConcurrently(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, groups []reconcileGroup) error {
+	errs := make([]error, len(groups))
+	copies := make([]*smv1alpha1.SecretsManagementConfig, len(groups))
+	var wg sync.WaitGroup
+	for i := range groups {
+		copies[i] = config.DeepCopy()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = r.runGroup(ctx, copies[i], groups[i])
+		}(i)
+	}
+	wg.Wait()
+	original := config.DeepCopy()
+	for _, updated := range copies {
+		r.mergeStatus(config, original, updated)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// mergeStatus copies into config the status fields and conditions that differ between original
+// and updated, and removes the conditions updated no longer has
+func (r *SecretsManagementConfigReconciler) mergeStatus(config, original, updated *smv1alpha1.SecretsManagementConfig) {
+	status := reflect.ValueOf(&config.Status).Elem()
+	before := reflect.ValueOf(original.Status)
+	after := reflect.ValueOf(updated.Status)
+	for i := 0; i < status.NumField(); i++ {
+		if status.Type().Field(i).Name == "Conditions" {
+			continue
+		}
+		if !equality.Semantic.DeepEqual(before.Field(i).Interface(), after.Field(i).Interface()) {
+			status.Field(i).Set(after.Field(i))
+		}
+	}
+
+	for _, condition := range updated.Status.Conditions {
+		previous := statusCondition(original, condition.Type)
+		if previous != nil && equality.Semantic.DeepEqual(*previous, condition) {
+			continue
+		}
+		if current := statusCondition(config, condition.Type); current != nil {
+			*current = condition
+		} else {
+			config.Status.Conditions = append(config.Status.Conditions, condition)
+		}
+	}
+	for _, condition := range original.Status.Conditions {
+		if statusCondition(updated, condition.Type) == nil {
+			r.removeCondition(config, condition.Type)
+		}
+	}
+}
+
+// runGroup runs the steps of group in order and sets its condition
+func (r *SecretsManagementConfigReconciler) runGroup(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, group reconcileGroup) error {
+	log := r.Log.WithValues("secretsmanagementconfig", config.Name)
+	var failures []string
+	var required []error
+	ctx, groupSpan := startSpan(ctx, string(group.condition))
+	defer groupSpan.End()
+	for _, step := range group.steps {
+		stepCtx, span := startSpan(ctx, step.name, attribute.Bool("optional", step.optional))
+		err := step.run(stepCtx, config)
+		endSpan(span, err)
+		if err == nil {
+			continue
+		}
+		log.Error(err, "Failed to "+step.name)
+		failures = append(failures, fmt.Sprintf("failed to %s: %v", step.name, err))
+		if !step.optional {
+			required = append(required, fmt.Errorf("failed to %s: %w", step.name, err))
+		}
+	}
+	if len(failures) > 0 {
+		r.setCondition(config, group.condition, "False", smv1alpha1.ReasonReconcileStepFailed, strings.Join(failures, "; "))
+		groupSpan.SetStatus(codes.Error, strings.Join(failures, "; "))
+	} else {
+		r.setCondition(config, group.condition, "True", smv1alpha1.ReasonReconcileStepsSucceeded, fmt.Sprintf("%d steps succeeded", len(group.steps)))
+	}
+	return utilerrors.NewAggregate(required)
+}
+
+// startSpan starts a span of the reconcile loop. The tracer is looked up on each call so the
+// provider installed at startup is used.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err, if any, on span and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
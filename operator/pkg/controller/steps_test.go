@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func TestRunGroupsConcurrently(t *testing.T) {
+	r := newTestReconciler()
+	config := newTestConfig(SingletonConfigName)
+	ok := func(ran *bool) func(context.Context, *smv1alpha1.SecretsManagementConfig) error {
+		return func(context.Context, *smv1alpha1.SecretsManagementConfig) error {
+			*ran = true
+			return nil
+		}
+	}
+	fail := func(context.Context, *smv1alpha1.SecretsManagementConfig) error {
+		return errors.New("console API unavailable")
+	}
+
+	var rbacRan, afterFailureRan, detectRan bool
+	err := r.runGroupsConcurrently(context.Background(), config, []reconcileGroup{
+		{condition: smv1alpha1.ConditionAccessReconciled, steps: []reconcileStep{
+			{name: "reconcile RBAC", run: ok(&rbacRan)},
+		}},
+		{condition: smv1alpha1.ConditionPluginReconciled, steps: []reconcileStep{
+			{name: "reconcile ConsolePlugin", run: fail},
+			{name: "reconcile plugin Route", run: ok(&afterFailureRan)},
+		}},
+		{condition: smv1alpha1.ConditionOperatorsDetected, steps: []reconcileStep{
+			{name: "detect operators", run: fail, optional: true},
+			{name: "detect providers", run: ok(&detectRan)},
+		}},
+	})
+
+	// Only the required step fails the loop, and every other step still ran
+	require.Error(t, err)
+	assert.Equal(t, "failed to reconcile ConsolePlugin: console API unavailable", err.Error())
+	assert.True(t, rbacRan)
+	assert.True(t, afterFailureRan)
+	assert.True(t, detectRan)
+
+	access := findCondition(config, smv1alpha1.ConditionAccessReconciled)
+	require.NotNil(t, access)
+	assert.Equal(t, "True", access.Status)
+	assert.Equal(t, smv1alpha1.ReasonReconcileStepsSucceeded, access.Reason)
+	assert.Equal(t, "1 steps succeeded", access.Message)
+
+	plugin := findCondition(config, smv1alpha1.ConditionPluginReconciled)
+	require.NotNil(t, plugin)
+	assert.Equal(t, "False", plugin.Status)
+	assert.Equal(t, smv1alpha1.ReasonReconcileStepFailed, plugin.Reason)
+	assert.Equal(t, "failed to reconcile ConsolePlugin: console API unavailable", plugin.Message)
+
+	// Optional failures are still reported
+	detected := findCondition(config, smv1alpha1.ConditionOperatorsDetected)
+	require.NotNil(t, detected)
+	assert.Equal(t, "False", detected.Status)
+	assert.Contains(t, detected.Message, "failed to detect operators")
+}
+
+func TestRunGroupsConcurrently_MergesStatus(t *testing.T) {
+	r := newTestReconciler()
+	config := newTestConfig(SingletonConfigName)
+	config.Status.Plugin.RouteHost = "old.apps.example.com"
+	r.setCondition(config, smv1alpha1.ConditionClusterExternalSecretsProvisioned, "True", smv1alpha1.ReasonClusterExternalSecretsProvisioned, "")
+
+	// Each group writes its own status fields and conditions while the others run
+	err := r.runGroupsConcurrently(context.Background(), config, []reconcileGroup{
+		{condition: smv1alpha1.ConditionOperatorsDetected, steps: []reconcileStep{
+			{name: "detect operators", run: func(_ context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+				config.Status.DetectedOperators.ExternalSecrets.Installed = true
+				r.removeCondition(config, smv1alpha1.ConditionClusterExternalSecretsProvisioned)
+				return nil
+			}},
+		}},
+		{condition: smv1alpha1.ConditionPluginReconciled, steps: []reconcileStep{
+			{name: "reconcile plugin Route", run: func(_ context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+				assert.False(t, config.Status.DetectedOperators.ExternalSecrets.Installed, "groups start from the same status")
+				config.Status.Plugin.RouteHost = "plugin.apps.example.com"
+				return nil
+			}},
+		}},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, config.Status.DetectedOperators.ExternalSecrets.Installed)
+	assert.Equal(t, "plugin.apps.example.com", config.Status.Plugin.RouteHost)
+	assert.Nil(t, findCondition(config, smv1alpha1.ConditionClusterExternalSecretsProvisioned))
+	require.NotNil(t, findCondition(config, smv1alpha1.ConditionOperatorsDetected))
+	require.NotNil(t, findCondition(config, smv1alpha1.ConditionPluginReconciled))
+}
+
+func TestReconcile_StepConditions(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler(config)
+	reconcileTestConfig(t, r)
+
+	updated := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(config), updated))
+	for _, condType := range []smv1alpha1.ConditionType{
+		smv1alpha1.ConditionAccessReconciled,
+		smv1alpha1.ConditionPluginReconciled,
+		smv1alpha1.ConditionPoliciesReconciled,
+		smv1alpha1.ConditionOperatorsDetected,
+		smv1alpha1.ConditionIntegrationsReconciled,
+	} {
+		condition := findCondition(updated, condType)
+		require.NotNil(t, condition, condType)
+		assert.Equal(t, "True", condition.Status, "%s: %s", condType, condition.Message)
+	}
+}
+
+func TestReconcile_Spans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler(config)
+	reconcileTestConfig(t, r)
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	reconcile, ok := spans["Reconcile"]
+	require.True(t, ok, "Reconcile span")
+	group, ok := spans[string(smv1alpha1.ConditionAccessReconciled)]
+	require.True(t, ok, "group span")
+	assert.Equal(t, reconcile.SpanContext().SpanID(), group.Parent().SpanID())
+	step, ok := spans["reconcile RBAC"]
+	require.True(t, ok, "step span")
+	assert.Equal(t, group.SpanContext().SpanID(), step.Parent().SpanID())
+	assert.Equal(t, reconcile.SpanContext().TraceID(), step.SpanContext().TraceID())
+	assert.Equal(t, codes.Unset, step.Status().Code)
+}
+
+func TestRunGroup_FailedStepSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	r := newTestReconciler()
+	config := newTestConfig(SingletonConfigName)
+	err := r.runGroup(context.Background(), config, reconcileGroup{
+		condition: smv1alpha1.ConditionPluginReconciled,
+		steps: []reconcileStep{{name: "reconcile ConsolePlugin", run: func(context.Context, *smv1alpha1.SecretsManagementConfig) error {
+			return errors.New("console API unavailable")
+		}}},
+	})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "reconcile ConsolePlugin", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "console API unavailable", spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1, "the error is recorded")
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/cloudcredentials"
+)
+
+const (
+	// ExpiresAtAnnotation on a store's auth Secret records when its credentials expire, as an
+	// RFC 3339 time. Providers do not report the expiry of a client secret or access key to the
+	// credential itself, so it is taken from whoever issued it.
+	ExpiresAtAnnotation = "secrets-management.openshift.io/expires-at"
+
+	// credentialsCheckInterval is how often a store's unchanged credentials are checked again
+	credentialsCheckInterval = time.Hour
+
+	// credentialsExpiryWarning is how long before ExpiresAtAnnotation a store is flagged
+	credentialsExpiryWarning = 14 * 24 * time.Hour
+)
+
+// checkStoreCredentials checks the credentials of an AWS, AzureKeyVault or GCPSecretManager store
+// against its provider, since the External Secrets Operator only reports a rejected credential
+// once a sync fails. Unchanged credentials are checked at most hourly; previous is the last
+// result, reused in between. It returns nil for Vault stores, which reconcileVaultHealth probes,
+// and while the auth Secret is incomplete, which the store's message already reports.
+func (r *SecretsManagementConfigReconciler) checkStoreCredentials(ctx context.Context, store *smv1alpha1.SecretStoreConfig, previous *smv1alpha1.StoreCredentialsStatus) (*smv1alpha1.StoreCredentialsStatus, error) {
+	if r.CredentialChecker == nil || store.Provider == smv1alpha1.SecretStoreProviderVault || validateSecretStore(store) != "" {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.apiReader().Get(ctx, types.NamespacedName{Namespace: PluginNamespace, Name: store.AuthSecret}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for _, key := range secretStoreAuthKeys[store.Provider] {
+		if len(secret.Data[key]) == 0 {
+			return nil, nil
+		}
+	}
+
+	var status *smv1alpha1.StoreCredentialsStatus
+	if previous != nil && previous.AuthSecretVersion == secret.ResourceVersion && time.Since(previous.LastChecked.Time) < credentialsCheckInterval {
+		status = previous.DeepCopy()
+	} else {
+		status = &smv1alpha1.StoreCredentialsStatus{LastChecked: metav1.Now(), AuthSecretVersion: secret.ResourceVersion}
+		identity, err := r.checkCredentials(ctx, store, secret)
+		var rejected *cloudcredentials.RejectedError
+		switch {
+		case errors.As(err, &rejected):
+			valid := false
+			status.Valid = &valid
+			status.Message = rejected.Message
+		case err != nil:
+			status.Message = fmt.Sprintf("could not check the credentials: %v", err)
+		default:
+			valid := true
+			status.Valid = &valid
+			status.Identity = identity
+		}
+	}
+
+	status.ExpiresAt = nil
+	status.Expiring = false
+	if value := secret.Annotations[ExpiresAtAnnotation]; value != "" {
+		expiresAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			status.Message = fmt.Sprintf("invalid %s annotation %q on the auth Secret", ExpiresAtAnnotation, value)
+		} else {
+			status.ExpiresAt = &metav1.Time{Time: expiresAt}
+			status.Expiring = time.Until(expiresAt) < credentialsExpiryWarning
+		}
+	}
+	return status, nil
+}
+
+// checkCredentials asks the store's provider to authenticate the credentials in secret
+func (r *SecretsManagementConfigReconciler) checkCredentials(ctx context.Context, store *smv1alpha1.SecretStoreConfig, secret *corev1.Secret) (string, error) {
+	value := func(key string) string { return strings.TrimSpace(string(secret.Data[key])) }
+	switch store.Provider {
+	case smv1alpha1.SecretStoreProviderAWS:
+		return r.CredentialChecker.AWS(ctx, store.Region, value("access-key-id"), value("secret-access-key"))
+	case smv1alpha1.SecretStoreProviderAzureKeyVault:
+		return r.CredentialChecker.Azure(ctx, store.TenantID, value("client-id"), value("client-secret"))
+	default:
+		return r.CredentialChecker.GCP(ctx, secret.Data["secret-access-credentials"])
+	}
+}
+
+// setStoreCredentialsCondition reports the stores whose credentials were rejected or expire soon
+func (r *SecretsManagementConfigReconciler) setStoreCredentialsCondition(config *smv1alpha1.SecretsManagementConfig, statuses []smv1alpha1.SecretStoreStatus) {
+	var checked int
+	var invalid, expiring []string
+	for _, status := range statuses {
+		credentials := status.Credentials
+		if credentials == nil {
+			continue
+		}
+		checked++
+		if credentials.Valid != nil && !*credentials.Valid {
+			invalid = append(invalid, fmt.Sprintf("%s: %s", status.Name, credentials.Message))
+		}
+		if credentials.Expiring {
+			expiring = append(expiring, fmt.Sprintf("%s: expires %s", status.Name, credentials.ExpiresAt.UTC().Format(time.RFC3339)))
+		}
+	}
+	switch {
+	case checked == 0:
+		r.removeCondition(config, smv1alpha1.ConditionStoreCredentialsValid)
+	case len(invalid) > 0:
+		sort.Strings(invalid)
+		r.setCondition(config, smv1alpha1.ConditionStoreCredentialsValid, "False", smv1alpha1.ReasonCredentialsInvalid,
+			"The provider rejected the credentials of "+strings.Join(invalid, "; "))
+	case len(expiring) > 0:
+		sort.Strings(expiring)
+		r.setCondition(config, smv1alpha1.ConditionStoreCredentialsValid, "False", smv1alpha1.ReasonCredentialsExpiring,
+			"Store credentials expire soon: "+strings.Join(expiring, "; "))
+	default:
+		r.setCondition(config, smv1alpha1.ConditionStoreCredentialsValid, "True", smv1alpha1.ReasonCredentialsValid,
+			fmt.Sprintf("The credentials of %d store(s) are accepted by their provider", checked))
+	}
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/cloudcredentials"
+)
+
+// newTestSTS answers GetCallerIdentity with status, counting the calls
+func newTestSTS(t *testing.T, status int, calls *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(calls, 1)
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_, _ = w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult><Arn>arn:aws:iam::123456789012:user/eso</Arn></GetCallerIdentityResult></GetCallerIdentityResponse>`))
+			return
+		}
+		_, _ = w.Write([]byte(`<ErrorResponse><Error><Code>InvalidClientTokenId</Code><Message>The security token included in the request is invalid.</Message></Error></ErrorResponse>`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestCredentialsConfig() *smv1alpha1.SecretsManagementConfig {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	config.Spec.Stores = []smv1alpha1.SecretStoreConfig{
+		{Name: "aws", Provider: smv1alpha1.SecretStoreProviderAWS, AuthSecret: "aws-creds", Region: "eu-west-1"},
+		{Name: "vault", Provider: smv1alpha1.SecretStoreProviderVault, AuthSecret: "vault-token", Address: "https://vault.example.com:8200"},
+	}
+	return config
+}
+
+func TestReconcileSecretStores_ChecksCredentials(t *testing.T) {
+	var calls int32
+	sts := newTestSTS(t, http.StatusOK, &calls)
+	config := newTestCredentialsConfig()
+	r := newTestReconciler(
+		newTestAuthSecret("aws-creds", "access-key-id", "secret-access-key"),
+		newTestAuthSecret("vault-token", "token"),
+	)
+	r.CredentialChecker = &cloudcredentials.Checker{STSEndpoint: sts.URL}
+
+	require.NoError(t, r.reconcileSecretStores(context.Background(), config))
+
+	require.Len(t, config.Status.Stores, 2)
+	credentials := config.Status.Stores[0].Credentials
+	require.NotNil(t, credentials)
+	require.NotNil(t, credentials.Valid)
+	assert.True(t, *credentials.Valid)
+	assert.Equal(t, "arn:aws:iam::123456789012:user/eso", credentials.Identity)
+	assert.Nil(t, config.Status.Stores[1].Credentials, "Vault stores are probed separately")
+	cond := findCondition(config, smv1alpha1.ConditionStoreCredentialsValid)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+
+	// Unchanged credentials are not checked again within the interval
+	require.NoError(t, r.reconcileSecretStores(context.Background(), config))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestReconcileSecretStores_RejectedCredentials(t *testing.T) {
+	var calls int32
+	sts := newTestSTS(t, http.StatusForbidden, &calls)
+	config := newTestCredentialsConfig()
+	r := newTestReconciler(
+		newTestAuthSecret("aws-creds", "access-key-id", "secret-access-key"),
+		newTestAuthSecret("vault-token", "token"),
+	)
+	r.CredentialChecker = &cloudcredentials.Checker{STSEndpoint: sts.URL}
+
+	require.NoError(t, r.reconcileSecretStores(context.Background(), config))
+
+	credentials := config.Status.Stores[0].Credentials
+	require.NotNil(t, credentials)
+	require.NotNil(t, credentials.Valid)
+	assert.False(t, *credentials.Valid)
+	cond := findCondition(config, smv1alpha1.ConditionStoreCredentialsValid)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonCredentialsInvalid, cond.Reason)
+	assert.Contains(t, cond.Message, "aws: InvalidClientTokenId")
+}
+
+func TestReconcileSecretStores_ExpiringCredentials(t *testing.T) {
+	var calls int32
+	sts := newTestSTS(t, http.StatusOK, &calls)
+	config := newTestCredentialsConfig()
+	secret := newTestAuthSecret("aws-creds", "access-key-id", "secret-access-key")
+	expiresAt := time.Now().Add(3 * 24 * time.Hour).UTC().Truncate(time.Second)
+	secret.Annotations = map[string]string{ExpiresAtAnnotation: expiresAt.Format(time.RFC3339)}
+	r := newTestReconciler(secret, newTestAuthSecret("vault-token", "token"))
+	r.CredentialChecker = &cloudcredentials.Checker{STSEndpoint: sts.URL}
+
+	require.NoError(t, r.reconcileSecretStores(context.Background(), config))
+
+	credentials := config.Status.Stores[0].Credentials
+	require.NotNil(t, credentials)
+	require.NotNil(t, credentials.ExpiresAt)
+	assert.True(t, expiresAt.Equal(credentials.ExpiresAt.Time))
+	assert.True(t, credentials.Expiring)
+	cond := findCondition(config, smv1alpha1.ConditionStoreCredentialsValid)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonCredentialsExpiring, cond.Reason)
+}
+
+func TestReconcileSecretStores_CredentialsNotChecked(t *testing.T) {
+	config := newTestCredentialsConfig()
+	r := newTestReconciler(
+		newTestAuthSecret("aws-creds", "access-key-id", "secret-access-key"),
+		newTestAuthSecret("vault-token", "token"),
+	)
+
+	require.NoError(t, r.reconcileSecretStores(context.Background(), config))
+
+	assert.Nil(t, config.Status.Stores[0].Credentials)
+	assert.Nil(t, findCondition(config, smv1alpha1.ConditionStoreCredentialsValid))
+}
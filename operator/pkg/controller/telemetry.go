@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/telemetry"
+)
+
+// telemetryResources are the kinds counted in the usage report, by the operator serving them
+var telemetryResources = []struct {
+	operator string
+	gvk      schema.GroupVersionKind
+}{
+	{"cert-manager", certificateGVK},
+	{"cert-manager", certificateGVK.GroupVersion().WithKind("Issuer")},
+	{"cert-manager", clusterIssuerGVK},
+	{"external-secrets", externalSecretGVK},
+	{"external-secrets", externalSecretGVK.GroupVersion().WithKind("SecretStore")},
+	{"external-secrets", clusterSecretStoreGVK},
+	{"secrets-store-csi", secretProviderClassGVK},
+}
+
+// reconcileTelemetry reports anonymized usage when spec.telemetry is enabled and stops reporting
+// it otherwise. Resources are only counted cluster-wide, so not in restricted mode.
+func (r *SecretsManagementConfigReconciler) reconcileTelemetry(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	if r.Telemetry == nil {
+		return nil
+	}
+	if !config.Spec.Telemetry.Enabled {
+		r.Telemetry.Report(nil)
+		return nil
+	}
+
+	detected := &config.Status.DetectedOperators
+	features := config.Spec.Features
+	usage := &telemetry.Usage{
+		Operators: map[string]bool{
+			"cert-manager":      detected.CertManager.Installed,
+			"external-secrets":  detected.ExternalSecrets.Installed,
+			"secrets-store-csi": detected.SecretsStoreCSI.Installed,
+		},
+		Resources: map[string]int{},
+		Features: map[string]bool{
+			"delete":          features.Delete.Enabled,
+			"create":          features.Create.Enabled,
+			"edit":            features.Edit.Enabled,
+			"read-only":       features.ReadOnly.Enabled,
+			"block-protected": features.Delete.BlockProtected,
+			"audit":           config.Spec.Audit.Enabled,
+			"compliance":      config.Spec.Compliance.Enabled,
+			"protection":      config.Spec.Protection.Enabled,
+		},
+	}
+
+	if !r.Restricted {
+		for _, resource := range telemetryResources {
+			if !usage.Operators[resource.operator] {
+				continue
+			}
+			count, err := r.countResources(ctx, resource.gvk)
+			if err != nil {
+				return err
+			}
+			usage.Resources[resource.gvk.Kind] = count
+		}
+	}
+
+	r.Telemetry.Report(usage)
+	return nil
+}
+
+// countResources counts the objects of a kind in every namespace. A kind its operator does not
+// serve counts as none.
+func (r *SecretsManagementConfigReconciler) countResources(ctx context.Context, gvk schema.GroupVersionKind) (int, error) {
+	count := 0
+	err := r.forEachResource(ctx, gvk, func(*unstructured.Unstructured) {
+		count++
+	})
+	return count, err
+}
+
+// forEachResource calls fn with each object of a kind in every namespace, listed a page at a
+// time. A kind its operator does not serve has no objects.
+func (r *SecretsManagementConfigReconciler) forEachResource(ctx context.Context, gvk schema.GroupVersionKind, fn func(*unstructured.Unstructured)) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	opts := []client.ListOption{client.Limit(podListPageSize)}
+	for {
+		if err := r.apiReader().List(ctx, list, opts...); err != nil {
+			if meta.IsNoMatchError(err) {
+				return nil
+			}
+			return err
+		}
+		for i := range list.Items {
+			fn(&list.Items[i])
+		}
+		if list.GetContinue() == "" {
+			return nil
+		}
+		opts = []client.ListOption{client.Limit(podListPageSize), client.Continue(list.GetContinue())}
+	}
+}
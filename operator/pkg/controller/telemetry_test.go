@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/telemetry"
+)
+
+func TestReconcileTelemetry(t *testing.T) {
+	ctx := context.Background()
+	var objects []*unstructured.Unstructured
+	for _, name := range []string{"serving", "client"} {
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(certificateGVK)
+		certificate.SetNamespace("app")
+		certificate.SetName(name)
+		objects = append(objects, certificate)
+	}
+	externalSecret := &unstructured.Unstructured{}
+	externalSecret.SetGroupVersionKind(externalSecretGVK)
+	externalSecret.SetNamespace("app")
+	externalSecret.SetName("db")
+
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.CertManager.Installed = true
+	r := newTestReconciler(objects[0], objects[1], externalSecret)
+	r.Telemetry = telemetry.NewReporter()
+
+	// Nothing is reported until the config opts in
+	require.NoError(t, r.reconcileTelemetry(ctx, config))
+	assert.Nil(t, r.Telemetry.Usage())
+
+	config.Spec.Telemetry.Enabled = true
+	require.NoError(t, r.reconcileTelemetry(ctx, config))
+	usage := r.Telemetry.Usage()
+	require.NotNil(t, usage)
+	assert.True(t, usage.Operators["cert-manager"])
+	assert.False(t, usage.Operators["external-secrets"])
+	assert.Equal(t, 2, usage.Resources["Certificate"])
+	assert.Equal(t, 0, usage.Resources["ClusterIssuer"])
+	_, counted := usage.Resources["ExternalSecret"]
+	assert.False(t, counted, "resources of operators that are not installed are not counted")
+	assert.Equal(t, config.Spec.Features.Delete.Enabled, usage.Features["delete"])
+
+	// Restricted mode reports no resource counts
+	r.Restricted = true
+	require.NoError(t, r.reconcileTelemetry(ctx, config))
+	assert.Empty(t, r.Telemetry.Usage().Resources)
+
+	// Opting out removes the report
+	config.Spec.Telemetry.Enabled = false
+	require.NoError(t, r.reconcileTelemetry(ctx, config))
+	assert.Nil(t, r.Telemetry.Usage())
+}
@@ -0,0 +1,348 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// MaxVaultServers bounds the Vault servers probed and listed in status.vault
+	MaxVaultServers = 20
+
+	// maxVaultServerUsers bounds the stores and SecretProviderClasses listed per server
+	maxVaultServerUsers = 10
+
+	// vaultProbeTimeout bounds each request to a Vault server
+	vaultProbeTimeout = 5 * time.Second
+
+	// vaultHealthPath answers 200 on active and standby nodes, 501 when Vault is not initialized
+	// and 503 when it is sealed
+	vaultHealthPath = "/v1/sys/health?standbyok=true&perfstandbyok=true"
+
+	// vaultTokenLookupPath answers 200 when the token in X-Vault-Token is valid
+	vaultTokenLookupPath = "/v1/auth/token/lookup-self"
+)
+
+// vaultServer is a Vault server to probe, with the tokens of the ClusterSecretStores using it
+type vaultServer struct {
+	address   string
+	usedBy    []string
+	caBundle  []byte
+	namespace string
+	tokens    []vaultStoreToken
+
+	// skipVerify is set when every SecretProviderClass using the server sets vaultSkipTLSVerify
+	// and no ClusterSecretStore, whose token would be sent, uses it
+	skipVerify bool
+}
+
+// vaultStoreToken is the token Secret of a ClusterSecretStore using token auth
+type vaultStoreToken struct {
+	store string
+	ref   types.NamespacedName
+	key   string
+}
+
+// vaultHealth is the part of the Vault health response the probe reads
+type vaultHealth struct {
+	Sealed bool `json:"sealed"`
+}
+
+// reconcileVaultHealth probes the Vault servers behind Vault ClusterSecretStores and the
+// SecretProviderClasses of the Vault CSI provider, since a sealed or unreachable Vault stops
+// secret sync across the cluster while every resource still looks configured. Each server's
+// health endpoint is checked, and the tokens of stores using token auth are looked up. Results
+// feed status.vault, the VaultReachable condition and the secrets_management_vault_up metric.
+// ClusterSecretStores and token Secrets cannot be read in restricted mode, so nothing is probed.
+func (r *SecretsManagementConfigReconciler) reconcileVaultHealth(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	servers, err := r.vaultServers(ctx, config)
+	if err != nil {
+		return err
+	}
+	vaultUp.Reset()
+	if len(servers) == 0 {
+		config.Status.Vault = nil
+		r.removeCondition(config, smv1alpha1.ConditionVaultReachable)
+		return nil
+	}
+
+	// Probe concurrently so one unreachable server does not hold up the others
+	statuses := make([]smv1alpha1.VaultServerStatus, len(servers))
+	var wg sync.WaitGroup
+	for i := range servers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			statuses[i] = r.probeVault(ctx, servers[i])
+		}(i)
+	}
+	wg.Wait()
+	config.Status.Vault = statuses
+
+	var unreachable, sealed, rejected []smv1alpha1.VaultServerStatus
+	for _, status := range statuses {
+		healthy := 0.0
+		switch {
+		case !status.Reachable:
+			unreachable = append(unreachable, status)
+		case status.Sealed:
+			sealed = append(sealed, status)
+		case status.TokenValid != nil && !*status.TokenValid:
+			rejected = append(rejected, status)
+		default:
+			healthy = 1
+		}
+		vaultUp.WithLabelValues(status.Server).Set(healthy)
+	}
+
+	for _, failing := range []struct {
+		servers []smv1alpha1.VaultServerStatus
+		reason  string
+	}{
+		{unreachable, smv1alpha1.ReasonVaultUnreachable},
+		{sealed, smv1alpha1.ReasonVaultSealed},
+		{rejected, smv1alpha1.ReasonVaultTokenRejected},
+	} {
+		if len(failing.servers) == 0 {
+			continue
+		}
+		first := failing.servers[0]
+		r.setCondition(config, smv1alpha1.ConditionVaultReachable, "False", failing.reason,
+			fmt.Sprintf("%d of %d Vault server(s) are failing, including %s: %s",
+				len(unreachable)+len(sealed)+len(rejected), len(statuses), first.Server, first.Message))
+		return nil
+	}
+	r.setCondition(config, smv1alpha1.ConditionVaultReachable, "True", smv1alpha1.ReasonVaultReachable,
+		fmt.Sprintf("%d Vault server(s) are reachable and unsealed", len(statuses)))
+	return nil
+}
+
+// vaultServers returns the Vault servers used by ClusterSecretStores and, when the Vault CSI
+// provider is deployed, by SecretProviderClasses, sorted by address
+func (r *SecretsManagementConfigReconciler) vaultServers(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) ([]*vaultServer, error) {
+	if r.Restricted {
+		return nil, nil
+	}
+
+	servers := map[string]*vaultServer{}
+	server := func(address, user string) *vaultServer {
+		address = strings.TrimRight(address, "/")
+		s := servers[address]
+		if s == nil {
+			s = &vaultServer{address: address, skipVerify: true}
+			servers[address] = s
+		}
+		if len(s.usedBy) < maxVaultServerUsers {
+			s.usedBy = append(s.usedBy, user)
+		}
+		return s
+	}
+
+	if config.Status.DetectedOperators.ExternalSecrets.Installed {
+		err := r.forEachResource(ctx, clusterSecretStoreGVK, func(store *unstructured.Unstructured) {
+			vault, _, _ := unstructured.NestedMap(store.Object, "spec", "provider", "vault")
+			address, _ := vault["server"].(string)
+			if address == "" {
+				return
+			}
+			s := server(address, "ClusterSecretStore/"+store.GetName())
+			s.skipVerify = false
+			if namespace, _ := vault["namespace"].(string); namespace != "" {
+				s.namespace = namespace
+			}
+			if encoded, _ := vault["caBundle"].(string); encoded != "" {
+				if ca, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+					s.caBundle = ca
+				}
+			}
+			ref, _, _ := unstructured.NestedStringMap(vault, "auth", "tokenSecretRef")
+			if ref["name"] != "" && ref["namespace"] != "" {
+				s.tokens = append(s.tokens, vaultStoreToken{
+					store: store.GetName(),
+					ref:   types.NamespacedName{Namespace: ref["namespace"], Name: ref["name"]},
+					key:   valueOr(ref["key"], "token"),
+				})
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if vaultCSIProviderDeployed(config) {
+		err := r.forEachResource(ctx, secretProviderClassGVK, func(spc *unstructured.Unstructured) {
+			if provider, _, _ := unstructured.NestedString(spc.Object, "spec", "provider"); provider != "vault" {
+				return
+			}
+			parameters, _, _ := unstructured.NestedStringMap(spc.Object, "spec", "parameters")
+			if parameters["vaultAddress"] == "" {
+				return
+			}
+			s := server(parameters["vaultAddress"], fmt.Sprintf("SecretProviderClass %s/%s", spc.GetNamespace(), spc.GetName()))
+			s.skipVerify = s.skipVerify && parameters["vaultSkipTLSVerify"] == "true"
+			if s.namespace == "" {
+				s.namespace = parameters["vaultNamespace"]
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	list := make([]*vaultServer, 0, len(servers))
+	for _, s := range servers {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].address < list[j].address })
+	if len(list) > MaxVaultServers {
+		list = list[:MaxVaultServers]
+	}
+	return list, nil
+}
+
+// vaultCSIProviderDeployed reports whether the Vault provider of the Secrets Store CSI driver was detected
+func vaultCSIProviderDeployed(config *smv1alpha1.SecretsManagementConfig) bool {
+	for _, provider := range config.Status.DetectedOperators.SecretsStoreCSI.Providers {
+		if provider.Provider == "vault" {
+			return true
+		}
+	}
+	return false
+}
+
+// probeVault checks the health endpoint of a Vault server and, when it is unsealed, looks up the
+// tokens of the ClusterSecretStores using it
+func (r *SecretsManagementConfigReconciler) probeVault(ctx context.Context, server *vaultServer) smv1alpha1.VaultServerStatus {
+	status := smv1alpha1.VaultServerStatus{Server: server.address, UsedBy: server.usedBy}
+	httpClient, err := vaultHTTPClient(server.caBundle, server.skipVerify)
+	if err != nil {
+		status.Message = err.Error()
+		return status
+	}
+
+	resp, err := vaultRequest(ctx, httpClient, server, vaultHealthPath, "")
+	if err != nil {
+		status.Message = err.Error()
+		return status
+	}
+	health := vaultHealth{}
+	_ = json.NewDecoder(resp.Body).Decode(&health)
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotImplemented:
+		status.Reachable = true
+		status.Sealed = true
+		status.Message = "Vault is not initialized"
+		return status
+	case resp.StatusCode == http.StatusServiceUnavailable || health.Sealed:
+		status.Reachable = true
+		status.Sealed = true
+		status.Message = "Vault is sealed"
+		return status
+	case resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusTooManyRequests:
+		status.Message = fmt.Sprintf("health endpoint responded with %s", resp.Status)
+		return status
+	}
+	status.Reachable = true
+
+	var rejected []string
+	checked := false
+	for _, token := range server.tokens {
+		secret := &corev1.Secret{}
+		if err := r.apiReader().Get(ctx, token.ref, secret); err != nil {
+			// The External Secrets Operator reports stores whose token Secret is missing
+			if !errors.IsNotFound(err) {
+				rejected = append(rejected, fmt.Sprintf("ClusterSecretStore/%s: %v", token.store, err))
+			}
+			continue
+		}
+		value := strings.TrimSpace(string(secret.Data[token.key]))
+		if value == "" {
+			continue
+		}
+		checked = true
+		resp, err := vaultRequest(ctx, httpClient, server, vaultTokenLookupPath, value)
+		if err != nil {
+			rejected = append(rejected, fmt.Sprintf("ClusterSecretStore/%s: %v", token.store, err))
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			rejected = append(rejected, fmt.Sprintf("ClusterSecretStore/%s: token lookup responded with %s", token.store, resp.Status))
+		}
+	}
+	if checked || len(rejected) > 0 {
+		valid := len(rejected) == 0
+		status.TokenValid = &valid
+		status.Message = strings.Join(rejected, "; ")
+	}
+	return status
+}
+
+// vaultRequest sends a GET for path to server, authenticated with token when it is set
+func vaultRequest(ctx context.Context, httpClient *http.Client, server *vaultServer, path, token string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, vaultProbeTimeout)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.address+path, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if server.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", server.namespace)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// vaultHTTPClient returns a client trusting only caBundle when it is set, or the system roots
+// otherwise. skipVerify mirrors vaultSkipTLSVerify of the SecretProviderClasses using a server;
+// it is never set for servers the probe sends a token to.
+func vaultHTTPClient(caBundle []byte, skipVerify bool) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: skipVerify}
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("caBundle holds no PEM certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Timeout: vaultProbeTimeout, Transport: transport}, nil
+}
+
+// cancelOnClose releases the request context of a response once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestVaultStore(name, server string) *unstructured.Unstructured {
+	store := &unstructured.Unstructured{}
+	store.SetGroupVersionKind(clusterSecretStoreGVK)
+	store.SetName(name)
+	_ = unstructured.SetNestedMap(store.Object, map[string]interface{}{
+		"server": server,
+		"auth": map[string]interface{}{
+			"tokenSecretRef": map[string]interface{}{"name": "vault-token", "namespace": "vault", "key": "token"},
+		},
+	}, "spec", "provider", "vault")
+	return store
+}
+
+// newTestVault serves the health endpoint with healthStatus and accepts only the token "valid"
+func newTestVault(t *testing.T, healthStatus int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/sys/health":
+			w.WriteHeader(healthStatus)
+			fmt.Fprintf(w, `{"initialized":true,"sealed":%t}`, healthStatus == http.StatusServiceUnavailable)
+		case "/v1/auth/token/lookup-self":
+			if req.Header.Get("X-Vault-Token") != "valid" {
+				w.WriteHeader(http.StatusForbidden)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestVaultToken(token string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "vault", Name: "vault-token"},
+		Data:       map[string][]byte{"token": []byte(token)},
+	}
+}
+
+func TestReconcileVaultHealth(t *testing.T) {
+	vault := newTestVault(t, http.StatusOK)
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	r := newTestReconciler(newTestVaultStore("vault", vault.URL+"/"), newTestVaultToken("valid"))
+
+	require.NoError(t, r.reconcileVaultHealth(context.Background(), config))
+
+	require.Len(t, config.Status.Vault, 1)
+	status := config.Status.Vault[0]
+	assert.Equal(t, vault.URL, status.Server, "the trailing slash is trimmed")
+	assert.Equal(t, []string{"ClusterSecretStore/vault"}, status.UsedBy)
+	assert.True(t, status.Reachable)
+	require.NotNil(t, status.TokenValid)
+	assert.True(t, *status.TokenValid)
+	assert.Equal(t, float64(1), testutil.ToFloat64(vaultUp.WithLabelValues(vault.URL)))
+	cond := findCondition(config, smv1alpha1.ConditionVaultReachable)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", cond.Status)
+}
+
+func TestReconcileVaultHealth_Sealed(t *testing.T) {
+	vault := newTestVault(t, http.StatusServiceUnavailable)
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	r := newTestReconciler(newTestVaultStore("vault", vault.URL), newTestVaultToken("valid"))
+
+	require.NoError(t, r.reconcileVaultHealth(context.Background(), config))
+
+	require.Len(t, config.Status.Vault, 1)
+	assert.True(t, config.Status.Vault[0].Sealed)
+	assert.Nil(t, config.Status.Vault[0].TokenValid, "tokens are not looked up on a sealed server")
+	assert.Equal(t, float64(0), testutil.ToFloat64(vaultUp.WithLabelValues(vault.URL)))
+	cond := findCondition(config, smv1alpha1.ConditionVaultReachable)
+	require.NotNil(t, cond)
+	assert.Equal(t, "False", cond.Status)
+	assert.Equal(t, smv1alpha1.ReasonVaultSealed, cond.Reason)
+	assert.Contains(t, cond.Message, "Vault is sealed")
+}
+
+func TestReconcileVaultHealth_TokenRejected(t *testing.T) {
+	vault := newTestVault(t, http.StatusOK)
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.ExternalSecrets.Installed = true
+	r := newTestReconciler(newTestVaultStore("vault", vault.URL), newTestVaultToken("expired"))
+
+	require.NoError(t, r.reconcileVaultHealth(context.Background(), config))
+
+	require.Len(t, config.Status.Vault, 1)
+	require.NotNil(t, config.Status.Vault[0].TokenValid)
+	assert.False(t, *config.Status.Vault[0].TokenValid)
+	cond := findCondition(config, smv1alpha1.ConditionVaultReachable)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonVaultTokenRejected, cond.Reason)
+	assert.Contains(t, cond.Message, "ClusterSecretStore/vault: token lookup responded with 403 Forbidden")
+}
+
+func TestReconcileVaultHealth_Unreachable(t *testing.T) {
+	vault := newTestVault(t, http.StatusOK)
+	vault.Close()
+	config := newTestConfig(SingletonConfigName)
+	config.Status.DetectedOperators.SecretsStoreCSI.Providers = []smv1alpha1.CSIDaemonSetStatus{{Provider: "vault"}}
+	spc := &unstructured.Unstructured{}
+	spc.SetGroupVersionKind(secretProviderClassGVK)
+	spc.SetNamespace("app")
+	spc.SetName("db")
+	_ = unstructured.SetNestedField(spc.Object, "vault", "spec", "provider")
+	_ = unstructured.SetNestedStringMap(spc.Object, map[string]string{"vaultAddress": vault.URL}, "spec", "parameters")
+	r := newTestReconciler(spc)
+
+	require.NoError(t, r.reconcileVaultHealth(context.Background(), config))
+
+	require.Len(t, config.Status.Vault, 1)
+	assert.Equal(t, []string{"SecretProviderClass app/db"}, config.Status.Vault[0].UsedBy)
+	assert.False(t, config.Status.Vault[0].Reachable)
+	cond := findCondition(config, smv1alpha1.ConditionVaultReachable)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonVaultUnreachable, cond.Reason)
+}
+
+func TestReconcileVaultHealth_NoVault(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Status.Vault = []smv1alpha1.VaultServerStatus{{Server: "https://vault.example.com"}}
+	r := newTestReconciler()
+	r.setCondition(config, smv1alpha1.ConditionVaultReachable, "False", smv1alpha1.ReasonVaultUnreachable, "")
+
+	require.NoError(t, r.reconcileVaultHealth(context.Background(), config))
+	assert.Nil(t, config.Status.Vault)
+	assert.Nil(t, findCondition(config, smv1alpha1.ConditionVaultReachable))
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// BackupTierLabel classifies the objects of the plugin namespace for Velero label selectors
+	BackupTierLabel = "secrets-management.openshift.io/backup-tier"
+
+	// BackupTierRegenerated marks objects the operator rebuilds from the config
+	BackupTierRegenerated = "regenerated"
+
+	// BackupTierBundle marks the backup bundle Secrets of spec.backup
+	BackupTierBundle = "bundle"
+
+	// VeleroExcludeLabel makes Velero leave an object out of its backups
+	VeleroExcludeLabel = "velero.io/exclude-from-backup"
+
+	// DefaultVeleroNamespace is the namespace OADP runs Velero in
+	DefaultVeleroNamespace = "openshift-adp"
+
+	// veleroPauseRequeue is how often a paused config checks whether Velero is done
+	veleroPauseRequeue = 30 * time.Second
+)
+
+var (
+	veleroBackupListGVK = schema.GroupVersionKind{
+		Group:   "velero.io",
+		Version: "v1",
+		Kind:    "BackupList",
+	}
+	veleroRestoreListGVK = schema.GroupVersionKind{
+		Group:   "velero.io",
+		Version: "v1",
+		Kind:    "RestoreList",
+	}
+)
+
+// veleroFinishedPhases are the phases of Backups and Restores that no longer touch the cluster
+var veleroFinishedPhases = map[string]bool{
+	"Completed":        true,
+	"PartiallyFailed":  true,
+	"Failed":           true,
+	"FailedValidation": true,
+	"Deleting":         true,
+}
+
+// +kubebuilder:rbac:groups=velero.io,resources=backups;restores,verbs=get;list
+
+// commonLabels returns spec.commonLabels with the backup tier labels of spec.backup.velero
+func commonLabels(config *smv1alpha1.SecretsManagementConfig) map[string]string {
+	velero := config.Spec.Backup.Velero
+	if !velero.Enabled {
+		return config.Spec.CommonLabels
+	}
+	labels := make(map[string]string, len(config.Spec.CommonLabels)+2)
+	for k, v := range config.Spec.CommonLabels {
+		labels[k] = v
+	}
+	labels[BackupTierLabel] = BackupTierRegenerated
+	if velero.ExcludeRegenerated {
+		labels[VeleroExcludeLabel] = "true"
+	}
+	return labels
+}
+
+// reconcileVeleroPause reports whether a Velero Backup or Restore covering the plugin namespace is
+// in progress, in which case the config is not reconciled until it finishes: writes during a
+// restore would race the objects Velero recreates, and writes during a backup would leave it
+// inconsistent. Restricted mode cannot read Velero's namespace and never pauses.
+func (r *SecretsManagementConfigReconciler) reconcileVeleroPause(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) (bool, error) {
+	velero := config.Spec.Backup.Velero
+	if !velero.Enabled || r.Restricted {
+		if statusCondition(config, smv1alpha1.ConditionReconcilePaused) != nil {
+			r.setCondition(config, smv1alpha1.ConditionReconcilePaused, "False", smv1alpha1.ReasonNoVeleroOperation, "Velero integration is disabled")
+		}
+		return false, nil
+	}
+	namespace := velero.Namespace
+	if namespace == "" {
+		namespace = DefaultVeleroNamespace
+	}
+
+	var active []string
+	for _, gvk := range []schema.GroupVersionKind{veleroBackupListGVK, veleroRestoreListGVK} {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		if err := r.apiReader().List(ctx, list, client.InNamespace(namespace)); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return false, err
+		}
+		kind := strings.TrimSuffix(gvk.Kind, "List")
+		for i := range list.Items {
+			if veleroOperationActive(&list.Items[i]) {
+				active = append(active, kind+" "+list.Items[i].GetName())
+			}
+		}
+	}
+
+	if len(active) == 0 {
+		r.setCondition(config, smv1alpha1.ConditionReconcilePaused, "False", smv1alpha1.ReasonNoVeleroOperation,
+			fmt.Sprintf("No Velero Backup or Restore in %s covers %s", namespace, PluginNamespace))
+		return false, nil
+	}
+	sort.Strings(active)
+	r.setCondition(config, smv1alpha1.ConditionReconcilePaused, "True", smv1alpha1.ReasonVeleroOperationInProgress,
+		fmt.Sprintf("Waiting for Velero to finish %s in %s", strings.Join(active, ", "), namespace))
+	return true, nil
+}
+
+// veleroOperationActive reports whether a Velero Backup or Restore is still running and covers
+// the plugin namespace
+func veleroOperationActive(u *unstructured.Unstructured) bool {
+	phase, _, _ := unstructured.NestedString(u.Object, "status", "phase")
+	if veleroFinishedPhases[phase] {
+		return false
+	}
+	excluded, _, _ := unstructured.NestedStringSlice(u.Object, "spec", "excludedNamespaces")
+	for _, namespace := range excluded {
+		if namespace == PluginNamespace {
+			return false
+		}
+	}
+	included, _, _ := unstructured.NestedStringSlice(u.Object, "spec", "includedNamespaces")
+	if len(included) == 0 {
+		return true
+	}
+	for _, namespace := range included {
+		if namespace == "*" || namespace == PluginNamespace {
+			return true
+		}
+	}
+	return false
+}
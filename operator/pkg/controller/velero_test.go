@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func newTestVeleroObject(kind, name, phase string, included []string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"phase": phase},
+	}}
+	if included != nil {
+		_ = unstructured.SetNestedStringSlice(u.Object, included, "spec", "includedNamespaces")
+	}
+	u.SetAPIVersion("velero.io/v1")
+	u.SetKind(kind)
+	u.SetNamespace(DefaultVeleroNamespace)
+	u.SetName(name)
+	return u
+}
+
+func TestVelero_LabelsManagedObjectsByTier(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Backup.Velero = smv1alpha1.VeleroConfig{Enabled: true, ExcludeRegenerated: true}
+	r := newTestReconciler(config)
+	reconcileTestConfig(t, r)
+
+	key := types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}
+	svc := &corev1.Service{}
+	require.NoError(t, r.Get(ctx, key, svc))
+	assert.Equal(t, BackupTierRegenerated, svc.Labels[BackupTierLabel])
+	assert.Equal(t, "true", svc.Labels[VeleroExcludeLabel])
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, key, deployment))
+	assert.Equal(t, BackupTierRegenerated, deployment.Spec.Template.Labels[BackupTierLabel])
+
+	// Disabling the integration removes the labels
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config))
+	config.Spec.Backup.Velero.Enabled = false
+	require.NoError(t, r.Update(ctx, config))
+	reconcileTestConfig(t, r)
+
+	require.NoError(t, r.Get(ctx, key, svc))
+	assert.NotContains(t, svc.Labels, BackupTierLabel)
+	assert.NotContains(t, svc.Labels, VeleroExcludeLabel)
+}
+
+func TestVelero_PausesDuringRestore(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Backup.Velero.Enabled = true
+	restore := newTestVeleroObject("Restore", "secrets", "InProgress", []string{PluginNamespace})
+	r := newTestReconciler(config, restore)
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: SingletonConfigName}})
+	require.NoError(t, err)
+	assert.Equal(t, veleroPauseRequeue, result.RequeueAfter)
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config))
+	cond := findCondition(config, smv1alpha1.ConditionReconcilePaused)
+	require.NotNil(t, cond)
+	assert.Equal(t, "True", string(cond.Status))
+	assert.Contains(t, cond.Message, "Restore secrets")
+
+	// Nothing was deployed while paused
+	err = r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, &appsv1.Deployment{})
+	assert.Error(t, err)
+
+	// Reconciling resumes once the restore completes
+	require.NoError(t, unstructured.SetNestedField(restore.Object, "Completed", "status", "phase"))
+	require.NoError(t, r.Update(ctx, restore))
+	reconcileTestConfig(t, r)
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, config))
+	cond = findCondition(config, smv1alpha1.ConditionReconcilePaused)
+	require.NotNil(t, cond)
+	assert.Equal(t, smv1alpha1.ReasonNoVeleroOperation, cond.Reason)
+	assert.NoError(t, r.Get(ctx, types.NamespacedName{Name: "ocp-secrets-management-plugin", Namespace: PluginNamespace}, &appsv1.Deployment{}))
+}
+
+func TestVeleroOperationActive(t *testing.T) {
+	excluded := newTestVeleroObject("Backup", "all-but-plugin", "InProgress", nil)
+	_ = unstructured.SetNestedStringSlice(excluded.Object, []string{PluginNamespace}, "spec", "excludedNamespaces")
+
+	tests := []struct {
+		name   string
+		object *unstructured.Unstructured
+		want   bool
+	}{
+		{name: "whole cluster", object: newTestVeleroObject("Backup", "b", "InProgress", nil), want: true},
+		{name: "wildcard", object: newTestVeleroObject("Backup", "b", "New", []string{"*"}), want: true},
+		{name: "plugin namespace", object: newTestVeleroObject("Restore", "r", "", []string{PluginNamespace}), want: true},
+		{name: "other namespace", object: newTestVeleroObject("Restore", "r", "InProgress", []string{"tenant"}), want: false},
+		{name: "excluded", object: excluded, want: false},
+		{name: "completed", object: newTestVeleroObject("Backup", "b", "Completed", nil), want: false},
+		{name: "failed", object: newTestVeleroObject("Restore", "r", "PartiallyFailed", nil), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, veleroOperationActive(tt.object))
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+const (
+	// RuntimeConfigKey is the key of the plugin runtime config in its ConfigMap
+	RuntimeConfigKey = "config.json"
+
+	// RuntimeConfigMountPath is where the runtime config ConfigMap is mounted, under the nginx root,
+	// so the plugin fetches it from runtime/config.json below its base path. A directory mount
+	// picks up changes without restarting the pods.
+	RuntimeConfigMountPath = "/usr/share/nginx/html/runtime"
+
+	// VisibilityRoleLabel marks the view Roles generated in visible namespaces; its value is the
+	// instance they were generated for
+	VisibilityRoleLabel = "secrets-management.openshift.io/visibility-role"
+)
+
+// pluginRuntimeConfig is the runtime configuration the console plugin reads
+type pluginRuntimeConfig struct {
+	Visibility runtimeVisibility `json:"visibility"`
+
+	// ReadOnly hides the plugin's create, edit and delete actions
+	ReadOnly bool `json:"readOnly"`
+
+	// HideCertManager, HideESO and HideCSI remove the pages of the operators disabled in
+	// spec.operators. With all three set, the plugin drops its navigation entry as well.
+	HideCertManager bool `json:"hideCertManager"`
+	HideESO         bool `json:"hideESO"`
+	HideCSI         bool `json:"hideCSI"`
+}
+
+// runtimeVisibility tells the plugin which namespaces to show. All are shown unless Restricted.
+type runtimeVisibility struct {
+	Restricted bool     `json:"restricted"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// reconcileVisibility renders the namespaces selected by spec.visibility into the plugin runtime
+// config and keeps a view Role in each of them, for tenants to be bound to. In restricted mode the
+// operator cannot manage Roles outside the plugin namespace, so none are generated.
+func (r *SecretsManagementConfigReconciler) reconcileVisibility(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) error {
+	namespaces, err := r.visibleNamespaces(ctx, config)
+	if err != nil {
+		return err
+	}
+	if err := r.reconcileRuntimeConfig(ctx, config, namespaces); err != nil {
+		return err
+	}
+
+	var desired []string
+	if namespaces != nil && config.Spec.RBAC.CreateDefaultRoles && !r.Restricted {
+		desired = namespaces
+	}
+	return r.reconcileVisibilityRoles(ctx, config, desired)
+}
+
+// visibleNamespaces returns the sorted namespaces spec.visibility selects, or nil when every
+// namespace is visible. In restricted mode Namespaces cannot be listed, so only Include applies.
+// The primary config also shows the namespaces accepted for SecretsManagementTenants, unless
+// they are excluded.
+func (r *SecretsManagementConfigReconciler) visibleNamespaces(ctx context.Context, config *smv1alpha1.SecretsManagementConfig) ([]string, error) {
+	selector := config.Spec.Visibility.NamespaceSelector
+	if selector == nil {
+		return nil, nil
+	}
+
+	visible := map[string]bool{}
+	if !r.Restricted && (len(selector.MatchLabels) > 0 || len(selector.Include) == 0) {
+		// Namespaces outside the plugin namespace are not cached
+		list := &corev1.NamespaceList{}
+		if err := r.apiReader().List(ctx, list, client.MatchingLabels(selector.MatchLabels)); err != nil {
+			return nil, err
+		}
+		for _, ns := range list.Items {
+			visible[ns.Name] = true
+		}
+	}
+	for _, name := range selector.Include {
+		visible[name] = true
+	}
+	if !r.Restricted && isPrimaryConfig(config) {
+		tenants := &smv1alpha1.SecretsManagementTenantList{}
+		if err := r.List(ctx, tenants); err != nil {
+			return nil, err
+		}
+		for _, tenant := range tenants.Items {
+			for _, name := range tenant.Status.Namespaces {
+				visible[name] = true
+			}
+		}
+	}
+	for _, name := range selector.Exclude {
+		delete(visible, name)
+	}
+
+	namespaces := make([]string, 0, len(visible))
+	for name := range visible {
+		namespaces = append(namespaces, name)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// primaryConfigForTenant enqueues the primary config when a tenant changes, since its accepted
+// namespaces are added to the plugin visibility
+func primaryConfigForTenant(_ context.Context, _ client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: SingletonConfigName}}}
+}
+
+// reconcileRuntimeConfig writes the plugin runtime config ConfigMap
+func (r *SecretsManagementConfigReconciler) reconcileRuntimeConfig(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, namespaces []string) error {
+	runtimeConfig := pluginRuntimeConfig{
+		Visibility: runtimeVisibility{Restricted: namespaces != nil, Namespaces: namespaces},
+		ReadOnly:   config.Spec.Features.ReadOnly.Enabled,
+
+		HideCertManager: !config.Spec.Operators.CertManager.Enabled,
+		HideESO:         !config.Spec.Operators.ExternalSecrets.Enabled,
+		HideCSI:         !config.Spec.Operators.SecretsStoreCSI.Enabled,
+	}
+	if runtimeConfig.Visibility.Namespaces == nil {
+		runtimeConfig.Visibility.Namespaces = []string{}
+	}
+	data, err := json.Marshal(runtimeConfig)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-runtime-config", instanceName(config)),
+			Namespace: PluginNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       instanceName(config),
+				"app.kubernetes.io/part-of":    "ocp-secrets-management",
+				"app.kubernetes.io/managed-by": "secrets-management-operator",
+			},
+		},
+		Data: map[string]string{
+			RuntimeConfigKey: string(data),
+		},
+	}
+	applyCommonMetadata(config, cm)
+	if err := setAppliedSpecHash(cm); err != nil {
+		return err
+	}
+
+	existing := &corev1.ConfigMap{}
+	err = r.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, existing)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return r.Create(ctx, cm)
+		}
+		return err
+	}
+
+	before := existing.DeepCopy()
+	if specChanged(existing, cm, existing.Data, cm.Data) {
+		existing.Data = cm.Data
+	}
+	mergeMetadata(existing, cm)
+	return updateIfChanged(ctx, r, before, existing)
+}
+
+// reconcileVisibilityRoles keeps a view Role in each of namespaces and deletes the ones generated
+// for config in other namespaces
+func (r *SecretsManagementConfigReconciler) reconcileVisibilityRoles(ctx context.Context, config *smv1alpha1.SecretsManagementConfig, namespaces []string) error {
+	desired := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		desired[namespace] = true
+		role := buildVisibilityRole(config, namespace)
+		applyCommonMetadata(config, role)
+
+		existing := &rbacv1.Role{}
+		err := r.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: namespace}, existing)
+		if errors.IsNotFound(err) {
+			if err := r.Create(ctx, role); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		before := existing.DeepCopy()
+		existing.Rules = role.Rules
+		mergeMetadata(existing, role)
+		if err := updateIfChanged(ctx, r, before, existing); err != nil {
+			return err
+		}
+	}
+
+	roles := &rbacv1.RoleList{}
+	if err := r.List(ctx, roles, client.MatchingLabels{VisibilityRoleLabel: instanceName(config)}); err != nil {
+		return err
+	}
+	for i := range roles.Items {
+		role := &roles.Items[i]
+		if desired[role.Namespace] {
+			continue
+		}
+		if err := r.Delete(ctx, role); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildVisibilityRole returns the Role granting read access to the namespaced secrets-management
+// resources in namespace
+func buildVisibilityRole(config *smv1alpha1.SecretsManagementConfig, namespace string) *rbacv1.Role {
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-view", rolePrefix(config)),
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "secrets-management-operator",
+				"app.kubernetes.io/part-of":    "ocp-secrets-management",
+				VisibilityRoleLabel:            instanceName(config),
+			},
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"cert-manager.io"},
+				Resources: []string{"certificates", "issuers"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"external-secrets.io"},
+				Resources: []string{"externalsecrets", "secretstores", "pushsecrets"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"secrets-store.csi.x-k8s.io"},
+				Resources: []string{"secretproviderclasses", "secretproviderclasspodstatuses"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+	role.Rules = withCoreSecrets(config, role.Rules, coreSecretsViewVerbs...)
+	return role
+}
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+func testNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func readRuntimeConfig(t *testing.T, r *SecretsManagementConfigReconciler) pluginRuntimeConfig {
+	t.Helper()
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{
+		Name:      "ocp-secrets-management-runtime-config",
+		Namespace: PluginNamespace,
+	}, cm))
+	var runtimeConfig pluginRuntimeConfig
+	require.NoError(t, json.Unmarshal([]byte(cm.Data[RuntimeConfigKey]), &runtimeConfig))
+	return runtimeConfig
+}
+
+func TestVisibleNamespaces(t *testing.T) {
+	ctx := context.Background()
+	team := map[string]string{"team": "payments"}
+	namespaces := []*corev1.Namespace{
+		testNamespace("payments-dev", team),
+		testNamespace("payments-prod", team),
+		testNamespace("billing", nil),
+	}
+
+	tests := []struct {
+		name       string
+		selector   *smv1alpha1.NamespaceSelectorConfig
+		restricted bool
+		want       []string
+	}{
+		{name: "no selector", selector: nil, want: nil},
+		{name: "empty selector selects all", selector: &smv1alpha1.NamespaceSelectorConfig{},
+			want: []string{"billing", "payments-dev", "payments-prod"}},
+		{name: "labels", selector: &smv1alpha1.NamespaceSelectorConfig{MatchLabels: team},
+			want: []string{"payments-dev", "payments-prod"}},
+		{name: "labels with include and exclude", selector: &smv1alpha1.NamespaceSelectorConfig{
+			MatchLabels: team, Include: []string{"billing"}, Exclude: []string{"payments-prod"}},
+			want: []string{"billing", "payments-dev"}},
+		{name: "include only", selector: &smv1alpha1.NamespaceSelectorConfig{Include: []string{"billing"}},
+			want: []string{"billing"}},
+		{name: "restricted ignores labels", restricted: true, selector: &smv1alpha1.NamespaceSelectorConfig{
+			MatchLabels: team, Include: []string{"billing"}},
+			want: []string{"billing"}},
+		{name: "everything excluded", selector: &smv1alpha1.NamespaceSelectorConfig{
+			Include: []string{"billing"}, Exclude: []string{"billing"}},
+			want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newTestConfig(SingletonConfigName)
+			config.Spec.Visibility.NamespaceSelector = tt.selector
+			r := newTestReconciler(namespaces[0], namespaces[1], namespaces[2])
+			r.Restricted = tt.restricted
+
+			got, err := r.visibleNamespaces(ctx, config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVisibleNamespaces_Tenants(t *testing.T) {
+	tenant := &smv1alpha1.SecretsManagementTenant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "team"},
+		Status:     smv1alpha1.SecretsManagementTenantStatus{Namespaces: []string{"payments", "payments-prod"}},
+	}
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Visibility.NamespaceSelector = &smv1alpha1.NamespaceSelectorConfig{Include: []string{"billing"}, Exclude: []string{"payments-prod"}}
+	r := newTestReconciler(tenant)
+
+	got, err := r.visibleNamespaces(context.Background(), config)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"billing", "payments"}, got)
+
+	// Canary configs keep their own selection
+	canary := newTestConfig("canary")
+	canary.Spec.Visibility = config.Spec.Visibility
+	got, err = r.visibleNamespaces(context.Background(), canary)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"billing"}, got)
+}
+
+func TestReconcileVisibility_RuntimeConfigAndRoles(t *testing.T) {
+	ctx := context.Background()
+	config := newTestConfig(SingletonConfigName)
+	r := newTestReconciler(config,
+		testNamespace("payments-dev", map[string]string{"team": "payments"}),
+		testNamespace("billing", nil))
+	reconcileTestConfig(t, r)
+
+	// Without a selector every namespace is visible and no Roles are generated
+	runtimeConfig := readRuntimeConfig(t, r)
+	assert.False(t, runtimeConfig.Visibility.Restricted)
+	assert.Empty(t, runtimeConfig.Visibility.Namespaces)
+	roles := &rbacv1.RoleList{}
+	require.NoError(t, r.List(ctx, roles))
+	assert.Empty(t, roles.Items)
+
+	current := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, current))
+	current.Spec.Visibility.NamespaceSelector = &smv1alpha1.NamespaceSelectorConfig{
+		MatchLabels: map[string]string{"team": "payments"},
+		Include:     []string{"billing"},
+	}
+	require.NoError(t, r.Update(ctx, current))
+	reconcileTestConfig(t, r)
+
+	runtimeConfig = readRuntimeConfig(t, r)
+	assert.True(t, runtimeConfig.Visibility.Restricted)
+	assert.Equal(t, []string{"billing", "payments-dev"}, runtimeConfig.Visibility.Namespaces)
+
+	role := &rbacv1.Role{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-view", Namespace: "payments-dev"}, role))
+	assert.Equal(t, PluginName, role.Labels[VisibilityRoleLabel])
+	assert.False(t, rulesGrantSecretRead(role.Rules), "view roles must not grant reading Secrets")
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-view", Namespace: "billing"}, role))
+
+	// Excluding a namespace removes its Role
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, current))
+	current.Spec.Visibility.NamespaceSelector.Exclude = []string{"billing"}
+	require.NoError(t, r.Update(ctx, current))
+	reconcileTestConfig(t, r)
+
+	err := r.Get(ctx, types.NamespacedName{Name: "secrets-management-view", Namespace: "billing"}, role)
+	assert.True(t, errors.IsNotFound(err))
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "secrets-management-view", Namespace: "payments-dev"}, role))
+
+	// Cleanup removes the remaining Roles
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: SingletonConfigName}, current))
+	require.NoError(t, r.cleanupRBAC(ctx, current))
+	require.NoError(t, r.List(ctx, roles))
+	assert.Empty(t, roles.Items)
+}
+
+func TestReconcileRuntimeConfig_HiddenOperators(t *testing.T) {
+	config := newTestConfig(SingletonConfigName)
+	config.Spec.Operators.ExternalSecrets.Enabled = false
+	r := newTestReconciler(config)
+	reconcileTestConfig(t, r)
+
+	runtimeConfig := readRuntimeConfig(t, r)
+	assert.False(t, runtimeConfig.HideCertManager)
+	assert.True(t, runtimeConfig.HideESO)
+	assert.False(t, runtimeConfig.HideCSI)
+}
@@ -0,0 +1,243 @@
+// Package crdschema serves simplified JSON schemas of the resources the console plugin edits.
+// The plugin generates its create and edit forms from them, so the forms follow the versions of
+// external-secrets, cert-manager and the Secrets Store CSI driver installed on the cluster instead
+// of the ones the plugin was built against.
+package crdschema
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/apiauth"
+)
+
+// SchemasPath is the endpoint the plugin reaches through the console proxy. GET returns the
+// schemas of the installed Resources; the kind query parameter selects one.
+const SchemasPath = "/api/v1/schemas"
+
+// Resource is a kind the plugin has forms for
+type Resource struct {
+	Kind string
+	CRD  string
+}
+
+// Resources are the kinds whose schemas are served
+var Resources = []Resource{
+	{Kind: "ExternalSecret", CRD: "externalsecrets.external-secrets.io"},
+	{Kind: "Certificate", CRD: "certificates.cert-manager.io"},
+	{Kind: "SecretProviderClass", CRD: "secretproviderclasses.secrets-store.csi.x-k8s.io"},
+}
+
+// Response is the body served at SchemasPath
+type Response struct {
+	Schemas []ResourceSchema `json:"schemas"`
+}
+
+// ResourceSchema is the schema of the spec of one kind, in the version the plugin should create
+type ResourceSchema struct {
+	Kind    string `json:"kind"`
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	// ResourceVersion is the CRD's, so the plugin can tell when a form must be rebuilt
+	ResourceVersion string  `json:"resourceVersion"`
+	Spec            *Schema `json:"spec"`
+}
+
+// Schema is the subset of an OpenAPI v3 schema the forms are generated from
+type Schema struct {
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Enum        []json.RawMessage  `json:"enum,omitempty"`
+	Default     json.RawMessage    `json:"default,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	// AdditionalProperties is the schema of the values of a map
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+	// FreeForm marks fields that accept any value, which the forms edit as YAML
+	FreeForm bool `json:"freeForm,omitempty"`
+}
+
+// Handler serves SchemasPath to authenticated users. The console proxy forwards the user's
+// bearer token, which is checked with a TokenReview. Simplified schemas are cached by CRD
+// resourceVersion.
+type Handler struct {
+	// Tokens authenticates the callers, sharing its cache with the operator's other endpoints
+	Tokens *apiauth.TokenCache
+	Log    logr.Logger
+
+	// APIReader reads CustomResourceDefinitions. It must not be the manager's cache, which drops
+	// their schemas.
+	APIReader client.Reader
+
+	mu    sync.Mutex
+	cache map[string]ResourceSchema
+}
+
+// ServeHTTP writes the schemas of the installed resources. Resources whose operator is not
+// installed are left out; asking for one of them by kind is answered with 404.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resources := Resources
+	if kind := req.URL.Query().Get("kind"); kind != "" {
+		resources = nil
+		for _, resource := range Resources {
+			if resource.Kind == kind {
+				resources = []Resource{resource}
+			}
+		}
+		if resources == nil {
+			http.Error(w, "unknown kind", http.StatusNotFound)
+			return
+		}
+	}
+
+	ctx := req.Context()
+	user, err := h.Tokens.Authenticate(ctx, req)
+	if err != nil {
+		h.Log.Error(err, "Failed to authenticate schema request")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if user == nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	response := Response{Schemas: []ResourceSchema{}}
+	for _, resource := range resources {
+		schema, found, err := h.schema(ctx, resource)
+		if err != nil {
+			h.Log.Error(err, "Failed to read CustomResourceDefinition", "name", resource.CRD)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		if found {
+			response.Schemas = append(response.Schemas, schema)
+		}
+	}
+	if len(resources) == 1 && len(response.Schemas) == 0 {
+		http.Error(w, resources[0].Kind+" is not installed", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// schema returns the simplified schema of resource, and false when its CRD is not installed or
+// serves no version
+func (h *Handler) schema(ctx context.Context, resource Resource) (ResourceSchema, bool, error) {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := h.APIReader.Get(ctx, types.NamespacedName{Name: resource.CRD}, crd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ResourceSchema{}, false, nil
+		}
+		return ResourceSchema{}, false, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if cached, ok := h.cache[resource.CRD]; ok && cached.ResourceVersion == crd.ResourceVersion {
+		return cached, true, nil
+	}
+
+	version := preferredVersion(crd)
+	if version == nil {
+		return ResourceSchema{}, false, nil
+	}
+	schema := ResourceSchema{
+		Kind:            crd.Spec.Names.Kind,
+		Group:           crd.Spec.Group,
+		Version:         version.Name,
+		ResourceVersion: crd.ResourceVersion,
+	}
+	if version.Schema != nil && version.Schema.OpenAPIV3Schema != nil {
+		if spec, ok := version.Schema.OpenAPIV3Schema.Properties["spec"]; ok {
+			schema.Spec = Simplify(&spec)
+		}
+	}
+	if h.cache == nil {
+		h.cache = map[string]ResourceSchema{}
+	}
+	h.cache[resource.CRD] = schema
+	return schema, true, nil
+}
+
+// preferredVersion returns the storage version when it is served, else the first served version
+func preferredVersion(crd *apiextensionsv1.CustomResourceDefinition) *apiextensionsv1.CustomResourceDefinitionVersion {
+	var first *apiextensionsv1.CustomResourceDefinitionVersion
+	for i := range crd.Spec.Versions {
+		version := &crd.Spec.Versions[i]
+		if !version.Served {
+			continue
+		}
+		if version.Storage {
+			return version
+		}
+		if first == nil {
+			first = version
+		}
+	}
+	return first
+}
+
+// Simplify keeps the parts of props the forms use. Combinators (allOf, oneOf, anyOf, not) and
+// Kubernetes extensions other than int-or-string and preserve-unknown-fields are dropped.
+func Simplify(props *apiextensionsv1.JSONSchemaProps) *Schema {
+	if props == nil {
+		return nil
+	}
+	schema := &Schema{
+		Type:        props.Type,
+		Format:      props.Format,
+		Description: props.Description,
+		Required:    props.Required,
+		Pattern:     props.Pattern,
+		Minimum:     props.Minimum,
+		Maximum:     props.Maximum,
+	}
+	for _, value := range props.Enum {
+		schema.Enum = append(schema.Enum, json.RawMessage(value.Raw))
+	}
+	if props.Default != nil {
+		schema.Default = json.RawMessage(props.Default.Raw)
+	}
+	if props.XIntOrString {
+		schema.Type, schema.Format = "string", "int-or-string"
+	}
+	if props.XPreserveUnknownFields != nil && *props.XPreserveUnknownFields && len(props.Properties) == 0 {
+		schema.FreeForm = true
+	}
+	if len(props.Properties) > 0 {
+		schema.Properties = make(map[string]*Schema, len(props.Properties))
+		for name, property := range props.Properties {
+			property := property
+			schema.Properties[name] = Simplify(&property)
+		}
+	}
+	if props.Items != nil {
+		schema.Items = Simplify(props.Items.Schema)
+	}
+	if props.AdditionalProperties != nil && props.AdditionalProperties.Schema != nil {
+		schema.AdditionalProperties = Simplify(props.AdditionalProperties.Schema)
+	}
+	return schema
+}
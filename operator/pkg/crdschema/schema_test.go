@@ -0,0 +1,194 @@
+package crdschema
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/apiauth"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/controller"
+)
+
+// newTestHandler returns a handler whose TokenReviews accept "<user>-token"
+func newTestHandler(objs ...client.Object) *Handler {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if review, ok := obj.(*authenticationv1.TokenReview); ok {
+					if user, ok := strings.CutSuffix(review.Spec.Token, "-token"); ok {
+						review.Status.Authenticated = true
+						review.Status.User.Username = user
+					}
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	return &Handler{Tokens: &apiauth.TokenCache{Client: c}, Log: logr.Discard(), APIReader: c}
+}
+
+func newTestCertificateCRD() *apiextensionsv1.CustomResourceDefinition {
+	preserve := true
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "certificates.cert-manager.io"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "cert-manager.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Certificate"},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha2", Served: false},
+				{
+					Name: "v1", Served: true, Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"spec": {
+								Type:     "object",
+								Required: []string{"secretName"},
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"secretName": {Type: "string", Description: "SecretName is the name of the Secret"},
+									"dnsNames":   {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"}}},
+									"privateKey": {Type: "object", Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"algorithm": {Type: "string", Enum: []apiextensionsv1.JSON{{Raw: []byte(`"RSA"`)}, {Raw: []byte(`"ECDSA"`)}}},
+									}},
+									"renewBefore":    {XIntOrString: true, AnyOf: []apiextensionsv1.JSONSchemaProps{{Type: "integer"}, {Type: "string"}}},
+									"additionalData": {Type: "object", XPreserveUnknownFields: &preserve},
+									"secretTemplate": {Type: "object", Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"labels": {Type: "object", AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"}}},
+									}},
+								},
+							},
+							"status": {Type: "object"},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func getSchemas(t *testing.T, h *Handler, query, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, SchemasPath+query, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	h := newTestHandler(newTestCertificateCRD())
+
+	rec := getSchemas(t, h, "", "alice-token")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var response Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	// Only the installed kinds are served
+	require.Len(t, response.Schemas, 1)
+	certificate := response.Schemas[0]
+	assert.Equal(t, "Certificate", certificate.Kind)
+	assert.Equal(t, "cert-manager.io", certificate.Group)
+	assert.Equal(t, "v1", certificate.Version)
+
+	spec := certificate.Spec
+	require.NotNil(t, spec)
+	assert.Equal(t, []string{"secretName"}, spec.Required)
+	assert.Equal(t, "SecretName is the name of the Secret", spec.Properties["secretName"].Description)
+	assert.Equal(t, "string", spec.Properties["dnsNames"].Items.Type)
+	assert.Equal(t, []json.RawMessage{json.RawMessage(`"RSA"`), json.RawMessage(`"ECDSA"`)}, spec.Properties["privateKey"].Properties["algorithm"].Enum)
+	assert.Equal(t, &Schema{Type: "string", Format: "int-or-string"}, spec.Properties["renewBefore"])
+	assert.True(t, spec.Properties["additionalData"].FreeForm)
+	assert.Equal(t, "string", spec.Properties["secretTemplate"].Properties["labels"].AdditionalProperties.Type)
+}
+
+func TestHandler_Kind(t *testing.T) {
+	h := newTestHandler(newTestCertificateCRD())
+
+	rec := getSchemas(t, h, "?kind=Certificate", "alice-token")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = getSchemas(t, h, "?kind=ExternalSecret", "alice-token")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "ExternalSecret is not installed")
+
+	rec = getSchemas(t, h, "?kind=Secret", "alice-token")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandler_Unauthenticated(t *testing.T) {
+	h := newTestHandler(newTestCertificateCRD())
+
+	assert.Equal(t, http.StatusUnauthorized, getSchemas(t, h, "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, getSchemas(t, h, "", "forged").Code)
+
+	req := httptest.NewRequest(http.MethodPost, SchemasPath, nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestHandler_ReadsSchemasPastCache(t *testing.T) {
+	// The manager's cache keeps CRDs without their schemas
+	var transform func(interface{}) (interface{}, error)
+	for obj, byObject := range controller.CacheOptions(false).ByObject {
+		if _, ok := obj.(*apiextensionsv1.CustomResourceDefinition); ok {
+			transform = byObject.Transform
+		}
+	}
+	require.NotNil(t, transform)
+	cached, err := transform(newTestCertificateCRD())
+	require.NoError(t, err)
+	require.Nil(t, cached.(*apiextensionsv1.CustomResourceDefinition).Spec.Versions[1].Schema)
+
+	h := newTestHandler(cached.(client.Object))
+	h.APIReader = newTestHandler(newTestCertificateCRD()).APIReader
+
+	var response Response
+	require.NoError(t, json.Unmarshal(getSchemas(t, h, "", "alice-token").Body.Bytes(), &response))
+	require.Len(t, response.Schemas, 1)
+	require.NotNil(t, response.Schemas[0].Spec)
+	assert.Contains(t, response.Schemas[0].Spec.Properties, "secretName")
+}
+
+func TestHandler_CRDUpdated(t *testing.T) {
+	crd := newTestCertificateCRD()
+	h := newTestHandler(crd)
+	ctx := context.Background()
+
+	require.Equal(t, http.StatusOK, getSchemas(t, h, "", "alice-token").Code)
+
+	// A new version of the operator adds a field
+	c := h.APIReader.(client.Client)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(crd), crd))
+	crd.Spec.Versions[1].Schema.OpenAPIV3Schema.Properties["spec"].Properties["duration"] = apiextensionsv1.JSONSchemaProps{Type: "string"}
+	require.NoError(t, c.Update(ctx, crd))
+
+	var response Response
+	require.NoError(t, json.Unmarshal(getSchemas(t, h, "", "alice-token").Body.Bytes(), &response))
+	require.Len(t, response.Schemas, 1)
+	assert.Contains(t, response.Schemas[0].Spec.Properties, "duration")
+}
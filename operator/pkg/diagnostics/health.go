@@ -0,0 +1,83 @@
+package diagnostics
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// checkTimeout bounds how long a check waits on the API server or the cache
+const checkTimeout = 2 * time.Second
+
+// CacheSyncer is the part of the manager's cache the cache-sync check uses
+type CacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// APIServerCheck fails when the API server does not answer a version request
+func APIServerCheck(d discovery.ServerVersionInterface) healthz.Checker {
+	return func(*http.Request) error {
+		if _, err := d.ServerVersion(); err != nil {
+			return fmt.Errorf("API server unreachable: %w", err)
+		}
+		return nil
+	}
+}
+
+// CacheSyncCheck fails until every informer the operator started has synced
+func CacheSyncCheck(c CacheSyncer) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), checkTimeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return fmt.Errorf("informer caches have not synced")
+		}
+		return nil
+	}
+}
+
+// CertificateCheck fails when tls.crt in dir cannot be read or parsed, or is not valid at the
+// time now returns
+func CertificateCheck(dir string, now func() time.Time) healthz.Checker {
+	return func(*http.Request) error {
+		path := filepath.Join(dir, "tls.crt")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading serving certificate: %w", err)
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return fmt.Errorf("%s holds no PEM certificate", path)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("parsing serving certificate: %w", err)
+		}
+		t := now()
+		if t.Before(cert.NotBefore) {
+			return fmt.Errorf("serving certificate is not valid until %s", cert.NotBefore.UTC().Format(time.RFC3339))
+		}
+		if t.After(cert.NotAfter) {
+			return fmt.Errorf("serving certificate expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+		return nil
+	}
+}
+
+// APIGroupCheck fails when the API server does not serve groupVersion, such as console.openshift.io/v1
+func APIGroupCheck(d discovery.DiscoveryInterface, groupVersion string) healthz.Checker {
+	return func(*http.Request) error {
+		if _, err := d.ServerResourcesForGroupVersion(groupVersion); err != nil {
+			return fmt.Errorf("%s is not served: %w", groupVersion, err)
+		}
+		return nil
+	}
+}
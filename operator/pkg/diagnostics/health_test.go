@@ -0,0 +1,79 @@
+package diagnostics
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeCache bool
+
+func (c fakeCache) WaitForCacheSync(context.Context) bool {
+	return bool(c)
+}
+
+func writeTestCert(t *testing.T, dir string, notBefore, notAfter time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "operator"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tls.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+}
+
+func TestCertificateCheck(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	check := CertificateCheck(dir, func() time.Time { return now })
+	req := httptest.NewRequest("GET", "/readyz/audit-cert", nil)
+
+	assert.ErrorContains(t, check(req), "reading serving certificate")
+
+	writeTestCert(t, dir, now.AddDate(0, -1, 0), now.AddDate(1, 0, 0))
+	assert.NoError(t, check(req))
+
+	writeTestCert(t, dir, now.AddDate(-1, 0, 0), now.AddDate(0, 0, -1))
+	assert.ErrorContains(t, check(req), "serving certificate expired at 2026-02-28")
+}
+
+func TestCacheSyncCheck(t *testing.T) {
+	req := httptest.NewRequest("GET", "/readyz/cache-sync", nil)
+	assert.NoError(t, CacheSyncCheck(fakeCache(true))(req))
+	assert.ErrorContains(t, CacheSyncCheck(fakeCache(false))(req), "not synced")
+}
+
+func TestDiscoveryChecks(t *testing.T) {
+	d := fake.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	d.FakedServerVersion = &version.Info{GitVersion: "v1.29.0"}
+	req := httptest.NewRequest("GET", "/readyz", nil)
+
+	assert.NoError(t, APIServerCheck(d)(req))
+
+	check := APIGroupCheck(d, "console.openshift.io/v1")
+	assert.ErrorContains(t, check(req), "console.openshift.io/v1 is not served")
+	d.Resources = []*metav1.APIResourceList{{GroupVersion: "console.openshift.io/v1"}}
+	assert.NoError(t, check(req))
+}
@@ -0,0 +1,26 @@
+package diagnostics
+
+import (
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// MetricsReaderClusterRole grants get on /metrics, for Prometheus to scrape the operator
+const MetricsReaderClusterRole = "secrets-management-operator-metrics-reader"
+
+// WithAuthenticationAndAuthorization is a metrics server FilterProvider that serves /metrics only
+// to callers allowed to get it, such as those bound to MetricsReaderClusterRole. It stands in for
+// controller-runtime's filter of the same name, which would pull k8s.io/apiserver into the module.
+func WithAuthenticationAndAuthorization(config *rest.Config, httpClient *http.Client) (metricsserver.Filter, error) {
+	c, err := client.New(config, client.Options{HTTPClient: httpClient})
+	if err != nil {
+		return nil, err
+	}
+	return func(log logr.Logger, handler http.Handler) (http.Handler, error) {
+		return Authorized(c, log, handler), nil
+	}, nil
+}
@@ -0,0 +1,30 @@
+package diagnostics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestWithAuthenticationAndAuthorization(t *testing.T) {
+	config := &rest.Config{Host: "https://127.0.0.1:1"}
+	httpClient, err := rest.HTTPClientFor(config)
+	require.NoError(t, err)
+
+	filter, err := WithAuthenticationAndAuthorization(config, httpClient)
+	require.NoError(t, err)
+	served := false
+	handler, err := filter(logr.Discard(), http.HandlerFunc(func(http.ResponseWriter, *http.Request) { served = true }))
+	require.NoError(t, err)
+
+	// Requests without a token are turned away before the API server is asked
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.False(t, served)
+}
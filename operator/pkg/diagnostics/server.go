@@ -0,0 +1,127 @@
+// Package diagnostics serves the Go runtime profiles (pprof) and expvar variables of the operator
+// over TLS, and guards them and the metrics endpoint so that only callers whose bearer token the
+// cluster authorizes to get the requested path are served. It also provides the component checks
+// behind the operator's readiness endpoint.
+package diagnostics
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/ocp-secrets-management/operator/pkg/apiauth"
+)
+
+const (
+	// PprofPath is the prefix of the pprof endpoints, such as /debug/pprof/heap
+	PprofPath = "/debug/pprof/"
+
+	// VarsPath serves the expvar variables, including runtime.MemStats
+	VarsPath = "/debug/vars"
+)
+
+// Server serves the diagnostics endpoints. Each request needs a bearer token that a TokenReview
+// accepts and whose user a SubjectAccessReview allows to get the request path as a non-resource
+// URL, as granted to cluster-admin.
+type Server struct {
+	// Client creates TokenReviews and SubjectAccessReviews
+	Client client.Client
+	Log    logr.Logger
+
+	// Addr is the address to listen on
+	Addr string
+
+	// CertDir holds tls.crt and tls.key, read on each handshake so rotated certificates are
+	// picked up without a restart
+	CertDir string
+}
+
+// NeedLeaderElection lets every replica serve its own profiles
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves until ctx is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: s.getCertificate,
+		},
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	s.Log.Info("Serving diagnostics endpoint", "addr", s.Addr)
+	if err := srv.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(filepath.Join(s.CertDir, "tls.crt"), filepath.Join(s.CertDir, "tls.key"))
+	if err != nil {
+		return nil, err
+	}
+	return &cert, nil
+}
+
+// Handler returns the HTTP handler for the diagnostics endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PprofPath, pprof.Index)
+	mux.HandleFunc(PprofPath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PprofPath+"profile", pprof.Profile)
+	mux.HandleFunc(PprofPath+"symbol", pprof.Symbol)
+	mux.HandleFunc(PprofPath+"trace", pprof.Trace)
+	mux.Handle(VarsPath, expvar.Handler())
+	return Authorized(s.Client, s.Log, mux)
+}
+
+// Authorized passes on requests whose bearer token a TokenReview accepts and whose user a
+// SubjectAccessReview allows to get the request path as a non-resource URL. c creates the reviews.
+func Authorized(c client.Client, log logr.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		user, err := apiauth.Authenticate(ctx, c, req)
+		if err != nil {
+			log.Error(err, "Failed to authenticate request", "path", req.URL.Path)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		if user == nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		status, err := apiauth.Authorize(ctx, c, user, nil, &authorizationv1.NonResourceAttributes{Path: req.URL.Path, Verb: "get"})
+		if err != nil {
+			log.Error(err, "Failed to authorize request", "path", req.URL.Path)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		if !status.Allowed {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,85 @@
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newTestServer returns a server whose TokenReviews accept "<user>-token" and whose
+// SubjectAccessReviews only allow the user "admin" to get /debug/ paths
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				switch review := obj.(type) {
+				case *authenticationv1.TokenReview:
+					if user, ok := strings.CutSuffix(review.Spec.Token, "-token"); ok {
+						review.Status.Authenticated = true
+						review.Status.User.Username = user
+					}
+					return nil
+				case *authorizationv1.SubjectAccessReview:
+					attrs := review.Spec.NonResourceAttributes
+					review.Status.Allowed = review.Spec.User == "admin" && attrs != nil && attrs.Verb == "get" && strings.HasPrefix(attrs.Path, "/debug/")
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	return &Server{Client: c, Log: logr.Discard()}
+}
+
+func doRequest(s *Server, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_RequiresAuthorizedToken(t *testing.T) {
+	s := newTestServer(t)
+
+	assert.Equal(t, http.StatusUnauthorized, doRequest(s, VarsPath, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, doRequest(s, VarsPath, "garbage").Code)
+	assert.Equal(t, http.StatusForbidden, doRequest(s, VarsPath, "developer-token").Code)
+	assert.Equal(t, http.StatusForbidden, doRequest(s, PprofPath+"heap", "developer-token").Code)
+}
+
+func TestServer_ServesProfilesAndVars(t *testing.T) {
+	s := newTestServer(t)
+
+	rec := doRequest(s, VarsPath, "admin-token")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"memstats"`)
+
+	rec = doRequest(s, PprofPath+"heap?debug=1", "admin-token")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "heap profile")
+
+	rec = doRequest(s, PprofPath, "admin-token")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "goroutine")
+}
@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// LevelConfigMapName names the ConfigMap in the operator namespace that changes the log level
+	// while the operator runs
+	LevelConfigMapName = "secrets-management-operator-logging"
+
+	// LevelKey is the ConfigMap key holding the level, in the syntax of --log-level
+	LevelKey = "logLevel"
+)
+
+// LevelWatcher sets the log level from the LevelConfigMapName ConfigMap, and back to the level
+// the operator started with when the ConfigMap or its key is removed. An invalid level is logged
+// and ignored.
+type LevelWatcher struct {
+	Client    kubernetes.Interface
+	Namespace string
+	Level     zap.AtomicLevel
+	Log       logr.Logger
+
+	initial zapcore.Level
+}
+
+// NewLevelWatcher returns a LevelWatcher for level, which is restored when the ConfigMap goes away
+func NewLevelWatcher(c kubernetes.Interface, namespace string, level zap.AtomicLevel, log logr.Logger) *LevelWatcher {
+	return &LevelWatcher{Client: c, Namespace: namespace, Level: level, Log: log, initial: level.Level()}
+}
+
+// NeedLeaderElection lets every replica follow the ConfigMap
+func (w *LevelWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start watches the ConfigMap until ctx is cancelled
+func (w *LevelWatcher) Start(ctx context.Context) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(w.Client, 0,
+		informers.WithNamespace(w.Namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", LevelConfigMapName).String()
+		}))
+	informer := factory.Core().V1().ConfigMaps().Informer()
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { w.apply(obj) },
+		UpdateFunc: func(_, obj interface{}) { w.apply(obj) },
+		DeleteFunc: func(interface{}) { w.apply(nil) },
+	}); err != nil {
+		return err
+	}
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	factory.Shutdown()
+	return nil
+}
+
+// apply sets the level from configMap, or restores the initial level when it is nil or has no level
+func (w *LevelWatcher) apply(obj interface{}) {
+	level := w.initial
+	if configMap, ok := obj.(*corev1.ConfigMap); ok {
+		if value, ok := configMap.Data[LevelKey]; ok {
+			parsed, err := ParseLevel(value)
+			if err != nil {
+				w.Log.Error(err, "Ignoring log level from ConfigMap", "configMap", LevelConfigMapName)
+				return
+			}
+			level = parsed
+		}
+	}
+	if level == w.Level.Level() {
+		return
+	}
+	w.Level.SetLevel(level)
+	w.Log.Info("Changed log level", "level", level.String())
+}
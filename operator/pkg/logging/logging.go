@@ -0,0 +1,152 @@
+// Package logging builds the operator's structured logger: the level, encoding and sampling are
+// set by flags or environment variables, and the level can be changed while the operator runs by
+// editing a ConfigMap.
+package logging
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	crzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const (
+	// LevelEnv sets the default of --log-level
+	LevelEnv = "LOG_LEVEL"
+
+	// FormatEnv sets the default of --log-format
+	FormatEnv = "LOG_FORMAT"
+
+	// SamplingInitialEnv sets the default of --log-sampling-initial
+	SamplingInitialEnv = "LOG_SAMPLING_INITIAL"
+
+	// SamplingThereafterEnv sets the default of --log-sampling-thereafter
+	SamplingThereafterEnv = "LOG_SAMPLING_THEREAFTER"
+
+	// FormatJSON writes one JSON object per line
+	FormatJSON = "json"
+
+	// FormatConsole writes human-readable lines
+	FormatConsole = "console"
+)
+
+// Options configures the logger
+type Options struct {
+	// Level is debug, info, warn or error, or a positive number to include logr V(n) messages
+	Level string
+
+	// Format is FormatJSON or FormatConsole
+	Format string
+
+	// SamplingInitial is how many messages with the same level and text are logged each second
+	// before sampling starts; sampling is off when zero
+	SamplingInitial int
+
+	// SamplingThereafter is how often a sampled message is logged: one in every SamplingThereafter
+	SamplingThereafter int
+
+	// Development adds caller details and stack traces from warnings, and panics on DPanic
+	Development bool
+}
+
+// DefaultOptions returns the production defaults, overridden by the environment variables
+func DefaultOptions() Options {
+	return Options{
+		Level:              envOr(LevelEnv, "info"),
+		Format:             envOr(FormatEnv, FormatJSON),
+		SamplingInitial:    envIntOr(SamplingInitialEnv, 100),
+		SamplingThereafter: envIntOr(SamplingThereafterEnv, 100),
+	}
+}
+
+// BindFlags registers the logging flags on fs, defaulting to o
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Level, "log-level", o.Level,
+		fmt.Sprintf("Log level: debug, info, warn, error, or a number to include more verbose messages. Defaults to $%s.", LevelEnv))
+	fs.StringVar(&o.Format, "log-format", o.Format,
+		fmt.Sprintf("Log encoding: %s or %s. Defaults to $%s.", FormatJSON, FormatConsole, FormatEnv))
+	fs.IntVar(&o.SamplingInitial, "log-sampling-initial", o.SamplingInitial,
+		fmt.Sprintf("Identical messages logged each second before sampling starts; 0 disables sampling. Defaults to $%s.", SamplingInitialEnv))
+	fs.IntVar(&o.SamplingThereafter, "log-sampling-thereafter", o.SamplingThereafter,
+		fmt.Sprintf("Once sampling starts, log one in this many identical messages. Defaults to $%s.", SamplingThereafterEnv))
+}
+
+// ParseLevel parses a level as accepted by --log-level
+func ParseLevel(value string) (zapcore.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info", "":
+		return zapcore.InfoLevel, nil
+	case "warn", "warning":
+		return zapcore.WarnLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	}
+	verbosity, err := strconv.Atoi(value)
+	if err != nil || verbosity < 0 || verbosity > 127 {
+		return 0, fmt.Errorf("invalid log level %q: want debug, info, warn, error or a verbosity from 0 to 127", value)
+	}
+	// logr V(n) is logged at zap level -n
+	return zapcore.Level(-verbosity), nil
+}
+
+// New returns a logger writing to w, and the level it logs at, which can be changed while it is
+// in use
+func New(w io.Writer, o Options) (logr.Logger, zap.AtomicLevel, error) {
+	level, err := ParseLevel(o.Level)
+	if err != nil {
+		return logr.Logger{}, zap.AtomicLevel{}, err
+	}
+	atomicLevel := zap.NewAtomicLevelAt(level)
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	if o.Development {
+		encoderConfig = zap.NewDevelopmentEncoderConfig()
+	}
+	encoderConfig.EncodeTime = zapcore.RFC3339TimeEncoder
+	var encoder zapcore.Encoder
+	switch o.Format {
+	case FormatJSON:
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	case FormatConsole:
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	default:
+		return logr.Logger{}, zap.AtomicLevel{}, fmt.Errorf("invalid log format %q: want %s or %s", o.Format, FormatJSON, FormatConsole)
+	}
+
+	sink := zapcore.AddSync(w)
+	core := zapcore.NewCore(&crzap.KubeAwareEncoder{Encoder: encoder, Verbose: o.Development}, sink, atomicLevel)
+	if o.SamplingInitial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, o.SamplingInitial, o.SamplingThereafter)
+	}
+
+	zapOpts := []zap.Option{zap.ErrorOutput(sink), zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)}
+	if o.Development {
+		zapOpts = append(zapOpts, zap.Development(), zap.AddStacktrace(zapcore.WarnLevel))
+	}
+	return zapr.NewLogger(zap.New(core, zapOpts...)), atomicLevel, nil
+}
+
+func envOr(name, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func envIntOr(name string, fallback int) int {
+	if n, err := strconv.Atoi(os.Getenv(name)); err == nil {
+		return n
+	}
+	return fallback
+}
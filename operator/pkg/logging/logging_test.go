@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseLevel(t *testing.T) {
+	for value, want := range map[string]zapcore.Level{
+		"debug": zapcore.DebugLevel,
+		"INFO":  zapcore.InfoLevel,
+		"warn":  zapcore.WarnLevel,
+		"error": zapcore.ErrorLevel,
+		"3":     zapcore.Level(-3),
+	} {
+		level, err := ParseLevel(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, level, value)
+	}
+	for _, value := range []string{"loud", "-1", "200"} {
+		_, err := ParseLevel(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestDefaultOptions_FromEnvironment(t *testing.T) {
+	t.Setenv(LevelEnv, "debug")
+	t.Setenv(FormatEnv, FormatConsole)
+	t.Setenv(SamplingInitialEnv, "0")
+
+	opts := DefaultOptions()
+	assert.Equal(t, "debug", opts.Level)
+	assert.Equal(t, FormatConsole, opts.Format)
+	assert.Equal(t, 0, opts.SamplingInitial)
+	assert.Equal(t, 100, opts.SamplingThereafter)
+}
+
+func TestNew(t *testing.T) {
+	var out bytes.Buffer
+	logger, level, err := New(&out, Options{Level: "info", Format: FormatJSON, SamplingInitial: 2, SamplingThereafter: 100})
+	require.NoError(t, err)
+
+	logger.V(1).Info("hidden")
+	for i := 0; i < 5; i++ {
+		logger.Info("repeated", "i", i)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2, "debug messages are dropped and repeats sampled")
+	entry := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "repeated", entry["msg"])
+
+	// Raising the level takes effect at once
+	out.Reset()
+	level.SetLevel(zapcore.DebugLevel)
+	logger.V(1).Info("shown")
+	assert.Contains(t, out.String(), "shown")
+
+	_, _, err = New(&out, Options{Level: "info", Format: "xml"})
+	assert.Error(t, err)
+}
+
+func TestLevelWatcher(t *testing.T) {
+	_, level, err := New(&bytes.Buffer{}, Options{Level: "info", Format: FormatJSON})
+	require.NoError(t, err)
+	client := fake.NewSimpleClientset()
+	w := NewLevelWatcher(client, "operators", level, logr.Discard())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = w.Start(ctx) }()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: LevelConfigMapName, Namespace: "operators"},
+		Data:       map[string]string{LevelKey: "2"},
+	}
+	_, err = client.CoreV1().ConfigMaps("operators").Create(ctx, configMap, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return level.Level() == zapcore.Level(-2) }, 5*time.Second, 10*time.Millisecond)
+
+	// An invalid level is ignored
+	configMap.Data[LevelKey] = "loud"
+	_, err = client.CoreV1().ConfigMaps("operators").Update(ctx, configMap, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, zapcore.Level(-2), level.Level())
+
+	// Removing the ConfigMap restores the starting level
+	require.NoError(t, client.CoreV1().ConfigMaps("operators").Delete(ctx, LevelConfigMapName, metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool { return level.Level() == zapcore.InfoLevel }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,197 @@
+// Package objectstorage uploads and downloads objects of S3 compatible buckets, signing the
+// requests with AWS Signature Version 4. Objects are addressed path-style, which every S3
+// compatible store supports.
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRegion is the region requests are signed for when none is set
+	DefaultRegion = "us-east-1"
+
+	// maxObjectSize bounds how much of a downloaded object is read
+	maxObjectSize = 16 << 20
+
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	signedHeaders    = "host;x-amz-content-sha256;x-amz-date"
+	amzDateFormat    = "20060102T150405Z"
+)
+
+// Client reads and writes the objects of one bucket
+type Client struct {
+	// Endpoint is the URL of the S3 API, such as https://s3.us-east-1.amazonaws.com
+	Endpoint string
+
+	// Bucket holds the objects
+	Bucket string
+
+	// Region the requests are signed for, DefaultRegion when empty
+	Region string
+
+	// AccessKeyID and SecretAccessKey sign the requests
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// HTTPClient sends the requests, http.DefaultClient when nil
+	HTTPClient *http.Client
+
+	now func() time.Time
+}
+
+// Put uploads body as the object named key, replacing any object of that name
+func (c *Client) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	req, err := c.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return responseError(http.MethodPut, key, resp)
+	}
+	return nil
+}
+
+// Get downloads the object named key
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return nil, responseError(http.MethodGet, key, resp)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxObjectSize))
+}
+
+// newRequest returns a signed request for the object named key
+func (c *Client) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(c.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", c.Endpoint, err)
+	}
+	if c.Bucket == "" || key == "" {
+		return nil, fmt.Errorf("bucket and object name are required")
+	}
+
+	path := endpoint.Path + "/" + c.Bucket + "/" + key
+	endpoint.Path = path
+	endpoint.RawPath = encodePath(path)
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	c.sign(req, body)
+	return req, nil
+}
+
+// sign adds the Signature Version 4 headers to req
+func (c *Client) sign(req *http.Request, body []byte) {
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	region := c.Region
+	if region == "" {
+		region = DefaultRegion
+	}
+	SignV4(req, body, "s3", region, c.AccessKeyID, c.SecretAccessKey, now())
+}
+
+// SignV4 adds the Signature Version 4 headers for service to req, covering the host, the payload
+// and the time t. Other AWS APIs accept the same signature, with their own service name.
+func SignV4(req *http.Request, body []byte, service, region, accessKeyID, secretAccessKey string, t time.Time) {
+	t = t.UTC()
+	amzDate := t.Format(amzDateFormat)
+	day := t.Format("20060102")
+
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"",
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, accessKeyID, scope, signedHeaders, signature))
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// encodePath percent-encodes every byte of path but the unreserved characters and '/', as
+// Signature Version 4 requires of the canonical URI
+func encodePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		ch := path[i]
+		if ch == '/' || ch == '-' || ch == '_' || ch == '.' || ch == '~' ||
+			(ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') {
+			b.WriteByte(ch)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", ch)
+	}
+	return b.String()
+}
+
+func responseError(method, key string, resp *http.Response) error {
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%s %s: %s: %s", method, key, resp.Status, strings.TrimSpace(string(detail)))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,134 @@
+// Package resourcemetrics exports the state of individual secret management resources as
+// Prometheus gauges, in the style of kube-state-metrics: one series per Certificate,
+// ExternalSecret and SecretProviderClassPodStatus, labeled by namespace and name, for teams that
+// build Grafana dashboards on the operator's metrics.
+package resourcemetrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	certificateExpiryDesc = prometheus.NewDesc(
+		"certmanager_certificate_expiry_seconds",
+		"Seconds until the cert-manager Certificate's current certificate expires; negative once it has expired",
+		[]string{"namespace", "name"}, nil,
+	)
+	externalSecretSyncDesc = prometheus.NewDesc(
+		"externalsecret_sync_status",
+		"Ready condition of the ExternalSecret: 1 for its current status, 0 for the others",
+		[]string{"namespace", "name", "status"}, nil,
+	)
+	podStatusMountedDesc = prometheus.NewDesc(
+		"secretproviderclasspodstatus_mounted",
+		"Whether the Secrets Store CSI driver mounted the SecretProviderClass in the pod (1) or not (0)",
+		[]string{"namespace", "name", "pod", "secret_provider_class"}, nil,
+	)
+)
+
+// conditionStatuses are the values of the status label of externalsecret_sync_status
+var conditionStatuses = []string{"True", "False", "Unknown"}
+
+// Certificate is the state of a cert-manager Certificate
+type Certificate struct {
+	Namespace, Name string
+
+	// NotAfter is when the current certificate expires; zero before one is issued
+	NotAfter time.Time
+}
+
+// ExternalSecret is the state of an External Secrets Operator ExternalSecret
+type ExternalSecret struct {
+	Namespace, Name string
+
+	// Ready is the status of the Ready condition, Unknown when the condition is missing
+	Ready string
+}
+
+// PodStatus is the state of a Secrets Store CSI SecretProviderClassPodStatus
+type PodStatus struct {
+	Namespace, Name          string
+	Pod, SecretProviderClass string
+	Mounted                  bool
+}
+
+// Snapshot is the state of the resources at one time
+type Snapshot struct {
+	Certificates    []Certificate
+	ExternalSecrets []ExternalSecret
+	PodStatuses     []PodStatus
+}
+
+// Exporter is a prometheus.Collector exporting the last reported Snapshot. Certificate expiry is
+// computed when scraped, so it stays current between reports. It exports nothing until a snapshot
+// is reported, and again once it is cleared, so disabling the exporter removes the series.
+type Exporter struct {
+	mu       sync.Mutex
+	snapshot *Snapshot
+
+	// now returns the current time; time.Now when nil
+	now func() time.Time
+}
+
+// NewExporter returns an Exporter with no snapshot reported
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// Report replaces the exported snapshot; nil stops exporting it
+func (e *Exporter) Report(snapshot *Snapshot) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.snapshot = snapshot
+}
+
+// Snapshot returns the exported snapshot, or nil when none is
+func (e *Exporter) Snapshot() *Snapshot {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.snapshot
+}
+
+// Describe implements prometheus.Collector
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- certificateExpiryDesc
+	ch <- externalSecretSyncDesc
+	ch <- podStatusMountedDesc
+}
+
+// Collect implements prometheus.Collector
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	snapshot := e.Snapshot()
+	if snapshot == nil {
+		return
+	}
+
+	now := time.Now()
+	if e.now != nil {
+		now = e.now()
+	}
+	for _, cert := range snapshot.Certificates {
+		if cert.NotAfter.IsZero() {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(certificateExpiryDesc, prometheus.GaugeValue, cert.NotAfter.Sub(now).Seconds(), cert.Namespace, cert.Name)
+	}
+	for _, es := range snapshot.ExternalSecrets {
+		for _, status := range conditionStatuses {
+			ch <- prometheus.MustNewConstMetric(externalSecretSyncDesc, prometheus.GaugeValue, boolValue(es.Ready == status), es.Namespace, es.Name, status)
+		}
+	}
+	for _, ps := range snapshot.PodStatuses {
+		ch <- prometheus.MustNewConstMetric(podStatusMountedDesc, prometheus.GaugeValue, boolValue(ps.Mounted), ps.Namespace, ps.Name, ps.Pod, ps.SecretProviderClass)
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package resourcemetrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gather returns the value of each exported series, keyed by metric name and label values
+func gather(t *testing.T, exporter *Exporter) map[string]float64 {
+	t.Helper()
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(exporter))
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	values := map[string]float64{}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			labels := make([]string, 0, len(m.GetLabel()))
+			for _, label := range m.GetLabel() {
+				labels = append(labels, label.GetName()+"="+label.GetValue())
+			}
+			values[family.GetName()+"{"+strings.Join(labels, ",")+"}"] = m.GetGauge().GetValue()
+		}
+	}
+	return values
+}
+
+func TestExporter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	exporter := NewExporter()
+	exporter.now = func() time.Time { return now }
+	assert.Empty(t, gather(t, exporter), "nothing is exported before a snapshot is reported")
+
+	exporter.Report(&Snapshot{
+		Certificates: []Certificate{
+			{Namespace: "team-a", Name: "web", NotAfter: now.Add(time.Hour)},
+			{Namespace: "team-a", Name: "pending"},
+		},
+		ExternalSecrets: []ExternalSecret{{Namespace: "team-a", Name: "db", Ready: "False"}},
+		PodStatuses:     []PodStatus{{Namespace: "team-a", Name: "web-0-team-a-vault", Pod: "web-0", SecretProviderClass: "vault", Mounted: true}},
+	})
+	assert.Equal(t, map[string]float64{
+		"certmanager_certificate_expiry_seconds{name=web,namespace=team-a}":                                                    3600,
+		"externalsecret_sync_status{name=db,namespace=team-a,status=True}":                                                     0,
+		"externalsecret_sync_status{name=db,namespace=team-a,status=False}":                                                    1,
+		"externalsecret_sync_status{name=db,namespace=team-a,status=Unknown}":                                                  0,
+		"secretproviderclasspodstatus_mounted{name=web-0-team-a-vault,namespace=team-a,pod=web-0,secret_provider_class=vault}": 1,
+	}, gather(t, exporter))
+
+	// Expiry is computed when scraped
+	now = now.Add(2 * time.Hour)
+	assert.Equal(t, float64(-3600), gather(t, exporter)["certmanager_certificate_expiry_seconds{name=web,namespace=team-a}"])
+
+	exporter.Report(nil)
+	assert.Empty(t, gather(t, exporter), "disabling the exporter removes the series")
+}
@@ -0,0 +1,111 @@
+// Package telemetry exports anonymized usage of the operator as Prometheus gauges: which secret
+// management operators are installed, how many of their resources exist and which plugin features
+// are enabled. No names, namespaces or other identifying values are exported. Cluster monitoring
+// scrapes the gauges and the telemetry client forwards the series its allowlist covers.
+package telemetry
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	operatorInstalledDesc = prometheus.NewDesc(
+		"secrets_management_usage_operator_installed",
+		"Whether a secret management operator is installed (1) or not (0), by operator",
+		[]string{"operator"}, nil,
+	)
+	resourcesDesc = prometheus.NewDesc(
+		"secrets_management_usage_resources",
+		"Number of resources of the detected operators on the cluster, by kind",
+		[]string{"kind"}, nil,
+	)
+	featureEnabledDesc = prometheus.NewDesc(
+		"secrets_management_usage_feature_enabled",
+		"Whether a console plugin feature is enabled (1) or not (0), by feature",
+		[]string{"feature"}, nil,
+	)
+)
+
+// Usage is a snapshot of how the operator is used
+type Usage struct {
+	// Operators reports whether each detected operator is installed, such as "cert-manager"
+	Operators map[string]bool
+
+	// Resources counts resources by kind, such as "Certificate"
+	Resources map[string]int
+
+	// Features reports whether each plugin feature is enabled, such as "delete"
+	Features map[string]bool
+}
+
+// Reporter is a prometheus.Collector exporting the last reported Usage. It exports nothing until
+// usage is reported, and again once it is cleared, so opting out removes the series.
+type Reporter struct {
+	mu    sync.Mutex
+	usage *Usage
+}
+
+// NewReporter returns a Reporter with no usage reported
+func NewReporter() *Reporter {
+	return &Reporter{}
+}
+
+// Report replaces the exported usage; nil stops exporting it
+func (r *Reporter) Report(usage *Usage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage = usage
+}
+
+// Usage returns the exported usage, or nil when none is
+func (r *Reporter) Usage() *Usage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.usage
+}
+
+// Describe implements prometheus.Collector
+func (r *Reporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- operatorInstalledDesc
+	ch <- resourcesDesc
+	ch <- featureEnabledDesc
+}
+
+// Collect implements prometheus.Collector
+func (r *Reporter) Collect(ch chan<- prometheus.Metric) {
+	r.mu.Lock()
+	usage := r.usage
+	r.mu.Unlock()
+	if usage == nil {
+		return
+	}
+
+	for _, name := range sortedKeys(usage.Operators) {
+		ch <- prometheus.MustNewConstMetric(operatorInstalledDesc, prometheus.GaugeValue, boolValue(usage.Operators[name]), name)
+	}
+	for _, kind := range sortedKeys(usage.Resources) {
+		ch <- prometheus.MustNewConstMetric(resourcesDesc, prometheus.GaugeValue, float64(usage.Resources[kind]), kind)
+	}
+	for _, feature := range sortedKeys(usage.Features) {
+		ch <- prometheus.MustNewConstMetric(featureEnabledDesc, prometheus.GaugeValue, boolValue(usage.Features[feature]), feature)
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gather returns the value of each exported series, keyed by metric name and label value
+func gather(t *testing.T, reporter *Reporter) map[string]float64 {
+	t.Helper()
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(reporter))
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	values := map[string]float64{}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			values[family.GetName()+"/"+m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+		}
+	}
+	return values
+}
+
+func TestReporter(t *testing.T) {
+	reporter := NewReporter()
+	assert.Empty(t, gather(t, reporter), "nothing is exported before usage is reported")
+
+	reporter.Report(&Usage{
+		Operators: map[string]bool{"cert-manager": true, "external-secrets": false},
+		Resources: map[string]int{"Certificate": 12},
+		Features:  map[string]bool{"delete": true},
+	})
+	assert.Equal(t, map[string]float64{
+		"secrets_management_usage_operator_installed/cert-manager":     1,
+		"secrets_management_usage_operator_installed/external-secrets": 0,
+		"secrets_management_usage_resources/Certificate":               12,
+		"secrets_management_usage_feature_enabled/delete":              1,
+	}, gather(t, reporter))
+
+	reporter.Report(nil)
+	assert.Empty(t, gather(t, reporter), "opting out removes the series")
+}
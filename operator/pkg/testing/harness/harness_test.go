@@ -0,0 +1,101 @@
+package harness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+	"github.com/openshift/ocp-secrets-management/operator/pkg/controller"
+	smtesting "github.com/openshift/ocp-secrets-management/operator/pkg/testing"
+)
+
+func TestNewReconciler(t *testing.T) {
+	r := NewReconciler(smtesting.NewConfig(controller.SingletonConfigName))
+	Reconcile(t, r, controller.SingletonConfigName)
+
+	ctx := context.Background()
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: controller.PluginName + "-plugin", Namespace: controller.PluginNamespace}, deployment))
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+
+	config := &smv1alpha1.SecretsManagementConfig{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: controller.SingletonConfigName}, config))
+	assert.NotNil(t, smtesting.FindCondition(config, smv1alpha1.ConditionRBACConfigured))
+}
+
+func TestStart(t *testing.T) {
+	env := Start(t, Options{})
+
+	ctx := context.Background()
+	require.NoError(t, env.Client.Create(ctx, smtesting.NewConfig(controller.SingletonConfigName)))
+
+	config := &smv1alpha1.SecretsManagementConfig{}
+	assert.Eventually(t, func() bool {
+		err := env.Client.Get(ctx, types.NamespacedName{Name: controller.SingletonConfigName}, config)
+		return err == nil && controllerutil.ContainsFinalizer(config, controller.FinalizerName)
+	}, 30*time.Second, 250*time.Millisecond, "the reconciler does not add its finalizer")
+}
+
+func TestCRDValidation(t *testing.T) {
+	env := Start(t, Options{Setup: func(manager.Manager) error { return nil }})
+
+	maxSurge := intstr.FromInt32(1)
+	for name, tc := range map[string]struct {
+		mutate func(config *smv1alpha1.SecretsManagementConfig)
+		reject string
+	}{
+		"valid": {mutate: func(*smv1alpha1.SecretsManagementConfig) {}},
+		"rolePrefix is not a DNS-1123 label": {
+			mutate: func(config *smv1alpha1.SecretsManagementConfig) { config.Spec.RBAC.RolePrefix = "Secrets_Management" },
+			reject: "rolePrefix must be a lowercase RFC 1123 label",
+		},
+		"too many replicas": {
+			mutate: func(config *smv1alpha1.SecretsManagementConfig) { config.Spec.Plugin.Replicas = 11 },
+			reject: "spec.plugin.replicas",
+		},
+		"invalid quantity": {
+			mutate: func(config *smv1alpha1.SecretsManagementConfig) { config.Spec.Plugin.Resources.Limits.Memory = "128MB" },
+			reject: "spec.plugin.resources.limits.memory",
+		},
+		"metrics port is the plugin port": {
+			mutate: func(config *smv1alpha1.SecretsManagementConfig) { config.Spec.Plugin.MetricsPort = 9443 },
+			reject: "metricsPort must differ from port",
+		},
+		"rolling update settings with Recreate": {
+			mutate: func(config *smv1alpha1.SecretsManagementConfig) {
+				config.Spec.Plugin.Strategy = smv1alpha1.DeploymentStrategyConfig{Type: "Recreate", MaxSurge: &maxSurge}
+			},
+			reject: "maxSurge and maxUnavailable are only valid for RollingUpdate",
+		},
+		"fallback delay without the fallback": {
+			mutate: func(config *smv1alpha1.SecretsManagementConfig) {
+				config.Spec.Plugin.ServingCert.FallbackAfter = &metav1.Duration{Duration: time.Minute}
+			},
+			reject: "fallbackAfter requires selfSignedFallback",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := smtesting.NewConfig(controller.SingletonConfigName)
+			tc.mutate(config)
+			err := env.Client.Create(context.Background(), config)
+			if tc.reject == "" {
+				require.NoError(t, err)
+				require.NoError(t, env.Client.Delete(context.Background(), config))
+				return
+			}
+			require.True(t, apierrors.IsInvalid(err), "expected the config to be rejected, got %v", err)
+			assert.Contains(t, err.Error(), tc.reject)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Package testing provides builders for unit tests of code that works with the operator's API:
+// a scheme, a SecretsManagementConfig with the defaults the operator's own tests use, and a fake
+// client. Package harness builds reconcilers and an envtest environment on top of it.
+package testing
+
+import (
+	"context"
+	"sync"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	smv1alpha1 "github.com/openshift/ocp-secrets-management/operator/pkg/apis/secretsmanagement/v1alpha1"
+)
+
+// NewScheme returns a scheme with the Kubernetes types, the operator's API and CRDs registered
+func NewScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = smv1alpha1.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
+	return scheme
+}
+
+// NewClient returns a fake client holding objs, with the status subresource of
+// SecretsManagementConfig enabled as on a cluster. The fake client registers the list kinds of
+// unstructured lists in its scheme while listing, which races with the scheme lookups of calls
+// made at the same time, so List excludes the other calls.
+func NewClient(objs ...client.Object) client.WithWatch {
+	c := fake.NewClientBuilder().
+		WithScheme(NewScheme()).
+		WithObjects(objs...).
+		WithStatusSubresource(&smv1alpha1.SecretsManagementConfig{}).
+		Build()
+	var mu sync.RWMutex
+	return interceptor.NewClient(c, interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			mu.Lock()
+			defer mu.Unlock()
+			return c.List(ctx, list, opts...)
+		},
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			mu.RLock()
+			defer mu.RUnlock()
+			return c.Get(ctx, key, obj, opts...)
+		},
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			mu.RLock()
+			defer mu.RUnlock()
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			mu.RLock()
+			defer mu.RUnlock()
+			return c.Update(ctx, obj, opts...)
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			mu.RLock()
+			defer mu.RUnlock()
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			mu.RLock()
+			defer mu.RUnlock()
+			return c.Delete(ctx, obj, opts...)
+		},
+		DeleteAllOf: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteAllOfOption) error {
+			mu.RLock()
+			defer mu.RUnlock()
+			return c.DeleteAllOf(ctx, obj, opts...)
+		},
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			mu.RLock()
+			defer mu.RUnlock()
+			return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+		},
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			mu.RLock()
+			defer mu.RUnlock()
+			return c.SubResource(subResource).Update(ctx, obj, opts...)
+		},
+	})
+}
+
+// NewConfig returns a SecretsManagementConfig named name with default roles, two plugin replicas
+// and every supported operator enabled
+func NewConfig(name string) *smv1alpha1.SecretsManagementConfig {
+	return &smv1alpha1.SecretsManagementConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: smv1alpha1.SecretsManagementConfigSpec{
+			RBAC: smv1alpha1.RBACConfig{
+				CreateDefaultRoles: true,
+				RolePrefix:         "secrets-management",
+			},
+			Plugin: smv1alpha1.PluginConfig{
+				Image:    "openshift.io/ocp-secrets-management:test",
+				Replicas: 2,
+			},
+			Operators: smv1alpha1.OperatorsConfig{
+				CertManager:     smv1alpha1.OperatorConfig{Enabled: true},
+				ExternalSecrets: smv1alpha1.OperatorConfig{Enabled: true},
+				SecretsStoreCSI: smv1alpha1.OperatorConfig{Enabled: true},
+			},
+		},
+	}
+}
+
+// FindCondition returns the condition of config with type condType, or nil
+func FindCondition(config *smv1alpha1.SecretsManagementConfig, condType smv1alpha1.ConditionType) *smv1alpha1.Condition {
+	for i := range config.Status.Conditions {
+		if config.Status.Conditions[i].Type == condType {
+			return &config.Status.Conditions[i]
+		}
+	}
+	return nil
+}
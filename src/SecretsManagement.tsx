@@ -32,6 +32,7 @@ import { SecretProviderClassTable } from './components/SecretProviderClassTable'
 import { OperatorNotInstalled, NoOperatorsInstalled } from './components/OperatorNotInstalled';
 import { useK8sWatchResource } from '@openshift-console/dynamic-plugin-sdk';
 import { useOperatorDetection, type OperatorStatus } from './hooks/useOperatorDetection';
+import { useRuntimeConfig } from './hooks/useRuntimeConfig';
 
 /** Badge for operator status: shows "Check failed" with tooltip on error, "Not Installed" when not installed, or nothing when installed. */
 const OperatorStatusBadge: React.FC<{ status: OperatorStatus }> = ({ status }) => {
@@ -72,6 +73,16 @@ type ResourceKind =
   | 'all';
 type ProjectType = 'all' | string;
 
+// Operator owning each resource kind
+const RESOURCE_KIND_OPERATORS: Record<string, OperatorType> = {
+  certificates: 'cert-manager',
+  issuers: 'cert-manager',
+  externalsecrets: 'external-secrets',
+  secretstores: 'external-secrets',
+  pushsecrets: 'external-secrets',
+  secretproviderclasses: 'secrets-store-csi',
+};
+
 // Project/Namespace resource model
 const ProjectModel = {
   group: '',
@@ -122,9 +133,26 @@ export default function SecretsManagement() {
     refresh: checkOperators,
   } = useOperatorDetection();
 
+  // Operators disabled in the SecretsManagementConfig are left out entirely
+  const { config: runtimeConfig } = useRuntimeConfig();
+  const isOperatorHidden = (operator: OperatorType): boolean => {
+    switch (operator) {
+      case 'cert-manager':
+        return runtimeConfig.hideCertManager;
+      case 'external-secrets':
+        return runtimeConfig.hideESO;
+      case 'secrets-store-csi':
+        return runtimeConfig.hideCSI;
+      default:
+        return false;
+    }
+  };
+
   // Check if any operators are installed
   const anyOperatorInstalled =
-    certManager.installed || externalSecrets.installed || secretsStoreCSI.installed;
+    (certManager.installed && !runtimeConfig.hideCertManager) ||
+    (externalSecrets.installed && !runtimeConfig.hideESO) ||
+    (secretsStoreCSI.installed && !runtimeConfig.hideCSI);
 
   // Fetch all namespaces/projects dynamically
   const [projects, projectsLoaded, projectsError] = useK8sWatchResource<Project[]>({
@@ -153,7 +181,7 @@ export default function SecretsManagement() {
       label: 'Secrets Store CSI Driver',
       description: t('Secret provider integration'),
     },
-  ];
+  ].filter((option) => !isOperatorHidden(option.value as OperatorType));
 
   // Generate dynamic project options from fetched namespaces
   const getProjectOptions = React.useMemo(() => {
@@ -250,7 +278,10 @@ export default function SecretsManagement() {
           label: t('Secret Provider Classes'),
           description: t('CSI secret provider configurations'),
         },
-      ];
+      ].filter(
+        (option) =>
+          option.value === 'all' || !isOperatorHidden(RESOURCE_KIND_OPERATORS[option.value]),
+      );
     } else if (operator === 'cert-manager') {
       return [
         ...baseOptions,
@@ -312,6 +343,7 @@ export default function SecretsManagement() {
   };
 
   const shouldShowComponent = (operator: OperatorType, resourceKind: ResourceKind) => {
+    if (isOperatorHidden(operator)) return false;
     if (filters.operator !== 'all' && filters.operator !== operator) return false;
     if (filters.resourceKind !== 'all' && filters.resourceKind !== resourceKind) return false;
     return true;
@@ -0,0 +1,19 @@
+/**
+ * Console feature flags set by the plugin
+ */
+
+import type { SetFeatureFlag } from '@openshift-console/dynamic-plugin-sdk';
+import { fetchRuntimeConfig } from './hooks/useRuntimeConfig';
+
+// Set while at least one operator is enabled in the SecretsManagementConfig; the navigation
+// entry requires it, so disabling every operator removes the plugin from the console menu
+export const FLAG_SECRETS_MANAGEMENT_OPERATORS = 'SECRETS_MANAGEMENT_OPERATORS';
+
+export const handler = (setFeatureFlag: SetFeatureFlag) => {
+  fetchRuntimeConfig().then((config) => {
+    setFeatureFlag(
+      FLAG_SECRETS_MANAGEMENT_OPERATORS,
+      !(config.hideCertManager && config.hideESO && config.hideCSI),
+    );
+  });
+};
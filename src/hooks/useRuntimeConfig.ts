@@ -0,0 +1,58 @@
+/**
+ * Hook to read the runtime config the operator mounts into the plugin pods
+ */
+
+import * as React from 'react';
+
+export interface RuntimeConfig {
+  readOnly: boolean;
+  // Operators disabled in the SecretsManagementConfig's spec.operators
+  hideCertManager: boolean;
+  hideESO: boolean;
+  hideCSI: boolean;
+}
+
+// Served by the plugin's nginx below its base path, which the console proxies
+const RUNTIME_CONFIG_URL = '/api/plugins/ocp-secrets-management/runtime/config.json';
+
+const DEFAULT_RUNTIME_CONFIG: RuntimeConfig = {
+  readOnly: false,
+  hideCertManager: false,
+  hideESO: false,
+  hideCSI: false,
+};
+
+/**
+ * Fetches the runtime config. Without one, e.g. when the plugin is installed without the
+ * operator, everything is shown.
+ */
+export async function fetchRuntimeConfig(): Promise<RuntimeConfig> {
+  try {
+    const response = await fetch(RUNTIME_CONFIG_URL, { cache: 'no-cache' });
+    if (!response.ok) return DEFAULT_RUNTIME_CONFIG;
+    const data = await response.json();
+    return { ...DEFAULT_RUNTIME_CONFIG, ...data };
+  } catch {
+    return DEFAULT_RUNTIME_CONFIG;
+  }
+}
+
+export const useRuntimeConfig = (): { config: RuntimeConfig; loaded: boolean } => {
+  const [config, setConfig] = React.useState<RuntimeConfig>(DEFAULT_RUNTIME_CONFIG);
+  const [loaded, setLoaded] = React.useState(false);
+
+  React.useEffect(() => {
+    let cancelled = false;
+    fetchRuntimeConfig().then((runtimeConfig) => {
+      if (!cancelled) {
+        setConfig(runtimeConfig);
+        setLoaded(true);
+      }
+    });
+    return () => {
+      cancelled = true;
+    };
+  }, []);
+
+  return { config, loaded };
+};